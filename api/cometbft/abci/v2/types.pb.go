@@ -1646,7 +1646,6 @@ type Response struct {
 	// Sum of all possible messages.
 	//
 	// Types that are valid to be assigned to Value:
-	//
 	//	*Response_Exception
 	//	*Response_Echo
 	//	*Response_Flush
@@ -2329,6 +2328,19 @@ type CheckTxResponse struct {
 	Events    []Event `protobuf:"bytes,7,rep,name=events,proto3" json:"events,omitempty"`
 	Codespace string  `protobuf:"bytes,8,opt,name=codespace,proto3" json:"codespace,omitempty"`
 	LaneId    string  `protobuf:"bytes,12,opt,name=lane_id,json=laneId,proto3" json:"lane_id,omitempty"`
+	// Priority of the transaction, used by the "priority" mempool to order
+	// transactions for inclusion in a block and to decide which ones to evict
+	// when the mempool is full. Higher values take precedence. Ignored by the
+	// other mempool types, except by the "flood" mempool to decide whether a
+	// transaction can replace another one with the same sender and sequence.
+	Priority int64 `protobuf:"varint,13,opt,name=priority,proto3" json:"priority,omitempty"`
+	// Sender of the transaction (e.g., the account that signed it), as defined by
+	// the application. When set, the "flood" mempool keeps the transactions of
+	// each sender ordered by sequence.
+	Sender string `protobuf:"bytes,14,opt,name=sender,proto3" json:"sender,omitempty"`
+	// Sequence number (nonce) of the transaction among the transactions of the
+	// same sender. Only used if sender is set.
+	Sequence uint64 `protobuf:"varint,15,opt,name=sequence,proto3" json:"sequence,omitempty"`
 }
 
 func (m *CheckTxResponse) Reset()         { *m = CheckTxResponse{} }
@@ -2427,6 +2439,27 @@ func (m *CheckTxResponse) GetLaneId() string {
 	return ""
 }
 
+func (m *CheckTxResponse) GetPriority() int64 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
+func (m *CheckTxResponse) GetSender() string {
+	if m != nil {
+		return m.Sender
+	}
+	return ""
+}
+
+func (m *CheckTxResponse) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
 // CommitResponse indicates how much blocks should CometBFT retain.
 type CommitResponse struct {
 	RetainHeight int64 `protobuf:"varint,3,opt,name=retain_height,json=retainHeight,proto3" json:"retain_height,omitempty"`
@@ -3199,6 +3232,12 @@ type ExecTxResult struct {
 	GasUsed   int64   `protobuf:"varint,6,opt,name=gas_used,proto3" json:"gas_used,omitempty"`
 	Events    []Event `protobuf:"bytes,7,rep,name=events,proto3" json:"events,omitempty"`
 	Codespace string  `protobuf:"bytes,8,opt,name=codespace,proto3" json:"codespace,omitempty"`
+	// Sender and sequence number of the transaction, as reported in
+	// CheckTxResponse. When set, the "flood" mempool advances the next sequence
+	// expected for the sender, even if the transaction was not in its mempool.
+	// Not part of the results hash.
+	Sender   string `protobuf:"bytes,9,opt,name=sender,proto3" json:"sender,omitempty"`
+	Sequence uint64 `protobuf:"varint,10,opt,name=sequence,proto3" json:"sequence,omitempty"`
 }
 
 func (m *ExecTxResult) Reset()         { *m = ExecTxResult{} }
@@ -3290,6 +3329,20 @@ func (m *ExecTxResult) GetCodespace() string {
 	return ""
 }
 
+func (m *ExecTxResult) GetSender() string {
+	if m != nil {
+		return m.Sender
+	}
+	return ""
+}
+
+func (m *ExecTxResult) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
 // TxResult contains results of executing the transaction.
 //
 // One usage is indexing transaction results.
@@ -3840,218 +3893,219 @@ func init() {
 func init() { proto.RegisterFile("cometbft/abci/v2/types.proto", fileDescriptor_6f0a5b1025f81964) }
 
 var fileDescriptor_6f0a5b1025f81964 = []byte{
-	// 3365 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x5a, 0x4b, 0x6c, 0x1b, 0xd7,
-	0xb9, 0xf6, 0xf0, 0x25, 0xf2, 0xe7, 0x43, 0xa3, 0x23, 0xc9, 0xa6, 0x15, 0x47, 0x92, 0xc7, 0x71,
-	0xec, 0xd8, 0x89, 0x74, 0xad, 0xe4, 0xe6, 0x79, 0x93, 0x80, 0xa2, 0xa9, 0x48, 0xb2, 0x2c, 0x31,
-	0x43, 0x5a, 0x37, 0xf6, 0x7d, 0x4c, 0x46, 0xe4, 0xa1, 0x38, 0x31, 0x39, 0x33, 0x99, 0x39, 0x54,
-	0xa4, 0x7b, 0x57, 0x2d, 0x9a, 0xa2, 0xc8, 0x2a, 0x9b, 0x02, 0x45, 0x81, 0x02, 0x05, 0x8a, 0x6e,
-	0xbb, 0xe8, 0xbe, 0xbb, 0xa2, 0xc8, 0xaa, 0xc9, 0xb2, 0xab, 0xb4, 0x48, 0xd0, 0x4d, 0xf7, 0x05,
-	0x0a, 0x74, 0x53, 0x9c, 0xc7, 0xbc, 0xc8, 0x19, 0xc9, 0x76, 0xd2, 0x45, 0xd1, 0xee, 0x78, 0xce,
-	0xf9, 0xfe, 0x7f, 0xce, 0xf9, 0xcf, 0x39, 0xff, 0xe3, 0x3b, 0x84, 0x4b, 0x1d, 0x6b, 0x88, 0xc9,
-	0x41, 0x8f, 0xac, 0xea, 0x07, 0x1d, 0x63, 0xf5, 0x68, 0x6d, 0x95, 0x9c, 0xd8, 0xd8, 0x5d, 0xb1,
-	0x1d, 0x8b, 0x58, 0x48, 0xf6, 0x46, 0x57, 0xe8, 0xe8, 0xca, 0xd1, 0xda, 0xc2, 0xa2, 0x8f, 0xef,
-	0x38, 0x27, 0x36, 0xb1, 0x56, 0x8f, 0x6e, 0xad, 0xda, 0x8e, 0x65, 0xf5, 0xb8, 0x44, 0x68, 0x9c,
-	0xe9, 0xa1, 0x0a, 0x6d, 0xdd, 0xd1, 0x87, 0x42, 0xe3, 0xc2, 0xe5, 0xc9, 0xf1, 0x23, 0x7d, 0x60,
-	0x74, 0x75, 0x62, 0x39, 0x02, 0x32, 0x77, 0x68, 0x1d, 0x5a, 0xec, 0xe7, 0x2a, 0xfd, 0x25, 0x7a,
-	0x97, 0x0e, 0x2d, 0xeb, 0x70, 0x80, 0x57, 0x59, 0xeb, 0x60, 0xd4, 0x5b, 0x25, 0xc6, 0x10, 0xbb,
-	0x44, 0x1f, 0xda, 0xde, 0x97, 0xc7, 0x01, 0xdd, 0x91, 0xa3, 0x13, 0xc3, 0x32, 0xf9, 0xb8, 0xf2,
-	0x79, 0x01, 0xa6, 0x54, 0xfc, 0xe1, 0x08, 0xbb, 0x04, 0xbd, 0x08, 0x19, 0xdc, 0xe9, 0x5b, 0x55,
-	0x69, 0x59, 0xba, 0x5e, 0x5c, 0x7b, 0x7a, 0x65, 0x7c, 0x99, 0x2b, 0x8d, 0x4e, 0xdf, 0x12, 0xe0,
-	0xcd, 0x73, 0x2a, 0x03, 0xa3, 0x97, 0x21, 0xdb, 0x1b, 0x8c, 0xdc, 0x7e, 0x35, 0xc5, 0xa4, 0x16,
-	0x27, 0xa5, 0x36, 0xe8, 0x70, 0x20, 0xc6, 0xe1, 0xf4, 0x63, 0x86, 0xd9, 0xb3, 0xaa, 0xe9, 0xa4,
-	0x8f, 0x6d, 0x99, 0xbd, 0xf0, 0xc7, 0x28, 0x18, 0xd5, 0x01, 0x0c, 0xd3, 0x20, 0x5a, 0xa7, 0xaf,
-	0x1b, 0x66, 0x35, 0xcb, 0x44, 0x95, 0x38, 0x51, 0x83, 0xd4, 0x29, 0x24, 0x90, 0x2f, 0x18, 0x5e,
-	0x1f, 0x9d, 0xf1, 0x87, 0x23, 0xec, 0x9c, 0x54, 0x73, 0x49, 0x33, 0x7e, 0x97, 0x0e, 0x87, 0x66,
-	0xcc, 0xe0, 0xe8, 0x4d, 0xc8, 0x77, 0xfa, 0xb8, 0xf3, 0x50, 0x23, 0xc7, 0xd5, 0x3c, 0x13, 0x5d,
-	0x9e, 0x14, 0xad, 0x53, 0x44, 0xfb, 0x38, 0x10, 0x9e, 0xea, 0xf0, 0x1e, 0xf4, 0x1a, 0xe4, 0x3a,
-	0xd6, 0x70, 0x68, 0x90, 0x6a, 0x91, 0x09, 0x2f, 0xc5, 0x08, 0xb3, 0xf1, 0x40, 0x56, 0x08, 0xa0,
-	0x3d, 0xa8, 0x0c, 0x0c, 0x97, 0x68, 0xae, 0xa9, 0xdb, 0x6e, 0xdf, 0x22, 0x6e, 0xb5, 0xc4, 0x54,
-	0x3c, 0x3b, 0xa9, 0x62, 0xc7, 0x70, 0x49, 0xcb, 0x83, 0x05, 0x9a, 0xca, 0x83, 0x70, 0x3f, 0x55,
-	0x68, 0xf5, 0x7a, 0xd8, 0xf1, 0x35, 0x56, 0xcb, 0x49, 0x0a, 0xf7, 0x28, 0xce, 0x93, 0x0c, 0x29,
-	0xb4, 0xc2, 0xfd, 0xe8, 0xbf, 0x61, 0x76, 0x60, 0xe9, 0x5d, 0x5f, 0x9f, 0xd6, 0xe9, 0x8f, 0xcc,
-	0x87, 0xd5, 0x0a, 0xd3, 0x7a, 0x23, 0x66, 0x9a, 0x96, 0xde, 0xf5, 0x84, 0xeb, 0x14, 0x1a, 0x68,
-	0x9e, 0x19, 0x8c, 0x8f, 0x21, 0x0d, 0xe6, 0x74, 0xdb, 0x1e, 0x9c, 0x8c, 0xab, 0x9f, 0x66, 0xea,
-	0x6f, 0x4e, 0xaa, 0xaf, 0x51, 0x74, 0x82, 0x7e, 0xa4, 0x4f, 0x0c, 0xa2, 0x7b, 0x20, 0xdb, 0x0e,
-	0xb6, 0x75, 0x07, 0x6b, 0xb6, 0x63, 0xd9, 0x96, 0xab, 0x0f, 0xaa, 0x32, 0x53, 0x7e, 0x7d, 0x52,
-	0x79, 0x93, 0x23, 0x9b, 0x02, 0x18, 0x68, 0x9e, 0xb6, 0xa3, 0x23, 0x5c, 0xad, 0xd5, 0xc1, 0xae,
-	0x1b, 0xa8, 0x9d, 0x49, 0x56, 0xcb, 0x90, 0xb1, 0x6a, 0x23, 0x23, 0x68, 0x03, 0x8a, 0xf8, 0x98,
-	0x60, 0xb3, 0xab, 0x1d, 0x59, 0x04, 0x57, 0x11, 0xd3, 0x78, 0x25, 0xe6, 0xba, 0x32, 0xd0, 0xbe,
-	0x45, 0x70, 0xa0, 0x0c, 0xb0, 0xdf, 0x89, 0x0e, 0x60, 0xfe, 0x08, 0x3b, 0x46, 0xef, 0x84, 0xe9,
-	0xd1, 0xd8, 0x88, 0x6b, 0x58, 0x66, 0x75, 0x96, 0x69, 0x7c, 0x7e, 0x52, 0xe3, 0x3e, 0x83, 0x53,
-	0xe1, 0x86, 0x07, 0x0e, 0x54, 0xcf, 0x1e, 0x4d, 0x8e, 0xd2, 0x93, 0xd6, 0x33, 0x4c, 0x7d, 0x60,
-	0xfc, 0x1f, 0xd6, 0x0e, 0x06, 0x56, 0xe7, 0x61, 0x75, 0x2e, 0xe9, 0xa4, 0x6d, 0x08, 0xdc, 0x3a,
-	0x85, 0x85, 0x4e, 0x5a, 0x2f, 0xdc, 0xbf, 0x3e, 0x05, 0xd9, 0x23, 0x7d, 0x30, 0xc2, 0xdb, 0x99,
-	0x7c, 0x46, 0xce, 0x6e, 0x67, 0xf2, 0x53, 0x72, 0x7e, 0x3b, 0x93, 0x2f, 0xc8, 0xb0, 0x9d, 0xc9,
-	0x83, 0x5c, 0x54, 0xae, 0x41, 0x31, 0xe4, 0xa7, 0x50, 0x15, 0xa6, 0x86, 0xd8, 0x75, 0xf5, 0x43,
-	0xcc, 0xfc, 0x5a, 0x41, 0xf5, 0x9a, 0x4a, 0x05, 0x4a, 0x61, 0xd7, 0xa4, 0x7c, 0x2a, 0x41, 0x31,
-	0xe4, 0x74, 0xa8, 0xe4, 0x11, 0x76, 0x98, 0x41, 0x84, 0xa4, 0x68, 0xa2, 0x2b, 0x50, 0x66, 0x6b,
-	0xd1, 0xbc, 0x71, 0xea, 0xfb, 0x32, 0x6a, 0x89, 0x75, 0xee, 0x0b, 0xd0, 0x12, 0x14, 0xed, 0x35,
-	0xdb, 0x87, 0xa4, 0x19, 0x04, 0xec, 0x35, 0xdb, 0x03, 0x5c, 0x86, 0x12, 0x5d, 0xba, 0x8f, 0xc8,
-	0xb0, 0x8f, 0x14, 0x69, 0x9f, 0x80, 0x28, 0xbf, 0x4d, 0x81, 0x3c, 0xee, 0xcc, 0xd0, 0xab, 0x90,
-	0xa1, 0x5e, 0x5e, 0xb8, 0xe9, 0x85, 0x15, 0xee, 0xe1, 0x57, 0x3c, 0x0f, 0xbf, 0xd2, 0xf6, 0x42,
-	0xc0, 0x7a, 0xfe, 0xb3, 0x2f, 0x97, 0xce, 0x7d, 0xfa, 0xfb, 0x25, 0x49, 0x65, 0x12, 0xe8, 0x22,
-	0xf5, 0x60, 0xba, 0x61, 0x6a, 0x46, 0x97, 0x4d, 0xb9, 0x40, 0xbd, 0x93, 0x6e, 0x98, 0x5b, 0x5d,
-	0x74, 0x17, 0xe4, 0x8e, 0x65, 0xba, 0xd8, 0x74, 0x47, 0xae, 0xc6, 0x63, 0x93, 0x70, 0xcd, 0x21,
-	0xff, 0xca, 0x83, 0x20, 0x73, 0x54, 0x02, 0xda, 0x64, 0x48, 0x75, 0xba, 0x13, 0xed, 0x40, 0xef,
-	0x00, 0xf8, 0x01, 0xcc, 0xad, 0x66, 0x96, 0xd3, 0xd7, 0x8b, 0x6b, 0x97, 0x63, 0xce, 0x93, 0x87,
-	0xb9, 0x67, 0x77, 0x75, 0x82, 0xd7, 0x33, 0x74, 0xc2, 0x6a, 0x48, 0x14, 0x3d, 0x0b, 0xd3, 0xba,
-	0x6d, 0x6b, 0x2e, 0xd1, 0x09, 0xd6, 0x0e, 0x4e, 0x08, 0x76, 0x99, 0xdb, 0x2f, 0xa9, 0x65, 0xdd,
-	0xb6, 0x5b, 0xb4, 0x77, 0x9d, 0x76, 0xa2, 0xab, 0x50, 0xa1, 0x1e, 0xde, 0xd0, 0x07, 0x5a, 0x1f,
-	0x1b, 0x87, 0x7d, 0xc2, 0xbc, 0x7b, 0x5a, 0x2d, 0x8b, 0xde, 0x4d, 0xd6, 0xa9, 0x74, 0xa1, 0x14,
-	0x76, 0xee, 0x08, 0x41, 0xa6, 0xab, 0x13, 0x9d, 0xd9, 0xb2, 0xa4, 0xb2, 0xdf, 0xb4, 0xcf, 0xd6,
-	0x49, 0x5f, 0x58, 0x88, 0xfd, 0x46, 0xe7, 0x21, 0x27, 0xd4, 0xa6, 0x99, 0x5a, 0xd1, 0x42, 0x73,
-	0x90, 0xb5, 0x1d, 0xeb, 0x08, 0xb3, 0xcd, 0xcb, 0xab, 0xbc, 0xa1, 0xdc, 0x87, 0x4a, 0x34, 0x0e,
-	0xa0, 0x0a, 0xa4, 0xc8, 0xb1, 0xf8, 0x4a, 0x8a, 0x1c, 0xa3, 0x5b, 0x90, 0xa1, 0xc6, 0x64, 0xda,
-	0x2a, 0x71, 0xd1, 0x4f, 0xc8, 0xb7, 0x4f, 0x6c, 0xac, 0x32, 0xe8, 0x76, 0x26, 0x9f, 0x92, 0xd3,
-	0xca, 0x34, 0x94, 0x23, 0x51, 0x42, 0x39, 0x0f, 0x73, 0x71, 0x3e, 0x5f, 0x31, 0x60, 0x2e, 0xce,
-	0x75, 0xa3, 0x97, 0x21, 0xef, 0x3b, 0x7d, 0xef, 0x04, 0x4d, 0x7c, 0xdd, 0x17, 0xf2, 0xb1, 0xf4,
-	0xec, 0xd0, 0x8d, 0xe8, 0xeb, 0x22, 0xd4, 0x97, 0xd4, 0x29, 0xdd, 0xb6, 0x37, 0x75, 0xb7, 0xaf,
-	0xbc, 0x0f, 0xd5, 0x24, 0x7f, 0x1e, 0x32, 0x9c, 0xc4, 0x2e, 0x80, 0x67, 0xb8, 0xf3, 0x90, 0xeb,
-	0x59, 0xce, 0x50, 0x27, 0x4c, 0x59, 0x59, 0x15, 0x2d, 0x6a, 0x50, 0xee, 0xdb, 0xd3, 0xac, 0x9b,
-	0x37, 0x14, 0x0d, 0x2e, 0x26, 0xba, 0x74, 0x2a, 0x62, 0x98, 0x5d, 0xcc, 0xcd, 0x5b, 0x56, 0x79,
-	0x23, 0x50, 0xc4, 0x27, 0xcb, 0x1b, 0xf4, 0xb3, 0x2e, 0x36, 0xbb, 0xd8, 0x61, 0xfa, 0x0b, 0xaa,
-	0x68, 0x29, 0x3f, 0x4e, 0xc3, 0xf9, 0x78, 0xbf, 0x8e, 0x96, 0xa1, 0x34, 0xd4, 0x8f, 0x35, 0x72,
-	0x2c, 0x8e, 0x9f, 0xc4, 0x0e, 0x00, 0x0c, 0xf5, 0xe3, 0xf6, 0x31, 0x3f, 0x7b, 0x32, 0xa4, 0xc9,
-	0xb1, 0x5b, 0x4d, 0x2d, 0xa7, 0xaf, 0x97, 0x54, 0xfa, 0x13, 0xed, 0xc3, 0xcc, 0xc0, 0xea, 0xe8,
-	0x03, 0x6d, 0xa0, 0xbb, 0x44, 0x13, 0x61, 0x9f, 0x5f, 0xa7, 0x67, 0x92, 0xfc, 0x34, 0xee, 0xf2,
-	0x8d, 0xa5, 0x2e, 0x48, 0x5c, 0x84, 0x69, 0xa6, 0x64, 0x47, 0x77, 0x09, 0x1f, 0x42, 0x0d, 0x28,
-	0x0e, 0x0d, 0xf7, 0x00, 0xf7, 0xf5, 0x23, 0xc3, 0x72, 0xc4, 0xbd, 0x8a, 0x39, 0x3d, 0x77, 0x03,
-	0x90, 0x50, 0x15, 0x96, 0x0b, 0x6d, 0x4a, 0x36, 0x72, 0x9a, 0x3d, 0xcf, 0x92, 0x7b, 0x6c, 0xcf,
-	0xf2, 0x6f, 0x30, 0x67, 0xe2, 0x63, 0xa2, 0x05, 0x37, 0x97, 0x9f, 0x94, 0x29, 0x66, 0x7c, 0x44,
-	0xc7, 0xfc, 0xbb, 0xee, 0xd2, 0x43, 0x83, 0x9e, 0x63, 0xb1, 0xd1, 0xb6, 0x5c, 0xec, 0x68, 0x7a,
-	0xb7, 0xeb, 0x60, 0xd7, 0x65, 0x59, 0x55, 0x89, 0xc5, 0x3b, 0xd6, 0x5f, 0xe3, 0xdd, 0xca, 0x27,
-	0x6c, 0x73, 0xe2, 0xa2, 0xa3, 0x67, 0x7a, 0x29, 0x30, 0x7d, 0x1b, 0xe6, 0x84, 0x7c, 0x37, 0x62,
-	0x7d, 0x9e, 0x9e, 0x5e, 0x4a, 0x4a, 0xba, 0x42, 0x56, 0x47, 0x9e, 0x7c, 0xb2, 0xe1, 0xd3, 0x4f,
-	0x68, 0x78, 0x04, 0x19, 0x66, 0x96, 0x0c, 0x77, 0x37, 0xf4, 0xf7, 0x3f, 0xda, 0x66, 0x7c, 0x9c,
-	0x86, 0x99, 0x89, 0xc4, 0xc2, 0x5f, 0x98, 0x14, 0xbb, 0xb0, 0x54, 0xec, 0xc2, 0xd2, 0x8f, 0xbd,
-	0x30, 0xb1, 0xdb, 0x99, 0xb3, 0x77, 0x3b, 0xfb, 0x6d, 0xee, 0x76, 0xee, 0x09, 0x77, 0xfb, 0xef,
-	0xba, 0x0f, 0x9f, 0x4b, 0xb0, 0x90, 0x9c, 0x8e, 0xc5, 0x6e, 0xc8, 0x4d, 0x98, 0xf1, 0xa7, 0xe2,
-	0xab, 0xe7, 0xee, 0x51, 0xf6, 0x07, 0x84, 0xfe, 0xc4, 0x88, 0x77, 0x15, 0x2a, 0x63, 0xd9, 0x22,
-	0x3f, 0xcc, 0xe5, 0xa3, 0x48, 0xde, 0x77, 0x0b, 0xe6, 0x4d, 0xcb, 0xd4, 0x1c, 0x7b, 0x3c, 0xb7,
-	0xcc, 0x8a, 0xc5, 0x5b, 0xa6, 0x6a, 0x47, 0x66, 0xae, 0xfc, 0x32, 0x0d, 0x73, 0x71, 0x39, 0x60,
-	0xcc, 0x25, 0x57, 0x61, 0xb6, 0x8b, 0x3b, 0x46, 0xf7, 0x89, 0xef, 0xf8, 0x8c, 0x10, 0xff, 0xd7,
-	0x15, 0x9f, 0x3c, 0x5a, 0xe8, 0x06, 0xcc, 0xb8, 0x27, 0x66, 0xc7, 0x30, 0x0f, 0x35, 0x62, 0x79,
-	0xe9, 0x54, 0x81, 0xcd, 0x7c, 0x5a, 0x0c, 0xb4, 0x2d, 0x91, 0x50, 0xfd, 0x1c, 0x20, 0xaf, 0x62,
-	0xd7, 0xa6, 0xf9, 0x1f, 0xaa, 0x43, 0x01, 0x1f, 0x77, 0xb0, 0x4d, 0xbc, 0x9c, 0x39, 0xa1, 0x2c,
-	0x11, 0x10, 0x4f, 0x8e, 0x96, 0xe7, 0xbe, 0x1c, 0x7a, 0x49, 0xb0, 0x10, 0x89, 0x7c, 0x02, 0xcf,
-	0xee, 0x7d, 0x51, 0x4e, 0x43, 0xbc, 0xe2, 0xd1, 0x10, 0xe9, 0xa4, 0xe2, 0x5a, 0xe4, 0xfa, 0xbe,
-	0x9c, 0xe0, 0x21, 0x5e, 0x12, 0x3c, 0x44, 0x26, 0xe9, 0x73, 0xbc, 0x24, 0x08, 0x3e, 0xc7, 0x88,
-	0x88, 0xdb, 0x11, 0x22, 0x22, 0x97, 0xb4, 0xd4, 0x50, 0xee, 0x1e, 0x2c, 0x35, 0x60, 0x22, 0x5e,
-	0xf1, 0x98, 0x88, 0xa9, 0xa4, 0x49, 0x8b, 0x64, 0x35, 0x98, 0x34, 0xa7, 0x22, 0xde, 0x0a, 0x51,
-	0x11, 0x05, 0x26, 0x7b, 0xf9, 0x14, 0x2a, 0xc2, 0x97, 0xf6, 0xb9, 0x88, 0xd7, 0x7d, 0x2e, 0xa2,
-	0x94, 0x48, 0x64, 0x88, 0x2c, 0xd3, 0x17, 0xf6, 0xc8, 0x88, 0xe6, 0x04, 0x19, 0xc1, 0xb9, 0x83,
-	0x6b, 0x67, 0x92, 0x11, 0xbe, 0xaa, 0x31, 0x36, 0xa2, 0x39, 0xc1, 0x46, 0x54, 0x92, 0x34, 0x8e,
-	0xa5, 0xb4, 0x81, 0xc6, 0x28, 0x1d, 0xf1, 0x3f, 0xf1, 0x74, 0x44, 0x22, 0x5f, 0x10, 0x93, 0xbe,
-	0xfa, 0xaa, 0x63, 0xf8, 0x88, 0xf7, 0x13, 0xf8, 0x08, 0x39, 0xa9, 0x6e, 0x8e, 0x4b, 0x5e, 0xfd,
-	0x0f, 0xc4, 0x11, 0x12, 0xfb, 0x31, 0x84, 0x04, 0x67, 0x0e, 0x9e, 0x7b, 0x04, 0x42, 0xc2, 0x57,
-	0x3d, 0xc1, 0x48, 0xec, 0xc7, 0x30, 0x12, 0x28, 0x59, 0xef, 0x58, 0xce, 0x15, 0xd6, 0x1b, 0xa5,
-	0x24, 0xde, 0x89, 0x52, 0x12, 0xb3, 0xa7, 0xa7, 0xba, 0x3c, 0x73, 0xf0, 0xb5, 0x85, 0x39, 0x89,
-	0x4e, 0x12, 0x27, 0xc1, 0x69, 0x83, 0x17, 0x1e, 0x91, 0x93, 0xf0, 0x75, 0xc7, 0x92, 0x12, 0xcd,
-	0x09, 0x52, 0x62, 0x3e, 0xe9, 0xc0, 0x8d, 0x05, 0xa4, 0xe0, 0xc0, 0x25, 0xb2, 0x12, 0x59, 0x39,
-	0xb7, 0x9d, 0xc9, 0xe7, 0xe5, 0x02, 0xe7, 0x23, 0xb6, 0x33, 0xf9, 0xa2, 0x5c, 0x52, 0x9e, 0xa3,
-	0x59, 0xd3, 0x98, 0xdf, 0xa3, 0x35, 0x0a, 0x76, 0x1c, 0xcb, 0x11, 0xfc, 0x02, 0x6f, 0x28, 0xd7,
-	0xa1, 0x14, 0x76, 0x71, 0xa7, 0x30, 0x18, 0xd3, 0x50, 0x8e, 0x78, 0x35, 0xe5, 0xaf, 0x29, 0x28,
-	0x85, 0xfd, 0x55, 0xa4, 0xbe, 0x2d, 0x88, 0xfa, 0x36, 0xc4, 0x6b, 0xa4, 0xa2, 0xbc, 0xc6, 0x12,
-	0x14, 0x69, 0x8d, 0x37, 0x46, 0x59, 0xe8, 0xb6, 0x4f, 0x59, 0xdc, 0x80, 0x19, 0x16, 0x6f, 0x39,
-	0xfb, 0x21, 0x22, 0x43, 0x86, 0x47, 0x06, 0x3a, 0xc0, 0x8c, 0xc1, 0x23, 0x03, 0x7a, 0x01, 0x66,
-	0x43, 0x58, 0xbf, 0x76, 0xe4, 0xf1, 0x5f, 0xf6, 0xd1, 0x35, 0x5e, 0x44, 0xa2, 0xff, 0x82, 0xe9,
-	0x81, 0x6e, 0xd2, 0xe3, 0x6e, 0x58, 0x8e, 0x41, 0x0c, 0xec, 0x8a, 0xbc, 0x6b, 0xed, 0x74, 0x97,
-	0xbc, 0xb2, 0xa3, 0x9b, 0xb8, 0xe9, 0x0b, 0x35, 0x4c, 0xe2, 0x9c, 0xa8, 0x95, 0x41, 0xa4, 0x13,
-	0x5d, 0x86, 0x52, 0x17, 0xf7, 0xf4, 0xd1, 0x80, 0x68, 0x74, 0x84, 0xf9, 0xdb, 0x82, 0x5a, 0x14,
-	0x7d, 0x54, 0xc3, 0x42, 0x0d, 0x66, 0x63, 0x34, 0xd1, 0xdc, 0xe3, 0x21, 0x3e, 0x11, 0xf6, 0xa3,
-	0x3f, 0xe9, 0xa6, 0xb1, 0xad, 0x16, 0x85, 0x2b, 0x6f, 0xbc, 0x9e, 0x7a, 0x55, 0x52, 0x7e, 0x23,
-	0xc1, 0xcc, 0x84, 0xc7, 0x8f, 0x65, 0x56, 0xa4, 0x6f, 0x8b, 0x59, 0x49, 0x3d, 0x39, 0xb3, 0x12,
-	0x2e, 0xe8, 0xd3, 0xd1, 0x82, 0xfe, 0x2f, 0x12, 0x94, 0x23, 0x91, 0x87, 0x9e, 0xa3, 0x8e, 0xd5,
-	0xc5, 0xa2, 0xc4, 0x66, 0xbf, 0xa9, 0x69, 0x06, 0xd6, 0xa1, 0x28, 0xa4, 0xe9, 0x4f, 0x8a, 0xf2,
-	0x63, 0x69, 0x41, 0x44, 0x4a, 0xbf, 0x3a, 0xe7, 0xa9, 0x8f, 0xa8, 0xce, 0x85, 0x59, 0x73, 0xec,
-	0xbb, 0x51, 0xb3, 0xf2, 0x14, 0x86, 0x37, 0xd0, 0x6b, 0x50, 0x60, 0xef, 0x28, 0x9a, 0x65, 0xbb,
-	0x82, 0x74, 0x0f, 0xa5, 0x77, 0xfc, 0xb1, 0x65, 0xe5, 0xe8, 0x16, 0x75, 0x55, 0x56, 0x6f, 0xcf,
-	0x76, 0xd5, 0xbc, 0x2d, 0x7e, 0x85, 0x92, 0xae, 0x42, 0x24, 0xe9, 0xba, 0x04, 0x05, 0x3a, 0x7d,
-	0xd7, 0xd6, 0x3b, 0xb8, 0x0a, 0x6c, 0xa6, 0x41, 0x87, 0xf2, 0xeb, 0x14, 0x4c, 0x8f, 0x05, 0xce,
-	0xd8, 0xc5, 0x7b, 0x17, 0x2b, 0x15, 0x22, 0x8e, 0x1e, 0xcd, 0x20, 0x8b, 0x00, 0x87, 0xba, 0xab,
-	0x7d, 0xa4, 0x9b, 0x04, 0x77, 0x85, 0x55, 0x42, 0x3d, 0x68, 0x01, 0xf2, 0xb4, 0x35, 0x72, 0x71,
-	0x57, 0x70, 0x58, 0x7e, 0x1b, 0x6d, 0x41, 0x0e, 0x1f, 0x61, 0x93, 0xb8, 0xd5, 0x29, 0xb6, 0xf1,
-	0x17, 0x62, 0x3c, 0x2c, 0x1d, 0x5f, 0xaf, 0xd2, 0xed, 0xfe, 0xd3, 0x97, 0x4b, 0x32, 0x87, 0x3f,
-	0x6f, 0x0d, 0x0d, 0x82, 0x87, 0x36, 0x39, 0x51, 0x85, 0x82, 0xa8, 0x19, 0xf2, 0x63, 0x66, 0x40,
-	0x17, 0x60, 0x8a, 0xdd, 0x46, 0xa3, 0xcb, 0x32, 0x84, 0x82, 0x9a, 0xa3, 0xcd, 0xad, 0x2e, 0x63,
-	0x5a, 0x4b, 0x1e, 0x6d, 0x42, 0xad, 0xcd, 0xae, 0xcb, 0x89, 0x5a, 0x1e, 0xe2, 0xa1, 0x6d, 0x59,
-	0x03, 0x8d, 0xfb, 0xb0, 0x1a, 0x54, 0xa2, 0x09, 0x04, 0xba, 0x02, 0x65, 0x07, 0x13, 0xdd, 0x30,
-	0xb5, 0x48, 0x59, 0x51, 0xe2, 0x9d, 0xdc, 0x67, 0x6c, 0x67, 0xf2, 0x92, 0x9c, 0x12, 0x4c, 0xd7,
-	0xbb, 0x30, 0x1f, 0x9b, 0x3f, 0xa0, 0x57, 0xa1, 0x10, 0xe4, 0x1e, 0x12, 0xb3, 0xc3, 0x69, 0x14,
-	0x56, 0x00, 0x56, 0xf6, 0x61, 0x3e, 0x36, 0x81, 0x40, 0x6f, 0x42, 0xce, 0xc1, 0xee, 0x68, 0xc0,
-	0x59, 0xaa, 0xca, 0xda, 0xd5, 0xb3, 0x33, 0x8f, 0xd1, 0x80, 0xa8, 0x42, 0x48, 0xb9, 0x05, 0x17,
-	0x13, 0x33, 0x88, 0x80, 0x88, 0x92, 0x42, 0x44, 0x94, 0xf2, 0x0b, 0x09, 0x16, 0x92, 0xb3, 0x02,
-	0xb4, 0x3e, 0x36, 0xa1, 0x1b, 0x8f, 0x98, 0x53, 0x84, 0x66, 0x45, 0x2b, 0x35, 0x07, 0xf7, 0x30,
-	0xe9, 0xf4, 0x79, 0x7a, 0xc2, 0xbd, 0x45, 0x59, 0x2d, 0x8b, 0x5e, 0x26, 0xe3, 0x72, 0xd8, 0x07,
-	0xb8, 0x43, 0x34, 0xbe, 0xa9, 0x2e, 0x2b, 0x7d, 0x0a, 0x14, 0x46, 0x7b, 0x5b, 0xbc, 0x53, 0xb9,
-	0x09, 0x17, 0x12, 0xf2, 0x8c, 0xc9, 0xfa, 0x4c, 0x79, 0x40, 0xc1, 0xb1, 0xc9, 0x03, 0x7a, 0x1b,
-	0x72, 0x2e, 0xd1, 0xc9, 0xc8, 0x15, 0x2b, 0xbb, 0x76, 0x66, 0xde, 0xd1, 0x62, 0x70, 0x55, 0x88,
-	0x29, 0x18, 0xd0, 0x64, 0x16, 0x11, 0x53, 0x96, 0x4a, 0x71, 0x65, 0xe9, 0x75, 0x90, 0x45, 0x59,
-	0x1a, 0x00, 0xf9, 0x15, 0xae, 0xb0, 0x8a, 0x34, 0xa8, 0x46, 0x0f, 0xe0, 0xa9, 0x53, 0x32, 0x0b,
-	0x54, 0x1f, 0x5b, 0xc6, 0xcd, 0x47, 0x4a, 0x4c, 0xc6, 0x96, 0xf2, 0xab, 0x34, 0xcc, 0xc7, 0x26,
-	0x18, 0xa1, 0x8b, 0x2e, 0x7d, 0xd3, 0x8b, 0xfe, 0x26, 0x00, 0x39, 0xd6, 0xf8, 0x99, 0xf0, 0x02,
-	0x46, 0x5c, 0x55, 0x75, 0x8c, 0x3b, 0xcc, 0xe7, 0xd1, 0x23, 0x54, 0x20, 0xe2, 0x97, 0x8b, 0xda,
-	0x61, 0xd2, 0x60, 0xc4, 0x82, 0x89, 0x2b, 0x8a, 0xe3, 0x47, 0x0e, 0x3b, 0x01, 0xbb, 0xc0, 0xbb,
-	0x5d, 0xf4, 0x00, 0x2e, 0x8c, 0x05, 0x45, 0x5f, 0x77, 0xe6, 0x91, 0x63, 0xe3, 0x7c, 0x34, 0x36,
-	0x7a, 0xba, 0xc3, 0x81, 0x2d, 0x1b, 0x09, 0x6c, 0x34, 0x16, 0xb3, 0xb2, 0x99, 0xe7, 0x24, 0x5d,
-	0x3c, 0xd0, 0xbd, 0x57, 0xe0, 0x8b, 0x13, 0xc5, 0xf7, 0x6d, 0xf1, 0x50, 0xce, 0x6b, 0xef, 0x1f,
-	0xd1, 0xda, 0xbb, 0x42, 0x85, 0xd9, 0x46, 0xdd, 0xa6, 0xa2, 0xca, 0x03, 0x80, 0x80, 0x59, 0xa0,
-	0x17, 0xdd, 0xb1, 0x46, 0x66, 0x97, 0x9d, 0x88, 0xac, 0xca, 0x1b, 0xe8, 0x65, 0xc8, 0xd2, 0x23,
-	0xe8, 0x59, 0x3e, 0xc6, 0x53, 0xd1, 0x13, 0x12, 0xa2, 0x26, 0x38, 0x5c, 0xf9, 0xc0, 0x3b, 0xe6,
-	0x61, 0x5e, 0x38, 0xe1, 0x1b, 0x6f, 0x45, 0xbf, 0xa1, 0x24, 0x53, 0xcc, 0xf1, 0xdf, 0xfa, 0x7f,
-	0xc8, 0xb2, 0xd3, 0x44, 0xe3, 0x15, 0x7b, 0x96, 0x10, 0xe9, 0x22, 0xfd, 0x8d, 0xfe, 0x17, 0x40,
-	0x27, 0xc4, 0x31, 0x0e, 0x46, 0xc1, 0x17, 0x96, 0x13, 0x8e, 0x63, 0xcd, 0x03, 0xae, 0x5f, 0x12,
-	0xe7, 0x72, 0x2e, 0x90, 0x0d, 0x9d, 0xcd, 0x90, 0x46, 0x65, 0x17, 0x2a, 0x51, 0xd9, 0xb3, 0x72,
-	0xae, 0x82, 0x97, 0x1c, 0xf8, 0xa9, 0x45, 0x9a, 0x3f, 0xbe, 0xb0, 0x86, 0xf2, 0x9d, 0x14, 0x94,
-	0xc2, 0x87, 0xf9, 0x9f, 0x30, 0x7c, 0x2b, 0xdf, 0x97, 0x20, 0xef, 0xaf, 0x3f, 0xfa, 0x04, 0x13,
-	0x79, 0xbb, 0xe2, 0xe6, 0x4b, 0x85, 0xdf, 0x4d, 0xf8, 0x4b, 0x55, 0xda, 0x7f, 0xa9, 0xfa, 0x0f,
-	0x3f, 0x12, 0x25, 0x32, 0x24, 0x61, 0x6b, 0x8b, 0x83, 0xe5, 0x45, 0xc6, 0x37, 0xa0, 0xe0, 0xbb,
-	0x04, 0x5a, 0x78, 0x78, 0xcc, 0x93, 0x24, 0xee, 0xa5, 0x60, 0x9c, 0xe6, 0x20, 0x6b, 0x5b, 0x1f,
-	0x89, 0x57, 0x99, 0xb4, 0xca, 0x1b, 0x8a, 0x0b, 0xd3, 0x63, 0xfe, 0x24, 0x00, 0xa6, 0x42, 0x40,
-	0xa4, 0x40, 0xd9, 0x1e, 0x1d, 0x68, 0x0f, 0xf1, 0x89, 0x78, 0xa3, 0xe1, 0xd3, 0x2f, 0xda, 0xa3,
-	0x83, 0x3b, 0xf8, 0x84, 0x3f, 0xd2, 0x2c, 0x43, 0xc9, 0xc3, 0xb0, 0x23, 0xce, 0xf7, 0x14, 0x38,
-	0xa4, 0xcd, 0x1f, 0xd8, 0x24, 0x39, 0xa5, 0xfc, 0x50, 0x82, 0xbc, 0x77, 0x4b, 0xd0, 0xdb, 0x50,
-	0xf0, 0x5d, 0x97, 0x48, 0xda, 0x9f, 0x3a, 0xc5, 0xe9, 0x89, 0xc5, 0x07, 0x32, 0x68, 0xdd, 0x7b,
-	0x29, 0x36, 0xba, 0x5a, 0x6f, 0xa0, 0x1f, 0x8a, 0x07, 0xbf, 0xc5, 0x18, 0xef, 0xc6, 0xfc, 0xca,
-	0xd6, 0xed, 0x8d, 0x81, 0x7e, 0xa8, 0x16, 0x99, 0xd0, 0x56, 0x97, 0x36, 0x44, 0x3a, 0xf4, 0xc7,
-	0x14, 0xc8, 0xe3, 0xb7, 0xf8, 0x9b, 0xcf, 0x6f, 0x32, 0x6c, 0xa6, 0xe3, 0xc2, 0xe6, 0x2a, 0xcc,
-	0xfa, 0x08, 0xcd, 0x35, 0x0e, 0x4d, 0x9d, 0x8c, 0x1c, 0x2c, 0x38, 0x4e, 0xe4, 0x0f, 0xb5, 0xbc,
-	0x91, 0xc9, 0x75, 0x67, 0x1f, 0x7b, 0xdd, 0xc9, 0x14, 0x72, 0x2e, 0x89, 0x42, 0x46, 0x6f, 0xc0,
-	0xc2, 0x78, 0x78, 0x0f, 0x4d, 0x97, 0x57, 0x16, 0x17, 0xa2, 0x81, 0xde, 0x9f, 0xb3, 0xb0, 0xf3,
-	0xc7, 0x29, 0x28, 0x86, 0x28, 0x5e, 0xf4, 0xef, 0x21, 0x97, 0x58, 0x89, 0x0b, 0x79, 0x21, 0x70,
-	0xf0, 0x5a, 0x1b, 0xdd, 0x99, 0xd4, 0x13, 0xec, 0x4c, 0x12, 0xff, 0xee, 0x71, 0xc6, 0x99, 0xc7,
-	0xe6, 0x8c, 0x9f, 0x07, 0x44, 0x2c, 0xa2, 0x0f, 0xa8, 0x39, 0x0d, 0xf3, 0x50, 0xe3, 0x17, 0x89,
-	0x7b, 0x30, 0x99, 0x8d, 0xec, 0xb3, 0x81, 0x26, 0xbb, 0x7c, 0xdf, 0x95, 0x20, 0xef, 0xf3, 0x69,
-	0x8f, 0xfb, 0x8a, 0x7b, 0x1e, 0x72, 0x22, 0xe5, 0xe4, 0xcf, 0xb8, 0xa2, 0x15, 0x4b, 0x8e, 0x2f,
-	0x40, 0x7e, 0x88, 0x89, 0xce, 0xdc, 0x31, 0x0f, 0xd7, 0x7e, 0xfb, 0xc6, 0x01, 0x14, 0x43, 0x0f,
-	0xe1, 0xe8, 0x22, 0xcc, 0xd7, 0x37, 0x1b, 0xf5, 0x3b, 0x5a, 0xfb, 0x3d, 0xad, 0x7d, 0xbf, 0xd9,
-	0xd0, 0xee, 0xed, 0xde, 0xd9, 0xdd, 0xfb, 0xcf, 0x5d, 0xf9, 0xdc, 0xe4, 0x90, 0xda, 0x60, 0x6d,
-	0x59, 0x42, 0x17, 0x60, 0x36, 0x3a, 0xc4, 0x07, 0x52, 0x0b, 0x99, 0x1f, 0xfc, 0x6c, 0xf1, 0xdc,
-	0x8d, 0x3f, 0x4b, 0x30, 0x1b, 0x93, 0xdc, 0xa3, 0xcb, 0xf0, 0xf4, 0xde, 0xc6, 0x46, 0x43, 0xd5,
-	0x5a, 0xbb, 0xb5, 0x66, 0x6b, 0x73, 0xaf, 0xad, 0xa9, 0x8d, 0xd6, 0xbd, 0x9d, 0x76, 0xe8, 0xa3,
-	0xcb, 0x70, 0x29, 0x1e, 0x52, 0xab, 0xd7, 0x1b, 0xcd, 0xb6, 0x2c, 0xa1, 0x25, 0x78, 0x2a, 0x01,
-	0xb1, 0xbe, 0xa7, 0xb6, 0xe5, 0x54, 0xb2, 0x0a, 0xb5, 0xb1, 0xdd, 0xa8, 0xb7, 0xe5, 0x34, 0xba,
-	0x06, 0x57, 0x4e, 0x43, 0x68, 0x1b, 0x7b, 0xea, 0xdd, 0x5a, 0x5b, 0xce, 0x9c, 0x09, 0x6c, 0x35,
-	0x76, 0x6f, 0x37, 0x54, 0x39, 0x2b, 0xd6, 0xfd, 0xd3, 0x14, 0x54, 0x93, 0x6a, 0x08, 0xaa, 0xab,
-	0xd6, 0x6c, 0xee, 0xdc, 0x0f, 0x74, 0xd5, 0x37, 0xef, 0xed, 0xde, 0x99, 0x34, 0xc1, 0xb3, 0xa0,
-	0x9c, 0x06, 0xf4, 0x0d, 0x71, 0x15, 0x2e, 0x9f, 0x8a, 0x13, 0xe6, 0x38, 0x03, 0xa6, 0x36, 0xda,
-	0xea, 0x7d, 0x39, 0x8d, 0x56, 0xe0, 0xc6, 0x99, 0x30, 0x7f, 0x4c, 0xce, 0xa0, 0x55, 0xb8, 0x79,
-	0x3a, 0x9e, 0x1b, 0xc8, 0x13, 0xf0, 0x4c, 0xf4, 0x89, 0x04, 0xf3, 0xb1, 0xc5, 0x08, 0xba, 0x02,
-	0x4b, 0x4d, 0x75, 0xaf, 0xde, 0x68, 0xb5, 0xb4, 0xa6, 0xba, 0xd7, 0xdc, 0x6b, 0xd5, 0x76, 0xb4,
-	0x56, 0xbb, 0xd6, 0xbe, 0xd7, 0x0a, 0xd9, 0x46, 0x81, 0xc5, 0x24, 0x90, 0x6f, 0x97, 0x53, 0x30,
-	0xe2, 0x04, 0x78, 0xe7, 0xf4, 0x27, 0x12, 0x5c, 0x4c, 0x2c, 0x29, 0xd0, 0x75, 0x78, 0x66, 0xbf,
-	0xa1, 0x6e, 0x6d, 0xdc, 0xd7, 0xf6, 0xf7, 0xda, 0x0d, 0xad, 0xf1, 0x5e, 0xbb, 0xb1, 0xdb, 0xda,
-	0xda, 0xdb, 0x9d, 0x9c, 0xd5, 0x35, 0xb8, 0x72, 0x2a, 0xd2, 0x9f, 0xda, 0x59, 0xc0, 0xb1, 0xf9,
-	0x7d, 0x4f, 0x82, 0xe9, 0x31, 0x5f, 0x88, 0x2e, 0x41, 0xf5, 0xee, 0x56, 0x6b, 0xbd, 0xb1, 0x59,
-	0xdb, 0xdf, 0xda, 0x53, 0xc7, 0xef, 0xec, 0x15, 0x58, 0x9a, 0x18, 0xbd, 0x7d, 0xaf, 0xb9, 0xb3,
-	0x55, 0xaf, 0xb5, 0x1b, 0xec, 0xa3, 0xb2, 0x44, 0x17, 0x36, 0x01, 0xda, 0xd9, 0x7a, 0x67, 0xb3,
-	0xad, 0xd5, 0x77, 0xb6, 0x1a, 0xbb, 0x6d, 0xad, 0xd6, 0x6e, 0xd7, 0x82, 0xeb, 0xbc, 0x7e, 0xe7,
-	0xb3, 0xaf, 0x16, 0xa5, 0x2f, 0xbe, 0x5a, 0x94, 0xfe, 0xf0, 0xd5, 0xa2, 0xf4, 0xe9, 0xd7, 0x8b,
-	0xe7, 0xbe, 0xf8, 0x7a, 0xf1, 0xdc, 0xef, 0xbe, 0x5e, 0x3c, 0xf7, 0xe0, 0xd6, 0xa1, 0x41, 0xfa,
-	0xa3, 0x03, 0xea, 0x85, 0x57, 0x83, 0xff, 0xeb, 0xfa, 0x7f, 0xf4, 0xb5, 0x8d, 0xd5, 0xf1, 0x7f,
-	0xfd, 0x1e, 0xe4, 0x98, 0x5b, 0x7d, 0xf1, 0x6f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x7e, 0x54, 0xeb,
-	0x69, 0x10, 0x2c, 0x00, 0x00,
+	// 3382 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x5a, 0x4d, 0x6c, 0x1b, 0xc7,
+	0xbd, 0xf7, 0x92, 0x14, 0x45, 0xfe, 0xf9, 0xa1, 0xd5, 0x48, 0xb2, 0x69, 0xc5, 0x91, 0xe4, 0x75,
+	0x1c, 0x3b, 0x76, 0x22, 0x3d, 0x2b, 0xef, 0xe5, 0xf3, 0x25, 0x01, 0x25, 0x53, 0x91, 0x64, 0x59,
+	0x62, 0x96, 0xb4, 0x5e, 0xec, 0xf7, 0x5e, 0x37, 0x2b, 0x72, 0x28, 0x6e, 0x4c, 0xee, 0x6e, 0x76,
+	0x87, 0x0c, 0xd5, 0xde, 0x82, 0xa6, 0x28, 0x72, 0xca, 0xa5, 0x40, 0x51, 0xa0, 0x40, 0x81, 0xa2,
+	0xd7, 0x1e, 0x0a, 0xf4, 0xd8, 0x6b, 0x91, 0x53, 0x93, 0x63, 0x4f, 0x69, 0x91, 0xa0, 0x97, 0xde,
+	0x0b, 0x14, 0xe8, 0xa5, 0x98, 0x8f, 0xfd, 0x22, 0x77, 0x25, 0xdb, 0x49, 0x0f, 0x45, 0x7b, 0xe3,
+	0xcc, 0xfc, 0xfe, 0xff, 0x9d, 0xf9, 0xcf, 0xcc, 0xff, 0xe3, 0x37, 0x84, 0x4b, 0x2d, 0xab, 0x8f,
+	0xc9, 0x51, 0x87, 0xac, 0xe9, 0x47, 0x2d, 0x63, 0x6d, 0xb8, 0xbe, 0x46, 0x4e, 0x6c, 0xec, 0xae,
+	0xda, 0x8e, 0x45, 0x2c, 0x24, 0x7b, 0xa3, 0xab, 0x74, 0x74, 0x75, 0xb8, 0xbe, 0xb8, 0xe4, 0xe3,
+	0x5b, 0xce, 0x89, 0x4d, 0xac, 0xb5, 0xe1, 0xad, 0x35, 0xdb, 0xb1, 0xac, 0x0e, 0x97, 0x08, 0x8d,
+	0x33, 0x3d, 0x54, 0xa1, 0xad, 0x3b, 0x7a, 0x5f, 0x68, 0x5c, 0xbc, 0x3c, 0x39, 0x3e, 0xd4, 0x7b,
+	0x46, 0x5b, 0x27, 0x96, 0x23, 0x20, 0xf3, 0xc7, 0xd6, 0xb1, 0xc5, 0x7e, 0xae, 0xd1, 0x5f, 0xa2,
+	0x77, 0xf9, 0xd8, 0xb2, 0x8e, 0x7b, 0x78, 0x8d, 0xb5, 0x8e, 0x06, 0x9d, 0x35, 0x62, 0xf4, 0xb1,
+	0x4b, 0xf4, 0xbe, 0xed, 0x7d, 0x79, 0x1c, 0xd0, 0x1e, 0x38, 0x3a, 0x31, 0x2c, 0x93, 0x8f, 0x2b,
+	0x9f, 0xe7, 0x61, 0x5a, 0xc5, 0x1f, 0x0c, 0xb0, 0x4b, 0xd0, 0x8b, 0x90, 0xc1, 0xad, 0xae, 0x55,
+	0x91, 0x56, 0xa4, 0xeb, 0x85, 0xf5, 0xa7, 0x57, 0xc7, 0x97, 0xb9, 0x5a, 0x6b, 0x75, 0x2d, 0x01,
+	0xde, 0x3e, 0xa7, 0x32, 0x30, 0x7a, 0x09, 0xa6, 0x3a, 0xbd, 0x81, 0xdb, 0xad, 0xa4, 0x98, 0xd4,
+	0xd2, 0xa4, 0xd4, 0x16, 0x1d, 0x0e, 0xc4, 0x38, 0x9c, 0x7e, 0xcc, 0x30, 0x3b, 0x56, 0x25, 0x9d,
+	0xf4, 0xb1, 0x1d, 0xb3, 0x13, 0xfe, 0x18, 0x05, 0xa3, 0x4d, 0x00, 0xc3, 0x34, 0x88, 0xd6, 0xea,
+	0xea, 0x86, 0x59, 0x99, 0x62, 0xa2, 0x4a, 0x9c, 0xa8, 0x41, 0x36, 0x29, 0x24, 0x90, 0xcf, 0x1b,
+	0x5e, 0x1f, 0x9d, 0xf1, 0x07, 0x03, 0xec, 0x9c, 0x54, 0xb2, 0x49, 0x33, 0x7e, 0x87, 0x0e, 0x87,
+	0x66, 0xcc, 0xe0, 0xe8, 0x0d, 0xc8, 0xb5, 0xba, 0xb8, 0xf5, 0x50, 0x23, 0xa3, 0x4a, 0x8e, 0x89,
+	0xae, 0x4c, 0x8a, 0x6e, 0x52, 0x44, 0x73, 0x14, 0x08, 0x4f, 0xb7, 0x78, 0x0f, 0x7a, 0x15, 0xb2,
+	0x2d, 0xab, 0xdf, 0x37, 0x48, 0xa5, 0xc0, 0x84, 0x97, 0x63, 0x84, 0xd9, 0x78, 0x20, 0x2b, 0x04,
+	0xd0, 0x01, 0x94, 0x7b, 0x86, 0x4b, 0x34, 0xd7, 0xd4, 0x6d, 0xb7, 0x6b, 0x11, 0xb7, 0x52, 0x64,
+	0x2a, 0x9e, 0x9d, 0x54, 0xb1, 0x67, 0xb8, 0xa4, 0xe1, 0xc1, 0x02, 0x4d, 0xa5, 0x5e, 0xb8, 0x9f,
+	0x2a, 0xb4, 0x3a, 0x1d, 0xec, 0xf8, 0x1a, 0x2b, 0xa5, 0x24, 0x85, 0x07, 0x14, 0xe7, 0x49, 0x86,
+	0x14, 0x5a, 0xe1, 0x7e, 0xf4, 0x7f, 0x30, 0xd7, 0xb3, 0xf4, 0xb6, 0xaf, 0x4f, 0x6b, 0x75, 0x07,
+	0xe6, 0xc3, 0x4a, 0x99, 0x69, 0xbd, 0x11, 0x33, 0x4d, 0x4b, 0x6f, 0x7b, 0xc2, 0x9b, 0x14, 0x1a,
+	0x68, 0x9e, 0xed, 0x8d, 0x8f, 0x21, 0x0d, 0xe6, 0x75, 0xdb, 0xee, 0x9d, 0x8c, 0xab, 0x9f, 0x61,
+	0xea, 0x6f, 0x4e, 0xaa, 0xaf, 0x52, 0x74, 0x82, 0x7e, 0xa4, 0x4f, 0x0c, 0xa2, 0x7b, 0x20, 0xdb,
+	0x0e, 0xb6, 0x75, 0x07, 0x6b, 0xb6, 0x63, 0xd9, 0x96, 0xab, 0xf7, 0x2a, 0x32, 0x53, 0x7e, 0x7d,
+	0x52, 0x79, 0x9d, 0x23, 0xeb, 0x02, 0x18, 0x68, 0x9e, 0xb1, 0xa3, 0x23, 0x5c, 0xad, 0xd5, 0xc2,
+	0xae, 0x1b, 0xa8, 0x9d, 0x4d, 0x56, 0xcb, 0x90, 0xb1, 0x6a, 0x23, 0x23, 0x68, 0x0b, 0x0a, 0x78,
+	0x44, 0xb0, 0xd9, 0xd6, 0x86, 0x16, 0xc1, 0x15, 0xc4, 0x34, 0x5e, 0x89, 0xb9, 0xae, 0x0c, 0x74,
+	0x68, 0x11, 0x1c, 0x28, 0x03, 0xec, 0x77, 0xa2, 0x23, 0x58, 0x18, 0x62, 0xc7, 0xe8, 0x9c, 0x30,
+	0x3d, 0x1a, 0x1b, 0x71, 0x0d, 0xcb, 0xac, 0xcc, 0x31, 0x8d, 0xcf, 0x4f, 0x6a, 0x3c, 0x64, 0x70,
+	0x2a, 0x5c, 0xf3, 0xc0, 0x81, 0xea, 0xb9, 0xe1, 0xe4, 0x28, 0x3d, 0x69, 0x1d, 0xc3, 0xd4, 0x7b,
+	0xc6, 0x77, 0xb1, 0x76, 0xd4, 0xb3, 0x5a, 0x0f, 0x2b, 0xf3, 0x49, 0x27, 0x6d, 0x4b, 0xe0, 0x36,
+	0x28, 0x2c, 0x74, 0xd2, 0x3a, 0xe1, 0xfe, 0x8d, 0x69, 0x98, 0x1a, 0xea, 0xbd, 0x01, 0xde, 0xcd,
+	0xe4, 0x32, 0xf2, 0xd4, 0x6e, 0x26, 0x37, 0x2d, 0xe7, 0x76, 0x33, 0xb9, 0xbc, 0x0c, 0xbb, 0x99,
+	0x1c, 0xc8, 0x05, 0xe5, 0x1a, 0x14, 0x42, 0x7e, 0x0a, 0x55, 0x60, 0xba, 0x8f, 0x5d, 0x57, 0x3f,
+	0xc6, 0xcc, 0xaf, 0xe5, 0x55, 0xaf, 0xa9, 0x94, 0xa1, 0x18, 0x76, 0x4d, 0xca, 0xa7, 0x12, 0x14,
+	0x42, 0x4e, 0x87, 0x4a, 0x0e, 0xb1, 0xc3, 0x0c, 0x22, 0x24, 0x45, 0x13, 0x5d, 0x81, 0x12, 0x5b,
+	0x8b, 0xe6, 0x8d, 0x53, 0xdf, 0x97, 0x51, 0x8b, 0xac, 0xf3, 0x50, 0x80, 0x96, 0xa1, 0x60, 0xaf,
+	0xdb, 0x3e, 0x24, 0xcd, 0x20, 0x60, 0xaf, 0xdb, 0x1e, 0xe0, 0x32, 0x14, 0xe9, 0xd2, 0x7d, 0x44,
+	0x86, 0x7d, 0xa4, 0x40, 0xfb, 0x04, 0x44, 0xf9, 0x5d, 0x0a, 0xe4, 0x71, 0x67, 0x86, 0x5e, 0x81,
+	0x0c, 0xf5, 0xf2, 0xc2, 0x4d, 0x2f, 0xae, 0x72, 0x0f, 0xbf, 0xea, 0x79, 0xf8, 0xd5, 0xa6, 0x17,
+	0x02, 0x36, 0x72, 0x9f, 0x7d, 0xb9, 0x7c, 0xee, 0xd3, 0x3f, 0x2c, 0x4b, 0x2a, 0x93, 0x40, 0x17,
+	0xa9, 0x07, 0xd3, 0x0d, 0x53, 0x33, 0xda, 0x6c, 0xca, 0x79, 0xea, 0x9d, 0x74, 0xc3, 0xdc, 0x69,
+	0xa3, 0xbb, 0x20, 0xb7, 0x2c, 0xd3, 0xc5, 0xa6, 0x3b, 0x70, 0x35, 0x1e, 0x9b, 0x2a, 0xe9, 0x71,
+	0xff, 0xca, 0x83, 0x20, 0x73, 0x54, 0x02, 0x5a, 0x67, 0x48, 0x75, 0xa6, 0x15, 0xed, 0x40, 0x6f,
+	0x03, 0xf8, 0x01, 0xcc, 0xad, 0x64, 0x56, 0xd2, 0xd7, 0x0b, 0xeb, 0x97, 0x63, 0xce, 0x93, 0x87,
+	0xb9, 0x67, 0xb7, 0x75, 0x82, 0x37, 0x32, 0x74, 0xc2, 0x6a, 0x48, 0x14, 0x3d, 0x0b, 0x33, 0xba,
+	0x6d, 0x6b, 0x2e, 0xd1, 0x09, 0xd6, 0x8e, 0x4e, 0x08, 0x76, 0x99, 0xdb, 0x2f, 0xaa, 0x25, 0xdd,
+	0xb6, 0x1b, 0xb4, 0x77, 0x83, 0x76, 0xa2, 0xab, 0x50, 0xa6, 0x1e, 0xde, 0xd0, 0x7b, 0x5a, 0x17,
+	0x1b, 0xc7, 0x5d, 0xc2, 0xbc, 0x7b, 0x5a, 0x2d, 0x89, 0xde, 0x6d, 0xd6, 0xa9, 0xb4, 0xa1, 0x18,
+	0x76, 0xee, 0x08, 0x41, 0xa6, 0xad, 0x13, 0x9d, 0xd9, 0xb2, 0xa8, 0xb2, 0xdf, 0xb4, 0xcf, 0xd6,
+	0x49, 0x57, 0x58, 0x88, 0xfd, 0x46, 0xe7, 0x21, 0x2b, 0xd4, 0xa6, 0x99, 0x5a, 0xd1, 0x42, 0xf3,
+	0x30, 0x65, 0x3b, 0xd6, 0x10, 0xb3, 0xcd, 0xcb, 0xa9, 0xbc, 0xa1, 0xdc, 0x87, 0x72, 0x34, 0x0e,
+	0xa0, 0x32, 0xa4, 0xc8, 0x48, 0x7c, 0x25, 0x45, 0x46, 0xe8, 0x16, 0x64, 0xa8, 0x31, 0x99, 0xb6,
+	0x72, 0x5c, 0xf4, 0x13, 0xf2, 0xcd, 0x13, 0x1b, 0xab, 0x0c, 0xba, 0x9b, 0xc9, 0xa5, 0xe4, 0xb4,
+	0x32, 0x03, 0xa5, 0x48, 0x94, 0x50, 0xce, 0xc3, 0x7c, 0x9c, 0xcf, 0x57, 0x0c, 0x98, 0x8f, 0x73,
+	0xdd, 0xe8, 0x25, 0xc8, 0xf9, 0x4e, 0xdf, 0x3b, 0x41, 0x13, 0x5f, 0xf7, 0x85, 0x7c, 0x2c, 0x3d,
+	0x3b, 0x74, 0x23, 0xba, 0xba, 0x08, 0xf5, 0x45, 0x75, 0x5a, 0xb7, 0xed, 0x6d, 0xdd, 0xed, 0x2a,
+	0xef, 0x41, 0x25, 0xc9, 0x9f, 0x87, 0x0c, 0x27, 0xb1, 0x0b, 0xe0, 0x19, 0xee, 0x3c, 0x64, 0x3b,
+	0x96, 0xd3, 0xd7, 0x09, 0x53, 0x56, 0x52, 0x45, 0x8b, 0x1a, 0x94, 0xfb, 0xf6, 0x34, 0xeb, 0xe6,
+	0x0d, 0x45, 0x83, 0x8b, 0x89, 0x2e, 0x9d, 0x8a, 0x18, 0x66, 0x1b, 0x73, 0xf3, 0x96, 0x54, 0xde,
+	0x08, 0x14, 0xf1, 0xc9, 0xf2, 0x06, 0xfd, 0xac, 0x8b, 0xcd, 0x36, 0x76, 0x98, 0xfe, 0xbc, 0x2a,
+	0x5a, 0xca, 0x4f, 0xd2, 0x70, 0x3e, 0xde, 0xaf, 0xa3, 0x15, 0x28, 0xf6, 0xf5, 0x91, 0x46, 0x46,
+	0xe2, 0xf8, 0x49, 0xec, 0x00, 0x40, 0x5f, 0x1f, 0x35, 0x47, 0xfc, 0xec, 0xc9, 0x90, 0x26, 0x23,
+	0xb7, 0x92, 0x5a, 0x49, 0x5f, 0x2f, 0xaa, 0xf4, 0x27, 0x3a, 0x84, 0xd9, 0x9e, 0xd5, 0xd2, 0x7b,
+	0x5a, 0x4f, 0x77, 0x89, 0x26, 0xc2, 0x3e, 0xbf, 0x4e, 0xcf, 0x24, 0xf9, 0x69, 0xdc, 0xe6, 0x1b,
+	0x4b, 0x5d, 0x90, 0xb8, 0x08, 0x33, 0x4c, 0xc9, 0x9e, 0xee, 0x12, 0x3e, 0x84, 0x6a, 0x50, 0xe8,
+	0x1b, 0xee, 0x11, 0xee, 0xea, 0x43, 0xc3, 0x72, 0xc4, 0xbd, 0x8a, 0x39, 0x3d, 0x77, 0x03, 0x90,
+	0x50, 0x15, 0x96, 0x0b, 0x6d, 0xca, 0x54, 0xe4, 0x34, 0x7b, 0x9e, 0x25, 0xfb, 0xd8, 0x9e, 0xe5,
+	0x3f, 0x60, 0xde, 0xc4, 0x23, 0xa2, 0x05, 0x37, 0x97, 0x9f, 0x94, 0x69, 0x66, 0x7c, 0x44, 0xc7,
+	0xfc, 0xbb, 0xee, 0xd2, 0x43, 0x83, 0x9e, 0x63, 0xb1, 0xd1, 0xb6, 0x5c, 0xec, 0x68, 0x7a, 0xbb,
+	0xed, 0x60, 0xd7, 0x65, 0x59, 0x55, 0x51, 0x9d, 0xf1, 0xfa, 0xab, 0xbc, 0x5b, 0xf9, 0x84, 0x6d,
+	0x4e, 0x5c, 0x74, 0xf4, 0x4c, 0x2f, 0x05, 0xa6, 0x6f, 0xc2, 0xbc, 0x90, 0x6f, 0x47, 0xac, 0xcf,
+	0xd3, 0xd3, 0x4b, 0x49, 0x49, 0x57, 0xc8, 0xea, 0xc8, 0x93, 0x4f, 0x36, 0x7c, 0xfa, 0x09, 0x0d,
+	0x8f, 0x20, 0xc3, 0xcc, 0x92, 0xe1, 0xee, 0x86, 0xfe, 0xfe, 0x67, 0xdb, 0x8c, 0x8f, 0xd3, 0x30,
+	0x3b, 0x91, 0x58, 0xf8, 0x0b, 0x93, 0x62, 0x17, 0x96, 0x8a, 0x5d, 0x58, 0xfa, 0xb1, 0x17, 0x26,
+	0x76, 0x3b, 0x73, 0xf6, 0x6e, 0x4f, 0x7d, 0x9b, 0xbb, 0x9d, 0x7d, 0xc2, 0xdd, 0xfe, 0x87, 0xee,
+	0xc3, 0xe7, 0x12, 0x2c, 0x26, 0xa7, 0x63, 0xb1, 0x1b, 0x72, 0x13, 0x66, 0xfd, 0xa9, 0xf8, 0xea,
+	0xb9, 0x7b, 0x94, 0xfd, 0x01, 0xa1, 0x3f, 0x31, 0xe2, 0x5d, 0x85, 0xf2, 0x58, 0xb6, 0xc8, 0x0f,
+	0x73, 0x69, 0x18, 0xc9, 0xfb, 0x6e, 0xc1, 0x82, 0x69, 0x99, 0x9a, 0x63, 0x8f, 0xe7, 0x96, 0x53,
+	0x62, 0xf1, 0x96, 0xa9, 0xda, 0x91, 0x99, 0x2b, 0xbf, 0x4a, 0xc3, 0x7c, 0x5c, 0x0e, 0x18, 0x73,
+	0xc9, 0x55, 0x98, 0x6b, 0xe3, 0x96, 0xd1, 0x7e, 0xe2, 0x3b, 0x3e, 0x2b, 0xc4, 0xff, 0x7d, 0xc5,
+	0x27, 0x8f, 0x16, 0xba, 0x01, 0xb3, 0xee, 0x89, 0xd9, 0x32, 0xcc, 0x63, 0x8d, 0x58, 0x5e, 0x3a,
+	0x95, 0x67, 0x33, 0x9f, 0x11, 0x03, 0x4d, 0x4b, 0x24, 0x54, 0xbf, 0x00, 0xc8, 0xa9, 0xd8, 0xb5,
+	0x2d, 0xd3, 0xc5, 0x68, 0x13, 0xf2, 0x78, 0xd4, 0xc2, 0x36, 0xf1, 0x72, 0xe6, 0x84, 0xb2, 0x44,
+	0x40, 0x3c, 0x39, 0x5a, 0x9e, 0xfb, 0x72, 0xe8, 0x3f, 0x05, 0x0b, 0x91, 0xc8, 0x27, 0xf0, 0xec,
+	0xde, 0x17, 0x65, 0x68, 0xf4, 0xb2, 0x47, 0x43, 0xa4, 0x93, 0x8a, 0x6b, 0x91, 0xeb, 0xfb, 0x72,
+	0x1c, 0x4f, 0x3f, 0xc7, 0x78, 0x88, 0x4c, 0xd2, 0xe7, 0x78, 0x49, 0x10, 0x7c, 0x8e, 0xa2, 0xd1,
+	0xed, 0x08, 0x11, 0x91, 0x4d, 0x5a, 0x6a, 0x28, 0x77, 0x0f, 0x96, 0x1a, 0x30, 0x11, 0x2f, 0x7b,
+	0x4c, 0xc4, 0x74, 0xd2, 0xa4, 0x45, 0xb2, 0x1a, 0x4c, 0x9a, 0xe1, 0xd1, 0x9b, 0x21, 0x2a, 0x22,
+	0xbf, 0x22, 0xc5, 0x27, 0xd7, 0x7e, 0x0a, 0xea, 0x4b, 0xfb, 0x5c, 0xc4, 0x6b, 0x3e, 0x17, 0x51,
+	0x4c, 0x24, 0x32, 0x44, 0x96, 0xe9, 0x0b, 0x0b, 0x09, 0x54, 0x9f, 0x20, 0x23, 0x38, 0x77, 0x70,
+	0xed, 0x4c, 0x32, 0xc2, 0x57, 0x35, 0xc6, 0x46, 0xd4, 0x27, 0xd8, 0x88, 0x72, 0x92, 0xc6, 0xb1,
+	0x94, 0x36, 0xd0, 0x18, 0xa5, 0x23, 0xfe, 0x3f, 0x9e, 0x8e, 0x48, 0xe4, 0x0b, 0x62, 0xd2, 0x57,
+	0x5f, 0x75, 0x0c, 0x1f, 0xf1, 0x5e, 0x02, 0x1f, 0x21, 0x27, 0xd5, 0xcd, 0x71, 0xc9, 0xab, 0xff,
+	0x81, 0x38, 0x42, 0xe2, 0x30, 0x86, 0x90, 0xe0, 0xcc, 0xc1, 0x73, 0x8f, 0x40, 0x48, 0xf8, 0xaa,
+	0x27, 0x18, 0x89, 0xc3, 0x18, 0x46, 0x02, 0x25, 0xeb, 0x1d, 0xcb, 0xb9, 0xc2, 0x7a, 0x23, 0x43,
+	0xe8, 0xed, 0x28, 0x25, 0x31, 0x77, 0x7a, 0xaa, 0xcb, 0x33, 0x07, 0x5f, 0x5b, 0x98, 0x93, 0x68,
+	0x25, 0x71, 0x12, 0x9c, 0x36, 0x78, 0xe1, 0x11, 0x39, 0x09, 0x5f, 0x77, 0x2c, 0x29, 0x51, 0x9f,
+	0x20, 0x25, 0x16, 0x92, 0x0e, 0xdc, 0x58, 0x40, 0x0a, 0x0e, 0x5c, 0x22, 0x2b, 0x31, 0x25, 0x67,
+	0x77, 0x33, 0xb9, 0x9c, 0x9c, 0xe7, 0x7c, 0xc4, 0x6e, 0x26, 0x57, 0x90, 0x8b, 0xca, 0x73, 0x34,
+	0x6b, 0x1a, 0xf3, 0x7b, 0xb4, 0x46, 0xc1, 0x8e, 0x63, 0x39, 0x82, 0x5f, 0xe0, 0x0d, 0xe5, 0x3a,
+	0x14, 0xc3, 0x2e, 0xee, 0x14, 0x06, 0x63, 0x06, 0x4a, 0x11, 0xaf, 0xa6, 0xfc, 0x2d, 0x05, 0xc5,
+	0xb0, 0xbf, 0x8a, 0xd4, 0xb7, 0x79, 0x51, 0xdf, 0x86, 0x78, 0x8d, 0x54, 0x94, 0xd7, 0x58, 0x86,
+	0x02, 0xad, 0xf1, 0xc6, 0x28, 0x0b, 0xdd, 0xf6, 0x29, 0x8b, 0x1b, 0x30, 0xcb, 0xe2, 0x2d, 0x67,
+	0x3f, 0x44, 0x64, 0xc8, 0xf0, 0xc8, 0x40, 0x07, 0x98, 0x31, 0x78, 0x64, 0x40, 0x2f, 0xc0, 0x5c,
+	0x08, 0xeb, 0xd7, 0x8e, 0x3c, 0xfe, 0xcb, 0x3e, 0xba, 0xca, 0x8b, 0x48, 0xf4, 0xbf, 0x30, 0xd3,
+	0xd3, 0x4d, 0x7a, 0xdc, 0x0d, 0xcb, 0x31, 0x88, 0x81, 0x5d, 0x91, 0x77, 0xad, 0x9f, 0xee, 0x92,
+	0x57, 0xf7, 0x74, 0x13, 0xd7, 0x7d, 0xa1, 0x9a, 0x49, 0x9c, 0x13, 0xb5, 0xdc, 0x8b, 0x74, 0x52,
+	0xaa, 0xa5, 0x8d, 0x3b, 0xfa, 0xa0, 0x47, 0x34, 0x3a, 0xc2, 0xfc, 0x6d, 0x5e, 0x2d, 0x88, 0x3e,
+	0xaa, 0x61, 0xb1, 0x0a, 0x73, 0x31, 0x9a, 0x68, 0xee, 0xf1, 0x10, 0x9f, 0x08, 0xfb, 0xd1, 0x9f,
+	0x68, 0x5e, 0x6c, 0xb5, 0x28, 0x5c, 0x79, 0xe3, 0xb5, 0xd4, 0x2b, 0x92, 0xf2, 0x5b, 0x09, 0x66,
+	0x27, 0x3c, 0x7e, 0x2c, 0xb3, 0x22, 0x7d, 0x5b, 0xcc, 0x4a, 0xea, 0xc9, 0x99, 0x95, 0x70, 0x41,
+	0x9f, 0x8e, 0x16, 0xf4, 0x7f, 0x95, 0xa0, 0x14, 0x89, 0x3c, 0xf4, 0x1c, 0xb5, 0xac, 0x36, 0x16,
+	0x25, 0x36, 0xfb, 0x4d, 0x4d, 0xd3, 0xb3, 0x8e, 0x45, 0x21, 0x4d, 0x7f, 0x52, 0x94, 0x1f, 0x4b,
+	0xf3, 0x22, 0x52, 0xfa, 0xd5, 0x39, 0x4f, 0x7d, 0x78, 0xc3, 0x33, 0x6b, 0x96, 0x7d, 0x37, 0x6a,
+	0x56, 0x9e, 0xc2, 0xf0, 0x06, 0x7a, 0x15, 0xf2, 0xec, 0x1d, 0x45, 0xb3, 0x6c, 0xb7, 0x92, 0x1b,
+	0x4f, 0xef, 0xf8, 0x63, 0xcb, 0xea, 0xf0, 0x16, 0x75, 0x55, 0x56, 0xe7, 0xc0, 0x76, 0xd5, 0x9c,
+	0x2d, 0x7e, 0x85, 0x92, 0xae, 0x7c, 0x24, 0xe9, 0xba, 0x04, 0x79, 0x3a, 0x7d, 0xd7, 0xd6, 0x5b,
+	0xb8, 0x02, 0x6c, 0xa6, 0x41, 0x87, 0xf2, 0x51, 0x1a, 0x66, 0xc6, 0x02, 0x67, 0xec, 0xe2, 0xbd,
+	0x8b, 0x95, 0x0a, 0x11, 0x47, 0x8f, 0x66, 0x90, 0x25, 0x80, 0x63, 0xdd, 0xd5, 0x3e, 0xd4, 0x4d,
+	0x82, 0xdb, 0xc2, 0x2a, 0xa1, 0x1e, 0xb4, 0x08, 0x39, 0xda, 0x1a, 0xb8, 0xb8, 0x2d, 0x38, 0x2c,
+	0xbf, 0x8d, 0x76, 0x20, 0x8b, 0x87, 0xd8, 0x24, 0x6e, 0x65, 0x9a, 0x6d, 0xfc, 0x85, 0x18, 0x0f,
+	0x4b, 0xc7, 0x37, 0x2a, 0x74, 0xbb, 0xff, 0xfc, 0xe5, 0xb2, 0xcc, 0xe1, 0xcf, 0x5b, 0x7d, 0x83,
+	0xe0, 0xbe, 0x4d, 0x4e, 0x54, 0xa1, 0x20, 0x6a, 0x86, 0xdc, 0x98, 0x19, 0xd0, 0x05, 0x98, 0x66,
+	0xb7, 0xd1, 0x68, 0xb3, 0x0c, 0x21, 0xaf, 0x66, 0x69, 0x73, 0x87, 0xcd, 0x4e, 0xdc, 0xd0, 0x13,
+	0x16, 0xf7, 0xd3, 0xaa, 0xdf, 0x0e, 0x91, 0x2b, 0xe5, 0x30, 0xb9, 0x42, 0x65, 0x5c, 0x9a, 0xca,
+	0x9b, 0x2d, 0xcc, 0x42, 0x70, 0x46, 0xf5, 0xdb, 0x8c, 0xb9, 0x2d, 0xaa, 0xa5, 0x3e, 0xee, 0xdb,
+	0x96, 0xd5, 0xd3, 0xb8, 0x07, 0xac, 0x42, 0x39, 0x9a, 0x7e, 0x50, 0xc6, 0xd5, 0xc1, 0x84, 0x52,
+	0x97, 0x91, 0xa2, 0xa4, 0xc8, 0x3b, 0xb9, 0xc7, 0xd9, 0xcd, 0xe4, 0x24, 0x39, 0x25, 0x78, 0xb2,
+	0x77, 0x60, 0x21, 0x36, 0xfb, 0x40, 0xaf, 0x40, 0x3e, 0xc8, 0x5c, 0xa4, 0x95, 0xf4, 0x19, 0x04,
+	0x58, 0x00, 0x56, 0x0e, 0x61, 0x21, 0x36, 0xfd, 0x40, 0x6f, 0x40, 0xd6, 0xc1, 0xee, 0xa0, 0xc7,
+	0x39, 0xae, 0xf2, 0xfa, 0xd5, 0xb3, 0xf3, 0x96, 0x41, 0x8f, 0xa8, 0x42, 0x48, 0xb9, 0x05, 0x17,
+	0x13, 0xf3, 0x8f, 0x80, 0xc6, 0x92, 0x42, 0x34, 0x96, 0xf2, 0x4b, 0x09, 0x16, 0x93, 0x73, 0x0a,
+	0xb4, 0x31, 0x36, 0xa1, 0x1b, 0x8f, 0x98, 0x91, 0x84, 0x66, 0x45, 0xeb, 0x3c, 0x07, 0x77, 0x30,
+	0x69, 0x75, 0x79, 0x72, 0xc3, 0x7d, 0x4d, 0x49, 0x2d, 0x89, 0x5e, 0x26, 0xe3, 0x72, 0xd8, 0xfb,
+	0xb8, 0x45, 0x34, 0xbe, 0xd9, 0x2e, 0x2b, 0x9c, 0xf2, 0x6a, 0x89, 0xf7, 0x36, 0x78, 0xa7, 0x72,
+	0x13, 0x2e, 0x24, 0x64, 0x29, 0x93, 0xd5, 0x9d, 0xf2, 0x80, 0x82, 0x63, 0x53, 0x0f, 0xf4, 0x16,
+	0x64, 0x5d, 0xa2, 0x93, 0x81, 0x2b, 0x56, 0x76, 0xed, 0xcc, 0xac, 0xa5, 0xc1, 0xe0, 0xaa, 0x10,
+	0x53, 0x30, 0xa0, 0xc9, 0x1c, 0x24, 0xa6, 0xa8, 0x95, 0xe2, 0x8a, 0xda, 0xeb, 0x20, 0x8b, 0xa2,
+	0x36, 0x00, 0x72, 0x07, 0x50, 0x66, 0xf5, 0x6c, 0x50, 0xcb, 0x1e, 0xc1, 0x53, 0xa7, 0xe4, 0x25,
+	0x68, 0x73, 0x6c, 0x19, 0x37, 0x1f, 0x29, 0xad, 0x19, 0x5b, 0xca, 0x6f, 0xd2, 0xb0, 0x10, 0x9b,
+	0x9e, 0x84, 0xdc, 0x84, 0xf4, 0x4d, 0xdd, 0xc4, 0x1b, 0x00, 0x64, 0xa4, 0xf1, 0x33, 0xe1, 0x85,
+	0x9b, 0xb8, 0x9a, 0x6c, 0x84, 0x5b, 0xcd, 0x91, 0x38, 0x42, 0x79, 0x22, 0x7e, 0x51, 0x7e, 0x26,
+	0x44, 0x39, 0x0c, 0x58, 0x28, 0x72, 0x2b, 0xe9, 0xc7, 0x0b, 0x5a, 0xf2, 0x30, 0xda, 0xed, 0xa2,
+	0x07, 0x70, 0x61, 0x2c, 0xa4, 0xfa, 0xba, 0x33, 0x8f, 0x1c, 0x59, 0x17, 0xa2, 0x91, 0xd5, 0xd3,
+	0x1d, 0x0e, 0x8b, 0x53, 0x91, 0xb0, 0x48, 0x23, 0x39, 0x2b, 0xba, 0x79, 0x46, 0xd3, 0xc6, 0x3d,
+	0xdd, 0x7b, 0x43, 0xbe, 0x38, 0x51, 0xba, 0xdf, 0x16, 0xcf, 0xec, 0xbc, 0x72, 0xff, 0x31, 0xad,
+	0xdc, 0xcb, 0x54, 0x98, 0x6d, 0xd4, 0x6d, 0x2a, 0xaa, 0x3c, 0x00, 0x08, 0x78, 0x09, 0x7a, 0xd1,
+	0x1d, 0x6b, 0x60, 0xb6, 0xd9, 0x89, 0x98, 0x52, 0x79, 0x83, 0xbe, 0x55, 0xd3, 0x23, 0xe8, 0x59,
+	0x3e, 0xc6, 0x53, 0xd1, 0x13, 0x12, 0x22, 0x36, 0x38, 0x5c, 0x79, 0x1f, 0xd0, 0x24, 0xab, 0x9c,
+	0xf0, 0x8d, 0x37, 0xa3, 0xdf, 0x50, 0x92, 0x09, 0xea, 0xf8, 0x6f, 0x7d, 0x0f, 0xa6, 0xd8, 0x69,
+	0xa2, 0xd1, 0x8e, 0x3d, 0x6a, 0x88, 0x64, 0x93, 0xfe, 0x46, 0xdf, 0x01, 0xd0, 0x09, 0x71, 0x8c,
+	0xa3, 0x41, 0xf0, 0x85, 0x95, 0x84, 0xe3, 0x58, 0xf5, 0x80, 0x1b, 0x97, 0xc4, 0xb9, 0x9c, 0x0f,
+	0x64, 0x43, 0x67, 0x33, 0xa4, 0x51, 0xd9, 0x87, 0x72, 0x54, 0xf6, 0xac, 0x8c, 0x2d, 0xef, 0xa5,
+	0x16, 0x7e, 0x62, 0x92, 0xe6, 0x4f, 0x37, 0xac, 0xa1, 0xfc, 0x3a, 0x05, 0xc5, 0xf0, 0x61, 0xfe,
+	0x57, 0x0c, 0xfe, 0x41, 0x1c, 0xcf, 0x27, 0xc6, 0x71, 0x88, 0xc6, 0x71, 0xe5, 0x07, 0x12, 0xe4,
+	0x7c, 0x9b, 0x45, 0x1f, 0x7d, 0x22, 0xaf, 0x65, 0xdc, 0xe4, 0xa9, 0xf0, 0x4b, 0x0d, 0x7f, 0x1b,
+	0x4b, 0xfb, 0x6f, 0x63, 0xff, 0xed, 0x47, 0xaf, 0x44, 0x4e, 0x26, 0xbc, 0x43, 0xe2, 0x30, 0x7a,
+	0xd1, 0xf4, 0x75, 0xc8, 0xfb, 0x6e, 0x84, 0x96, 0x3a, 0x1e, 0xd7, 0x25, 0x89, 0xbb, 0xcc, 0x9b,
+	0x74, 0x2a, 0xb6, 0xf5, 0xa1, 0x78, 0x07, 0x4a, 0xab, 0xbc, 0xa1, 0xb8, 0x30, 0x33, 0xe6, 0x83,
+	0x02, 0x60, 0x2a, 0x04, 0x44, 0x0a, 0x94, 0xec, 0xc1, 0x91, 0xf6, 0x10, 0x9f, 0x88, 0x57, 0x21,
+	0x3e, 0xfd, 0x82, 0x3d, 0x38, 0xba, 0x83, 0x4f, 0xf8, 0xb3, 0xd0, 0x0a, 0x14, 0x3d, 0x0c, 0xbb,
+	0x16, 0xfc, 0x1c, 0x00, 0x87, 0x34, 0xf9, 0x93, 0x9e, 0x24, 0xa7, 0x94, 0x1f, 0x49, 0x90, 0xf3,
+	0x6e, 0x16, 0x7a, 0x0b, 0xf2, 0xbe, 0xbb, 0x13, 0x65, 0xc2, 0x53, 0xa7, 0x38, 0x4a, 0xb1, 0xf8,
+	0x40, 0x06, 0x6d, 0x78, 0x6f, 0xd3, 0x46, 0x5b, 0xeb, 0xf4, 0xf4, 0x63, 0xf1, 0xc4, 0xb8, 0x14,
+	0xe3, 0x11, 0x99, 0x2f, 0xda, 0xb9, 0xbd, 0xd5, 0xd3, 0x8f, 0xd5, 0x02, 0x13, 0xda, 0x69, 0xd3,
+	0x86, 0x48, 0xa1, 0xfe, 0x94, 0x02, 0x79, 0xfc, 0xe6, 0x7f, 0xf3, 0xf9, 0x4d, 0x86, 0xda, 0x74,
+	0x5c, 0xa8, 0x5d, 0x83, 0x39, 0x1f, 0xa1, 0xb9, 0xc6, 0xb1, 0xa9, 0x93, 0x81, 0x83, 0x05, 0xab,
+	0x8a, 0xfc, 0xa1, 0x86, 0x37, 0x32, 0xb9, 0xee, 0xa9, 0xc7, 0x5e, 0x77, 0x32, 0x69, 0x9d, 0x4d,
+	0x22, 0xad, 0xd1, 0xeb, 0xb0, 0x38, 0x9e, 0x12, 0x84, 0xa6, 0xcb, 0x6b, 0x99, 0x0b, 0xd1, 0xe4,
+	0xc0, 0x9f, 0xb3, 0xb0, 0xf3, 0xc7, 0x29, 0x28, 0x84, 0x48, 0x65, 0xf4, 0x5f, 0x21, 0x37, 0x5a,
+	0x8e, 0x0b, 0x93, 0x21, 0x70, 0xf0, 0x3e, 0x1c, 0xdd, 0x99, 0xd4, 0x13, 0xec, 0x4c, 0x12, 0xe3,
+	0xef, 0xb1, 0xd4, 0x99, 0xc7, 0x66, 0xa9, 0x9f, 0x07, 0x44, 0x2c, 0xa2, 0xf7, 0xa8, 0x39, 0x29,
+	0x9b, 0xcc, 0x2f, 0x12, 0xf7, 0x7a, 0x32, 0x1b, 0x39, 0x64, 0x03, 0x75, 0x76, 0xf9, 0x3e, 0x92,
+	0x20, 0xe7, 0x33, 0x78, 0x8f, 0xfb, 0x6e, 0x7c, 0x1e, 0xb2, 0x22, 0x4d, 0xe5, 0x0f, 0xc7, 0xa2,
+	0x15, 0x4b, 0xc7, 0x2f, 0x42, 0xae, 0x8f, 0x89, 0xce, 0x5c, 0x38, 0x0f, 0xf1, 0x7e, 0xfb, 0xc6,
+	0x11, 0x14, 0x42, 0x4f, 0xef, 0xe8, 0x22, 0x2c, 0x6c, 0x6e, 0xd7, 0x36, 0xef, 0x68, 0xcd, 0x77,
+	0xb5, 0xe6, 0xfd, 0x7a, 0x4d, 0xbb, 0xb7, 0x7f, 0x67, 0xff, 0xe0, 0x7f, 0xf6, 0xe5, 0x73, 0x93,
+	0x43, 0x6a, 0x8d, 0xb5, 0x65, 0x09, 0x5d, 0x80, 0xb9, 0xe8, 0x10, 0x1f, 0x48, 0x2d, 0x66, 0x7e,
+	0xf8, 0xf3, 0xa5, 0x73, 0x37, 0xfe, 0x22, 0xc1, 0x5c, 0x4c, 0x41, 0x80, 0x2e, 0xc3, 0xd3, 0x07,
+	0x5b, 0x5b, 0x35, 0x55, 0x6b, 0xec, 0x57, 0xeb, 0x8d, 0xed, 0x83, 0xa6, 0xa6, 0xd6, 0x1a, 0xf7,
+	0xf6, 0x9a, 0xa1, 0x8f, 0xae, 0xc0, 0xa5, 0x78, 0x48, 0x75, 0x73, 0xb3, 0x56, 0x6f, 0xca, 0x12,
+	0x5a, 0x86, 0xa7, 0x12, 0x10, 0x1b, 0x07, 0x6a, 0x53, 0x4e, 0x25, 0xab, 0x50, 0x6b, 0xbb, 0xb5,
+	0xcd, 0xa6, 0x9c, 0x46, 0xd7, 0xe0, 0xca, 0x69, 0x08, 0x6d, 0xeb, 0x40, 0xbd, 0x5b, 0x6d, 0xca,
+	0x99, 0x33, 0x81, 0x8d, 0xda, 0xfe, 0xed, 0x9a, 0x2a, 0x4f, 0x89, 0x75, 0xff, 0x2c, 0x05, 0x95,
+	0xa4, 0xba, 0x83, 0xea, 0xaa, 0xd6, 0xeb, 0x7b, 0xf7, 0x03, 0x5d, 0x9b, 0xdb, 0xf7, 0xf6, 0xef,
+	0x4c, 0x9a, 0xe0, 0x59, 0x50, 0x4e, 0x03, 0xfa, 0x86, 0xb8, 0x0a, 0x97, 0x4f, 0xc5, 0x09, 0x73,
+	0x9c, 0x01, 0x53, 0x6b, 0x4d, 0xf5, 0xbe, 0x9c, 0x46, 0xab, 0x70, 0xe3, 0x4c, 0x98, 0x3f, 0x26,
+	0x67, 0xd0, 0x1a, 0xdc, 0x3c, 0x1d, 0xcf, 0x0d, 0xe4, 0x09, 0x78, 0x26, 0xfa, 0x44, 0x82, 0x85,
+	0xd8, 0x02, 0x06, 0x5d, 0x81, 0xe5, 0xba, 0x7a, 0xb0, 0x59, 0x6b, 0x34, 0xb4, 0xba, 0x7a, 0x50,
+	0x3f, 0x68, 0x54, 0xf7, 0xb4, 0x46, 0xb3, 0xda, 0xbc, 0xd7, 0x08, 0xd9, 0x46, 0x81, 0xa5, 0x24,
+	0x90, 0x6f, 0x97, 0x53, 0x30, 0xe2, 0x04, 0x78, 0xe7, 0xf4, 0xa7, 0x12, 0x5c, 0x4c, 0x2c, 0x43,
+	0xd0, 0x75, 0x78, 0xe6, 0xb0, 0xa6, 0xee, 0x6c, 0xdd, 0xd7, 0x0e, 0x0f, 0x9a, 0x35, 0xad, 0xf6,
+	0x6e, 0xb3, 0xb6, 0xdf, 0xd8, 0x39, 0xd8, 0x9f, 0x9c, 0xd5, 0x35, 0xb8, 0x72, 0x2a, 0xd2, 0x9f,
+	0xda, 0x59, 0xc0, 0xb1, 0xf9, 0x7d, 0x5f, 0x82, 0x99, 0x31, 0x5f, 0x88, 0x2e, 0x41, 0xe5, 0xee,
+	0x4e, 0x63, 0xa3, 0xb6, 0x5d, 0x3d, 0xdc, 0x39, 0x50, 0xc7, 0xef, 0xec, 0x15, 0x58, 0x9e, 0x18,
+	0xbd, 0x7d, 0xaf, 0xbe, 0xb7, 0xb3, 0x59, 0x6d, 0xd6, 0xd8, 0x47, 0x65, 0x89, 0x2e, 0x6c, 0x02,
+	0xb4, 0xb7, 0xf3, 0xf6, 0x76, 0x53, 0xdb, 0xdc, 0xdb, 0xa9, 0xed, 0x37, 0xb5, 0x6a, 0xb3, 0x59,
+	0x0d, 0xae, 0xf3, 0xc6, 0x9d, 0xcf, 0xbe, 0x5a, 0x92, 0xbe, 0xf8, 0x6a, 0x49, 0xfa, 0xe3, 0x57,
+	0x4b, 0xd2, 0xa7, 0x5f, 0x2f, 0x9d, 0xfb, 0xe2, 0xeb, 0xa5, 0x73, 0xbf, 0xff, 0x7a, 0xe9, 0xdc,
+	0x83, 0x5b, 0xc7, 0x06, 0xe9, 0x0e, 0x8e, 0xa8, 0x17, 0x5e, 0x0b, 0xfe, 0x21, 0xec, 0xfd, 0xd0,
+	0x6d, 0x63, 0x6d, 0xfc, 0x7f, 0xc6, 0x47, 0x59, 0xe6, 0x56, 0x5f, 0xfc, 0xfb, 0x00, 0xfb, 0xff,
+	0x9f, 0xc0, 0x82, 0x2c, 0x00, 0x00,
 }
 
 func (m *Request) Marshal() (dAtA []byte, err error) {
@@ -6005,6 +6059,23 @@ func (m *CheckTxResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Sequence != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Sequence))
+		i--
+		dAtA[i] = 0x78
+	}
+	if len(m.Sender) > 0 {
+		i -= len(m.Sender)
+		copy(dAtA[i:], m.Sender)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Sender)))
+		i--
+		dAtA[i] = 0x72
+	}
+	if m.Priority != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Priority))
+		i--
+		dAtA[i] = 0x68
+	}
 	if len(m.LaneId) > 0 {
 		i -= len(m.LaneId)
 		copy(dAtA[i:], m.LaneId)
@@ -6662,6 +6733,18 @@ func (m *ExecTxResult) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Sequence != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Sequence))
+		i--
+		dAtA[i] = 0x50
+	}
+	if len(m.Sender) > 0 {
+		i -= len(m.Sender)
+		copy(dAtA[i:], m.Sender)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Sender)))
+		i--
+		dAtA[i] = 0x4a
+	}
 	if len(m.Codespace) > 0 {
 		i -= len(m.Codespace)
 		copy(dAtA[i:], m.Codespace)
@@ -8051,6 +8134,16 @@ func (m *CheckTxResponse) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if m.Priority != 0 {
+		n += 1 + sovTypes(uint64(m.Priority))
+	}
+	l = len(m.Sender)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Sequence != 0 {
+		n += 1 + sovTypes(uint64(m.Sequence))
+	}
 	return n
 }
 
@@ -8336,6 +8429,13 @@ func (m *ExecTxResult) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	l = len(m.Sender)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Sequence != 0 {
+		n += 1 + sovTypes(uint64(m.Sequence))
+	}
 	return n
 }
 
@@ -13700,6 +13800,76 @@ func (m *CheckTxResponse) Unmarshal(dAtA []byte) error {
 			}
 			m.LaneId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Priority", wireType)
+			}
+			m.Priority = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Priority |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sender", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Sender = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 15:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sequence", wireType)
+			}
+			m.Sequence = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Sequence |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -15503,6 +15673,57 @@ func (m *ExecTxResult) Unmarshal(dAtA []byte) error {
 			}
 			m.Codespace = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sender", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Sender = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sequence", wireType)
+			}
+			m.Sequence = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Sequence |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -17,6 +17,12 @@ func (m *BlockRequest) Wrap() proto.Message {
 	return bm
 }
 
+func (m *BlockRangeRequest) Wrap() proto.Message {
+	bm := &Message{}
+	bm.Sum = &Message_BlockRangeRequest{BlockRangeRequest: m}
+	return bm
+}
+
 func (m *BlockResponse) Wrap() proto.Message {
 	bm := &Message{}
 	bm.Sum = &Message_BlockResponse{BlockResponse: m}
@@ -48,6 +54,9 @@ func (m *Message) Unwrap() (proto.Message, error) {
 	case *Message_BlockRequest:
 		return m.GetBlockRequest(), nil
 
+	case *Message_BlockRangeRequest:
+		return m.GetBlockRangeRequest(), nil
+
 	case *Message_BlockResponse:
 		return m.GetBlockResponse(), nil
 
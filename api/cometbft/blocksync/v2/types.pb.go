@@ -68,6 +68,61 @@ func (m *BlockRequest) GetHeight() int64 {
 	return 0
 }
 
+// BlockRangeRequest requests the blocks from start_height to
+// start_height+count-1. The peer responds with a BlockResponse or a
+// NoBlockResponse for each of them.
+type BlockRangeRequest struct {
+	StartHeight int64 `protobuf:"varint,1,opt,name=start_height,json=startHeight,proto3" json:"start_height,omitempty"`
+	Count       int64 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (m *BlockRangeRequest) Reset()         { *m = BlockRangeRequest{} }
+func (m *BlockRangeRequest) String() string { return proto.CompactTextString(m) }
+func (*BlockRangeRequest) ProtoMessage()    {}
+func (*BlockRangeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_909d653dec9b4244, []int{1}
+}
+func (m *BlockRangeRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BlockRangeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BlockRangeRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *BlockRangeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockRangeRequest.Merge(m, src)
+}
+func (m *BlockRangeRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *BlockRangeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockRangeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockRangeRequest proto.InternalMessageInfo
+
+func (m *BlockRangeRequest) GetStartHeight() int64 {
+	if m != nil {
+		return m.StartHeight
+	}
+	return 0
+}
+
+func (m *BlockRangeRequest) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
 // NoBlockResponse informs the node that the peer does not have block at the requested height
 type NoBlockResponse struct {
 	Height int64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
@@ -77,7 +132,7 @@ func (m *NoBlockResponse) Reset()         { *m = NoBlockResponse{} }
 func (m *NoBlockResponse) String() string { return proto.CompactTextString(m) }
 func (*NoBlockResponse) ProtoMessage()    {}
 func (*NoBlockResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_909d653dec9b4244, []int{1}
+	return fileDescriptor_909d653dec9b4244, []int{2}
 }
 func (m *NoBlockResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -121,7 +176,7 @@ func (m *StatusRequest) Reset()         { *m = StatusRequest{} }
 func (m *StatusRequest) String() string { return proto.CompactTextString(m) }
 func (*StatusRequest) ProtoMessage()    {}
 func (*StatusRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_909d653dec9b4244, []int{2}
+	return fileDescriptor_909d653dec9b4244, []int{3}
 }
 func (m *StatusRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -154,13 +209,16 @@ var xxx_messageInfo_StatusRequest proto.InternalMessageInfo
 type StatusResponse struct {
 	Height int64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
 	Base   int64 `protobuf:"varint,2,opt,name=base,proto3" json:"base,omitempty"`
+	// The maximum number of blocks the peer serves per BlockRangeRequest, 0 if
+	// it does not support them.
+	MaxBlockRange int64 `protobuf:"varint,3,opt,name=max_block_range,json=maxBlockRange,proto3" json:"max_block_range,omitempty"`
 }
 
 func (m *StatusResponse) Reset()         { *m = StatusResponse{} }
 func (m *StatusResponse) String() string { return proto.CompactTextString(m) }
 func (*StatusResponse) ProtoMessage()    {}
 func (*StatusResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_909d653dec9b4244, []int{3}
+	return fileDescriptor_909d653dec9b4244, []int{4}
 }
 func (m *StatusResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -203,6 +261,13 @@ func (m *StatusResponse) GetBase() int64 {
 	return 0
 }
 
+func (m *StatusResponse) GetMaxBlockRange() int64 {
+	if m != nil {
+		return m.MaxBlockRange
+	}
+	return 0
+}
+
 // BlockResponse returns block to the requested
 type BlockResponse struct {
 	Block     *v2.Block          `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
@@ -213,7 +278,7 @@ func (m *BlockResponse) Reset()         { *m = BlockResponse{} }
 func (m *BlockResponse) String() string { return proto.CompactTextString(m) }
 func (*BlockResponse) ProtoMessage()    {}
 func (*BlockResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_909d653dec9b4244, []int{4}
+	return fileDescriptor_909d653dec9b4244, []int{5}
 }
 func (m *BlockResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -266,6 +331,7 @@ type Message struct {
 	//	*Message_BlockResponse
 	//	*Message_StatusRequest
 	//	*Message_StatusResponse
+	//	*Message_BlockRangeRequest
 	Sum isMessage_Sum `protobuf_oneof:"sum"`
 }
 
@@ -273,7 +339,7 @@ func (m *Message) Reset()         { *m = Message{} }
 func (m *Message) String() string { return proto.CompactTextString(m) }
 func (*Message) ProtoMessage()    {}
 func (*Message) Descriptor() ([]byte, []int) {
-	return fileDescriptor_909d653dec9b4244, []int{5}
+	return fileDescriptor_909d653dec9b4244, []int{6}
 }
 func (m *Message) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -323,12 +389,16 @@ type Message_StatusRequest struct {
 type Message_StatusResponse struct {
 	StatusResponse *StatusResponse `protobuf:"bytes,5,opt,name=status_response,json=statusResponse,proto3,oneof" json:"status_response,omitempty"`
 }
+type Message_BlockRangeRequest struct {
+	BlockRangeRequest *BlockRangeRequest `protobuf:"bytes,6,opt,name=block_range_request,json=blockRangeRequest,proto3,oneof" json:"block_range_request,omitempty"`
+}
 
-func (*Message_BlockRequest) isMessage_Sum()    {}
-func (*Message_NoBlockResponse) isMessage_Sum() {}
-func (*Message_BlockResponse) isMessage_Sum()   {}
-func (*Message_StatusRequest) isMessage_Sum()   {}
-func (*Message_StatusResponse) isMessage_Sum()  {}
+func (*Message_BlockRequest) isMessage_Sum()      {}
+func (*Message_NoBlockResponse) isMessage_Sum()   {}
+func (*Message_BlockResponse) isMessage_Sum()     {}
+func (*Message_StatusRequest) isMessage_Sum()     {}
+func (*Message_StatusResponse) isMessage_Sum()    {}
+func (*Message_BlockRangeRequest) isMessage_Sum() {}
 
 func (m *Message) GetSum() isMessage_Sum {
 	if m != nil {
@@ -372,6 +442,13 @@ func (m *Message) GetStatusResponse() *StatusResponse {
 	return nil
 }
 
+func (m *Message) GetBlockRangeRequest() *BlockRangeRequest {
+	if x, ok := m.GetSum().(*Message_BlockRangeRequest); ok {
+		return x.BlockRangeRequest
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Message) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -380,11 +457,13 @@ func (*Message) XXX_OneofWrappers() []interface{} {
 		(*Message_BlockResponse)(nil),
 		(*Message_StatusRequest)(nil),
 		(*Message_StatusResponse)(nil),
+		(*Message_BlockRangeRequest)(nil),
 	}
 }
 
 func init() {
 	proto.RegisterType((*BlockRequest)(nil), "cometbft.blocksync.v2.BlockRequest")
+	proto.RegisterType((*BlockRangeRequest)(nil), "cometbft.blocksync.v2.BlockRangeRequest")
 	proto.RegisterType((*NoBlockResponse)(nil), "cometbft.blocksync.v2.NoBlockResponse")
 	proto.RegisterType((*StatusRequest)(nil), "cometbft.blocksync.v2.StatusRequest")
 	proto.RegisterType((*StatusResponse)(nil), "cometbft.blocksync.v2.StatusResponse")
@@ -395,33 +474,38 @@ func init() {
 func init() { proto.RegisterFile("cometbft/blocksync/v2/types.proto", fileDescriptor_909d653dec9b4244) }
 
 var fileDescriptor_909d653dec9b4244 = []byte{
-	// 415 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x93, 0xcf, 0x6a, 0xdb, 0x40,
-	0x10, 0xc6, 0xa5, 0xca, 0x76, 0xe9, 0xd8, 0xb2, 0xa8, 0xa0, 0xc5, 0x14, 0x2a, 0x6a, 0xb5, 0x35,
-	0xed, 0x65, 0x05, 0x2a, 0xf4, 0xd4, 0x43, 0x71, 0x08, 0x98, 0x80, 0x83, 0x51, 0x72, 0xca, 0xc5,
-	0x48, 0xf2, 0xc6, 0x16, 0x89, 0xb4, 0x8a, 0x77, 0x65, 0xec, 0x63, 0xde, 0x20, 0xcf, 0x90, 0xa7,
-	0xc9, 0xd1, 0xc7, 0x1c, 0x83, 0xfd, 0x22, 0x41, 0x2b, 0x79, 0x91, 0x85, 0xec, 0xdc, 0x46, 0xb3,
-	0xdf, 0xf7, 0xd3, 0xfc, 0x61, 0xa0, 0xeb, 0x93, 0x10, 0x33, 0xef, 0x9a, 0x59, 0xde, 0x2d, 0xf1,
-	0x6f, 0xe8, 0x2a, 0xf2, 0xad, 0x85, 0x6d, 0xb1, 0x55, 0x8c, 0x29, 0x8a, 0xe7, 0x84, 0x11, 0xfd,
-	0xd3, 0x4e, 0x82, 0x84, 0x04, 0x2d, 0xec, 0x2f, 0x5f, 0x85, 0x93, 0x8b, 0x53, 0x17, 0x7f, 0xcf,
-	0x5c, 0x55, 0xcf, 0x05, 0xa8, 0xd9, 0x83, 0x56, 0x3f, 0x55, 0x3b, 0xf8, 0x2e, 0xc1, 0x94, 0xe9,
-	0x9f, 0xa1, 0x31, 0xc3, 0xc1, 0x74, 0xc6, 0x3a, 0xf2, 0x37, 0xf9, 0x97, 0xe2, 0xe4, 0x5f, 0xe6,
-	0x6f, 0xd0, 0xce, 0x49, 0xae, 0xa4, 0x31, 0x89, 0x28, 0x3e, 0x28, 0xd5, 0x40, 0xbd, 0x60, 0x2e,
-	0x4b, 0x68, 0xce, 0x34, 0xff, 0x41, 0x7b, 0x97, 0x38, 0x6e, 0xd5, 0x75, 0xa8, 0x79, 0x2e, 0xc5,
-	0x9d, 0x77, 0x3c, 0xcb, 0x63, 0xf3, 0x5e, 0x06, 0x75, 0xff, 0xc7, 0x08, 0xea, 0xbc, 0x43, 0x6e,
-	0x6e, 0xda, 0x1d, 0x24, 0x06, 0x93, 0x75, 0xb6, 0xb0, 0x51, 0x66, 0xc8, 0x64, 0xfa, 0x7f, 0x00,
-	0xbc, 0x64, 0x63, 0x9f, 0x84, 0x61, 0xc0, 0x38, 0xbb, 0x69, 0x77, 0x2b, 0x4c, 0xa7, 0x4b, 0x86,
-	0xa3, 0x09, 0x9e, 0x9c, 0x70, 0xa1, 0xf3, 0x01, 0x2f, 0x59, 0x16, 0x9a, 0x8f, 0x0a, 0xbc, 0x1f,
-	0x62, 0x4a, 0xdd, 0x29, 0xd6, 0xcf, 0x40, 0xe5, 0xd8, 0xf1, 0x3c, 0x6b, 0x2f, 0xaf, 0xe2, 0x3b,
-	0xaa, 0x5c, 0x0f, 0x2a, 0x4e, 0x77, 0x20, 0x39, 0x2d, 0xaf, 0x38, 0xed, 0x4b, 0xf8, 0x18, 0x91,
-	0xf1, 0x0e, 0x97, 0xb5, 0x97, 0x17, 0xd8, 0x3b, 0xc0, 0x2b, 0x6d, 0x61, 0x20, 0x39, 0x5a, 0x54,
-	0x5a, 0xcc, 0x10, 0xda, 0x25, 0xa4, 0xc2, 0x91, 0x3f, 0x8e, 0x97, 0x28, 0x80, 0xaa, 0x57, 0xc6,
-	0x51, 0xbe, 0x3e, 0xd1, 0x71, 0xed, 0x28, 0x6e, 0x6f, 0xf9, 0x29, 0x8e, 0x16, 0x13, 0xfa, 0x08,
-	0x34, 0x81, 0xcb, 0xcb, 0xab, 0x73, 0xde, 0xcf, 0x37, 0x78, 0xa2, 0xbe, 0x36, 0xdd, 0xcb, 0xf4,
-	0xeb, 0xa0, 0xd0, 0x24, 0xec, 0x8f, 0x9e, 0x36, 0x86, 0xbc, 0xde, 0x18, 0xf2, 0xcb, 0xc6, 0x90,
-	0x1f, 0xb6, 0x86, 0xb4, 0xde, 0x1a, 0xd2, 0xf3, 0xd6, 0x90, 0xae, 0xfe, 0x4e, 0x03, 0x36, 0x4b,
-	0xbc, 0x94, 0x6f, 0x89, 0x73, 0x10, 0x81, 0x1b, 0x07, 0x56, 0xe5, 0xf5, 0x79, 0x0d, 0x7e, 0x23,
-	0x7f, 0x5e, 0x03, 0x00, 0x00, 0xff, 0xff, 0xb6, 0xec, 0xde, 0x0f, 0x9d, 0x03, 0x00, 0x00,
+	// 485 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x54, 0x4d, 0x8b, 0xd3, 0x40,
+	0x18, 0x4e, 0xcc, 0xb6, 0xe2, 0xdb, 0xa6, 0xa1, 0xe3, 0x07, 0x45, 0x30, 0xd8, 0xa8, 0x65, 0xbd,
+	0x24, 0x10, 0xc1, 0xb3, 0x54, 0x84, 0x22, 0xae, 0x2c, 0xd1, 0xd3, 0x5e, 0x4a, 0x26, 0x1d, 0xdb,
+	0xa2, 0xc9, 0xd4, 0xce, 0xa4, 0x64, 0x8f, 0xfe, 0x03, 0x7f, 0x96, 0xc7, 0x3d, 0x7a, 0x94, 0xf6,
+	0x7f, 0x88, 0x64, 0x66, 0x3a, 0xa6, 0xa1, 0xcd, 0xde, 0x26, 0xef, 0x3c, 0xcf, 0xf3, 0x3e, 0xef,
+	0xc7, 0x04, 0x86, 0x09, 0x4d, 0x09, 0xc7, 0x5f, 0x78, 0x80, 0xbf, 0xd1, 0xe4, 0x2b, 0xbb, 0xce,
+	0x92, 0x60, 0x13, 0x06, 0xfc, 0x7a, 0x45, 0x98, 0xbf, 0x5a, 0x53, 0x4e, 0xd1, 0xc3, 0x3d, 0xc4,
+	0xd7, 0x10, 0x7f, 0x13, 0x3e, 0x7e, 0xa2, 0x99, 0x02, 0x5c, 0xb2, 0xc4, 0xbd, 0x64, 0x1d, 0xbb,
+	0xae, 0x88, 0x7a, 0x23, 0xe8, 0x8e, 0x4b, 0x74, 0x44, 0xbe, 0xe7, 0x84, 0x71, 0xf4, 0x08, 0xda,
+	0x0b, 0xb2, 0x9c, 0x2f, 0xf8, 0xc0, 0x7c, 0x6a, 0x9e, 0x5b, 0x91, 0xfa, 0xf2, 0x3e, 0x40, 0x5f,
+	0xe2, 0xe2, 0x6c, 0x4e, 0xf6, 0xe0, 0x21, 0x74, 0x19, 0x8f, 0xd7, 0x7c, 0x7a, 0x40, 0xe9, 0x88,
+	0xd8, 0x44, 0x84, 0xd0, 0x03, 0x68, 0x25, 0x34, 0xcf, 0xf8, 0xe0, 0x8e, 0xb8, 0x93, 0x1f, 0xde,
+	0x4b, 0x70, 0x3e, 0x52, 0x95, 0x97, 0xad, 0x68, 0xc6, 0xc8, 0xc9, 0xc4, 0x0e, 0xd8, 0x9f, 0x78,
+	0xcc, 0x73, 0xa6, 0x92, 0x7a, 0x33, 0xe8, 0xed, 0x03, 0xcd, 0x54, 0x84, 0xe0, 0x0c, 0xc7, 0x8c,
+	0xa8, 0xd4, 0xe2, 0x8c, 0x46, 0xe0, 0xa4, 0x71, 0x31, 0x15, 0x1d, 0x9a, 0xae, 0xcb, 0x62, 0x06,
+	0x96, 0xb8, 0xb6, 0xd3, 0xb8, 0xf8, 0x5f, 0xa1, 0xf7, 0xc3, 0x04, 0xfb, 0xd0, 0xa0, 0x0f, 0x2d,
+	0xc1, 0x12, 0x49, 0x3a, 0xe1, 0xc0, 0xd7, 0xe3, 0x90, 0xfd, 0xdc, 0x84, 0xbe, 0x24, 0x48, 0x18,
+	0x7a, 0x03, 0x40, 0x0a, 0x3e, 0x4d, 0x68, 0x9a, 0x2e, 0x65, 0xf9, 0x9d, 0x70, 0x78, 0x84, 0xf4,
+	0xae, 0xe0, 0x24, 0x9b, 0x91, 0xd9, 0x5b, 0x01, 0x8c, 0xee, 0x91, 0x82, 0xcb, 0xa3, 0xf7, 0xd7,
+	0x82, 0xbb, 0x17, 0x84, 0xb1, 0x78, 0x4e, 0xd0, 0x7b, 0xb0, 0x95, 0x67, 0xd9, 0x06, 0xe5, 0xe2,
+	0x99, 0x7f, 0x74, 0x29, 0xfc, 0xea, 0x4c, 0x27, 0x46, 0xd4, 0xc5, 0xd5, 0x19, 0x7f, 0x86, 0x7e,
+	0x46, 0xf7, 0x2d, 0x50, 0xe5, 0x29, 0x83, 0xa3, 0x13, 0x7a, 0xb5, 0x69, 0x4d, 0x8c, 0xc8, 0xc9,
+	0x6a, 0x03, 0xbc, 0x80, 0x5e, 0x4d, 0xd2, 0x12, 0x92, 0xcf, 0x9b, 0x2d, 0x6a, 0x41, 0x1b, 0xd7,
+	0xe5, 0x98, 0x18, 0xb3, 0xae, 0xf8, 0xac, 0x51, 0xee, 0x60, 0x49, 0x4a, 0x39, 0x56, 0x0d, 0xa0,
+	0x4b, 0x70, 0xb4, 0x9c, 0xb2, 0xd7, 0x12, 0x7a, 0x2f, 0x6e, 0xd1, 0xd3, 0xfe, 0x7a, 0xec, 0x70,
+	0xeb, 0xae, 0xe0, 0x7e, 0x65, 0x8b, 0xb4, 0xcb, 0xb6, 0x50, 0x3d, 0x6f, 0x2c, 0xba, 0xf2, 0x86,
+	0x26, 0x46, 0xd4, 0xc7, 0xf5, 0xe0, 0xb8, 0x05, 0x16, 0xcb, 0xd3, 0xf1, 0xe5, 0xaf, 0xad, 0x6b,
+	0xde, 0x6c, 0x5d, 0xf3, 0xcf, 0xd6, 0x35, 0x7f, 0xee, 0x5c, 0xe3, 0x66, 0xe7, 0x1a, 0xbf, 0x77,
+	0xae, 0x71, 0xf5, 0x7a, 0xbe, 0xe4, 0x8b, 0x1c, 0x97, 0x59, 0x02, 0xfd, 0xc0, 0xf5, 0x21, 0x5e,
+	0x2d, 0x83, 0xa3, 0xff, 0x13, 0xdc, 0x16, 0xaf, 0xfe, 0xd5, 0xbf, 0x01, 0x00, 0x44, 0xea, 0x31,
+	0xd0, 0x6f, 0x04, 0x00, 0x00,
 }
 
 func (m *BlockRequest) Marshal() (dAtA []byte, err error) {
@@ -452,6 +536,39 @@ func (m *BlockRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *BlockRangeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BlockRangeRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BlockRangeRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Count != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Count))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.StartHeight != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.StartHeight))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *NoBlockResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -523,6 +640,11 @@ func (m *StatusResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.MaxBlockRange != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.MaxBlockRange))
+		i--
+		dAtA[i] = 0x18
+	}
 	if m.Base != 0 {
 		i = encodeVarintTypes(dAtA, i, uint64(m.Base))
 		i--
@@ -720,6 +842,27 @@ func (m *Message_StatusResponse) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	}
 	return len(dAtA) - i, nil
 }
+func (m *Message_BlockRangeRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_BlockRangeRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.BlockRangeRequest != nil {
+		{
+			size, err := m.BlockRangeRequest.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	return len(dAtA) - i, nil
+}
 func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
 	offset -= sovTypes(v)
 	base := offset
@@ -743,6 +886,21 @@ func (m *BlockRequest) Size() (n int) {
 	return n
 }
 
+func (m *BlockRangeRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.StartHeight != 0 {
+		n += 1 + sovTypes(uint64(m.StartHeight))
+	}
+	if m.Count != 0 {
+		n += 1 + sovTypes(uint64(m.Count))
+	}
+	return n
+}
+
 func (m *NoBlockResponse) Size() (n int) {
 	if m == nil {
 		return 0
@@ -776,6 +934,9 @@ func (m *StatusResponse) Size() (n int) {
 	if m.Base != 0 {
 		n += 1 + sovTypes(uint64(m.Base))
 	}
+	if m.MaxBlockRange != 0 {
+		n += 1 + sovTypes(uint64(m.MaxBlockRange))
+	}
 	return n
 }
 
@@ -868,6 +1029,18 @@ func (m *Message_StatusResponse) Size() (n int) {
 	}
 	return n
 }
+func (m *Message_BlockRangeRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.BlockRangeRequest != nil {
+		l = m.BlockRangeRequest.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
 
 func sovTypes(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
@@ -944,6 +1117,94 @@ func (m *BlockRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *BlockRangeRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BlockRangeRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BlockRangeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartHeight", wireType)
+			}
+			m.StartHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StartHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
+			}
+			m.Count = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Count |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *NoBlockResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -1130,6 +1391,25 @@ func (m *StatusResponse) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBlockRange", wireType)
+			}
+			m.MaxBlockRange = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxBlockRange |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -1477,6 +1757,41 @@ func (m *Message) Unmarshal(dAtA []byte) error {
 			}
 			m.Sum = &Message_StatusResponse{v}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockRangeRequest", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &BlockRangeRequest{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Message_BlockRangeRequest{v}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -0,0 +1,286 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: cometbft/privval/v2/service.proto
+
+package v2
+
+import (
+	context "context"
+	fmt "fmt"
+	grpc1 "github.com/cosmos/gogoproto/grpc"
+	proto "github.com/cosmos/gogoproto/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+func init() { proto.RegisterFile("cometbft/privval/v2/service.proto", fileDescriptor_5bfc107d4131c50c) }
+
+var fileDescriptor_5bfc107d4131c50c = []byte{
+	// 293 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x92, 0x4f, 0x4b, 0xc3, 0x30,
+	0x18, 0xc6, 0x5b, 0x14, 0x71, 0xc1, 0x83, 0xc4, 0xdb, 0x0e, 0xd1, 0xf9, 0x1f, 0x84, 0x16, 0xaa,
+	0x5f, 0xc0, 0x5d, 0x44, 0x06, 0x52, 0x54, 0x06, 0xee, 0xd6, 0x6e, 0xaf, 0x35, 0x50, 0x9b, 0x98,
+	0xa4, 0x81, 0x7e, 0x0b, 0x3f, 0x96, 0xc7, 0x81, 0x17, 0x8f, 0xd2, 0x7e, 0x11, 0xe9, 0xda, 0xcc,
+	0x4b, 0xda, 0xdd, 0x4a, 0x9f, 0xdf, 0xf3, 0x7b, 0x20, 0xbc, 0x68, 0x34, 0x67, 0xef, 0xa0, 0xe2,
+	0x57, 0xe5, 0x73, 0x41, 0xb5, 0x8e, 0x52, 0x5f, 0x07, 0xbe, 0x04, 0xa1, 0xe9, 0x1c, 0x3c, 0x2e,
+	0x98, 0x62, 0xf8, 0xc0, 0x20, 0x5e, 0x8b, 0x78, 0x3a, 0x18, 0x1e, 0xda, 0x7a, 0xaa, 0xe0, 0x20,
+	0x9b, 0x56, 0xf0, 0xbd, 0x85, 0xf6, 0x43, 0x41, 0xf5, 0x34, 0x4a, 0xe9, 0x22, 0x52, 0x4c, 0xdc,
+	0x86, 0xf7, 0xf8, 0x19, 0x0d, 0xee, 0x40, 0x85, 0x79, 0x3c, 0x81, 0x02, 0x1f, 0x7b, 0x16, 0xb1,
+	0xd7, 0x84, 0x8f, 0xf0, 0x91, 0x83, 0x54, 0xc3, 0x93, 0x5e, 0x46, 0x72, 0x96, 0x49, 0xc0, 0x2f,
+	0x68, 0xf7, 0x89, 0x26, 0xd9, 0x94, 0x29, 0xc0, 0xa7, 0xd6, 0x82, 0x89, 0x8d, 0xf6, 0xa2, 0x93,
+	0x82, 0x45, 0xc3, 0xb5, 0x6a, 0x40, 0x7b, 0xf5, 0xdf, 0x50, 0x30, 0xce, 0x64, 0x94, 0xe2, 0xcb,
+	0xce, 0xa2, 0x41, 0xcc, 0xc4, 0x55, 0xcf, 0xc4, 0x3f, 0xdb, 0xce, 0xcc, 0xd0, 0xa0, 0x4e, 0xc6,
+	0x85, 0x02, 0x89, 0xcf, 0x3a, 0x9b, 0xab, 0xdc, 0x0c, 0x9c, 0x6f, 0xc2, 0x5a, 0xf7, 0x04, 0x6d,
+	0x87, 0x34, 0x4b, 0xf0, 0x91, 0xfd, 0x29, 0x69, 0x96, 0x18, 0xe3, 0xa8, 0x87, 0x68, 0x64, 0xe3,
+	0x87, 0xaf, 0x92, 0xb8, 0xcb, 0x92, 0xb8, 0xbf, 0x25, 0x71, 0x3f, 0x2b, 0xe2, 0x2c, 0x2b, 0xe2,
+	0xfc, 0x54, 0xc4, 0x99, 0xdd, 0x24, 0x54, 0xbd, 0xe5, 0x71, 0xad, 0xf0, 0xd7, 0xb7, 0xb1, 0xfe,
+	0x88, 0x38, 0xf5, 0x2d, 0x17, 0x13, 0xef, 0xac, 0x8e, 0xe5, 0xfa, 0x6f, 0x00, 0xdd, 0xb5, 0x0e,
+	0x46, 0x87, 0x02, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// PrivValidatorAPIClient is the client API for PrivValidatorAPI service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type PrivValidatorAPIClient interface {
+	// GetPubKey returns the public key of the validator.
+	GetPubKey(ctx context.Context, in *PubKeyRequest, opts ...grpc.CallOption) (*PubKeyResponse, error)
+	// SignVote signs a vote.
+	SignVote(ctx context.Context, in *SignVoteRequest, opts ...grpc.CallOption) (*SignedVoteResponse, error)
+	// SignProposal signs a proposal.
+	SignProposal(ctx context.Context, in *SignProposalRequest, opts ...grpc.CallOption) (*SignedProposalResponse, error)
+	// SignBytes signs arbitrary bytes.
+	SignBytes(ctx context.Context, in *SignBytesRequest, opts ...grpc.CallOption) (*SignBytesResponse, error)
+	// Ping checks that the signer is up.
+	Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error)
+}
+
+type privValidatorAPIClient struct {
+	cc grpc1.ClientConn
+}
+
+func NewPrivValidatorAPIClient(cc grpc1.ClientConn) PrivValidatorAPIClient {
+	return &privValidatorAPIClient{cc}
+}
+
+func (c *privValidatorAPIClient) GetPubKey(ctx context.Context, in *PubKeyRequest, opts ...grpc.CallOption) (*PubKeyResponse, error) {
+	out := new(PubKeyResponse)
+	err := c.cc.Invoke(ctx, "/cometbft.privval.v2.PrivValidatorAPI/GetPubKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *privValidatorAPIClient) SignVote(ctx context.Context, in *SignVoteRequest, opts ...grpc.CallOption) (*SignedVoteResponse, error) {
+	out := new(SignedVoteResponse)
+	err := c.cc.Invoke(ctx, "/cometbft.privval.v2.PrivValidatorAPI/SignVote", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *privValidatorAPIClient) SignProposal(ctx context.Context, in *SignProposalRequest, opts ...grpc.CallOption) (*SignedProposalResponse, error) {
+	out := new(SignedProposalResponse)
+	err := c.cc.Invoke(ctx, "/cometbft.privval.v2.PrivValidatorAPI/SignProposal", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *privValidatorAPIClient) SignBytes(ctx context.Context, in *SignBytesRequest, opts ...grpc.CallOption) (*SignBytesResponse, error) {
+	out := new(SignBytesResponse)
+	err := c.cc.Invoke(ctx, "/cometbft.privval.v2.PrivValidatorAPI/SignBytes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *privValidatorAPIClient) Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error) {
+	out := new(PingResponse)
+	err := c.cc.Invoke(ctx, "/cometbft.privval.v2.PrivValidatorAPI/Ping", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PrivValidatorAPIServer is the server API for PrivValidatorAPI service.
+type PrivValidatorAPIServer interface {
+	// GetPubKey returns the public key of the validator.
+	GetPubKey(context.Context, *PubKeyRequest) (*PubKeyResponse, error)
+	// SignVote signs a vote.
+	SignVote(context.Context, *SignVoteRequest) (*SignedVoteResponse, error)
+	// SignProposal signs a proposal.
+	SignProposal(context.Context, *SignProposalRequest) (*SignedProposalResponse, error)
+	// SignBytes signs arbitrary bytes.
+	SignBytes(context.Context, *SignBytesRequest) (*SignBytesResponse, error)
+	// Ping checks that the signer is up.
+	Ping(context.Context, *PingRequest) (*PingResponse, error)
+}
+
+// UnimplementedPrivValidatorAPIServer can be embedded to have forward compatible implementations.
+type UnimplementedPrivValidatorAPIServer struct {
+}
+
+func (*UnimplementedPrivValidatorAPIServer) GetPubKey(ctx context.Context, req *PubKeyRequest) (*PubKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPubKey not implemented")
+}
+func (*UnimplementedPrivValidatorAPIServer) SignVote(ctx context.Context, req *SignVoteRequest) (*SignedVoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignVote not implemented")
+}
+func (*UnimplementedPrivValidatorAPIServer) SignProposal(ctx context.Context, req *SignProposalRequest) (*SignedProposalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignProposal not implemented")
+}
+func (*UnimplementedPrivValidatorAPIServer) SignBytes(ctx context.Context, req *SignBytesRequest) (*SignBytesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignBytes not implemented")
+}
+func (*UnimplementedPrivValidatorAPIServer) Ping(ctx context.Context, req *PingRequest) (*PingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
+}
+
+func RegisterPrivValidatorAPIServer(s grpc1.Server, srv PrivValidatorAPIServer) {
+	s.RegisterService(&_PrivValidatorAPI_serviceDesc, srv)
+}
+
+func _PrivValidatorAPI_GetPubKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PubKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrivValidatorAPIServer).GetPubKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cometbft.privval.v2.PrivValidatorAPI/GetPubKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrivValidatorAPIServer).GetPubKey(ctx, req.(*PubKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PrivValidatorAPI_SignVote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignVoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrivValidatorAPIServer).SignVote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cometbft.privval.v2.PrivValidatorAPI/SignVote",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrivValidatorAPIServer).SignVote(ctx, req.(*SignVoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PrivValidatorAPI_SignProposal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignProposalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrivValidatorAPIServer).SignProposal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cometbft.privval.v2.PrivValidatorAPI/SignProposal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrivValidatorAPIServer).SignProposal(ctx, req.(*SignProposalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PrivValidatorAPI_SignBytes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignBytesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrivValidatorAPIServer).SignBytes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cometbft.privval.v2.PrivValidatorAPI/SignBytes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrivValidatorAPIServer).SignBytes(ctx, req.(*SignBytesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PrivValidatorAPI_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrivValidatorAPIServer).Ping(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cometbft.privval.v2.PrivValidatorAPI/Ping",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrivValidatorAPIServer).Ping(ctx, req.(*PingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var PrivValidatorAPI_serviceDesc = _PrivValidatorAPI_serviceDesc
+var _PrivValidatorAPI_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "cometbft.privval.v2.PrivValidatorAPI",
+	HandlerType: (*PrivValidatorAPIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetPubKey",
+			Handler:    _PrivValidatorAPI_GetPubKey_Handler,
+		},
+		{
+			MethodName: "SignVote",
+			Handler:    _PrivValidatorAPI_SignVote_Handler,
+		},
+		{
+			MethodName: "SignProposal",
+			Handler:    _PrivValidatorAPI_SignProposal_Handler,
+		},
+		{
+			MethodName: "SignBytes",
+			Handler:    _PrivValidatorAPI_SignBytes_Handler,
+		},
+		{
+			MethodName: "Ping",
+			Handler:    _PrivValidatorAPI_Ping_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "cometbft/privval/v2/service.proto",
+}
@@ -0,0 +1,247 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: cometbft/services/mempool/v1/mempool_service.proto
+
+package v1
+
+import (
+	context "context"
+	fmt "fmt"
+	grpc1 "github.com/cosmos/gogoproto/grpc"
+	proto "github.com/cosmos/gogoproto/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+func init() {
+	proto.RegisterFile("cometbft/services/mempool/v1/mempool_service.proto", fileDescriptor_f8560b1ab7181466)
+}
+
+var fileDescriptor_f8560b1ab7181466 = []byte{
+	// 247 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x32, 0x4a, 0xce, 0xcf, 0x4d,
+	0x2d, 0x49, 0x4a, 0x2b, 0xd1, 0x2f, 0x4e, 0x2d, 0x2a, 0xcb, 0x4c, 0x4e, 0x2d, 0xd6, 0xcf, 0x4d,
+	0xcd, 0x2d, 0xc8, 0xcf, 0xcf, 0xd1, 0x2f, 0x33, 0x84, 0x31, 0xe3, 0xa1, 0x72, 0x7a, 0x05, 0x45,
+	0xf9, 0x25, 0xf9, 0x42, 0x32, 0x30, 0x3d, 0x7a, 0x30, 0x3d, 0x7a, 0x50, 0x85, 0x7a, 0x65, 0x86,
+	0x52, 0x5a, 0xc4, 0x98, 0x08, 0x31, 0xc9, 0xe8, 0x13, 0x13, 0x17, 0x9f, 0x2f, 0x44, 0x24, 0x18,
+	0xa2, 0x58, 0xa8, 0x85, 0x91, 0x4b, 0xd0, 0x3d, 0xb5, 0x24, 0x34, 0x2f, 0x39, 0x3f, 0x2f, 0x2d,
+	0xb3, 0x28, 0x37, 0x35, 0x25, 0xa4, 0xa2, 0x58, 0xc8, 0x4c, 0x0f, 0x9f, 0x9d, 0x7a, 0x18, 0x1a,
+	0x82, 0x52, 0x0b, 0x4b, 0x53, 0x8b, 0x4b, 0xa4, 0xcc, 0x49, 0xd6, 0x57, 0x5c, 0x90, 0x9f, 0x57,
+	0x9c, 0x2a, 0x94, 0xc3, 0xc5, 0xe9, 0x9e, 0x5a, 0x12, 0x5c, 0x92, 0x58, 0x52, 0x5a, 0x2c, 0xa4,
+	0x47, 0xd0, 0x14, 0x88, 0x42, 0x98, 0xad, 0xfa, 0x44, 0xab, 0x87, 0xda, 0x96, 0xcd, 0xc5, 0x11,
+	0x9e, 0x58, 0x92, 0x9c, 0x01, 0xf2, 0xaa, 0x2e, 0x7e, 0xcd, 0x30, 0x75, 0x30, 0xbb, 0xf4, 0x88,
+	0x55, 0x0e, 0xb1, 0xca, 0x80, 0xd1, 0x29, 0xfc, 0xc4, 0x23, 0x39, 0xc6, 0x0b, 0x8f, 0xe4, 0x18,
+	0x1f, 0x3c, 0x92, 0x63, 0x9c, 0xf0, 0x58, 0x8e, 0xe1, 0xc2, 0x63, 0x39, 0x86, 0x1b, 0x8f, 0xe5,
+	0x18, 0xa2, 0x6c, 0xd3, 0x33, 0x4b, 0x32, 0x4a, 0x93, 0x40, 0x26, 0xea, 0xc3, 0x63, 0x11, 0xce,
+	0x48, 0x2c, 0xc8, 0xd4, 0xc7, 0x17, 0xb7, 0x49, 0x6c, 0xe0, 0x48, 0x35, 0x06, 0x0c, 0x00, 0xe0,
+	0x45, 0x7f, 0x3e, 0x54, 0x02, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// MempoolServiceClient is the client API for MempoolService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type MempoolServiceClient interface {
+	// GetUnconfirmedTxs returns the transactions in the mempool, in the order in
+	// which they would be reaped to build a block.
+	GetUnconfirmedTxs(ctx context.Context, in *GetUnconfirmedTxsRequest, opts ...grpc.CallOption) (*GetUnconfirmedTxsResponse, error)
+	// GetStatus returns the size of the mempool, and the occupancy of its lanes.
+	GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*GetStatusResponse, error)
+	// WatchTxs returns a stream of the transactions admitted to and evicted
+	// from the mempool. This is a long-lived stream that is only terminated by
+	// the server if an error occurs, e.g. if the client does not keep up with
+	// the transactions. The caller is expected to handle such disconnections and
+	// reconnect.
+	WatchTxs(ctx context.Context, in *WatchTxsRequest, opts ...grpc.CallOption) (MempoolService_WatchTxsClient, error)
+}
+
+type mempoolServiceClient struct {
+	cc grpc1.ClientConn
+}
+
+func NewMempoolServiceClient(cc grpc1.ClientConn) MempoolServiceClient {
+	return &mempoolServiceClient{cc}
+}
+
+func (c *mempoolServiceClient) GetUnconfirmedTxs(ctx context.Context, in *GetUnconfirmedTxsRequest, opts ...grpc.CallOption) (*GetUnconfirmedTxsResponse, error) {
+	out := new(GetUnconfirmedTxsResponse)
+	err := c.cc.Invoke(ctx, "/cometbft.services.mempool.v1.MempoolService/GetUnconfirmedTxs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mempoolServiceClient) GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*GetStatusResponse, error) {
+	out := new(GetStatusResponse)
+	err := c.cc.Invoke(ctx, "/cometbft.services.mempool.v1.MempoolService/GetStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mempoolServiceClient) WatchTxs(ctx context.Context, in *WatchTxsRequest, opts ...grpc.CallOption) (MempoolService_WatchTxsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_MempoolService_serviceDesc.Streams[0], "/cometbft.services.mempool.v1.MempoolService/WatchTxs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &mempoolServiceWatchTxsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type MempoolService_WatchTxsClient interface {
+	Recv() (*WatchTxsResponse, error)
+	grpc.ClientStream
+}
+
+type mempoolServiceWatchTxsClient struct {
+	grpc.ClientStream
+}
+
+func (x *mempoolServiceWatchTxsClient) Recv() (*WatchTxsResponse, error) {
+	m := new(WatchTxsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// MempoolServiceServer is the server API for MempoolService service.
+type MempoolServiceServer interface {
+	// GetUnconfirmedTxs returns the transactions in the mempool, in the order in
+	// which they would be reaped to build a block.
+	GetUnconfirmedTxs(context.Context, *GetUnconfirmedTxsRequest) (*GetUnconfirmedTxsResponse, error)
+	// GetStatus returns the size of the mempool, and the occupancy of its lanes.
+	GetStatus(context.Context, *GetStatusRequest) (*GetStatusResponse, error)
+	// WatchTxs returns a stream of the transactions admitted to and evicted
+	// from the mempool. This is a long-lived stream that is only terminated by
+	// the server if an error occurs, e.g. if the client does not keep up with
+	// the transactions. The caller is expected to handle such disconnections and
+	// reconnect.
+	WatchTxs(*WatchTxsRequest, MempoolService_WatchTxsServer) error
+}
+
+// UnimplementedMempoolServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedMempoolServiceServer struct {
+}
+
+func (*UnimplementedMempoolServiceServer) GetUnconfirmedTxs(ctx context.Context, req *GetUnconfirmedTxsRequest) (*GetUnconfirmedTxsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUnconfirmedTxs not implemented")
+}
+func (*UnimplementedMempoolServiceServer) GetStatus(ctx context.Context, req *GetStatusRequest) (*GetStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
+}
+func (*UnimplementedMempoolServiceServer) WatchTxs(req *WatchTxsRequest, srv MempoolService_WatchTxsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchTxs not implemented")
+}
+
+func RegisterMempoolServiceServer(s grpc1.Server, srv MempoolServiceServer) {
+	s.RegisterService(&_MempoolService_serviceDesc, srv)
+}
+
+func _MempoolService_GetUnconfirmedTxs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUnconfirmedTxsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MempoolServiceServer).GetUnconfirmedTxs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cometbft.services.mempool.v1.MempoolService/GetUnconfirmedTxs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MempoolServiceServer).GetUnconfirmedTxs(ctx, req.(*GetUnconfirmedTxsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MempoolService_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MempoolServiceServer).GetStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cometbft.services.mempool.v1.MempoolService/GetStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MempoolServiceServer).GetStatus(ctx, req.(*GetStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MempoolService_WatchTxs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchTxsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MempoolServiceServer).WatchTxs(m, &mempoolServiceWatchTxsServer{stream})
+}
+
+type MempoolService_WatchTxsServer interface {
+	Send(*WatchTxsResponse) error
+	grpc.ServerStream
+}
+
+type mempoolServiceWatchTxsServer struct {
+	grpc.ServerStream
+}
+
+func (x *mempoolServiceWatchTxsServer) Send(m *WatchTxsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var MempoolService_serviceDesc = _MempoolService_serviceDesc
+var _MempoolService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "cometbft.services.mempool.v1.MempoolService",
+	HandlerType: (*MempoolServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetUnconfirmedTxs",
+			Handler:    _MempoolService_GetUnconfirmedTxs_Handler,
+		},
+		{
+			MethodName: "GetStatus",
+			Handler:    _MempoolService_GetStatus_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchTxs",
+			Handler:       _MempoolService_WatchTxs_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "cometbft/services/mempool/v1/mempool_service.proto",
+}
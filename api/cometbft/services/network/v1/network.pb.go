@@ -0,0 +1,2182 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: cometbft/services/network/v1/network.proto
+
+package v1
+
+import (
+	fmt "fmt"
+	v1 "github.com/cometbft/cometbft/api/cometbft/p2p/v1"
+	_ "github.com/cosmos/gogoproto/gogoproto"
+	proto "github.com/cosmos/gogoproto/proto"
+	_ "github.com/cosmos/gogoproto/types"
+	github_com_cosmos_gogoproto_types "github.com/cosmos/gogoproto/types"
+	_ "github.com/golang/protobuf/ptypes/duration"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+	time "time"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+var _ = time.Kitchen
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// GetNetInfoRequest - empty message since no parameter is required
+type GetNetInfoRequest struct {
+}
+
+func (m *GetNetInfoRequest) Reset()         { *m = GetNetInfoRequest{} }
+func (m *GetNetInfoRequest) String() string { return proto.CompactTextString(m) }
+func (*GetNetInfoRequest) ProtoMessage()    {}
+func (*GetNetInfoRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_01781f2e7f4f5406, []int{0}
+}
+func (m *GetNetInfoRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetNetInfoRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetNetInfoRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetNetInfoRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetNetInfoRequest.Merge(m, src)
+}
+func (m *GetNetInfoRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetNetInfoRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetNetInfoRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetNetInfoRequest proto.InternalMessageInfo
+
+// GetNetInfoResponse contains the listening addresses and the peers of the
+// node.
+type GetNetInfoResponse struct {
+	Listening bool     `protobuf:"varint,1,opt,name=listening,proto3" json:"listening,omitempty"`
+	Listeners []string `protobuf:"bytes,2,rep,name=listeners,proto3" json:"listeners,omitempty"`
+	Peers     []*Peer  `protobuf:"bytes,3,rep,name=peers,proto3" json:"peers,omitempty"`
+}
+
+func (m *GetNetInfoResponse) Reset()         { *m = GetNetInfoResponse{} }
+func (m *GetNetInfoResponse) String() string { return proto.CompactTextString(m) }
+func (*GetNetInfoResponse) ProtoMessage()    {}
+func (*GetNetInfoResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_01781f2e7f4f5406, []int{1}
+}
+func (m *GetNetInfoResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetNetInfoResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetNetInfoResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetNetInfoResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetNetInfoResponse.Merge(m, src)
+}
+func (m *GetNetInfoResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetNetInfoResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetNetInfoResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetNetInfoResponse proto.InternalMessageInfo
+
+func (m *GetNetInfoResponse) GetListening() bool {
+	if m != nil {
+		return m.Listening
+	}
+	return false
+}
+
+func (m *GetNetInfoResponse) GetListeners() []string {
+	if m != nil {
+		return m.Listeners
+	}
+	return nil
+}
+
+func (m *GetNetInfoResponse) GetPeers() []*Peer {
+	if m != nil {
+		return m.Peers
+	}
+	return nil
+}
+
+// Peer contains information about a peer and the connection to it.
+type Peer struct {
+	NodeInfo   *v1.DefaultNodeInfo `protobuf:"bytes,1,opt,name=node_info,json=nodeInfo,proto3" json:"node_info,omitempty"`
+	IsOutbound bool                `protobuf:"varint,2,opt,name=is_outbound,json=isOutbound,proto3" json:"is_outbound,omitempty"`
+	RemoteIp   string              `protobuf:"bytes,3,opt,name=remote_ip,json=remoteIp,proto3" json:"remote_ip,omitempty"`
+	// How long the peer has been connected for.
+	ConnectedFor time.Duration `protobuf:"bytes,4,opt,name=connected_for,json=connectedFor,proto3,stdduration" json:"connected_for"`
+	// The delays imposed by the rate limiters of the connection.
+	SendRateLimiterDelay time.Duration `protobuf:"bytes,5,opt,name=send_rate_limiter_delay,json=sendRateLimiterDelay,proto3,stdduration" json:"send_rate_limiter_delay"`
+	RecvRateLimiterDelay time.Duration `protobuf:"bytes,6,opt,name=recv_rate_limiter_delay,json=recvRateLimiterDelay,proto3,stdduration" json:"recv_rate_limiter_delay"`
+}
+
+func (m *Peer) Reset()         { *m = Peer{} }
+func (m *Peer) String() string { return proto.CompactTextString(m) }
+func (*Peer) ProtoMessage()    {}
+func (*Peer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_01781f2e7f4f5406, []int{2}
+}
+func (m *Peer) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Peer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Peer.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Peer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Peer.Merge(m, src)
+}
+func (m *Peer) XXX_Size() int {
+	return m.Size()
+}
+func (m *Peer) XXX_DiscardUnknown() {
+	xxx_messageInfo_Peer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Peer proto.InternalMessageInfo
+
+func (m *Peer) GetNodeInfo() *v1.DefaultNodeInfo {
+	if m != nil {
+		return m.NodeInfo
+	}
+	return nil
+}
+
+func (m *Peer) GetIsOutbound() bool {
+	if m != nil {
+		return m.IsOutbound
+	}
+	return false
+}
+
+func (m *Peer) GetRemoteIp() string {
+	if m != nil {
+		return m.RemoteIp
+	}
+	return ""
+}
+
+func (m *Peer) GetConnectedFor() time.Duration {
+	if m != nil {
+		return m.ConnectedFor
+	}
+	return 0
+}
+
+func (m *Peer) GetSendRateLimiterDelay() time.Duration {
+	if m != nil {
+		return m.SendRateLimiterDelay
+	}
+	return 0
+}
+
+func (m *Peer) GetRecvRateLimiterDelay() time.Duration {
+	if m != nil {
+		return m.RecvRateLimiterDelay
+	}
+	return 0
+}
+
+// GetConsensusStateRequest - empty message since no parameter is required
+type GetConsensusStateRequest struct {
+}
+
+func (m *GetConsensusStateRequest) Reset()         { *m = GetConsensusStateRequest{} }
+func (m *GetConsensusStateRequest) String() string { return proto.CompactTextString(m) }
+func (*GetConsensusStateRequest) ProtoMessage()    {}
+func (*GetConsensusStateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_01781f2e7f4f5406, []int{3}
+}
+func (m *GetConsensusStateRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetConsensusStateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetConsensusStateRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetConsensusStateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConsensusStateRequest.Merge(m, src)
+}
+func (m *GetConsensusStateRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetConsensusStateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetConsensusStateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetConsensusStateRequest proto.InternalMessageInfo
+
+// GetConsensusStateResponse contains a summary of the consensus state.
+type GetConsensusStateResponse struct {
+	Height int64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Round  int32 `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	// The step of the round (e.g. "RoundStepPropose").
+	Step      string    `protobuf:"bytes,3,opt,name=step,proto3" json:"step,omitempty"`
+	StartTime time.Time `protobuf:"bytes,4,opt,name=start_time,json=startTime,proto3,stdtime" json:"start_time"`
+	// The hashes of the proposal, locked and valid blocks, if any.
+	ProposalBlockHash []byte `protobuf:"bytes,5,opt,name=proposal_block_hash,json=proposalBlockHash,proto3" json:"proposal_block_hash,omitempty"`
+	LockedBlockHash   []byte `protobuf:"bytes,6,opt,name=locked_block_hash,json=lockedBlockHash,proto3" json:"locked_block_hash,omitempty"`
+	LockedRound       int32  `protobuf:"varint,7,opt,name=locked_round,json=lockedRound,proto3" json:"locked_round,omitempty"`
+	ValidBlockHash    []byte `protobuf:"bytes,8,opt,name=valid_block_hash,json=validBlockHash,proto3" json:"valid_block_hash,omitempty"`
+	ValidRound        int32  `protobuf:"varint,9,opt,name=valid_round,json=validRound,proto3" json:"valid_round,omitempty"`
+	// The proposer of the current round.
+	ProposerAddress []byte `protobuf:"bytes,10,opt,name=proposer_address,json=proposerAddress,proto3" json:"proposer_address,omitempty"`
+	ProposerIndex   int32  `protobuf:"varint,11,opt,name=proposer_index,json=proposerIndex,proto3" json:"proposer_index,omitempty"`
+	// The votes received for each round of the current height.
+	Votes []*RoundVotes `protobuf:"bytes,12,rep,name=votes,proto3" json:"votes,omitempty"`
+}
+
+func (m *GetConsensusStateResponse) Reset()         { *m = GetConsensusStateResponse{} }
+func (m *GetConsensusStateResponse) String() string { return proto.CompactTextString(m) }
+func (*GetConsensusStateResponse) ProtoMessage()    {}
+func (*GetConsensusStateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_01781f2e7f4f5406, []int{4}
+}
+func (m *GetConsensusStateResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetConsensusStateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetConsensusStateResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetConsensusStateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConsensusStateResponse.Merge(m, src)
+}
+func (m *GetConsensusStateResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetConsensusStateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetConsensusStateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetConsensusStateResponse proto.InternalMessageInfo
+
+func (m *GetConsensusStateResponse) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *GetConsensusStateResponse) GetRound() int32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *GetConsensusStateResponse) GetStep() string {
+	if m != nil {
+		return m.Step
+	}
+	return ""
+}
+
+func (m *GetConsensusStateResponse) GetStartTime() time.Time {
+	if m != nil {
+		return m.StartTime
+	}
+	return time.Time{}
+}
+
+func (m *GetConsensusStateResponse) GetProposalBlockHash() []byte {
+	if m != nil {
+		return m.ProposalBlockHash
+	}
+	return nil
+}
+
+func (m *GetConsensusStateResponse) GetLockedBlockHash() []byte {
+	if m != nil {
+		return m.LockedBlockHash
+	}
+	return nil
+}
+
+func (m *GetConsensusStateResponse) GetLockedRound() int32 {
+	if m != nil {
+		return m.LockedRound
+	}
+	return 0
+}
+
+func (m *GetConsensusStateResponse) GetValidBlockHash() []byte {
+	if m != nil {
+		return m.ValidBlockHash
+	}
+	return nil
+}
+
+func (m *GetConsensusStateResponse) GetValidRound() int32 {
+	if m != nil {
+		return m.ValidRound
+	}
+	return 0
+}
+
+func (m *GetConsensusStateResponse) GetProposerAddress() []byte {
+	if m != nil {
+		return m.ProposerAddress
+	}
+	return nil
+}
+
+func (m *GetConsensusStateResponse) GetProposerIndex() int32 {
+	if m != nil {
+		return m.ProposerIndex
+	}
+	return 0
+}
+
+func (m *GetConsensusStateResponse) GetVotes() []*RoundVotes {
+	if m != nil {
+		return m.Votes
+	}
+	return nil
+}
+
+// RoundVotes contains the votes received for a round.
+type RoundVotes struct {
+	Round int32 `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
+	// The validators that have prevoted and precommitted, and the fraction of
+	// the voting power they represent, e.g.
+	// "BA{4:xx_x} 30/40 = 0.75".
+	PrevotesBitArray   string `protobuf:"bytes,2,opt,name=prevotes_bit_array,json=prevotesBitArray,proto3" json:"prevotes_bit_array,omitempty"`
+	PrecommitsBitArray string `protobuf:"bytes,3,opt,name=precommits_bit_array,json=precommitsBitArray,proto3" json:"precommits_bit_array,omitempty"`
+	// The hash of the block that received +2/3 of the prevotes or precommits.
+	// Empty if no block did, including if +2/3 voted for nil.
+	PrevotesMaj23Hash   []byte `protobuf:"bytes,4,opt,name=prevotes_maj23_hash,json=prevotesMaj23Hash,proto3" json:"prevotes_maj23_hash,omitempty"`
+	PrecommitsMaj23Hash []byte `protobuf:"bytes,5,opt,name=precommits_maj23_hash,json=precommitsMaj23Hash,proto3" json:"precommits_maj23_hash,omitempty"`
+}
+
+func (m *RoundVotes) Reset()         { *m = RoundVotes{} }
+func (m *RoundVotes) String() string { return proto.CompactTextString(m) }
+func (*RoundVotes) ProtoMessage()    {}
+func (*RoundVotes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_01781f2e7f4f5406, []int{5}
+}
+func (m *RoundVotes) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RoundVotes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RoundVotes.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RoundVotes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RoundVotes.Merge(m, src)
+}
+func (m *RoundVotes) XXX_Size() int {
+	return m.Size()
+}
+func (m *RoundVotes) XXX_DiscardUnknown() {
+	xxx_messageInfo_RoundVotes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RoundVotes proto.InternalMessageInfo
+
+func (m *RoundVotes) GetRound() int32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *RoundVotes) GetPrevotesBitArray() string {
+	if m != nil {
+		return m.PrevotesBitArray
+	}
+	return ""
+}
+
+func (m *RoundVotes) GetPrecommitsBitArray() string {
+	if m != nil {
+		return m.PrecommitsBitArray
+	}
+	return ""
+}
+
+func (m *RoundVotes) GetPrevotesMaj23Hash() []byte {
+	if m != nil {
+		return m.PrevotesMaj23Hash
+	}
+	return nil
+}
+
+func (m *RoundVotes) GetPrecommitsMaj23Hash() []byte {
+	if m != nil {
+		return m.PrecommitsMaj23Hash
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*GetNetInfoRequest)(nil), "cometbft.services.network.v1.GetNetInfoRequest")
+	proto.RegisterType((*GetNetInfoResponse)(nil), "cometbft.services.network.v1.GetNetInfoResponse")
+	proto.RegisterType((*Peer)(nil), "cometbft.services.network.v1.Peer")
+	proto.RegisterType((*GetConsensusStateRequest)(nil), "cometbft.services.network.v1.GetConsensusStateRequest")
+	proto.RegisterType((*GetConsensusStateResponse)(nil), "cometbft.services.network.v1.GetConsensusStateResponse")
+	proto.RegisterType((*RoundVotes)(nil), "cometbft.services.network.v1.RoundVotes")
+}
+
+func init() {
+	proto.RegisterFile("cometbft/services/network/v1/network.proto", fileDescriptor_01781f2e7f4f5406)
+}
+
+var fileDescriptor_01781f2e7f4f5406 = []byte{
+	// 789 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x55, 0xcf, 0x6f, 0x1b, 0x45,
+	0x14, 0xce, 0xd6, 0x71, 0xf0, 0x3e, 0xa7, 0x25, 0x99, 0x04, 0xd8, 0xa6, 0x95, 0x6d, 0x2c, 0x21,
+	0x99, 0x0a, 0xed, 0x52, 0xf7, 0xc2, 0xa5, 0x48, 0x75, 0x23, 0xda, 0x48, 0x50, 0xd0, 0x82, 0x40,
+	0xea, 0x65, 0x35, 0xf6, 0x3e, 0xdb, 0x43, 0x77, 0x77, 0x86, 0x99, 0x59, 0x43, 0xfe, 0x00, 0x2e,
+	0x9c, 0x7a, 0xe4, 0x4f, 0xea, 0xb1, 0x47, 0x4e, 0x80, 0x12, 0x89, 0x3f, 0x03, 0xa1, 0x99, 0xd9,
+	0x1f, 0xa6, 0x54, 0x11, 0xbd, 0xbd, 0xf9, 0xbe, 0xef, 0x7d, 0x7e, 0xbf, 0x36, 0x81, 0x3b, 0x0b,
+	0x9e, 0xa3, 0x9e, 0x2f, 0x75, 0xa4, 0x50, 0x6e, 0xd8, 0x02, 0x55, 0x54, 0xa0, 0xfe, 0x91, 0xcb,
+	0x67, 0xd1, 0xe6, 0x6e, 0x1d, 0x86, 0x42, 0x72, 0xcd, 0xc9, 0xed, 0x5a, 0x1b, 0xd6, 0xda, 0xb0,
+	0x16, 0x6c, 0xee, 0x9e, 0xdc, 0x6a, 0x9c, 0xc4, 0x54, 0x98, 0x64, 0x7d, 0x2e, 0x50, 0xb9, 0xd4,
+	0x93, 0xe3, 0x15, 0x5f, 0x71, 0x1b, 0x46, 0x26, 0xaa, 0xd0, 0xc1, 0x8a, 0xf3, 0x55, 0x86, 0x91,
+	0x7d, 0xcd, 0xcb, 0x65, 0x94, 0x96, 0x92, 0x6a, 0xc6, 0x8b, 0x8a, 0x1f, 0xbe, 0xca, 0x6b, 0x96,
+	0xa3, 0xd2, 0x34, 0x17, 0x4e, 0x30, 0x3e, 0x82, 0xc3, 0x47, 0xa8, 0x9f, 0xa0, 0x3e, 0x2b, 0x96,
+	0x3c, 0xc6, 0x1f, 0x4a, 0x54, 0x7a, 0xfc, 0x8b, 0x07, 0x64, 0x1b, 0x55, 0x82, 0x17, 0x0a, 0xc9,
+	0x6d, 0xf0, 0x33, 0xa6, 0x34, 0x16, 0xac, 0x58, 0x05, 0xde, 0xc8, 0x9b, 0xf4, 0xe2, 0x16, 0x68,
+	0x59, 0x94, 0x2a, 0xb8, 0x36, 0xea, 0x4c, 0xfc, 0xb8, 0x05, 0xc8, 0x27, 0xd0, 0x15, 0x68, 0x98,
+	0xce, 0xa8, 0x33, 0xe9, 0x4f, 0xc7, 0xe1, 0x55, 0x93, 0x08, 0xbf, 0x42, 0x94, 0xb1, 0x4b, 0x18,
+	0xff, 0xdc, 0x81, 0x5d, 0xf3, 0x26, 0xf7, 0xc1, 0x2f, 0x78, 0x8a, 0x09, 0x2b, 0x96, 0xdc, 0xfe,
+	0x7c, 0x7f, 0x3a, 0x6a, 0x6d, 0xc4, 0x54, 0x98, 0xcc, 0x53, 0x5c, 0xd2, 0x32, 0xd3, 0x4f, 0x78,
+	0x8a, 0xb6, 0xf6, 0x5e, 0x51, 0x45, 0x64, 0x08, 0x7d, 0xa6, 0x12, 0x5e, 0xea, 0x39, 0x2f, 0x8b,
+	0x34, 0xb8, 0x66, 0xeb, 0x07, 0xa6, 0xbe, 0xac, 0x10, 0x72, 0x0b, 0x7c, 0x89, 0x39, 0xd7, 0x98,
+	0x30, 0x11, 0x74, 0x46, 0xde, 0xc4, 0x8f, 0x7b, 0x0e, 0x38, 0x13, 0xe4, 0x31, 0x5c, 0x5f, 0xf0,
+	0xa2, 0xc0, 0x85, 0xc6, 0x34, 0x59, 0x72, 0x19, 0xec, 0xda, 0x02, 0x6e, 0x86, 0x6e, 0xc0, 0x61,
+	0x3d, 0xe0, 0xf0, 0xb4, 0x5a, 0xc0, 0xac, 0xf7, 0xe2, 0xf7, 0xe1, 0xce, 0xaf, 0x7f, 0x0c, 0xbd,
+	0x78, 0xbf, 0xc9, 0xfc, 0x8c, 0x4b, 0xf2, 0x14, 0xde, 0x53, 0x58, 0xa4, 0x89, 0xa4, 0x1a, 0x93,
+	0x8c, 0xe5, 0x4c, 0xa3, 0x4c, 0x52, 0xcc, 0xe8, 0x79, 0xd0, 0xfd, 0xff, 0x9e, 0xc7, 0xc6, 0x23,
+	0xa6, 0x1a, 0x3f, 0x77, 0x0e, 0xa7, 0xc6, 0xc0, 0x78, 0x4b, 0x5c, 0x6c, 0x5e, 0xe7, 0xbd, 0xf7,
+	0x06, 0xde, 0xc6, 0xe3, 0x55, 0xef, 0xf1, 0x09, 0x04, 0x8f, 0x50, 0x3f, 0x34, 0x97, 0x50, 0xa8,
+	0x52, 0x7d, 0xad, 0xa9, 0xc6, 0xfa, 0x60, 0xfe, 0xee, 0xc0, 0xcd, 0xd7, 0x90, 0xd5, 0xdd, 0xbc,
+	0x0b, 0x7b, 0x6b, 0x64, 0xab, 0xb5, 0xb6, 0x5b, 0xeb, 0xc4, 0xd5, 0x8b, 0x1c, 0x43, 0x57, 0x36,
+	0xbb, 0xe8, 0xc6, 0xee, 0x41, 0x08, 0xec, 0x2a, 0x8d, 0xf5, 0x06, 0x6c, 0x4c, 0x1e, 0x02, 0x28,
+	0x4d, 0xa5, 0x4e, 0xcc, 0xf9, 0x56, 0xa3, 0x3f, 0xf9, 0x4f, 0x2b, 0xdf, 0xd4, 0xb7, 0xed, 0x7a,
+	0x79, 0x6e, 0x7a, 0xf1, 0x6d, 0x9e, 0x61, 0x48, 0x08, 0x47, 0x42, 0x72, 0xc1, 0x15, 0xcd, 0x92,
+	0x79, 0xc6, 0x17, 0xcf, 0x92, 0x35, 0x55, 0x6b, 0x3b, 0xf4, 0xfd, 0xf8, 0xb0, 0xa6, 0x66, 0x86,
+	0x79, 0x4c, 0xd5, 0x9a, 0xdc, 0x81, 0x43, 0x13, 0x63, 0xba, 0xad, 0xde, 0xb3, 0xea, 0xb7, 0x1d,
+	0xd1, 0x6a, 0xdf, 0x87, 0xfd, 0x4a, 0xeb, 0x3a, 0x7a, 0xcb, 0x76, 0xd4, 0x77, 0x58, 0x6c, 0xfb,
+	0x9a, 0xc0, 0xc1, 0x86, 0x66, 0xec, 0x5f, 0x6e, 0x3d, 0xeb, 0x76, 0xc3, 0xe2, 0xad, 0xd9, 0x10,
+	0xfa, 0x4e, 0xe9, 0xbc, 0x7c, 0xeb, 0x05, 0x16, 0x72, 0x56, 0x1f, 0xc2, 0x81, 0x2b, 0x17, 0x65,
+	0x42, 0xd3, 0x54, 0xa2, 0x52, 0x01, 0xb8, 0xc2, 0x6a, 0xfc, 0x81, 0x83, 0xc9, 0x07, 0x70, 0xa3,
+	0x91, 0xb2, 0x22, 0xc5, 0x9f, 0x82, 0xbe, 0xb5, 0xbb, 0x5e, 0xa3, 0x67, 0x06, 0x24, 0x9f, 0x42,
+	0x77, 0xc3, 0x35, 0xaa, 0x60, 0xdf, 0x7e, 0x9e, 0x93, 0xab, 0x3f, 0x4f, 0x5b, 0xc5, 0xb7, 0x46,
+	0x1f, 0xbb, 0xb4, 0xf1, 0x5f, 0x1e, 0x40, 0x8b, 0xb6, 0x9b, 0xf5, 0xb6, 0x37, 0xfb, 0x11, 0x10,
+	0x21, 0xd1, 0x26, 0x24, 0x73, 0xa6, 0x13, 0x2a, 0x25, 0x3d, 0xb7, 0xcb, 0xf7, 0xe3, 0x83, 0x9a,
+	0x99, 0x31, 0xfd, 0xc0, 0xe0, 0xe4, 0x63, 0x38, 0x16, 0x12, 0x17, 0x3c, 0xcf, 0x99, 0xde, 0xd6,
+	0xbb, 0xbb, 0x20, 0x2d, 0xd7, 0x64, 0xd8, 0x05, 0x57, 0xfe, 0x39, 0xfd, 0x7e, 0x7a, 0xcf, 0x0d,
+	0x79, 0xb7, 0x5e, 0xb0, 0xa3, 0xbe, 0x30, 0x8c, 0x9d, 0xf3, 0x14, 0xde, 0xd9, 0xfa, 0x85, 0xad,
+	0x0c, 0x77, 0x12, 0x47, 0x2d, 0xd9, 0xe4, 0xcc, 0xbe, 0x7b, 0x71, 0x31, 0xf0, 0x5e, 0x5e, 0x0c,
+	0xbc, 0x3f, 0x2f, 0x06, 0xde, 0xf3, 0xcb, 0xc1, 0xce, 0xcb, 0xcb, 0xc1, 0xce, 0x6f, 0x97, 0x83,
+	0x9d, 0xa7, 0xf7, 0x57, 0x4c, 0xaf, 0xcb, 0xb9, 0x99, 0x5c, 0xd4, 0xfc, 0x21, 0x6f, 0x02, 0x2a,
+	0x58, 0x74, 0xd5, 0x3f, 0x8a, 0xf9, 0x9e, 0x3d, 0xe3, 0x7b, 0xff, 0x0c, 0x00, 0xac, 0xd9, 0x8a,
+	0xf0, 0x4f, 0x06, 0x00, 0x00,
+}
+
+func (m *GetNetInfoRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetNetInfoRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetNetInfoRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *GetNetInfoResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetNetInfoResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetNetInfoResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Peers) > 0 {
+		for iNdEx := len(m.Peers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Peers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintNetwork(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Listeners) > 0 {
+		for iNdEx := len(m.Listeners) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Listeners[iNdEx])
+			copy(dAtA[i:], m.Listeners[iNdEx])
+			i = encodeVarintNetwork(dAtA, i, uint64(len(m.Listeners[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Listening {
+		i--
+		if m.Listening {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Peer) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Peer) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Peer) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	n1, err1 := github_com_cosmos_gogoproto_types.StdDurationMarshalTo(m.RecvRateLimiterDelay, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.RecvRateLimiterDelay):])
+	if err1 != nil {
+		return 0, err1
+	}
+	i -= n1
+	i = encodeVarintNetwork(dAtA, i, uint64(n1))
+	i--
+	dAtA[i] = 0x32
+	n2, err2 := github_com_cosmos_gogoproto_types.StdDurationMarshalTo(m.SendRateLimiterDelay, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.SendRateLimiterDelay):])
+	if err2 != nil {
+		return 0, err2
+	}
+	i -= n2
+	i = encodeVarintNetwork(dAtA, i, uint64(n2))
+	i--
+	dAtA[i] = 0x2a
+	n3, err3 := github_com_cosmos_gogoproto_types.StdDurationMarshalTo(m.ConnectedFor, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.ConnectedFor):])
+	if err3 != nil {
+		return 0, err3
+	}
+	i -= n3
+	i = encodeVarintNetwork(dAtA, i, uint64(n3))
+	i--
+	dAtA[i] = 0x22
+	if len(m.RemoteIp) > 0 {
+		i -= len(m.RemoteIp)
+		copy(dAtA[i:], m.RemoteIp)
+		i = encodeVarintNetwork(dAtA, i, uint64(len(m.RemoteIp)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.IsOutbound {
+		i--
+		if m.IsOutbound {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.NodeInfo != nil {
+		{
+			size, err := m.NodeInfo.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintNetwork(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetConsensusStateRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetConsensusStateRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetConsensusStateRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *GetConsensusStateResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetConsensusStateResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetConsensusStateResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Votes) > 0 {
+		for iNdEx := len(m.Votes) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Votes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintNetwork(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x62
+		}
+	}
+	if m.ProposerIndex != 0 {
+		i = encodeVarintNetwork(dAtA, i, uint64(m.ProposerIndex))
+		i--
+		dAtA[i] = 0x58
+	}
+	if len(m.ProposerAddress) > 0 {
+		i -= len(m.ProposerAddress)
+		copy(dAtA[i:], m.ProposerAddress)
+		i = encodeVarintNetwork(dAtA, i, uint64(len(m.ProposerAddress)))
+		i--
+		dAtA[i] = 0x52
+	}
+	if m.ValidRound != 0 {
+		i = encodeVarintNetwork(dAtA, i, uint64(m.ValidRound))
+		i--
+		dAtA[i] = 0x48
+	}
+	if len(m.ValidBlockHash) > 0 {
+		i -= len(m.ValidBlockHash)
+		copy(dAtA[i:], m.ValidBlockHash)
+		i = encodeVarintNetwork(dAtA, i, uint64(len(m.ValidBlockHash)))
+		i--
+		dAtA[i] = 0x42
+	}
+	if m.LockedRound != 0 {
+		i = encodeVarintNetwork(dAtA, i, uint64(m.LockedRound))
+		i--
+		dAtA[i] = 0x38
+	}
+	if len(m.LockedBlockHash) > 0 {
+		i -= len(m.LockedBlockHash)
+		copy(dAtA[i:], m.LockedBlockHash)
+		i = encodeVarintNetwork(dAtA, i, uint64(len(m.LockedBlockHash)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.ProposalBlockHash) > 0 {
+		i -= len(m.ProposalBlockHash)
+		copy(dAtA[i:], m.ProposalBlockHash)
+		i = encodeVarintNetwork(dAtA, i, uint64(len(m.ProposalBlockHash)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	n5, err5 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(m.StartTime, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(m.StartTime):])
+	if err5 != nil {
+		return 0, err5
+	}
+	i -= n5
+	i = encodeVarintNetwork(dAtA, i, uint64(n5))
+	i--
+	dAtA[i] = 0x22
+	if len(m.Step) > 0 {
+		i -= len(m.Step)
+		copy(dAtA[i:], m.Step)
+		i = encodeVarintNetwork(dAtA, i, uint64(len(m.Step)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Round != 0 {
+		i = encodeVarintNetwork(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Height != 0 {
+		i = encodeVarintNetwork(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RoundVotes) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RoundVotes) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RoundVotes) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.PrecommitsMaj23Hash) > 0 {
+		i -= len(m.PrecommitsMaj23Hash)
+		copy(dAtA[i:], m.PrecommitsMaj23Hash)
+		i = encodeVarintNetwork(dAtA, i, uint64(len(m.PrecommitsMaj23Hash)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.PrevotesMaj23Hash) > 0 {
+		i -= len(m.PrevotesMaj23Hash)
+		copy(dAtA[i:], m.PrevotesMaj23Hash)
+		i = encodeVarintNetwork(dAtA, i, uint64(len(m.PrevotesMaj23Hash)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.PrecommitsBitArray) > 0 {
+		i -= len(m.PrecommitsBitArray)
+		copy(dAtA[i:], m.PrecommitsBitArray)
+		i = encodeVarintNetwork(dAtA, i, uint64(len(m.PrecommitsBitArray)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.PrevotesBitArray) > 0 {
+		i -= len(m.PrevotesBitArray)
+		copy(dAtA[i:], m.PrevotesBitArray)
+		i = encodeVarintNetwork(dAtA, i, uint64(len(m.PrevotesBitArray)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Round != 0 {
+		i = encodeVarintNetwork(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintNetwork(dAtA []byte, offset int, v uint64) int {
+	offset -= sovNetwork(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *GetNetInfoRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *GetNetInfoResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Listening {
+		n += 2
+	}
+	if len(m.Listeners) > 0 {
+		for _, s := range m.Listeners {
+			l = len(s)
+			n += 1 + l + sovNetwork(uint64(l))
+		}
+	}
+	if len(m.Peers) > 0 {
+		for _, e := range m.Peers {
+			l = e.Size()
+			n += 1 + l + sovNetwork(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Peer) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.NodeInfo != nil {
+		l = m.NodeInfo.Size()
+		n += 1 + l + sovNetwork(uint64(l))
+	}
+	if m.IsOutbound {
+		n += 2
+	}
+	l = len(m.RemoteIp)
+	if l > 0 {
+		n += 1 + l + sovNetwork(uint64(l))
+	}
+	l = github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.ConnectedFor)
+	n += 1 + l + sovNetwork(uint64(l))
+	l = github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.SendRateLimiterDelay)
+	n += 1 + l + sovNetwork(uint64(l))
+	l = github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.RecvRateLimiterDelay)
+	n += 1 + l + sovNetwork(uint64(l))
+	return n
+}
+
+func (m *GetConsensusStateRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *GetConsensusStateResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovNetwork(uint64(m.Height))
+	}
+	if m.Round != 0 {
+		n += 1 + sovNetwork(uint64(m.Round))
+	}
+	l = len(m.Step)
+	if l > 0 {
+		n += 1 + l + sovNetwork(uint64(l))
+	}
+	l = github_com_cosmos_gogoproto_types.SizeOfStdTime(m.StartTime)
+	n += 1 + l + sovNetwork(uint64(l))
+	l = len(m.ProposalBlockHash)
+	if l > 0 {
+		n += 1 + l + sovNetwork(uint64(l))
+	}
+	l = len(m.LockedBlockHash)
+	if l > 0 {
+		n += 1 + l + sovNetwork(uint64(l))
+	}
+	if m.LockedRound != 0 {
+		n += 1 + sovNetwork(uint64(m.LockedRound))
+	}
+	l = len(m.ValidBlockHash)
+	if l > 0 {
+		n += 1 + l + sovNetwork(uint64(l))
+	}
+	if m.ValidRound != 0 {
+		n += 1 + sovNetwork(uint64(m.ValidRound))
+	}
+	l = len(m.ProposerAddress)
+	if l > 0 {
+		n += 1 + l + sovNetwork(uint64(l))
+	}
+	if m.ProposerIndex != 0 {
+		n += 1 + sovNetwork(uint64(m.ProposerIndex))
+	}
+	if len(m.Votes) > 0 {
+		for _, e := range m.Votes {
+			l = e.Size()
+			n += 1 + l + sovNetwork(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *RoundVotes) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Round != 0 {
+		n += 1 + sovNetwork(uint64(m.Round))
+	}
+	l = len(m.PrevotesBitArray)
+	if l > 0 {
+		n += 1 + l + sovNetwork(uint64(l))
+	}
+	l = len(m.PrecommitsBitArray)
+	if l > 0 {
+		n += 1 + l + sovNetwork(uint64(l))
+	}
+	l = len(m.PrevotesMaj23Hash)
+	if l > 0 {
+		n += 1 + l + sovNetwork(uint64(l))
+	}
+	l = len(m.PrecommitsMaj23Hash)
+	if l > 0 {
+		n += 1 + l + sovNetwork(uint64(l))
+	}
+	return n
+}
+
+func sovNetwork(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozNetwork(x uint64) (n int) {
+	return sovNetwork(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *GetNetInfoRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNetwork
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetNetInfoRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetNetInfoRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNetwork(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNetwork
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetNetInfoResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNetwork
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetNetInfoResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetNetInfoResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Listening", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNetwork
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Listening = bool(v != 0)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Listeners", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNetwork
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthNetwork
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNetwork
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Listeners = append(m.Listeners, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNetwork
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNetwork
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNetwork
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Peers = append(m.Peers, &Peer{})
+			if err := m.Peers[len(m.Peers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNetwork(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNetwork
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Peer) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNetwork
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Peer: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Peer: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeInfo", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNetwork
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNetwork
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNetwork
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.NodeInfo == nil {
+				m.NodeInfo = &v1.DefaultNodeInfo{}
+			}
+			if err := m.NodeInfo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IsOutbound", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNetwork
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IsOutbound = bool(v != 0)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RemoteIp", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNetwork
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthNetwork
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNetwork
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RemoteIp = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConnectedFor", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNetwork
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNetwork
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNetwork
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_cosmos_gogoproto_types.StdDurationUnmarshal(&m.ConnectedFor, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SendRateLimiterDelay", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNetwork
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNetwork
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNetwork
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_cosmos_gogoproto_types.StdDurationUnmarshal(&m.SendRateLimiterDelay, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RecvRateLimiterDelay", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNetwork
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNetwork
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNetwork
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_cosmos_gogoproto_types.StdDurationUnmarshal(&m.RecvRateLimiterDelay, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNetwork(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNetwork
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetConsensusStateRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNetwork
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetConsensusStateRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetConsensusStateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNetwork(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNetwork
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetConsensusStateResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNetwork
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetConsensusStateResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetConsensusStateResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNetwork
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNetwork
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Round |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Step", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNetwork
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthNetwork
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNetwork
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Step = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartTime", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNetwork
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNetwork
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNetwork
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_cosmos_gogoproto_types.StdTimeUnmarshal(&m.StartTime, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProposalBlockHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNetwork
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNetwork
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNetwork
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ProposalBlockHash = append(m.ProposalBlockHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.ProposalBlockHash == nil {
+				m.ProposalBlockHash = []byte{}
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LockedBlockHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNetwork
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNetwork
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNetwork
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LockedBlockHash = append(m.LockedBlockHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.LockedBlockHash == nil {
+				m.LockedBlockHash = []byte{}
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LockedRound", wireType)
+			}
+			m.LockedRound = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNetwork
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LockedRound |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidBlockHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNetwork
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNetwork
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNetwork
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ValidBlockHash = append(m.ValidBlockHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.ValidBlockHash == nil {
+				m.ValidBlockHash = []byte{}
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidRound", wireType)
+			}
+			m.ValidRound = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNetwork
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ValidRound |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProposerAddress", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNetwork
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNetwork
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNetwork
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ProposerAddress = append(m.ProposerAddress[:0], dAtA[iNdEx:postIndex]...)
+			if m.ProposerAddress == nil {
+				m.ProposerAddress = []byte{}
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProposerIndex", wireType)
+			}
+			m.ProposerIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNetwork
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ProposerIndex |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Votes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNetwork
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNetwork
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNetwork
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Votes = append(m.Votes, &RoundVotes{})
+			if err := m.Votes[len(m.Votes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNetwork(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNetwork
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RoundVotes) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowNetwork
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RoundVotes: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RoundVotes: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNetwork
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Round |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PrevotesBitArray", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNetwork
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthNetwork
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNetwork
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PrevotesBitArray = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PrecommitsBitArray", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNetwork
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthNetwork
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNetwork
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PrecommitsBitArray = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PrevotesMaj23Hash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNetwork
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNetwork
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNetwork
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PrevotesMaj23Hash = append(m.PrevotesMaj23Hash[:0], dAtA[iNdEx:postIndex]...)
+			if m.PrevotesMaj23Hash == nil {
+				m.PrevotesMaj23Hash = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PrecommitsMaj23Hash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNetwork
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNetwork
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNetwork
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PrecommitsMaj23Hash = append(m.PrecommitsMaj23Hash[:0], dAtA[iNdEx:postIndex]...)
+			if m.PrecommitsMaj23Hash == nil {
+				m.PrecommitsMaj23Hash = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipNetwork(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthNetwork
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipNetwork(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowNetwork
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowNetwork
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowNetwork
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthNetwork
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupNetwork
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthNetwork
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthNetwork        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowNetwork          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupNetwork = fmt.Errorf("proto: unexpected end of group")
+)
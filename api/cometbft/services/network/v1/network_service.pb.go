@@ -0,0 +1,169 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: cometbft/services/network/v1/network_service.proto
+
+package v1
+
+import (
+	context "context"
+	fmt "fmt"
+	grpc1 "github.com/cosmos/gogoproto/grpc"
+	proto "github.com/cosmos/gogoproto/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+func init() {
+	proto.RegisterFile("cometbft/services/network/v1/network_service.proto", fileDescriptor_078e6c25047d98e9)
+}
+
+var fileDescriptor_078e6c25047d98e9 = []byte{
+	// 221 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x32, 0x4a, 0xce, 0xcf, 0x4d,
+	0x2d, 0x49, 0x4a, 0x2b, 0xd1, 0x2f, 0x4e, 0x2d, 0x2a, 0xcb, 0x4c, 0x4e, 0x2d, 0xd6, 0xcf, 0x4b,
+	0x2d, 0x29, 0xcf, 0x2f, 0xca, 0xd6, 0x2f, 0x33, 0x84, 0x31, 0xe3, 0xa1, 0x72, 0x7a, 0x05, 0x45,
+	0xf9, 0x25, 0xf9, 0x42, 0x32, 0x30, 0x3d, 0x7a, 0x30, 0x3d, 0x7a, 0x50, 0x85, 0x7a, 0x65, 0x86,
+	0x52, 0x5a, 0xc4, 0x98, 0x08, 0x31, 0xc9, 0xa8, 0x83, 0x89, 0x8b, 0xcf, 0x0f, 0x22, 0x12, 0x0c,
+	0x51, 0x2c, 0x94, 0xcf, 0xc5, 0xe5, 0x9e, 0x5a, 0xe2, 0x97, 0x5a, 0xe2, 0x99, 0x97, 0x96, 0x2f,
+	0xa4, 0xaf, 0x87, 0xcf, 0x2e, 0x3d, 0x84, 0xca, 0xa0, 0xd4, 0xc2, 0xd2, 0xd4, 0xe2, 0x12, 0x29,
+	0x03, 0xe2, 0x35, 0x14, 0x17, 0xe4, 0xe7, 0x15, 0xa7, 0x0a, 0xb5, 0x30, 0x72, 0x09, 0xba, 0xa7,
+	0x96, 0x38, 0x83, 0x38, 0x79, 0xc5, 0xa5, 0xc5, 0xc1, 0x25, 0x89, 0x25, 0xa9, 0x42, 0x66, 0x04,
+	0xcd, 0x41, 0xd5, 0x00, 0xb3, 0xdf, 0x9c, 0x64, 0x7d, 0x10, 0x67, 0x38, 0x85, 0x9f, 0x78, 0x24,
+	0xc7, 0x78, 0xe1, 0x91, 0x1c, 0xe3, 0x83, 0x47, 0x72, 0x8c, 0x13, 0x1e, 0xcb, 0x31, 0x5c, 0x78,
+	0x2c, 0xc7, 0x70, 0xe3, 0xb1, 0x1c, 0x43, 0x94, 0x6d, 0x7a, 0x66, 0x49, 0x46, 0x69, 0x12, 0xc8,
+	0x60, 0x7d, 0x78, 0xd8, 0xc2, 0x19, 0x89, 0x05, 0x99, 0xfa, 0xf8, 0x42, 0x3c, 0x89, 0x0d, 0x1c,
+	0xd4, 0xc6, 0x80, 0x01, 0x00, 0xbc, 0x1e, 0x3b, 0x05, 0xea, 0x01, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// NetworkServiceClient is the client API for NetworkService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type NetworkServiceClient interface {
+	// GetNetInfo returns the listening addresses and the peers of the node.
+	GetNetInfo(ctx context.Context, in *GetNetInfoRequest, opts ...grpc.CallOption) (*GetNetInfoResponse, error)
+	// GetConsensusState returns a summary of the consensus state of the node.
+	GetConsensusState(ctx context.Context, in *GetConsensusStateRequest, opts ...grpc.CallOption) (*GetConsensusStateResponse, error)
+}
+
+type networkServiceClient struct {
+	cc grpc1.ClientConn
+}
+
+func NewNetworkServiceClient(cc grpc1.ClientConn) NetworkServiceClient {
+	return &networkServiceClient{cc}
+}
+
+func (c *networkServiceClient) GetNetInfo(ctx context.Context, in *GetNetInfoRequest, opts ...grpc.CallOption) (*GetNetInfoResponse, error) {
+	out := new(GetNetInfoResponse)
+	err := c.cc.Invoke(ctx, "/cometbft.services.network.v1.NetworkService/GetNetInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServiceClient) GetConsensusState(ctx context.Context, in *GetConsensusStateRequest, opts ...grpc.CallOption) (*GetConsensusStateResponse, error) {
+	out := new(GetConsensusStateResponse)
+	err := c.cc.Invoke(ctx, "/cometbft.services.network.v1.NetworkService/GetConsensusState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// NetworkServiceServer is the server API for NetworkService service.
+type NetworkServiceServer interface {
+	// GetNetInfo returns the listening addresses and the peers of the node.
+	GetNetInfo(context.Context, *GetNetInfoRequest) (*GetNetInfoResponse, error)
+	// GetConsensusState returns a summary of the consensus state of the node.
+	GetConsensusState(context.Context, *GetConsensusStateRequest) (*GetConsensusStateResponse, error)
+}
+
+// UnimplementedNetworkServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedNetworkServiceServer struct {
+}
+
+func (*UnimplementedNetworkServiceServer) GetNetInfo(ctx context.Context, req *GetNetInfoRequest) (*GetNetInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNetInfo not implemented")
+}
+func (*UnimplementedNetworkServiceServer) GetConsensusState(ctx context.Context, req *GetConsensusStateRequest) (*GetConsensusStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConsensusState not implemented")
+}
+
+func RegisterNetworkServiceServer(s grpc1.Server, srv NetworkServiceServer) {
+	s.RegisterService(&_NetworkService_serviceDesc, srv)
+}
+
+func _NetworkService_GetNetInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNetInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServiceServer).GetNetInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cometbft.services.network.v1.NetworkService/GetNetInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServiceServer).GetNetInfo(ctx, req.(*GetNetInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkService_GetConsensusState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConsensusStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServiceServer).GetConsensusState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cometbft.services.network.v1.NetworkService/GetConsensusState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServiceServer).GetConsensusState(ctx, req.(*GetConsensusStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var NetworkService_serviceDesc = _NetworkService_serviceDesc
+var _NetworkService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "cometbft.services.network.v1.NetworkService",
+	HandlerType: (*NetworkServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetNetInfo",
+			Handler:    _NetworkService_GetNetInfo_Handler,
+		},
+		{
+			MethodName: "GetConsensusState",
+			Handler:    _NetworkService_GetConsensusState_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "cometbft/services/network/v1/network_service.proto",
+}
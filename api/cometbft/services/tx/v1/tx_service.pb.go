@@ -0,0 +1,325 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: cometbft/services/tx/v1/tx_service.proto
+
+package v1
+
+import (
+	context "context"
+	fmt "fmt"
+	grpc1 "github.com/cosmos/gogoproto/grpc"
+	proto "github.com/cosmos/gogoproto/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+func init() {
+	proto.RegisterFile("cometbft/services/tx/v1/tx_service.proto", fileDescriptor_8fe218d3aae58411)
+}
+
+var fileDescriptor_8fe218d3aae58411 = []byte{
+	// 280 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xd2, 0x48, 0xce, 0xcf, 0x4d,
+	0x2d, 0x49, 0x4a, 0x2b, 0xd1, 0x2f, 0x4e, 0x2d, 0x2a, 0xcb, 0x4c, 0x4e, 0x2d, 0xd6, 0x2f, 0xa9,
+	0xd0, 0x2f, 0x33, 0xd4, 0x2f, 0xa9, 0x88, 0x87, 0x8a, 0xe8, 0x15, 0x14, 0xe5, 0x97, 0xe4, 0x0b,
+	0x89, 0xc3, 0x54, 0xea, 0xc1, 0x54, 0xea, 0x95, 0x54, 0xe8, 0x95, 0x19, 0x4a, 0x29, 0xe0, 0x36,
+	0x02, 0xa2, 0xd5, 0xa8, 0x9d, 0x85, 0x8b, 0x33, 0xa4, 0x22, 0x18, 0x22, 0x2b, 0x94, 0xc1, 0xc5,
+	0xed, 0x54, 0x94, 0x9f, 0x98, 0x92, 0x9c, 0x58, 0x5c, 0x12, 0x52, 0x21, 0xa4, 0xad, 0x87, 0xc3,
+	0x60, 0x3d, 0x24, 0x55, 0x41, 0xa9, 0x85, 0xa5, 0xa9, 0xc5, 0x25, 0x52, 0x3a, 0xc4, 0x29, 0x2e,
+	0x2e, 0xc8, 0xcf, 0x2b, 0x4e, 0x15, 0x8a, 0xe1, 0x62, 0x77, 0xce, 0x48, 0x4d, 0xce, 0x0e, 0xa9,
+	0x10, 0x52, 0xc7, 0xa9, 0x11, 0xaa, 0x02, 0x66, 0x83, 0x06, 0x61, 0x85, 0x50, 0xd3, 0xc3, 0xb8,
+	0x58, 0xdd, 0x53, 0x41, 0x3e, 0x50, 0xc5, 0xa9, 0x05, 0x2c, 0x0f, 0x33, 0x59, 0x8d, 0x90, 0x32,
+	0xa8, 0xb9, 0x49, 0x5c, 0x9c, 0xc1, 0xa9, 0x89, 0x45, 0xc9, 0x19, 0x21, 0x15, 0xc5, 0x42, 0x9a,
+	0x38, 0x35, 0xc1, 0xd5, 0xc0, 0xcc, 0xd7, 0x22, 0x46, 0x29, 0xd4, 0x8e, 0x32, 0x2e, 0x7e, 0xb8,
+	0x60, 0x70, 0x49, 0x51, 0x6a, 0x62, 0xae, 0x90, 0x3e, 0x61, 0xed, 0x10, 0x95, 0x30, 0xfb, 0x0c,
+	0x88, 0xd7, 0x00, 0xb1, 0xd5, 0x80, 0xd1, 0x29, 0xe8, 0xc4, 0x23, 0x39, 0xc6, 0x0b, 0x8f, 0xe4,
+	0x18, 0x1f, 0x3c, 0x92, 0x63, 0x9c, 0xf0, 0x58, 0x8e, 0xe1, 0xc2, 0x63, 0x39, 0x86, 0x1b, 0x8f,
+	0xe5, 0x18, 0xa2, 0x2c, 0xd2, 0x33, 0x4b, 0x32, 0x4a, 0x93, 0x40, 0x66, 0xea, 0xc3, 0x13, 0x14,
+	0x9c, 0x91, 0x58, 0x90, 0xa9, 0x8f, 0x23, 0x99, 0x25, 0xb1, 0x81, 0x13, 0x99, 0x31, 0x60, 0x00,
+	0xde, 0x4b, 0xbf, 0x67, 0xcb, 0x02, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// TxServiceClient is the client API for TxService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type TxServiceClient interface {
+	// BroadcastTx submits a transaction to the mempool of the node, and waits
+	// for the result of CheckTx or, in commit mode, for the transaction to be
+	// included in a block.
+	BroadcastTx(ctx context.Context, in *BroadcastTxRequest, opts ...grpc.CallOption) (*BroadcastTxResponse, error)
+	// CheckTx checks a transaction against the application without adding it
+	// to the mempool.
+	CheckTx(ctx context.Context, in *CheckTxRequest, opts ...grpc.CallOption) (*CheckTxResponse, error)
+	// GetTx retrieves an indexed transaction by its hash.
+	GetTx(ctx context.Context, in *GetTxRequest, opts ...grpc.CallOption) (*GetTxResponse, error)
+	// SearchTxs returns a page of the indexed transactions matching a query.
+	SearchTxs(ctx context.Context, in *SearchTxsRequest, opts ...grpc.CallOption) (*SearchTxsResponse, error)
+	// SearchTxsStream returns a stream of all the indexed transactions matching
+	// a query. The stream is terminated by the server once all the matching
+	// transactions have been sent.
+	SearchTxsStream(ctx context.Context, in *SearchTxsStreamRequest, opts ...grpc.CallOption) (TxService_SearchTxsStreamClient, error)
+}
+
+type txServiceClient struct {
+	cc grpc1.ClientConn
+}
+
+func NewTxServiceClient(cc grpc1.ClientConn) TxServiceClient {
+	return &txServiceClient{cc}
+}
+
+func (c *txServiceClient) BroadcastTx(ctx context.Context, in *BroadcastTxRequest, opts ...grpc.CallOption) (*BroadcastTxResponse, error) {
+	out := new(BroadcastTxResponse)
+	err := c.cc.Invoke(ctx, "/cometbft.services.tx.v1.TxService/BroadcastTx", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *txServiceClient) CheckTx(ctx context.Context, in *CheckTxRequest, opts ...grpc.CallOption) (*CheckTxResponse, error) {
+	out := new(CheckTxResponse)
+	err := c.cc.Invoke(ctx, "/cometbft.services.tx.v1.TxService/CheckTx", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *txServiceClient) GetTx(ctx context.Context, in *GetTxRequest, opts ...grpc.CallOption) (*GetTxResponse, error) {
+	out := new(GetTxResponse)
+	err := c.cc.Invoke(ctx, "/cometbft.services.tx.v1.TxService/GetTx", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *txServiceClient) SearchTxs(ctx context.Context, in *SearchTxsRequest, opts ...grpc.CallOption) (*SearchTxsResponse, error) {
+	out := new(SearchTxsResponse)
+	err := c.cc.Invoke(ctx, "/cometbft.services.tx.v1.TxService/SearchTxs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *txServiceClient) SearchTxsStream(ctx context.Context, in *SearchTxsStreamRequest, opts ...grpc.CallOption) (TxService_SearchTxsStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_TxService_serviceDesc.Streams[0], "/cometbft.services.tx.v1.TxService/SearchTxsStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &txServiceSearchTxsStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TxService_SearchTxsStreamClient interface {
+	Recv() (*SearchTxsStreamResponse, error)
+	grpc.ClientStream
+}
+
+type txServiceSearchTxsStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *txServiceSearchTxsStreamClient) Recv() (*SearchTxsStreamResponse, error) {
+	m := new(SearchTxsStreamResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// TxServiceServer is the server API for TxService service.
+type TxServiceServer interface {
+	// BroadcastTx submits a transaction to the mempool of the node, and waits
+	// for the result of CheckTx or, in commit mode, for the transaction to be
+	// included in a block.
+	BroadcastTx(context.Context, *BroadcastTxRequest) (*BroadcastTxResponse, error)
+	// CheckTx checks a transaction against the application without adding it
+	// to the mempool.
+	CheckTx(context.Context, *CheckTxRequest) (*CheckTxResponse, error)
+	// GetTx retrieves an indexed transaction by its hash.
+	GetTx(context.Context, *GetTxRequest) (*GetTxResponse, error)
+	// SearchTxs returns a page of the indexed transactions matching a query.
+	SearchTxs(context.Context, *SearchTxsRequest) (*SearchTxsResponse, error)
+	// SearchTxsStream returns a stream of all the indexed transactions matching
+	// a query. The stream is terminated by the server once all the matching
+	// transactions have been sent.
+	SearchTxsStream(*SearchTxsStreamRequest, TxService_SearchTxsStreamServer) error
+}
+
+// UnimplementedTxServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedTxServiceServer struct {
+}
+
+func (*UnimplementedTxServiceServer) BroadcastTx(ctx context.Context, req *BroadcastTxRequest) (*BroadcastTxResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BroadcastTx not implemented")
+}
+func (*UnimplementedTxServiceServer) CheckTx(ctx context.Context, req *CheckTxRequest) (*CheckTxResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckTx not implemented")
+}
+func (*UnimplementedTxServiceServer) GetTx(ctx context.Context, req *GetTxRequest) (*GetTxResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTx not implemented")
+}
+func (*UnimplementedTxServiceServer) SearchTxs(ctx context.Context, req *SearchTxsRequest) (*SearchTxsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchTxs not implemented")
+}
+func (*UnimplementedTxServiceServer) SearchTxsStream(req *SearchTxsStreamRequest, srv TxService_SearchTxsStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method SearchTxsStream not implemented")
+}
+
+func RegisterTxServiceServer(s grpc1.Server, srv TxServiceServer) {
+	s.RegisterService(&_TxService_serviceDesc, srv)
+}
+
+func _TxService_BroadcastTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BroadcastTxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxServiceServer).BroadcastTx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cometbft.services.tx.v1.TxService/BroadcastTx",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxServiceServer).BroadcastTx(ctx, req.(*BroadcastTxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TxService_CheckTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckTxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxServiceServer).CheckTx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cometbft.services.tx.v1.TxService/CheckTx",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxServiceServer).CheckTx(ctx, req.(*CheckTxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TxService_GetTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxServiceServer).GetTx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cometbft.services.tx.v1.TxService/GetTx",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxServiceServer).GetTx(ctx, req.(*GetTxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TxService_SearchTxs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchTxsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxServiceServer).SearchTxs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cometbft.services.tx.v1.TxService/SearchTxs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxServiceServer).SearchTxs(ctx, req.(*SearchTxsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TxService_SearchTxsStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SearchTxsStreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TxServiceServer).SearchTxsStream(m, &txServiceSearchTxsStreamServer{stream})
+}
+
+type TxService_SearchTxsStreamServer interface {
+	Send(*SearchTxsStreamResponse) error
+	grpc.ServerStream
+}
+
+type txServiceSearchTxsStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *txServiceSearchTxsStreamServer) Send(m *SearchTxsStreamResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var TxService_serviceDesc = _TxService_serviceDesc
+var _TxService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "cometbft.services.tx.v1.TxService",
+	HandlerType: (*TxServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "BroadcastTx",
+			Handler:    _TxService_BroadcastTx_Handler,
+		},
+		{
+			MethodName: "CheckTx",
+			Handler:    _TxService_CheckTx_Handler,
+		},
+		{
+			MethodName: "GetTx",
+			Handler:    _TxService_GetTx_Handler,
+		},
+		{
+			MethodName: "SearchTxs",
+			Handler:    _TxService_SearchTxs_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SearchTxsStream",
+			Handler:       _TxService_SearchTxsStream_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "cometbft/services/tx/v1/tx_service.proto",
+}
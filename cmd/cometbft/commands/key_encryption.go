@@ -0,0 +1,146 @@
+package commands
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	cfg "github.com/cometbft/cometbft/v2/config"
+	"github.com/cometbft/cometbft/v2/crypto/armor"
+	cmtos "github.com/cometbft/cometbft/v2/internal/os"
+	"github.com/cometbft/cometbft/v2/internal/passphrase"
+	"github.com/cometbft/cometbft/v2/p2p"
+	"github.com/cometbft/cometbft/v2/privval"
+)
+
+var (
+	encryptPrivValidatorKey bool
+	encryptNodeKey          bool
+)
+
+// EncryptKeysCmd encrypts the private validator key and the node key in place.
+var EncryptKeysCmd = &cobra.Command{
+	Use:     "encrypt-keys",
+	Aliases: []string{"encrypt_keys"},
+	Short:   "Encrypt the private validator key and the node key with a passphrase",
+	Long: `
+Encrypt the private validator key and the node key in place with a passphrase.
+The keys are stored in an armor block, encrypted with XChaCha20-Poly1305 and a
+key derived from the passphrase with Argon2id.
+
+The passphrase is read from the CMT_KEY_PASSPHRASE environment variable, from
+the key_passphrase_file, or else prompted for. The node reads the passphrase
+from the same sources on startup. Keys which are already encrypted are left
+untouched.
+`,
+	RunE: encryptKeys,
+}
+
+// DecryptKeysCmd decrypts the private validator key and the node key in place.
+var DecryptKeysCmd = &cobra.Command{
+	Use:     "decrypt-keys",
+	Aliases: []string{"decrypt_keys"},
+	Short:   "Decrypt the private validator key and the node key encrypted with encrypt-keys",
+	RunE:    decryptKeys,
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{EncryptKeysCmd, DecryptKeysCmd} {
+		cmd.Flags().BoolVar(&encryptPrivValidatorKey, "priv-validator-key", true, "process the private validator key")
+		cmd.Flags().BoolVar(&encryptNodeKey, "node-key", true, "process the node key")
+	}
+}
+
+// keyPassphrase returns the source of the passphrase of the encrypted keys.
+func keyPassphrase(config *cfg.Config) armor.PassphraseFunc {
+	return passphrase.New(config.KeyPassphrasePath())
+}
+
+func encryptKeys(*cobra.Command, []string) error {
+	files, err := keyFilesToProcess(true)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		logger.Info("No keys to encrypt")
+		return nil
+	}
+	pass, err := passphrase.Read(config.KeyPassphrasePath(), true)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if file == config.NodeKeyFile() {
+			nk, err := p2p.LoadNodeKey(file)
+			if err != nil {
+				return err
+			}
+			if err := nk.SaveEncryptedAs(file, pass); err != nil {
+				return err
+			}
+		} else {
+			pv := privval.LoadFilePVEmptyState(file, config.PrivValidatorStateFile())
+			pv.Key.SetPassphrase(pass)
+			pv.Key.Save()
+		}
+		logger.Info("Encrypted key", "path", file)
+	}
+	return nil
+}
+
+func decryptKeys(*cobra.Command, []string) error {
+	files, err := keyFilesToProcess(false)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		logger.Info("No keys to decrypt")
+		return nil
+	}
+	pass := keyPassphrase(config)
+	for _, file := range files {
+		if file == config.NodeKeyFile() {
+			nk, err := p2p.LoadNodeKeyWithPassphrase(file, pass)
+			if err != nil {
+				return err
+			}
+			if err := nk.SaveAs(file); err != nil {
+				return err
+			}
+		} else {
+			pv := privval.LoadFilePVEmptyStateWithPassphrase(file, config.PrivValidatorStateFile(), pass)
+			pv.Key.SetPassphrase(nil)
+			pv.Key.Save()
+		}
+		logger.Info("Decrypted key", "path", file)
+	}
+	return nil
+}
+
+// keyFilesToProcess returns the key files selected by the flags which are
+// unencrypted if encrypt is true, and encrypted otherwise.
+func keyFilesToProcess(encrypt bool) ([]string, error) {
+	var candidates, files []string
+	if encryptPrivValidatorKey {
+		candidates = append(candidates, config.PrivValidatorKeyFile())
+	}
+	if encryptNodeKey {
+		candidates = append(candidates, config.NodeKeyFile())
+	}
+	for _, file := range candidates {
+		if !cmtos.FileExists(file) {
+			logger.Info("Key not found, skipping", "path", file)
+			continue
+		}
+		bz, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		if armor.IsArmored(bz) == encrypt {
+			logger.Info("Key already in the requested state, skipping", "path", file, "encrypted", !encrypt)
+			continue
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
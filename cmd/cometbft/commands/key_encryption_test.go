@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/cometbft/cometbft/v2/config"
+	"github.com/cometbft/cometbft/v2/crypto/armor"
+	"github.com/cometbft/cometbft/v2/internal/passphrase"
+	"github.com/cometbft/cometbft/v2/p2p"
+	"github.com/cometbft/cometbft/v2/privval"
+)
+
+func Test_EncryptDecryptKeys(t *testing.T) {
+	testConfig := cfg.TestConfig()
+	dir := t.TempDir()
+	testConfig.SetRoot(dir)
+	cfg.EnsureRoot(dir)
+	require.NoError(t, initFilesWithConfig(testConfig))
+	pv := privval.LoadFilePV(testConfig.PrivValidatorKeyFile(), testConfig.PrivValidatorStateFile())
+	nk, err := p2p.LoadNodeKey(testConfig.NodeKeyFile())
+	require.NoError(t, err)
+
+	prevConfig := config
+	config = testConfig
+	t.Cleanup(func() { config = prevConfig })
+	t.Setenv(passphrase.EnvVar, "passphrase")
+
+	requireArmored := func(file string, armored bool) {
+		t.Helper()
+		bz, err := os.ReadFile(file)
+		require.NoError(t, err)
+		require.Equal(t, armored, armor.IsArmored(bz))
+	}
+
+	require.NoError(t, encryptKeys(nil, nil))
+	requireArmored(config.PrivValidatorKeyFile(), true)
+	requireArmored(config.NodeKeyFile(), true)
+	_, err = p2p.LoadNodeKey(config.NodeKeyFile())
+	require.ErrorIs(t, err, armor.ErrPassphraseRequired)
+
+	// Resetting the validator keeps the key encrypted.
+	require.NoError(t, resetFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile(), logger))
+	requireArmored(config.PrivValidatorKeyFile(), true)
+
+	require.NoError(t, decryptKeys(nil, nil))
+	requireArmored(config.PrivValidatorKeyFile(), false)
+	requireArmored(config.NodeKeyFile(), false)
+	require.Equal(t, pv.Key.PrivKey, privval.LoadFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile()).Key.PrivKey)
+	nk2, err := p2p.LoadNodeKey(config.NodeKeyFile())
+	require.NoError(t, err)
+	require.Equal(t, nk.ID(), nk2.ID())
+}
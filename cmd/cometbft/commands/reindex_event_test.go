@@ -14,6 +14,7 @@ import (
 	cmtcfg "github.com/cometbft/cometbft/v2/config"
 	"github.com/cometbft/cometbft/v2/internal/test"
 	blockmocks "github.com/cometbft/cometbft/v2/state/indexer/mocks"
+	"github.com/cometbft/cometbft/v2/state/indexer/sink/sqlite"
 	"github.com/cometbft/cometbft/v2/state/mocks"
 	txmocks "github.com/cometbft/cometbft/v2/state/txindex/mocks"
 	"github.com/cometbft/cometbft/v2/types"
@@ -89,12 +90,14 @@ func TestLoadEventSink(t *testing.T) {
 		{"NULL", "", true},
 		{"KV", "", false},
 		{"PSQL", "", true}, // true because empty connect url
+		{"SQLITE", "", !sqlite.Supported},
 		// skip to test PSQL connect with correct url
 		{"UnsupportedSinkType", "wrongUrl", true},
 	}
 
 	for idx, tc := range testCases {
 		cfg := cmtcfg.TestConfig()
+		cfg.DBPath = t.TempDir()
 		cfg.TxIndex.Indexer = tc.sinks
 		cfg.TxIndex.PsqlConn = tc.connURL
 		_, _, err := loadEventSinks(cfg, test.DefaultTestChainID)
@@ -79,6 +79,8 @@ func resetPrivValidator(cmd *cobra.Command, _ []string) (err error) {
 		return err
 	}
 
+	removeSlashingProtectionDB(config.DBDir(), logger)
+	removeSignerHighWaterMark(config.DBDir(), logger)
 	return resetFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile(), logger)
 }
 
@@ -160,7 +162,7 @@ func resetState(dbDir string, logger log.Logger) error {
 
 func resetFilePV(privValKeyFile, privValStateFile string, logger log.Logger) error {
 	if _, err := os.Stat(privValKeyFile); err == nil {
-		pv := privval.LoadFilePVEmptyState(privValKeyFile, privValStateFile)
+		pv := privval.LoadFilePVEmptyStateWithPassphrase(privValKeyFile, privValStateFile, keyPassphrase(config))
 		pv.Reset()
 		logger.Info(
 			"Reset private validator file to genesis state",
@@ -182,6 +184,26 @@ func resetFilePV(privValKeyFile, privValStateFile string, logger log.Logger) err
 	return nil
 }
 
+func removeSlashingProtectionDB(dbDir string, logger log.Logger) {
+	spdb := filepath.Join(dbDir, privval.SlashingProtectionDBID+".db")
+	if cmtos.FileExists(spdb) {
+		if err := os.RemoveAll(spdb); err == nil {
+			logger.Info("Removed slashing-protection database", "dir", spdb)
+		} else {
+			logger.Error("error removing slashing-protection database", "dir", spdb, "err", err)
+		}
+	}
+}
+
+func removeSignerHighWaterMark(dbDir string, logger log.Logger) {
+	hwmFile := filepath.Join(dbDir, privval.SignerHighWaterMarkFile)
+	if err := os.Remove(hwmFile); err == nil {
+		logger.Info("Removed signer high-water mark", "file", hwmFile)
+	} else if !os.IsNotExist(err) {
+		logger.Error("error removing signer high-water mark", "file", hwmFile, "err", err)
+	}
+}
+
 func removeAddrBook(addrBookFile string, logger log.Logger) {
 	if err := os.Remove(addrBookFile); err == nil {
 		logger.Info("Removed existing address book", "file", addrBookFile)
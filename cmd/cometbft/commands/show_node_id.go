@@ -17,7 +17,7 @@ var ShowNodeIDCmd = &cobra.Command{
 }
 
 func showNodeID(*cobra.Command, []string) error {
-	nk, err := p2p.LoadNodeKey(config.NodeKeyFile())
+	nk, err := p2p.LoadNodeKeyWithPassphrase(config.NodeKeyFile(), keyPassphrase(config))
 	if err != nil {
 		return err
 	}
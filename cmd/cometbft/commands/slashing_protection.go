@@ -0,0 +1,107 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	dbm "github.com/cometbft/cometbft-db"
+	cfg "github.com/cometbft/cometbft/v2/config"
+	"github.com/cometbft/cometbft/v2/internal/tempfile"
+	"github.com/cometbft/cometbft/v2/privval"
+)
+
+// SlashingProtectionCmd allows exporting and importing the slashing-protection
+// database of the validator.
+var SlashingProtectionCmd = &cobra.Command{
+	Use:     "slashing-protection",
+	Aliases: []string{"slashing_protection"},
+	Short:   "Export or import the slashing-protection database of the validator",
+	Long: `
+The slashing-protection database records all the votes and proposals signed by
+the validator key, so that the node never signs conflicting data, even if the
+priv_validator_state.json file is lost or rolled back.
+
+When moving a validator to another machine, export the database after stopping
+the old node, and import it on the new machine before starting the new node.
+These commands must not be run while the node is running.
+`,
+}
+
+var slashingProtectionExportCmd = &cobra.Command{
+	Use:   "export [file]",
+	Short: "Export the slashing-protection database to a JSON file (standard output by default)",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		spdb, err := openSlashingProtectionDB(config)
+		if err != nil {
+			return err
+		}
+		defer spdb.Close()
+
+		interchange, err := spdb.Export()
+		if err != nil {
+			return fmt.Errorf("failed to export slashing-protection database: %w", err)
+		}
+		bz, err := privval.MarshalInterchange(interchange)
+		if err != nil {
+			return err
+		}
+
+		if len(args) == 0 {
+			fmt.Println(string(bz))
+			return nil
+		}
+		if err := tempfile.WriteFileAtomic(args[0], bz, 0o600); err != nil {
+			return err
+		}
+		logger.Info("Exported slashing-protection database", "file", args[0], "keys", len(interchange.Data))
+		return nil
+	},
+}
+
+var slashingProtectionImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import a JSON file into the slashing-protection database",
+	Long: `
+Import the records of a file produced by "slashing-protection export" into the
+slashing-protection database. Existing records are kept. Nothing is imported if
+a record of the file conflicts with an existing record.
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		bz, err := os.ReadFile(args[0])
+		if err != nil {
+			return err
+		}
+		interchange, err := privval.UnmarshalInterchange(bz)
+		if err != nil {
+			return fmt.Errorf("failed to parse %v: %w", args[0], err)
+		}
+
+		spdb, err := openSlashingProtectionDB(config)
+		if err != nil {
+			return err
+		}
+		defer spdb.Close()
+
+		if err := spdb.Import(interchange); err != nil {
+			return fmt.Errorf("failed to import slashing-protection database: %w", err)
+		}
+		logger.Info("Imported slashing-protection database", "file", args[0], "keys", len(interchange.Data))
+		return nil
+	},
+}
+
+func init() {
+	SlashingProtectionCmd.AddCommand(slashingProtectionExportCmd, slashingProtectionImportCmd)
+}
+
+func openSlashingProtectionDB(config *cfg.Config) (*privval.SlashingProtectionDB, error) {
+	db, err := dbm.NewDB(privval.SlashingProtectionDBID, dbm.BackendType(config.DBBackend), config.DBDir())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open slashing-protection database: %w", err)
+	}
+	return privval.NewSlashingProtectionDB(db), nil
+}
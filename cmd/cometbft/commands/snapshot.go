@@ -0,0 +1,83 @@
+package commands
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	cfg "github.com/cometbft/cometbft/v2/config"
+	nm "github.com/cometbft/cometbft/v2/node"
+	"github.com/cometbft/cometbft/v2/proxy"
+)
+
+var (
+	snapshotTrustHeight int64
+	snapshotTrustHash   string
+)
+
+// SnapshotCmd groups the commands operating on state sync snapshots.
+var SnapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Operate on state sync snapshots",
+}
+
+var snapshotRestoreCmd = &cobra.Command{
+	Use:   "restore <dir|archive>",
+	Short: "Restore the application and the node from a local snapshot, without any peers",
+	Long: `
+Restore the application from a local application snapshot, and bootstrap the
+state and block stores from the node snapshot at the same height, without
+connecting to any peers. This is meant for disaster recovery.
+
+The snapshot is either a directory or a tar archive (optionally gzipped) of a
+directory containing:
+
+  snapshot.json      the application snapshot metadata, with the height,
+                     format, chunks, hash and metadata fields returned by the
+                     application in ListSnapshots
+  chunks/<index>     the application snapshot chunks
+  <height>.snapshot  the node snapshot at the same height, as stored in
+                     data/snapshots by a node with statesync.node_snapshot_interval set
+
+The node snapshot is verified against the trusted hash of the header at the
+snapshot height, or at the following one. The application must be running, and
+the state and block stores must be empty. The node then starts from the
+restored height, and block syncs the following blocks from its peers.
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		if snapshotTrustHeight <= 0 {
+			return errors.New("--trust-height must be set")
+		}
+		trustHash, err := hex.DecodeString(snapshotTrustHash)
+		if err != nil || len(trustHash) == 0 {
+			return fmt.Errorf("--trust-hash must be a hex-encoded hash: %q", snapshotTrustHash)
+		}
+
+		state, err := nm.RestoreLocalSnapshot(
+			config,
+			cfg.DefaultDBProvider,
+			nm.DefaultGenesisDocProviderFunc(config),
+			proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir()),
+			args[0],
+			snapshotTrustHeight,
+			trustHash,
+			logger,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to restore snapshot: %w", err)
+		}
+		fmt.Printf("Restored snapshot at height %d with app hash %X\n", state.LastBlockHeight, state.AppHash)
+		return nil
+	},
+}
+
+func init() {
+	snapshotRestoreCmd.Flags().Int64Var(&snapshotTrustHeight, "trust-height", 0,
+		"height of the trusted header, the snapshot height or the following one")
+	snapshotRestoreCmd.Flags().StringVar(&snapshotTrustHash, "trust-hash", "",
+		"hex-encoded hash of the trusted header")
+	SnapshotCmd.AddCommand(snapshotRestoreCmd)
+}
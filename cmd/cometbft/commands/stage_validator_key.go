@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cometbft/cometbft/v2/crypto/ed25519"
+	kt "github.com/cometbft/cometbft/v2/internal/keytypes"
+	cmtos "github.com/cometbft/cometbft/v2/internal/os"
+	cmtjson "github.com/cometbft/cometbft/v2/libs/json"
+	"github.com/cometbft/cometbft/v2/privval"
+)
+
+// StageValidatorKeyCmd generates the key the validator rotates to, and prints
+// its public key.
+var StageValidatorKeyCmd = &cobra.Command{
+	Use:     "stage-validator-key",
+	Aliases: []string{"stage_validator_key"},
+	Short:   "Generate the next validator key and print its public key",
+	Long: `
+Generate a new validator key and save it to priv_validator_next_key_file. The
+node keeps signing with the current key until the application replaces it with
+the new key in the validator updates of FinalizeBlock. Updates returned at
+height H take effect at height H+2: the node signs with the current key up to
+height H+1, and then rotates to the new key, which replaces the current key in
+priv_validator_key_file.
+
+The new key is encrypted with the same passphrase as the current key, if any.
+The command prints the public key of the new key, to be submitted to the
+application. A running node picks up the new key without being restarted.
+
+Key rotation is not supported with remote signers (priv_validator_laddr or
+priv_validator_grpc_addr), which must rotate their keys themselves.
+`,
+	RunE: stageValidatorKey,
+}
+
+func init() {
+	StageValidatorKeyCmd.Flags().StringVarP(&keyType, "key-type", "k", ed25519.KeyType, fmt.Sprintf("private key type (one of %s)", kt.SupportedKeyTypesStr()))
+}
+
+func stageValidatorKey(*cobra.Command, []string) error {
+	if config.PrivValidatorListenAddr != "" || config.PrivValidatorGRPCAddr != "" || config.PrivValidatorThreshold != "" {
+		return errors.New("key rotation is not supported with remote signers: rotate the key in the signer")
+	}
+	keyFile, nextKeyFile := config.PrivValidatorKeyFile(), config.PrivValidatorNextKeyFile()
+	if !cmtos.FileExists(keyFile) {
+		return fmt.Errorf("private validator file %s does not exist", keyFile)
+	}
+	if cmtos.FileExists(nextKeyFile) {
+		return fmt.Errorf("next private validator key %s already exists", nextKeyFile)
+	}
+
+	pv := privval.LoadFilePVEmptyStateWithPassphrase(keyFile, config.PrivValidatorStateFile(), keyPassphrase(config))
+	privKey, err := genPrivKeyFromFlag()
+	if err != nil {
+		return fmt.Errorf("cannot generate key: %w", err)
+	}
+	if err := pv.StageNextKey(privKey, nextKeyFile); err != nil {
+		return err
+	}
+	logger.Info("Staged next private validator key", "path", nextKeyFile)
+
+	bz, err := cmtjson.Marshal(privKey.PubKey())
+	if err != nil {
+		return fmt.Errorf("failed to marshal next private validator pubkey: %w", err)
+	}
+	fmt.Println(string(bz))
+	return nil
+}
@@ -0,0 +1,37 @@
+package commands
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/cometbft/cometbft/v2/config"
+	"github.com/cometbft/cometbft/v2/privval"
+)
+
+func Test_StageValidatorKey(t *testing.T) {
+	testConfig := cfg.TestConfig()
+	dir := t.TempDir()
+	testConfig.SetRoot(dir)
+	cfg.EnsureRoot(dir)
+	require.NoError(t, initFilesWithConfig(testConfig))
+
+	prevConfig := config
+	config = testConfig
+	t.Cleanup(func() { config = prevConfig })
+
+	require.NoError(t, stageValidatorKey(nil, nil))
+	require.Error(t, stageValidatorKey(nil, nil), "next key already staged")
+
+	pv := privval.LoadFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile())
+	require.NoError(t, pv.LoadNextKey(config.PrivValidatorNextKeyFile(), nil))
+	require.NotNil(t, pv.NextKey)
+	require.NotEqual(t, pv.Key.Address, pv.NextKey.Address)
+
+	// Remote signers rotate their keys themselves.
+	require.NoError(t, os.Remove(config.PrivValidatorNextKeyFile()))
+	config.PrivValidatorListenAddr = "tcp://127.0.0.1:26659"
+	require.Error(t, stageValidatorKey(nil, nil))
+	require.NoFileExists(t, config.PrivValidatorNextKeyFile())
+}
@@ -30,6 +30,11 @@ func main() {
 		cmd.RollbackStateCmd,
 		cmd.CompactGoLevelDBCmd,
 		cmd.InspectCmd,
+		cmd.SlashingProtectionCmd,
+		cmd.EncryptKeysCmd,
+		cmd.DecryptKeysCmd,
+		cmd.StageValidatorKeyCmd,
+		cmd.SnapshotCmd,
 		debug.DebugCmd,
 		config.Command(),
 		cli.NewCompletionCmd(rootCmd, true),
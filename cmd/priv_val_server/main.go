@@ -2,9 +2,14 @@ package main
 
 import (
 	"flag"
+	"net"
 	"os"
 	"time"
 
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	pvproto "github.com/cometbft/cometbft/api/cometbft/privval/v2"
 	"github.com/cometbft/cometbft/v2/crypto/ed25519"
 	cmtnet "github.com/cometbft/cometbft/v2/internal/net"
 	cmtos "github.com/cometbft/cometbft/v2/internal/os"
@@ -18,6 +23,10 @@ func main() {
 		chainID          = flag.String("chain-id", "mychain", "chain id")
 		privValKeyPath   = flag.String("priv-key", "", "priv val key file path")
 		privValStatePath = flag.String("priv-state", "", "priv val state file path")
+		grpcLaddr        = flag.String("grpc-laddr", "", "Address to serve the PrivValidatorAPI gRPC service on, instead of connecting to addr")
+		tlsCertFile      = flag.String("tls-cert", "", "TLS certificate file of the gRPC service")
+		tlsKeyFile       = flag.String("tls-key", "", "TLS key file of the gRPC service")
+		tlsCAFile        = flag.String("tls-ca", "", "Certificate file of the CA authenticating gRPC clients")
 
 		logger = log.NewLogger(
 			os.Stdout,
@@ -35,6 +44,11 @@ func main() {
 
 	pv := privval.LoadFilePV(*privValKeyPath, *privValStatePath)
 
+	if *grpcLaddr != "" {
+		serveGRPC(*grpcLaddr, *chainID, pv, *tlsCertFile, *tlsKeyFile, *tlsCAFile, logger)
+		return
+	}
+
 	var dialer privval.SocketDialer
 	protocol, address := cmtnet.ProtocolAndAddress(*addr)
 	switch protocol {
@@ -67,3 +81,32 @@ func main() {
 	// Run forever.
 	select {}
 }
+
+func serveGRPC(addr, chainID string, pv *privval.FilePV, certFile, keyFile, caFile string, logger log.Logger) {
+	var opts []grpc.ServerOption
+	if certFile != "" || keyFile != "" || caFile != "" {
+		tlsConfig, err := privval.NewGRPCServerTLSConfig(certFile, keyFile, caFile)
+		if err != nil {
+			logger.Error("Failed to load TLS configuration", "err", err)
+			os.Exit(1)
+		}
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	protocol, address := cmtnet.ProtocolAndAddress(addr)
+	ln, err := net.Listen(protocol, address)
+	if err != nil {
+		logger.Error("Failed to listen", "addr", addr, "err", err)
+		os.Exit(1)
+	}
+
+	srv := grpc.NewServer(opts...)
+	pvproto.RegisterPrivValidatorAPIServer(srv, privval.NewGRPCSignerServer(chainID, pv, logger))
+
+	// Stop upon receiving SIGTERM or CTRL-C.
+	cmtos.TrapSignal(logger, srv.GracefulStop)
+
+	if err := srv.Serve(ln); err != nil {
+		panic(err)
+	}
+}
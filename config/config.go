@@ -9,6 +9,8 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -37,11 +39,14 @@ const (
 	DefaultConfigFileName  = "config.toml"
 	DefaultGenesisJSONName = "genesis.json"
 
-	DefaultPrivValKeyName   = "priv_validator_key.json"
-	DefaultPrivValStateName = "priv_validator_state.json"
+	DefaultPrivValKeyName     = "priv_validator_key.json"
+	DefaultPrivValNextKeyName = "priv_validator_next_key.json"
+	DefaultPrivValStateName   = "priv_validator_state.json"
 
-	DefaultNodeKeyName  = "node_key.json"
-	DefaultAddrBookName = "addrbook.json"
+	DefaultNodeKeyName        = "node_key.json"
+	DefaultAddrBookName       = "addrbook.json"
+	DefaultPeerReputationName = "peer_reputation.json"
+	DefaultFirewallName       = "firewall.json"
 
 	DefaultPruningInterval = 10 * time.Second
 
@@ -49,8 +54,12 @@ const (
 	v1 = "v1"
 	v2 = "v2"
 
-	MempoolTypeFlood = "flood"
-	MempoolTypeNop   = "nop"
+	MempoolTypeFlood    = "flood"
+	MempoolTypeNop      = "nop"
+	MempoolTypePriority = "priority"
+
+	P2PTransportTCP  = "tcp"
+	P2PTransportQUIC = "quic"
 )
 
 // NOTE: Most of the structs & relevant comments + the
@@ -60,13 +69,16 @@ const (
 // config/toml.go
 // NOTE: libs/cli must know to look in the config dir!
 var (
-	defaultConfigFilePath   = filepath.Join(DefaultConfigDir, DefaultConfigFileName)
-	defaultGenesisJSONPath  = filepath.Join(DefaultConfigDir, DefaultGenesisJSONName)
-	defaultPrivValKeyPath   = filepath.Join(DefaultConfigDir, DefaultPrivValKeyName)
-	defaultPrivValStatePath = filepath.Join(DefaultDataDir, DefaultPrivValStateName)
+	defaultConfigFilePath     = filepath.Join(DefaultConfigDir, DefaultConfigFileName)
+	defaultGenesisJSONPath    = filepath.Join(DefaultConfigDir, DefaultGenesisJSONName)
+	defaultPrivValKeyPath     = filepath.Join(DefaultConfigDir, DefaultPrivValKeyName)
+	defaultPrivValNextKeyPath = filepath.Join(DefaultConfigDir, DefaultPrivValNextKeyName)
+	defaultPrivValStatePath   = filepath.Join(DefaultDataDir, DefaultPrivValStateName)
 
-	defaultNodeKeyPath  = filepath.Join(DefaultConfigDir, DefaultNodeKeyName)
-	defaultAddrBookPath = filepath.Join(DefaultConfigDir, DefaultAddrBookName)
+	defaultNodeKeyPath        = filepath.Join(DefaultConfigDir, DefaultNodeKeyName)
+	defaultAddrBookPath       = filepath.Join(DefaultConfigDir, DefaultAddrBookName)
+	defaultPeerReputationPath = filepath.Join(DefaultConfigDir, DefaultPeerReputationName)
+	defaultFirewallPath       = filepath.Join(DefaultConfigDir, DefaultFirewallName)
 
 	minSubscriptionBufferSize     = 100
 	defaultSubscriptionBufferSize = 200
@@ -181,6 +193,9 @@ func (cfg *Config) ValidateBasic() error {
 	if err := cfg.Storage.ValidateBasic(); err != nil {
 		return fmt.Errorf("error in [storage] section: %w", err)
 	}
+	if err := cfg.TxIndex.ValidateBasic(); err != nil {
+		return ErrInSection{Section: "tx_index", Err: err}
+	}
 	if err := cfg.Instrumentation.ValidateBasic(); err != nil {
 		return ErrInSection{Section: "instrumentation", Err: err}
 	}
@@ -264,16 +279,50 @@ type BaseConfig struct {
 	// Path to the JSON file containing the private key to use as a validator in the consensus protocol
 	PrivValidatorKey string `mapstructure:"priv_validator_key_file"`
 
+	// Path to the JSON file containing the private key the validator switches
+	// to once it is in the validator set (see the stage-validator-key command)
+	PrivValidatorNextKey string `mapstructure:"priv_validator_next_key_file"`
+
 	// Path to the JSON file containing the last sign state of a validator
 	PrivValidatorState string `mapstructure:"priv_validator_state_file"`
 
 	// TCP or UNIX socket address for CometBFT to listen on for
-	// connections from an external PrivValidator process
+	// connections from an external PrivValidator process, or comma-separated
+	// list of addresses to fail over between several PrivValidator processes
 	PrivValidatorListenAddr string `mapstructure:"priv_validator_laddr"`
 
+	// gRPC address of an external PrivValidator process serving the
+	// PrivValidatorAPI service for CometBFT to connect to, or comma-separated
+	// list of addresses to fail over between several PrivValidator processes.
+	// Cannot be used together with priv_validator_laddr.
+	PrivValidatorGRPCAddr string `mapstructure:"priv_validator_grpc_addr"`
+
+	// Paths to the TLS certificate and key CometBFT authenticates itself with
+	// to the PrivValidator processes, and to the certificate of the CA
+	// authenticating the PrivValidator processes. Might be either absolute
+	// paths or paths relative to CometBFT's config directory.
+	//
+	// NOTE: all three files are required for TCP addresses. Connections over
+	// UNIX sockets may omit them to disable TLS.
+	PrivValidatorGRPCTLSCertFile string `mapstructure:"priv_validator_grpc_tls_cert_file"`
+	PrivValidatorGRPCTLSKeyFile  string `mapstructure:"priv_validator_grpc_tls_key_file"`
+	PrivValidatorGRPCTLSCAFile   string `mapstructure:"priv_validator_grpc_tls_ca_file"`
+
+	// Path to the JSON file describing a BLS12-381 validator key split between
+	// several external PrivValidator processes, a threshold of which must
+	// approve each signature (see privval.ThresholdSignerFile). Cannot be used
+	// together with priv_validator_laddr or priv_validator_grpc_addr.
+	PrivValidatorThreshold string `mapstructure:"priv_validator_threshold_file"`
+
 	// A JSON file containing the private key to use for p2p authenticated encryption
 	NodeKey string `mapstructure:"node_key_file"`
 
+	// Path to a file containing the passphrase of the private keys stored
+	// encrypted on disk (see the encrypt-keys command). If empty, the
+	// passphrase is read from the CMT_KEY_PASSPHRASE environment variable, or
+	// prompted for on startup.
+	KeyPassphraseFile string `mapstructure:"key_passphrase_file"`
+
 	// Mechanism to connect to the ABCI application: socket | grpc
 	ABCI string `mapstructure:"abci"`
 
@@ -285,20 +334,21 @@ type BaseConfig struct {
 // DefaultBaseConfig returns a default base configuration for a CometBFT node.
 func DefaultBaseConfig() BaseConfig {
 	return BaseConfig{
-		Version:            version.CMTSemVer,
-		Genesis:            defaultGenesisJSONPath,
-		PrivValidatorKey:   defaultPrivValKeyPath,
-		PrivValidatorState: defaultPrivValStatePath,
-		NodeKey:            defaultNodeKeyPath,
-		Moniker:            defaultMoniker,
-		ProxyApp:           "tcp://127.0.0.1:26658",
-		ABCI:               "socket",
-		LogLevel:           DefaultLogLevel,
-		LogFormat:          LogFormatPlain,
-		LogColors:          true,
-		FilterPeers:        false,
-		DBBackend:          "pebbledb",
-		DBPath:             DefaultDataDir,
+		Version:              version.CMTSemVer,
+		Genesis:              defaultGenesisJSONPath,
+		PrivValidatorKey:     defaultPrivValKeyPath,
+		PrivValidatorNextKey: defaultPrivValNextKeyPath,
+		PrivValidatorState:   defaultPrivValStatePath,
+		NodeKey:              defaultNodeKeyPath,
+		Moniker:              defaultMoniker,
+		ProxyApp:             "tcp://127.0.0.1:26658",
+		ABCI:                 "socket",
+		LogLevel:             DefaultLogLevel,
+		LogFormat:            LogFormatPlain,
+		LogColors:            true,
+		FilterPeers:          false,
+		DBBackend:            "pebbledb",
+		DBPath:               DefaultDataDir,
 	}
 }
 
@@ -320,16 +370,69 @@ func (cfg BaseConfig) PrivValidatorKeyFile() string {
 	return rootify(cfg.PrivValidatorKey, cfg.RootDir)
 }
 
+// PrivValidatorThresholdFile returns the full path to the threshold signer
+// file, or an empty string if it is not set.
+func (cfg BaseConfig) PrivValidatorThresholdFile() string {
+	if cfg.PrivValidatorThreshold == "" {
+		return ""
+	}
+	return rootify(cfg.PrivValidatorThreshold, cfg.RootDir)
+}
+
+// PrivValidatorNextKeyFile returns the full path to the
+// priv_validator_next_key.json file.
+func (cfg BaseConfig) PrivValidatorNextKeyFile() string {
+	return rootify(cfg.PrivValidatorNextKey, cfg.RootDir)
+}
+
 // PrivValidatorStateFile returns the full path to the priv_validator_state.json file.
 func (cfg BaseConfig) PrivValidatorStateFile() string {
 	return rootify(cfg.PrivValidatorState, cfg.RootDir)
 }
 
+// PrivValidatorGRPCCertFile returns the full path to the TLS certificate
+// used to connect to the PrivValidatorAPI.
+func (cfg BaseConfig) PrivValidatorGRPCCertFile() string {
+	return cfg.configFile(cfg.PrivValidatorGRPCTLSCertFile)
+}
+
+// PrivValidatorGRPCKeyFile returns the full path to the TLS key used to
+// connect to the PrivValidatorAPI.
+func (cfg BaseConfig) PrivValidatorGRPCKeyFile() string {
+	return cfg.configFile(cfg.PrivValidatorGRPCTLSKeyFile)
+}
+
+// PrivValidatorGRPCCAFile returns the full path to the certificate of the CA
+// authenticating the PrivValidatorAPI.
+func (cfg BaseConfig) PrivValidatorGRPCCAFile() string {
+	return cfg.configFile(cfg.PrivValidatorGRPCTLSCAFile)
+}
+
+// IsPrivValidatorGRPCTLSEnabled returns true if the TLS files used to connect
+// to the PrivValidatorAPI are set.
+func (cfg BaseConfig) IsPrivValidatorGRPCTLSEnabled() bool {
+	return cfg.PrivValidatorGRPCTLSCertFile != "" && cfg.PrivValidatorGRPCTLSKeyFile != "" &&
+		cfg.PrivValidatorGRPCTLSCAFile != ""
+}
+
+func (cfg BaseConfig) configFile(path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return rootify(filepath.Join(DefaultConfigDir, path), cfg.RootDir)
+}
+
 // NodeKeyFile returns the full path to the node_key.json file.
 func (cfg BaseConfig) NodeKeyFile() string {
 	return rootify(cfg.NodeKey, cfg.RootDir)
 }
 
+// KeyPassphrasePath returns the full path to the file containing the
+// passphrase of the encrypted keys, or an empty string if it is not set.
+func (cfg BaseConfig) KeyPassphrasePath() string {
+	return cfg.configFile(cfg.KeyPassphraseFile)
+}
+
 // DBDir returns the full path to the database directory.
 func (cfg BaseConfig) DBDir() string {
 	return rootify(cfg.DBPath, cfg.RootDir)
@@ -350,9 +453,40 @@ func (cfg BaseConfig) ValidateBasic() error {
 		return errors.New("unknown log_format (must be 'plain' or 'json')")
 	}
 
+	if err := cfg.validatePrivValidatorGRPC(); err != nil {
+		return err
+	}
+	if cfg.PrivValidatorThreshold != "" && (cfg.PrivValidatorListenAddr != "" || cfg.PrivValidatorGRPCAddr != "") {
+		return errors.New("priv_validator_threshold_file cannot be set together with priv_validator_laddr or priv_validator_grpc_addr")
+	}
+
 	return cfg.validateProxyApp()
 }
 
+func (cfg BaseConfig) validatePrivValidatorGRPC() error {
+	tlsFiles := []string{cfg.PrivValidatorGRPCTLSCertFile, cfg.PrivValidatorGRPCTLSKeyFile, cfg.PrivValidatorGRPCTLSCAFile}
+	if !cfg.IsPrivValidatorGRPCTLSEnabled() && slices.ContainsFunc(tlsFiles, func(f string) bool { return f != "" }) {
+		return errors.New("priv_validator_grpc_tls_cert_file, priv_validator_grpc_tls_key_file and " +
+			"priv_validator_grpc_tls_ca_file must all be set, or none of them")
+	}
+	if cfg.PrivValidatorGRPCAddr == "" {
+		return nil
+	}
+	if cfg.PrivValidatorListenAddr != "" {
+		return errors.New("priv_validator_laddr and priv_validator_grpc_addr cannot both be set")
+	}
+	for _, addr := range strings.Split(cfg.PrivValidatorGRPCAddr, ",") {
+		protocol, _, found := strings.Cut(strings.TrimSpace(addr), "://")
+		switch {
+		case !found || (protocol != "tcp" && protocol != "unix"):
+			return fmt.Errorf("invalid priv_validator_grpc_addr %q: expected tcp:// or unix:// address", addr)
+		case protocol == "tcp" && !cfg.IsPrivValidatorGRPCTLSEnabled():
+			return fmt.Errorf("priv_validator_grpc_addr %q requires the priv_validator_grpc_tls files to be set", addr)
+		}
+	}
+	return nil
+}
+
 func (cfg BaseConfig) validateProxyApp() error {
 	if cfg.ProxyApp == "" {
 		return errors.New("proxy_app cannot be empty")
@@ -628,6 +762,22 @@ type GRPCConfig struct {
 	// If no height is provided, the block results of the latest height are returned
 	BlockResultsService *GRPCBlockResultsServiceConfig `mapstructure:"block_results_service"`
 
+	// The gRPC event service allows clients to subscribe to the events
+	// published by the node
+	EventService *GRPCEventServiceConfig `mapstructure:"event_service"`
+
+	// The gRPC tx service allows clients to broadcast, look up and search
+	// transactions
+	TxService *GRPCTxServiceConfig `mapstructure:"tx_service"`
+
+	// The gRPC mempool service provides information about the transactions in
+	// the mempool
+	MempoolService *GRPCMempoolServiceConfig `mapstructure:"mempool_service"`
+
+	// The gRPC network service provides information about the peers of the
+	// node and its consensus state
+	NetworkService *GRPCNetworkServiceConfig `mapstructure:"network_service"`
+
 	// The "privileged" section provides configuration for the gRPC server
 	// dedicated to privileged clients.
 	Privileged *GRPCPrivilegedConfig `mapstructure:"privileged"`
@@ -639,6 +789,10 @@ func DefaultGRPCConfig() *GRPCConfig {
 		VersionService:      DefaultGRPCVersionServiceConfig(),
 		BlockService:        DefaultGRPCBlockServiceConfig(),
 		BlockResultsService: DefaultGRPCBlockResultsServiceConfig(),
+		EventService:        DefaultGRPCEventServiceConfig(),
+		TxService:           DefaultGRPCTxServiceConfig(),
+		MempoolService:      DefaultGRPCMempoolServiceConfig(),
+		NetworkService:      DefaultGRPCNetworkServiceConfig(),
 		Privileged:          DefaultGRPCPrivilegedConfig(),
 	}
 }
@@ -649,6 +803,10 @@ func TestGRPCConfig() *GRPCConfig {
 		VersionService:      TestGRPCVersionServiceConfig(),
 		BlockService:        TestGRPCBlockServiceConfig(),
 		BlockResultsService: DefaultGRPCBlockResultsServiceConfig(),
+		EventService:        TestGRPCEventServiceConfig(),
+		TxService:           TestGRPCTxServiceConfig(),
+		MempoolService:      TestGRPCMempoolServiceConfig(),
+		NetworkService:      TestGRPCNetworkServiceConfig(),
 		Privileged:          TestGRPCPrivilegedConfig(),
 	}
 }
@@ -663,6 +821,9 @@ func (cfg *GRPCConfig) ValidateBasic() error {
 			)
 		}
 	}
+	if cfg.TxService != nil && cfg.TxService.TimeoutBroadcastTxCommit < 0 {
+		return cmterrors.ErrNegativeField{Field: "tx_service.timeout_broadcast_tx_commit"}
+	}
 	return nil
 }
 
@@ -708,6 +869,76 @@ func TestGRPCBlockServiceConfig() *GRPCBlockServiceConfig {
 	}
 }
 
+type GRPCEventServiceConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
+func DefaultGRPCEventServiceConfig() *GRPCEventServiceConfig {
+	return &GRPCEventServiceConfig{
+		Enabled: true,
+	}
+}
+
+func TestGRPCEventServiceConfig() *GRPCEventServiceConfig {
+	return &GRPCEventServiceConfig{
+		Enabled: true,
+	}
+}
+
+type GRPCTxServiceConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+
+	// How long to wait for a tx to be committed when it is broadcast in
+	// commit mode.
+	TimeoutBroadcastTxCommit time.Duration `mapstructure:"timeout_broadcast_tx_commit"`
+}
+
+func DefaultGRPCTxServiceConfig() *GRPCTxServiceConfig {
+	return &GRPCTxServiceConfig{
+		Enabled:                  true,
+		TimeoutBroadcastTxCommit: 10 * time.Second,
+	}
+}
+
+func TestGRPCTxServiceConfig() *GRPCTxServiceConfig {
+	return &GRPCTxServiceConfig{
+		Enabled:                  true,
+		TimeoutBroadcastTxCommit: 10 * time.Second,
+	}
+}
+
+type GRPCMempoolServiceConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
+func DefaultGRPCMempoolServiceConfig() *GRPCMempoolServiceConfig {
+	return &GRPCMempoolServiceConfig{
+		Enabled: false,
+	}
+}
+
+func TestGRPCMempoolServiceConfig() *GRPCMempoolServiceConfig {
+	return &GRPCMempoolServiceConfig{
+		Enabled: true,
+	}
+}
+
+type GRPCNetworkServiceConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
+func DefaultGRPCNetworkServiceConfig() *GRPCNetworkServiceConfig {
+	return &GRPCNetworkServiceConfig{
+		Enabled: false,
+	}
+}
+
+func TestGRPCNetworkServiceConfig() *GRPCNetworkServiceConfig {
+	return &GRPCNetworkServiceConfig{
+		Enabled: true,
+	}
+}
+
 // -----------------------------------------------------------------------------
 // GRPCPrivilegedConfig
 
@@ -763,6 +994,11 @@ type P2PConfig struct { //nolint: maligned
 	// Address to listen for incoming connections
 	ListenAddress string `mapstructure:"laddr"`
 
+	// Transport used to connect to peers: "tcp" (multiplexed connections
+	// encrypted with SecretConnection) or "quic" (one QUIC stream per
+	// channel)
+	Transport string `mapstructure:"transport"`
+
 	// Address to advertise to peers for them to dial
 	ExternalAddress string `mapstructure:"external_address"`
 
@@ -780,6 +1016,13 @@ type P2PConfig struct { //nolint: maligned
 	// Set false for private or local networks
 	AddrBookStrict bool `mapstructure:"addr_book_strict"`
 
+	// Path to the file storing the reputation of peers
+	PeerReputation string `mapstructure:"peer_reputation_file"`
+
+	// Path to the file of the firewall rules, which allow or deny peers by
+	// node ID or CIDR. The file is reloaded when it changes.
+	Firewall string `mapstructure:"firewall_file"`
+
 	// Maximum number of inbound peers
 	MaxNumInboundPeers int `mapstructure:"max_num_inbound_peers"`
 
@@ -804,6 +1047,17 @@ type P2PConfig struct { //nolint: maligned
 	// Rate at which packets can be received, in bytes/second
 	RecvRate int64 `mapstructure:"recv_rate"`
 
+	// Comma separated list of per-peer rates at which messages can be sent on
+	// a given channel, in bytes/second. Each entry is "<channel ID>:<rate>",
+	// e.g. "0x30:102400" limits the mempool channel to 100 kB/s.
+	ChannelSendRates string `mapstructure:"channel_send_rates"`
+
+	// Comma separated list of per-peer rates at which messages can be
+	// received on a given channel, in bytes/second, with the same format as
+	// ChannelSendRates. Messages received above the rate are dropped, so only
+	// the mempool (0x30) and evidence (0x38) channels can be limited.
+	ChannelRecvRates string `mapstructure:"channel_recv_rates"`
+
 	// Set true to enable the peer-exchange reactor
 	PexReactor bool `mapstructure:"pex"`
 
@@ -832,9 +1086,12 @@ type P2PConfig struct { //nolint: maligned
 func DefaultP2PConfig() *P2PConfig {
 	return &P2PConfig{
 		ListenAddress:                "tcp://0.0.0.0:26656",
+		Transport:                    P2PTransportTCP,
 		ExternalAddress:              "",
 		AddrBook:                     defaultAddrBookPath,
 		AddrBookStrict:               true,
+		PeerReputation:               defaultPeerReputationPath,
+		Firewall:                     defaultFirewallPath,
 		MaxNumInboundPeers:           40,
 		MaxNumOutboundPeers:          10,
 		PersistentPeersMaxDialPeriod: 0 * time.Second,
@@ -864,9 +1121,69 @@ func (cfg *P2PConfig) AddrBookFile() string {
 	return rootify(cfg.AddrBook, cfg.RootDir)
 }
 
+// PeerReputationFile returns the full path to the peer reputation file.
+func (cfg *P2PConfig) PeerReputationFile() string {
+	return rootify(cfg.PeerReputation, cfg.RootDir)
+}
+
+// FirewallFile returns the full path to the firewall rules file.
+func (cfg *P2PConfig) FirewallFile() string {
+	return rootify(cfg.Firewall, cfg.RootDir)
+}
+
+// ChannelSendRateLimits returns the send rates of the channels, in
+// bytes/second, by channel ID.
+func (cfg *P2PConfig) ChannelSendRateLimits() (map[byte]int64, error) {
+	return parseChannelRates(cfg.ChannelSendRates)
+}
+
+// ChannelRecvRateLimits returns the receive rates of the channels, in
+// bytes/second, by channel ID.
+func (cfg *P2PConfig) ChannelRecvRateLimits() (map[byte]int64, error) {
+	return parseChannelRates(cfg.ChannelRecvRates)
+}
+
+// parseChannelRates parses a comma separated list of "<channel ID>:<rate>"
+// entries. The channel ID may be decimal or hexadecimal (with the 0x prefix).
+func parseChannelRates(s string) (map[byte]int64, error) {
+	rates := make(map[byte]int64)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		chIDStr, rateStr, ok := strings.Cut(entry, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid entry %q, expected <channel ID>:<rate>", entry)
+		}
+		chID, err := strconv.ParseUint(strings.TrimSpace(chIDStr), 0, 8)
+		if err != nil {
+			return nil, fmt.Errorf("invalid channel ID in %q: %w", entry, err)
+		}
+		rate, err := strconv.ParseInt(strings.TrimSpace(rateStr), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate in %q: %w", entry, err)
+		}
+		if rate <= 0 {
+			return nil, fmt.Errorf("rate in %q must be positive", entry)
+		}
+		if _, ok := rates[byte(chID)]; ok {
+			return nil, fmt.Errorf("duplicate channel ID %#x", chID)
+		}
+		rates[byte(chID)] = rate
+	}
+	return rates, nil
+}
+
 // ValidateBasic performs basic validation (checking param bounds, etc.) and
 // returns an error if any check fails.
 func (cfg *P2PConfig) ValidateBasic() error {
+	switch cfg.Transport {
+	case P2PTransportTCP, P2PTransportQUIC:
+	case "": // allow empty string to be backwards compatible
+	default:
+		return fmt.Errorf("unknown p2p transport: %q", cfg.Transport)
+	}
 	if cfg.MaxNumInboundPeers < 0 {
 		return cmterrors.ErrNegativeField{Field: "max_num_inbound_peers"}
 	}
@@ -888,6 +1205,12 @@ func (cfg *P2PConfig) ValidateBasic() error {
 	if cfg.RecvRate < 0 {
 		return cmterrors.ErrNegativeField{Field: "recv_rate"}
 	}
+	if _, err := cfg.ChannelSendRateLimits(); err != nil {
+		return fmt.Errorf("channel_send_rates: %w", err)
+	}
+	if _, err := cfg.ChannelRecvRateLimits(); err != nil {
+		return fmt.Errorf("channel_recv_rates: %w", err)
+	}
 	return nil
 }
 
@@ -929,6 +1252,9 @@ type MempoolConfig struct {
 	//  - "nop"   : nop-mempool (short for no operation; the ABCI app is
 	//  responsible for storing, disseminating and proposing txs).
 	//  "create_empty_blocks=false" is not supported.
+	//  - "priority" : mempool that orders transactions by the priority
+	//  assigned by the app in CheckTx and evicts the lowest priority ones when
+	//  full. Lanes are not supported.
 	Type string `mapstructure:"type"`
 	// RootDir is the root directory for all data. This should be configured via
 	// the $CMTHOME env variable or --home cmd flag rather than overriding this
@@ -966,6 +1292,23 @@ type MempoolConfig struct {
 	// Set to true if it's not possible for any invalid transaction to become
 	// valid again in the future.
 	KeepInvalidTxsInCache bool `mapstructure:"keep-invalid-txs-in-cache"`
+	// TTLDuration, if non-zero, defines the maximum amount of time a transaction
+	// can exist in the mempool before it is removed, during the next Update.
+	TTLDuration time.Duration `mapstructure:"ttl_duration"`
+	// TTLNumBlocks, if non-zero, defines the maximum number of blocks a
+	// transaction can exist in the mempool before it is removed, during the
+	// next Update.
+	TTLNumBlocks int64 `mapstructure:"ttl_num_blocks"`
+	// MaxHeldTxsPerSender is the maximum number of transactions of a sender
+	// held by the mempool because of a gap in their sequence numbers. Held
+	// transactions do not count towards Size and MaxTxsBytes, but are limited
+	// to as many transactions and bytes in total, in addition to the others.
+	MaxHeldTxsPerSender int `mapstructure:"max_held_txs_per_sender"`
+	// Journal, if true, persists the transactions in the mempool to a database
+	// (using the node's db_backend), so they are restored after a restart.
+	// Transactions that were committed in the meantime are dropped, and the
+	// rest are validated again with CheckTx before the node starts gossiping.
+	Journal bool `mapstructure:"journal"`
 	// Experimental parameters to limit gossiping txs to up to the specified number of peers.
 	// We use two independent upper values for persistent and non-persistent peers.
 	// Unconditional peers are not affected by this feature.
@@ -1021,6 +1364,7 @@ func DefaultMempoolConfig() *MempoolConfig {
 		DOGProtocolEnabled:  false,
 		DOGTargetRedundancy: 1,
 		DOGAdjustInterval:   1000 * time.Millisecond,
+		MaxHeldTxsPerSender: 16,
 	}
 }
 
@@ -1035,7 +1379,7 @@ func TestMempoolConfig() *MempoolConfig {
 // returns an error if any check fails.
 func (cfg *MempoolConfig) ValidateBasic() error {
 	switch cfg.Type {
-	case MempoolTypeFlood, MempoolTypeNop:
+	case MempoolTypeFlood, MempoolTypeNop, MempoolTypePriority:
 	case "": // allow empty string to be backwards compatible
 	default:
 		return fmt.Errorf("unknown mempool type: %q", cfg.Type)
@@ -1052,6 +1396,15 @@ func (cfg *MempoolConfig) ValidateBasic() error {
 	if cfg.MaxTxBytes < 0 {
 		return cmterrors.ErrNegativeField{Field: "max_tx_bytes"}
 	}
+	if cfg.TTLDuration < 0 {
+		return cmterrors.ErrNegativeField{Field: "ttl_duration"}
+	}
+	if cfg.TTLNumBlocks < 0 {
+		return cmterrors.ErrNegativeField{Field: "ttl_num_blocks"}
+	}
+	if cfg.MaxHeldTxsPerSender < 0 {
+		return cmterrors.ErrNegativeField{Field: "max_held_txs_per_sender"}
+	}
 	if cfg.ExperimentalMaxGossipConnectionsToPersistentPeers < 0 {
 		return cmterrors.ErrNegativeField{Field: "experimental_max_gossip_connections_to_persistent_peers"}
 	}
@@ -1059,7 +1412,7 @@ func (cfg *MempoolConfig) ValidateBasic() error {
 		return cmterrors.ErrNegativeField{Field: "experimental_max_gossip_connections_to_non_persistent_peers"}
 	}
 
-	// Flood mempool with zero capacity is not allowed.
+	// Flood and priority mempools with zero capacity are not allowed.
 	if cfg.Type != MempoolTypeNop {
 		if cfg.Size == 0 {
 			return cmterrors.ErrNegativeOrZeroField{Field: "size"}
@@ -1072,6 +1425,13 @@ func (cfg *MempoolConfig) ValidateBasic() error {
 		}
 	}
 
+	if cfg.Type == MempoolTypeNop && cfg.Journal {
+		return cmterrors.ErrWrongField{
+			Field: "journal",
+			Err:   errors.New("the journal cannot be used with the nop mempool type"),
+		}
+	}
+
 	// DOG gossip protocol
 	if cfg.Type != MempoolTypeFlood && cfg.DOGProtocolEnabled {
 		return cmterrors.ErrWrongField{
@@ -1105,12 +1465,20 @@ type StateSyncConfig struct {
 	Enable              bool          `mapstructure:"enable"`
 	TempDir             string        `mapstructure:"temp_dir"`
 	RPCServers          []string      `mapstructure:"rpc_servers"`
+	UseP2P              bool          `mapstructure:"use_p2p"`
 	TrustPeriod         time.Duration `mapstructure:"trust_period"`
 	TrustHeight         int64         `mapstructure:"trust_height"`
 	TrustHash           string        `mapstructure:"trust_hash"`
 	MaxDiscoveryTime    time.Duration `mapstructure:"max_discovery_time"`
 	ChunkRequestTimeout time.Duration `mapstructure:"chunk_request_timeout"`
 	ChunkFetchers       int32         `mapstructure:"chunk_fetchers"`
+
+	// Interval, in blocks, at which the node takes snapshots of its own state
+	// and recent blocks, which it serves to state syncing peers along with the
+	// application snapshots. 0 disables node snapshots.
+	NodeSnapshotInterval int64 `mapstructure:"node_snapshot_interval"`
+	// Number of recent node snapshots to keep.
+	NodeSnapshotKeepRecent int `mapstructure:"node_snapshot_keep_recent"`
 }
 
 func (cfg *StateSyncConfig) TrustHashBytes() []byte {
@@ -1125,10 +1493,12 @@ func (cfg *StateSyncConfig) TrustHashBytes() []byte {
 // DefaultStateSyncConfig returns a default configuration for the state sync service.
 func DefaultStateSyncConfig() *StateSyncConfig {
 	return &StateSyncConfig{
-		TrustPeriod:         168 * time.Hour,
-		MaxDiscoveryTime:    2 * time.Minute,
-		ChunkRequestTimeout: 10 * time.Second,
-		ChunkFetchers:       4,
+		TrustPeriod:            168 * time.Hour,
+		MaxDiscoveryTime:       2 * time.Minute,
+		ChunkRequestTimeout:    10 * time.Second,
+		ChunkFetchers:          4,
+		NodeSnapshotInterval:   0,
+		NodeSnapshotKeepRecent: 2,
 	}
 }
 
@@ -1140,17 +1510,20 @@ func TestStateSyncConfig() *StateSyncConfig {
 // ValidateBasic performs basic validation.
 func (cfg *StateSyncConfig) ValidateBasic() error {
 	if cfg.Enable {
-		if len(cfg.RPCServers) == 0 {
-			return cmterrors.ErrRequiredField{Field: "rpc_servers"}
-		}
+		// The light blocks are fetched from peers instead of RPC servers.
+		if !cfg.UseP2P {
+			if len(cfg.RPCServers) == 0 {
+				return cmterrors.ErrRequiredField{Field: "rpc_servers"}
+			}
 
-		if len(cfg.RPCServers) < 2 {
-			return ErrNotEnoughRPCServers
-		}
+			if len(cfg.RPCServers) < 2 {
+				return ErrNotEnoughRPCServers
+			}
 
-		for _, server := range cfg.RPCServers {
-			if len(server) == 0 {
-				return ErrEmptyRPCServerEntry
+			for _, server := range cfg.RPCServers {
+				if len(server) == 0 {
+					return ErrEmptyRPCServerEntry
+				}
 			}
 		}
 
@@ -1184,6 +1557,14 @@ func (cfg *StateSyncConfig) ValidateBasic() error {
 		}
 	}
 
+	if cfg.NodeSnapshotInterval < 0 {
+		return cmterrors.ErrNegativeField{Field: "node_snapshot_interval"}
+	}
+
+	if cfg.NodeSnapshotInterval > 0 && cfg.NodeSnapshotKeepRecent <= 0 {
+		return cmterrors.ErrRequiredField{Field: "node_snapshot_keep_recent"}
+	}
+
 	return nil
 }
 
@@ -1459,6 +1840,9 @@ type TxIndexConfig struct {
 	//   2) "kv" (default) - the simplest possible indexer,
 	//      backed by key-value storage (defaults to levelDB; see DBBackend).
 	//   3) "psql" - the indexer services backed by PostgreSQL.
+	//   4) "sqlite" - the indexer services backed by an embedded SQLite
+	//      database, stored in the tx_index.sqlite file of DBDir. It is only
+	//      supported by binaries built with cgo.
 	Indexer string `mapstructure:"indexer"`
 
 	// The PostgreSQL connection configuration, the connection format:
@@ -1487,6 +1871,15 @@ func TestTxIndexConfig() *TxIndexConfig {
 	return DefaultTxIndexConfig()
 }
 
+// ValidateBasic performs basic validation and returns an error if any check
+// fails.
+func (cfg *TxIndexConfig) ValidateBasic() error {
+	if strings.EqualFold(cfg.Indexer, "sqlite") && !sqliteIndexerSupported {
+		return ErrSQLiteIndexerNotSupported
+	}
+	return nil
+}
+
 // -----------------------------------------------------------------------------
 // InstrumentationConfig
 
@@ -58,16 +58,51 @@ genesis_file = "{{ js .BaseConfig.Genesis }}"
 # Path to the JSON file containing the private key to use as a validator in the consensus protocol
 priv_validator_key_file = "{{ js .BaseConfig.PrivValidatorKey }}"
 
+# Path to the JSON file containing the private key the validator switches to
+# once the application added it to the validator set (see the
+# stage-validator-key command)
+priv_validator_next_key_file = "{{ js .BaseConfig.PrivValidatorNextKey }}"
+
 # Path to the JSON file containing the last sign state of a validator
 priv_validator_state_file = "{{ js .BaseConfig.PrivValidatorState }}"
 
 # TCP or UNIX socket address for CometBFT to listen on for
-# connections from an external PrivValidator process
+# connections from an external PrivValidator process.
+# Comma-separated list of addresses to fail over between several
+# PrivValidator processes holding the same key.
 priv_validator_laddr = "{{ .BaseConfig.PrivValidatorListenAddr }}"
 
+# gRPC address (tcp:// or unix://) of an external PrivValidator process serving
+# the PrivValidatorAPI service, for CometBFT to connect to. Comma-separated list
+# of addresses to fail over between several PrivValidator processes holding the
+# same key. Cannot be used together with priv_validator_laddr.
+priv_validator_grpc_addr = "{{ .BaseConfig.PrivValidatorGRPCAddr }}"
+
+# Paths to the TLS certificate and key CometBFT authenticates itself with to the
+# PrivValidator processes, and to the certificate of the CA authenticating them,
+# relative to the config directory or absolute.
+# NOTE: all three files are required for TCP addresses. Connections over UNIX
+# sockets may omit them to disable TLS.
+priv_validator_grpc_tls_cert_file = "{{ js .BaseConfig.PrivValidatorGRPCTLSCertFile }}"
+priv_validator_grpc_tls_key_file = "{{ js .BaseConfig.PrivValidatorGRPCTLSKeyFile }}"
+priv_validator_grpc_tls_ca_file = "{{ js .BaseConfig.PrivValidatorGRPCTLSCAFile }}"
+
+# Path to the JSON file describing a BLS12-381 validator key split between
+# several external PrivValidator processes, a threshold of which must approve
+# each signature. CometBFT listens for a connection from each of them on the
+# address given in the file. Cannot be used together with priv_validator_laddr
+# or priv_validator_grpc_addr.
+priv_validator_threshold_file = "{{ js .BaseConfig.PrivValidatorThreshold }}"
+
 # Path to the JSON file containing the private key to use for node authentication in the p2p protocol
 node_key_file = "{{ js .BaseConfig.NodeKey }}"
 
+# Path to a file containing the passphrase of the private keys stored encrypted
+# on disk (see the encrypt-keys command), relative to the config directory. If
+# empty, the passphrase is read from the CMT_KEY_PASSPHRASE environment
+# variable, or prompted for on startup.
+key_passphrase_file = "{{ js .BaseConfig.KeyPassphraseFile }}"
+
 # Mechanism to connect to the ABCI application: socket | grpc
 abci = "{{ .BaseConfig.ABCI }}"
 
@@ -221,6 +256,38 @@ enabled = {{ .GRPC.BlockService.Enabled }}
 [grpc.block_results_service]
 enabled = {{ .GRPC.BlockResultsService.Enabled }}
 
+# The gRPC event service allows clients to subscribe to the events published by
+# the node, using the same query language as the "subscribe" JSON-RPC method.
+# Subscriptions are bounded by rpc.max_subscription_clients and
+# rpc.max_subscriptions_per_client, a client being a gRPC connection.
+[grpc.event_service]
+enabled = {{ .GRPC.EventService.Enabled }}
+
+# The gRPC tx service allows clients to broadcast transactions, to check them
+# against the application, and to look up and search the indexed transactions.
+[grpc.tx_service]
+enabled = {{ .GRPC.TxService.Enabled }}
+
+# How long to wait for a tx to be committed when it is broadcast in commit mode.
+timeout_broadcast_tx_commit = "{{ .GRPC.TxService.TimeoutBroadcastTxCommit }}"
+
+# The gRPC mempool service returns the transactions in the mempool and the
+# occupancy of its lanes, and streams the transactions admitted to and evicted
+# from the mempool. Enabling it makes the mempool publish PendingTx events.
+# Watching transactions counts as an event subscription against
+# rpc.max_subscription_clients and rpc.max_subscriptions_per_client.
+#
+# Disabled by default.
+[grpc.mempool_service]
+enabled = {{ .GRPC.MempoolService.Enabled }}
+
+# The gRPC network service returns the peers of the node and a summary of its
+# consensus state.
+#
+# Disabled by default.
+[grpc.network_service]
+enabled = {{ .GRPC.NetworkService.Enabled }}
+
 #
 # Configuration for privileged gRPC endpoints, which should **never** be exposed
 # to the public internet.
@@ -250,6 +317,15 @@ enabled = {{ .GRPC.Privileged.PruningService.Enabled }}
 # Address to listen for incoming connections
 laddr = "{{ .P2P.ListenAddress }}"
 
+# Transport used to connect to peers:
+#   1) "tcp" - (default) all channels are multiplexed over a single TCP
+#      connection, encrypted and authenticated with SecretConnection.
+#   2) "quic" - every channel is sent on its own QUIC stream over UDP, so
+#      that slow channels (e.g. mempool) do not delay the others (e.g.
+#      consensus). The node listens on the UDP port of laddr. Nodes can only
+#      connect to peers using the same transport.
+transport = "{{ .P2P.Transport }}"
+
 # Address to advertise to peers for them to dial. If empty, will use the same
 # port as the laddr, and will introspect on the listener to figure out the
 # address. IP and port are required. Example: 159.89.10.97:26656
@@ -268,6 +344,19 @@ addr_book_file = "{{ js .P2P.AddrBook }}"
 # Set false for private or local networks
 addr_book_strict = {{ .P2P.AddrBookStrict }}
 
+# Path to the file storing the reputation of peers. Reactors report the
+# behaviour of peers, which is used to prefer well-behaved peers when dialing,
+# and to ban misbehaving peers for increasingly long durations.
+peer_reputation_file = "{{ js .P2P.PeerReputation }}"
+
+# Path to the JSON file of the firewall rules, which allow or deny peers by
+# node ID or IP range (CIDR), e.g.
+#   {"deny_ids": ["<node ID>"], "deny_cidrs": ["203.0.113.0/24"]}
+# The file is reloaded when it changes, and updated by the unsafe
+# add_firewall_rules and remove_firewall_rules RPC routes. A missing file means
+# there are no rules.
+firewall_file = "{{ js .P2P.Firewall }}"
+
 # Maximum number of inbound peers
 max_num_inbound_peers = {{ .P2P.MaxNumInboundPeers }}
 
@@ -292,6 +381,19 @@ send_rate = {{ .P2P.SendRate }}
 # Rate at which packets can be received, in bytes/second
 recv_rate = {{ .P2P.RecvRate }}
 
+# Comma separated list of per-peer rates at which messages can be sent on a
+# given channel, in bytes/second. Each entry is "<channel ID>:<rate>", e.g.
+# "0x30:102400" limits the mempool channel to 100 kB/s for every peer.
+# Rate limited channels do not delay the other channels.
+channel_send_rates = "{{ .P2P.ChannelSendRates }}"
+
+# Comma separated list of per-peer rates at which messages can be received on
+# a given channel, in bytes/second, with the same format as channel_send_rates.
+# With the tcp transport, messages received above the rate are dropped; with
+# the quic transport, the peer is slowed down on this channel only. Only the
+# mempool (0x30) and evidence (0x38) channels can be limited.
+channel_recv_rates = "{{ .P2P.ChannelRecvRates }}"
+
 # Set true to enable the peer-exchange reactor
 pex = {{ .P2P.PexReactor }}
 
@@ -320,6 +422,9 @@ allow_duplicate_ip = {{ .P2P.AllowDuplicateIP }}
 #  - "nop"   : nop-mempool (short for no operation; the ABCI app is responsible
 #  for storing, disseminating and proposing txs). "create_empty_blocks=false" is
 #  not supported.
+#  - "priority" : mempool that orders transactions by the priority assigned by
+#  the app in CheckTx and evicts the lowest priority ones when full. Lanes are
+#  not supported.
 type = "{{ .Mempool.Type }}"
 
 # recheck (default: true) defines whether CometBFT should recheck the
@@ -362,6 +467,26 @@ cache_size = {{ .Mempool.CacheSize }}
 # again in the future.
 keep-invalid-txs-in-cache = {{ .Mempool.KeepInvalidTxsInCache }}
 
+# ttl_duration, if non-zero, defines the maximum amount of time a transaction
+# can exist in the mempool before it is removed.
+ttl_duration = "{{ .Mempool.TTLDuration }}"
+
+# ttl_num_blocks, if non-zero, defines the maximum number of blocks a
+# transaction can exist in the mempool before it is removed.
+ttl_num_blocks = {{ .Mempool.TTLNumBlocks }}
+
+# max_held_txs_per_sender is the maximum number of transactions of a sender held
+# because of a gap in their sequence numbers (see CheckTxResponse.sequence).
+# Held transactions do not count towards size and max_txs_bytes, but are limited
+# to as many transactions and bytes in total, in addition to the others.
+max_held_txs_per_sender = {{ .Mempool.MaxHeldTxsPerSender }}
+
+# journal, if true, persists the transactions in the mempool to a database
+# (using db_backend), so they are restored after a restart. Transactions that
+# were committed in the meantime are dropped, and the rest are validated again
+# with CheckTx before the node starts gossiping.
+journal = {{ .Mempool.Journal }}
+
 # Experimental parameters to limit gossiping txs to up to the specified number of peers.
 # We use two independent upper values for persistent and non-persistent peers.
 # Unconditional peers are not affected by this feature.
@@ -419,6 +544,10 @@ trust_height = {{ .StateSync.TrustHeight }}
 trust_hash = "{{ .StateSync.TrustHash }}"
 trust_period = "{{ .StateSync.TrustPeriod }}"
 
+# Fetch the light blocks and consensus parameters for light client verification from peers over
+# p2p, instead of from rpc_servers, which are then not required. At least 2 peers are needed.
+use_p2p = {{ .StateSync.UseP2P }}
+
 # Time to spend discovering snapshots before switching to blocksync. If set to
 # 0, state sync will be trying indefinitely.
 max_discovery_time = "{{ .StateSync.MaxDiscoveryTime }}"
@@ -434,6 +563,15 @@ chunk_request_timeout = "{{ .StateSync.ChunkRequestTimeout }}"
 # The number of concurrent chunk fetchers to run (default: 1).
 chunk_fetchers = "{{ .StateSync.ChunkFetchers }}"
 
+# Interval, in blocks, at which the node takes snapshots of its own state and recent blocks,
+# which it serves to state syncing peers along with the application snapshots. Set it to the
+# snapshot interval of the application, so that the node snapshots match the application
+# snapshots. 0 disables node snapshots.
+node_snapshot_interval = {{ .StateSync.NodeSnapshotInterval }}
+
+# Number of recent node snapshots to keep.
+node_snapshot_keep_recent = {{ .StateSync.NodeSnapshotKeepRecent }}
+
 #######################################################
 ###       Block Sync Configuration Options          ###
 #######################################################
@@ -560,7 +698,9 @@ initial_block_results_retain_height = {{ .Storage.Pruning.DataCompanion.InitialB
 #   2) "kv" (default) - the simplest possible indexer, backed by key-value storage (defaults to levelDB; see DBBackend).
 # 		- When "kv" is chosen "tx.height" and "tx.hash" will always be indexed.
 #   3) "psql" - the indexer services backed by PostgreSQL.
-# When "kv" or "psql" is chosen "tx.height" and "tx.hash" will always be indexed.
+#   4) "sqlite" - the indexer services backed by an embedded SQLite database
+#      (the tx_index.sqlite file in db_dir). It requires a binary built with cgo.
+# When "kv", "psql" or "sqlite" is chosen "tx.height" and "tx.hash" will always be indexed.
 indexer = "{{ .TxIndex.Indexer }}"
 
 # The PostgreSQL connection configuration, the connection format:
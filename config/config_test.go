@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/cometbft/cometbft/v2/config"
+	"github.com/cometbft/cometbft/v2/state/indexer/sink/sqlite"
 )
 
 func TestDefaultConfig(t *testing.T) {
@@ -97,6 +98,54 @@ func TestBaseConfigProxyApp_ValidateBasic(t *testing.T) {
 	}
 }
 
+func TestBaseConfigPrivValidatorGRPC_ValidateBasic(t *testing.T) {
+	testcases := map[string]struct {
+		laddr, grpcAddr string
+		tls             []string
+		expectErr       bool
+	}{
+		"unix without tls":    {"", "unix:///tmp/signer.sock", nil, false},
+		"tcp with tls":        {"", "tcp://127.0.0.1:26659", []string{"c.crt", "c.key", "ca.crt"}, false},
+		"tcp without tls":     {"", "tcp://127.0.0.1:26659", nil, true},
+		"failover":            {"", "unix:///tmp/a.sock, unix:///tmp/b.sock", nil, false},
+		"invalid proto":       {"", "127.0.0.1:26659", []string{"c.crt", "c.key", "ca.crt"}, true},
+		"partial tls":         {"", "unix:///tmp/signer.sock", []string{"c.crt", "", ""}, true},
+		"with listen address": {"tcp://127.0.0.1:26658", "unix:///tmp/signer.sock", nil, true},
+	}
+	for desc, tc := range testcases {
+		t.Run(desc, func(t *testing.T) {
+			cfg := config.DefaultBaseConfig()
+			cfg.PrivValidatorListenAddr = tc.laddr
+			cfg.PrivValidatorGRPCAddr = tc.grpcAddr
+			if tc.tls != nil {
+				cfg.PrivValidatorGRPCTLSCertFile = tc.tls[0]
+				cfg.PrivValidatorGRPCTLSKeyFile = tc.tls[1]
+				cfg.PrivValidatorGRPCTLSCAFile = tc.tls[2]
+			}
+
+			err := cfg.ValidateBasic()
+			if tc.expectErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestBaseConfigPrivValidatorThreshold_ValidateBasic(t *testing.T) {
+	cfg := config.DefaultBaseConfig()
+	cfg.PrivValidatorThreshold = "config/priv_validator_threshold.json"
+	require.NoError(t, cfg.ValidateBasic())
+
+	cfg.PrivValidatorListenAddr = "tcp://127.0.0.1:26658"
+	require.Error(t, cfg.ValidateBasic())
+
+	cfg.PrivValidatorListenAddr = ""
+	cfg.PrivValidatorGRPCAddr = "unix:///tmp/signer.sock"
+	require.Error(t, cfg.ValidateBasic())
+}
+
 func TestRPCConfigValidateBasic(t *testing.T) {
 	cfg := config.TestRPCConfig()
 	require.NoError(t, cfg.ValidateBasic())
@@ -118,6 +167,18 @@ func TestRPCConfigValidateBasic(t *testing.T) {
 	}
 }
 
+func TestGRPCConfigValidateBasic(t *testing.T) {
+	cfg := config.TestGRPCConfig()
+	require.NoError(t, cfg.ValidateBasic())
+
+	cfg.ListenAddress = "127.0.0.1:36670"
+	require.Error(t, cfg.ValidateBasic())
+	cfg.ListenAddress = ""
+
+	cfg.TxService.TimeoutBroadcastTxCommit = -1
+	require.Error(t, cfg.ValidateBasic())
+}
+
 func TestP2PConfigValidateBasic(t *testing.T) {
 	cfg := config.TestP2PConfig()
 	require.NoError(t, cfg.ValidateBasic())
@@ -136,6 +197,25 @@ func TestP2PConfigValidateBasic(t *testing.T) {
 		require.Error(t, cfg.ValidateBasic())
 		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(0)
 	}
+
+	cfg.Transport = config.P2PTransportQUIC
+	require.NoError(t, cfg.ValidateBasic())
+	cfg.Transport = "udp"
+	require.Error(t, cfg.ValidateBasic())
+}
+
+func TestP2PConfigChannelRates(t *testing.T) {
+	cfg := config.TestP2PConfig()
+	cfg.ChannelSendRates = "0x30:102400, 32:2048"
+	require.NoError(t, cfg.ValidateBasic())
+	rates, err := cfg.ChannelSendRateLimits()
+	require.NoError(t, err)
+	assert.Equal(t, map[byte]int64{0x30: 102400, 0x20: 2048}, rates)
+
+	for _, invalid := range []string{"0x30", "0x100:1024", "0x30:0", "0x30:abc", "0x30:1,0x30:2"} {
+		cfg.ChannelRecvRates = invalid
+		require.Error(t, cfg.ValidateBasic(), invalid)
+	}
 }
 
 func TestMempoolConfigValidateBasic(t *testing.T) {
@@ -145,6 +225,8 @@ func TestMempoolConfigValidateBasic(t *testing.T) {
 	// tamper with type
 	reflect.ValueOf(cfg).Elem().FieldByName("Type").SetString("invalid")
 	require.Error(t, cfg.ValidateBasic())
+	reflect.ValueOf(cfg).Elem().FieldByName("Type").SetString(config.MempoolTypePriority)
+	require.NoError(t, cfg.ValidateBasic())
 	reflect.ValueOf(cfg).Elem().FieldByName("Type").SetString(config.MempoolTypeFlood)
 	reflect.ValueOf(cfg).Elem().FieldByName("DOGProtocolEnabled").SetBool(false)
 
@@ -164,6 +246,8 @@ func TestMempoolConfigValidateBasic(t *testing.T) {
 		{"MaxTxBytes", []int64{1}, []int64{-1, 0}},
 		{"ExperimentalMaxGossipConnectionsToPersistentPeers", []int64{0, 1}, []int64{-1}},
 		{"ExperimentalMaxGossipConnectionsToNonPersistentPeers", []int64{0, 1}, []int64{-1}},
+		{"TTLDuration", []int64{0, 1}, []int64{-1}},
+		{"TTLNumBlocks", []int64{0, 1}, []int64{-1}},
 	}
 	for _, field := range fields2values {
 		for _, value := range field.AllowedValues {
@@ -192,6 +276,11 @@ func TestMempoolConfigValidateBasic(t *testing.T) {
 		setFieldTo(name, 1) // reset
 	}
 
+	// the journal can't be used with the nop mempool
+	reflect.ValueOf(cfg).Elem().FieldByName("Journal").SetBool(true)
+	require.Error(t, cfg.ValidateBasic())
+	reflect.ValueOf(cfg).Elem().FieldByName("Journal").SetBool(false)
+
 	// with DOG protocol only works with Flood and no MaxGossip feature.
 	reflect.ValueOf(cfg).Elem().FieldByName("DOGProtocolEnabled").SetBool(true)
 	require.Error(t, cfg.ValidateBasic())
@@ -204,6 +293,23 @@ func TestMempoolConfigValidateBasic(t *testing.T) {
 func TestStateSyncConfigValidateBasic(t *testing.T) {
 	cfg := config.TestStateSyncConfig()
 	require.NoError(t, cfg.ValidateBasic())
+
+	cfg.NodeSnapshotInterval = -1
+	require.Error(t, cfg.ValidateBasic())
+
+	cfg.NodeSnapshotInterval = 100
+	cfg.NodeSnapshotKeepRecent = 0
+	require.Error(t, cfg.ValidateBasic())
+	cfg.NodeSnapshotKeepRecent = 2
+	require.NoError(t, cfg.ValidateBasic())
+
+	// RPC servers are only required when the light blocks are not fetched over p2p.
+	cfg.Enable = true
+	cfg.TrustHeight = 1
+	cfg.TrustHash = "0102"
+	require.Error(t, cfg.ValidateBasic())
+	cfg.UseP2P = true
+	require.NoError(t, cfg.ValidateBasic())
 }
 
 func TestBlockSyncConfigValidateBasic(t *testing.T) {
@@ -218,6 +324,19 @@ func TestBlockSyncConfigValidateBasic(t *testing.T) {
 	require.Error(t, cfg.ValidateBasic())
 }
 
+func TestTxIndexConfigValidateBasic(t *testing.T) {
+	cfg := config.TestTxIndexConfig()
+	require.NoError(t, cfg.ValidateBasic())
+
+	// The sqlite indexer is only supported by binaries built with cgo.
+	cfg.Indexer = "sqlite"
+	if sqlite.Supported {
+		require.NoError(t, cfg.ValidateBasic())
+	} else {
+		require.ErrorIs(t, cfg.ValidateBasic(), config.ErrSQLiteIndexerNotSupported)
+	}
+}
+
 func TestConsensusConfig_ValidateBasic(t *testing.T) {
 	//nolint: lll
 	testcases := map[string]struct {
@@ -11,6 +11,7 @@ var (
 	ErrInsufficientChunkRequestTimeout = errors.New("timeout for re-requesting a chunk (chunk_request_timeout) is less than 5 seconds")
 	ErrUnknownLogFormat                = errors.New("unknown log_format (must be 'plain' or 'json')")
 	ErrSubscriptionBufferSizeInvalid   = fmt.Errorf("experimental_subscription_buffer_size must be >= %d", minSubscriptionBufferSize)
+	ErrSQLiteIndexerNotSupported       = errors.New("the sqlite indexer requires a binary built with cgo (CGO_ENABLED=1)")
 )
 
 // ErrInSection is returned if validate basic does not pass for any underlying config service.
@@ -0,0 +1,7 @@
+//go:build !cgo
+
+package config
+
+// sqliteIndexerSupported reports whether the sqlite indexer is supported by
+// the binary: its driver requires cgo.
+const sqliteIndexerSupported = false
@@ -19,3 +19,64 @@ func TestArmor(t *testing.T) {
 	assert.Equal(t, blockType, blockType2)
 	assert.Equal(t, data, data2)
 }
+
+func TestEncryptArmor(t *testing.T) {
+	blockType := "MINT TEST"
+	data := []byte("somedata")
+	passphrase := []byte("passphrase")
+
+	for _, kdf := range []string{KDFArgon2id, KDFScrypt} {
+		t.Run(kdf, func(t *testing.T) {
+			armorStr, err := EncryptArmor(blockType, data, passphrase, kdf)
+			require.NoError(t, err)
+			assert.True(t, IsArmored([]byte(armorStr)))
+			assert.NotContains(t, armorStr, string(data))
+
+			blockType2, data2, err := DecryptArmor(armorStr, passphrase)
+			require.NoError(t, err)
+			assert.Equal(t, blockType, blockType2)
+			assert.Equal(t, data, data2)
+
+			_, _, err = DecryptArmor(armorStr, []byte("wrong"))
+			require.ErrorIs(t, err, ErrDecrypt)
+		})
+	}
+
+	_, err := EncryptArmor(blockType, data, passphrase, "md5")
+	require.Error(t, err)
+	assert.False(t, IsArmored([]byte(`{"priv_key":{}}`)))
+}
+
+func TestDecryptArmorKDFLimits(t *testing.T) {
+	passphrase := []byte("passphrase")
+	testCases := []struct {
+		name    string
+		kdf     string
+		headers map[string]string
+	}{
+		{"argon2 time", KDFArgon2id, map[string]string{headerTime: "1000000"}},
+		{"argon2 memory", KDFArgon2id, map[string]string{headerMemory: "4294967295"}},
+		{"argon2 threads", KDFArgon2id, map[string]string{headerThreads: "255"}},
+		{"scrypt n", KDFScrypt, map[string]string{headerN: "1073741824"}},
+		{"scrypt r", KDFScrypt, map[string]string{headerR: "1000"}},
+		{"scrypt p", KDFScrypt, map[string]string{headerP: "1000000"}},
+		// Each parameter is within bounds, but not the memory they need.
+		{"scrypt memory", KDFScrypt, map[string]string{headerN: "1048576", headerR: "16"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			armorStr, err := EncryptArmor("MINT TEST", []byte("somedata"), passphrase, tc.kdf)
+			require.NoError(t, err)
+			blockType, headers, data, err := DecodeArmor(armorStr)
+			require.NoError(t, err)
+			for name, value := range tc.headers {
+				headers[name] = value
+			}
+			armorStr, err = EncodeArmor(blockType, headers, data)
+			require.NoError(t, err)
+
+			_, _, err = DecryptArmor(armorStr, passphrase)
+			require.ErrorContains(t, err, "maximum is")
+		})
+	}
+}
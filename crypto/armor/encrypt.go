@@ -0,0 +1,232 @@
+package armor
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Key derivation functions supported by [EncryptArmor].
+const (
+	KDFArgon2id = "argon2id"
+	KDFScrypt   = "scrypt"
+)
+
+const (
+	cipherXChaCha20Poly1305 = "xchacha20-poly1305"
+	saltSize                = 16
+
+	// Parameters of the key derivation functions. They are stored in the
+	// headers of the armor block, so that they can be changed without
+	// breaking existing files.
+	argon2Time    = 3
+	argon2Memory  = 64 * 1024 // KiB
+	argon2Threads = 4
+	scryptN       = 1 << 15
+	scryptR       = 8
+	scryptP       = 1
+
+	// Maximum parameters of the key derivation functions accepted when
+	// decrypting, since the headers are not authenticated: otherwise, a
+	// tampered file could make the node use unbounded memory or CPU time.
+	// Both functions are limited to 1 GiB of memory.
+	maxArgon2Time    = 16
+	maxArgon2Memory  = 1024 * 1024 // KiB
+	maxArgon2Threads = 64
+	maxScryptN       = 1 << 20
+	maxScryptR       = 32
+	maxScryptP       = 16
+	maxScryptMemory  = 1 << 30 // bytes, i.e. 128 * n * r
+)
+
+// Headers of the encrypted armor blocks.
+const (
+	headerKDF     = "kdf"
+	headerSalt    = "salt"
+	headerCipher  = "cipher"
+	headerTime    = "argon2-time"
+	headerMemory  = "argon2-memory"
+	headerThreads = "argon2-threads"
+	headerN       = "scrypt-n"
+	headerR       = "scrypt-r"
+	headerP       = "scrypt-p"
+)
+
+// ErrDecrypt is returned by [DecryptArmor] when the passphrase is wrong or
+// the data was tampered with.
+var ErrDecrypt = errors.New("armor: could not decrypt data: wrong passphrase or corrupted data")
+
+// ErrPassphraseRequired is returned by [DecryptIfArmored] when the data is
+// encrypted but no passphrase was given.
+var ErrPassphraseRequired = errors.New("armor: data is encrypted, but no passphrase was given")
+
+// PassphraseFunc returns the passphrase of encrypted armor blocks. It is only
+// called when an encrypted block is read, so that the user is not asked for a
+// passphrase unless needed.
+type PassphraseFunc func() ([]byte, error)
+
+// IsArmored returns true if bz starts with an armor block.
+func IsArmored(bz []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(bz), []byte("-----BEGIN "))
+}
+
+// EncryptArmor encrypts data with a key derived from passphrase with the given
+// key derivation function (KDFArgon2id or KDFScrypt) and XChaCha20-Poly1305,
+// and encodes the result in an armor block of the given type. The parameters
+// needed to decrypt the data, except for the passphrase, are stored in the
+// headers of the block.
+func EncryptArmor(blockType string, data, passphrase []byte, kdf string) (string, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	headers := map[string]string{
+		headerKDF:    kdf,
+		headerSalt:   hex.EncodeToString(salt),
+		headerCipher: cipherXChaCha20Poly1305,
+	}
+	switch kdf {
+	case KDFArgon2id:
+		headers[headerTime] = strconv.Itoa(argon2Time)
+		headers[headerMemory] = strconv.Itoa(argon2Memory)
+		headers[headerThreads] = strconv.Itoa(argon2Threads)
+	case KDFScrypt:
+		headers[headerN] = strconv.Itoa(scryptN)
+		headers[headerR] = strconv.Itoa(scryptR)
+		headers[headerP] = strconv.Itoa(scryptP)
+	default:
+		return "", fmt.Errorf("armor: unknown key derivation function %q", kdf)
+	}
+
+	key, err := deriveKey(headers, salt, passphrase)
+	if err != nil {
+		return "", err
+	}
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(data)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	// The block type is authenticated, so that a block cannot be passed off as
+	// another type of key.
+	sealed := aead.Seal(nonce, nonce, data, []byte(blockType))
+	return EncodeArmor(blockType, headers, sealed)
+}
+
+// DecryptArmor decodes an armor block produced by [EncryptArmor] and
+// decrypts its data with passphrase. It returns ErrDecrypt if the passphrase
+// is wrong.
+func DecryptArmor(armorStr string, passphrase []byte) (blockType string, data []byte, err error) {
+	blockType, headers, sealed, err := DecodeArmor(armorStr)
+	if err != nil {
+		return "", nil, err
+	}
+	if headers[headerCipher] != cipherXChaCha20Poly1305 {
+		return "", nil, fmt.Errorf("armor: unknown cipher %q", headers[headerCipher])
+	}
+	salt, err := hex.DecodeString(headers[headerSalt])
+	if err != nil || len(salt) == 0 {
+		return "", nil, fmt.Errorf("armor: invalid salt %q", headers[headerSalt])
+	}
+	key, err := deriveKey(headers, salt, passphrase)
+	if err != nil {
+		return "", nil, err
+	}
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return "", nil, ErrDecrypt
+	}
+	data, err = aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], []byte(blockType))
+	if err != nil {
+		return "", nil, ErrDecrypt
+	}
+	return blockType, data, nil
+}
+
+// DecryptIfArmored returns bz as is if it is not an armor block. Otherwise, it
+// decrypts the armor block, which must be of the given type, with the
+// passphrase returned by passphrase, and returns the decrypted data along with
+// the passphrase.
+func DecryptIfArmored(bz []byte, blockType string, passphrase PassphraseFunc) (data, usedPassphrase []byte, err error) {
+	if !IsArmored(bz) {
+		return bz, nil, nil
+	}
+	if passphrase == nil {
+		return nil, nil, ErrPassphraseRequired
+	}
+	usedPassphrase, err = passphrase()
+	if err != nil {
+		return nil, nil, fmt.Errorf("armor: could not get passphrase: %w", err)
+	}
+	bt, data, err := DecryptArmor(string(bz), usedPassphrase)
+	if err != nil {
+		return nil, nil, err
+	}
+	if bt != blockType {
+		return nil, nil, fmt.Errorf("armor: unexpected block type %q, expected %q", bt, blockType)
+	}
+	return data, usedPassphrase, nil
+}
+
+// deriveKey derives the encryption key from passphrase with the key
+// derivation function and parameters in headers.
+func deriveKey(headers map[string]string, salt, passphrase []byte) ([]byte, error) {
+	param := func(name string, maxValue uint64) (uint64, error) {
+		v, err := strconv.ParseUint(headers[name], 10, 64)
+		if err != nil || v == 0 {
+			return 0, fmt.Errorf("armor: invalid header %s: %q", name, headers[name])
+		}
+		if v > maxValue {
+			return 0, fmt.Errorf("armor: header %s is %d, maximum is %d", name, v, maxValue)
+		}
+		return v, nil
+	}
+	switch kdf := headers[headerKDF]; kdf {
+	case KDFArgon2id:
+		t, err := param(headerTime, maxArgon2Time)
+		if err != nil {
+			return nil, err
+		}
+		m, err := param(headerMemory, maxArgon2Memory)
+		if err != nil {
+			return nil, err
+		}
+		p, err := param(headerThreads, maxArgon2Threads)
+		if err != nil {
+			return nil, err
+		}
+		return argon2.IDKey(passphrase, salt, uint32(t), uint32(m), uint8(p), chacha20poly1305.KeySize), nil
+	case KDFScrypt:
+		n, err := param(headerN, maxScryptN)
+		if err != nil {
+			return nil, err
+		}
+		r, err := param(headerR, maxScryptR)
+		if err != nil {
+			return nil, err
+		}
+		p, err := param(headerP, maxScryptP)
+		if err != nil {
+			return nil, err
+		}
+		if mem := 128 * n * r; mem > maxScryptMemory {
+			return nil, fmt.Errorf("armor: scrypt parameters need %d bytes of memory, maximum is %d", mem, maxScryptMemory)
+		}
+		return scrypt.Key(passphrase, salt, int(n), int(r), int(p), chacha20poly1305.KeySize)
+	default:
+		return nil, fmt.Errorf("armor: unknown key derivation function %q", kdf)
+	}
+}
@@ -0,0 +1,13 @@
+//go:build !bls12381
+
+package bls12381
+
+// SplitPrivKey returns ErrDisabled.
+func SplitPrivKey(PrivKey, int, int) ([]PrivKey, error) {
+	return nil, ErrDisabled
+}
+
+// CombineSignatures returns ErrDisabled.
+func CombineSignatures([]int, [][]byte) ([]byte, error) {
+	return nil, ErrDisabled
+}
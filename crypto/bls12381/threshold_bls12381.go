@@ -0,0 +1,112 @@
+//go:build bls12381
+
+package bls12381
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+
+	blst "github.com/supranational/blst/bindings/go"
+)
+
+// curveOrder is the order r of the BLS12-381 groups, i.e. the modulus of the
+// private keys.
+var curveOrder, _ = new(big.Int).SetString("73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001", 16)
+
+// SplitPrivKey splits privKey into n key shares using Shamir's secret sharing,
+// so that the partial signatures of any threshold shares over a message can be
+// combined with CombineSignatures into a signature verifiable with the public
+// key of privKey. Fewer than threshold shares reveal nothing about privKey.
+//
+// The index of the i-th share, to be given to CombineSignatures, is i+1.
+func SplitPrivKey(privKey *PrivKey, threshold, n int) ([]*PrivKey, error) {
+	if threshold < 1 || threshold > n {
+		return nil, fmt.Errorf("invalid threshold %d for %d shares", threshold, n)
+	}
+
+	// f(x) = secret + c_1*x + ... + c_{threshold-1}*x^(threshold-1)
+	coeffs := make([]*big.Int, threshold)
+	coeffs[0] = new(big.Int).SetBytes(privKey.sk.Serialize())
+	for i := 1; i < threshold; i++ {
+		c, err := rand.Int(rand.Reader, curveOrder)
+		if err != nil {
+			return nil, err
+		}
+		coeffs[i] = c
+	}
+
+	shares := make([]*PrivKey, n)
+	for i := range shares {
+		x := big.NewInt(int64(i + 1))
+		y := new(big.Int)
+		for j := threshold - 1; j >= 0; j-- {
+			y.Mul(y, x).Add(y, coeffs[j]).Mod(y, curveOrder)
+		}
+		sk := new(blst.SecretKey).Deserialize(y.FillBytes(make([]byte, PrivKeySize)))
+		if sk == nil {
+			// Only happens if the share is zero, which is negligibly likely.
+			return nil, errors.New("bls12381: invalid key share, try again")
+		}
+		shares[i] = &PrivKey{sk: sk}
+	}
+	return shares, nil
+}
+
+// CombineSignatures combines the partial signatures of a message produced by
+// the key shares with the given indexes (see SplitPrivKey) into the signature
+// of the message by the key that was split. The number of partial signatures
+// must be at least the threshold of the split, otherwise the result is not a
+// valid signature. Partial signatures are not verified.
+func CombineSignatures(indexes []int, sigs [][]byte) ([]byte, error) {
+	if len(indexes) != len(sigs) {
+		return nil, fmt.Errorf("got %d indexes for %d signatures", len(indexes), len(sigs))
+	}
+	if len(sigs) == 0 {
+		return nil, errors.New("no signatures to combine")
+	}
+
+	xs := make([]*big.Int, len(indexes))
+	seen := make(map[int]bool, len(indexes))
+	for i, index := range indexes {
+		if index < 1 || seen[index] {
+			return nil, fmt.Errorf("invalid or duplicate index %d", index)
+		}
+		seen[index] = true
+		xs[i] = big.NewInt(int64(index))
+	}
+
+	var combined *blst.P2
+	for i, sig := range sigs {
+		affine := new(blstSignature).Uncompress(sig)
+		if affine == nil || !affine.SigValidate(false) {
+			return nil, fmt.Errorf("invalid signature of share %d", indexes[i])
+		}
+
+		// Lagrange coefficient of x_i at 0: prod_{j != i} x_j / (x_j - x_i).
+		num, den := big.NewInt(1), big.NewInt(1)
+		for j, xj := range xs {
+			if j == i {
+				continue
+			}
+			num.Mul(num, xj).Mod(num, curveOrder)
+			den.Mul(den, new(big.Int).Sub(xj, xs[i])).Mod(den, curveOrder)
+		}
+		lambda := num.Mul(num, den.ModInverse(den, curveOrder)).Mod(num, curveOrder)
+		scalar := new(blst.Scalar).Deserialize(lambda.FillBytes(make([]byte, PrivKeySize)))
+		if scalar == nil {
+			return nil, fmt.Errorf("invalid Lagrange coefficient for share %d", indexes[i])
+		}
+
+		var p blst.P2
+		p.FromAffine(affine)
+		p.MultAssign(scalar)
+		if combined == nil {
+			combined = &p
+		} else {
+			combined.AddAssign(&p)
+		}
+	}
+	return combined.Compress(), nil
+}
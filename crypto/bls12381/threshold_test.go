@@ -0,0 +1,51 @@
+//go:build bls12381
+
+package bls12381_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/v2/crypto/bls12381"
+)
+
+func TestSplitPrivKeyCombineSignatures(t *testing.T) {
+	privKey, err := bls12381.GenPrivKey()
+	require.NoError(t, err)
+	pubKey := privKey.PubKey()
+
+	shares, err := bls12381.SplitPrivKey(privKey, 3, 5)
+	require.NoError(t, err)
+	require.Len(t, shares, 5)
+
+	msg := []byte("hello world")
+	sigs := make([][]byte, len(shares))
+	for i, share := range shares {
+		sigs[i], err = share.Sign(msg)
+		require.NoError(t, err)
+		require.True(t, share.PubKey().VerifySignature(msg, sigs[i]))
+		require.False(t, pubKey.VerifySignature(msg, sigs[i]))
+	}
+
+	// Any 3 shares produce the signature of the key.
+	for _, indexes := range [][]int{{1, 2, 3}, {5, 3, 1}, {2, 4, 5}, {1, 2, 3, 4, 5}} {
+		partials := make([][]byte, len(indexes))
+		for i, index := range indexes {
+			partials[i] = sigs[index-1]
+		}
+		sig, err := bls12381.CombineSignatures(indexes, partials)
+		require.NoError(t, err)
+		require.True(t, pubKey.VerifySignature(msg, sig), indexes)
+	}
+
+	// 2 shares are not enough.
+	sig, err := bls12381.CombineSignatures([]int{1, 2}, sigs[:2])
+	require.NoError(t, err)
+	require.False(t, pubKey.VerifySignature(msg, sig))
+
+	_, err = bls12381.CombineSignatures([]int{1, 1}, sigs[:2])
+	require.Error(t, err)
+	_, err = bls12381.SplitPrivKey(privKey, 6, 5)
+	require.Error(t, err)
+}
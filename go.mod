@@ -22,6 +22,7 @@ require (
 	github.com/fortytw2/leaktest v1.3.0
 	github.com/goccmack/goutil v1.2.3
 	github.com/golang/protobuf v1.5.4 // indirect
+	github.com/google/btree v1.1.3
 	github.com/google/orderedcode v0.0.1
 	github.com/google/uuid v1.6.0
 	github.com/gorilla/websocket v1.5.3
@@ -57,6 +58,9 @@ require (
 require (
 	github.com/go-git/go-git/v5 v5.13.2
 	github.com/go-viper/mapstructure/v2 v2.2.1
+	github.com/mattn/go-sqlite3 v1.14.33
+	github.com/quic-go/quic-go v0.54.0
+	golang.org/x/term v0.30.0
 	google.golang.org/protobuf v1.36.5
 )
 
@@ -95,7 +99,6 @@ require (
 	github.com/gogo/protobuf v1.3.2 // indirect
 	github.com/golang/groupcache v0.0.0-20241129210726-2c02b8208cf8 // indirect
 	github.com/golang/snappy v0.0.5-0.20220116011046-fa5810519dcb // indirect
-	github.com/google/btree v1.1.3 // indirect
 	github.com/google/flatbuffers v25.2.10+incompatible // indirect
 	github.com/google/go-cmp v0.6.0 // indirect
 	github.com/gotestyourself/gotestyourself v2.2.0+incompatible // indirect
@@ -142,3 +145,5 @@ require (
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 	gotest.tools v2.2.0+incompatible // indirect
 )
+
+replace github.com/cometbft/cometbft/api => ./api
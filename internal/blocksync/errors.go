@@ -30,6 +30,17 @@ func (e ErrInvalidBase) Error() string {
 	return fmt.Sprintf("invalid base %v: %s", e.Base, e.Reason)
 }
 
+// ErrInvalidBlockRange is returned when peer requests, or informs of, an
+// invalid number of blocks per range.
+type ErrInvalidBlockRange struct {
+	Count  int64
+	Reason string
+}
+
+func (e ErrInvalidBlockRange) Error() string {
+	return fmt.Sprintf("invalid block range %v: %s", e.Count, e.Reason)
+}
+
 type ErrUnknownMessageType struct {
 	Msg proto.Message
 }
@@ -16,6 +16,10 @@ const (
 	MaxMsgSize                       = types.MaxBlockSizeBytes +
 		BlockResponseMessagePrefixSize +
 		BlockResponseMessageFieldKeySize
+
+	// MaxBlockRangeSize is the maximum number of blocks requested, and served,
+	// per BlockRangeRequest.
+	MaxBlockRangeSize = 10
 )
 
 // ValidateMsg validates a message.
@@ -29,6 +33,13 @@ func ValidateMsg(pb proto.Message) error {
 		if msg.Height < 0 {
 			return ErrInvalidHeight{Height: msg.Height, Reason: "negative height"}
 		}
+	case *bcproto.BlockRangeRequest:
+		if msg.StartHeight < 0 {
+			return ErrInvalidHeight{Height: msg.StartHeight, Reason: "negative start height"}
+		}
+		if msg.Count < 1 || msg.Count > MaxBlockRangeSize {
+			return ErrInvalidBlockRange{Count: msg.Count, Reason: fmt.Sprintf("must be between 1 and %d", MaxBlockRangeSize)}
+		}
 	case *bcproto.BlockResponse:
 		// Avoid double-calling `types.BlockFromProto` for performance reasons.
 		// See https://github.com/cometbft/cometbft/v2/issues/1964
@@ -47,6 +58,9 @@ func ValidateMsg(pb proto.Message) error {
 		if msg.Base > msg.Height {
 			return ErrInvalidHeight{Height: msg.Height, Reason: fmt.Sprintf("base %v cannot be greater than height", msg.Base)}
 		}
+		if msg.MaxBlockRange < 0 {
+			return ErrInvalidBlockRange{Count: msg.MaxBlockRange, Reason: "negative max block range"}
+		}
 	case *bcproto.StatusRequest:
 		return nil
 	default:
@@ -33,6 +33,28 @@ func TestBcBlockRequestMessageValidateBasic(t *testing.T) {
 	}
 }
 
+func TestBcBlockRangeRequestMessageValidateBasic(t *testing.T) {
+	testCases := []struct {
+		testName    string
+		startHeight int64
+		count       int64
+		expectErr   bool
+	}{
+		{"Valid Range Request Message", 1, 1, false},
+		{"Valid Range Request Message", 1, blocksync.MaxBlockRangeSize, false},
+		{"Invalid Range Request Message", -1, 1, true},
+		{"Invalid Range Request Message", 1, 0, true},
+		{"Invalid Range Request Message", 1, blocksync.MaxBlockRangeSize + 1, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			request := bcproto.BlockRangeRequest{StartHeight: tc.startHeight, Count: tc.count}
+			assert.Equal(t, tc.expectErr, blocksync.ValidateMsg(&request) != nil, "Validate Basic had an unexpected result")
+		})
+	}
+}
+
 func TestBcNoBlockResponseMessageValidateBasic(t *testing.T) {
 	testCases := []struct {
 		testName          string
@@ -61,16 +83,19 @@ func TestBcStatusResponseMessageValidateBasic(t *testing.T) {
 	testCases := []struct {
 		testName       string
 		responseHeight int64
+		maxBlockRange  int64
 		expectErr      bool
 	}{
-		{"Valid Response Message", 0, false},
-		{"Valid Response Message", 1, false},
-		{"Invalid Response Message", -1, true},
+		{"Valid Response Message", 0, 0, false},
+		{"Valid Response Message", 1, 0, false},
+		{"Valid Response Message", 1, blocksync.MaxBlockRangeSize, false},
+		{"Invalid Response Message", -1, 0, true},
+		{"Invalid Response Message", 1, -1, true},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.testName, func(t *testing.T) {
-			response := bcproto.StatusResponse{Height: tc.responseHeight}
+			response := bcproto.StatusResponse{Height: tc.responseHeight, MaxBlockRange: tc.maxBlockRange}
 			assert.Equal(t, tc.expectErr, blocksync.ValidateMsg(&response) != nil, "Validate Basic had an unexpected result")
 		})
 	}
@@ -98,6 +123,9 @@ func TestBlocksyncMessageVectors(t *testing.T) {
 			}},
 			"0a0a08ffffffffffffffff7f",
 		},
+		{"BlockRangeRequestMessage", &bcproto.Message{Sum: &bcproto.Message_BlockRangeRequest{
+			BlockRangeRequest: &bcproto.BlockRangeRequest{StartHeight: 1, Count: 10},
+		}}, "32040801100a"},
 		{"BlockResponseMessage", &bcproto.Message{Sum: &bcproto.Message_BlockResponse{
 			BlockResponse: &bcproto.BlockResponse{Block: bpb},
 		}}, "1a700a6e0a5b0a02080b1803220b088092b8c398feffffff012a0212003a20c4da88e876062aa1543400d50d0eaa0dac88096057949cfb7bca7f3a48c04bf96a20e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855120d0a0b48656c6c6f20576f726c641a00"},
@@ -128,6 +156,12 @@ func TestBlocksyncMessageVectors(t *testing.T) {
 			}},
 			"2a1408ffffffffffffffff7f10ffffffffffffffff7f",
 		},
+		{
+			"StatusResponseMessage", &bcproto.Message{Sum: &bcproto.Message_StatusResponse{
+				StatusResponse: &bcproto.StatusResponse{Height: 1, Base: 2, MaxBlockRange: 10},
+			}},
+			"2a0608011002180a",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -129,10 +129,14 @@ func (pool *BlockPool) makeRequestersRoutine() {
 
 		pool.mtx.Lock()
 		var (
-			maxRequestersCreated = len(pool.requesters) >= len(pool.peers)*maxPendingRequestsPerPeer
+			maxRequesters        = len(pool.peers) * maxPendingRequestsPerPeer
+			maxRequestersCreated = len(pool.requesters) >= maxRequesters
 
 			nextHeight           = pool.height + int64(len(pool.requesters))
 			maxPeerHeightReached = nextHeight > pool.maxPeerHeight
+
+			// The number of blocks to request at once, from a peer serving ranges.
+			rangeSize = min(MaxBlockRangeSize, int64(maxRequesters-len(pool.requesters)), pool.maxPeerHeight-nextHeight+1)
 		)
 		pool.mtx.Unlock()
 
@@ -143,7 +147,7 @@ func (pool *BlockPool) makeRequestersRoutine() {
 		case maxPeerHeightReached: // If we're caught up, wait for a bit so reactor could finish or a higher height is reported.
 			time.Sleep(requestInterval)
 		default:
-			pool.makeNextRequester(nextHeight)
+			pool.makeNextRequesters(nextHeight, rangeSize)
 			// Sleep for a bit to make the requests more ordered.
 			time.Sleep(requestInterval)
 		}
@@ -229,8 +233,31 @@ func (pool *BlockPool) PeekTwoBlocks() (first, second *types.Block, firstExtComm
 	return first, second, firstExtCommit
 }
 
+// PeekBlocks returns up to maxBlocks consecutive blocks from pool.height,
+// stopping at the first block not received yet. It is used to verify the
+// blocks ahead of their application.
+func (pool *BlockPool) PeekBlocks(maxBlocks int) []*types.Block {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+
+	blocks := make([]*types.Block, 0, maxBlocks)
+	for h := pool.height; len(blocks) < maxBlocks; h++ {
+		r := pool.requesters[h]
+		if r == nil {
+			break
+		}
+		block := r.getBlock()
+		if block == nil {
+			break
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
+
 // PopRequest removes the requester at pool.height and increments pool.height.
-func (pool *BlockPool) PopRequest() {
+// It returns the ID of the peer that sent the block at pool.height.
+func (pool *BlockPool) PopRequest() (gotBlockFrom p2p.ID) {
 	pool.mtx.Lock()
 	defer pool.mtx.Unlock()
 
@@ -238,6 +265,7 @@ func (pool *BlockPool) PopRequest() {
 	if r == nil {
 		panic(fmt.Sprintf("Expected requester to pop, got nothing at height %v", pool.height))
 	}
+	gotBlockFrom = r.gotBlockFromPeerID()
 
 	if err := r.Stop(); err != nil {
 		pool.Logger.Error("Error stopping requester", "err", err)
@@ -250,6 +278,7 @@ func (pool *BlockPool) PopRequest() {
 	for i := int64(0); i < minBlocksForSingleRequest && i < int64(len(pool.requesters)); i++ {
 		pool.requesters[pool.height+i].newHeight(pool.height)
 	}
+	return gotBlockFrom
 }
 
 // RemovePeerAndRedoAllPeerRequests retries the request at the given height and
@@ -385,6 +414,17 @@ func (pool *BlockPool) SetPeerRange(peerID p2p.ID, base int64, height int64) {
 	}
 }
 
+// SetPeerMaxBlockRange sets the maximum number of blocks the peer serves per
+// range request, 0 if it does not serve ranges.
+func (pool *BlockPool) SetPeerMaxBlockRange(peerID p2p.ID, maxBlockRange int64) {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+
+	if peer := pool.peers[peerID]; peer != nil {
+		peer.maxBlockRange = min(maxBlockRange, MaxBlockRangeSize)
+	}
+}
+
 // RemovePeer removes the peer with peerID from the pool. If there's no peer
 // with peerID, function is a no-op.
 func (pool *BlockPool) RemovePeer(peerID p2p.ID) {
@@ -480,6 +520,34 @@ func (pool *BlockPool) pickIncrAvailablePeer(height int64, excludePeerID p2p.ID)
 	return nil
 }
 
+// Pick an available peer serving a range of blocks from the given height, and
+// returns the number of blocks to request from it, up to count. Only ranges of
+// more than one block are picked. If no peers are available, returns nil.
+func (pool *BlockPool) pickIncrAvailablePeerRange(height int64, count int64) (*bpPeer, int64) {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+
+	for _, peer := range pool.sortedPeers {
+		if peer.didTimeout {
+			pool.removePeer(peer.id)
+			continue
+		}
+		if height < peer.base || height > peer.height {
+			continue
+		}
+		n := min(count, peer.maxBlockRange, int64(maxPendingRequestsPerPeer-peer.numPending), peer.height-height+1)
+		if n < 2 {
+			continue
+		}
+		for i := int64(0); i < n; i++ {
+			peer.incrPending()
+		}
+		return peer, n
+	}
+
+	return nil, 0
+}
+
 // Sort peers by curRate, highest first.
 //
 // CONTRACT: pool.mtx must be locked.
@@ -489,6 +557,39 @@ func (pool *BlockPool) sortPeers() {
 	})
 }
 
+// makeNextRequesters makes the requesters for the blocks from nextHeight, up to
+// rangeSize of them, requested at once from a peer serving ranges. If no such
+// peer is available, a single requester is made.
+func (pool *BlockPool) makeNextRequesters(nextHeight int64, rangeSize int64) {
+	if rangeSize < 2 {
+		pool.makeNextRequester(nextHeight)
+		return
+	}
+	peer, count := pool.pickIncrAvailablePeerRange(nextHeight, rangeSize)
+	if peer == nil {
+		pool.makeNextRequester(nextHeight)
+		return
+	}
+
+	requesters := make([]*bpRequester, 0, count)
+	pool.mtx.Lock()
+	for height := nextHeight; height < nextHeight+count; height++ {
+		request := newBPRequester(pool, height)
+		// The block is requested from the peer as part of the range.
+		request.peerID = peer.id
+		pool.requesters[height] = request
+		requesters = append(requesters, request)
+	}
+	pool.mtx.Unlock()
+
+	for _, request := range requesters {
+		if err := request.Start(); err != nil {
+			request.Logger.Error("Error starting request", "err", err)
+		}
+	}
+	pool.sendRangeRequest(nextHeight, count, peer.id)
+}
+
 func (pool *BlockPool) makeNextRequester(nextHeight int64) {
 	pool.mtx.Lock()
 	request := newBPRequester(pool, nextHeight)
@@ -505,7 +606,15 @@ func (pool *BlockPool) sendRequest(height int64, peerID p2p.ID) {
 	if !pool.IsRunning() {
 		return
 	}
-	pool.requestsCh <- BlockRequest{height, peerID}
+	pool.requestsCh <- BlockRequest{Height: height, PeerID: peerID}
+}
+
+// thread-safe.
+func (pool *BlockPool) sendRangeRequest(height int64, count int64, peerID p2p.ID) {
+	if !pool.IsRunning() {
+		return
+	}
+	pool.requestsCh <- BlockRequest{Height: height, PeerID: peerID, Count: count}
 }
 
 // thread-safe.
@@ -540,14 +649,15 @@ func (pool *BlockPool) debug() string {
 // -------------------------------------
 
 type bpPeer struct {
-	didTimeout  bool
-	curRate     int64
-	numPending  int32
-	height      int64
-	base        int64
-	pool        *BlockPool
-	id          p2p.ID
-	recvMonitor *flow.Monitor
+	didTimeout    bool
+	curRate       int64
+	numPending    int32
+	height        int64
+	base          int64
+	maxBlockRange int64 // 0 if the peer does not serve ranges
+	pool          *BlockPool
+	id            p2p.ID
+	recvMonitor   *flow.Monitor
 
 	timeout *time.Timer
 
@@ -821,7 +931,10 @@ func (bpr *bpRequester) requestRoutine() {
 
 OUTER_LOOP:
 	for {
-		bpr.pickPeerAndSendRequest()
+		// The block may have already been requested as part of a range.
+		if len(bpr.requestedFrom()) == 0 {
+			bpr.pickPeerAndSendRequest()
+		}
 
 		poolHeight := bpr.pool.Height()
 		if bpr.height-poolHeight < minBlocksForSingleRequest {
@@ -881,8 +994,10 @@ OUTER_LOOP:
 }
 
 // BlockRequest stores a block request identified by the block Height and the PeerID responsible for
-// delivering the block.
+// delivering the block. If Count is more than 1, the blocks from Height to Height+Count-1 are
+// requested at once.
 type BlockRequest struct {
 	Height int64
 	PeerID p2p.ID
+	Count  int64
 }
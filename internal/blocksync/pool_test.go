@@ -168,6 +168,74 @@ func TestBlockPoolBasic(t *testing.T) {
 	}
 }
 
+func TestBlockPoolRangeRequests(t *testing.T) {
+	var (
+		start      = int64(42)
+		peers      = makePeers(10, start, 1000)
+		errorsCh   = make(chan peerError, 10)
+		requestsCh = make(chan BlockRequest)
+	)
+
+	pool := NewBlockPool(start, requestsCh, errorsCh)
+	pool.SetLogger(log.TestingLogger())
+
+	err := pool.Start()
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		if err := pool.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	for _, peer := range peers {
+		pool.SetPeerRange(peer.id, peer.base, peer.height)
+		pool.SetPeerMaxBlockRange(peer.id, MaxBlockRangeSize)
+	}
+
+	// Start a goroutine to pull blocks
+	go func() {
+		for {
+			if !pool.IsRunning() {
+				return
+			}
+			first, second, _ := pool.PeekTwoBlocks()
+			if first != nil && second != nil {
+				pool.PopRequest()
+			} else {
+				time.Sleep(10 * time.Millisecond)
+			}
+		}
+	}()
+
+	// Pull from channels, the blocks are mostly requested in ranges.
+	rangeRequests := 0
+	for {
+		select {
+		case err := <-errorsCh:
+			t.Error(err)
+		case request := <-requestsCh:
+			count := max(request.Count, 1)
+			require.LessOrEqual(t, count, int64(MaxBlockRangeSize))
+			if count > 1 {
+				rangeRequests++
+			}
+			if request.Height+count > 300 {
+				assert.Positive(t, rangeRequests)
+				return // Done!
+			}
+			for height := request.Height; height < request.Height+count; height++ {
+				block := &types.Block{Header: types.Header{Height: height}, LastCommit: &types.Commit{}}
+				// The block may have already been received from a second peer.
+				_ = pool.AddBlock(request.PeerID, block, &types.ExtendedCommit{Height: height}, 123)
+			}
+		case <-time.After(10 * time.Second):
+			t.Error("Timed out waiting for block requests")
+			return
+		}
+	}
+}
+
 func TestBlockPoolTimeout(t *testing.T) {
 	var (
 		start      = int64(42)
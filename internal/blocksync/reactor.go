@@ -3,13 +3,17 @@ package blocksync
 import (
 	"fmt"
 	"reflect"
+	"runtime"
 	"sync"
 	"time"
 
+	"github.com/cosmos/gogoproto/proto"
+
 	bcproto "github.com/cometbft/cometbft/api/cometbft/blocksync/v2"
 	"github.com/cometbft/cometbft/v2/crypto"
 	"github.com/cometbft/cometbft/v2/libs/log"
 	"github.com/cometbft/cometbft/v2/p2p"
+	"github.com/cometbft/cometbft/v2/p2p/reputation"
 	tcpconn "github.com/cometbft/cometbft/v2/p2p/transport/tcp/conn"
 	sm "github.com/cometbft/cometbft/v2/state"
 	"github.com/cometbft/cometbft/v2/store"
@@ -62,6 +66,7 @@ type Reactor struct {
 	blockExec     *sm.BlockExecutor
 	store         sm.BlockStore
 	pool          *BlockPool
+	verifier      *blockVerifier
 	blockSync     bool
 	localAddr     crypto.Address
 	poolRoutineWg sync.WaitGroup
@@ -111,6 +116,7 @@ func NewReactor(state sm.State, blockExec *sm.BlockExecutor, store *store.BlockS
 		blockExec:    blockExec,
 		store:        store,
 		pool:         pool,
+		verifier:     newBlockVerifier(state.ChainID, runtime.NumCPU()),
 		blockSync:    blockSync,
 		localAddr:    localAddr,
 		requestsCh:   requestsCh,
@@ -125,6 +131,7 @@ func NewReactor(state sm.State, blockExec *sm.BlockExecutor, store *store.BlockS
 func (bcR *Reactor) SetLogger(l log.Logger) {
 	bcR.BaseService.Logger = l
 	bcR.pool.Logger = l
+	bcR.verifier.Logger = l
 }
 
 // OnStart implements service.Service.
@@ -192,8 +199,9 @@ func (bcR *Reactor) AddPeer(peer p2p.Peer) {
 	_ = peer.Send(p2p.Envelope{
 		ChannelID: BlocksyncChannel,
 		Message: &bcproto.StatusResponse{
-			Base:   bcR.store.Base(),
-			Height: bcR.store.Height(),
+			Base:          bcR.store.Base(),
+			Height:        bcR.store.Height(),
+			MaxBlockRange: MaxBlockRangeSize,
 		},
 	})
 
@@ -253,7 +261,7 @@ func (bcR *Reactor) handlePeerResponse(msg *bcproto.BlockResponse, src p2p.Peer)
 	bi, err := types.BlockFromProto(msg.Block)
 	if err != nil {
 		bcR.Logger.Error("Peer sent us invalid block", "peer", src, "msg", msg, "err", err)
-		bcR.Switch.StopPeerForError(src, err)
+		bcR.Switch.StopPeerForBehaviour(src, reputation.BadMessage, err)
 		return
 	}
 	var extCommit *types.ExtendedCommit
@@ -264,7 +272,7 @@ func (bcR *Reactor) handlePeerResponse(msg *bcproto.BlockResponse, src p2p.Peer)
 			bcR.Logger.Error("failed to convert extended commit from proto",
 				"peer", src,
 				"err", err)
-			bcR.Switch.StopPeerForError(src, err)
+			bcR.Switch.StopPeerForBehaviour(src, reputation.BadMessage, err)
 			return
 		}
 	}
@@ -278,7 +286,7 @@ func (bcR *Reactor) handlePeerResponse(msg *bcproto.BlockResponse, src p2p.Peer)
 func (bcR *Reactor) Receive(e p2p.Envelope) {
 	if err := ValidateMsg(e.Message); err != nil {
 		bcR.Logger.Error("Peer sent us invalid msg", "peer", e.Src, "msg", e.Message, "err", err)
-		bcR.Switch.StopPeerForError(e.Src, err)
+		bcR.Switch.StopPeerForBehaviour(e.Src, reputation.BadMessage, err)
 		return
 	}
 
@@ -287,6 +295,13 @@ func (bcR *Reactor) Receive(e p2p.Envelope) {
 	switch msg := e.Message.(type) {
 	case *bcproto.BlockRequest:
 		bcR.respondToPeer(msg, e.Src)
+	case *bcproto.BlockRangeRequest:
+		for height := msg.StartHeight; height < msg.StartHeight+msg.Count; height++ {
+			// The remaining blocks are requested again by the peer, once timed out.
+			if !bcR.respondToPeer(&bcproto.BlockRequest{Height: height}, e.Src) {
+				break
+			}
+		}
 	case *bcproto.BlockResponse:
 		go bcR.handlePeerResponse(msg, e.Src)
 	case *bcproto.StatusRequest:
@@ -294,13 +309,15 @@ func (bcR *Reactor) Receive(e p2p.Envelope) {
 		_ = e.Src.TrySend(p2p.Envelope{
 			ChannelID: BlocksyncChannel,
 			Message: &bcproto.StatusResponse{
-				Height: bcR.store.Height(),
-				Base:   bcR.store.Base(),
+				Height:        bcR.store.Height(),
+				Base:          bcR.store.Base(),
+				MaxBlockRange: MaxBlockRangeSize,
 			},
 		})
 	case *bcproto.StatusResponse:
 		// Got a peer status. Unverified.
 		bcR.pool.SetPeerRange(e.Src.ID(), msg.Base, msg.Height)
+		bcR.pool.SetPeerMaxBlockRange(e.Src.ID(), msg.MaxBlockRange)
 	case *bcproto.NoBlockResponse:
 		bcR.Logger.Debug("Peer does not have requested block", "peer", e.Src, "height", msg.Height)
 		bcR.pool.RedoRequestFrom(msg.Height, e.Src.ID())
@@ -321,10 +338,26 @@ func (bcR *Reactor) poolRoutine(stateSynced bool) {
 		lastHundred  = time.Now()
 		lastRate     = 0.0
 		didProcessCh = make(chan struct{}, 1)
+
+		// The signatures of the last commit verified, reused to validate the
+		// last commit of the next block.
+		lastSignatures types.SignatureCache
 	)
 
 	go bcR.handleBlockRequestsRoutine()
 
+	// The commits are verified ahead of the application of the blocks.
+	bcR.verifier.SetState(state)
+	if err := bcR.verifier.Start(); err != nil {
+		bcR.Logger.Error("Error starting block verifier", "err", err)
+		return
+	}
+	defer func() {
+		if err := bcR.verifier.Stop(); err != nil {
+			bcR.Logger.Error("Error stopping block verifier", "err", err)
+		}
+	}()
+
 	if bcR.switchToConsensusMs == 0 {
 		bcR.switchToConsensusMs = switchToConsensusIntervalSeconds * 1000
 	}
@@ -369,6 +402,9 @@ FOR_LOOP:
 			// coupling them as it's written here.  TODO uncouple from request
 			// routine.
 
+			// Verify the blocks received so far ahead of their application.
+			bcR.verifier.Schedule(bcR.pool.PeekBlocks(maxVerifyAheadBlocks))
+
 			// See if there are any blocks to sync.
 			first, second, extCommit := bcR.pool.PeekTwoBlocks()
 			if first == nil || second == nil {
@@ -396,7 +432,7 @@ FOR_LOOP:
 			// Try again quickly next loop.
 			didProcessCh <- struct{}{}
 
-			firstParts, err := first.MakePartSet(types.BlockPartSizeBytes)
+			firstParts, signatures, err := bcR.verifier.Result(first, second)
 			if err != nil {
 				bcR.Logger.Error("failed to make ",
 					"height", first.Height,
@@ -404,10 +440,13 @@ FOR_LOOP:
 				break FOR_LOOP
 			}
 
-			if state, err = bcR.processBlock(first, second, firstParts, state, extCommit); err != nil {
+			if state, err = bcR.processBlock(first, second, firstParts, state, extCommit, signatures, lastSignatures); err != nil {
 				bcR.Logger.Error("Invalid block", "height", first.Height, "err", err)
 				continue FOR_LOOP
 			}
+			lastSignatures = signatures
+			bcR.verifier.SetState(state)
+			bcR.verifier.Prune(first.Height)
 
 			blocksSynced++
 
@@ -441,9 +480,13 @@ func (bcR *Reactor) handleBlockRequest(request BlockRequest) {
 	if peer == nil {
 		return
 	}
+	var msg proto.Message = &bcproto.BlockRequest{Height: request.Height}
+	if request.Count > 1 {
+		msg = &bcproto.BlockRangeRequest{StartHeight: request.Height, Count: request.Count}
+	}
 	err := peer.TrySend(p2p.Envelope{
 		ChannelID: BlocksyncChannel,
-		Message:   &bcproto.BlockRequest{Height: request.Height},
+		Message:   msg,
 	})
 	if err != nil {
 		if e, ok := err.(p2p.SendError); ok && e.Full() {
@@ -532,7 +575,16 @@ func (bcR *Reactor) isCaughtUp(state sm.State, blocksSynced uint64, stateSynced
 	return false
 }
 
-func (bcR *Reactor) processBlock(first, second *types.Block, firstParts *types.PartSet, state sm.State, extCommit *types.ExtendedCommit) (sm.State, error) {
+// processBlock verifies the first block with the commit found in the second one,
+// then saves and applies it. The signatures already verified, of its commit and
+// of its last commit, are skipped.
+func (bcR *Reactor) processBlock(
+	first, second *types.Block,
+	firstParts *types.PartSet,
+	state sm.State,
+	extCommit *types.ExtendedCommit,
+	signatures, lastSignatures types.SignatureCache,
+) (sm.State, error) {
 	var (
 		chainID            = bcR.initialState.ChainID
 		firstPartSetHeader = firstParts.Header()
@@ -544,12 +596,12 @@ func (bcR *Reactor) processBlock(first, second *types.Block, firstParts *types.P
 	// first.Hash() doesn't verify the tx contents, so MakePartSet() is
 	// currently necessary.
 	// TODO(sergio): Should we also validate against the extended commit?
-	err := state.Validators.VerifyCommitLight(
-		chainID, firstID, first.Height, second.LastCommit)
+	err := state.Validators.VerifyCommitLightWithCache(
+		chainID, firstID, first.Height, second.LastCommit, signatures)
 
 	if err == nil {
 		// validate the block before we persist it
-		err = bcR.blockExec.ValidateBlock(state, first)
+		err = bcR.blockExec.ValidateBlockWithCache(state, first, lastSignatures)
 	}
 
 	presentExtCommit := extCommit != nil
@@ -571,20 +623,22 @@ func (bcR *Reactor) processBlock(first, second *types.Block, firstParts *types.P
 		if peer != nil {
 			// NOTE: we've already removed the peer's request, but we
 			// still need to clean up the rest.
-			bcR.Switch.StopPeerForError(peer, ErrReactorValidation{Err: err})
+			bcR.Switch.StopPeerForBehaviour(peer, reputation.InvalidBlock, ErrReactorValidation{Err: err})
 		}
 		peerID2 := bcR.pool.RemovePeerAndRedoAllPeerRequests(second.Height)
 		peer2 := bcR.Switch.Peers().Get(peerID2)
 		if peer2 != nil && peer2 != peer {
 			// NOTE: we've already removed the peer's request, but we
 			// still need to clean up the rest.
-			bcR.Switch.StopPeerForError(peer2, ErrReactorValidation{Err: err})
+			bcR.Switch.StopPeerForBehaviour(peer2, reputation.InvalidBlock, ErrReactorValidation{Err: err})
 		}
 		return state, err
 	}
 
 	// SUCCESS. Pop the block from the pool.
-	bcR.pool.PopRequest()
+	if peer := bcR.Switch.Peers().Get(bcR.pool.PopRequest()); peer != nil {
+		bcR.Switch.ReportBehaviour(peer, reputation.Block)
+	}
 
 	// TODO: batch saves so we dont persist to disk every block
 	if extensionsEnabled {
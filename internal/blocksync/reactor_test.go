@@ -552,6 +552,12 @@ func (bcR *ByzantineReactor) Receive(e p2p.Envelope) {
 	switch msg := e.Message.(type) {
 	case *bcproto.BlockRequest:
 		bcR.respondToPeer(msg, e.Src)
+	case *bcproto.BlockRangeRequest:
+		for height := msg.StartHeight; height < msg.StartHeight+msg.Count; height++ {
+			if !bcR.respondToPeer(&bcproto.BlockRequest{Height: height}, e.Src) {
+				break
+			}
+		}
 	case *bcproto.BlockResponse:
 		bi, err := types.BlockFromProto(msg.Block)
 		if err != nil {
@@ -580,13 +586,15 @@ func (bcR *ByzantineReactor) Receive(e p2p.Envelope) {
 		_ = e.Src.TrySend(p2p.Envelope{
 			ChannelID: BlocksyncChannel,
 			Message: &bcproto.StatusResponse{
-				Height: bcR.store.Height(),
-				Base:   bcR.store.Base(),
+				Height:        bcR.store.Height(),
+				Base:          bcR.store.Base(),
+				MaxBlockRange: MaxBlockRangeSize,
 			},
 		})
 	case *bcproto.StatusResponse:
 		// Got a peer status. Unverified.
 		bcR.pool.SetPeerRange(e.Src.ID(), msg.Base, msg.Height)
+		bcR.pool.SetPeerMaxBlockRange(e.Src.ID(), msg.MaxBlockRange)
 	case *bcproto.NoBlockResponse:
 		bcR.Logger.Debug("Peer does not have requested block", "peer", e.Src, "height", msg.Height)
 		bcR.pool.RedoRequestFrom(msg.Height, e.Src.ID())
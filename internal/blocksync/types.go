@@ -11,4 +11,5 @@ var (
 	_ types.Wrapper = &cmtbs.NoBlockResponse{}
 	_ types.Wrapper = &cmtbs.BlockResponse{}
 	_ types.Wrapper = &cmtbs.BlockRequest{}
+	_ types.Wrapper = &cmtbs.BlockRangeRequest{}
 )
@@ -0,0 +1,187 @@
+package blocksync
+
+import (
+	"errors"
+
+	"github.com/cometbft/cometbft/v2/libs/service"
+	cmtsync "github.com/cometbft/cometbft/v2/libs/sync"
+	sm "github.com/cometbft/cometbft/v2/state"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+// maxVerifyAheadBlocks is the maximum number of blocks verified ahead of their
+// application.
+const maxVerifyAheadBlocks = 64
+
+// blockVerifier verifies the commits of the blocks received from peers on
+// multiple goroutines, ahead of their application. The verified signatures are
+// cached, so that they are not verified again when the blocks are validated
+// and applied, one at a time. The part sets of the blocks are made along the
+// way.
+//
+// The commits are verified with the validator set whose hash is in the block
+// header, if it is the validator set of the last state, or the next one. Other
+// blocks are verified on application only. As the headers are not verified
+// yet, the verification ahead of application only caches valid signatures: the
+// commits are verified again, from the cache, against the state on application.
+type blockVerifier struct {
+	service.BaseService
+
+	chainID string
+	workers int
+	jobs    chan *blockVerification
+
+	mtx           cmtsync.Mutex
+	validatorSets map[string]*types.ValidatorSet // by hash
+	verifications map[int64]*blockVerification   // by height
+}
+
+// blockVerification is the verification of the commit of a block, found in
+// the next block.
+type blockVerification struct {
+	block *types.Block
+	next  *types.Block
+	done  chan struct{}
+
+	// Set once done is closed.
+	parts      *types.PartSet
+	signatures types.SignatureCache
+	err        error
+}
+
+func newBlockVerifier(chainID string, workers int) *blockVerifier {
+	bv := &blockVerifier{
+		chainID:       chainID,
+		workers:       workers,
+		jobs:          make(chan *blockVerification, maxVerifyAheadBlocks),
+		validatorSets: make(map[string]*types.ValidatorSet),
+		verifications: make(map[int64]*blockVerification),
+	}
+	bv.BaseService = *service.NewBaseService(nil, "blockVerifier", bv)
+	return bv
+}
+
+// OnStart implements service.Service by spawning the workers.
+func (bv *blockVerifier) OnStart() error {
+	for i := 0; i < bv.workers; i++ {
+		go bv.verifyRoutine()
+	}
+	return nil
+}
+
+func (bv *blockVerifier) verifyRoutine() {
+	for {
+		select {
+		case <-bv.Quit():
+			return
+		case v := <-bv.jobs:
+			bv.verify(v)
+		}
+	}
+}
+
+// SetState sets the validator sets the commits are verified with ahead of
+// application, from the last state.
+func (bv *blockVerifier) SetState(state sm.State) {
+	bv.mtx.Lock()
+	defer bv.mtx.Unlock()
+
+	bv.validatorSets = make(map[string]*types.ValidatorSet, 2)
+	for _, vals := range []*types.ValidatorSet{state.Validators, state.NextValidators} {
+		if !vals.IsNilOrEmpty() {
+			// The workers use a copy, as the proposer is memoized on use.
+			bv.validatorSets[string(vals.Hash())] = vals.Copy()
+		}
+	}
+}
+
+// Schedule schedules the verification of the commits of the given consecutive
+// blocks, found in the block following each of them. Blocks whose verification
+// is already scheduled are skipped.
+func (bv *blockVerifier) Schedule(blocks []*types.Block) {
+	bv.mtx.Lock()
+	defer bv.mtx.Unlock()
+
+	for i := 0; i+1 < len(blocks); i++ {
+		block, next := blocks[i], blocks[i+1]
+		if v := bv.verifications[block.Height]; v != nil && v.block == block && v.next == next {
+			continue
+		}
+		v := newBlockVerification(block, next)
+		select {
+		case bv.jobs <- v:
+			bv.verifications[block.Height] = v
+		default: // the workers are busy, the remaining blocks are scheduled later on
+			return
+		}
+	}
+}
+
+// Result returns the part set of a block, and the signatures of its commit,
+// found in the next block, which were verified ahead of application. If the
+// verification was not scheduled, it is done right away.
+func (bv *blockVerifier) Result(block, next *types.Block) (*types.PartSet, types.SignatureCache, error) {
+	bv.mtx.Lock()
+	v := bv.verifications[block.Height]
+	if v == nil || v.block != block || v.next != next {
+		v = newBlockVerification(block, next)
+		bv.verifications[block.Height] = v
+		bv.mtx.Unlock()
+		bv.verify(v)
+	} else {
+		bv.mtx.Unlock()
+	}
+
+	select {
+	case <-v.done:
+		return v.parts, v.signatures, v.err
+	case <-bv.Quit():
+		return nil, nil, errors.New("block verifier stopped")
+	}
+}
+
+// Prune removes the verifications of the blocks up to the given height.
+func (bv *blockVerifier) Prune(height int64) {
+	bv.mtx.Lock()
+	defer bv.mtx.Unlock()
+
+	for h := range bv.verifications {
+		if h <= height {
+			delete(bv.verifications, h)
+		}
+	}
+}
+
+func (bv *blockVerifier) validatorSet(hash []byte) *types.ValidatorSet {
+	bv.mtx.Lock()
+	defer bv.mtx.Unlock()
+	return bv.validatorSets[string(hash)]
+}
+
+// verify makes the part set of the block, and verifies its commit, caching the
+// valid signatures.
+func (bv *blockVerifier) verify(v *blockVerification) {
+	defer close(v.done)
+
+	v.parts, v.err = v.block.MakePartSet(types.BlockPartSizeBytes)
+	if v.err != nil {
+		return
+	}
+	v.signatures = types.NewSignatureCache()
+	vals := bv.validatorSet(v.block.ValidatorsHash)
+	if vals == nil {
+		return
+	}
+	blockID := types.BlockID{Hash: v.block.Hash(), PartSetHeader: v.parts.Header()}
+	// An invalid commit is rejected on application, only the valid signatures
+	// are cached.
+	_ = vals.VerifyCommitWithCache(bv.chainID, blockID, v.block.Height, v.next.LastCommit, v.signatures)
+}
+
+func newBlockVerification(block, next *types.Block) *blockVerification {
+	return &blockVerification{
+		block: block,
+		next:  next,
+		done:  make(chan struct{}),
+	}
+}
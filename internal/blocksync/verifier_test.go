@@ -0,0 +1,162 @@
+package blocksync
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/v2/internal/test"
+	sm "github.com/cometbft/cometbft/v2/state"
+	"github.com/cometbft/cometbft/v2/types"
+	cmttime "github.com/cometbft/cometbft/v2/types/time"
+)
+
+// makeVerifierChain makes a chain of blocks with the given number of
+// validators, each block containing the commit of the previous one.
+func makeVerifierChain(tb testing.TB, numVals int, numBlocks int64) (sm.State, []*types.Block) {
+	tb.Helper()
+
+	vals, privVals := types.RandValidatorSet(numVals, 10)
+	genDoc := test.GenesisDoc(cmttime.Now(), vals.Validators, test.ConsensusParams(), test.DefaultTestChainID)
+	state, err := sm.MakeGenesisState(genDoc)
+	require.NoError(tb, err)
+
+	blocks := make([]*types.Block, 0, numBlocks)
+	lastCommit := &types.Commit{}
+	for h := int64(1); h <= numBlocks; h++ {
+		block := state.MakeBlock(h, nil, lastCommit, nil, state.Validators.GetProposer().Address)
+		parts, err := block.MakePartSet(types.BlockPartSizeBytes)
+		require.NoError(tb, err)
+		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: parts.Header()}
+		lastCommit, err = test.MakeCommit(blockID, h, 0, state.Validators, privVals, state.ChainID, cmttime.Now())
+		require.NoError(tb, err)
+		blocks = append(blocks, block)
+	}
+	return state, blocks
+}
+
+func TestBlockVerifier(t *testing.T) {
+	state, blocks := makeVerifierChain(t, 4, 5)
+
+	verifier := newBlockVerifier(state.ChainID, 2)
+	verifier.SetState(state)
+	require.NoError(t, verifier.Start())
+	t.Cleanup(func() {
+		if err := verifier.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	verifier.Schedule(blocks[:4])
+	for i := 0; i < 3; i++ {
+		parts, signatures, err := verifier.Result(blocks[i], blocks[i+1])
+		require.NoError(t, err)
+		expected, err := blocks[i].MakePartSet(types.BlockPartSizeBytes)
+		require.NoError(t, err)
+		assert.Equal(t, expected.Header(), parts.Header())
+		assert.Equal(t, 4, signatures.Len())
+
+		// The verification on application only checks the cached signatures.
+		blockID := types.BlockID{Hash: blocks[i].Hash(), PartSetHeader: parts.Header()}
+		require.NoError(t, state.Validators.VerifyCommitLightWithCache(
+			state.ChainID, blockID, blocks[i].Height, blocks[i+1].LastCommit, signatures))
+		require.NoError(t, state.Validators.VerifyCommitWithCache(
+			state.ChainID, blockID, blocks[i].Height, blocks[i+1].LastCommit, signatures))
+		assert.Equal(t, 4, signatures.Len())
+	}
+
+	// The verification of blocks not scheduled is done right away.
+	_, signatures, err := verifier.Result(blocks[3], blocks[4])
+	require.NoError(t, err)
+	assert.Equal(t, 4, signatures.Len())
+
+	// The verifications up to the applied height are removed.
+	verifier.Prune(4)
+	assert.Empty(t, verifier.verifications)
+
+	// Invalid signatures are not cached.
+	next := &types.Block{LastCommit: blocks[4].LastCommit.Clone()}
+	next.LastCommit.Signatures[0].Signature = []byte("invalid")
+	_, signatures, err = verifier.Result(blocks[3], next)
+	require.NoError(t, err)
+	_, ok := signatures.Get("invalid")
+	assert.False(t, ok)
+
+	// The commits of blocks with an unknown validator set are verified on
+	// application only.
+	otherState, _ := makeVerifierChain(t, 4, 1)
+	verifier.SetState(otherState)
+	parts, signatures, err := verifier.Result(blocks[0], blocks[1])
+	require.NoError(t, err)
+	assert.NotNil(t, parts)
+	assert.Equal(t, 0, signatures.Len())
+}
+
+func TestBlockPoolPeekBlocks(t *testing.T) {
+	pool := NewBlockPool(1, make(chan BlockRequest), make(chan peerError, 10))
+	pool.SetPeerRange("peer", 1, 10)
+	for h := int64(1); h <= 4; h++ {
+		r := newBPRequester(pool, h)
+		r.peerID = "peer"
+		pool.requesters[h] = r
+		pool.peers["peer"].incrPending()
+	}
+	for _, h := range []int64{1, 2, 4} {
+		block := &types.Block{Header: types.Header{Height: h}, LastCommit: &types.Commit{}}
+		require.NoError(t, pool.AddBlock("peer", block, nil, 1))
+	}
+
+	blocks := pool.PeekBlocks(10)
+	require.Len(t, blocks, 2)
+	assert.EqualValues(t, 1, blocks[0].Height)
+	assert.EqualValues(t, 2, blocks[1].Height)
+	assert.Len(t, pool.PeekBlocks(1), 1)
+}
+
+// BenchmarkBlockVerification compares the verification of the commits of a
+// chain one block at a time, as they are applied, with their verification
+// ahead of application on multiple goroutines.
+func BenchmarkBlockVerification(b *testing.B) {
+	const numBlocks = 32
+	state, blocks := makeVerifierChain(b, 100, numBlocks+1)
+
+	b.Run("sequential", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			for i := 0; i < numBlocks; i++ {
+				parts, err := blocks[i].MakePartSet(types.BlockPartSizeBytes)
+				require.NoError(b, err)
+				blockID := types.BlockID{Hash: blocks[i].Hash(), PartSetHeader: parts.Header()}
+				// The commit is verified, then verified again as the last
+				// commit of the next block.
+				require.NoError(b, state.Validators.VerifyCommitLight(
+					state.ChainID, blockID, blocks[i].Height, blocks[i+1].LastCommit))
+				require.NoError(b, state.Validators.VerifyCommit(
+					state.ChainID, blockID, blocks[i].Height, blocks[i+1].LastCommit))
+			}
+		}
+		b.ReportMetric(float64(numBlocks*b.N)/b.Elapsed().Seconds(), "blocks/s")
+	})
+
+	b.Run("parallel", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			verifier := newBlockVerifier(state.ChainID, runtime.NumCPU())
+			verifier.SetState(state)
+			require.NoError(b, verifier.Start())
+			verifier.Schedule(blocks)
+			for i := 0; i < numBlocks; i++ {
+				parts, signatures, err := verifier.Result(blocks[i], blocks[i+1])
+				require.NoError(b, err)
+				blockID := types.BlockID{Hash: blocks[i].Hash(), PartSetHeader: parts.Header()}
+				require.NoError(b, state.Validators.VerifyCommitLightWithCache(
+					state.ChainID, blockID, blocks[i].Height, blocks[i+1].LastCommit, signatures))
+				require.NoError(b, state.Validators.VerifyCommitWithCache(
+					state.ChainID, blockID, blocks[i].Height, blocks[i+1].LastCommit, signatures))
+				verifier.Prune(blocks[i].Height)
+			}
+			require.NoError(b, verifier.Stop())
+		}
+		b.ReportMetric(float64(numBlocks*b.N)/b.Elapsed().Seconds(), "blocks/s")
+	})
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/cometbft/cometbft/v2/libs/log"
 	cmtsync "github.com/cometbft/cometbft/v2/libs/sync"
 	"github.com/cometbft/cometbft/v2/p2p"
+	"github.com/cometbft/cometbft/v2/p2p/reputation"
 	tcpconn "github.com/cometbft/cometbft/v2/p2p/transport/tcp/conn"
 	sm "github.com/cometbft/cometbft/v2/state"
 	"github.com/cometbft/cometbft/v2/types"
@@ -130,6 +131,12 @@ func (conR *Reactor) SwitchToConsensus(state sm.State, skipWAL bool) {
 		// NOTE: The line below causes broadcastNewRoundStepRoutine() to broadcast a
 		// NewRoundStepMessage.
 		conR.conS.updateToState(state)
+
+		// The private validator may have to rotate to its next key at the
+		// height we caught up to.
+		if err := conR.conS.updatePrivValidatorPubKey(); err != nil {
+			conR.Logger.Error("Failed to get private validator pubkey", "err", err)
+		}
 	}()
 
 	// stop waiting for syncing to finish
@@ -248,13 +255,13 @@ func (conR *Reactor) Receive(e p2p.Envelope) {
 	msg, err := MsgFromProto(e.Message)
 	if err != nil {
 		conR.Logger.Error("Error decoding message", "src", e.Src, "chId", e.ChannelID, "err", err)
-		conR.Switch.StopPeerForError(e.Src, err)
+		conR.Switch.StopPeerForBehaviour(e.Src, reputation.BadMessage, err)
 		return
 	}
 
 	if err = msg.ValidateBasic(); err != nil {
 		conR.Logger.Error("Peer sent us invalid msg", "peer", e.Src, "msg", e.Message, "err", err)
-		conR.Switch.StopPeerForError(e.Src, err)
+		conR.Switch.StopPeerForBehaviour(e.Src, reputation.BadMessage, err)
 		return
 	}
 
@@ -1035,10 +1042,12 @@ func (conR *Reactor) peerStatsRoutine() {
 			}
 			switch msg.Msg.(type) {
 			case *VoteMessage:
+				conR.Switch.ReportBehaviour(peer, reputation.ConsensusVote)
 				if numVotes := ps.RecordVote(); numVotes%votesToContributeToBecomeGoodPeer == 0 {
 					conR.Switch.MarkPeerAsGood(peer)
 				}
 			case *BlockPartMessage:
+				conR.Switch.ReportBehaviour(peer, reputation.BlockPart)
 				if numParts := ps.RecordBlockPart(); numParts%blocksToContributeToBecomeGoodPeer == 0 {
 					conR.Switch.MarkPeerAsGood(peer)
 				}
@@ -2642,11 +2642,19 @@ func (cs *State) signAddVote(
 // updatePrivValidatorPubKey gets the private validator public key and
 // memoizes it. This func returns an error if the private validator is not
 // responding or responds with an error.
+//
+// If the private validator holds a next key which is in the validator set of
+// the current height, it first rotates to it.
 func (cs *State) updatePrivValidatorPubKey() error {
 	if cs.privValidator == nil {
 		return nil
 	}
 
+	// Keep signing with the current key if the rotation fails.
+	if err := cs.rotatePrivValidatorKey(); err != nil {
+		cs.Logger.Error("Failed to rotate private validator key", "height", cs.Height, "err", err)
+	}
+
 	pubKey, err := cs.privValidator.GetPubKey()
 	if err != nil {
 		return err
@@ -2655,6 +2663,29 @@ func (cs *State) updatePrivValidatorPubKey() error {
 	return nil
 }
 
+// rotatePrivValidatorKey rotates the private validator to its next key, if it
+// has one and the next key is in the validator set of the current height. The
+// validator updates of FinalizeBlock take effect two heights later, so the
+// current key signs all the heights up to then.
+func (cs *State) rotatePrivValidatorKey() error {
+	rotator, ok := cs.privValidator.(types.KeyRotatingPrivValidator)
+	if !ok || cs.Validators == nil {
+		return nil
+	}
+	nextPubKey, err := rotator.NextPubKey()
+	if err != nil || nextPubKey == nil {
+		return err
+	}
+	if !cs.Validators.HasAddress(nextPubKey.Address()) {
+		return nil
+	}
+	if err := rotator.RotateKey(); err != nil {
+		return fmt.Errorf("failed to rotate private validator key: %w", err)
+	}
+	cs.Logger.Info("Rotated private validator key", "height", cs.Height, "address", nextPubKey.Address())
+	return nil
+}
+
 // look back to check existence of the node's consensus votes before joining consensus.
 func (cs *State) checkDoubleSigningRisk(height int64) error {
 	if cs.privValidator != nil && cs.privValidatorPubKey != nil && cs.config.DoubleSignCheckHeight > 0 && height > 0 {
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -16,6 +17,7 @@ import (
 	"github.com/cometbft/cometbft/v2/abci/example/kvstore"
 	abci "github.com/cometbft/cometbft/v2/abci/types"
 	abcimocks "github.com/cometbft/cometbft/v2/abci/types/mocks"
+	"github.com/cometbft/cometbft/v2/crypto/ed25519"
 	"github.com/cometbft/cometbft/v2/crypto/tmhash"
 	cstypes "github.com/cometbft/cometbft/v2/internal/consensus/types"
 	cmtrand "github.com/cometbft/cometbft/v2/internal/rand"
@@ -25,6 +27,7 @@ import (
 	"github.com/cometbft/cometbft/v2/libs/protoio"
 	cmtpubsub "github.com/cometbft/cometbft/v2/libs/pubsub"
 	p2pmock "github.com/cometbft/cometbft/v2/p2p/mock"
+	"github.com/cometbft/cometbft/v2/privval"
 	"github.com/cometbft/cometbft/v2/types"
 )
 
@@ -3332,3 +3335,29 @@ func TestReadSerializedBlockFromBlockParts(t *testing.T) {
 		}
 	}
 }
+
+func TestStateRotatePrivValidatorKey(t *testing.T) {
+	cs, _ := randState(1)
+	dir := t.TempDir()
+	keyFile, nextKeyFile := filepath.Join(dir, "key.json"), filepath.Join(dir, "next_key.json")
+	pv, err := privval.GenFilePV(keyFile, filepath.Join(dir, "state.json"), nil)
+	require.NoError(t, err)
+	pv.Save()
+	nextKey := ed25519.GenPrivKey()
+	require.NoError(t, pv.StageNextKey(nextKey, nextKeyFile))
+
+	// The current key signs until the next key is in the validator set.
+	cs.SetPrivValidator(pv)
+	require.Equal(t, pv.Key.PubKey, cs.privValidatorPubKey)
+
+	cs.Validators = types.NewValidatorSet([]*types.Validator{types.NewValidator(nextKey.PubKey(), 10)})
+	require.NoError(t, cs.updatePrivValidatorPubKey())
+	require.Equal(t, nextKey.PubKey(), cs.privValidatorPubKey)
+	nextPubKey, err := pv.NextPubKey()
+	require.NoError(t, err)
+	require.Nil(t, nextPubKey)
+
+	// The rotation is persisted.
+	require.NoFileExists(t, nextKeyFile)
+	require.Equal(t, nextKey, privval.LoadFilePVEmptyState(keyFile, "").Key.PrivKey)
+}
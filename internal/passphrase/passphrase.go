@@ -0,0 +1,84 @@
+// Package passphrase reads the passphrase of the keys stored encrypted on
+// disk, such as priv_validator_key.json and node_key.json.
+package passphrase
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"golang.org/x/term"
+
+	"github.com/cometbft/cometbft/v2/crypto/armor"
+)
+
+// EnvVar is the environment variable from which the passphrase is read.
+const EnvVar = "CMT_KEY_PASSPHRASE"
+
+// ErrNoPassphrase is returned when the passphrase is not set in EnvVar or in a
+// file, and cannot be prompted for because the standard input is not a
+// terminal.
+var ErrNoPassphrase = errors.New("no passphrase: set " + EnvVar + " or key_passphrase_file, or run interactively")
+
+// New returns a PassphraseFunc which reads the passphrase with Read the first
+// time it is called, and returns the same passphrase afterwards, so that the
+// user is prompted at most once for all the keys.
+func New(file string) armor.PassphraseFunc {
+	var (
+		once       sync.Once
+		passphrase []byte
+		err        error
+	)
+	return func() ([]byte, error) {
+		once.Do(func() { passphrase, err = Read(file, false) })
+		return passphrase, err
+	}
+}
+
+// Read reads the passphrase from EnvVar if it is set, or else from file if it
+// is not empty, or else from an interactive prompt on the terminal. If confirm
+// is true, the prompt asks for the passphrase twice, as is appropriate when
+// encrypting a key.
+func Read(file string, confirm bool) ([]byte, error) {
+	var passphrase []byte
+	switch env, ok := os.LookupEnv(EnvVar); {
+	case ok:
+		passphrase = []byte(env)
+	case file != "":
+		bz, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read passphrase file: %w", err)
+		}
+		passphrase = bytes.TrimRight(bz, "\r\n")
+	default:
+		var err error
+		if passphrase, err = prompt("Enter key passphrase: "); err != nil {
+			return nil, err
+		}
+		if confirm {
+			again, err := prompt("Repeat key passphrase: ")
+			if err != nil {
+				return nil, err
+			}
+			if !bytes.Equal(passphrase, again) {
+				return nil, errors.New("passphrases do not match")
+			}
+		}
+	}
+	if len(passphrase) == 0 {
+		return nil, errors.New("empty passphrase")
+	}
+	return passphrase, nil
+}
+
+func prompt(msg string) ([]byte, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return nil, ErrNoPassphrase
+	}
+	fmt.Fprint(os.Stderr, msg)
+	defer fmt.Fprintln(os.Stderr)
+	return term.ReadPassword(fd)
+}
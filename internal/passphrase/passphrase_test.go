@@ -0,0 +1,28 @@
+package passphrase
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRead(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "passphrase")
+	require.NoError(t, os.WriteFile(file, []byte("from file\n"), 0o600))
+
+	passphrase, err := Read(file, false)
+	require.NoError(t, err)
+	require.Equal(t, []byte("from file"), passphrase)
+
+	// The environment variable takes precedence over the file.
+	t.Setenv(EnvVar, "from env")
+	passphrase, err = New(file)()
+	require.NoError(t, err)
+	require.Equal(t, []byte("from env"), passphrase)
+
+	t.Setenv(EnvVar, "")
+	_, err = Read(file, false)
+	require.ErrorContains(t, err, "empty passphrase")
+}
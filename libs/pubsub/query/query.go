@@ -2,6 +2,7 @@
 // subscriptions in CometBFT.
 //
 //	abci.invoice.number=22 AND abci.invoice.owner=Ivan
+//	abci.invoice.owner=Ivan AND (abci.invoice.number=22 OR NOT abci.invoice.paid EXISTS)
 //
 // Query expressions can handle attribute values encoding numbers, strings,
 // dates, and timestamps.  The complete query grammar is described in the
@@ -24,17 +25,18 @@ var All *Query
 
 // A Query is the compiled form of a query.
 type Query struct {
-	ast   syntax.Query
-	conds []condition
+	expr syntax.Expr
+	ast  syntax.Query // nil if expr is not a conjunction of conditions
+	root node
 }
 
 // New parses and compiles the query expression into an executable query.
 func New(query string) (*Query, error) {
-	ast, err := syntax.Parse(query)
+	expr, err := syntax.ParseExpr(query)
 	if err != nil {
 		return nil, err
 	}
-	return Compile(ast)
+	return CompileExpr(expr)
 }
 
 // MustCompile compiles the query expression into an executable query.
@@ -52,15 +54,18 @@ func MustCompile(query string) *Query {
 
 // Compile compiles the given query AST so it can be used to match events.
 func Compile(ast syntax.Query) (*Query, error) {
-	conds := make([]condition, len(ast))
-	for i, q := range ast {
-		cond, err := compileCondition(q)
-		if err != nil {
-			return nil, fmt.Errorf("compile %s: %w", q, err)
-		}
-		conds[i] = cond
+	return CompileExpr(syntax.QueryExpr(ast))
+}
+
+// CompileExpr compiles the given query expression so it can be used to match
+// events.
+func CompileExpr(expr syntax.Expr) (*Query, error) {
+	root, err := compileNode(expr)
+	if err != nil {
+		return nil, err
 	}
-	return &Query{ast: ast, conds: conds}, nil
+	ast, _ := expr.Conditions()
+	return &Query{expr: expr, ast: ast, root: root}, nil
 }
 
 func ExpandEvents(flattenedEvents map[string][]string) []types.Event {
@@ -100,10 +105,12 @@ func (q *Query) String() string {
 	if q == nil {
 		return "<empty>"
 	}
-	return q.ast.String()
+	return q.expr.String()
 }
 
-// Syntax returns the syntax tree representation of q.
+// Syntax returns the conditions of q if q is a conjunction of conditions, or
+// nil otherwise (if q uses the OR or NOT operators). Use Expr to inspect
+// arbitrary queries.
 func (q *Query) Syntax() syntax.Query {
 	if q == nil {
 		return nil
@@ -111,14 +118,89 @@ func (q *Query) Syntax() syntax.Query {
 	return q.ast
 }
 
-// matchesEvents reports whether all the conditions match the given events.
+// Expr returns the syntax tree representation of q. For a nil *Query, which
+// matches all events, it returns an empty conjunction.
+func (q *Query) Expr() syntax.Expr {
+	if q == nil {
+		return syntax.Expr{Op: syntax.TAnd}
+	}
+	return q.expr
+}
+
+// ValueMatcher returns a function reporting whether an attribute value
+// satisfies the operator and argument of cond, as when matching events. This
+// allows conditions to be evaluated against values stored outside of events,
+// e.g. by indexers.
+func ValueMatcher(cond syntax.Condition) (func(value string) bool, error) {
+	c, err := compileCondition(cond)
+	if err != nil {
+		return nil, err
+	}
+	return c.match, nil
+}
+
+// matchesEvents reports whether the given events satisfy the query.
 func (q *Query) matchesEvents(events []types.Event) bool {
-	for _, cond := range q.conds {
-		if !cond.matchesAny(events) {
-			return false
+	return len(events) != 0 && q.root.matches(events)
+}
+
+// A node is a compiled query expression: a condition, or the conjunction,
+// disjunction or negation of other nodes.
+type node struct {
+	cond *condition
+	op   syntax.Token
+	args []node
+}
+
+func compileNode(expr syntax.Expr) (node, error) {
+	if expr.Cond != nil {
+		cond, err := compileCondition(*expr.Cond)
+		if err != nil {
+			return node{}, fmt.Errorf("compile %s: %w", expr.Cond, err)
+		}
+		return node{cond: &cond}, nil
+	}
+	switch expr.Op {
+	case syntax.TAnd, syntax.TOr, syntax.TNot:
+	default:
+		return node{}, fmt.Errorf("compile %s: unknown operator %v", expr, expr.Op)
+	}
+	args := make([]node, len(expr.Args))
+	for i, arg := range expr.Args {
+		n, err := compileNode(arg)
+		if err != nil {
+			return node{}, err
+		}
+		args[i] = n
+	}
+	return node{op: expr.Op, args: args}, nil
+}
+
+// matches reports whether the given events satisfy n.
+func (n node) matches(events []types.Event) bool {
+	if n.cond != nil {
+		return n.cond.matchesAny(events)
+	}
+	switch n.op {
+	case syntax.TAnd:
+		for _, arg := range n.args {
+			if !arg.matches(events) {
+				return false
+			}
 		}
+		return true
+	case syntax.TOr:
+		for _, arg := range n.args {
+			if arg.matches(events) {
+				return true
+			}
+		}
+		return false
+	case syntax.TNot:
+		return !n.args[0].matches(events)
+	default:
+		return false
 	}
-	return len(events) != 0
 }
 
 // A condition is a compiled match condition.  A condition matches an event if
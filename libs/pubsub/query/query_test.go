@@ -478,6 +478,48 @@ func TestCompiledMatches(t *testing.T) {
 			`tm.event = 'Tx' AND rewards.withdraw.source = 'W'`,
 			apiEvents, false,
 		},
+
+		// Disjunctions, negations and groups.
+		{
+			`slash.reason = 'double_sign' OR slash.power > 1000`,
+			newTestEvents(`slash|reason=missing_signature|power=6000`),
+			true,
+		},
+		{
+			`slash.reason = 'double_sign' OR slash.power > 10000`,
+			newTestEvents(`slash|reason=missing_signature|power=6000`),
+			false,
+		},
+		{
+			`NOT slash.reason = 'double_sign'`,
+			newTestEvents(`slash|reason=missing_signature|power=6000`),
+			true,
+		},
+		{
+			`NOT slash.reason EXISTS`,
+			newTestEvents(`slash|reason=missing_signature|power=6000`),
+			false,
+		},
+		{
+			`tm.event = 'Tx' AND (transfer.sender = 'AddrZ' OR transfer.sender = 'AddrC')`,
+			apiEvents, true,
+		},
+		{
+			`tm.event = 'Tx' AND NOT (transfer.sender = 'AddrZ' OR transfer.sender = 'AddrC')`,
+			apiEvents, false,
+		},
+		{
+			`transfer.sender = 'AddrZ' OR tm.event = 'Tx' AND transfer.sender = 'AddrC'`,
+			apiEvents, true,
+		},
+		{
+			`(transfer.sender = 'AddrZ' OR tm.event = 'Tx') AND transfer.sender = 'AddrY'`,
+			apiEvents, false,
+		},
+		{
+			`NOT tm.event = 'Tx' OR NOT NOT transfer.sender = 'AddrC'`,
+			apiEvents, true,
+		},
 	}
 
 	// NOTE: The original implementation allowed arbitrary prefix matches on
@@ -536,6 +578,35 @@ func TestExpandEvents(t *testing.T) {
 	}
 }
 
+func TestValueMatcher(t *testing.T) {
+	tests := []struct {
+		cond  string
+		value string
+		want  bool
+	}{
+		{`a.b = 'x'`, "x", true},
+		{`a.b = 'x'`, "y", false},
+		{`a.b CONTAINS 'bc'`, "abcd", true},
+		{`a.b > 5`, "6", true},
+		{`a.b > 5`, "6atom", true},
+		{`a.b > 5`, "5", false},
+		{`a.b > 5`, "x", false},
+		{`a.b <= DATE 2025-01-02`, "2025-01-02", true},
+		{`a.b < TIME 2025-01-02T00:00:00Z`, "2025-01-02T00:00:01Z", false},
+		{`a.b EXISTS`, "", true},
+	}
+	for _, test := range tests {
+		ast, err := syntax.Parse(test.cond)
+		require.NoError(t, err)
+		match, err := query.ValueMatcher(ast[0])
+		require.NoError(t, err)
+		require.Equal(t, test.want, match(test.value), "%s with %q", test.cond, test.value)
+	}
+
+	_, err := query.ValueMatcher(syntax.Condition{Tag: "a.b", Op: syntax.TEq})
+	require.Error(t, err)
+}
+
 func TestAllMatchesAll(t *testing.T) {
 	events := newTestEvents(
 		``,
@@ -5,14 +5,20 @@
 //
 // The grammar of the query language is defined by the following EBNF:
 //
-//	query      = conditions EOF
-//	conditions = condition {"AND" condition}
-//	condition  = tag comparison
-//	comparison = equal / order / contains / "EXISTS"
-//	equal      = "=" (date / number / time / value)
-//	order      = cmp (date / number / time)
-//	contains   = "CONTAINS" value
-//	cmp        = "<" / "<=" / ">" / ">="
+//	query       = expr EOF
+//	expr        = conjunction {"OR" conjunction}
+//	conjunction = unary {"AND" unary}
+//	unary       = "NOT" unary / "(" expr ")" / condition
+//	condition   = tag comparison
+//	comparison  = equal / order / contains / "EXISTS"
+//	equal       = "=" (date / number / time / value)
+//	order       = cmp (date / number / time)
+//	contains    = "CONTAINS" value
+//	cmp         = "<" / "<=" / ">" / ">="
+//
+// AND binds more tightly than OR, and NOT more tightly than AND, so that
+// "a EXISTS OR NOT b EXISTS AND c EXISTS" is equivalent to
+// "a EXISTS OR ((NOT b EXISTS) AND c EXISTS)".
 //
 // The lexical terms are defined here using RE2 regular expression notation:
 //
@@ -14,8 +14,14 @@ func Parse(s string) (Query, error) {
 	return NewParser(strings.NewReader(s)).Parse()
 }
 
-// Query is the root of the parse tree for a query.  A query is the conjunction
-// of one or more conditions.
+// ParseExpr parses the specified query expression. It is shorthand for
+// constructing a parser for s and calling its ParseExpr method.
+func ParseExpr(s string) (Expr, error) {
+	return NewParser(strings.NewReader(s)).ParseExpr()
+}
+
+// Query is a conjunction of one or more conditions. It is the parse tree of
+// queries that do not use the OR and NOT operators or parentheses.
 type Query []Condition
 
 func (q Query) String() string {
@@ -26,6 +32,83 @@ func (q Query) String() string {
 	return strings.Join(ss, " AND ")
 }
 
+// Expr is the root of the parse tree for a query expression. An expression is
+// either a single condition, or the conjunction (AND), disjunction (OR) or
+// negation (NOT) of other expressions.
+type Expr struct {
+	// Cond is the condition of the expression, or nil if the expression is
+	// compound.
+	Cond *Condition
+
+	// Op is the operator of a compound expression: TAnd, TOr or TNot.
+	Op Token
+
+	// Args are the operands of a compound expression: two or more for TAnd
+	// and TOr, exactly one for TNot.
+	Args []Expr
+}
+
+// Conditions returns the conditions of e and true if e is a single condition
+// or a conjunction of conditions. Otherwise, it returns nil and false.
+func (e Expr) Conditions() (Query, bool) {
+	if e.Cond != nil {
+		return Query{*e.Cond}, true
+	}
+	if e.Op != TAnd {
+		return nil, false
+	}
+	conds := make(Query, 0, len(e.Args))
+	for _, arg := range e.Args {
+		if arg.Cond == nil {
+			return nil, false
+		}
+		conds = append(conds, *arg.Cond)
+	}
+	return conds, true
+}
+
+func (e Expr) String() string {
+	if e.Cond != nil {
+		return e.Cond.String()
+	}
+	switch e.Op {
+	case TNot:
+		return "NOT " + e.Args[0].group(TNot)
+	case TAnd, TOr:
+		ss := make([]string, len(e.Args))
+		for i, arg := range e.Args {
+			ss[i] = arg.group(e.Op)
+		}
+		if e.Op == TAnd {
+			return strings.Join(ss, " AND ")
+		}
+		return strings.Join(ss, " OR ")
+	default:
+		return ""
+	}
+}
+
+// group returns the string representation of e as an operand of op, enclosed
+// in parentheses if needed.
+func (e Expr) group(op Token) string {
+	if e.Cond == nil && (op == TNot || e.Op == TOr) {
+		return "(" + e.String() + ")"
+	}
+	return e.String()
+}
+
+// QueryExpr returns the expression corresponding to the conjunction q.
+func QueryExpr(q Query) Expr {
+	if len(q) == 1 {
+		return Expr{Cond: &q[0]}
+	}
+	args := make([]Expr, len(q))
+	for i := range q {
+		args[i] = Expr{Cond: &q[i]}
+	}
+	return Expr{Op: TAnd, Args: args}
+}
+
 // A Condition is a single conditional expression, consisting of a tag, a
 // comparison operator, and an optional argument. The type of the argument
 // depends on the operator.
@@ -129,6 +212,7 @@ func (a *Arg) Value() string {
 // defined in the syntax package documentation.
 type Parser struct {
 	scanner *Scanner
+	eof     bool // whether the scanner reached the end of the input
 }
 
 // NewParser constructs a new parser that reads the input from r.
@@ -136,31 +220,129 @@ func NewParser(r io.Reader) *Parser {
 	return &Parser{scanner: NewScanner(r)}
 }
 
-// Parse parses the complete input and returns the resulting query.
+// Parse parses the complete input and returns the resulting query. It reports
+// an error if the input is not a conjunction of conditions.
 func (p *Parser) Parse() (Query, error) {
-	cond, err := p.parseCond()
+	expr, err := p.ParseExpr()
 	if err != nil {
 		return nil, err
 	}
-	conds := []Condition{cond}
-	for p.scanner.Next() != io.EOF {
-		if tok := p.scanner.Token(); tok != TAnd {
-			return nil, fmt.Errorf("offset %d: got %v, want %v", p.scanner.Pos(), tok, TAnd)
+	q, ok := expr.Conditions()
+	if !ok {
+		return nil, fmt.Errorf("query %#q is not a conjunction of conditions", expr)
+	}
+	return q, nil
+}
+
+// ParseExpr parses the complete input and returns the resulting expression.
+func (p *Parser) ParseExpr() (Expr, error) {
+	if err := p.next(); err != nil {
+		return Expr{}, err
+	}
+	expr, err := p.parseOr()
+	if err != nil {
+		return Expr{}, err
+	}
+	if !p.eof {
+		return Expr{}, fmt.Errorf("offset %d: got %v, wanted %s", p.scanner.Pos(), p.scanner.Token(), tokLabel([]Token{TAnd, TOr}))
+	}
+	return expr, nil
+}
+
+// parseOr parses a disjunction: and {OR and}.
+func (p *Parser) parseOr() (Expr, error) {
+	return p.parseBinary(TOr, p.parseAnd)
+}
+
+// parseAnd parses a conjunction: unary {AND unary}.
+func (p *Parser) parseAnd() (Expr, error) {
+	return p.parseBinary(TAnd, p.parseUnary)
+}
+
+// parseBinary parses one or more operands, parsed with parseOperand and
+// separated by op. Nested expressions with the same operator are flattened.
+func (p *Parser) parseBinary(op Token, parseOperand func() (Expr, error)) (Expr, error) {
+	var args []Expr
+	for {
+		arg, err := parseOperand()
+		if err != nil {
+			return Expr{}, err
+		}
+		if arg.Cond == nil && arg.Op == op {
+			args = append(args, arg.Args...)
+		} else {
+			args = append(args, arg)
+		}
+		if p.eof || p.scanner.Token() != op {
+			break
+		}
+		if err := p.next(); err != nil {
+			return Expr{}, err
+		}
+	}
+	if len(args) == 1 {
+		return args[0], nil
+	}
+	return Expr{Op: op, Args: args}, nil
+}
+
+// parseUnary parses a negation, a parenthesized expression or a condition.
+func (p *Parser) parseUnary() (Expr, error) {
+	if p.eof {
+		return Expr{}, fmt.Errorf("offset %d: %w", p.scanner.Pos(), io.EOF)
+	}
+	switch p.scanner.Token() {
+	case TNot:
+		if err := p.next(); err != nil {
+			return Expr{}, err
+		}
+		arg, err := p.parseUnary()
+		if err != nil {
+			return Expr{}, err
+		}
+		return Expr{Op: TNot, Args: []Expr{arg}}, nil
+
+	case TLParen:
+		if err := p.next(); err != nil {
+			return Expr{}, err
+		}
+		expr, err := p.parseOr()
+		if err != nil {
+			return Expr{}, err
+		}
+		if p.eof || p.scanner.Token() != TRParen {
+			return Expr{}, fmt.Errorf("offset %d: got %v, wanted %v", p.scanner.Pos(), p.scanner.Token(), TRParen)
 		}
+		return expr, p.next()
+
+	default:
 		cond, err := p.parseCond()
 		if err != nil {
-			return nil, err
+			return Expr{}, err
 		}
-		conds = append(conds, cond)
+		return Expr{Cond: &cond}, p.next()
+	}
+}
+
+// next advances the scanner to the next token, recording whether it reached
+// the end of the input.
+func (p *Parser) next() error {
+	err := p.scanner.Next()
+	if err == io.EOF {
+		p.eof = true
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("offset %d: %w", p.scanner.Pos(), err)
 	}
-	return conds, nil
+	return nil
 }
 
-// parseCond parses a conditional expression: tag OP value.
+// parseCond parses a conditional expression: tag OP value. The scanner must
+// be positioned at the tag.
 func (p *Parser) parseCond() (Condition, error) {
 	var cond Condition
-	if err := p.require(TTag); err != nil {
-		return cond, err
+	if tok := p.scanner.Token(); tok != TTag {
+		return cond, fmt.Errorf("offset %d: got %v, wanted %v", p.scanner.Pos(), tok, TTag)
 	}
 	cond.Tag = p.scanner.Text()
 	if err := p.require(TLeq, TGeq, TLt, TGt, TEq, TContains, TExists); err != nil {
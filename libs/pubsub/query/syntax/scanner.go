@@ -28,6 +28,10 @@ const (
 	TLeq             // operator: <=
 	TGt              // operator: >
 	TGeq             // operator: >=
+	TOr              // operator: OR
+	TNot             // operator: NOT
+	TLParen          // left parenthesis: (
+	TRParen          // right parenthesis: )
 
 	// Do not reorder these values without updating the scanner code.
 )
@@ -47,6 +51,10 @@ var tString = [...]string{
 	TLeq:      "<= operator",
 	TGt:       "> operator",
 	TGeq:      ">= operator",
+	TOr:       "OR operator",
+	TNot:      "NOT operator",
+	TLParen:   "left parenthesis",
+	TRParen:   "right parenthesis",
 }
 
 func (t Token) String() string {
@@ -107,6 +115,8 @@ func (s *Scanner) Next() error {
 			return s.scanString(ch)
 		case '<', '>', '=':
 			return s.scanCompare(ch)
+		case '(', ')':
+			return s.scanParen(ch)
 		default:
 			return s.invalid(ch)
 		}
@@ -193,6 +203,16 @@ func (s *Scanner) scanCompare(first rune) error {
 	return nil
 }
 
+func (s *Scanner) scanParen(ch rune) error {
+	s.buf.WriteRune(ch)
+	if ch == '(' {
+		s.tok = TLParen
+	} else {
+		s.tok = TRParen
+	}
+	return nil
+}
+
 func (s *Scanner) scanTagLike(first rune) error {
 	s.buf.WriteRune(first)
 	var hasSpace bool
@@ -224,6 +244,10 @@ func (s *Scanner) scanTagLike(first rune) error {
 		s.tok = TTag
 	case "AND":
 		s.tok = TAnd
+	case "OR":
+		s.tok = TOr
+	case "NOT":
+		s.tok = TNot
 	case "EXISTS":
 		s.tok = TExists
 	case "CONTAINS":
@@ -42,6 +42,11 @@ func TestScanner(t *testing.T) {
 		{`x.y CONTAINS 'z'`, []syntax.Token{syntax.TTag, syntax.TContains, syntax.TString}},
 		{`foo EXISTS`, []syntax.Token{syntax.TTag, syntax.TExists}},
 		{`and AND`, []syntax.Token{syntax.TTag, syntax.TAnd}},
+		{`x OR NOT y`, []syntax.Token{syntax.TTag, syntax.TOr, syntax.TNot, syntax.TTag}},
+		{`(x.y=1)`, []syntax.Token{
+			syntax.TLParen, syntax.TTag, syntax.TEq, syntax.TNumber, syntax.TRParen,
+		}},
+		{`or not`, []syntax.Token{syntax.TTag, syntax.TTag}},
 
 		// Timestamp
 		{`TIME 2021-11-23T15:16:17Z`, []syntax.Token{syntax.TTime}},
@@ -171,10 +176,24 @@ func TestParseValid(t *testing.T) {
 		{"hash=136E18F7E4C348B780CF873A0BF43922E5BAFA63", false},
 
 		{"cosm-wasm.transfer_amount=100", true},
+
+		{"account.balance=100 OR slashing.amount EXISTS", true},
+		{"NOT slashing.amount EXISTS", true},
+		{"account.balance=100 AND NOT (slashing.amount EXISTS OR slashing.reason='x')", true},
+		{"(account.balance=100)", true},
+		{"((a.b=1 OR a.c=2) AND NOT NOT a.d EXISTS) OR a.e=3", true},
+		{"account.balance=100 OR", false},
+		{"OR account.balance=100", false},
+		{"NOT", false},
+		{"account.balance=100 NOT slashing.amount EXISTS", false},
+		{"(account.balance=100", false},
+		{"account.balance=100)", false},
+		{"()", false},
+		{"(account.balance=100) (slashing.amount EXISTS)", false},
 	}
 
 	for _, test := range tests {
-		q, err := syntax.Parse(test.input)
+		q, err := syntax.ParseExpr(test.input)
 		if test.valid != (err == nil) {
 			t.Errorf("Parse %#q: valid %v got err=%v", test.input, test.valid, err)
 		}
@@ -182,7 +201,7 @@ func TestParseValid(t *testing.T) {
 		// For valid queries, check that the query round-trips.
 		if test.valid {
 			qstr := q.String()
-			r, err := syntax.Parse(qstr)
+			r, err := syntax.ParseExpr(qstr)
 			if err != nil {
 				t.Errorf("Reparse %#q failed: %v", qstr, err)
 			}
@@ -192,3 +211,36 @@ func TestParseValid(t *testing.T) {
 		}
 	}
 }
+
+func TestParseExpr(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string // canonical form
+		conj  bool   // whether the expression is a conjunction of conditions
+	}{
+		{"a.b = 1", "a.b = 1", true},
+		{"a.b = 1 AND a.c EXISTS", "a.b = 1 AND a.c EXISTS", true},
+		{"(a.b = 1 AND a.c EXISTS) AND a.d = 'x'", "a.b = 1 AND a.c EXISTS AND a.d = 'x'", true},
+		{"a.b = 1 OR a.c EXISTS AND a.d = 'x'", "a.b = 1 OR a.c EXISTS AND a.d = 'x'", false},
+		{"(a.b = 1 OR a.c EXISTS) AND a.d = 'x'", "(a.b = 1 OR a.c EXISTS) AND a.d = 'x'", false},
+		{"NOT a.b = 1 AND a.c EXISTS", "NOT a.b = 1 AND a.c EXISTS", false},
+		{"NOT NOT a.b = 1", "NOT (NOT a.b = 1)", false},
+		{"NOT (a.b = 1 AND a.c EXISTS)", "NOT (a.b = 1 AND a.c EXISTS)", false},
+	}
+	for _, test := range tests {
+		expr, err := syntax.ParseExpr(test.input)
+		if err != nil {
+			t.Errorf("ParseExpr %#q: unexpected error: %v", test.input, err)
+			continue
+		}
+		if got := expr.String(); got != test.want {
+			t.Errorf("ParseExpr %#q: got %#q, want %#q", test.input, got, test.want)
+		}
+		if _, conj := expr.Conditions(); conj != test.conj {
+			t.Errorf("ParseExpr %#q: conjunction %v, want %v", test.input, conj, test.conj)
+		}
+		if _, err := syntax.Parse(test.input); (err == nil) != test.conj {
+			t.Errorf("Parse %#q: got err=%v", test.input, err)
+		}
+	}
+}
@@ -863,6 +863,30 @@ func (c *Client) Witnesses() []provider.Provider {
 	return c.witnesses
 }
 
+// AddWitness adds a witness provider, for example once a new peer is
+// available.
+//
+// NOTE: the client does not check whether the provider is already a witness.
+func (c *Client) AddWitness(p provider.Provider) {
+	c.providerMutex.Lock()
+	defer c.providerMutex.Unlock()
+	c.witnesses = append(c.witnesses, p)
+}
+
+// RemoveWitness removes a witness provider, for example once its peer is
+// disconnected. It does nothing if the provider is not a witness.
+func (c *Client) RemoveWitness(p provider.Provider) {
+	c.providerMutex.Lock()
+	defer c.providerMutex.Unlock()
+	witnesses := make([]provider.Provider, 0, len(c.witnesses))
+	for _, w := range c.witnesses {
+		if w != p {
+			witnesses = append(witnesses, w)
+		}
+	}
+	c.witnesses = witnesses
+}
+
 // Cleanup removes all the data (headers and validator sets) stored. Note: the
 // client must be stopped at this point.
 func (c *Client) Cleanup() error {
@@ -778,6 +778,28 @@ func TestClientReplacesPrimaryWithWitnessIfPrimaryIsUnavailable(t *testing.T) {
 	assert.Len(t, c.Witnesses(), 2)
 }
 
+func TestClient_AddWitness(t *testing.T) {
+	c, err := light.NewClient(
+		ctx,
+		chainID,
+		trustOptions,
+		fullNode,
+		[]provider.Provider{fullNode},
+		dbs.New(dbm.NewMemDB(), chainID),
+		light.Logger(log.TestingLogger()),
+		light.MaxRetryAttempts(1),
+	)
+	require.NoError(t, err)
+	assert.Len(t, c.Witnesses(), 1)
+
+	c.AddWitness(fullNode)
+	assert.Len(t, c.Witnesses(), 2)
+
+	c.AddWitness(deadNode)
+	c.RemoveWitness(fullNode)
+	assert.Equal(t, []provider.Provider{deadNode}, c.Witnesses())
+}
+
 func TestClient_BackwardsVerification(t *testing.T) {
 	{
 		trustHeader, _ := largeFullNode.LightBlock(ctx, 6)
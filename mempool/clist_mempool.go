@@ -38,6 +38,7 @@ type CListMempool struct {
 	notifiedTxsAvailable atomic.Bool
 	txsAvailable         chan struct{} // fires once for each height, when the mempool is not empty
 	onNewTx              func(types.Tx)
+	onEvictedTx          func(types.Tx, string)
 
 	config *config.MempoolConfig
 
@@ -60,6 +61,14 @@ type CListMempool struct {
 	txsBytes  int64                           // total size of mempool, in bytes
 	numTxs    int64                           // total number of txs in the mempool
 
+	// Txs for which the app reported a sender, grouped by sender (see
+	// senderTxs). Txs that cannot be executed yet, because of a gap in the
+	// sequence numbers of their sender, are held outside of the lanes, so
+	// they are neither reaped nor gossiped until the gap is filled.
+	seqSenders   map[string]*senderTxs
+	heldTxs      map[types.TxKey]*mempoolTx
+	heldTxsBytes int64
+
 	addTxChMtx    cmtsync.RWMutex  // Protects the fields below
 	addTxCh       chan struct{}    // Blocks until the next TX is added
 	addTxSeq      int64            // Helps detect is new TXs have been added to a given lane
@@ -73,6 +82,9 @@ type CListMempool struct {
 	// This reduces the pressure on the proxyApp.
 	cache TxCache
 
+	// Optional journal where txs are persisted (nil if disabled).
+	journal *Journal
+
 	logger  log.Logger
 	metrics *Metrics
 }
@@ -110,6 +122,8 @@ func NewCListMempool(
 		config:        cfg,
 		proxyAppConn:  proxyAppConn,
 		txsMap:        make(map[types.TxKey]*clist.CElement),
+		seqSenders:    make(map[string]*senderTxs),
+		heldTxs:       make(map[types.TxKey]*mempoolTx),
 		laneBytes:     make(map[LaneID]int64),
 		logger:        log.NewNopLogger(),
 		metrics:       NopMetrics(),
@@ -160,14 +174,24 @@ func (mem *CListMempool) GetSenders(txKey types.TxKey) ([]p2p.ID, error) {
 	mem.txsMtx.RLock()
 	defer mem.txsMtx.RUnlock()
 
-	elem, ok := mem.txsMap[txKey]
-	if !ok {
+	memTx := mem.getMemTx(txKey)
+	if memTx == nil {
 		return nil, ErrTxNotFound
 	}
-	memTx := elem.Value.(*mempoolTx)
 	return memTx.Senders(), nil
 }
 
+// getMemTx returns the mempool entry of the tx identified by txKey, whether it
+// is in a lane or held, or nil if the tx is not in the mempool.
+//
+// txsMtx must be held by the caller.
+func (mem *CListMempool) getMemTx(txKey types.TxKey) *mempoolTx {
+	if elem, ok := mem.txsMap[txKey]; ok {
+		return elem.Value.(*mempoolTx)
+	}
+	return mem.heldTxs[txKey]
+}
+
 func (mem *CListMempool) addToCache(tx types.Tx) bool {
 	return mem.cache.Push(tx)
 }
@@ -208,12 +232,11 @@ func (mem *CListMempool) addSender(txKey types.TxKey, sender p2p.ID) error {
 	mem.txsMtx.Lock()
 	defer mem.txsMtx.Unlock()
 
-	elem, ok := mem.txsMap[txKey]
-	if !ok {
+	memTx := mem.getMemTx(txKey)
+	if memTx == nil {
 		return ErrTxNotFound
 	}
 
-	memTx := elem.Value.(*mempoolTx)
 	if found := memTx.addSender(sender); found {
 		// It should not be possible to receive twice a tx from the same sender.
 		return ErrTxAlreadyReceivedFromSender
@@ -256,6 +279,24 @@ func WithNewTxCallback(cb func(types.Tx)) CListMempoolOption {
 	return func(mem *CListMempool) { mem.onNewTx = cb }
 }
 
+// WithEvictedTxCallback sets a callback function to be executed when a
+// transaction is removed from the mempool without being committed. The callback
+// function will receive the evicted transaction and the reason for its eviction
+// (one of the types.EvictedTxReason constants).
+func WithEvictedTxCallback(cb func(tx types.Tx, reason string)) CListMempoolOption {
+	return func(mem *CListMempool) { mem.onEvictedTx = cb }
+}
+
+// WithJournal sets a journal where the mempool records the transactions it
+// admits and removes, so they can be restored after a restart.
+func WithJournal(journal *Journal) CListMempoolOption {
+	return func(mem *CListMempool) { mem.journal = journal }
+}
+
+func (mem *CListMempool) getMetrics() *Metrics {
+	return mem.metrics
+}
+
 // Lock acquires the exclusive lock for mempool updates.
 // Safe for concurrent use by multiple goroutines.
 func (mem *CListMempool) Lock() {
@@ -310,6 +351,33 @@ func (mem *CListMempool) LaneSizes(lane LaneID) (numTxs int, bytes int64) {
 	return txs.Len(), bytes
 }
 
+// LaneStats describes the occupancy of a lane.
+type LaneStats struct {
+	ID       LaneID
+	Priority LanePriority
+	NumTxs   int
+	Bytes    int64
+}
+
+// LaneStats returns the occupancy of each lane, sorted by descending priority.
+//
+// Safe for concurrent use by multiple goroutines.
+func (mem *CListMempool) LaneStats() []LaneStats {
+	mem.txsMtx.RLock()
+	defer mem.txsMtx.RUnlock()
+
+	stats := make([]LaneStats, 0, len(mem.sortedLanes))
+	for _, lane := range mem.sortedLanes {
+		stats = append(stats, LaneStats{
+			ID:       lane.id,
+			Priority: lane.priority,
+			NumTxs:   mem.lanes[lane.id].Len(),
+			Bytes:    mem.laneBytes[lane.id],
+		})
+	}
+	return stats
+}
+
 // Lock() must be help by the caller during execution.
 func (mem *CListMempool) FlushAppConn() error {
 	err := mem.proxyAppConn.Flush(context.TODO())
@@ -332,14 +400,24 @@ func (mem *CListMempool) Flush() {
 	for lane := range mem.lanes {
 		mem.removeAllTxs(lane)
 	}
+	if mem.journal != nil {
+		if err := mem.journal.Reset(); err != nil {
+			mem.logger.Error("Could not reset mempool journal", "err", err)
+		}
+	}
+
+	mem.txsMtx.Lock()
+	mem.seqSenders = make(map[string]*senderTxs)
+	mem.heldTxs = make(map[types.TxKey]*mempoolTx)
+	mem.heldTxsBytes = 0
+	mem.txsMtx.Unlock()
 }
 
 func (mem *CListMempool) Contains(txKey types.TxKey) bool {
 	mem.txsMtx.RLock()
 	defer mem.txsMtx.RUnlock()
 
-	_, ok := mem.txsMap[txKey]
-	return ok
+	return mem.getMemTx(txKey) != nil
 }
 
 // It blocks if we're waiting on Update() or Reap().
@@ -446,6 +524,9 @@ func (mem *CListMempool) handleCheckTxResponse(tx types.Tx, sender p2p.ID) func(
 			}
 			lane = LaneID(res.LaneId)
 		}
+		if res.Sender != "" {
+			lane = mem.senderLane(res.Sender, lane)
+		}
 
 		if err := mem.isLaneFull(len(tx), lane); err != nil {
 			mem.forceRemoveFromCache(tx) // lane might have space later
@@ -468,8 +549,30 @@ func (mem *CListMempool) handleCheckTxResponse(tx types.Tx, sender p2p.ID) func(
 		}
 
 		// Add tx to mempool and notify that new txs are available.
-		mem.addTx(tx, res.GasWanted, sender, lane)
-		mem.notifyTxsAvailable()
+		memTx := &mempoolTx{
+			tx:          tx,
+			height:      mem.height.Load(),
+			gasWanted:   res.GasWanted,
+			priority:    res.Priority,
+			lane:        lane,
+			timestamp:   cmttime.Now(),
+			appSender:   res.Sender,
+			appSequence: res.Sequence,
+		}
+		if err := mem.addTx(memTx, sender); err != nil {
+			mem.tryRemoveFromCache(tx)
+			mem.logger.Debug("Reject tx", "tx", log.NewLazyHash(tx), "height", mem.height.Load(), "err", err)
+			mem.metrics.RejectedTxs.Add(1)
+			return err
+		}
+		if mem.journal != nil {
+			if err := mem.journal.AddTx(tx, memTx.height); err != nil {
+				mem.logger.Error("Could not add tx to mempool journal", "tx", log.NewLazyHash(tx), "err", err)
+			}
+		}
+		if mem.Size() > 0 {
+			mem.notifyTxsAvailable()
+		}
 
 		if mem.onNewTx != nil {
 			mem.onNewTx(tx)
@@ -483,10 +586,27 @@ func (mem *CListMempool) handleCheckTxResponse(tx types.Tx, sender p2p.ID) func(
 
 // Called from:
 //   - handleCheckTxResponse (lock not held) if tx is valid
-func (mem *CListMempool) addTx(tx types.Tx, gasWanted int64, sender p2p.ID, lane LaneID) {
+//
+// Txs with a sender are added in sequence order, which may require holding
+// them or replacing another tx with the same sender and sequence.
+func (mem *CListMempool) addTx(memTx *mempoolTx, sender p2p.ID) error {
 	mem.txsMtx.Lock()
 	defer mem.txsMtx.Unlock()
 
+	_ = memTx.addSender(sender)
+	if memTx.appSender != "" {
+		return mem.addSequencedTx(memTx)
+	}
+	mem.pushTx(memTx)
+	return nil
+}
+
+// pushTx appends memTx to the back of its lane.
+//
+// txsMtx must be held by the caller.
+func (mem *CListMempool) pushTx(memTx *mempoolTx) {
+	tx, lane := memTx.tx, memTx.lane
+
 	// Get lane's clist.
 	txs, ok := mem.lanes[lane]
 	if !ok {
@@ -500,14 +620,7 @@ func (mem *CListMempool) addTx(tx types.Tx, gasWanted int64, sender p2p.ID, lane
 	mem.addTxLaneSeqs[lane] = mem.addTxSeq
 
 	// Add new transaction.
-	memTx := &mempoolTx{
-		tx:        tx,
-		height:    mem.height.Load(),
-		gasWanted: gasWanted,
-		lane:      lane,
-		seq:       mem.addTxSeq,
-	}
-	_ = memTx.addSender(sender)
+	memTx.seq = mem.addTxSeq
 	e := txs.PushBack(memTx)
 
 	// Update auxiliary variables.
@@ -532,23 +645,72 @@ func (mem *CListMempool) addTx(tx types.Tx, gasWanted int64, sender p2p.ID, lane
 	)
 }
 
-// RemoveTxByKey removes a transaction from the mempool by its TxKey index.
+// RemoveTxByKey removes a transaction from the mempool by its TxKey index. If
+// the transaction has a sender, the following transactions of the sender are
+// held until the gap is filled.
+func (mem *CListMempool) RemoveTxByKey(txKey types.TxKey) error {
+	if err := mem.removeTx(txKey, true); err != nil {
+		return err
+	}
+	mem.syncJournal()
+	return nil
+}
+
+// removeTx removes a transaction from the mempool by its TxKey index. If
+// resequence is false, the txs of the same sender are not resequenced, so the
+// caller must call resequenceAll later.
 // Called from:
 //   - Update (updateMtx held) if tx was committed
 //   - handleRecheckTxResponse (updateMtx not held) if tx was invalidated
-func (mem *CListMempool) RemoveTxByKey(txKey types.TxKey) error {
+func (mem *CListMempool) removeTx(txKey types.TxKey, resequence bool) error {
 	mem.txsMtx.Lock()
 	defer mem.txsMtx.Unlock()
 
-	elem, ok := mem.txsMap[txKey]
-	if !ok {
+	memTx := mem.getMemTx(txKey)
+	if memTx == nil {
 		return ErrTxNotFound
 	}
+	mem.removeTxEntry(memTx)
+	mem.removeFromJournal(memTx.tx)
+	mem.metrics.TxLifeSpan.With("lane", string(memTx.lane)).Observe(float64(cmttime.Since(memTx.timestamp).Milliseconds()))
+
+	if st, ok := mem.seqSenders[memTx.appSender]; ok {
+		if len(st.txs) == 0 {
+			delete(mem.seqSenders, memTx.appSender)
+		} else if resequence {
+			mem.resequence(st)
+		}
+	}
+	return nil
+}
 
-	memTx := elem.Value.(*mempoolTx)
+// removeFromJournal records in the journal, if any, that tx left the mempool.
+// The removal is only written by syncJournal or along with the next added tx,
+// so it can be recorded while holding txsMtx.
+func (mem *CListMempool) removeFromJournal(tx types.Tx) {
+	if mem.journal == nil {
+		return
+	}
+	mem.journal.RemoveTx(tx.Key())
+}
 
-	label := string(memTx.lane)
-	mem.metrics.TxLifeSpan.With("lane", label).Observe(float64(memTx.timestamp.Sub(time.Now().UTC())))
+// syncJournal writes the removals recorded in the journal, if any. It must be
+// called without holding txsMtx.
+func (mem *CListMempool) syncJournal() {
+	if mem.journal == nil {
+		return
+	}
+	if err := mem.journal.Sync(); err != nil {
+		mem.logger.Error("Could not sync mempool journal", "err", err)
+	}
+}
+
+// removeFromLane removes the given element from its lane.
+//
+// txsMtx must be held by the caller.
+func (mem *CListMempool) removeFromLane(elem *clist.CElement) {
+	memTx := elem.Value.(*mempoolTx)
+	txKey := memTx.tx.Key()
 
 	// Remove tx from lane.
 	mem.lanes[memTx.lane].Remove(elem)
@@ -567,12 +729,17 @@ func (mem *CListMempool) RemoveTxByKey(txKey types.TxKey) error {
 		"height", mem.height.Load(),
 		"total", mem.numTxs,
 	)
-	return nil
 }
 
+// isFull checks whether there is space for a tx of the given size, taking into
+// account the held txs.
 func (mem *CListMempool) isFull(txSize int) error {
-	memSize := mem.Size()
-	txsBytes := mem.SizeBytes()
+	// Held txs have their own limits (see checkHeldCapacity).
+	mem.txsMtx.RLock()
+	memSize := int(mem.numTxs)
+	txsBytes := mem.txsBytes
+	mem.txsMtx.RUnlock()
+
 	if memSize >= mem.config.Size || uint64(txSize)+uint64(txsBytes) > uint64(mem.config.MaxTxsBytes) {
 		return ErrMempoolIsFull{
 			NumTxs:      memSize,
@@ -644,7 +811,7 @@ func (mem *CListMempool) handleRecheckTxResponse(tx types.Tx) func(res *abci.Res
 		if (res.Code != abci.CodeTypeOK) || postCheckErr != nil {
 			// Tx became invalidated due to newly committed block.
 			mem.logger.Debug("Tx is no longer valid", "tx", log.NewLazyHash(tx), "res", res, "postCheckErr", postCheckErr)
-			if err := mem.RemoveTxByKey(tx.Key()); err != nil {
+			if err := mem.removeTx(tx.Key(), false); err != nil {
 				mem.logger.Debug("Transaction could not be removed from mempool", "err", err)
 				return err
 			}
@@ -658,6 +825,9 @@ func (mem *CListMempool) handleRecheckTxResponse(tx types.Tx) func(res *abci.Res
 			}
 
 			mem.tryRemoveFromCache(tx)
+			if mem.onEvictedTx != nil {
+				mem.onEvictedTx(tx, types.EvictedTxReasonInvalid)
+			}
 			if postCheckErr != nil {
 				return postCheckErr
 			}
@@ -756,8 +926,8 @@ func (mem *CListMempool) GetTxByHash(hash []byte) types.Tx {
 	mem.txsMtx.RLock()
 	defer mem.txsMtx.RUnlock()
 
-	if elem, ok := mem.txsMap[types.TxKey(hash)]; ok {
-		return elem.Value.(*mempoolTx).tx
+	if memTx := mem.getMemTx(types.TxKey(hash)); memTx != nil {
+		return memTx.tx
 	}
 	return nil
 }
@@ -784,6 +954,10 @@ func (mem *CListMempool) Update(
 		mem.postCheck = postCheck
 	}
 
+	// Txs of the same sender with lower sequence numbers than the committed
+	// ones can no longer be executed.
+	mem.updateSequences(txs, txResults)
+
 	for i, tx := range txs {
 		if txResults[i].Code == abci.CodeTypeOK {
 			// Add valid committed tx to the cache (if missing).
@@ -802,17 +976,27 @@ func (mem *CListMempool) Update(
 		// Mempool after:
 		//   100
 		// https://github.com/tendermint/tendermint/issues/3322.
-		if err := mem.RemoveTxByKey(tx.Key()); err != nil {
+		if err := mem.removeTx(tx.Key(), false); err != nil {
 			mem.logger.Debug("Committed transaction not in local mempool (not an error)",
 				"tx", log.NewLazyHash(tx),
 				"error", err.Error())
 		}
 	}
 
+	// Remove txs that have been in the mempool for too long.
+	mem.purgeExpiredTxs(height)
+
+	// Release the held txs that can now be executed, so they are rechecked.
+	mem.resequenceAll()
+
 	// Recheck txs left in the mempool to remove them if they became invalid in the new state.
 	if mem.config.Recheck {
 		mem.recheckTxs()
+		mem.recheckHeldTxs()
+		// Hold the txs following those that became invalid.
+		mem.resequenceAll()
 	}
+	mem.syncJournal()
 
 	// Notify if there are still txs left in the mempool.
 	if mem.Size() > 0 {
@@ -827,6 +1011,42 @@ func (mem *CListMempool) Update(
 	return nil
 }
 
+// purgeExpiredTxs removes from the mempool the txs that, at the given height,
+// have been in the mempool for more blocks or time than allowed by the config.
+// Expired txs are also removed from the cache, so they can be resubmitted.
+func (mem *CListMempool) purgeExpiredTxs(height int64) {
+	if mem.config.TTLNumBlocks == 0 && mem.config.TTLDuration == 0 {
+		return
+	}
+
+	now := cmttime.Now()
+	expired := make([]*mempoolTx, 0)
+	mem.txsMtx.RLock()
+	for _, elem := range mem.txsMap {
+		if memTx := elem.Value.(*mempoolTx); memTx.isExpired(mem.config, height, now) {
+			expired = append(expired, memTx)
+		}
+	}
+	for _, memTx := range mem.heldTxs {
+		if memTx.isExpired(mem.config, height, now) {
+			expired = append(expired, memTx)
+		}
+	}
+	mem.txsMtx.RUnlock()
+
+	for _, memTx := range expired {
+		if err := mem.removeTx(memTx.tx.Key(), false); err != nil {
+			continue
+		}
+		mem.forceRemoveFromCache(memTx.tx)
+		mem.metrics.ExpiredTxs.Add(1)
+		mem.logger.Debug("Removed expired transaction", "tx", log.NewLazyHash(memTx.tx), "height", height)
+		if mem.onEvictedTx != nil {
+			mem.onEvictedTx(memTx.tx, types.EvictedTxReasonExpired)
+		}
+	}
+}
+
 // updateSizeMetrics updates the size-related metrics of a given lane.
 func (mem *CListMempool) updateSizeMetrics(laneID LaneID) {
 	laneTxs, laneBytes := mem.LaneSizes(laneID)
@@ -835,6 +1055,9 @@ func (mem *CListMempool) updateSizeMetrics(laneID LaneID) {
 	mem.metrics.LaneBytes.With("lane", label).Set(float64(laneBytes))
 	mem.metrics.Size.Set(float64(mem.Size()))
 	mem.metrics.SizeBytes.Set(float64(mem.SizeBytes()))
+	mem.txsMtx.RLock()
+	mem.metrics.HeldTxs.Set(float64(len(mem.heldTxs)))
+	mem.txsMtx.RUnlock()
 }
 
 // recheckTxs sends all transactions in the mempool to the app for re-validation. When the function
@@ -893,6 +1116,98 @@ func (mem *CListMempool) recheckTxs() {
 	mem.logger.Debug("Done rechecking", "height", mem.height.Load(), "num-txs", mem.Size())
 }
 
+// recheckHeldTxs sends all held transactions to the app for re-validation,
+// and removes those that became invalid. When the function returns, all
+// recheck responses from the app have been processed, or the recheck timeout
+// has expired.
+func (mem *CListMempool) recheckHeldTxs() {
+	mem.txsMtx.RLock()
+	heldTxs := make([]*mempoolTx, 0, len(mem.heldTxs))
+	for _, memTx := range mem.heldTxs {
+		heldTxs = append(heldTxs, memTx)
+	}
+	mem.txsMtx.RUnlock()
+
+	if len(heldTxs) == 0 {
+		return
+	}
+	mem.logger.Debug("Recheck held txs", "height", mem.height.Load(), "num-txs", len(heldTxs))
+
+	var (
+		numPendingTxs atomic.Int32
+		timedOut      atomic.Bool
+		doneCh        = make(chan struct{})
+	)
+	numPendingTxs.Store(int32(len(heldTxs)))
+	for _, memTx := range heldTxs {
+		resReq, err := mem.proxyAppConn.CheckTxAsync(context.TODO(), &abci.CheckTxRequest{
+			Tx:   memTx.Tx(),
+			Type: abci.CHECK_TX_TYPE_RECHECK,
+		})
+		if err != nil {
+			panic(fmt.Errorf("(re-)CheckTx request for tx %s failed: %w", memTx.Tx().Hash(), err))
+		}
+		handleRes := mem.handleHeldRecheckTxResponse(memTx.Tx())
+		resReq.SetCallback(func(r *abci.Response) error {
+			if timedOut.Load() {
+				mem.logger.Error("Failed to recheck held tx", "tx", log.NewLazyHash(memTx.Tx()), "err", ErrLateRecheckResponse)
+				return ErrLateRecheckResponse
+			}
+			defer func() {
+				if numPendingTxs.Add(-1) == 0 {
+					close(doneCh)
+				}
+			}()
+			return handleRes(r)
+		})
+	}
+
+	// Flush any pending asynchronous recheck requests to process.
+	mem.proxyAppConn.Flush(context.TODO())
+
+	select {
+	case <-time.After(mem.config.RecheckTimeout):
+		timedOut.Store(true)
+		mem.logger.Error("Timed out waiting for held txs recheck responses", "not-rechecked", numPendingTxs.Load())
+	case <-doneCh:
+	}
+}
+
+// handleHeldRecheckTxResponse handles the recheck response of a held tx,
+// removing it from the mempool and the cache if it is no longer valid.
+func (mem *CListMempool) handleHeldRecheckTxResponse(tx types.Tx) func(res *abci.Response) error {
+	return func(r *abci.Response) error {
+		res := r.GetCheckTx()
+		if res == nil {
+			panic(fmt.Sprintf("unexpected response value %v not of type CheckTx", r))
+		}
+		mem.metrics.RecheckTimes.Add(1)
+
+		var postCheckErr error
+		if mem.postCheck != nil {
+			postCheckErr = mem.postCheck(tx, res)
+		}
+		if res.Code == abci.CodeTypeOK && postCheckErr == nil {
+			return nil
+		}
+
+		mem.logger.Debug("Held tx is no longer valid", "tx", log.NewLazyHash(tx), "res", res, "postCheckErr", postCheckErr)
+		if err := mem.removeTx(tx.Key(), false); err != nil {
+			mem.logger.Debug("Transaction could not be removed from mempool", "err", err)
+			return err
+		}
+		mem.metrics.EvictedTxs.Add(1)
+		mem.tryRemoveFromCache(tx)
+		if mem.onEvictedTx != nil {
+			mem.onEvictedTx(tx, types.EvictedTxReasonInvalid)
+		}
+		if postCheckErr != nil {
+			return postCheckErr
+		}
+		return ErrInvalidTx{Code: res.Code, Data: res.Data, Log: res.Log, Codespace: res.Codespace, Hash: tx.Hash()}
+	}
+}
+
 // When a recheck response for a transaction is received, cursor will point to
 // the entry in the mempool corresponding to that transaction, advancing the
 // cursor, thus narrowing the list of transactions to recheck. In case there are
@@ -300,6 +300,21 @@ func TestMempoolAddTxLane(t *testing.T) {
 		entry := mp.txsMap[types.Tx(tx).Key()].Value.(*mempoolTx)
 		require.Equal(t, kvstoreAssignLane(i), entry.lane, "id %x", tx)
 	}
+
+	// The lanes are sorted by descending priority.
+	stats := mp.LaneStats()
+	require.Len(t, stats, 4)
+	expected := []struct {
+		id     LaneID
+		numTxs int
+	}{{"val", 0}, {"foo", 10}, {defaultLane, 60}, {"bar", 30}}
+	for i, e := range expected {
+		require.Equal(t, e.id, stats[i].ID)
+		require.Equal(t, e.numTxs, stats[i].NumTxs)
+		numTxs, bytes := mp.LaneSizes(e.id)
+		require.Equal(t, numTxs, stats[i].NumTxs)
+		require.Equal(t, bytes, stats[i].Bytes)
+	}
 }
 
 func kvstoreAssignLane(key int) LaneID {
@@ -353,6 +368,49 @@ func TestMempoolUpdate(t *testing.T) {
 	}
 }
 
+func TestMempoolTTL(t *testing.T) {
+	app := kvstore.NewInMemoryApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	cfg := test.ResetTestRoot("mempool_test")
+	cfg.Mempool.TTLNumBlocks = 2
+	cfg.Mempool.TTLDuration = time.Hour
+	mp, cleanup := newMempoolWithAppAndConfig(cc, cfg)
+	defer cleanup()
+
+	var evicted types.Txs
+	mp.onEvictedTx = func(tx types.Tx, reason string) {
+		require.Equal(t, types.EvictedTxReasonExpired, reason)
+		evicted = append(evicted, tx)
+	}
+
+	// tx1 enters the mempool at height 0 and tx2 at height 1.
+	tx1 := kvstore.NewTxFromID(1)
+	callCheckTx(t, mp, types.Txs{tx1})
+	doUpdate(t, mp, 1, nil)
+	tx2 := kvstore.NewTxFromID(2)
+	callCheckTx(t, mp, types.Txs{tx2})
+
+	doUpdate(t, mp, 2, nil)
+	require.Equal(t, 2, mp.Size())
+	require.Empty(t, evicted)
+
+	// tx1 expires by height.
+	doUpdate(t, mp, 3, nil)
+	require.Equal(t, types.Txs{tx2}, mp.ReapMaxTxs(-1))
+	require.Equal(t, types.Txs{tx1}, evicted)
+
+	// Expired txs are removed from the cache, so they can be resubmitted.
+	callCheckTx(t, mp, types.Txs{tx1})
+	require.Equal(t, 2, mp.Size())
+
+	// Both txs expire by time.
+	mp.config.TTLNumBlocks = 0
+	mp.config.TTLDuration = time.Nanosecond
+	doUpdate(t, mp, 4, nil)
+	require.Zero(t, mp.Size())
+	require.Len(t, evicted, 3)
+}
+
 func TestMempoolBuildLanesInfo(t *testing.T) {
 	emptyMap := make(map[string]uint32)
 	_, err := BuildLanesInfo(emptyMap, "")
@@ -99,6 +99,70 @@ func (e ErrLaneIsFull) Error() string {
 	)
 }
 
+// ErrSequenceTooLow is returned when the sequence number of a transaction is
+// lower than the next sequence expected for its sender, which means that a
+// transaction of the sender with the same sequence has already been committed.
+type ErrSequenceTooLow struct {
+	Sender       string
+	Sequence     uint64
+	NextSequence uint64
+}
+
+func (e ErrSequenceTooLow) Error() string {
+	return fmt.Sprintf(
+		"sequence %d of sender %s is too low (next expected: %d)",
+		e.Sequence,
+		e.Sender,
+		e.NextSequence,
+	)
+}
+
+// ErrHeldTxsFull is returned when a transaction would be held, because of a
+// gap in the sequence numbers of its sender, but the limits on held
+// transactions, per sender or in total, have been reached.
+type ErrHeldTxsFull struct {
+	Sender       string
+	NumSenderTxs int
+	MaxSenderTxs int
+	NumTxs       int
+	MaxTxs       int
+	TxsBytes     int64
+	MaxTxsBytes  int64
+}
+
+func (e ErrHeldTxsFull) Error() string {
+	return fmt.Sprintf(
+		"held txs are full: sender %s has %d held txs (max: %d); total held txs: %d (max: %d); total held bytes: %d (max: %d)",
+		e.Sender,
+		e.NumSenderTxs,
+		e.MaxSenderTxs,
+		e.NumTxs,
+		e.MaxTxs,
+		e.TxsBytes,
+		e.MaxTxsBytes,
+	)
+}
+
+// ErrReplacementUnderpriced is returned when a transaction has the same sender
+// and sequence number as a transaction already in the mempool, but its
+// priority is not high enough to replace it.
+type ErrReplacementUnderpriced struct {
+	Sender           string
+	Sequence         uint64
+	Priority         int64
+	ExistingPriority int64
+}
+
+func (e ErrReplacementUnderpriced) Error() string {
+	return fmt.Sprintf(
+		"replacement tx for sender %s and sequence %d is underpriced: priority %d (must be higher than %d)",
+		e.Sender,
+		e.Sequence,
+		e.Priority,
+		e.ExistingPriority,
+	)
+}
+
 // ErrPreCheck defines an error where a transaction fails a pre-check.
 type ErrPreCheck struct {
 	Err error
@@ -122,6 +122,10 @@ func NewBlockingIterator(ctx context.Context, mem *CListMempool, name string) It
 	}
 }
 
+func (mem *CListMempool) blockingIterator(ctx context.Context, name string) Iterator {
+	return NewBlockingIterator(ctx, mem, name)
+}
+
 // WaitNextCh returns a channel to wait for the next available entry. The channel will be explicitly
 // closed when the entry gets removed before it is added to the channel, or when reaching the end of
 // the list.
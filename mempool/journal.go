@@ -0,0 +1,287 @@
+package mempool
+
+import (
+	"cmp"
+	"encoding/binary"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	"github.com/cometbft/cometbft/v2/libs/log"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+// journalEntryHeaderSize is the size of the header of each journal entry: the
+// sequence number of the entry followed by the height at which the tx was
+// added to the mempool, both as big-endian uint64.
+const journalEntryHeaderSize = 16
+
+var journalTxPrefix = []byte("tx/")
+
+// BlockStore is the subset of the block store needed to replay a Journal.
+type BlockStore interface {
+	Base() int64
+	Height() int64
+	LoadBlock(height int64) (*types.Block, *types.BlockMeta)
+}
+
+// Journal persists the transactions admitted into the mempool, and removes
+// them when they leave the mempool, so that they can be restored after a
+// restart. It is safe for concurrent use.
+//
+// Removals are only recorded in memory, so that the mempool can report them
+// while holding its locks, and are written in batches along with the next
+// added tx or on Sync. Removals lost in a crash are harmless: Replay drops the
+// committed txs and the mempool rejects the invalid ones.
+type Journal struct {
+	db     dbm.DB
+	seq    atomic.Uint64 // sequence number of the last entry written
+	logger log.Logger
+
+	mtx       sync.Mutex               // guards removed and replaying, and orders the writes
+	removed   map[types.TxKey]struct{} // removals not written yet
+	replaying map[types.TxKey]struct{} // replayed txs not accepted by the mempool yet
+}
+
+type journalEntry struct {
+	seq    uint64
+	height int64
+	tx     types.Tx
+}
+
+// NewJournal returns a journal stored in the given database.
+func NewJournal(db dbm.DB) (*Journal, error) {
+	j := &Journal{
+		db:      db,
+		logger:  log.NewNopLogger(),
+		removed: make(map[types.TxKey]struct{}),
+	}
+	entries, err := j.entries()
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) > 0 {
+		j.seq.Store(entries[len(entries)-1].seq)
+	}
+	return j, nil
+}
+
+// SetLogger sets the Logger.
+func (j *Journal) SetLogger(l log.Logger) {
+	j.logger = l
+}
+
+// AddTx records that tx was added to the mempool at the given height, along
+// with the pending removals.
+func (j *Journal) AddTx(tx types.Tx, height int64) error {
+	value := make([]byte, journalEntryHeaderSize+len(tx))
+	binary.BigEndian.PutUint64(value[0:8], j.seq.Add(1))
+	binary.BigEndian.PutUint64(value[8:16], uint64(height))
+	copy(value[journalEntryHeaderSize:], tx)
+
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+	delete(j.removed, tx.Key())
+	delete(j.replaying, tx.Key())
+	batch := j.db.NewBatch()
+	defer batch.Close()
+	if err := j.deleteRemoved(batch); err != nil {
+		return err
+	}
+	if err := batch.Set(journalTxKey(tx.Key()), value); err != nil {
+		return err
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	clear(j.removed)
+	return nil
+}
+
+// RemoveTx records that the tx identified by txKey left the mempool. The
+// removal is written by the next call to AddTx or Sync.
+func (j *Journal) RemoveTx(txKey types.TxKey) {
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+	j.removed[txKey] = struct{}{}
+}
+
+// Sync writes the pending removals.
+func (j *Journal) Sync() error {
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+	if len(j.removed) == 0 {
+		return nil
+	}
+	batch := j.db.NewBatch()
+	defer batch.Close()
+	if err := j.deleteRemoved(batch); err != nil {
+		return err
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	clear(j.removed)
+	return nil
+}
+
+// deleteRemoved adds the pending removals to batch. The caller must hold mtx.
+func (j *Journal) deleteRemoved(batch dbm.Batch) error {
+	for txKey := range j.removed {
+		if err := batch.Delete(journalTxKey(txKey)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Reset removes all the entries in the journal.
+func (j *Journal) Reset() error {
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+	entries, err := j.entries()
+	if err != nil {
+		return err
+	}
+	batch := j.db.NewBatch()
+	defer batch.Close()
+	for _, entry := range entries {
+		if err := batch.Delete(journalTxKey(entry.tx.Key())); err != nil {
+			return err
+		}
+	}
+	if err := batch.WriteSync(); err != nil {
+		return err
+	}
+	clear(j.removed)
+	return nil
+}
+
+// Replay adds to mp, through CheckTx, the transactions in the journal that
+// were not committed according to blockStore. The entries of the committed
+// transactions and of those rejected by mp are only removed once all the
+// transactions have been replayed, so that the journal survives a crash
+// during the replay. It must be called before the mempool starts receiving
+// transactions from peers or clients.
+func (j *Journal) Replay(mp Mempool, blockStore BlockStore) error {
+	entries, err := j.entries()
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+
+	// Committed txs are normally removed from the journal when the mempool is
+	// updated, unless the node stopped before that happened. A tx can only be
+	// committed in a block after the height at which it entered the mempool.
+	fromHeight := entries[0].height
+	for _, entry := range entries {
+		fromHeight = min(fromHeight, entry.height)
+	}
+	fromHeight = max(fromHeight+1, blockStore.Base())
+	committed := make(map[types.TxKey]struct{})
+	for height := fromHeight; height <= blockStore.Height(); height++ {
+		block, _ := blockStore.LoadBlock(height)
+		if block == nil {
+			continue
+		}
+		for _, tx := range block.Txs {
+			committed[tx.Key()] = struct{}{}
+		}
+	}
+
+	// The mempool rewrites the entries of the txs it accepts from its CheckTx
+	// callback, which clears them from replaying. The callback may run after
+	// reqRes.Wait returns, so its AddTx calls are the only sign of acceptance.
+	j.mtx.Lock()
+	j.replaying = make(map[types.TxKey]struct{}, len(entries))
+	for _, entry := range entries {
+		if _, ok := committed[entry.tx.Key()]; !ok {
+			j.replaying[entry.tx.Key()] = struct{}{}
+		}
+	}
+	j.mtx.Unlock()
+
+	numCommitted := 0
+	for _, entry := range entries {
+		txKey := entry.tx.Key()
+		if _, ok := committed[txKey]; ok {
+			numCommitted++
+			j.RemoveTx(txKey)
+			continue
+		}
+		reqRes, err := mp.CheckTx(entry.tx, noSender)
+		if err != nil {
+			j.logger.Debug("Could not replay tx", "tx", log.NewLazyHash(entry.tx), "err", err)
+			continue
+		}
+		reqRes.Wait()
+	}
+
+	// A replayed tx is still in replaying if the mempool rejected it. The
+	// entry of a tx accepted after this point is written back by AddTx.
+	j.mtx.Lock()
+	numRejected := len(j.replaying)
+	for txKey := range j.replaying {
+		j.removed[txKey] = struct{}{}
+	}
+	j.replaying = nil
+	j.mtx.Unlock()
+	if err := j.Sync(); err != nil {
+		return err
+	}
+
+	j.logger.Info("Replayed mempool journal",
+		"txs", len(entries),
+		"committed", numCommitted,
+		"rejected", numRejected,
+		"size", mp.Size(),
+	)
+	return nil
+}
+
+// Close writes the pending removals and closes the underlying database.
+func (j *Journal) Close() error {
+	if err := j.Sync(); err != nil {
+		return err
+	}
+	return j.db.Close()
+}
+
+// entries returns all the entries in the journal, sorted by sequence number.
+func (j *Journal) entries() ([]journalEntry, error) {
+	iter, err := dbm.IteratePrefix(j.db, journalTxPrefix)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+
+	var entries []journalEntry
+	for ; iter.Valid(); iter.Next() {
+		value := iter.Value()
+		if len(value) < journalEntryHeaderSize {
+			return nil, fmt.Errorf("journal entry %X is too short: %d bytes", iter.Key(), len(value))
+		}
+		entries = append(entries, journalEntry{
+			seq:    binary.BigEndian.Uint64(value[0:8]),
+			height: int64(binary.BigEndian.Uint64(value[8:16])),
+			tx:     types.Tx(slices.Clone(value[journalEntryHeaderSize:])),
+		})
+	}
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(entries, func(a, b journalEntry) int {
+		return cmp.Compare(a.seq, b.seq)
+	})
+	return entries, nil
+}
+
+func journalTxKey(txKey types.TxKey) []byte {
+	return append(slices.Clone(journalTxPrefix), txKey[:]...)
+}
@@ -0,0 +1,200 @@
+package mempool
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/stretchr/testify/require"
+
+	abcicli "github.com/cometbft/cometbft/v2/abci/client"
+	"github.com/cometbft/cometbft/v2/abci/example/kvstore"
+	cmtrand "github.com/cometbft/cometbft/v2/internal/rand"
+	"github.com/cometbft/cometbft/v2/internal/test"
+	"github.com/cometbft/cometbft/v2/libs/log"
+	"github.com/cometbft/cometbft/v2/p2p"
+	"github.com/cometbft/cometbft/v2/proxy"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+// journalBlockStore is a BlockStore holding the txs of each block.
+type journalBlockStore map[int64]types.Txs
+
+func (bs journalBlockStore) Base() int64 { return 1 }
+
+func (bs journalBlockStore) Height() int64 { return int64(len(bs)) }
+
+func (bs journalBlockStore) LoadBlock(height int64) (*types.Block, *types.BlockMeta) {
+	txs, ok := bs[height]
+	if !ok {
+		return nil, nil
+	}
+	return &types.Block{Data: types.Data{Txs: txs}}, nil
+}
+
+func newMempoolWithJournal(t *testing.T, journal *Journal) *CListMempool {
+	t.Helper()
+	cc := proxy.NewLocalClientCreator(kvstore.NewInMemoryApplicationWithoutLanes())
+	return newMempoolWithJournalAndApp(t, journal, cc)
+}
+
+func newMempoolWithJournalAndApp(t *testing.T, journal *Journal, cc proxy.ClientCreator) *CListMempool {
+	t.Helper()
+	cfg := test.ResetTestRoot("mempool_test")
+	t.Cleanup(func() { os.RemoveAll(cfg.RootDir) })
+
+	appConnMem, err := cc.NewABCIMempoolClient()
+	require.NoError(t, err)
+	require.NoError(t, appConnMem.Start())
+	t.Cleanup(func() { _ = appConnMem.Stop() })
+
+	lanesInfo, err := BuildLanesInfo(nil, "")
+	require.NoError(t, err)
+	mp := NewCListMempool(cfg.Mempool, appConnMem, lanesInfo, 0, WithJournal(journal))
+	mp.SetLogger(log.TestingLogger())
+	return mp
+}
+
+func TestJournal(t *testing.T) {
+	journal, err := NewJournal(dbm.NewMemDB())
+	require.NoError(t, err)
+
+	txs := types.Txs{kvstore.NewTx("a", "1"), kvstore.NewTx("b", "2"), kvstore.NewTx("c", "3")}
+	for i, tx := range txs {
+		require.NoError(t, journal.AddTx(tx, int64(i+1)))
+	}
+	journal.RemoveTx(txs[1].Key())
+
+	// Removals are only written on Sync or along with the next added tx.
+	entries, err := journal.entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	require.NoError(t, journal.Sync())
+
+	entries, err = journal.entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, txs[0], entries[0].tx)
+	require.Equal(t, int64(1), entries[0].height)
+	require.Equal(t, txs[2], entries[1].tx)
+	require.Equal(t, int64(3), entries[1].height)
+
+	require.NoError(t, journal.Reset())
+	entries, err = journal.entries()
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestJournalReplay(t *testing.T) {
+	db := dbm.NewMemDB()
+	journal, err := NewJournal(db)
+	require.NoError(t, err)
+
+	// The mempool records the txs it admits and the ones it removes.
+	mp := newMempoolWithJournal(t, journal)
+	txs := types.Txs{kvstore.NewTx("a", "1"), kvstore.NewTx("b", "2"), kvstore.NewTx("c", "3"), kvstore.NewTx("d", "4")}
+	for _, tx := range txs {
+		rr, err := mp.CheckTx(tx, noSender)
+		require.NoError(t, err)
+		rr.Wait()
+		require.NoError(t, rr.Error())
+	}
+	doUpdate(t, mp, 1, txs[:1])
+	require.NoError(t, mp.RemoveTxByKey(txs[1].Key()))
+
+	// The node stops after committing txs[2] but before updating the mempool.
+	blockStore := journalBlockStore{1: txs[:1], 2: txs[2:3]}
+
+	journal, err = NewJournal(db)
+	require.NoError(t, err)
+	mp = newMempoolWithJournal(t, journal)
+	require.NoError(t, journal.Replay(mp, blockStore))
+	require.Equal(t, txs[3:], mp.ReapMaxTxs(-1))
+
+	entries, err := journal.entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, txs[3], entries[0].tx)
+
+	// Txs committed later are removed from the journal on Update.
+	doUpdate(t, mp, 3, txs[3:])
+	entries, err = journal.entries()
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestJournalReplayRemoteApp(t *testing.T) {
+	sockPath := fmt.Sprintf("unix:///tmp/echo_%v.sock", cmtrand.Str(6))
+	server := newRemoteApp(t, sockPath, kvstore.NewInMemoryApplicationWithoutLanes())
+	t.Cleanup(func() { _ = server.Stop() })
+
+	db := dbm.NewMemDB()
+	journal, err := NewJournal(db)
+	require.NoError(t, err)
+	txs := types.Txs{kvstore.NewTx("a", "1"), types.Tx("invalid"), kvstore.NewTx("b", "2"), types.Tx("=")}
+	for i, tx := range txs {
+		require.NoError(t, journal.AddTx(tx, int64(i+1)))
+	}
+
+	// The socket client releases the waiters on a response before invoking the
+	// mempool callback, so the replay must not decide on its own whether a tx
+	// was accepted.
+	mp := newMempoolWithJournalAndApp(t, journal, proxy.NewRemoteClientCreator(sockPath, "socket", true))
+	require.NoError(t, journal.Replay(mp, journalBlockStore{}))
+
+	// The mempool writes the entry of an accepted tx after adding it.
+	valid := types.Txs{txs[0], txs[2]}
+	var entries []journalEntry
+	require.Eventually(t, func() bool {
+		entries, err = journal.entries()
+		return err == nil && len(entries) == len(valid)
+	}, time.Second, 10*time.Millisecond)
+	for i, entry := range entries {
+		require.Equal(t, valid[i], entry.tx)
+	}
+	require.Equal(t, valid, mp.ReapMaxTxs(-1))
+}
+
+// crashingMempool stops the replay, as a crash would, on the first checked tx.
+type crashingMempool struct {
+	*CListMempool
+}
+
+var errCrash = errors.New("crash")
+
+func (*crashingMempool) CheckTx(types.Tx, p2p.ID) (*abcicli.ReqRes, error) {
+	panic(errCrash)
+}
+
+func TestJournalReplayCrash(t *testing.T) {
+	db := dbm.NewMemDB()
+	journal, err := NewJournal(db)
+	require.NoError(t, err)
+	txs := types.Txs{kvstore.NewTx("a", "1"), kvstore.NewTx("b", "2"), kvstore.NewTx("c", "3")}
+	for i, tx := range txs {
+		require.NoError(t, journal.AddTx(tx, int64(i+1)))
+	}
+
+	// The node crashes while replaying the second tx, after the first one was
+	// committed.
+	mp := &crashingMempool{newMempoolWithJournal(t, journal)}
+	blockStore := journalBlockStore{1: txs[:0], 2: txs[:1]}
+	require.PanicsWithValue(t, errCrash, func() { _ = journal.Replay(mp, blockStore) })
+
+	// No entry is lost.
+	journal, err = NewJournal(db)
+	require.NoError(t, err)
+	entries, err := journal.entries()
+	require.NoError(t, err)
+	require.Len(t, entries, len(txs))
+
+	newMp := newMempoolWithJournal(t, journal)
+	require.NoError(t, journal.Replay(newMp, blockStore))
+	require.Equal(t, txs[1:], newMp.ReapMaxTxs(-1))
+	entries, err = journal.entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+}
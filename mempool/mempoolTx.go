@@ -5,6 +5,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/cometbft/cometbft/v2/config"
 	"github.com/cometbft/cometbft/v2/p2p"
 	"github.com/cometbft/cometbft/v2/types"
 )
@@ -13,11 +14,18 @@ import (
 type mempoolTx struct {
 	height    int64    // height that this tx had been validated in
 	gasWanted int64    // amount of gas this tx states it will require
+	priority  int64    // priority assigned by the app (for ordering or replacing txs)
 	tx        types.Tx // validated by the application
 	lane      LaneID
 	seq       int64
 	timestamp time.Time // time when entry was created
 
+	// Sender and sequence number of the tx, as reported by the app in
+	// CheckTxResponse (only used by CListMempool). Not to be confused with
+	// the peers in senders.
+	appSender   string
+	appSequence uint64
+
 	// ids of peers who've sent us this tx (as a map for quick lookups).
 	// senders: PeerID -> struct{}
 	senders sync.Map
@@ -35,6 +43,15 @@ func (memTx *mempoolTx) GasWanted() int64 {
 	return memTx.gasWanted
 }
 
+// isExpired returns true iff, at the given height and time, the tx has been in
+// the mempool for more blocks or time than allowed by cfg.
+func (memTx *mempoolTx) isExpired(cfg *config.MempoolConfig, height int64, now time.Time) bool {
+	if cfg.TTLNumBlocks > 0 && height-memTx.Height() > cfg.TTLNumBlocks {
+		return true
+	}
+	return cfg.TTLDuration > 0 && now.Sub(memTx.timestamp) > cfg.TTLDuration
+}
+
 func (memTx *mempoolTx) IsSender(peerID p2p.ID) bool {
 	_, ok := memTx.senders.Load(peerID)
 	return ok
@@ -57,6 +57,22 @@ type Metrics struct {
 	// metrics:Number of evicted transactions.
 	EvictedTxs metrics.Counter
 
+	// ExpiredTxs defines the number of expired transactions. These are
+	// transactions removed from the mempool because they stayed there for
+	// longer than allowed by the ttl_duration or ttl_num_blocks config options.
+	// metrics:Number of expired transactions.
+	ExpiredTxs metrics.Counter
+
+	// ReplacedTxs defines the number of replaced transactions. These are
+	// transactions removed from the mempool because a transaction with the
+	// same sender and sequence number, but with a higher priority, was added.
+	// metrics:Number of replaced transactions.
+	ReplacedTxs metrics.Counter
+
+	// Number of transactions held in the mempool until a gap in the sequence
+	// numbers of their sender is filled.
+	HeldTxs metrics.Gauge
+
 	// Number of times transactions are rechecked in the mempool.
 	RecheckTimes metrics.Counter
 
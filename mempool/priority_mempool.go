@@ -0,0 +1,945 @@
+package mempool
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/btree"
+
+	abcicli "github.com/cometbft/cometbft/v2/abci/client"
+	abci "github.com/cometbft/cometbft/v2/abci/types"
+	"github.com/cometbft/cometbft/v2/config"
+	"github.com/cometbft/cometbft/v2/internal/clist"
+	"github.com/cometbft/cometbft/v2/libs/log"
+	cmtmath "github.com/cometbft/cometbft/v2/libs/math"
+	cmtsync "github.com/cometbft/cometbft/v2/libs/sync"
+	"github.com/cometbft/cometbft/v2/p2p"
+	"github.com/cometbft/cometbft/v2/proxy"
+	"github.com/cometbft/cometbft/v2/types"
+	cmttime "github.com/cometbft/cometbft/v2/types/time"
+)
+
+// PriorityMempool is an in-memory pool of transactions ordered by the priority
+// that the application assigns to each of them in CheckTxResponse.Priority.
+//
+// Transactions are reaped for inclusion in a block in descending order of
+// priority; transactions with the same priority are reaped in the order in
+// which they entered the mempool. When the mempool is full, a new transaction
+// is only admitted if enough transactions with strictly lower priority can be
+// evicted to make room for it.
+//
+// Lanes are not supported: the lane returned by the application in CheckTx is
+// ignored. Transactions are gossiped to peers in arrival order.
+type PriorityMempool struct {
+	height atomic.Int64 // the last block Update()'d to
+
+	// notify listeners (ie. consensus) when txs are available
+	notifiedTxsAvailable atomic.Bool
+	txsAvailable         chan struct{} // fires once for each height, when the mempool is not empty
+	onNewTx              func(types.Tx)
+	onEvictedTx          func(types.Tx, string)
+
+	config *config.MempoolConfig
+
+	// Exclusive mutex for Update method to prevent concurrent execution of
+	// CheckTx or ReapMaxBytesMaxGas(ReapMaxTxs) methods.
+	updateMtx cmtsync.RWMutex
+	preCheck  PreCheckFunc
+	postCheck PostCheckFunc
+
+	proxyAppConn proxy.AppConnMempool
+
+	// Data in the following variables must to be kept in sync and updated atomically.
+	txsMtx   cmtsync.RWMutex
+	txs      *clist.CList                    // valid txs in arrival order, used for gossiping
+	txsMap   map[types.TxKey]*clist.CElement // for quick access to the mempool entry of a given tx
+	txsBytes int64                           // total size of mempool, in bytes
+	addTxSeq int64                           // sequence number of the last added tx
+
+	// Valid txs by descending priority and, for the same priority, in arrival
+	// order, for reaping and eviction.
+	byPriority *btree.BTreeG[*mempoolTx]
+
+	// Keep a cache of already-seen txs.
+	// This reduces the pressure on the proxyApp.
+	cache TxCache
+
+	// Optional journal where txs are persisted (nil if disabled).
+	journal *Journal
+
+	logger  log.Logger
+	metrics *Metrics
+}
+
+var _ Mempool = &PriorityMempool{}
+
+// priorityTreeDegree is the degree of the B-tree of transactions ordered by
+// priority.
+const priorityTreeDegree = 32
+
+// priorityLess orders transactions by descending priority and, among
+// transactions with the same priority, in arrival order.
+func priorityLess(a, b *mempoolTx) bool {
+	if a.priority != b.priority {
+		return a.priority > b.priority
+	}
+	return a.seq < b.seq
+}
+
+// PriorityMempoolOption sets an optional parameter on the PriorityMempool.
+type PriorityMempoolOption func(*PriorityMempool)
+
+// NewPriorityMempool returns a new priority mempool with the given
+// configuration and connection to an application.
+func NewPriorityMempool(
+	cfg *config.MempoolConfig,
+	proxyAppConn proxy.AppConnMempool,
+	height int64,
+	options ...PriorityMempoolOption,
+) *PriorityMempool {
+	mp := &PriorityMempool{
+		config:       cfg,
+		proxyAppConn: proxyAppConn,
+		txs:          clist.New(),
+		txsMap:       make(map[types.TxKey]*clist.CElement),
+		byPriority:   btree.NewG(priorityTreeDegree, priorityLess),
+		logger:       log.NewNopLogger(),
+		metrics:      NopMetrics(),
+	}
+	mp.height.Store(height)
+
+	if cfg.CacheSize > 0 {
+		mp.cache = NewLRUTxCache(cfg.CacheSize)
+	} else {
+		mp.cache = NopTxCache{}
+	}
+
+	for _, option := range options {
+		option(mp)
+	}
+
+	return mp
+}
+
+// WithPriorityPreCheck sets a filter for the mempool to reject a tx if f(tx)
+// returns an error. This is ran before CheckTx. Only applies to the first
+// created block. After that, Update overwrites the existing value.
+func WithPriorityPreCheck(f PreCheckFunc) PriorityMempoolOption {
+	return func(mem *PriorityMempool) { mem.preCheck = f }
+}
+
+// WithPriorityPostCheck sets a filter for the mempool to reject a tx if f(tx)
+// returns an error. This is ran after CheckTx. Only applies to the first
+// created block. After that, Update overwrites the existing value.
+func WithPriorityPostCheck(f PostCheckFunc) PriorityMempoolOption {
+	return func(mem *PriorityMempool) { mem.postCheck = f }
+}
+
+// WithPriorityMetrics sets the metrics.
+func WithPriorityMetrics(metrics *Metrics) PriorityMempoolOption {
+	return func(mem *PriorityMempool) { mem.metrics = metrics }
+}
+
+// WithPriorityNewTxCallback sets a callback function to be executed when a
+// new transaction is added to the mempool.
+func WithPriorityNewTxCallback(cb func(types.Tx)) PriorityMempoolOption {
+	return func(mem *PriorityMempool) { mem.onNewTx = cb }
+}
+
+// WithPriorityEvictedTxCallback sets a callback function to be executed when a
+// transaction is removed from the mempool without being committed. The callback
+// function will receive the evicted transaction and the reason for its eviction
+// (one of the types.EvictedTxReason constants).
+func WithPriorityEvictedTxCallback(cb func(tx types.Tx, reason string)) PriorityMempoolOption {
+	return func(mem *PriorityMempool) { mem.onEvictedTx = cb }
+}
+
+// WithPriorityJournal sets a journal where the mempool records the transactions
+// it admits and removes, so they can be restored after a restart.
+func WithPriorityJournal(journal *Journal) PriorityMempoolOption {
+	return func(mem *PriorityMempool) { mem.journal = journal }
+}
+
+// SetLogger sets the Logger.
+func (mem *PriorityMempool) SetLogger(l log.Logger) {
+	mem.logger = l
+}
+
+// NOTE: not thread safe - should only be called once, on startup.
+func (mem *PriorityMempool) EnableTxsAvailable() {
+	mem.txsAvailable = make(chan struct{}, 1)
+}
+
+// Safe for concurrent use by multiple goroutines.
+func (mem *PriorityMempool) TxsAvailable() <-chan struct{} {
+	return mem.txsAvailable
+}
+
+// Lock acquires the exclusive lock for mempool updates.
+// Safe for concurrent use by multiple goroutines.
+func (mem *PriorityMempool) Lock() {
+	mem.updateMtx.Lock()
+}
+
+// Unlock releases the exclusive lock for mempool updates.
+// Safe for concurrent use by multiple goroutines.
+func (mem *PriorityMempool) Unlock() {
+	mem.updateMtx.Unlock()
+}
+
+// PreUpdate does nothing: rechecking always finishes within Update, so the
+// mempool never needs to be considered full while waiting for it.
+func (*PriorityMempool) PreUpdate() {}
+
+// Size returns the total number of transactions in the mempool.
+// Safe for concurrent use by multiple goroutines.
+func (mem *PriorityMempool) Size() int {
+	mem.txsMtx.RLock()
+	defer mem.txsMtx.RUnlock()
+
+	return len(mem.txsMap)
+}
+
+// Safe for concurrent use by multiple goroutines.
+func (mem *PriorityMempool) SizeBytes() int64 {
+	mem.txsMtx.RLock()
+	defer mem.txsMtx.RUnlock()
+
+	return mem.txsBytes
+}
+
+// Lock() must be help by the caller during execution.
+func (mem *PriorityMempool) FlushAppConn() error {
+	err := mem.proxyAppConn.Flush(context.TODO())
+	if err != nil {
+		return ErrFlushAppConn{Err: err}
+	}
+
+	return nil
+}
+
+// XXX: Unsafe! Calling Flush may leave mempool in inconsistent state.
+func (mem *PriorityMempool) Flush() {
+	mem.updateMtx.Lock()
+	defer mem.updateMtx.Unlock()
+
+	mem.txsMtx.Lock()
+	defer mem.txsMtx.Unlock()
+
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		mem.txs.Remove(e)
+		e.DetachPrev()
+	}
+	mem.txsMap = make(map[types.TxKey]*clist.CElement)
+	mem.byPriority.Clear(false)
+	mem.txsBytes = 0
+	mem.cache.Reset()
+	if mem.journal != nil {
+		if err := mem.journal.Reset(); err != nil {
+			mem.logger.Error("Could not reset mempool journal", "err", err)
+		}
+	}
+}
+
+func (mem *PriorityMempool) Contains(txKey types.TxKey) bool {
+	mem.txsMtx.RLock()
+	defer mem.txsMtx.RUnlock()
+
+	_, ok := mem.txsMap[txKey]
+	return ok
+}
+
+// GetTxByHash returns the types.Tx with the given hash if found in the mempool, otherwise returns nil.
+func (mem *PriorityMempool) GetTxByHash(hash []byte) types.Tx {
+	mem.txsMtx.RLock()
+	defer mem.txsMtx.RUnlock()
+
+	if elem, ok := mem.txsMap[types.TxKey(hash)]; ok {
+		return elem.Value.(*mempoolTx).tx
+	}
+	return nil
+}
+
+func (mem *PriorityMempool) GetSenders(txKey types.TxKey) ([]p2p.ID, error) {
+	mem.txsMtx.RLock()
+	defer mem.txsMtx.RUnlock()
+
+	elem, ok := mem.txsMap[txKey]
+	if !ok {
+		return nil, ErrTxNotFound
+	}
+	return elem.Value.(*mempoolTx).Senders(), nil
+}
+
+// addSender adds a peer ID to the list of senders on the entry corresponding to
+// tx, identified by its key.
+func (mem *PriorityMempool) addSender(txKey types.TxKey, sender p2p.ID) error {
+	if sender == noSender {
+		return nil
+	}
+
+	mem.txsMtx.Lock()
+	defer mem.txsMtx.Unlock()
+
+	elem, ok := mem.txsMap[txKey]
+	if !ok {
+		return ErrTxNotFound
+	}
+
+	if found := elem.Value.(*mempoolTx).addSender(sender); found {
+		// It should not be possible to receive twice a tx from the same sender.
+		return ErrTxAlreadyReceivedFromSender
+	}
+	return nil
+}
+
+// tryRemoveFromCache removes a transaction from the cache in case it can be
+// added to the mempool at a later stage (probably when the transaction becomes
+// valid).
+func (mem *PriorityMempool) tryRemoveFromCache(tx types.Tx) {
+	if !mem.config.KeepInvalidTxsInCache {
+		mem.cache.Remove(tx)
+	}
+}
+
+// CheckTx sends tx to the application for validation. Contrary to
+// CListMempool, a full mempool does not reject the transaction upfront, as it
+// may have enough priority to evict other transactions once the application
+// has assigned it one.
+//
+// It blocks if we're waiting on Update() or Reap().
+// Safe for concurrent use by multiple goroutines.
+func (mem *PriorityMempool) CheckTx(tx types.Tx, sender p2p.ID) (*abcicli.ReqRes, error) {
+	mem.updateMtx.RLock()
+	// use defer to unlock mutex because application (*local client*) might panic
+	defer mem.updateMtx.RUnlock()
+
+	txSize := len(tx)
+
+	if txSize > mem.config.MaxTxBytes {
+		return nil, ErrTxTooLarge{
+			Max:    mem.config.MaxTxBytes,
+			Actual: txSize,
+		}
+	}
+
+	if int64(txSize) > mem.config.MaxTxsBytes {
+		mem.metrics.RejectedTxs.Add(1)
+		return nil, ErrMempoolIsFull{
+			NumTxs:      mem.Size(),
+			MaxTxs:      mem.config.Size,
+			TxsBytes:    mem.SizeBytes(),
+			MaxTxsBytes: mem.config.MaxTxsBytes,
+		}
+	}
+
+	if mem.preCheck != nil {
+		if err := mem.preCheck(tx); err != nil {
+			return nil, ErrPreCheck{Err: err}
+		}
+	}
+
+	// NOTE: proxyAppConn may error if tx buffer is full
+	if err := mem.proxyAppConn.Error(); err != nil {
+		return nil, ErrAppConnMempool{Err: err}
+	}
+
+	if added := mem.cache.Push(tx); !added {
+		mem.metrics.AlreadyReceivedTxs.Add(1)
+		// Record a new sender for a tx we've already seen.
+		if err := mem.addSender(tx.Key(), sender); err != nil {
+			mem.logger.Error("Could not add sender to tx", "tx", log.NewLazyHash(tx), "sender", sender, "err", err)
+		}
+		return nil, ErrTxInCache
+	}
+
+	reqRes, err := mem.proxyAppConn.CheckTxAsync(context.TODO(), &abci.CheckTxRequest{
+		Tx:   tx,
+		Type: abci.CHECK_TX_TYPE_CHECK,
+	})
+	if err != nil {
+		panic(fmt.Errorf("CheckTx request for tx %s failed: %w", tx.Hash(), err))
+	}
+	reqRes.SetCallback(mem.handleCheckTxResponse(tx, sender))
+
+	return reqRes, nil
+}
+
+// handleCheckTxResponse handles CheckTx responses for transactions validated
+// for the first time.
+//
+//   - sender optionally holds the ID of the peer that sent the transaction, if any.
+func (mem *PriorityMempool) handleCheckTxResponse(tx types.Tx, sender p2p.ID) func(res *abci.Response) error {
+	return func(r *abci.Response) error {
+		res := r.GetCheckTx()
+		if res == nil {
+			panic(fmt.Sprintf("unexpected response value %v not of type CheckTx", r))
+		}
+
+		var postCheckErr error
+		if mem.postCheck != nil {
+			postCheckErr = mem.postCheck(tx, res)
+		}
+
+		// If tx is invalid, remove it from the cache.
+		if res.Code != abci.CodeTypeOK || postCheckErr != nil {
+			mem.tryRemoveFromCache(tx)
+			mem.logger.Debug(
+				"Rejected invalid transaction",
+				"tx", log.NewLazyHash(tx),
+				"res", res,
+				"err", postCheckErr,
+			)
+			mem.metrics.FailedTxs.Add(1)
+
+			if postCheckErr != nil {
+				return postCheckErr
+			}
+			return ErrInvalidTx{Code: res.Code, Data: res.Data, Log: res.Log, Codespace: res.Codespace, Hash: tx.Hash()}
+		}
+
+		if err := mem.addTx(tx, res.GasWanted, res.Priority, sender); err != nil {
+			return err
+		}
+		if mem.journal != nil {
+			if err := mem.journal.AddTx(tx, mem.height.Load()); err != nil {
+				mem.logger.Error("Could not add tx to mempool journal", "tx", log.NewLazyHash(tx), "err", err)
+			}
+		}
+		mem.notifyTxsAvailable()
+
+		if mem.onNewTx != nil {
+			mem.onNewTx(tx)
+		}
+
+		mem.updateSizeMetrics()
+
+		return nil
+	}
+}
+
+// addTx adds a valid transaction to the mempool, evicting transactions with
+// lower priority if needed to make room for it.
+//
+// Called from:
+//   - handleCheckTxResponse (lock not held) if tx is valid
+func (mem *PriorityMempool) addTx(tx types.Tx, gasWanted, priority int64, sender p2p.ID) error {
+	mem.txsMtx.Lock()
+	defer mem.txsMtx.Unlock()
+
+	// Check that tx is not already in the mempool. This can happen when the
+	// cache overflows.
+	txKey := tx.Key()
+	if elem, ok := mem.txsMap[txKey]; ok {
+		mem.metrics.RejectedTxs.Add(1)
+		_ = elem.Value.(*mempoolTx).addSender(sender)
+		mem.logger.Debug("Reject tx", "tx", log.NewLazyHash(tx), "height", mem.height.Load(), "err", ErrTxInMempool)
+		return ErrTxInMempool
+	}
+
+	if err := mem.makeRoomFor(tx, priority); err != nil {
+		// The mempool might have space later.
+		mem.cache.Remove(tx)
+		// use debug level to avoid spamming logs when traffic is high
+		mem.logger.Debug(err.Error())
+		mem.metrics.RejectedTxs.Add(1)
+		return err
+	}
+
+	mem.addTxSeq++
+	memTx := &mempoolTx{
+		tx:        tx,
+		height:    mem.height.Load(),
+		gasWanted: gasWanted,
+		priority:  priority,
+		seq:       mem.addTxSeq,
+		timestamp: cmttime.Now(),
+	}
+	_ = memTx.addSender(sender)
+	mem.txsMap[txKey] = mem.txs.PushBack(memTx)
+	mem.byPriority.ReplaceOrInsert(memTx)
+	mem.txsBytes += int64(len(tx))
+
+	// Update metrics.
+	mem.metrics.TxSizeBytes.Observe(float64(len(tx)))
+
+	mem.logger.Debug(
+		"Added transaction",
+		"tx", log.NewLazyHash(tx),
+		"priority", priority,
+		"height", mem.height.Load(),
+		"total", len(mem.txsMap),
+	)
+	return nil
+}
+
+// makeRoomFor evicts transactions with priority strictly lower than the given
+// one until tx fits in the mempool. If that is not possible, no transaction is
+// evicted and ErrMempoolIsFull is returned.
+//
+// The caller must hold txsMtx.
+func (mem *PriorityMempool) makeRoomFor(tx types.Tx, priority int64) error {
+	numTxs, txsBytes := len(mem.txsMap), mem.txsBytes
+	isFull := func(numTxs int, txsBytes int64) bool {
+		return numTxs >= mem.config.Size || int64(len(tx))+txsBytes > mem.config.MaxTxsBytes
+	}
+	if !isFull(numTxs, txsBytes) {
+		return nil
+	}
+
+	// Pick victims starting from the lowest priority and, among transactions
+	// with the same priority, from the most recently added one.
+	var victims []*mempoolTx
+	mem.byPriority.Descend(func(memTx *mempoolTx) bool {
+		if !isFull(numTxs, txsBytes) || memTx.priority >= priority {
+			return false
+		}
+		victims = append(victims, memTx)
+		numTxs--
+		txsBytes -= int64(len(memTx.tx))
+		return true
+	})
+	if isFull(numTxs, txsBytes) {
+		return ErrMempoolIsFull{
+			NumTxs:      len(mem.txsMap),
+			MaxTxs:      mem.config.Size,
+			TxsBytes:    mem.txsBytes,
+			MaxTxsBytes: mem.config.MaxTxsBytes,
+		}
+	}
+
+	for _, victim := range victims {
+		_ = mem.removeTx(victim.tx.Key())
+		// Allow the evicted tx to be resubmitted later.
+		mem.cache.Remove(victim.tx)
+		mem.metrics.EvictedTxs.Add(1)
+		if mem.onEvictedTx != nil {
+			mem.onEvictedTx(victim.tx, types.EvictedTxReasonFull)
+		}
+		mem.logger.Debug(
+			"Evicted transaction to make room for a higher priority one",
+			"tx", log.NewLazyHash(victim.tx),
+			"priority", victim.priority,
+			"new-priority", priority,
+		)
+	}
+	return nil
+}
+
+// RemoveTxByKey removes a transaction from the mempool by its TxKey index.
+// Called from:
+//   - Update (updateMtx held) if tx was committed
+//   - recheckTxs (updateMtx held) if tx was invalidated
+func (mem *PriorityMempool) RemoveTxByKey(txKey types.TxKey) error {
+	mem.txsMtx.Lock()
+	err := mem.removeTx(txKey)
+	mem.txsMtx.Unlock()
+	if err != nil {
+		return err
+	}
+	mem.syncJournal()
+	return nil
+}
+
+// removeTx removes a transaction from the mempool. The caller must hold txsMtx.
+func (mem *PriorityMempool) removeTx(txKey types.TxKey) error {
+	elem, ok := mem.txsMap[txKey]
+	if !ok {
+		return ErrTxNotFound
+	}
+
+	memTx := elem.Value.(*mempoolTx)
+	mem.metrics.TxLifeSpan.With("lane", "").Observe(float64(cmttime.Since(memTx.timestamp).Milliseconds()))
+
+	mem.txs.Remove(elem)
+	elem.DetachPrev()
+
+	delete(mem.txsMap, txKey)
+	mem.byPriority.Delete(memTx)
+	mem.txsBytes -= int64(len(memTx.tx))
+
+	if mem.journal != nil {
+		// Written by syncJournal or along with the next added tx, out of txsMtx.
+		mem.journal.RemoveTx(txKey)
+	}
+
+	mem.logger.Debug(
+		"Removed transaction",
+		"tx", log.NewLazyHash(memTx.tx),
+		"height", mem.height.Load(),
+		"total", len(mem.txsMap),
+	)
+	return nil
+}
+
+// syncJournal writes the removals recorded in the journal, if any. It must be
+// called without holding txsMtx.
+func (mem *PriorityMempool) syncJournal() {
+	if mem.journal == nil {
+		return
+	}
+	if err := mem.journal.Sync(); err != nil {
+		mem.logger.Error("Could not sync mempool journal", "err", err)
+	}
+}
+
+func (mem *PriorityMempool) notifyTxsAvailable() {
+	if mem.Size() == 0 {
+		return
+	}
+	if mem.txsAvailable != nil && mem.notifiedTxsAvailable.CompareAndSwap(false, true) {
+		// channel cap is 1, so this will send once
+		select {
+		case mem.txsAvailable <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// ReapMaxBytesMaxGas returns transactions in descending order of priority.
+// Transactions that do not fit in the remaining byte or gas budget are
+// skipped, so lower priority transactions may still fill the block.
+//
+// Safe for concurrent use by multiple goroutines.
+func (mem *PriorityMempool) ReapMaxBytesMaxGas(maxBytes, maxGas int64) types.Txs {
+	mem.updateMtx.RLock()
+	defer mem.updateMtx.RUnlock()
+
+	mem.txsMtx.RLock()
+	defer mem.txsMtx.RUnlock()
+
+	var (
+		totalGas    int64
+		runningSize int64
+	)
+	txs := make([]types.Tx, 0, mem.byPriority.Len())
+	mem.byPriority.Ascend(func(memTx *mempoolTx) bool {
+		dataSize := types.ComputeProtoSizeForTxs([]types.Tx{memTx.tx})
+
+		// Check total size requirement
+		if maxBytes > -1 && runningSize+dataSize > maxBytes {
+			return true
+		}
+
+		// Check total gas requirement.
+		// If maxGas is negative, skip this check.
+		newTotalGas := totalGas + memTx.gasWanted
+		if maxGas > -1 && newTotalGas > maxGas {
+			return true
+		}
+
+		runningSize += dataSize
+		totalGas = newTotalGas
+		txs = append(txs, memTx.tx)
+		return true
+	})
+	return txs
+}
+
+// ReapMaxTxs returns up to max transactions in descending order of priority.
+//
+// Safe for concurrent use by multiple goroutines.
+func (mem *PriorityMempool) ReapMaxTxs(max int) types.Txs {
+	mem.updateMtx.RLock()
+	defer mem.updateMtx.RUnlock()
+
+	mem.txsMtx.RLock()
+	defer mem.txsMtx.RUnlock()
+
+	if max < 0 {
+		max = mem.byPriority.Len()
+	}
+
+	txs := make([]types.Tx, 0, cmtmath.MinInt(mem.byPriority.Len(), max))
+	mem.byPriority.Ascend(func(memTx *mempoolTx) bool {
+		if len(txs) >= max {
+			return false
+		}
+		txs = append(txs, memTx.tx)
+		return true
+	})
+	return txs
+}
+
+// Lock() must be help by the caller during execution.
+// TODO: this function always returns nil; remove the return value.
+func (mem *PriorityMempool) Update(
+	height int64,
+	txs types.Txs,
+	txResults []*abci.ExecTxResult,
+	preCheck PreCheckFunc,
+	postCheck PostCheckFunc,
+) error {
+	mem.logger.Debug("Update", "height", height, "len(txs)", len(txs))
+
+	// Set height
+	mem.height.Store(height)
+	mem.notifiedTxsAvailable.Store(false)
+
+	if preCheck != nil {
+		mem.preCheck = preCheck
+	}
+	if postCheck != nil {
+		mem.postCheck = postCheck
+	}
+
+	for i, tx := range txs {
+		if txResults[i].Code == abci.CodeTypeOK {
+			// Add valid committed tx to the cache (if missing).
+			_ = mem.cache.Push(tx)
+		} else {
+			mem.tryRemoveFromCache(tx)
+		}
+
+		// Remove committed tx from the mempool.
+		if err := mem.RemoveTxByKey(tx.Key()); err != nil {
+			mem.logger.Debug("Committed transaction not in local mempool (not an error)",
+				"tx", log.NewLazyHash(tx),
+				"error", err.Error())
+		}
+	}
+
+	// Remove txs that have been in the mempool for too long.
+	mem.purgeExpiredTxs(height)
+
+	// Recheck txs left in the mempool to remove them if they became invalid in the new state.
+	if mem.config.Recheck {
+		mem.recheckTxs()
+	}
+	mem.syncJournal()
+
+	// Notify if there are still txs left in the mempool.
+	if mem.Size() > 0 {
+		mem.notifyTxsAvailable()
+	}
+
+	mem.updateSizeMetrics()
+
+	return nil
+}
+
+// purgeExpiredTxs removes from the mempool the txs that, at the given height,
+// have been in the mempool for more blocks or time than allowed by the config.
+// Expired txs are also removed from the cache, so they can be resubmitted.
+func (mem *PriorityMempool) purgeExpiredTxs(height int64) {
+	if mem.config.TTLNumBlocks == 0 && mem.config.TTLDuration == 0 {
+		return
+	}
+
+	now := cmttime.Now()
+	expired := make([]*mempoolTx, 0)
+	mem.txsMtx.Lock()
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		if memTx := e.Value.(*mempoolTx); memTx.isExpired(mem.config, height, now) {
+			expired = append(expired, memTx)
+		}
+	}
+	for _, memTx := range expired {
+		_ = mem.removeTx(memTx.tx.Key())
+		mem.cache.Remove(memTx.tx)
+	}
+	mem.txsMtx.Unlock()
+
+	for _, memTx := range expired {
+		mem.metrics.ExpiredTxs.Add(1)
+		mem.logger.Debug("Removed expired transaction", "tx", log.NewLazyHash(memTx.tx), "height", height)
+		if mem.onEvictedTx != nil {
+			mem.onEvictedTx(memTx.tx, types.EvictedTxReasonExpired)
+		}
+	}
+}
+
+// updateSizeMetrics updates the size-related metrics.
+func (mem *PriorityMempool) updateSizeMetrics() {
+	mem.metrics.Size.Set(float64(mem.Size()))
+	mem.metrics.SizeBytes.Set(float64(mem.SizeBytes()))
+}
+
+// recheckTxs sends all transactions in the mempool to the app for
+// re-validation, updating their priority or removing them if they became
+// invalid. When the function returns, all recheck responses from the app have
+// been processed or the recheck timeout has expired.
+func (mem *PriorityMempool) recheckTxs() {
+	mem.logger.Debug("Recheck txs", "height", mem.height.Load(), "num-txs", mem.Size())
+
+	mem.txsMtx.RLock()
+	entries := make([]*mempoolTx, 0, len(mem.txsMap))
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		entries = append(entries, e.Value.(*mempoolTx))
+	}
+	mem.txsMtx.RUnlock()
+
+	if len(entries) == 0 {
+		return
+	}
+
+	defer func(start time.Time) {
+		mem.metrics.RecheckDurationSeconds.Set(cmttime.Since(start).Seconds())
+	}(cmttime.Now())
+
+	rc := &priorityRecheck{doneCh: make(chan struct{})}
+	rc.numPendingTxs.Store(int32(len(entries)))
+
+	for _, memTx := range entries {
+		reqRes, err := mem.proxyAppConn.CheckTxAsync(context.TODO(), &abci.CheckTxRequest{
+			Tx:   memTx.tx,
+			Type: abci.CHECK_TX_TYPE_RECHECK,
+		})
+		if err != nil {
+			panic(fmt.Errorf("(re-)CheckTx request for tx %s failed: %w", memTx.tx.Hash(), err))
+		}
+		reqRes.SetCallback(mem.handleRecheckTxResponse(memTx, rc))
+	}
+
+	// Flush any pending asynchronous recheck requests to process.
+	mem.proxyAppConn.Flush(context.TODO())
+
+	// Give some time to finish processing the responses; then finish the
+	// rechecking process, even if not all txs were rechecked.
+	select {
+	case <-time.After(mem.config.RecheckTimeout):
+		rc.timedOut.Store(true)
+		mem.logger.Error("Timed out waiting for recheck responses")
+	case <-rc.doneCh:
+	}
+
+	if n := rc.numPendingTxs.Load(); n > 0 {
+		mem.logger.Error("Not all txs were rechecked", "not-rechecked", n)
+	}
+
+	mem.logger.Debug("Done rechecking", "height", mem.height.Load(), "num-txs", mem.Size())
+}
+
+// priorityRecheck keeps track of an ongoing rechecking process of the
+// PriorityMempool.
+type priorityRecheck struct {
+	doneCh        chan struct{} // closed when all recheck responses have been processed
+	numPendingTxs atomic.Int32  // number of transactions still pending to recheck
+	timedOut      atomic.Bool   // true if responses are no longer awaited
+}
+
+// handleRecheckTxResponse handles CheckTx responses for transactions in the
+// mempool that need to be revalidated after a mempool update.
+func (mem *PriorityMempool) handleRecheckTxResponse(memTx *mempoolTx, rc *priorityRecheck) func(res *abci.Response) error {
+	return func(r *abci.Response) error {
+		res := r.GetCheckTx()
+		if res == nil {
+			panic(fmt.Sprintf("unexpected response value %v not of type CheckTx", r))
+		}
+
+		if rc.timedOut.Load() {
+			mem.logger.Error("Failed to recheck tx", "tx", log.NewLazyHash(memTx.tx), "err", ErrLateRecheckResponse)
+			return ErrLateRecheckResponse
+		}
+		defer func() {
+			if rc.numPendingTxs.Add(-1) == 0 {
+				close(rc.doneCh)
+			}
+		}()
+		mem.metrics.RecheckTimes.Add(1)
+
+		var postCheckErr error
+		if mem.postCheck != nil {
+			postCheckErr = mem.postCheck(memTx.tx, res)
+		}
+
+		// If tx is invalid, remove it from the mempool and the cache.
+		if res.Code != abci.CodeTypeOK || postCheckErr != nil {
+			// Tx became invalidated due to newly committed block.
+			mem.logger.Debug("Tx is no longer valid", "tx", log.NewLazyHash(memTx.tx), "res", res, "postCheckErr", postCheckErr)
+			if err := mem.RemoveTxByKey(memTx.tx.Key()); err != nil {
+				mem.logger.Debug("Transaction could not be removed from mempool", "err", err)
+				return err
+			}
+			mem.metrics.EvictedTxs.Add(1)
+			mem.tryRemoveFromCache(memTx.tx)
+			if mem.onEvictedTx != nil {
+				mem.onEvictedTx(memTx.tx, types.EvictedTxReasonInvalid)
+			}
+
+			if postCheckErr != nil {
+				return postCheckErr
+			}
+			return ErrInvalidTx{Code: res.Code, Data: res.Data, Log: res.Log, Codespace: res.Codespace, Hash: memTx.tx.Hash()}
+		}
+
+		// The application may assign a new priority to the transaction in
+		// the new state. The tx is reinserted to keep the priority order.
+		mem.txsMtx.Lock()
+		if _, ok := mem.byPriority.Delete(memTx); ok {
+			memTx.priority = res.Priority
+			mem.byPriority.ReplaceOrInsert(memTx)
+		}
+		mem.txsMtx.Unlock()
+
+		return nil
+	}
+}
+
+// NewBlockingIterator returns an iterator that traverses the mempool entries
+// in arrival order, waiting for new entries when it reaches the end.
+func (mem *PriorityMempool) NewBlockingIterator(ctx context.Context) Iterator {
+	return &priorityBlockingIterator{
+		ctx: ctx,
+		mp:  mem,
+	}
+}
+
+func (mem *PriorityMempool) blockingIterator(ctx context.Context, _ string) Iterator {
+	return mem.NewBlockingIterator(ctx)
+}
+
+func (mem *PriorityMempool) getMetrics() *Metrics {
+	return mem.metrics
+}
+
+// priorityBlockingIterator is a blocking iterator over the entries of a
+// PriorityMempool, in the order in which they were added.
+type priorityBlockingIterator struct {
+	ctx    context.Context
+	mp     *PriorityMempool
+	cursor *clist.CElement // last accessed entry
+}
+
+// WaitNextCh returns a channel to wait for the next available entry. The
+// channel will be explicitly closed without sending an entry when the context
+// is done or when the last accessed entry got removed at the end of the list,
+// in which case the iteration starts again from the front of the list.
+//
+// Unsafe for concurrent use by multiple goroutines.
+func (iter *priorityBlockingIterator) WaitNextCh() <-chan Entry {
+	ch := make(chan Entry)
+	go func() {
+		defer close(ch)
+
+		var next *clist.CElement
+		if iter.cursor == nil {
+			select {
+			case <-iter.mp.txs.WaitChan():
+			case <-iter.ctx.Done():
+				return
+			}
+			next = iter.mp.txs.Front()
+		} else {
+			select {
+			case <-iter.cursor.NextWaitChan():
+			case <-iter.ctx.Done():
+				return
+			}
+			// If the cursor was removed at the end of the list, Next returns
+			// nil and the iteration will restart from the front.
+			next = iter.cursor.Next()
+		}
+
+		iter.cursor = next
+		if next != nil {
+			select {
+			case ch <- next.Value.(Entry):
+			case <-iter.ctx.Done():
+			}
+		}
+	}()
+	return ch
+}
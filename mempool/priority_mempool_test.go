@@ -0,0 +1,339 @@
+package mempool
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/v2/abci/example/kvstore"
+	abci "github.com/cometbft/cometbft/v2/abci/types"
+	"github.com/cometbft/cometbft/v2/config"
+	"github.com/cometbft/cometbft/v2/internal/test"
+	"github.com/cometbft/cometbft/v2/libs/log"
+	"github.com/cometbft/cometbft/v2/p2p"
+	"github.com/cometbft/cometbft/v2/proxy"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+// priorityApp is a kvstore application that assigns to each transaction of
+// the form key=value a priority equal to value, when value is an integer.
+type priorityApp struct {
+	*kvstore.Application
+}
+
+func (app *priorityApp) CheckTx(ctx context.Context, req *abci.CheckTxRequest) (*abci.CheckTxResponse, error) {
+	res, err := app.Application.CheckTx(ctx, req)
+	if err != nil || res.Code != abci.CodeTypeOK {
+		return res, err
+	}
+	_, value, _ := bytes.Cut(req.Tx, []byte("="))
+	if priority, err := strconv.ParseInt(string(value), 10, 64); err == nil {
+		res.Priority = priority
+	}
+	return res, nil
+}
+
+func newPriorityMempoolWithAppAndConfig(t *testing.T, cfg *config.Config) *PriorityMempool {
+	t.Helper()
+	app := &priorityApp{kvstore.NewInMemoryApplicationWithoutLanes()}
+	cc := proxy.NewLocalClientCreator(app)
+	appConnMem, _ := cc.NewABCIMempoolClient()
+	appConnMem.SetLogger(log.TestingLogger().With("module", "abci-client", "connection", "mempool"))
+	require.NoError(t, appConnMem.Start())
+	t.Cleanup(func() {
+		_ = appConnMem.Stop()
+		os.RemoveAll(cfg.RootDir)
+	})
+
+	mp := NewPriorityMempool(cfg.Mempool, appConnMem, 0)
+	mp.SetLogger(log.TestingLogger())
+	return mp
+}
+
+func newPriorityMempoolWithApp(t *testing.T) *PriorityMempool {
+	t.Helper()
+	return newPriorityMempoolWithAppAndConfig(t, test.ResetTestRoot("mempool_test"))
+}
+
+// checkTxWithPriority adds to the mempool a transaction with the given key and
+// priority, and returns the transaction and the error returned by the
+// CheckTx callback, if any.
+func checkTxWithPriority(t *testing.T, mp Mempool, key string, priority int64) (types.Tx, error) {
+	t.Helper()
+	tx := kvstore.NewTx(key, strconv.FormatInt(priority, 10))
+	rr, err := mp.CheckTx(tx, noSender)
+	require.NoError(t, err)
+	rr.Wait()
+	return tx, rr.Error()
+}
+
+func TestPriorityMempoolReapOrder(t *testing.T) {
+	mp := newPriorityMempoolWithApp(t)
+
+	priorities := []int64{5, 1, 10, 5, 7}
+	txs := make(types.Txs, len(priorities))
+	for i, p := range priorities {
+		tx, err := checkTxWithPriority(t, mp, "k"+strconv.Itoa(i), p)
+		require.NoError(t, err)
+		txs[i] = tx
+	}
+	require.Equal(t, len(priorities), mp.Size())
+
+	// Descending priority; same priority in arrival order.
+	expected := types.Txs{txs[2], txs[4], txs[0], txs[3], txs[1]}
+	require.Equal(t, expected, mp.ReapMaxBytesMaxGas(-1, -1))
+	require.Equal(t, expected, mp.ReapMaxTxs(-1))
+	require.Equal(t, expected[:2], mp.ReapMaxTxs(2))
+
+	// Each tx has gas wanted 1, so only the two highest priority txs fit.
+	require.Equal(t, expected[:2], mp.ReapMaxBytesMaxGas(-1, 2))
+
+	// A budget of bytes that does not fit the first tx still allows smaller,
+	// lower priority txs to be reaped.
+	bigTx, err := checkTxWithPriority(t, mp, "big-key-with-many-bytes", 100)
+	require.NoError(t, err)
+	maxBytes := types.ComputeProtoSizeForTxs(expected[:3])
+	require.Greater(t, types.ComputeProtoSizeForTxs(types.Txs{bigTx}), maxBytes)
+	require.Equal(t, expected[:3], mp.ReapMaxBytesMaxGas(maxBytes, -1))
+}
+
+func TestPriorityMempoolEviction(t *testing.T) {
+	cfg := test.ResetTestRoot("mempool_test")
+	cfg.Mempool.Size = 3
+	mp := newPriorityMempoolWithAppAndConfig(t, cfg)
+
+	tx1, err := checkTxWithPriority(t, mp, "a", 1)
+	require.NoError(t, err)
+	tx2, err := checkTxWithPriority(t, mp, "b", 2)
+	require.NoError(t, err)
+	tx3, err := checkTxWithPriority(t, mp, "c", 3)
+	require.NoError(t, err)
+	require.Equal(t, 3, mp.Size())
+
+	// A tx with a priority not higher than any other is rejected.
+	_, err = checkTxWithPriority(t, mp, "d", 1)
+	require.ErrorAs(t, err, &ErrMempoolIsFull{})
+	require.Equal(t, 3, mp.Size())
+
+	// A tx with higher priority evicts the lowest priority tx.
+	tx5, err := checkTxWithPriority(t, mp, "e", 5)
+	require.NoError(t, err)
+	require.Equal(t, 3, mp.Size())
+	require.False(t, mp.Contains(tx1.Key()))
+	require.Equal(t, types.Txs{tx5, tx3, tx2}, mp.ReapMaxTxs(-1))
+
+	// The evicted tx can be resubmitted once there is room for it.
+	require.NoError(t, mp.RemoveTxByKey(tx5.Key()))
+	_, err = checkTxWithPriority(t, mp, "a", 1)
+	require.NoError(t, err)
+	require.True(t, mp.Contains(tx1.Key()))
+}
+
+func TestPriorityMempoolEvictionBytes(t *testing.T) {
+	cfg := test.ResetTestRoot("mempool_test")
+	mp := newPriorityMempoolWithAppAndConfig(t, cfg)
+
+	txLow1, err := checkTxWithPriority(t, mp, "aaaa", 1)
+	require.NoError(t, err)
+	txLow2, err := checkTxWithPriority(t, mp, "bbbb", 2)
+	require.NoError(t, err)
+	cfg.Mempool.MaxTxsBytes = mp.SizeBytes()
+
+	// Making room for a bigger tx requires evicting both lower priority txs.
+	txHigh, err := checkTxWithPriority(t, mp, "cccccccc", 3)
+	require.NoError(t, err)
+	require.False(t, mp.Contains(txLow1.Key()))
+	require.False(t, mp.Contains(txLow2.Key()))
+	require.Equal(t, types.Txs{txHigh}, mp.ReapMaxTxs(-1))
+	require.EqualValues(t, len(txHigh), mp.SizeBytes())
+}
+
+func TestPriorityMempoolUpdate(t *testing.T) {
+	mp := newPriorityMempoolWithApp(t)
+	mp.EnableTxsAvailable()
+
+	tx1, err := checkTxWithPriority(t, mp, "a", 1)
+	require.NoError(t, err)
+	tx2, err := checkTxWithPriority(t, mp, "b", 2)
+	require.NoError(t, err)
+	ensureFire(t, mp.TxsAvailable(), 1000)
+
+	mp.Lock()
+	err = mp.Update(1, types.Txs{tx2}, abciResponses(1, abci.CodeTypeOK), nil, nil)
+	mp.Unlock()
+	require.NoError(t, err)
+	require.Equal(t, 1, mp.Size())
+	require.EqualValues(t, len(tx1), mp.SizeBytes())
+	require.True(t, mp.Contains(tx1.Key()))
+	ensureFire(t, mp.TxsAvailable(), 1000)
+
+	// A committed tx is kept in the cache.
+	_, err = mp.CheckTx(tx2, noSender)
+	require.ErrorIs(t, err, ErrTxInCache)
+
+	mp.Flush()
+	require.Zero(t, mp.Size())
+	require.Zero(t, mp.SizeBytes())
+}
+
+// reprioritizingApp is a priorityApp that, on recheck, assigns to the
+// transactions in priorities a new priority.
+type reprioritizingApp struct {
+	priorityApp
+	priorities map[string]int64
+}
+
+func (app *reprioritizingApp) CheckTx(ctx context.Context, req *abci.CheckTxRequest) (*abci.CheckTxResponse, error) {
+	res, err := app.priorityApp.CheckTx(ctx, req)
+	if err != nil || res.Code != abci.CodeTypeOK {
+		return res, err
+	}
+	if priority, ok := app.priorities[string(req.Tx)]; ok && req.Type == abci.CHECK_TX_TYPE_RECHECK {
+		res.Priority = priority
+	}
+	return res, nil
+}
+
+func TestPriorityMempoolRecheckPriority(t *testing.T) {
+	cfg := test.ResetTestRoot("mempool_test")
+	app := &reprioritizingApp{
+		priorityApp: priorityApp{kvstore.NewInMemoryApplicationWithoutLanes()},
+		priorities:  make(map[string]int64),
+	}
+	appConnMem, _ := proxy.NewLocalClientCreator(app).NewABCIMempoolClient()
+	require.NoError(t, appConnMem.Start())
+	t.Cleanup(func() {
+		_ = appConnMem.Stop()
+		os.RemoveAll(cfg.RootDir)
+	})
+	mp := NewPriorityMempool(cfg.Mempool, appConnMem, 0)
+
+	txA, err := checkTxWithPriority(t, mp, "a", 1)
+	require.NoError(t, err)
+	txB, err := checkTxWithPriority(t, mp, "b", 2)
+	require.NoError(t, err)
+	txC, err := checkTxWithPriority(t, mp, "c", 3)
+	require.NoError(t, err)
+	require.Equal(t, types.Txs{txC, txB, txA}, mp.ReapMaxTxs(-1))
+
+	// The new priorities assigned on recheck change the reaping order.
+	app.priorities[string(txA)] = 5
+	app.priorities[string(txC)] = 0
+	mp.Lock()
+	err = mp.Update(1, types.Txs{}, abciResponses(0, abci.CodeTypeOK), nil, nil)
+	mp.Unlock()
+	require.NoError(t, err)
+	require.Equal(t, types.Txs{txA, txB, txC}, mp.ReapMaxTxs(-1))
+	require.Equal(t, types.Txs{txA, txB}, mp.ReapMaxTxs(2))
+}
+
+func TestPriorityMempoolTTL(t *testing.T) {
+	cfg := test.ResetTestRoot("mempool_test")
+	cfg.Mempool.TTLNumBlocks = 1
+	mp := newPriorityMempoolWithAppAndConfig(t, cfg)
+
+	var evicted types.Txs
+	mp.onEvictedTx = func(tx types.Tx, reason string) {
+		require.Equal(t, types.EvictedTxReasonExpired, reason)
+		evicted = append(evicted, tx)
+	}
+
+	tx1, err := checkTxWithPriority(t, mp, "a", 1)
+	require.NoError(t, err)
+	doUpdate(t, mp, 1, nil)
+	tx2, err := checkTxWithPriority(t, mp, "b", 2)
+	require.NoError(t, err)
+
+	doUpdate(t, mp, 2, nil)
+	require.Equal(t, types.Txs{tx2}, mp.ReapMaxTxs(-1))
+	require.Equal(t, types.Txs{tx1}, evicted)
+	require.False(t, mp.cache.Has(tx1))
+}
+
+func TestPriorityMempoolSenders(t *testing.T) {
+	mp := newPriorityMempoolWithApp(t)
+
+	tx := types.Tx(kvstore.NewTx("a", "1"))
+	rr, err := mp.CheckTx(tx, "peer1")
+	require.NoError(t, err)
+	require.NoError(t, rr.Error())
+
+	_, err = mp.CheckTx(tx, "peer2")
+	require.ErrorIs(t, err, ErrTxInCache)
+
+	senders, err := mp.GetSenders(tx.Key())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []p2p.ID{"peer1", "peer2"}, senders)
+	assert.Equal(t, tx, mp.GetTxByHash(tx.Hash()))
+}
+
+func TestPriorityMempoolBlockingIterator(t *testing.T) {
+	mp := newPriorityMempoolWithApp(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	iter := mp.NewBlockingIterator(ctx)
+	tx1, err := checkTxWithPriority(t, mp, "a", 1)
+	require.NoError(t, err)
+	tx2, err := checkTxWithPriority(t, mp, "b", 2)
+	require.NoError(t, err)
+
+	// Entries are traversed in arrival order, not in priority order.
+	entry := <-iter.WaitNextCh()
+	require.Equal(t, tx1, entry.Tx())
+	entry = <-iter.WaitNextCh()
+	require.Equal(t, tx2, entry.Tx())
+
+	// The iterator blocks until a new entry is added.
+	ch := iter.WaitNextCh()
+	tx3, err := checkTxWithPriority(t, mp, "c", 3)
+	require.NoError(t, err)
+	entry = <-ch
+	require.Equal(t, tx3, entry.Tx())
+
+	// The channel gets closed when the context is done.
+	ch = iter.WaitNextCh()
+	cancel()
+	require.Nil(t, <-ch)
+}
+
+func TestPriorityMempoolReactorBroadcastTxs(t *testing.T) {
+	conf := config.TestConfig()
+	const n = 2
+	reactors := make([]*Reactor, n)
+	for i := 0; i < n; i++ {
+		mp := newPriorityMempoolWithApp(t)
+		reactors[i] = NewReactor(conf.Mempool, mp, false)
+		reactors[i].SetLogger(log.TestingLogger().With("validator", i))
+	}
+	connectReactors(conf, reactors, p2p.Connect2Switches)
+	defer func() {
+		for _, r := range reactors {
+			require.NoError(t, r.Stop())
+		}
+	}()
+	for _, r := range reactors {
+		for _, peer := range r.Switch.Peers().Copy() {
+			peer.Set(types.PeerStateKey, peerState{1})
+		}
+	}
+
+	txs := make(types.Txs, 0, 10)
+	for i := 0; i < 10; i++ {
+		tx, err := checkTxWithPriority(t, reactors[0].mempool, "k"+strconv.Itoa(i), int64(i))
+		require.NoError(t, err)
+		txs = append(txs, tx)
+	}
+
+	waitForNumTxsInMempool(len(txs), reactors[1].mempool)
+	// The receiving mempool orders txs by priority as well.
+	reaped := reactors[1].mempool.ReapMaxTxs(-1)
+	for i, tx := range reaped {
+		require.Equal(t, txs[len(txs)-1-i], tx)
+	}
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/cometbft/cometbft/v2/libs/log"
 	cmtsync "github.com/cometbft/cometbft/v2/libs/sync"
 	"github.com/cometbft/cometbft/v2/p2p"
+	"github.com/cometbft/cometbft/v2/p2p/reputation"
 	tcpconn "github.com/cometbft/cometbft/v2/p2p/transport/tcp/conn"
 	"github.com/cometbft/cometbft/v2/types"
 )
@@ -25,13 +26,34 @@ import (
 // and upper bounds for redundancy levels as a deviation from the target value.
 const targetRedundancyDeltaPercent = 10
 
+// GossipMempool is a mempool whose transactions can be disseminated to peers
+// by the Reactor. It is implemented by CListMempool and PriorityMempool.
+type GossipMempool interface {
+	Mempool
+
+	// SetLogger sets the Logger.
+	SetLogger(l log.Logger)
+
+	// blockingIterator returns an iterator used for gossiping entries to the
+	// peer with the given name.
+	blockingIterator(ctx context.Context, name string) Iterator
+
+	// getMetrics returns the metrics of the mempool, shared with the reactor.
+	getMetrics() *Metrics
+}
+
+var (
+	_ GossipMempool = &CListMempool{}
+	_ GossipMempool = &PriorityMempool{}
+)
+
 // Reactor handles mempool tx broadcasting amongst peers.
 // It maintains a map from peer ID to counter, to prevent gossiping txs to the
 // peers you received it from.
 type Reactor struct {
 	p2p.BaseReactor
 	config  *cfg.MempoolConfig
-	mempool *CListMempool
+	mempool GossipMempool
 
 	waitSync   atomic.Bool
 	waitSyncCh chan struct{} // for signaling when to start receiving and sending txs
@@ -48,7 +70,7 @@ type Reactor struct {
 }
 
 // NewReactor returns a new Reactor with the given config and mempool.
-func NewReactor(config *cfg.MempoolConfig, mempool *CListMempool, waitSync bool) *Reactor {
+func NewReactor(config *cfg.MempoolConfig, mempool GossipMempool, waitSync bool) *Reactor {
 	memR := &Reactor{
 		config:   config,
 		mempool:  mempool,
@@ -165,8 +187,8 @@ func (memR *Reactor) AddPeer(peer p2p.Peer) {
 				}
 			}
 
-			memR.mempool.metrics.ActiveOutboundConnections.Add(1)
-			defer memR.mempool.metrics.ActiveOutboundConnections.Add(-1)
+			memR.mempool.getMetrics().ActiveOutboundConnections.Add(1)
+			defer memR.mempool.getMetrics().ActiveOutboundConnections.Add(-1)
 			memR.broadcastTxRoutine(peer)
 		}()
 	}
@@ -178,7 +200,7 @@ func (memR *Reactor) RemovePeer(peer p2p.Peer, _ any) {
 		// adjust redundancy.
 		memR.router.resetRoutes(peer.ID())
 		memR.redundancyControl.triggerAdjustment(memR)
-		memR.mempool.metrics.DisabledRoutes.Set(float64(memR.router.numRoutes()))
+		memR.mempool.getMetrics().DisabledRoutes.Set(float64(memR.router.numRoutes()))
 	}
 }
 
@@ -215,14 +237,14 @@ func (memR *Reactor) Receive(e p2p.Envelope) {
 				memR.router.disableRoute(senders[0], senderID)
 
 				memR.Logger.Debug("Disable route", "source", senders[0], "target", senderID)
-				memR.mempool.metrics.DisabledRoutes.Set(float64(memR.router.numRoutes()))
+				memR.mempool.getMetrics().DisabledRoutes.Set(float64(memR.router.numRoutes()))
 			}
 
 		case *protomem.ResetRoute:
 			memR.Logger.Debug("Received Reset", "from", senderID)
 			if memR.router != nil {
 				memR.router.resetRandomRouteWithTarget(senderID)
-				memR.mempool.metrics.DisabledRoutes.Set(float64(memR.router.numRoutes()))
+				memR.mempool.getMetrics().DisabledRoutes.Set(float64(memR.router.numRoutes()))
 			}
 
 		default:
@@ -302,6 +324,9 @@ func (memR *Reactor) TryAddTx(tx types.Tx, sender p2p.Peer) (*abcicli.ReqRes, er
 	if memR.redundancyControl != nil {
 		memR.redundancyControl.incFirstTimeTxs()
 	}
+	if sender != nil {
+		memR.Switch.ReportBehaviour(sender, reputation.Tx)
+	}
 
 	return reqRes, nil
 }
@@ -349,7 +374,7 @@ func (memR *Reactor) broadcastTxRoutine(peer p2p.Peer) {
 		}
 	}()
 
-	iter := NewBlockingIterator(ctx, memR.mempool, peer.ID())
+	iter := memR.mempool.blockingIterator(ctx, string(peer.ID()))
 	for {
 		// In case of both next.NextWaitChan() and peer.Quit() are variable at the same time
 		if !memR.IsRunning() || !peer.IsRunning() {
@@ -593,7 +618,7 @@ func (rc *redundancyControl) adjustRedundancy(memR *Reactor) {
 	}
 
 	// Update metrics.
-	memR.mempool.metrics.Redundancy.Set(redundancy)
+	memR.mempool.getMetrics().Redundancy.Set(redundancy)
 }
 
 func (rc *redundancyControl) controlLoop(memR *Reactor) {
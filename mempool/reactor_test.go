@@ -193,7 +193,7 @@ func TestMempoolReactorSendLaggingPeer(t *testing.T) {
 	}()
 
 	// First reactor is at height 10 and knows that its peer is lagging at height 1.
-	reactors[0].mempool.height.Store(10)
+	reactors[0].mempool.(*CListMempool).height.Store(10)
 	peerID := reactors[1].Switch.NodeInfo().ID()
 	reactors[0].Switch.Peers().Get(peerID).Set(types.PeerStateKey, peerState{1})
 
@@ -226,7 +226,7 @@ func TestMempoolReactorSendRemovedTx(t *testing.T) {
 
 	// First reactor is at height 10 and knows that its peer is lagging at height 1.
 	// We do this to hold sending transactions, giving us time to remove some of them.
-	reactors[0].mempool.height.Store(10)
+	reactors[0].mempool.(*CListMempool).height.Store(10)
 	peerID := reactors[1].Switch.NodeInfo().ID()
 	reactors[0].Switch.Peers().Get(peerID).Set(types.PeerStateKey, peerState{1})
 
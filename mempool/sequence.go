@@ -0,0 +1,315 @@
+package mempool
+
+import (
+	"slices"
+
+	abci "github.com/cometbft/cometbft/v2/abci/types"
+	"github.com/cometbft/cometbft/v2/libs/log"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+// senderTxs keeps track of the transactions in the mempool of a given sender,
+// as reported by the application in CheckTxResponse.Sender.
+//
+// The transactions with sequence numbers nextSeq, nextSeq+1, ... (without gaps)
+// are ready: they are stored in a lane, in sequence order, so they can be
+// reaped and gossiped. The rest of the sender's transactions are held until the
+// gap before them is filled.
+type senderTxs struct {
+	// All the txs of the sender are stored in the same lane, so that reaping
+	// them respects their sequence order.
+	lane LaneID
+
+	// Sequence number of the next tx of the sender to be executed. Until a tx
+	// of the sender is committed, this is the lowest sequence seen.
+	nextSeq uint64
+
+	// Whether nextSeq was derived from a committed tx. If so, txs with lower
+	// sequence numbers are rejected.
+	committed bool
+
+	// All the txs of the sender in the mempool (ready or held), by sequence.
+	txs map[uint64]*mempoolTx
+}
+
+// senderLane returns the lane where the txs of sender are stored, or lane if the
+// mempool has no txs from sender.
+func (mem *CListMempool) senderLane(sender string, lane LaneID) LaneID {
+	mem.txsMtx.RLock()
+	defer mem.txsMtx.RUnlock()
+
+	if st, ok := mem.seqSenders[sender]; ok {
+		return st.lane
+	}
+	return lane
+}
+
+// addSequencedTx adds to the mempool a tx that has a sender. If there is
+// already a tx with the same sender and sequence, it is replaced by memTx only
+// if memTx has a higher priority.
+//
+// txsMtx must be held by the caller.
+func (mem *CListMempool) addSequencedTx(memTx *mempoolTx) error {
+	st, ok := mem.seqSenders[memTx.appSender]
+	if !ok {
+		st = &senderTxs{
+			lane:    memTx.lane,
+			nextSeq: memTx.appSequence,
+			txs:     make(map[uint64]*mempoolTx),
+		}
+		mem.seqSenders[memTx.appSender] = st
+	}
+	memTx.lane = st.lane
+
+	if memTx.appSequence < st.nextSeq {
+		if st.committed {
+			return ErrSequenceTooLow{
+				Sender:       memTx.appSender,
+				Sequence:     memTx.appSequence,
+				NextSequence: st.nextSeq,
+			}
+		}
+		// The app accepted a tx with a lower sequence than the ones we have
+		// seen so far, so it must be the next to be executed.
+		st.nextSeq = memTx.appSequence
+	}
+	if st.hasGapBefore(memTx.appSequence) {
+		if err := mem.checkHeldCapacity(st, memTx); err != nil {
+			return err
+		}
+	}
+
+	if oldTx, ok := st.txs[memTx.appSequence]; ok {
+		if memTx.priority <= oldTx.priority {
+			return ErrReplacementUnderpriced{
+				Sender:           memTx.appSender,
+				Sequence:         memTx.appSequence,
+				Priority:         memTx.priority,
+				ExistingPriority: oldTx.priority,
+			}
+		}
+		mem.removeTxEntry(oldTx)
+		mem.removeFromJournal(oldTx.tx)
+		mem.metrics.ReplacedTxs.Add(1)
+		if mem.onEvictedTx != nil {
+			mem.onEvictedTx(oldTx.tx, types.EvictedTxReasonReplaced)
+		}
+		mem.logger.Debug(
+			"Replaced transaction",
+			"old-tx", log.NewLazyHash(oldTx.tx),
+			"tx", log.NewLazyHash(memTx.tx),
+			"sender", memTx.appSender,
+			"sequence", memTx.appSequence,
+		)
+	}
+
+	st.txs[memTx.appSequence] = memTx
+	mem.holdTx(memTx)
+	mem.resequence(st)
+	return nil
+}
+
+// hasGapBefore reports whether some sequence between the next expected one
+// and seq is missing from the txs of the sender, in which case a tx with
+// sequence seq is held.
+func (st *senderTxs) hasGapBefore(seq uint64) bool {
+	// There is a gap within len(st.txs)+1 sequences, at the latest.
+	for s := st.nextSeq; s < seq; s++ {
+		if _, ok := st.txs[s]; !ok {
+			return true
+		}
+	}
+	return false
+}
+
+// checkHeldCapacity checks whether memTx, which would be held, fits within the
+// limits of held txs, per sender and in total. A tx of the sender with the same
+// sequence, which memTx would replace, is not counted.
+//
+// txsMtx must be held by the caller.
+func (mem *CListMempool) checkHeldCapacity(st *senderTxs, memTx *mempoolTx) error {
+	numSenderTxs, numTxs, txsBytes := 0, len(mem.heldTxs), mem.heldTxsBytes
+	for _, senderTx := range st.txs {
+		if _, ok := mem.heldTxs[senderTx.tx.Key()]; ok {
+			numSenderTxs++
+		}
+	}
+	if oldTx, ok := st.txs[memTx.appSequence]; ok {
+		numSenderTxs--
+		numTxs--
+		txsBytes -= int64(len(oldTx.tx))
+	}
+
+	if numSenderTxs >= mem.config.MaxHeldTxsPerSender || numTxs >= mem.config.Size ||
+		txsBytes+int64(len(memTx.tx)) > mem.config.MaxTxsBytes {
+		return ErrHeldTxsFull{
+			Sender:       memTx.appSender,
+			NumSenderTxs: numSenderTxs,
+			MaxSenderTxs: mem.config.MaxHeldTxsPerSender,
+			NumTxs:       numTxs,
+			MaxTxs:       mem.config.Size,
+			TxsBytes:     txsBytes,
+			MaxTxsBytes:  mem.config.MaxTxsBytes,
+		}
+	}
+	return nil
+}
+
+// updateSequences advances the next expected sequence of the senders of the
+// given committed txs. The sender and sequence of a committed tx are those
+// reported by the app in its result or, if not reported, those of the tx in
+// the mempool, if any. This way, the gaps filled by txs that were never in the
+// mempool are also taken into account.
+func (mem *CListMempool) updateSequences(txs types.Txs, txResults []*abci.ExecTxResult) {
+	mem.txsMtx.Lock()
+	defer mem.txsMtx.Unlock()
+
+	for i, tx := range txs {
+		sender, seq := txResults[i].Sender, txResults[i].Sequence
+		if sender == "" {
+			memTx := mem.getMemTx(tx.Key())
+			if memTx == nil || memTx.appSender == "" {
+				continue
+			}
+			sender, seq = memTx.appSender, memTx.appSequence
+		}
+		st, ok := mem.seqSenders[sender]
+		if !ok {
+			continue
+		}
+		if seq >= st.nextSeq {
+			st.nextSeq = seq + 1
+		}
+		st.committed = true
+	}
+}
+
+// resequenceAll makes sure that, for all senders, the ready txs are exactly
+// those without gaps from the next expected sequence. The txs with a sequence
+// lower than a committed one can no longer be executed, so they are removed.
+func (mem *CListMempool) resequenceAll() {
+	mem.txsMtx.Lock()
+	defer mem.txsMtx.Unlock()
+
+	for sender, st := range mem.seqSenders {
+		if st.committed {
+			mem.removeStaleTxs(st)
+		}
+		if len(st.txs) == 0 {
+			delete(mem.seqSenders, sender)
+			continue
+		}
+		mem.resequence(st)
+	}
+}
+
+// removeStaleTxs removes the txs of a sender with a sequence lower than the
+// next expected one, which was derived from a committed tx.
+//
+// txsMtx must be held by the caller.
+func (mem *CListMempool) removeStaleTxs(st *senderTxs) {
+	for seq, memTx := range st.txs {
+		if seq >= st.nextSeq {
+			continue
+		}
+		mem.removeTxEntry(memTx)
+		mem.removeFromJournal(memTx.tx)
+		mem.tryRemoveFromCache(memTx.tx)
+		mem.metrics.EvictedTxs.Add(1)
+		mem.logger.Debug(
+			"Removed transaction with a committed sequence",
+			"tx", log.NewLazyHash(memTx.tx),
+			"sender", memTx.appSender,
+			"sequence", memTx.appSequence,
+		)
+		if mem.onEvictedTx != nil {
+			mem.onEvictedTx(memTx.tx, types.EvictedTxReasonInvalid)
+		}
+	}
+}
+
+// resequence moves the txs of a sender between its lane and the held txs, so
+// that the lane contains, in sequence order, all the sender's txs starting from
+// the next expected sequence and without gaps.
+//
+// txsMtx must be held by the caller.
+func (mem *CListMempool) resequence(st *senderTxs) {
+	seqs := make([]uint64, 0, len(st.txs))
+	for seq := range st.txs {
+		seqs = append(seqs, seq)
+	}
+	slices.Sort(seqs)
+
+	// Txs currently in the lane, which by construction are in sequence order.
+	ready := make([]*mempoolTx, 0, len(seqs))
+	for _, seq := range seqs {
+		if memTx := st.txs[seq]; mem.txsMap[memTx.tx.Key()] != nil {
+			ready = append(ready, memTx)
+		}
+	}
+
+	// Txs that should be in the lane.
+	next := make([]*mempoolTx, 0, len(seqs))
+	for seq := st.nextSeq; len(next) < len(seqs); seq++ {
+		memTx, ok := st.txs[seq]
+		if !ok {
+			break
+		}
+		next = append(next, memTx)
+	}
+
+	// Keep the longest common prefix in the lane. The rest of the txs in the
+	// lane are moved to the back of the lane (if they are still ready), to
+	// preserve the sequence order.
+	i := 0
+	for i < len(ready) && i < len(next) && ready[i] == next[i] {
+		i++
+	}
+	for _, memTx := range ready[i:] {
+		mem.removeFromLane(mem.txsMap[memTx.tx.Key()])
+		mem.holdTx(memTx)
+	}
+	for _, memTx := range next[i:] {
+		mem.unholdTx(memTx)
+		mem.pushTx(memTx)
+	}
+}
+
+// holdTx adds memTx to the set of held txs.
+//
+// txsMtx must be held by the caller.
+func (mem *CListMempool) holdTx(memTx *mempoolTx) {
+	mem.heldTxs[memTx.tx.Key()] = memTx
+	mem.heldTxsBytes += int64(len(memTx.tx))
+}
+
+// unholdTx removes memTx from the set of held txs.
+//
+// txsMtx must be held by the caller.
+func (mem *CListMempool) unholdTx(memTx *mempoolTx) {
+	txKey := memTx.tx.Key()
+	if _, ok := mem.heldTxs[txKey]; !ok {
+		return
+	}
+	delete(mem.heldTxs, txKey)
+	mem.heldTxsBytes -= int64(len(memTx.tx))
+}
+
+// removeTxEntry removes memTx from the mempool, whether it is in a lane or
+// held, and from the txs of its sender.
+//
+// txsMtx must be held by the caller.
+func (mem *CListMempool) removeTxEntry(memTx *mempoolTx) {
+	if elem, ok := mem.txsMap[memTx.tx.Key()]; ok {
+		mem.removeFromLane(elem)
+	} else {
+		mem.unholdTx(memTx)
+	}
+
+	if memTx.appSender == "" {
+		return
+	}
+	if st, ok := mem.seqSenders[memTx.appSender]; ok && st.txs[memTx.appSequence] == memTx {
+		delete(st.txs, memTx.appSequence)
+	}
+}
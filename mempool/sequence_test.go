@@ -0,0 +1,221 @@
+package mempool
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/v2/abci/example/kvstore"
+	abci "github.com/cometbft/cometbft/v2/abci/types"
+	"github.com/cometbft/cometbft/v2/proxy"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+// sequenceApp is a kvstore application that, for each transaction of the form
+// sender.sequence=priority, reports the sender, sequence and priority of the
+// transaction. Transactions in invalid are rejected.
+type sequenceApp struct {
+	priorityApp
+	invalid map[string]bool
+}
+
+func (app *sequenceApp) CheckTx(ctx context.Context, req *abci.CheckTxRequest) (*abci.CheckTxResponse, error) {
+	if app.invalid[string(req.Tx)] {
+		return &abci.CheckTxResponse{Code: kvstore.CodeTypeInvalidTxFormat}, nil
+	}
+	res, err := app.priorityApp.CheckTx(ctx, req)
+	if err != nil || res.Code != abci.CodeTypeOK {
+		return res, err
+	}
+	key, _, _ := strings.Cut(string(req.Tx), "=")
+	if sender, seq, ok := strings.Cut(key, "."); ok {
+		res.Sender = sender
+		res.Sequence, _ = strconv.ParseUint(seq, 10, 64)
+	}
+	return res, nil
+}
+
+func newMempoolWithSequenceApp(t *testing.T) (*CListMempool, *sequenceApp) {
+	t.Helper()
+	app := &sequenceApp{
+		priorityApp: priorityApp{kvstore.NewInMemoryApplicationWithoutLanes()},
+		invalid:     make(map[string]bool),
+	}
+	mp, cleanup := newMempoolWithApp(proxy.NewLocalClientCreator(app))
+	t.Cleanup(cleanup)
+	return mp, app
+}
+
+func seqTx(sender string, seq uint64, priority int64) types.Tx {
+	return kvstore.NewTx(sender+"."+strconv.FormatUint(seq, 10), strconv.FormatInt(priority, 10))
+}
+
+func checkSeqTx(t *testing.T, mp Mempool, tx types.Tx) error {
+	t.Helper()
+	rr, err := mp.CheckTx(tx, noSender)
+	require.NoError(t, err)
+	rr.Wait()
+	return rr.Error()
+}
+
+func TestMempoolSequenceGaps(t *testing.T) {
+	mp, _ := newMempoolWithSequenceApp(t)
+
+	alice1, alice2, alice3 := seqTx("alice", 1, 0), seqTx("alice", 2, 0), seqTx("alice", 3, 0)
+	bob1 := seqTx("bob", 1, 0)
+	require.NoError(t, checkSeqTx(t, mp, alice1))
+	require.NoError(t, checkSeqTx(t, mp, alice3))
+	require.NoError(t, checkSeqTx(t, mp, bob1))
+
+	// alice3 is held until alice2 arrives.
+	require.True(t, mp.Contains(alice3.Key()))
+	require.Equal(t, 2, mp.Size())
+	require.Equal(t, types.Txs{alice1, bob1}, mp.ReapMaxTxs(-1))
+
+	require.NoError(t, checkSeqTx(t, mp, alice2))
+	require.Equal(t, 4, mp.Size())
+	require.Equal(t, types.Txs{alice1, bob1, alice2, alice3}, mp.ReapMaxBytesMaxGas(-1, -1))
+
+	// A lower sequence goes before the txs of the same sender.
+	alice0 := seqTx("alice", 0, 0)
+	require.NoError(t, checkSeqTx(t, mp, alice0))
+	require.Equal(t, types.Txs{bob1, alice0, alice1, alice2, alice3}, mp.ReapMaxTxs(-1))
+}
+
+func TestMempoolSequenceReplaceByFee(t *testing.T) {
+	mp, _ := newMempoolWithSequenceApp(t)
+
+	alice1, alice2 := seqTx("alice", 1, 5), seqTx("alice", 2, 5)
+	require.NoError(t, checkSeqTx(t, mp, alice1))
+	require.NoError(t, checkSeqTx(t, mp, alice2))
+
+	// A replacement needs a higher priority.
+	err := checkSeqTx(t, mp, seqTx("alice", 1, 4))
+	require.ErrorAs(t, err, &ErrReplacementUnderpriced{})
+	require.Equal(t, types.Txs{alice1, alice2}, mp.ReapMaxTxs(-1))
+
+	alice1b := seqTx("alice", 1, 7)
+	require.NoError(t, checkSeqTx(t, mp, alice1b))
+	require.False(t, mp.Contains(alice1.Key()))
+	require.Equal(t, 2, mp.Size())
+	require.Equal(t, types.Txs{alice1b, alice2}, mp.ReapMaxTxs(-1))
+
+	// Held txs can be replaced as well.
+	alice4, alice4b := seqTx("alice", 4, 1), seqTx("alice", 4, 2)
+	require.NoError(t, checkSeqTx(t, mp, alice4))
+	require.NoError(t, checkSeqTx(t, mp, alice4b))
+	require.False(t, mp.Contains(alice4.Key()))
+	require.True(t, mp.Contains(alice4b.Key()))
+	require.Equal(t, 2, mp.Size())
+}
+
+func TestMempoolSequenceUpdate(t *testing.T) {
+	mp, app := newMempoolWithSequenceApp(t)
+
+	alice1, alice2, alice3 := seqTx("alice", 1, 0), seqTx("alice", 2, 0), seqTx("alice", 3, 0)
+	for _, tx := range []types.Tx{alice1, alice2, alice3} {
+		require.NoError(t, checkSeqTx(t, mp, tx))
+	}
+
+	// alice2 becomes invalid after alice1 is committed, so alice3 is held
+	// instead of being reaped.
+	app.invalid[string(alice2)] = true
+	doUpdate(t, mp, 1, types.Txs{alice1})
+	require.False(t, mp.Contains(alice2.Key()))
+	require.True(t, mp.Contains(alice3.Key()))
+	require.Zero(t, mp.Size())
+	require.Empty(t, mp.ReapMaxTxs(-1))
+
+	// A sequence lower than a committed one is rejected.
+	err := checkSeqTx(t, mp, seqTx("alice", 0, 0))
+	require.ErrorAs(t, err, &ErrSequenceTooLow{})
+
+	// Filling the gap releases alice3.
+	delete(app.invalid, string(alice2))
+	require.NoError(t, checkSeqTx(t, mp, alice2))
+	require.Equal(t, types.Txs{alice2, alice3}, mp.ReapMaxTxs(-1))
+
+	// Removing a tx holds the following ones.
+	require.NoError(t, mp.RemoveTxByKey(alice2.Key()))
+	require.Zero(t, mp.Size())
+	require.True(t, mp.Contains(alice3.Key()))
+
+	mp.Flush()
+	require.False(t, mp.Contains(alice3.Key()))
+}
+
+func TestMempoolSequenceCommittedResults(t *testing.T) {
+	mp, _ := newMempoolWithSequenceApp(t)
+
+	alice1, alice3 := seqTx("alice", 1, 0), seqTx("alice", 3, 0)
+	require.NoError(t, checkSeqTx(t, mp, alice1))
+	require.NoError(t, checkSeqTx(t, mp, alice3))
+	require.Equal(t, types.Txs{alice1}, mp.ReapMaxTxs(-1))
+
+	// alice2, which was never in the mempool, is committed: the app reports
+	// its sender and sequence, so alice1 is removed and alice3 is released.
+	alice2 := seqTx("alice", 2, 0)
+	mp.Lock()
+	err := mp.Update(1, types.Txs{alice2}, []*abci.ExecTxResult{
+		{Code: abci.CodeTypeOK, Sender: "alice", Sequence: 2},
+	}, nil, nil)
+	mp.Unlock()
+	require.NoError(t, err)
+	require.False(t, mp.Contains(alice1.Key()))
+	require.Equal(t, types.Txs{alice3}, mp.ReapMaxTxs(-1))
+
+	err = checkSeqTx(t, mp, seqTx("alice", 2, 1))
+	require.ErrorAs(t, err, &ErrSequenceTooLow{})
+}
+
+func TestMempoolSequenceHeldCapacity(t *testing.T) {
+	mp, _ := newMempoolWithSequenceApp(t)
+	mp.config.Size = 2
+	mp.config.MaxHeldTxsPerSender = 1
+
+	alice1, alice3, alice4 := seqTx("alice", 1, 0), seqTx("alice", 3, 0), seqTx("alice", 4, 0)
+	require.NoError(t, checkSeqTx(t, mp, alice1))
+	require.NoError(t, checkSeqTx(t, mp, alice3))
+
+	// The sender already has the maximum number of held txs.
+	err := checkSeqTx(t, mp, alice4)
+	require.ErrorAs(t, err, &ErrHeldTxsFull{})
+	require.False(t, mp.Contains(alice4.Key()))
+
+	// A held tx can still be replaced.
+	alice3b := seqTx("alice", 3, 1)
+	require.NoError(t, checkSeqTx(t, mp, alice3b))
+	require.False(t, mp.Contains(alice3.Key()))
+
+	// Held txs do not count towards the mempool capacity.
+	bob1 := seqTx("bob", 1, 0)
+	require.NoError(t, checkSeqTx(t, mp, bob1))
+	require.Equal(t, 2, mp.Size())
+	_, err = mp.CheckTx(seqTx("carol", 1, 0), noSender)
+	require.ErrorAs(t, err, &ErrMempoolIsFull{})
+
+	// Filling the gap releases the held tx, even beyond the mempool capacity.
+	doUpdate(t, mp, 1, types.Txs{bob1})
+	alice2 := seqTx("alice", 2, 0)
+	require.NoError(t, checkSeqTx(t, mp, alice2))
+	require.Equal(t, types.Txs{alice1, alice2, alice3b}, mp.ReapMaxTxs(-1))
+}
+
+func TestMempoolSequenceRecheckHeld(t *testing.T) {
+	mp, app := newMempoolWithSequenceApp(t)
+
+	alice1, alice3, alice4 := seqTx("alice", 1, 0), seqTx("alice", 3, 0), seqTx("alice", 4, 0)
+	for _, tx := range []types.Tx{alice1, alice3, alice4} {
+		require.NoError(t, checkSeqTx(t, mp, tx))
+	}
+
+	// Held txs that became invalid are removed on recheck.
+	app.invalid[string(alice3)] = true
+	doUpdate(t, mp, 1, nil)
+	require.False(t, mp.Contains(alice3.Key()))
+	require.True(t, mp.Contains(alice4.Key()))
+	require.Equal(t, types.Txs{alice1}, mp.ReapMaxTxs(-1))
+}
@@ -64,6 +64,17 @@ func (e ErrPrivValidatorSocketClient) Unwrap() error {
 	return e.Err
 }
 
+// ErrKeyRotationNotSupported is returned when a next validator key is staged,
+// but the private validator, e.g. a remote signer, cannot rotate to it.
+type ErrKeyRotationNotSupported struct {
+	KeyFile string
+}
+
+func (e ErrKeyRotationNotSupported) Error() string {
+	return fmt.Sprintf("next validator key %s is staged, but key rotation is only supported with "+
+		"priv_validator_key_file: remove it and rotate the key in the remote signer", e.KeyFile)
+}
+
 // ErrGetPubKey is returned when the node fails to get the public key.
 type ErrGetPubKey struct {
 	Err error
@@ -6,9 +6,11 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -30,13 +32,15 @@ import (
 	"github.com/cometbft/cometbft/v2/light"
 	mempl "github.com/cometbft/cometbft/v2/mempool"
 	"github.com/cometbft/cometbft/v2/p2p"
+	"github.com/cometbft/cometbft/v2/p2p/firewall"
 	na "github.com/cometbft/cometbft/v2/p2p/netaddr"
 	"github.com/cometbft/cometbft/v2/p2p/pex"
-	"github.com/cometbft/cometbft/v2/p2p/transport/tcp"
+	"github.com/cometbft/cometbft/v2/privval"
 	"github.com/cometbft/cometbft/v2/proxy"
 	rpccore "github.com/cometbft/cometbft/v2/rpc/core"
 	grpcserver "github.com/cometbft/cometbft/v2/rpc/grpc/server"
 	grpcprivserver "github.com/cometbft/cometbft/v2/rpc/grpc/server/privileged"
+	grpcsubscription "github.com/cometbft/cometbft/v2/rpc/grpc/server/services/subscription"
 	rpcserver "github.com/cometbft/cometbft/v2/rpc/jsonrpc/server"
 	sm "github.com/cometbft/cometbft/v2/state"
 	"github.com/cometbft/cometbft/v2/state/indexer"
@@ -59,10 +63,15 @@ type Node struct {
 	genesisTime   time.Time
 	privValidator types.PrivValidator // local node's validator key
 
+	// slashing-protection database of the local FilePV (nil if the validator
+	// key is not a FilePV)
+	slashingProtection *privval.SlashingProtectionDB
+
 	// network
-	transport   *tcp.MultiplexTransport
-	sw          *p2p.Switch  // p2p connections
-	addrBook    pex.AddrBook // known peers
+	transport   p2pTransport
+	sw          *p2p.Switch        // p2p connections
+	firewall    *firewall.Firewall // allow and deny rules of peers
+	addrBook    pex.AddrBook       // known peers
 	nodeInfo    p2p.NodeInfo
 	nodeKey     *p2p.NodeKey // our node privkey
 	isListening bool
@@ -72,9 +81,11 @@ type Node struct {
 	stateStore       sm.Store
 	blockStore       *store.BlockStore // store the blockchain to disk
 	pruner           *sm.Pruner
+	nodeSnapshotter  *statesync.NodeSnapshotter
 	bcReactor        p2p.Reactor    // for block-syncing
 	mempoolReactor   mempoolReactor // for gossipping transactions
 	mempool          mempl.Mempool
+	mempoolJournal   *mempl.Journal // persists the mempool txs (nil if disabled)
 	consensusState   *cs.State      // latest consensus state
 	consensusReactor *cs.Reactor    // for participating in the consensus
 	pexReactor       *pex.Reactor   // for exchanging peer addresses
@@ -281,6 +292,98 @@ func BootstrapState(ctx context.Context, config *cfg.Config, dbProvider cfg.DBPr
 	return err
 }
 
+// RestoreLocalSnapshot restores the application from a local snapshot, without
+// any peers, and bootstraps the stores from the node snapshot it contains. The
+// snapshot is verified against the trusted hash of the header at trustHeight,
+// which must be the snapshot height or the following one. See
+// statesync.RestoreLocalSnapshot for the layout of the local snapshot. It is
+// expected that the block store and state store are empty at the time the
+// function is called.
+func RestoreLocalSnapshot(
+	config *cfg.Config,
+	dbProvider cfg.DBProvider,
+	genProvider GenesisDocProvider,
+	clientCreator proxy.ClientCreator,
+	path string,
+	trustHeight int64,
+	trustHash []byte,
+	logger log.Logger,
+) (state sm.State, err error) {
+	if dbProvider == nil {
+		dbProvider = cfg.DefaultDBProvider
+	}
+	blockStoreDB, stateDB, err := initDBs(config, dbProvider)
+	if err != nil {
+		return sm.State{}, err
+	}
+
+	blockStore := store.NewBlockStore(blockStoreDB, store.WithDBKeyLayout(config.Storage.ExperimentalKeyLayout))
+	defer func() {
+		if derr := blockStore.Close(); derr != nil {
+			logger.Error("Failed to close blockstore", "err", derr)
+			err = derr
+		}
+	}()
+	if !blockStore.IsEmpty() {
+		return sm.State{}, ErrNonEmptyBlockStore
+	}
+
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		DiscardABCIResponses: config.Storage.DiscardABCIResponses,
+		Logger:               logger,
+		DBKeyLayout:          config.Storage.ExperimentalKeyLayout,
+	})
+	defer func() {
+		if derr := stateStore.Close(); derr != nil {
+			logger.Error("Failed to close statestore", "err", derr)
+			err = derr
+		}
+	}()
+	state, err = stateStore.Load()
+	if err != nil {
+		return sm.State{}, err
+	}
+	if !state.IsEmpty() {
+		return sm.State{}, ErrNonEmptyState
+	}
+	genState, _, err := LoadStateFromDBOrGenesisDocProvider(stateDB, genProvider, "")
+	if err != nil {
+		return sm.State{}, err
+	}
+
+	proxyApp, err := createAndStartProxyAppConns(clientCreator, logger, proxy.NopMetrics())
+	if err != nil {
+		return sm.State{}, err
+	}
+	defer func() {
+		if err := proxyApp.Stop(); err != nil {
+			logger.Error("Failed to stop proxy app connections", "err", err)
+		}
+	}()
+
+	state, commit, blocks, err := statesync.RestoreLocalSnapshot(*config.StateSync, genState.ChainID, path,
+		trustHeight, trustHash, proxyApp.Snapshot(), proxyApp.Query(), logger.With("module", "statesync"))
+	if err != nil {
+		return sm.State{}, err
+	}
+
+	if err := stateStore.Bootstrap(state); err != nil {
+		return sm.State{}, err
+	}
+	if err := statesync.SaveBlocks(blockStore, blocks, commit); err != nil {
+		return sm.State{}, err
+	}
+	if err := blockStore.SaveSeenCommit(state.LastBlockHeight, commit); err != nil {
+		return sm.State{}, err
+	}
+
+	// As with BootstrapState, the node has no extended commit at the restored height.
+	if err := stateStore.SetOfflineStateSyncHeight(state.LastBlockHeight); err != nil {
+		return sm.State{}, ErrSetSyncHeight{Err: err}
+	}
+	return state, nil
+}
+
 // ------------------------------------------------------------------------------
 
 // NewNode returns a new, ready to go, CometBFT Node.
@@ -384,15 +487,53 @@ func NewNodeWithCliParams(ctx context.Context,
 	}
 
 	// If an address is provided, listen on the socket for a connection from an
-	// external signing process.
-	if config.PrivValidatorListenAddr != "" {
+	// external signing process. If several addresses are provided, fail over
+	// between the signing processes connecting to them.
+	if listenAddrs := splitAndTrimEmpty(config.PrivValidatorListenAddr, ",", " "); len(listenAddrs) > 0 {
 		// FIXME: we should start services inside OnStart
-		privValidator, err = createAndStartPrivValidatorSocketClient(config.PrivValidatorListenAddr, genDoc.ChainID, logger)
+		if len(listenAddrs) == 1 {
+			privValidator, err = createAndStartPrivValidatorSocketClient(listenAddrs[0], genDoc.ChainID, logger)
+		} else {
+			privValidator, err = createAndStartPrivValidatorFailoverClient(
+				listenAddrs, genDoc.ChainID, filepath.Join(config.DBDir(), privval.SignerHighWaterMarkFile), logger)
+		}
+		if err != nil {
+			return nil, ErrPrivValidatorSocketClient{Err: err}
+		}
+	}
+
+	// If a gRPC address is provided, connect to the PrivValidatorAPI service of
+	// an external signing process, failing over between several processes if
+	// several addresses are provided.
+	if config.PrivValidatorGRPCAddr != "" {
+		privValidator, err = createPrivValidatorGRPCClient(config, genDoc.ChainID, logger)
 		if err != nil {
 			return nil, ErrPrivValidatorSocketClient{Err: err}
 		}
 	}
 
+	// If a threshold signer file is provided, listen for connections from the
+	// signing processes holding the shares of the split key.
+	if config.PrivValidatorThreshold != "" {
+		privValidator, err = createAndStartPrivValidatorThresholdClient(config, genDoc.ChainID, logger)
+		if err != nil {
+			return nil, ErrPrivValidatorSocketClient{Err: err}
+		}
+	}
+
+	// Only the file-based private validator rotates to a staged key; a remote
+	// signer would keep signing with its own key.
+	if _, ok := privValidator.(types.KeyRotatingPrivValidator); !ok {
+		if _, err := os.Stat(config.PrivValidatorNextKeyFile()); err == nil {
+			return nil, ErrKeyRotationNotSupported{KeyFile: config.PrivValidatorNextKeyFile()}
+		}
+	}
+
+	slashingProtection, err := initSlashingProtection(config, dbProvider, privValidator)
+	if err != nil {
+		return nil, err
+	}
+
 	pubKey, err := privValidator.GetPubKey()
 	if err != nil {
 		return nil, ErrGetPubKey{Err: err}
@@ -434,7 +575,17 @@ func NewNodeWithCliParams(ctx context.Context,
 	// Blocksync is always active, except if the local node blocks the chain
 	waitSync := !state.Validators.ValidatorBlocksTheChain(localAddr)
 
-	mempool, mempoolReactor := createMempoolAndMempoolReactor(config, proxyApp, state, eventBus, waitSync, memplMetrics, logger, appInfoResponse)
+	mempoolJournal, err := createMempoolJournal(config, dbProvider, logger)
+	if err != nil {
+		return nil, fmt.Errorf("could not create mempool journal: %w", err)
+	}
+	mempool, mempoolReactor := createMempoolAndMempoolReactor(config, proxyApp, state, eventBus, waitSync, memplMetrics, logger, appInfoResponse, mempoolJournal)
+	if mempoolJournal != nil {
+		// Restore the mempool before the reactor starts gossiping txs.
+		if err := mempoolJournal.Replay(mempool, blockStore); err != nil {
+			return nil, fmt.Errorf("could not replay mempool journal: %w", err)
+		}
+	}
 
 	evidenceReactor, evidencePool, err := createEvidenceReactor(config, dbProvider, stateStore, blockStore, logger)
 	if err != nil {
@@ -489,6 +640,11 @@ func NewNodeWithCliParams(ctx context.Context,
 	if err != nil {
 		panic(fmt.Sprintf("failed to reset the offline state sync height %s", err))
 	}
+	nodeSnapshotStore, nodeSnapshotter, err := createNodeSnapshotter(config, stateStore, blockStore, logger)
+	if err != nil {
+		return nil, fmt.Errorf("could not create node snapshotter: %w", err)
+	}
+
 	// Set up state sync reactor, and schedule a sync if requested.
 	// FIXME The way we do phased startups (e.g. replay -> block sync -> consensus) is very messy,
 	// we should clean this whole thing up. See:
@@ -498,6 +654,9 @@ func NewNodeWithCliParams(ctx context.Context,
 		proxyApp.Snapshot(),
 		proxyApp.Query(),
 		ssMetrics,
+		statesync.WithNodeSnapshotStore(nodeSnapshotStore),
+		statesync.WithProgressDir(filepath.Join(config.DBDir(), "statesync")),
+		statesync.WithStores(stateStore, blockStore),
 	)
 	stateSyncReactor.SetLogger(logger.With("module", "statesync"))
 
@@ -506,13 +665,21 @@ func NewNodeWithCliParams(ctx context.Context,
 		return nil, err
 	}
 
-	transport, peerFilters := createTransport(config, nodeKey, proxyApp)
-
 	p2pLogger := logger.With("module", "p2p")
+
+	p2pFirewall, err := createFirewall(config, p2pLogger)
+	if err != nil {
+		return nil, err
+	}
+
+	transport, peerFilters, err := createTransport(config, nodeKey, proxyApp, p2pFirewall)
+	if err != nil {
+		return nil, fmt.Errorf("could not create transport: %w", err)
+	}
 	transport.SetLogger(p2pLogger)
 
 	sw := createSwitch(
-		config, transport, p2pMetrics, peerFilters, mempoolReactor, bcReactor,
+		config, transport, p2pMetrics, peerFilters, p2pFirewall, mempoolReactor, bcReactor,
 		stateSyncReactor, consensusReactor, evidenceReactor, nodeInfo, nodeKey, p2pLogger,
 	)
 
@@ -556,8 +723,11 @@ func NewNodeWithCliParams(ctx context.Context,
 		genesisTime:   genDoc.GenesisTime,
 		privValidator: privValidator,
 
+		slashingProtection: slashingProtection,
+
 		transport: transport,
 		sw:        sw,
+		firewall:  p2pFirewall,
 		addrBook:  addrBook,
 		nodeInfo:  nodeInfo,
 		nodeKey:   nodeKey,
@@ -567,6 +737,7 @@ func NewNodeWithCliParams(ctx context.Context,
 		pruner:           pruner,
 		bcReactor:        bcReactor,
 		mempoolReactor:   mempoolReactor,
+		mempoolJournal:   mempoolJournal,
 		mempool:          mempool,
 		consensusState:   consensusState,
 		consensusReactor: consensusReactor,
@@ -581,6 +752,7 @@ func NewNodeWithCliParams(ctx context.Context,
 		// statesync
 		stateSync:        stateSync,
 		stateSyncReactor: stateSyncReactor,
+		nodeSnapshotter:  nodeSnapshotter,
 		stateSyncState:   state,
 		stateSyncGenDoc:  genDoc,
 		appInfoResponse:  appInfoResponse,
@@ -713,6 +885,12 @@ func (n *Node) OnStart() error {
 		return ErrStartPruning{Err: err}
 	}
 
+	if n.nodeSnapshotter != nil {
+		if err := n.nodeSnapshotter.Start(); err != nil {
+			return fmt.Errorf("failed to start node snapshotter: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -726,6 +904,11 @@ func (n *Node) OnStop() {
 	if err := n.pruner.Stop(); err != nil {
 		n.Logger.Error("Error stopping the pruning service", "err", err)
 	}
+	if n.nodeSnapshotter != nil {
+		if err := n.nodeSnapshotter.Stop(); err != nil {
+			n.Logger.Error("Error stopping the node snapshotter", "err", err)
+		}
+	}
 	if err := n.eventBus.Stop(); err != nil {
 		n.Logger.Error("Error closing eventBus", "err", err)
 	}
@@ -757,6 +940,10 @@ func (n *Node) OnStop() {
 		if err := pvsc.Stop(); err != nil {
 			n.Logger.Error("Error closing private validator", "err", err)
 		}
+	} else if pvc, ok := n.privValidator.(io.Closer); ok {
+		if err := pvc.Close(); err != nil {
+			n.Logger.Error("Error closing private validator", "err", err)
+		}
 	}
 
 	if n.prometheusSrv != nil {
@@ -782,12 +969,24 @@ func (n *Node) OnStop() {
 			n.Logger.Error("problem closing statestore", "err", err)
 		}
 	}
+	if n.mempoolJournal != nil {
+		n.Logger.Info("Closing mempool journal")
+		if err := n.mempoolJournal.Close(); err != nil {
+			n.Logger.Error("problem closing mempool journal", "err", err)
+		}
+	}
 	if n.evidencePool != nil {
 		n.Logger.Info("Closing evidencestore")
 		if err := n.EvidencePool().Close(); err != nil {
 			n.Logger.Error("problem closing evidencestore", "err", err)
 		}
 	}
+	if n.slashingProtection != nil {
+		n.Logger.Info("Closing slashing-protection database")
+		if err := n.slashingProtection.Close(); err != nil {
+			n.Logger.Error("problem closing slashing-protection database", "err", err)
+		}
+	}
 }
 
 // ConfigureRPC initializes and returns an `Environment` object with all the data
@@ -808,6 +1007,7 @@ func (n *Node) ConfigureRPC() (*rpccore.Environment, error) {
 		ConsensusState: n.consensusState,
 		P2PPeers:       n.sw,
 		P2PTransport:   n,
+		P2PFirewall:    n.firewall,
 		PubKey:         pubKey,
 
 		TxIndexer:        n.txIndexer,
@@ -935,6 +1135,9 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 		opts := []grpcserver.Option{
 			grpcserver.WithLogger(n.Logger),
 		}
+		// Event subscriptions are bounded like the JSON-RPC ones, across all
+		// the gRPC services.
+		subLimiter := grpcsubscription.NewLimiter(n.config.RPC.MaxSubscriptionClients, n.config.RPC.MaxSubscriptionsPerClient)
 		if n.config.GRPC.VersionService.Enabled {
 			opts = append(opts, grpcserver.WithVersionService())
 		}
@@ -944,6 +1147,27 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 		if n.config.GRPC.BlockResultsService.Enabled {
 			opts = append(opts, grpcserver.WithBlockResultsService(n.blockStore, n.stateStore, n.Logger))
 		}
+		if n.config.GRPC.EventService.Enabled {
+			opts = append(opts, grpcserver.WithEventService(n.eventBus, subLimiter, n.Logger))
+		}
+		if n.config.GRPC.TxService.Enabled {
+			opts = append(opts, grpcserver.WithTxService(
+				n.mempoolReactor,
+				n.proxyApp.Mempool(),
+				n.txIndexer,
+				n.blockStore,
+				n.eventBus,
+				subLimiter,
+				n.config.GRPC.TxService.TimeoutBroadcastTxCommit,
+				n.Logger,
+			))
+		}
+		if n.config.GRPC.MempoolService.Enabled {
+			opts = append(opts, grpcserver.WithMempoolService(n.mempool, n.eventBus, subLimiter, n.Logger))
+		}
+		if n.config.GRPC.NetworkService.Enabled {
+			opts = append(opts, grpcserver.WithNetworkService(n, n.sw, n.consensusState, n.Logger))
+		}
 		go func() {
 			if err := grpcserver.Serve(listener, opts...); err != nil {
 				n.Logger.Error("Error starting gRPC server", "err", err)
@@ -1139,6 +1363,7 @@ func makeNodeInfo(
 			mempl.MempoolChannel, mempl.MempoolControlChannel,
 			evidence.EvidenceChannel,
 			statesync.SnapshotChannel, statesync.ChunkChannel,
+			statesync.NodeSnapshotChannel, statesync.NodeChunkChannel, statesync.LightBlockChannel,
 		},
 		Moniker: config.Moniker,
 		Other: p2p.NodeInfoDefaultOther{
@@ -16,8 +16,10 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 
 	dbm "github.com/cometbft/cometbft-db"
+	pvproto "github.com/cometbft/cometbft/api/cometbft/privval/v2"
 	"github.com/cometbft/cometbft/v2/abci/example/kvstore"
 	cfg "github.com/cometbft/cometbft/v2/config"
 	"github.com/cometbft/cometbft/v2/crypto"
@@ -33,6 +35,7 @@ import (
 	mempl "github.com/cometbft/cometbft/v2/mempool"
 	"github.com/cometbft/cometbft/v2/p2p"
 	p2pmock "github.com/cometbft/cometbft/v2/p2p/mock"
+	"github.com/cometbft/cometbft/v2/p2p/transport/quic"
 	"github.com/cometbft/cometbft/v2/p2p/transport/tcp/conn"
 	"github.com/cometbft/cometbft/v2/privval"
 	"github.com/cometbft/cometbft/v2/proxy"
@@ -85,6 +88,19 @@ func TestNodeStartStop(t *testing.T) {
 	}
 }
 
+func TestNodeStartStopQUIC(t *testing.T) {
+	config := test.ResetTestRoot("node_node_test")
+	defer os.RemoveAll(config.RootDir)
+	config.P2P.Transport = cfg.P2PTransportQUIC
+
+	n, err := DefaultNewNode(config, log.TestingLogger(), CliParams{}, nil)
+	require.NoError(t, err)
+	assert.IsType(t, &quic.Transport{}, n.transport)
+
+	require.NoError(t, n.Start())
+	require.NoError(t, n.Stop())
+}
+
 func TestSplitAndTrimEmpty(t *testing.T) {
 	testCases := []struct {
 		s        string
@@ -213,6 +229,93 @@ func TestNodeSetPrivValTCP(t *testing.T) {
 	assert.IsType(t, &privval.RetrySignerClient{}, n.PrivValidator())
 }
 
+func TestNodeKeyRotationRemoteSigner(t *testing.T) {
+	addr := "tcp://" + testFreeAddr(t)
+
+	config := test.ResetTestRoot("node_key_rotation_remote_signer_test")
+	defer os.RemoveAll(config.RootDir)
+	config.BaseConfig.PrivValidatorListenAddr = addr
+	pv := privval.LoadFilePVEmptyState(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile())
+	require.NoError(t, pv.StageNextKey(ed25519.GenPrivKey(), config.PrivValidatorNextKeyFile()))
+
+	dialer := privval.DialTCPFn(addr, 100*time.Millisecond, ed25519.GenPrivKey())
+	dialerEndpoint := privval.NewSignerDialerEndpoint(
+		log.TestingLogger(),
+		dialer,
+	)
+	privval.SignerDialerEndpointTimeoutReadWrite(100 * time.Millisecond)(dialerEndpoint)
+
+	signerServer := privval.NewSignerServer(
+		dialerEndpoint,
+		test.DefaultTestChainID,
+		types.NewMockPV(),
+	)
+
+	go func() {
+		err := signerServer.Start()
+		if err != nil {
+			panic(err)
+		}
+	}()
+	defer signerServer.Stop() //nolint:errcheck // ignore for tests
+
+	// The remote signer would not rotate to the staged key.
+	_, err := DefaultNewNode(config, log.TestingLogger(), CliParams{}, nil)
+	require.ErrorAs(t, err, &ErrKeyRotationNotSupported{})
+}
+
+func TestCreateTransportChannelRecvRates(t *testing.T) {
+	config := test.ResetTestRoot("node_channel_recv_rates_test")
+	defer os.RemoveAll(config.RootDir)
+	nodeKey := &p2p.NodeKey{PrivKey: ed25519.GenPrivKey()}
+
+	// Consensus messages above the rate would be dropped.
+	config.P2P.ChannelRecvRates = "0x20:102400"
+	_, _, err := createTransport(config, nodeKey, nil, nil)
+	require.Error(t, err)
+
+	config.P2P.ChannelRecvRates = "0x30:102400,0x38:102400"
+	_, _, err = createTransport(config, nodeKey, nil, nil)
+	require.NoError(t, err)
+}
+
+func TestNodeSetPrivValFailover(t *testing.T) {
+	addr1, addr2 := "tcp://"+testFreeAddr(t), "tcp://"+testFreeAddr(t)
+
+	config := test.ResetTestRoot("node_priv_val_failover_test")
+	defer os.RemoveAll(config.RootDir)
+	config.BaseConfig.PrivValidatorListenAddr = addr1 + ", " + addr2
+
+	// Only a signer for the second address is running.
+	dialer := privval.DialTCPFn(addr2, 100*time.Millisecond, ed25519.GenPrivKey())
+	dialerEndpoint := privval.NewSignerDialerEndpoint(
+		log.TestingLogger(),
+		dialer,
+	)
+	// Keep the connection open while the node waits for a signer on the first
+	// address.
+	privval.SignerDialerEndpointTimeoutReadWrite(10 * time.Second)(dialerEndpoint)
+
+	signerServer := privval.NewSignerServer(
+		dialerEndpoint,
+		test.DefaultTestChainID,
+		types.NewMockPV(),
+	)
+
+	go func() {
+		err := signerServer.Start()
+		if err != nil {
+			panic(err)
+		}
+	}()
+	defer signerServer.Stop() //nolint:errcheck // ignore for tests
+
+	n, err := DefaultNewNode(config, log.TestingLogger(), CliParams{}, nil)
+	require.NoError(t, err)
+	require.IsType(t, &privval.FailoverSignerClient{}, n.PrivValidator())
+	assert.Equal(t, 1, n.PrivValidator().(*privval.FailoverSignerClient).Active())
+}
+
 // address without a protocol must result in error.
 func TestPrivValidatorListenAddrNoProtocol(t *testing.T) {
 	addrNoPrefix := testFreeAddr(t)
@@ -257,6 +360,27 @@ func TestNodeSetPrivValIPC(t *testing.T) {
 	assert.IsType(t, &privval.RetrySignerClient{}, n.PrivValidator())
 }
 
+func TestNodeSetPrivValGRPC(t *testing.T) {
+	tmpfile := "/tmp/kms." + cmtrand.Str(6) + ".sock"
+	defer os.Remove(tmpfile) // clean up
+
+	config := test.ResetTestRoot("node_priv_val_grpc_test")
+	defer os.RemoveAll(config.RootDir)
+	config.BaseConfig.PrivValidatorGRPCAddr = "unix://" + tmpfile
+
+	ln, err := net.Listen("unix", tmpfile)
+	require.NoError(t, err)
+	srv := grpc.NewServer()
+	pvproto.RegisterPrivValidatorAPIServer(srv,
+		privval.NewGRPCSignerServer(test.DefaultTestChainID, types.NewMockPV(), log.TestingLogger()))
+	go func() { _ = srv.Serve(ln) }()
+	defer srv.Stop()
+
+	n, err := DefaultNewNode(config, log.TestingLogger(), CliParams{}, nil)
+	require.NoError(t, err)
+	assert.IsType(t, &privval.GRPCSignerClient{}, n.PrivValidator())
+}
+
 func TestNodeSetFilePrivVal(t *testing.T) {
 	for _, keyType := range kt.ListSupportedKeyTypes() {
 		t.Run(keyType, func(t *testing.T) {
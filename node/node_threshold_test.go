@@ -0,0 +1,92 @@
+//go:build bls12381
+
+package node
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/v2/crypto/bls12381"
+	"github.com/cometbft/cometbft/v2/crypto/ed25519"
+	"github.com/cometbft/cometbft/v2/internal/test"
+	"github.com/cometbft/cometbft/v2/libs/log"
+	"github.com/cometbft/cometbft/v2/privval"
+	"github.com/cometbft/cometbft/v2/types"
+	cmttime "github.com/cometbft/cometbft/v2/types/time"
+)
+
+// TestNodeThresholdSigner checks that a node whose validator key is split 2-of-3
+// between remote signers produces blocks while one of the signers is down.
+func TestNodeThresholdSigner(t *testing.T) {
+	config := test.ResetTestRoot("node_threshold_signer_test")
+	defer os.RemoveAll(config.RootDir)
+
+	privKey, err := bls12381.GenPrivKey()
+	require.NoError(t, err)
+	shares, err := bls12381.SplitPrivKey(privKey, 2, 3)
+	require.NoError(t, err)
+
+	// The node is the only validator, with the split key.
+	params := types.DefaultConsensusParams()
+	params.Validator.PubKeyTypes = []string{bls12381.KeyType}
+	genDoc := &types.GenesisDoc{
+		ChainID:         test.DefaultTestChainID,
+		GenesisTime:     cmttime.Now(),
+		ConsensusParams: params,
+		Validators:      []types.GenesisValidator{{PubKey: privKey.PubKey(), Power: 10}},
+	}
+	require.NoError(t, genDoc.SaveAs(config.GenesisFile()))
+
+	tf := &privval.ThresholdSignerFile{PubKey: privKey.PubKey(), Threshold: 2}
+	for i, share := range shares {
+		tf.Signers = append(tf.Signers, privval.ThresholdSignerAddr{
+			Index:      i + 1,
+			PubKey:     share.PubKey(),
+			ListenAddr: "tcp://" + testFreeAddr(t),
+		})
+	}
+	config.PrivValidatorThreshold = "config/priv_validator_threshold.json"
+	require.NoError(t, tf.Save(config.PrivValidatorThresholdFile()))
+
+	// Only the first two signers are running.
+	for i, share := range shares[:2] {
+		dialer := privval.DialTCPFn(tf.Signers[i].ListenAddr, 100*time.Millisecond, ed25519.GenPrivKey())
+		dialerEndpoint := privval.NewSignerDialerEndpoint(log.TestingLogger(), dialer)
+		dir := t.TempDir()
+		filePV := privval.NewFilePV(share, filepath.Join(dir, "key.json"), filepath.Join(dir, "state.json"))
+		signerServer := privval.NewSignerServer(dialerEndpoint, test.DefaultTestChainID, filePV)
+		go func() {
+			if err := signerServer.Start(); err != nil {
+				panic(err)
+			}
+		}()
+		defer signerServer.Stop() //nolint:errcheck // ignore for tests
+	}
+
+	n, err := DefaultNewNode(config, log.TestingLogger(), CliParams{}, nil)
+	require.NoError(t, err)
+	require.IsType(t, &privval.ThresholdSignerClient{}, n.PrivValidator())
+
+	blocksSub, err := n.EventBus().Subscribe(context.Background(), "node_test", types.EventQueryNewBlock)
+	require.NoError(t, err)
+	require.NoError(t, n.Start())
+	defer n.Stop() //nolint:errcheck // ignore for tests
+
+	// The second block holds the commit of the first one, signed by the split key.
+	for height := int64(1); height <= 2; height++ {
+		select {
+		case msg := <-blocksSub.Out():
+			block := msg.Data().(types.EventDataNewBlock).Block
+			require.Equal(t, height, block.Height)
+		case <-blocksSub.Canceled():
+			t.Fatal("blocksSub was canceled")
+		case <-time.After(20 * time.Second):
+			t.Fatal("timed out waiting for the node to produce a block")
+		}
+	}
+}
@@ -7,12 +7,15 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
 	_ "net/http/pprof" //nolint: gosec,gci // securely exposed on separate, optional port
 
 	_ "github.com/lib/pq" //nolint: gci // provide the psql db driver.
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
 
 	dbm "github.com/cometbft/cometbft-db"
 	abci "github.com/cometbft/cometbft/v2/abci/types"
@@ -22,13 +25,17 @@ import (
 	"github.com/cometbft/cometbft/v2/internal/blocksync"
 	cs "github.com/cometbft/cometbft/v2/internal/consensus"
 	"github.com/cometbft/cometbft/v2/internal/evidence"
+	"github.com/cometbft/cometbft/v2/internal/passphrase"
 	"github.com/cometbft/cometbft/v2/libs/log"
 	"github.com/cometbft/cometbft/v2/light"
 	mempl "github.com/cometbft/cometbft/v2/mempool"
 	"github.com/cometbft/cometbft/v2/p2p"
+	"github.com/cometbft/cometbft/v2/p2p/firewall"
 	na "github.com/cometbft/cometbft/v2/p2p/netaddr"
 	"github.com/cometbft/cometbft/v2/p2p/pex"
+	"github.com/cometbft/cometbft/v2/p2p/reputation"
 	"github.com/cometbft/cometbft/v2/p2p/transport"
+	"github.com/cometbft/cometbft/v2/p2p/transport/quic"
 	"github.com/cometbft/cometbft/v2/p2p/transport/tcp"
 	tcpconn "github.com/cometbft/cometbft/v2/p2p/transport/tcp/conn"
 	"github.com/cometbft/cometbft/v2/privval"
@@ -105,15 +112,19 @@ func DefaultNewNode(
 	cliParams CliParams,
 	keyGenF func() (crypto.PrivKey, error),
 ) (*Node, error) {
-	nodeKey, err := p2p.LoadOrGenNodeKey(config.NodeKeyFile())
+	// The passphrase is only read if one of the keys is encrypted.
+	keyPassphrase := passphrase.New(config.KeyPassphrasePath())
+
+	nodeKey, err := p2p.LoadOrGenNodeKeyWithPassphrase(config.NodeKeyFile(), keyPassphrase)
 	if err != nil {
 		return nil, ErrorLoadOrGenNodeKey{Err: err, NodeKeyFile: config.NodeKeyFile()}
 	}
 
-	pv, err := privval.LoadOrGenFilePV(
+	pv, err := privval.LoadOrGenFilePVWithPassphrase(
 		config.PrivValidatorKeyFile(),
 		config.PrivValidatorStateFile(),
 		keyGenF,
+		keyPassphrase,
 	)
 	if err != nil {
 		return nil, ErrorLoadOrGenFilePV{
@@ -122,6 +133,14 @@ func DefaultNewNode(
 			StateFile: config.PrivValidatorStateFile(),
 		}
 	}
+	// The next key is also loaded once it is staged while the node is running.
+	if err := pv.WatchNextKey(config.PrivValidatorNextKeyFile(), keyPassphrase); err != nil {
+		return nil, ErrorLoadOrGenFilePV{
+			Err:       err,
+			KeyFile:   config.PrivValidatorNextKeyFile(),
+			StateFile: config.PrivValidatorStateFile(),
+		}
+	}
 
 	return NewNodeWithCliParams(context.Background(), config,
 		pv,
@@ -176,6 +195,21 @@ func initDBs(config *cfg.Config, dbProvider cfg.DBProvider) (bsDB dbm.DB, stateD
 	return bsDB, stateDB, nil
 }
 
+// initSlashingProtection opens the slashing-protection database and attaches
+// it to privValidator, if it is a FilePV.
+func initSlashingProtection(config *cfg.Config, dbProvider cfg.DBProvider, privValidator types.PrivValidator) (*privval.SlashingProtectionDB, error) {
+	filePV, ok := privValidator.(*privval.FilePV)
+	if !ok {
+		return nil, nil
+	}
+	db, err := dbProvider(&cfg.DBContext{ID: privval.SlashingProtectionDBID, Config: config})
+	if err != nil {
+		return nil, err
+	}
+	filePV.SlashingProtection = privval.NewSlashingProtectionDB(db)
+	return filePV.SlashingProtection, nil
+}
+
 func createAndStartProxyAppConns(clientCreator proxy.ClientCreator, logger log.Logger, metrics *proxy.Metrics) (proxy.AppConns, error) {
 	proxyApp := proxy.NewAppConns(clientCreator, metrics)
 	proxyApp.SetLogger(logger.With("module", "proxy"))
@@ -273,6 +307,57 @@ func logNodeStartupInfo(state sm.State, pubKey crypto.PubKey, logger, consensusL
 	}
 }
 
+// createMempoolJournal creates the journal where the mempool persists its
+// transactions, or returns nil if the journal is disabled in the config.
+func createMempoolJournal(config *cfg.Config, dbProvider cfg.DBProvider, logger log.Logger) (*mempl.Journal, error) {
+	if !config.Mempool.Journal {
+		return nil, nil
+	}
+	journalDB, err := dbProvider(&cfg.DBContext{ID: "mempool", Config: config})
+	if err != nil {
+		return nil, err
+	}
+	journal, err := mempl.NewJournal(journalDB)
+	if err != nil {
+		return nil, err
+	}
+	journal.SetLogger(logger.With("module", "mempool"))
+	return journal, nil
+}
+
+// createNodeSnapshotter creates the store of the node snapshots served to peers
+// and the service taking them, or nils if node snapshots are disabled.
+func createNodeSnapshotter(
+	config *cfg.Config,
+	stateStore sm.Store,
+	blockStore *store.BlockStore,
+	logger log.Logger,
+) (*statesync.NodeSnapshotStore, *statesync.NodeSnapshotter, error) {
+	if config.StateSync.NodeSnapshotInterval == 0 {
+		return nil, nil, nil
+	}
+	nodeSnapshotStore, err := statesync.NewNodeSnapshotStore(filepath.Join(config.DBDir(), "snapshots"))
+	if err != nil {
+		return nil, nil, err
+	}
+	nodeSnapshotter := statesync.NewNodeSnapshotter(
+		nodeSnapshotStore,
+		stateStore,
+		blockStore,
+		config.StateSync.NodeSnapshotInterval,
+		config.StateSync.NodeSnapshotKeepRecent,
+		logger.With("module", "statesync"),
+	)
+	return nodeSnapshotStore, nodeSnapshotter, nil
+}
+
+// publishPendingTxs reports whether the mempool must publish PendingTx events,
+// which are also streamed by the gRPC mempool service.
+func publishPendingTxs(config *cfg.Config) bool {
+	return config.Mempool.ExperimentalPublishEventPendingTx ||
+		(config.GRPC.ListenAddress != "" && config.GRPC.MempoolService.Enabled)
+}
+
 // createMempoolAndMempoolReactor creates a mempool and a mempool reactor based on the config.
 func createMempoolAndMempoolReactor(
 	config *cfg.Config,
@@ -283,6 +368,7 @@ func createMempoolAndMempoolReactor(
 	memplMetrics *mempl.Metrics,
 	logger log.Logger,
 	appInfoResponse *abci.InfoResponse,
+	journal *mempl.Journal,
 ) (mempl.Mempool, mempoolReactor) {
 	switch config.Mempool.Type {
 	// allow empty string for backward compatibility
@@ -297,8 +383,17 @@ func createMempoolAndMempoolReactor(
 			mempl.WithMetrics(memplMetrics),
 			mempl.WithPreCheck(sm.TxPreCheck(state)),
 			mempl.WithPostCheck(sm.TxPostCheck(state)),
+			mempl.WithEvictedTxCallback(func(tx types.Tx, reason string) {
+				_ = eventBus.PublishEventEvictedTx(types.EventDataEvictedTx{
+					Tx:     tx,
+					Reason: reason,
+				})
+			}),
 		}
-		if config.Mempool.ExperimentalPublishEventPendingTx {
+		if journal != nil {
+			options = append(options, mempl.WithJournal(journal))
+		}
+		if publishPendingTxs(config) {
 			options = append(options, mempl.WithNewTxCallback(func(tx types.Tx) {
 				_ = eventBus.PublishEventPendingTx(types.EventDataPendingTx{
 					Tx: tx,
@@ -323,6 +418,47 @@ func createMempoolAndMempoolReactor(
 		}
 		reactor.SetLogger(logger)
 
+		return mp, reactor
+	case cfg.MempoolTypePriority:
+		logger = logger.With("module", "mempool")
+		options := []mempl.PriorityMempoolOption{
+			mempl.WithPriorityMetrics(memplMetrics),
+			mempl.WithPriorityPreCheck(sm.TxPreCheck(state)),
+			mempl.WithPriorityPostCheck(sm.TxPostCheck(state)),
+			mempl.WithPriorityEvictedTxCallback(func(tx types.Tx, reason string) {
+				_ = eventBus.PublishEventEvictedTx(types.EventDataEvictedTx{
+					Tx:     tx,
+					Reason: reason,
+				})
+			}),
+		}
+		if journal != nil {
+			options = append(options, mempl.WithPriorityJournal(journal))
+		}
+		if publishPendingTxs(config) {
+			options = append(options, mempl.WithPriorityNewTxCallback(func(tx types.Tx) {
+				_ = eventBus.PublishEventPendingTx(types.EventDataPendingTx{
+					Tx: tx,
+				})
+			}))
+		}
+		mp := mempl.NewPriorityMempool(
+			config.Mempool,
+			proxyApp.Mempool(),
+			state.LastBlockHeight,
+			options...,
+		)
+		mp.SetLogger(logger)
+		reactor := mempl.NewReactor(
+			config.Mempool,
+			mp,
+			waitSync,
+		)
+		if config.Consensus.WaitForTxs() {
+			mp.EnableTxsAvailable()
+		}
+		reactor.SetLogger(logger)
+
 		return mp, reactor
 	case cfg.MempoolTypeNop:
 		// Strictly speaking, there's no need to have a `mempl.NopMempoolReactor`, but
@@ -408,51 +544,63 @@ func createConsensusReactor(config *cfg.Config,
 	return consensusReactor, consensusState
 }
 
+// p2pTransport is the transport the node listens on for peers.
+type p2pTransport interface {
+	transport.Transport
+	Listen(addr na.NetAddr) error
+	Close() error
+	SetLogger(l log.Logger)
+}
+
+// createFirewall creates the p2p firewall and loads its rules. Unlike the
+// reputation of peers, the rules must be applied from the start, so a node
+// whose rules cannot be read does not start.
+func createFirewall(config *cfg.Config, p2pLogger log.Logger) (*firewall.Firewall, error) {
+	fw := firewall.NewFirewall(config.P2P.FirewallFile())
+	fw.SetLogger(p2pLogger.With("module", "firewall"))
+	if err := fw.Load(); err != nil {
+		return nil, fmt.Errorf("could not load firewall rules: %w", err)
+	}
+	return fw, nil
+}
+
 func createTransport(
 	config *cfg.Config,
 	nodeKey *p2p.NodeKey,
 	proxyApp proxy.AppConns,
+	p2pFirewall *firewall.Firewall,
 ) (
-	*tcp.MultiplexTransport,
+	p2pTransport,
 	[]p2p.PeerFilterFunc,
+	error,
 ) {
-	tcpConfig := tcpconn.DefaultMConnConfig()
-	tcpConfig.FlushThrottle = config.P2P.FlushThrottleTimeout
-	tcpConfig.SendRate = config.P2P.SendRate
-	tcpConfig.RecvRate = config.P2P.RecvRate
-	tcpConfig.MaxPacketMsgPayloadSize = config.P2P.MaxPacketMsgPayloadSize
-	tcpConfig.TestFuzz = config.P2P.TestFuzz
-	tcpConfig.TestFuzzConfig = config.P2P.TestFuzzConfig
 	var (
-		transport   = tcp.NewMultiplexTransport(*nodeKey, tcpConfig)
-		connFilters = []tcp.ConnFilterFunc{}
+		filterAddrs bool
 		peerFilters = []p2p.PeerFilterFunc{}
 	)
 
-	if !config.P2P.AllowDuplicateIP {
-		connFilters = append(connFilters, tcp.ConnDuplicateIPFilter())
+	channelSendRates, err := config.P2P.ChannelSendRateLimits()
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid p2p.channel_send_rates: %w", err)
+	}
+	channelRecvRates, err := config.P2P.ChannelRecvRateLimits()
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid p2p.channel_recv_rates: %w", err)
+	}
+	// With the tcp transport, the messages received above the rate are
+	// dropped, so only the channels whose messages are gossiped again can be
+	// limited. Losing consensus messages, for instance, could stall the node.
+	for chID := range channelRecvRates {
+		if chID != mempl.MempoolChannel && chID != evidence.EvidenceChannel {
+			return nil, nil, fmt.Errorf("invalid p2p.channel_recv_rates: channel %#x cannot be limited, "+
+				"only the mempool (%#x) and evidence (%#x) channels can", chID, mempl.MempoolChannel, evidence.EvidenceChannel)
+		}
 	}
 
 	// Filter peers by addr or pubkey with an ABCI query.
 	// If the query return code is OK, add peer.
 	if config.FilterPeers {
-		connFilters = append(
-			connFilters,
-			// ABCI query for address filtering.
-			func(_ tcp.ConnSet, c net.Conn, _ []net.IP) error {
-				res, err := proxyApp.Query().Query(context.TODO(), &abci.QueryRequest{
-					Path: "/p2p/filter/addr/" + c.RemoteAddr().String(),
-				})
-				if err != nil {
-					return err
-				}
-				if res.IsErr() {
-					return fmt.Errorf("error querying abci app: %v", res)
-				}
-
-				return nil
-			},
-		)
+		filterAddrs = true
 
 		peerFilters = append(
 			peerFilters,
@@ -473,19 +621,97 @@ func createTransport(
 		)
 	}
 
-	tcp.MultiplexTransportConnFilters(connFilters...)(transport)
+	// ABCI query for address filtering.
+	filterAddr := func(addr net.Addr) error {
+		res, err := proxyApp.Query().Query(context.TODO(), &abci.QueryRequest{
+			Path: "/p2p/filter/addr/" + addr.String(),
+		})
+		if err != nil {
+			return err
+		}
+		if res.IsErr() {
+			return fmt.Errorf("error querying abci app: %v", res)
+		}
+
+		return nil
+	}
 
 	// Limit the number of incoming connections.
 	max := config.P2P.MaxNumInboundPeers + len(splitAndTrimEmpty(config.P2P.UnconditionalPeerIDs, ",", " "))
+
+	if config.P2P.Transport == cfg.P2PTransportQUIC {
+		connFilters := []quic.ConnFilterFunc{}
+		if !config.P2P.AllowDuplicateIP {
+			connFilters = append(connFilters, quic.ConnDuplicateIPFilter())
+		}
+		if filterAddrs {
+			connFilters = append(connFilters, func(_ []net.IP, remoteAddr net.Addr) error {
+				return filterAddr(remoteAddr)
+			})
+		}
+		// Reject denied IPs before the handshake.
+		connFilters = append(connFilters, func(_ []net.IP, remoteAddr net.Addr) error {
+			if udpAddr, ok := remoteAddr.(*net.UDPAddr); ok {
+				return p2pFirewall.CheckIP(udpAddr.IP)
+			}
+			return nil
+		})
+
+		transport, err := quic.NewTransport(
+			*nodeKey,
+			quic.TransportConnFilters(connFilters...),
+			quic.TransportMaxIncomingConnections(max),
+			quic.TransportStreamRates(channelSendRates, channelRecvRates),
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+		return transport, peerFilters, nil
+	}
+
+	tcpConfig := tcpconn.DefaultMConnConfig()
+	tcpConfig.FlushThrottle = config.P2P.FlushThrottleTimeout
+	tcpConfig.SendRate = config.P2P.SendRate
+	tcpConfig.RecvRate = config.P2P.RecvRate
+	tcpConfig.StreamSendRates = channelSendRates
+	tcpConfig.StreamRecvRates = channelRecvRates
+	tcpConfig.MaxPacketMsgPayloadSize = config.P2P.MaxPacketMsgPayloadSize
+	tcpConfig.TestFuzz = config.P2P.TestFuzz
+	tcpConfig.TestFuzzConfig = config.P2P.TestFuzzConfig
+	var (
+		transport   = tcp.NewMultiplexTransport(*nodeKey, tcpConfig)
+		connFilters = []tcp.ConnFilterFunc{}
+	)
+
+	if !config.P2P.AllowDuplicateIP {
+		connFilters = append(connFilters, tcp.ConnDuplicateIPFilter())
+	}
+	if filterAddrs {
+		connFilters = append(connFilters, func(_ tcp.ConnSet, c net.Conn, _ []net.IP) error {
+			return filterAddr(c.RemoteAddr())
+		})
+	}
+	// Reject denied IPs before the handshake.
+	connFilters = append(connFilters, func(_ tcp.ConnSet, _ net.Conn, ips []net.IP) error {
+		for _, ip := range ips {
+			if err := p2pFirewall.CheckIP(ip); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	tcp.MultiplexTransportConnFilters(connFilters...)(transport)
 	tcp.MultiplexTransportMaxIncomingConnections(max)(transport)
 
-	return transport, peerFilters
+	return transport, peerFilters, nil
 }
 
 func createSwitch(config *cfg.Config,
 	transport transport.Transport,
 	p2pMetrics *p2p.Metrics,
 	peerFilters []p2p.PeerFilterFunc,
+	p2pFirewall *firewall.Firewall,
 	mempoolReactor p2p.Reactor,
 	bcReactor p2p.Reactor,
 	stateSyncReactor *statesync.Reactor,
@@ -495,11 +721,20 @@ func createSwitch(config *cfg.Config,
 	nodeKey *p2p.NodeKey,
 	p2pLogger log.Logger,
 ) *p2p.Switch {
+	// The reputation of peers is only advisory, so a node whose reputation
+	// file cannot be read starts afresh instead of failing.
+	peerReputation := reputation.NewStore(config.P2P.PeerReputationFile())
+	if err := peerReputation.Load(); err != nil {
+		p2pLogger.Error("Failed to load peer reputation", "file", config.P2P.PeerReputationFile(), "err", err)
+	}
+
 	sw := p2p.NewSwitch(
 		config.P2P,
 		transport,
 		p2p.WithMetrics(p2pMetrics),
 		p2p.SwitchPeerFilters(peerFilters...),
+		p2p.SwitchReputation(peerReputation),
+		p2p.SwitchFirewall(p2pFirewall),
 	)
 	sw.SetLogger(p2pLogger)
 	if config.Mempool.Type != cfg.MempoolTypeNop {
@@ -540,6 +775,7 @@ func createAddrBookAndSetOnSwitch(config *cfg.Config, sw *p2p.Switch,
 	}
 
 	sw.SetAddrBook(addrBook)
+	addrBook.SetPeerScorer(sw)
 
 	return addrBook, nil
 }
@@ -577,18 +813,19 @@ func startStateSync(
 ) (chan sm.State, chan error, error) {
 	ssR.Logger.Info("Starting state sync")
 
-	if stateProvider == nil {
+	trustOptions := light.TrustOptions{
+		Period: config.TrustPeriod,
+		Height: config.TrustHeight,
+		Hash:   config.TrustHashBytes(),
+	}
+	if stateProvider == nil && !config.UseP2P {
 		var err error
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 		stateProvider, err = statesync.NewLightClientStateProviderWithDBKeyVersion(
 			ctx,
 			state.ChainID, state.Version, state.InitialHeight,
-			config.RPCServers, light.TrustOptions{
-				Period: config.TrustPeriod,
-				Height: config.TrustHeight,
-				Hash:   config.TrustHashBytes(),
-			}, ssR.Logger.With("module", "light"),
+			config.RPCServers, trustOptions, ssR.Logger.With("module", "light"),
 			dbKeyLayoutVersion)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to set up light client state provider: %w", err)
@@ -599,7 +836,26 @@ func startStateSync(
 	stateCh := make(chan sm.State, 1)
 	errc := make(chan error, 1)
 	go func() {
-		newState, commit, err := ssR.Sync(stateProvider, config.MaxDiscoveryTime)
+		if stateProvider == nil {
+			// The p2p state provider needs connected peers, which are only
+			// available once the node has started.
+			ctx := context.Background()
+			if config.MaxDiscoveryTime > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, config.MaxDiscoveryTime)
+				defer cancel()
+			}
+			var err error
+			stateProvider, err = ssR.NewP2PStateProvider(ctx,
+				state.ChainID, state.Version, state.InitialHeight,
+				trustOptions, dbKeyLayoutVersion)
+			if err != nil {
+				errc <- fmt.Errorf("failed to set up p2p state provider: %w", err)
+				return
+			}
+		}
+
+		newState, commit, blocks, err := ssR.Sync(stateProvider, config.MaxDiscoveryTime)
 		if err != nil {
 			errc <- fmt.Errorf("statesync: %w", err)
 			return
@@ -611,6 +867,13 @@ func startStateSync(
 			return
 		}
 
+		// The recent blocks are only available if they were restored from a node snapshot.
+		err = statesync.SaveBlocks(blockStore, blocks, commit)
+		if err != nil {
+			errc <- fmt.Errorf("save blocks: %w", err)
+			return
+		}
+
 		err = blockStore.SaveSeenCommit(newState.LastBlockHeight, commit)
 		if err != nil {
 			errc <- fmt.Errorf("save seen commit: %w", err)
@@ -738,6 +1001,136 @@ func createAndStartPrivValidatorSocketClient(
 	return pvscWithRetries, nil
 }
 
+func createAndStartPrivValidatorFailoverClient(
+	listenAddrs []string,
+	chainID string,
+	highWaterMarkFile string,
+	logger log.Logger,
+) (types.PrivValidator, error) {
+	hwm, err := privval.LoadOrNewSignerHighWaterMark(highWaterMarkFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load signer high-water mark: %w", err)
+	}
+
+	signers := make([]types.PrivValidator, len(listenAddrs))
+	for i, listenAddr := range listenAddrs {
+		pve, err := privval.NewSignerListener(listenAddr, logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to start private validator: %w", err)
+		}
+		signers[i], err = privval.NewSignerClient(pve, chainID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to start private validator: %w", err)
+		}
+	}
+
+	return newPrivValidatorFailoverClient(signers, hwm, logger)
+}
+
+func createAndStartPrivValidatorThresholdClient(
+	config *cfg.Config,
+	chainID string,
+	logger log.Logger,
+) (types.PrivValidator, error) {
+	tf, err := privval.LoadThresholdSignerFile(config.PrivValidatorThresholdFile())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load threshold signer file: %w", err)
+	}
+
+	signers := make([]privval.ThresholdSigner, len(tf.Signers))
+	for i, s := range tf.Signers {
+		pve, err := privval.NewSignerListener(s.ListenAddr, logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to start private validator: %w", err)
+		}
+		client, err := privval.NewSignerClient(pve, chainID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to start private validator: %w", err)
+		}
+		signers[i] = privval.ThresholdSigner{Index: s.Index, PubKey: s.PubKey, Client: client}
+	}
+
+	metrics := privval.NopMetrics()
+	if config.Instrumentation.Prometheus {
+		metrics = privval.PrometheusMetrics(config.Instrumentation.Namespace, "chain_id", chainID)
+	}
+	ptc, err := privval.NewThresholdSignerClient(tf.PubKey, tf.Threshold, signers,
+		privval.ThresholdSignerClientLogger(logger.With("module", "privval")),
+		privval.ThresholdSignerClientMetrics(metrics))
+	if err != nil {
+		return nil, fmt.Errorf("failed to start private validator: %w", err)
+	}
+
+	// wait for a threshold of signers to connect
+	if err := ptc.Ping(); err != nil {
+		return nil, fmt.Errorf("can't reach the signers: %w", err)
+	}
+
+	return ptc, nil
+}
+
+func newPrivValidatorFailoverClient(
+	signers []types.PrivValidator,
+	hwm *privval.SignerHighWaterMark,
+	logger log.Logger,
+) (types.PrivValidator, error) {
+	const pingInterval = 3 * time.Second
+	pvfc, err := privval.NewFailoverSignerClient(signers, hwm,
+		privval.FailoverSignerClientLogger(logger.With("module", "privval")),
+		privval.FailoverSignerClientPingInterval(pingInterval))
+	if err != nil {
+		return nil, fmt.Errorf("failed to start private validator: %w", err)
+	}
+
+	// try to get a pubkey from private validate first time
+	_, err = pvfc.GetPubKey()
+	if err != nil {
+		return nil, fmt.Errorf("can't get pubkey: %w", err)
+	}
+
+	return pvfc, nil
+}
+
+func createPrivValidatorGRPCClient(
+	config *cfg.Config,
+	chainID string,
+	logger log.Logger,
+) (types.PrivValidator, error) {
+	creds := insecure.NewCredentials()
+	if config.IsPrivValidatorGRPCTLSEnabled() {
+		tlsConfig, err := privval.NewGRPCClientTLSConfig(config.PrivValidatorGRPCCertFile(),
+			config.PrivValidatorGRPCKeyFile(), config.PrivValidatorGRPCCAFile())
+		if err != nil {
+			return nil, err
+		}
+		creds = credentials.NewTLS(tlsConfig)
+	}
+
+	addrs := splitAndTrimEmpty(config.PrivValidatorGRPCAddr, ",", " ")
+	signers := make([]types.PrivValidator, len(addrs))
+	for i, addr := range addrs {
+		var err error
+		signers[i], err = privval.NewGRPCSignerClient(addr, chainID, creds)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(signers) > 1 {
+		hwm, err := privval.LoadOrNewSignerHighWaterMark(filepath.Join(config.DBDir(), privval.SignerHighWaterMarkFile))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load signer high-water mark: %w", err)
+		}
+		return newPrivValidatorFailoverClient(signers, hwm, logger)
+	}
+
+	// try to get a pubkey from private validate first time
+	if _, err := signers[0].GetPubKey(); err != nil {
+		return nil, fmt.Errorf("can't get pubkey: %w", err)
+	}
+	return signers[0], nil
+}
+
 // splitAndTrimEmpty slices s into all subslices separated by sep and returns a
 // slice of the string s with all leading and trailing Unicode code points
 // contained in cutset removed. If sep is empty, SplitAndTrim splits after each
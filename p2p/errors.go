@@ -57,6 +57,15 @@ func (ErrPeerRemoval) Error() string {
 	return "peer removal failed"
 }
 
+// ErrPeerBanned is raised when a peer is banned for bad behaviour.
+type ErrPeerBanned struct {
+	ID nodekey.ID
+}
+
+func (e ErrPeerBanned) Error() string {
+	return fmt.Sprintf("peer %v is banned for bad behaviour", e.ID)
+}
+
 // -------------------------------------------------------------------
 
 // ErrCurrentlyDialingOrExistingAddress indicates that we're currently
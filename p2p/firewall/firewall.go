@@ -0,0 +1,413 @@
+// Package firewall decides which peers the node may connect to.
+//
+// The Firewall has allow and deny rules by node ID and by IP range (CIDR).
+// Deny rules take precedence over allow rules. If there are no allow rules,
+// every peer that is not denied is allowed; otherwise, a peer must match an
+// allow rule, either by its node ID or by its IP.
+//
+// The rules are kept in a JSON file, which is reloaded when it changes, so
+// that operators can update them without restarting the node. They can also
+// be updated at runtime, in which case the file is rewritten.
+package firewall
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/cometbft/cometbft/v2/internal/tempfile"
+	"github.com/cometbft/cometbft/v2/libs/service"
+	cmtsync "github.com/cometbft/cometbft/v2/libs/sync"
+	"github.com/cometbft/cometbft/v2/p2p/internal/nodekey"
+	na "github.com/cometbft/cometbft/v2/p2p/netaddr"
+)
+
+// DefaultReloadInterval is the default interval at which the file of the
+// rules is checked for changes.
+const DefaultReloadInterval = 5 * time.Second
+
+// Rules are the allow and deny rules of the Firewall. CIDRs may also be
+// single IP addresses.
+type Rules struct {
+	AllowIDs   []nodekey.ID `json:"allow_ids,omitempty"`
+	AllowCIDRs []string     `json:"allow_cidrs,omitempty"`
+	DenyIDs    []nodekey.ID `json:"deny_ids,omitempty"`
+	DenyCIDRs  []string     `json:"deny_cidrs,omitempty"`
+}
+
+// IsEmpty returns true if there are no rules.
+func (r Rules) IsEmpty() bool {
+	return len(r.AllowIDs) == 0 && len(r.AllowCIDRs) == 0 &&
+		len(r.DenyIDs) == 0 && len(r.DenyCIDRs) == 0
+}
+
+// ValidateBasic checks that the node IDs and CIDRs are valid.
+func (r Rules) ValidateBasic() error {
+	for _, ids := range [][]nodekey.ID{r.AllowIDs, r.DenyIDs} {
+		for _, id := range ids {
+			if err := na.ValidateID(id); err != nil {
+				return na.ErrInvalidPeerID{ID: id, Source: err}
+			}
+		}
+	}
+	for _, cidrs := range [][]string{r.AllowCIDRs, r.DenyCIDRs} {
+		for _, cidr := range cidrs {
+			if _, err := parseCIDR(cidr); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// merge returns the union of r and other.
+func (r Rules) merge(other Rules) Rules {
+	return Rules{
+		AllowIDs:   union(r.AllowIDs, other.AllowIDs),
+		AllowCIDRs: union(r.AllowCIDRs, other.AllowCIDRs),
+		DenyIDs:    union(r.DenyIDs, other.DenyIDs),
+		DenyCIDRs:  union(r.DenyCIDRs, other.DenyCIDRs),
+	}
+}
+
+// subtract returns the rules of r which are not in other.
+func (r Rules) subtract(other Rules) Rules {
+	return Rules{
+		AllowIDs:   difference(r.AllowIDs, other.AllowIDs),
+		AllowCIDRs: difference(r.AllowCIDRs, other.AllowCIDRs),
+		DenyIDs:    difference(r.DenyIDs, other.DenyIDs),
+		DenyCIDRs:  difference(r.DenyCIDRs, other.DenyCIDRs),
+	}
+}
+
+// ErrDenied is returned when the firewall denies a peer.
+type ErrDenied struct {
+	ID     nodekey.ID
+	IP     net.IP
+	Reason string
+}
+
+func (e ErrDenied) Error() string {
+	return fmt.Sprintf("peer %v (%v) denied by firewall: %s", e.ID, e.IP, e.Reason)
+}
+
+// compiledRules are the Rules in a form suitable for lookups.
+type compiledRules struct {
+	allowIDs   map[nodekey.ID]struct{}
+	allowNets  []*net.IPNet
+	denyIDs    map[nodekey.ID]struct{}
+	denyNets   []*net.IPNet
+	allowByIDs bool
+}
+
+// Firewall applies allow and deny rules to peers. It is safe for concurrent
+// use.
+type Firewall struct {
+	service.BaseService
+
+	mtx      cmtsync.RWMutex
+	rules    Rules
+	compiled compiledRules
+	modTime  time.Time
+
+	filePath       string
+	reloadInterval time.Duration
+	onUpdate       func()
+}
+
+// Option sets an optional parameter on the Firewall.
+type Option func(*Firewall)
+
+// WithReloadInterval sets the interval at which the file of the rules is
+// checked for changes.
+func WithReloadInterval(interval time.Duration) Option {
+	return func(fw *Firewall) { fw.reloadInterval = interval }
+}
+
+// NewFirewall returns a new Firewall without rules, whose rules are kept in
+// filePath. If filePath is empty, the rules are kept in memory only.
+func NewFirewall(filePath string, options ...Option) *Firewall {
+	fw := &Firewall{
+		filePath:       filePath,
+		reloadInterval: DefaultReloadInterval,
+	}
+	fw.compiled, _ = compile(Rules{})
+	fw.BaseService = *service.NewBaseService(nil, "Firewall", fw)
+	for _, option := range options {
+		option(fw)
+	}
+	return fw
+}
+
+// OnUpdate sets a function, which is called after the rules changed.
+func (fw *Firewall) OnUpdate(fn func()) {
+	fw.mtx.Lock()
+	defer fw.mtx.Unlock()
+	fw.onUpdate = fn
+}
+
+// OnStart implements service.Service. It starts watching the file of the
+// rules for changes.
+func (fw *Firewall) OnStart() error {
+	if fw.filePath != "" && fw.reloadInterval > 0 {
+		go fw.reloadRoutine()
+	}
+	return nil
+}
+
+// Load loads the rules from the file of the Firewall. A missing file means
+// there are no rules.
+func (fw *Firewall) Load() error {
+	if fw.filePath == "" {
+		return nil
+	}
+
+	var (
+		rules   Rules
+		modTime time.Time
+	)
+	info, err := os.Stat(fw.filePath)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return err
+	default:
+		modTime = info.ModTime()
+		bz, err := os.ReadFile(fw.filePath)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(bz, &rules); err != nil {
+			return fmt.Errorf("reading firewall rules from %s: %w", fw.filePath, err)
+		}
+	}
+
+	compiled, err := compile(rules)
+	if err != nil {
+		return fmt.Errorf("invalid firewall rules in %s: %w", fw.filePath, err)
+	}
+
+	fw.mtx.Lock()
+	fw.rules, fw.compiled, fw.modTime = rules, compiled, modTime
+	onUpdate := fw.onUpdate
+	fw.mtx.Unlock()
+
+	if onUpdate != nil {
+		onUpdate()
+	}
+	return nil
+}
+
+// Rules returns the current rules.
+func (fw *Firewall) Rules() Rules {
+	fw.mtx.RLock()
+	defer fw.mtx.RUnlock()
+	return fw.rules.merge(Rules{})
+}
+
+// AddRules adds rules to the current rules, and saves them.
+func (fw *Firewall) AddRules(rules Rules) error {
+	if err := rules.ValidateBasic(); err != nil {
+		return err
+	}
+	return fw.update(func(current Rules) Rules { return current.merge(rules) })
+}
+
+// RemoveRules removes rules from the current rules, and saves them.
+func (fw *Firewall) RemoveRules(rules Rules) error {
+	return fw.update(func(current Rules) Rules { return current.subtract(rules) })
+}
+
+// SetRules replaces the current rules, and saves them.
+func (fw *Firewall) SetRules(rules Rules) error {
+	return fw.update(func(Rules) Rules { return rules })
+}
+
+func (fw *Firewall) update(fn func(Rules) Rules) error {
+	fw.mtx.Lock()
+	rules := fn(fw.rules)
+	compiled, err := compile(rules)
+	if err != nil {
+		fw.mtx.Unlock()
+		return err
+	}
+	if err := fw.save(rules); err != nil {
+		fw.mtx.Unlock()
+		return err
+	}
+	fw.rules, fw.compiled = rules, compiled
+	onUpdate := fw.onUpdate
+	fw.mtx.Unlock()
+
+	if onUpdate != nil {
+		onUpdate()
+	}
+	return nil
+}
+
+// save writes rules to the file of the Firewall. It must be called with the
+// mutex held.
+func (fw *Firewall) save(rules Rules) error {
+	if fw.filePath == "" {
+		return nil
+	}
+	bz, err := json.MarshalIndent(rules, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := tempfile.WriteFileAtomic(fw.filePath, bz, 0o644); err != nil {
+		return err
+	}
+	// Do not reload our own changes.
+	if info, err := os.Stat(fw.filePath); err == nil {
+		fw.modTime = info.ModTime()
+	}
+	return nil
+}
+
+// Check returns ErrDenied if the peer with the given ID and IP is not
+// allowed. Either of them may be unknown (empty).
+func (fw *Firewall) Check(id nodekey.ID, ip net.IP) error {
+	fw.mtx.RLock()
+	defer fw.mtx.RUnlock()
+	c := &fw.compiled
+
+	if _, ok := c.denyIDs[id]; ok && id != "" {
+		return ErrDenied{ID: id, IP: ip, Reason: "node ID is denied"}
+	}
+	if ip != nil && containsIP(c.denyNets, ip) {
+		return ErrDenied{ID: id, IP: ip, Reason: "IP is denied"}
+	}
+
+	if len(c.allowIDs) == 0 && len(c.allowNets) == 0 {
+		return nil
+	}
+	if _, ok := c.allowIDs[id]; ok && id != "" {
+		return nil
+	}
+	if ip != nil && containsIP(c.allowNets, ip) {
+		return nil
+	}
+	if id == "" && c.allowByIDs {
+		// The peer may still be allowed by its ID, once known.
+		return nil
+	}
+	return ErrDenied{ID: id, IP: ip, Reason: "not in the allow list"}
+}
+
+// CheckIP returns ErrDenied if peers with the given IP are denied regardless
+// of their node ID. It is meant to reject connections before the node ID of
+// the peer is known.
+func (fw *Firewall) CheckIP(ip net.IP) error {
+	return fw.Check("", ip)
+}
+
+// reloadRoutine reloads the rules when their file changes, until the
+// Firewall is stopped.
+func (fw *Firewall) reloadRoutine() {
+	ticker := time.NewTicker(fw.reloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			var modTime time.Time
+			if info, err := os.Stat(fw.filePath); err == nil {
+				modTime = info.ModTime()
+			}
+			fw.mtx.RLock()
+			changed := !modTime.Equal(fw.modTime)
+			fw.mtx.RUnlock()
+			if !changed {
+				continue
+			}
+
+			if err := fw.Load(); err != nil {
+				fw.Logger.Error("Failed to reload firewall rules. Keeping the previous rules", "err", err)
+				// Do not retry until the file changes again.
+				fw.mtx.Lock()
+				fw.modTime = modTime
+				fw.mtx.Unlock()
+				continue
+			}
+			fw.Logger.Info("Reloaded firewall rules", "file", fw.filePath)
+		case <-fw.Quit():
+			return
+		}
+	}
+}
+
+func compile(rules Rules) (compiledRules, error) {
+	if err := rules.ValidateBasic(); err != nil {
+		return compiledRules{}, err
+	}
+	c := compiledRules{
+		allowIDs:   make(map[nodekey.ID]struct{}, len(rules.AllowIDs)),
+		denyIDs:    make(map[nodekey.ID]struct{}, len(rules.DenyIDs)),
+		allowByIDs: len(rules.AllowIDs) > 0,
+	}
+	for _, id := range rules.AllowIDs {
+		c.allowIDs[id] = struct{}{}
+	}
+	for _, id := range rules.DenyIDs {
+		c.denyIDs[id] = struct{}{}
+	}
+	for _, cidr := range rules.AllowCIDRs {
+		ipNet, _ := parseCIDR(cidr)
+		c.allowNets = append(c.allowNets, ipNet)
+	}
+	for _, cidr := range rules.DenyCIDRs {
+		ipNet, _ := parseCIDR(cidr)
+		c.denyNets = append(c.denyNets, ipNet)
+	}
+	return c, nil
+}
+
+// parseCIDR parses a CIDR, or a single IP address.
+func parseCIDR(s string) (*net.IPNet, error) {
+	if ip := net.ParseIP(s); ip != nil {
+		bits := 8 * net.IPv6len
+		if ip4 := ip.To4(); ip4 != nil {
+			ip, bits = ip4, 8*net.IPv4len
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+	}
+	_, ipNet, err := net.ParseCIDR(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CIDR %q: %w", s, err)
+	}
+	return ipNet, nil
+}
+
+func containsIP(nets []*net.IPNet, ip net.IP) bool {
+	for _, ipNet := range nets {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func union[T comparable](a, b []T) []T {
+	res := slices.Clone(a)
+	for _, v := range b {
+		if !slices.Contains(res, v) {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+func difference[T comparable](a, b []T) []T {
+	var res []T
+	for _, v := range a {
+		if !slices.Contains(b, v) {
+			res = append(res, v)
+		}
+	}
+	return res
+}
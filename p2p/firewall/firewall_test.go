@@ -0,0 +1,127 @@
+package firewall
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/v2/p2p/internal/nodekey"
+)
+
+var (
+	id1 = nodekey.ID("1111111111111111111111111111111111111111")
+	id2 = nodekey.ID("2222222222222222222222222222222222222222")
+)
+
+func TestFirewallCheck(t *testing.T) {
+	var (
+		ip1 = net.ParseIP("10.0.0.1")
+		ip2 = net.ParseIP("192.168.1.1")
+		ip3 = net.ParseIP("2001:db8::1")
+	)
+
+	testCases := []struct {
+		name    string
+		rules   Rules
+		id      nodekey.ID
+		ip      net.IP
+		allowed bool
+	}{
+		{"no rules", Rules{}, id1, ip1, true},
+		{"denied ID", Rules{DenyIDs: []nodekey.ID{id1}}, id1, ip1, false},
+		{"other ID", Rules{DenyIDs: []nodekey.ID{id1}}, id2, ip1, true},
+		{"denied CIDR", Rules{DenyCIDRs: []string{"10.0.0.0/8"}}, id1, ip1, false},
+		{"denied single IP", Rules{DenyCIDRs: []string{"10.0.0.1"}}, id1, ip1, false},
+		{"denied IPv6", Rules{DenyCIDRs: []string{"2001:db8::/32"}}, id1, ip3, false},
+		{"outside denied CIDR", Rules{DenyCIDRs: []string{"10.0.0.0/8"}}, id1, ip2, true},
+		{"allowed ID", Rules{AllowIDs: []nodekey.ID{id1}}, id1, ip1, true},
+		{"not allowed ID", Rules{AllowIDs: []nodekey.ID{id1}}, id2, ip1, false},
+		{"allowed CIDR", Rules{AllowCIDRs: []string{"192.168.0.0/16"}}, id1, ip2, true},
+		{"not allowed CIDR", Rules{AllowCIDRs: []string{"192.168.0.0/16"}}, id1, ip1, false},
+		{
+			"deny takes precedence",
+			Rules{AllowIDs: []nodekey.ID{id1}, DenyCIDRs: []string{"10.0.0.0/8"}},
+			id1, ip1, false,
+		},
+		{"unknown ID allowed by IDs", Rules{AllowIDs: []nodekey.ID{id1}}, "", ip1, true},
+		{"unknown ID not allowed by CIDR", Rules{AllowCIDRs: []string{"192.168.0.0/16"}}, "", ip1, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fw := NewFirewall("")
+			require.NoError(t, fw.SetRules(tc.rules))
+
+			err := fw.Check(tc.id, tc.ip)
+			if tc.allowed {
+				require.NoError(t, err)
+			} else {
+				require.ErrorAs(t, err, &ErrDenied{})
+			}
+		})
+	}
+}
+
+func TestFirewallInvalidRules(t *testing.T) {
+	fw := NewFirewall("")
+	require.Error(t, fw.AddRules(Rules{DenyCIDRs: []string{"10.0.0.0/33"}}))
+	require.Error(t, fw.AddRules(Rules{DenyIDs: []nodekey.ID{"invalid"}}))
+	assert.True(t, fw.Rules().IsEmpty())
+}
+
+func TestFirewallAddRemoveRules(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "firewall.json")
+	fw := NewFirewall(filePath)
+
+	var updates atomic.Int32
+	fw.OnUpdate(func() { updates.Add(1) })
+
+	require.NoError(t, fw.AddRules(Rules{DenyIDs: []nodekey.ID{id1}, DenyCIDRs: []string{"10.0.0.0/8"}}))
+	require.NoError(t, fw.AddRules(Rules{DenyIDs: []nodekey.ID{id1, id2}}))
+	assert.Equal(t, Rules{DenyIDs: []nodekey.ID{id1, id2}, DenyCIDRs: []string{"10.0.0.0/8"}}, fw.Rules())
+
+	require.NoError(t, fw.RemoveRules(Rules{DenyIDs: []nodekey.ID{id1}}))
+	assert.Equal(t, Rules{DenyIDs: []nodekey.ID{id2}, DenyCIDRs: []string{"10.0.0.0/8"}}, fw.Rules())
+	assert.EqualValues(t, 3, updates.Load())
+
+	// The rules were saved.
+	loaded := NewFirewall(filePath)
+	require.NoError(t, loaded.Load())
+	assert.Equal(t, fw.Rules(), loaded.Rules())
+
+	// Loading a missing file is not an error.
+	require.NoError(t, NewFirewall(filepath.Join(t.TempDir(), "missing.json")).Load())
+}
+
+func TestFirewallReloadsFile(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "firewall.json")
+	fw := NewFirewall(filePath, WithReloadInterval(10*time.Millisecond))
+	require.NoError(t, fw.Load())
+	require.NoError(t, fw.Start())
+	t.Cleanup(func() { _ = fw.Stop() })
+
+	require.NoError(t, fw.Check(id1, nil))
+
+	bz := []byte(`{"deny_ids": ["` + string(id1) + `"]}`)
+	require.NoError(t, os.WriteFile(filePath, bz, 0o600))
+	require.Eventually(t, func() bool {
+		return fw.Check(id1, nil) != nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Invalid rules are ignored.
+	require.NoError(t, os.WriteFile(filePath, []byte(`{"deny_cidrs": ["invalid"]}`), 0o600))
+	time.Sleep(100 * time.Millisecond)
+	require.Error(t, fw.Check(id1, nil))
+
+	// Removing the file removes the rules.
+	require.NoError(t, os.Remove(filePath))
+	require.Eventually(t, func() bool {
+		return fw.Check(id1, nil) == nil
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -7,8 +7,10 @@ import (
 	"os"
 
 	"github.com/cometbft/cometbft/v2/crypto"
+	"github.com/cometbft/cometbft/v2/crypto/armor"
 	"github.com/cometbft/cometbft/v2/crypto/ed25519"
 	cmtos "github.com/cometbft/cometbft/v2/internal/os"
+	"github.com/cometbft/cometbft/v2/internal/tempfile"
 	cmtjson "github.com/cometbft/cometbft/v2/libs/json"
 )
 
@@ -19,9 +21,12 @@ type ID = string
 // TODO: support other length addresses ?
 const IDByteLength = crypto.AddressSize
 
+// ArmorBlockType is the type of the armor block in which node keys encrypted
+// with a passphrase are stored.
+const ArmorBlockType = "COMETBFT NODE KEY"
+
 // ------------------------------------------------------------------------------
 // Persistent peer ID
-// TODO: encrypt on disk
 
 // NodeKey is the persistent peer key.
 // It contains the nodes private key for authentication.
@@ -48,8 +53,15 @@ func PubKeyToID(pubKey crypto.PubKey) ID {
 // LoadOrGen attempts to load the NodeKey from the given filePath. If
 // the file does not exist, it generates and saves a new NodeKey.
 func LoadOrGen(filePath string) (*NodeKey, error) {
+	return LoadOrGenWithPassphrase(filePath, nil)
+}
+
+// LoadOrGenWithPassphrase is like LoadOrGen, but decrypts the NodeKey with the
+// passphrase returned by passphrase if it is encrypted. New keys are saved
+// unencrypted.
+func LoadOrGenWithPassphrase(filePath string, passphrase armor.PassphraseFunc) (*NodeKey, error) {
 	if cmtos.FileExists(filePath) {
-		nodeKey, err := Load(filePath)
+		nodeKey, err := LoadWithPassphrase(filePath, passphrase)
 		if err != nil {
 			return nil, err
 		}
@@ -68,12 +80,23 @@ func LoadOrGen(filePath string) (*NodeKey, error) {
 	return nodeKey, nil
 }
 
-// Load loads NodeKey located in filePath.
+// Load loads NodeKey located in filePath. It fails if the NodeKey is
+// encrypted.
 func Load(filePath string) (*NodeKey, error) {
-	jsonBytes, err := os.ReadFile(filePath)
+	return LoadWithPassphrase(filePath, nil)
+}
+
+// LoadWithPassphrase loads NodeKey located in filePath, decrypting it with the
+// passphrase returned by passphrase if it is encrypted.
+func LoadWithPassphrase(filePath string, passphrase armor.PassphraseFunc) (*NodeKey, error) {
+	bz, err := os.ReadFile(filePath)
 	if err != nil {
 		return nil, err
 	}
+	jsonBytes, _, err := armor.DecryptIfArmored(bz, ArmorBlockType, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("error reading node key from %v: %w", filePath, err)
+	}
 	nodeKey := new(NodeKey)
 	err = cmtjson.Unmarshal(jsonBytes, nodeKey)
 	if err != nil {
@@ -95,6 +118,20 @@ func (nk *NodeKey) SaveAs(filePath string) error {
 	return nil
 }
 
+// SaveEncryptedAs persists the NodeKey to filePath, encrypted with
+// passphrase in an armor block.
+func (nk *NodeKey) SaveEncryptedAs(filePath string, passphrase []byte) error {
+	jsonBytes, err := cmtjson.Marshal(nk)
+	if err != nil {
+		return err
+	}
+	armored, err := armor.EncryptArmor(ArmorBlockType, jsonBytes, passphrase, armor.KDFArgon2id)
+	if err != nil {
+		return err
+	}
+	return tempfile.WriteFileAtomic(filePath, []byte(armored), 0o600)
+}
+
 // ------------------------------------------------------------------------------
 
 // MakePoWTarget returns the big-endian encoding of 2^(targetBits - difficulty) - 1.
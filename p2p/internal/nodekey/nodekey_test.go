@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/cometbft/cometbft/v2/crypto/armor"
 	"github.com/cometbft/cometbft/v2/crypto/ed25519"
 	cmtrand "github.com/cometbft/cometbft/v2/internal/rand"
 )
@@ -53,6 +54,26 @@ func TestNodeKey_SaveAs(t *testing.T) {
 	assert.FileExists(t, filePath)
 }
 
+func TestNodeKey_SaveEncryptedAs(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "node_key.json")
+	passphrase := func() ([]byte, error) { return []byte("passphrase"), nil }
+
+	nodeKey := &NodeKey{PrivKey: ed25519.GenPrivKey()}
+	require.NoError(t, nodeKey.SaveEncryptedAs(filePath, []byte("passphrase")))
+
+	_, err := Load(filePath)
+	require.ErrorIs(t, err, armor.ErrPassphraseRequired)
+	_, err = LoadWithPassphrase(filePath, func() ([]byte, error) { return []byte("wrong"), nil })
+	require.ErrorIs(t, err, armor.ErrDecrypt)
+
+	nodeKey2, err := LoadWithPassphrase(filePath, passphrase)
+	require.NoError(t, err)
+	assert.Equal(t, nodeKey, nodeKey2)
+	nodeKey2, err = LoadOrGenWithPassphrase(filePath, passphrase)
+	require.NoError(t, err)
+	assert.Equal(t, nodeKey, nodeKey2)
+}
+
 // ----------------------------------------------------------
 
 func padBytes(bz []byte) []byte {
@@ -38,6 +38,36 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "message_send_bytes_total",
 			Help:      "Number of bytes of each message type sent.",
 		}, append(labels, "message_type")).With(labelsAndValues...),
+		PeerSendBytesTotal: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_send_bytes_total",
+			Help:      "Number of bytes sent to a given peer, per channel.",
+		}, append(labels, "peer_id", "channel_id")).With(labelsAndValues...),
+		PeerReceiveBytesTotal: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_receive_bytes_total",
+			Help:      "Number of bytes received from a given peer, per channel.",
+		}, append(labels, "peer_id", "channel_id")).With(labelsAndValues...),
+		PeerSendMessagesTotal: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_send_messages_total",
+			Help:      "Number of messages sent to a given peer, per channel.",
+		}, append(labels, "peer_id", "channel_id")).With(labelsAndValues...),
+		PeerReceiveMessagesTotal: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_receive_messages_total",
+			Help:      "Number of messages received from a given peer, per channel.",
+		}, append(labels, "peer_id", "channel_id")).With(labelsAndValues...),
+		PeerDroppedMessagesTotal: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_dropped_messages_total",
+			Help:      "Number of messages received from a given peer, which were dropped because the channel exceeded its receive rate.",
+		}, append(labels, "peer_id", "channel_id")).With(labelsAndValues...),
 		RecvRateLimiterDelay: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
 			Namespace: namespace,
 			Subsystem: MetricsSubsystem,
@@ -50,6 +80,24 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "send_rate_limiter_delay",
 			Help:      "Time in seconds spent sleeping by the send rate limiter",
 		}, append(labels, "peer_id")).With(labelsAndValues...),
+		PeerScore: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_score",
+			Help:      "Reputation score of a given peer.",
+		}, append(labels, "peer_id")).With(labelsAndValues...),
+		PeerBehaviourReports: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_behaviour_reports",
+			Help:      "Number of behaviour reports of peers, by reason.",
+		}, append(labels, "reason")).With(labelsAndValues...),
+		PeerBans: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_bans",
+			Help:      "Number of peers banned for bad behaviour.",
+		}, labels).With(labelsAndValues...),
 	}
 }
 
@@ -59,7 +107,15 @@ func NopMetrics() *Metrics {
 		PeerPendingSendBytes:     discard.NewGauge(),
 		MessageReceiveBytesTotal: discard.NewCounter(),
 		MessageSendBytesTotal:    discard.NewCounter(),
+		PeerSendBytesTotal:       discard.NewCounter(),
+		PeerReceiveBytesTotal:    discard.NewCounter(),
+		PeerSendMessagesTotal:    discard.NewCounter(),
+		PeerReceiveMessagesTotal: discard.NewCounter(),
+		PeerDroppedMessagesTotal: discard.NewCounter(),
 		RecvRateLimiterDelay:     discard.NewCounter(),
 		SendRateLimiterDelay:     discard.NewCounter(),
+		PeerScore:                discard.NewGauge(),
+		PeerBehaviourReports:     discard.NewCounter(),
+		PeerBans:                 discard.NewCounter(),
 	}
 }
@@ -32,10 +32,27 @@ type Metrics struct {
 	MessageReceiveBytesTotal metrics.Counter `metrics_labels:"message_type"`
 	// Number of bytes of each message type sent.
 	MessageSendBytesTotal metrics.Counter `metrics_labels:"message_type"`
+	// Number of bytes sent to a given peer, per channel.
+	PeerSendBytesTotal metrics.Counter `metrics_labels:"peer_id,channel_id"`
+	// Number of bytes received from a given peer, per channel.
+	PeerReceiveBytesTotal metrics.Counter `metrics_labels:"peer_id,channel_id"`
+	// Number of messages sent to a given peer, per channel.
+	PeerSendMessagesTotal metrics.Counter `metrics_labels:"peer_id,channel_id"`
+	// Number of messages received from a given peer, per channel.
+	PeerReceiveMessagesTotal metrics.Counter `metrics_labels:"peer_id,channel_id"`
+	// Number of messages received from a given peer, which were dropped
+	// because the channel exceeded its receive rate.
+	PeerDroppedMessagesTotal metrics.Counter `metrics_labels:"peer_id,channel_id"`
 	// Time in seconds spent sleeping by the receive rate limiter
 	RecvRateLimiterDelay metrics.Counter `metrics_labels:"peer_id"`
 	// Time in seconds spent sleeping by the send rate limiter
 	SendRateLimiterDelay metrics.Counter `metrics_labels:"peer_id"`
+	// Reputation score of a given peer.
+	PeerScore metrics.Gauge `metrics_labels:"peer_id"`
+	// Number of behaviour reports of peers, by reason.
+	PeerBehaviourReports metrics.Counter `metrics_labels:"reason"`
+	// Number of peers banned for bad behaviour.
+	PeerBans metrics.Counter
 }
 
 type peerPendingMetricsCache struct {
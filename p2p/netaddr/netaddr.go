@@ -36,16 +36,23 @@ func IDAddrString(id nodekey.ID, protocolHostPort string) string {
 	return fmt.Sprintf("%s@%s", id, hostPort)
 }
 
-// New returns a new address using the provided TCP
-// address. When testing, other net.Addr (except TCP) will result in
-// using 0.0.0.0:0. When normal run, other net.Addr (except TCP) will
-// panic. Panics if ID is invalid.
+// New returns a new address using the provided TCP or UDP (QUIC)
+// address. When testing, other net.Addr will result in using 0.0.0.0:0.
+// When normal run, other net.Addr will panic. Panics if ID is invalid.
 // TODO: socks proxies?
 func New(id nodekey.ID, addr net.Addr) *NetAddr {
-	tcpAddr, ok := addr.(*net.TCPAddr)
-	if !ok {
+	var (
+		ip   net.IP
+		port int
+	)
+	switch addr := addr.(type) {
+	case *net.TCPAddr:
+		ip, port = addr.IP, addr.Port
+	case *net.UDPAddr:
+		ip, port = addr.IP, addr.Port
+	default:
 		if flag.Lookup("test.v") == nil { // normal run
-			panic(fmt.Sprintf("Only TCPAddrs are supported. Got: %v", addr))
+			panic(fmt.Sprintf("Only TCPAddrs and UDPAddrs are supported. Got: %v", addr))
 		}
 		// in testing
 		netAddr := NewFromIPPort(net.IP("127.0.0.1"), 0)
@@ -57,9 +64,7 @@ func New(id nodekey.ID, addr net.Addr) *NetAddr {
 		panic(fmt.Sprintf("Invalid ID %v: %v (addr: %v)", id, err, addr))
 	}
 
-	ip := tcpAddr.IP
-	port := uint16(tcpAddr.Port)
-	na := NewFromIPPort(ip, port)
+	na := NewFromIPPort(ip, uint16(port))
 	na.ID = id
 	return na
 }
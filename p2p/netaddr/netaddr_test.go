@@ -42,9 +42,12 @@ func TestNew(t *testing.T) {
 	addr := New("deadbeefdeadbeefdeadbeefdeadbeefdeadbeef", tcpAddr)
 	assert.Equal(t, "deadbeefdeadbeefdeadbeefdeadbeefdeadbeef@127.0.0.1:8080", addr.String())
 
+	udpAddr := New("deadbeefdeadbeefdeadbeefdeadbeefdeadbeef", &net.UDPAddr{IP: net.ParseIP("127.0.0.1"), Port: 8000})
+	assert.Equal(t, "deadbeefdeadbeefdeadbeefdeadbeefdeadbeef@127.0.0.1:8000", udpAddr.String())
+
 	assert.NotPanics(t, func() {
-		New("", &net.UDPAddr{IP: net.ParseIP("127.0.0.1"), Port: 8000})
-	}, "Calling New with UDPAddr should not panic in testing")
+		New("", &net.UnixAddr{Name: "/tmp/sock", Net: "unix"})
+	}, "Calling New with UnixAddr should not panic in testing")
 }
 
 func TestNewFromString(t *testing.T) {
@@ -17,6 +17,7 @@ import (
 	"github.com/cometbft/cometbft/v2/p2p/internal/nodekey"
 	na "github.com/cometbft/cometbft/v2/p2p/netaddr"
 	"github.com/cometbft/cometbft/v2/p2p/transport"
+	"github.com/cometbft/cometbft/v2/p2p/transport/quic"
 	tcpconn "github.com/cometbft/cometbft/v2/p2p/transport/tcp/conn"
 	"github.com/cometbft/cometbft/v2/types"
 )
@@ -71,6 +72,18 @@ type Peer interface {
 
 // ----------------------------------------------------------
 
+// receivingConn is a connection, which passes the received messages to a
+// callback once started, such as tcpconn.MConnection and quic.Conn.
+type receivingConn interface {
+	OnReceive(fn tcpconn.OnReceiveFn)
+	Start() error
+}
+
+var (
+	_ receivingConn = (*tcpconn.MConnection)(nil)
+	_ receivingConn = (*quic.Conn)(nil)
+)
+
 // peerConn contains the raw connection and its config.
 type peerConn struct {
 	outbound       bool
@@ -189,8 +202,8 @@ func newPeer(
 		option(p)
 	}
 
-	if mconn, ok := p.peerConn.Conn.(*tcpconn.MConnection); ok {
-		mconn.OnReceive(p.onReceive)
+	if rc, ok := p.peerConn.Conn.(receivingConn); ok {
+		rc.OnReceive(p.onReceive)
 	}
 
 	return p
@@ -279,11 +292,12 @@ func (p *peer) OnStart() error {
 		p.streams[streamID] = stream
 	}
 
-	// Start the MConnection if it's an MConnection.
-	// NOTE: we do not start the MConnection until all the streams are registered.
-	if mconn, ok := p.peerConn.Conn.(*tcpconn.MConnection); ok {
-		if err := mconn.Start(); err != nil {
-			return fmt.Errorf("starting MConnection: %w", err)
+	// Start the connection if it delivers messages with a callback (e.g.
+	// MConnection).
+	// NOTE: we do not start the connection until all the streams are registered.
+	if rc, ok := p.peerConn.Conn.(receivingConn); ok {
+		if err := rc.Start(); err != nil {
+			return fmt.Errorf("starting connection: %w", err)
 		}
 	}
 
@@ -456,6 +470,10 @@ func (p *peer) eventLoop() {
 	metricsTicker := time.NewTicker(metricsTickerDuration)
 	defer metricsTicker.Stop()
 
+	// The stream states at the previous tick, to report the counters since
+	// the last interval.
+	prevStreamStates := make(map[byte]transport.StreamState)
+
 	for {
 		select {
 		case err := <-p.Conn.ErrorCh():
@@ -474,6 +492,17 @@ func (p *peer) eventLoop() {
 				Add(state.SendRateLimiterDelay.Seconds())
 			p.metrics.PeerPendingSendBytes.With("peer_id", p.ID()).Set(float64(totalSendQueueSize))
 
+			for streamID, s := range state.StreamStates {
+				prev := prevStreamStates[streamID]
+				labels := []string{"peer_id", p.ID(), "channel_id", fmt.Sprintf("%#x", streamID)}
+				p.metrics.PeerSendBytesTotal.With(labels...).Add(float64(s.BytesSent - prev.BytesSent))
+				p.metrics.PeerReceiveBytesTotal.With(labels...).Add(float64(s.BytesReceived - prev.BytesReceived))
+				p.metrics.PeerSendMessagesTotal.With(labels...).Add(float64(s.MessagesSent - prev.MessagesSent))
+				p.metrics.PeerReceiveMessagesTotal.With(labels...).Add(float64(s.MessagesReceived - prev.MessagesReceived))
+				p.metrics.PeerDroppedMessagesTotal.With(labels...).Add(float64(s.MessagesDropped - prev.MessagesDropped))
+				prevStreamStates[streamID] = s
+			}
+
 			// Report per peer, per message total bytes, since the last interval
 			func() {
 				p.pendingMetrics.mtx.Lock()
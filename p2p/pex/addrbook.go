@@ -60,6 +60,9 @@ type AddrBook interface {
 
 	// Pick an address to dial
 	PickAddress(biasTowardsNewAddrs int) *na.NetAddr
+	// Set the scorer used to prefer peers with a good reputation when picking
+	// an address to dial
+	SetPeerScorer(scorer PeerScorer)
 
 	// Mark address
 	MarkGood(id nodekey.ID)
@@ -84,6 +87,11 @@ type AddrBook interface {
 
 var _ AddrBook = (*addrBook)(nil)
 
+// PeerScorer returns the reputation score of peers.
+type PeerScorer interface {
+	PeerScore(id nodekey.ID) float64
+}
+
 // addrBook - concurrency safe peer address manager.
 // Implements AddrBook.
 type addrBook struct {
@@ -100,6 +108,7 @@ type addrBook struct {
 	bucketsNew []map[string]*knownAddress
 	nOld       int
 	nNew       int
+	scorer     PeerScorer
 
 	// immutable after creation
 	filePath          string
@@ -268,6 +277,8 @@ func (a *addrBook) Empty() bool {
 // The address is picked randomly from an old or new bucket according
 // to the biasTowardsNewAddrs argument, which must be between [0, 100] (or else is truncated to that range)
 // and determines how biased we are to pick an address from a new bucket.
+// If a PeerScorer is set, the address of the best scored peer among a few
+// random candidates is picked.
 // PickAddress returns nil if the AddrBook is empty or if we try to pick
 // from an empty bucket.
 func (a *addrBook) PickAddress(biasTowardsNewAddrs int) *na.NetAddr {
@@ -292,16 +303,42 @@ func (a *addrBook) PickAddress(biasTowardsNewAddrs int) *na.NetAddr {
 	oldCorrelation := math.Sqrt(float64(a.nOld)) * (100.0 - float64(biasTowardsNewAddrs))
 	newCorrelation := math.Sqrt(float64(a.nNew)) * float64(biasTowardsNewAddrs)
 
-	// pick a random peer from a random bucket
-	var bucket map[string]*knownAddress
 	pickFromOldBucket := (newCorrelation+oldCorrelation)*a.rand.Float64() < oldCorrelation
 	if (pickFromOldBucket && a.nOld == 0) ||
 		(!pickFromOldBucket && a.nNew == 0) {
 		return nil
 	}
+
+	ka := a.pickRandomAddress(pickFromOldBucket)
+	if ka == nil {
+		return nil
+	}
+	if a.scorer == nil {
+		return ka.Addr
+	}
+	// Prefer the peer with the best reputation among a few random candidates,
+	// so that well-behaved peers are dialed more often, while still giving a
+	// chance to peers we know nothing about.
+	bestScore := a.scorer.PeerScore(ka.ID())
+	for i := 1; i < numScoredCandidates; i++ {
+		candidate := a.pickRandomAddress(pickFromOldBucket)
+		if candidate == nil {
+			continue
+		}
+		if score := a.scorer.PeerScore(candidate.ID()); score > bestScore {
+			ka, bestScore = candidate, score
+		}
+	}
+	return ka.Addr
+}
+
+// pickRandomAddress picks a random address from a random non-empty old or new
+// bucket. There must be at least one address of the requested bucket type.
+func (a *addrBook) pickRandomAddress(fromOldBucket bool) *knownAddress {
 	// loop until we pick a random non-empty bucket
+	var bucket map[string]*knownAddress
 	for len(bucket) == 0 {
-		if pickFromOldBucket {
+		if fromOldBucket {
 			bucket = a.bucketsOld[a.rand.Intn(len(a.bucketsOld))]
 		} else {
 			bucket = a.bucketsNew[a.rand.Intn(len(a.bucketsNew))]
@@ -311,13 +348,20 @@ func (a *addrBook) PickAddress(biasTowardsNewAddrs int) *na.NetAddr {
 	randIndex := a.rand.Intn(len(bucket))
 	for _, ka := range bucket {
 		if randIndex == 0 {
-			return ka.Addr
+			return ka
 		}
 		randIndex--
 	}
 	return nil
 }
 
+// SetPeerScorer implements AddrBook.
+func (a *addrBook) SetPeerScorer(scorer PeerScorer) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	a.scorer = scorer
+}
+
 // MarkGood implements AddrBook - it marks the peer as good and
 // moves it into an "old" bucket.
 func (a *addrBook) MarkGood(id nodekey.ID) {
@@ -443,6 +443,32 @@ func TestBanBadPeers(t *testing.T) {
 	assert.False(t, book.IsGood(addr))
 }
 
+type mapPeerScorer map[nodekey.ID]float64
+
+func (s mapPeerScorer) PeerScore(id nodekey.ID) float64 { return s[id] }
+
+func TestAddrBookPickAddressPrefersScoredPeers(t *testing.T) {
+	fname := createTempFileName()
+	defer deleteTempFile(fname)
+
+	book := NewAddrBook(fname, true)
+	book.SetLogger(log.TestingLogger())
+
+	good, bad := randIPv4Address(t), randIPv4Address(t)
+	require.NoError(t, book.AddAddress(good, good))
+	require.NoError(t, book.AddAddress(bad, bad))
+	book.SetPeerScorer(mapPeerScorer{good.ID: 10, bad.ID: -10})
+
+	picked := make(map[nodekey.ID]int)
+	for i := 0; i < 100; i++ {
+		addr := book.PickAddress(100)
+		require.NotNil(t, addr)
+		picked[addr.ID]++
+	}
+	// The bad peer is only picked when all the candidates are the bad peer.
+	assert.Greater(t, picked[good.ID], 2*picked[bad.ID])
+}
+
 func TestAddrBookEmpty(t *testing.T) {
 	fname := createTempFileName()
 	defer deleteTempFile(fname)
@@ -52,4 +52,7 @@ const (
 	// max addresses returned by GetSelection
 	// NOTE: this must match "maxMsgSize".
 	maxGetSelection = 250
+
+	// random addresses among which PickAddress picks the best scored one.
+	numScoredCandidates = 3
 )
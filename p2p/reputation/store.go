@@ -0,0 +1,278 @@
+// Package reputation keeps track of the behaviour of peers.
+//
+// Reactors report good and bad behaviour of peers to the Switch, which records
+// it in a Store. Each report adds a (possibly negative) delta to the score of
+// the peer. Scores decay exponentially towards zero, so that old behaviour
+// matters less than recent behaviour. When the score of a peer drops to the
+// ban threshold, the peer is banned for a duration that doubles with every
+// ban, and its score is reset.
+package reputation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"time"
+
+	"github.com/cometbft/cometbft/v2/internal/tempfile"
+	cmtsync "github.com/cometbft/cometbft/v2/libs/sync"
+	"github.com/cometbft/cometbft/v2/p2p/internal/nodekey"
+)
+
+const (
+	// DefaultHalfLife is the default time after which a score is halved.
+	DefaultHalfLife = time.Hour
+	// DefaultBanThreshold is the default score at which a peer is banned.
+	DefaultBanThreshold = -100.0
+	// DefaultMaxScore is the default maximum score of a peer. It prevents a
+	// peer from building up enough goodwill to misbehave for a long time.
+	DefaultMaxScore = 100.0
+	// DefaultBanTime is the default duration of the first ban of a peer.
+	// Every following ban lasts twice as long as the previous one, up to
+	// DefaultMaxBanTime.
+	DefaultBanTime = 10 * time.Minute
+	// DefaultMaxBanTime is the default maximum duration of a ban.
+	DefaultMaxBanTime = 24 * time.Hour
+
+	// Scores with an absolute value below minScore are considered zero, and
+	// records of peers with such a score and no recent ban are not saved.
+	minScore = 0.01
+)
+
+// Behaviour is a kind of behaviour of a peer, with the delta it adds to the
+// score of the peer.
+type Behaviour struct {
+	Reason string
+	Delta  float64
+}
+
+// Behaviours reported by the reactors.
+var (
+	// ConsensusVote is reported when a peer sends a vote we did not have.
+	ConsensusVote = Behaviour{Reason: "consensus_vote", Delta: 1}
+	// BlockPart is reported when a peer sends a block part we did not have.
+	BlockPart = Behaviour{Reason: "block_part", Delta: 1}
+	// Block is reported when a block sent by a peer is verified.
+	Block = Behaviour{Reason: "block", Delta: 2}
+	// Tx is reported when a peer sends a transaction we did not have.
+	Tx = Behaviour{Reason: "tx", Delta: 0.1}
+	// SnapshotChunk is reported when a peer sends a snapshot chunk we did not
+	// have.
+	SnapshotChunk = Behaviour{Reason: "snapshot_chunk", Delta: 2}
+	// Error is reported when a peer is stopped for an error.
+	Error = Behaviour{Reason: "error", Delta: -20}
+	// BadMessage is reported when a peer sends a malformed or invalid message.
+	BadMessage = Behaviour{Reason: "bad_message", Delta: -50}
+	// InvalidBlock is reported when a peer sends a block that does not verify.
+	InvalidBlock = Behaviour{Reason: "invalid_block", Delta: -100}
+)
+
+// record is the reputation of a single peer.
+type record struct {
+	Score       float64   `json:"score"`
+	Updated     time.Time `json:"updated"`
+	Bans        int       `json:"bans,omitempty"`
+	BannedUntil time.Time `json:"banned_until,omitempty"`
+}
+
+// Store keeps the reputation of peers. It is safe for concurrent use.
+type Store struct {
+	mtx     cmtsync.Mutex
+	records map[nodekey.ID]*record
+
+	filePath     string
+	halfLife     time.Duration
+	banThreshold float64
+	maxScore     float64
+	banTime      time.Duration
+	maxBanTime   time.Duration
+
+	now func() time.Time
+}
+
+// StoreOption sets an optional parameter on the Store.
+type StoreOption func(*Store)
+
+// WithHalfLife sets the time after which a score is halved.
+func WithHalfLife(halfLife time.Duration) StoreOption {
+	return func(s *Store) { s.halfLife = halfLife }
+}
+
+// WithBanThreshold sets the score at which a peer is banned. It must be
+// negative.
+func WithBanThreshold(threshold float64) StoreOption {
+	return func(s *Store) { s.banThreshold = threshold }
+}
+
+// WithMaxScore sets the maximum score of a peer.
+func WithMaxScore(maxScore float64) StoreOption {
+	return func(s *Store) { s.maxScore = maxScore }
+}
+
+// WithBanTime sets the duration of the first ban of a peer, and the maximum
+// duration of a ban.
+func WithBanTime(banTime, maxBanTime time.Duration) StoreOption {
+	return func(s *Store) {
+		s.banTime = banTime
+		s.maxBanTime = maxBanTime
+	}
+}
+
+// NewStore returns a new Store, which is saved to filePath. If filePath is
+// empty, the Store is kept in memory only.
+func NewStore(filePath string, options ...StoreOption) *Store {
+	s := &Store{
+		records:      make(map[nodekey.ID]*record),
+		filePath:     filePath,
+		halfLife:     DefaultHalfLife,
+		banThreshold: DefaultBanThreshold,
+		maxScore:     DefaultMaxScore,
+		banTime:      DefaultBanTime,
+		maxBanTime:   DefaultMaxBanTime,
+		now:          time.Now,
+	}
+	for _, option := range options {
+		option(s)
+	}
+	return s
+}
+
+// Load loads the reputation of peers from the file of the Store. It does
+// nothing if the file does not exist.
+func (s *Store) Load() error {
+	if s.filePath == "" {
+		return nil
+	}
+	bz, err := os.ReadFile(s.filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	records := make(map[nodekey.ID]*record)
+	if err := json.Unmarshal(bz, &records); err != nil {
+		return fmt.Errorf("reading peer reputation from %s: %w", s.filePath, err)
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.records = records
+	return nil
+}
+
+// Prune removes the records that no longer carry any information, i.e. those
+// of peers whose score decayed to zero and whose past bans no longer count.
+func (s *Store) Prune() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.prune()
+}
+
+// prune implements Prune. The caller must hold mtx.
+func (s *Store) prune() {
+	now := s.now()
+	for id, r := range s.records {
+		s.decay(r, now)
+		if math.Abs(r.Score) < minScore && s.isForgiven(r, now) {
+			delete(s.records, id)
+		}
+	}
+}
+
+// Save saves the reputation of peers to the file of the Store, after pruning
+// the records that no longer carry any information.
+func (s *Store) Save() error {
+	if s.filePath == "" {
+		return nil
+	}
+
+	s.mtx.Lock()
+	s.prune()
+	bz, err := json.MarshalIndent(s.records, "", "  ")
+	s.mtx.Unlock()
+	if err != nil {
+		return err
+	}
+
+	return tempfile.WriteFileAtomic(s.filePath, bz, 0o644)
+}
+
+// Report records a behaviour of the peer and returns its new score. If the
+// score dropped to the ban threshold, the peer is banned, its score is reset,
+// and the duration of the ban is returned. Otherwise, banTime is zero.
+func (s *Store) Report(id nodekey.ID, b Behaviour) (score float64, banTime time.Duration) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	now := s.now()
+	r, ok := s.records[id]
+	if !ok {
+		r = &record{Updated: now}
+		s.records[id] = r
+	}
+	s.decay(r, now)
+	r.Score = math.Min(r.Score+b.Delta, s.maxScore)
+	if r.Score > s.banThreshold {
+		return r.Score, 0
+	}
+
+	if s.isForgiven(r, now) {
+		r.Bans = 0
+	}
+	r.Bans++
+	banTime = s.banTime
+	for i := 1; i < r.Bans && banTime < s.maxBanTime; i++ {
+		banTime *= 2
+	}
+	banTime = min(banTime, s.maxBanTime)
+	r.BannedUntil = now.Add(banTime)
+	r.Score = 0
+	return r.Score, banTime
+}
+
+// Score returns the current score of the peer, which is zero for unknown
+// peers.
+func (s *Store) Score(id nodekey.ID) float64 {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	r, ok := s.records[id]
+	if !ok {
+		return 0
+	}
+	s.decay(r, s.now())
+	return r.Score
+}
+
+// IsBanned returns true if the peer is currently banned.
+func (s *Store) IsBanned(id nodekey.ID) bool {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	r, ok := s.records[id]
+	return ok && s.now().Before(r.BannedUntil)
+}
+
+// isForgiven returns true if the past bans of r no longer count, i.e. the peer
+// has not been banned for longer than the maximum ban time.
+func (s *Store) isForgiven(r *record, now time.Time) bool {
+	return r.BannedUntil.IsZero() || now.Sub(r.BannedUntil) > s.maxBanTime
+}
+
+// decay applies the exponential decay of the score of r since it was last
+// updated.
+func (s *Store) decay(r *record, now time.Time) {
+	elapsed := now.Sub(r.Updated)
+	if elapsed <= 0 {
+		return
+	}
+	if s.halfLife > 0 {
+		r.Score *= math.Exp2(-float64(elapsed) / float64(s.halfLife))
+		if math.Abs(r.Score) < minScore {
+			r.Score = 0
+		}
+	}
+	r.Updated = now
+}
@@ -0,0 +1,121 @@
+package reputation
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/v2/p2p/internal/nodekey"
+)
+
+type testClock struct{ t time.Time }
+
+func (c *testClock) now() time.Time          { return c.t }
+func (c *testClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestStore(filePath string, options ...StoreOption) (*Store, *testClock) {
+	clock := &testClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	s := NewStore(filePath, options...)
+	s.now = clock.now
+	return s, clock
+}
+
+func TestStoreScoreDecays(t *testing.T) {
+	s, clock := newTestStore("")
+	id := nodekey.ID("peer")
+
+	assert.Zero(t, s.Score(id))
+
+	score, banTime := s.Report(id, Block)
+	assert.InDelta(t, 2, score, 1e-9)
+	assert.Zero(t, banTime)
+
+	clock.advance(DefaultHalfLife)
+	assert.InDelta(t, 1, s.Score(id), 1e-9)
+
+	score, _ = s.Report(id, Error)
+	assert.InDelta(t, -19, score, 1e-9)
+
+	// The score cannot exceed the maximum.
+	for i := 0; i < 1000; i++ {
+		s.Report(id, ConsensusVote)
+	}
+	assert.InDelta(t, DefaultMaxScore, s.Score(id), 1e-9)
+}
+
+func TestStoreGraduatedBans(t *testing.T) {
+	s, clock := newTestStore("", WithBanTime(time.Minute, 3*time.Minute))
+	id := nodekey.ID("peer")
+
+	// The first ban lasts banTime.
+	_, banTime := s.Report(id, BadMessage)
+	assert.Zero(t, banTime)
+	assert.False(t, s.IsBanned(id))
+	score, banTime := s.Report(id, BadMessage)
+	assert.Zero(t, score)
+	assert.Equal(t, time.Minute, banTime)
+	assert.True(t, s.IsBanned(id))
+	clock.advance(time.Minute)
+	assert.False(t, s.IsBanned(id))
+
+	// Every following ban lasts twice as long, up to maxBanTime.
+	_, banTime = s.Report(id, InvalidBlock)
+	assert.Equal(t, 2*time.Minute, banTime)
+	clock.advance(2 * time.Minute)
+	_, banTime = s.Report(id, InvalidBlock)
+	assert.Equal(t, 3*time.Minute, banTime)
+
+	// A peer that was not banned for longer than maxBanTime starts over.
+	clock.advance(3*time.Minute + 3*time.Minute + time.Second)
+	_, banTime = s.Report(id, InvalidBlock)
+	assert.Equal(t, time.Minute, banTime)
+}
+
+func TestStoreSaveLoad(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "peer_reputation.json")
+	s, clock := newTestStore(filePath)
+
+	var (
+		good   = nodekey.ID("good")
+		banned = nodekey.ID("banned")
+		idle   = nodekey.ID("idle")
+	)
+	s.Report(good, Block)
+	s.Report(idle, Tx)
+	clock.advance(10 * DefaultHalfLife)
+	s.Report(good, Block)
+	s.Report(banned, InvalidBlock)
+	require.NoError(t, s.Save())
+
+	loaded, _ := newTestStore(filePath)
+	loaded.now = clock.now
+	require.NoError(t, loaded.Load())
+	assert.InDelta(t, s.Score(good), loaded.Score(good), 1e-9)
+	assert.True(t, loaded.IsBanned(banned))
+	// Records without information are pruned.
+	assert.NotContains(t, loaded.records, idle)
+
+	// Loading a missing file is not an error.
+	require.NoError(t, NewStore(filepath.Join(t.TempDir(), "missing.json")).Load())
+}
+
+func TestStorePrune(t *testing.T) {
+	// In-memory stores are pruned too, although they are never saved.
+	s, clock := newTestStore("", WithBanTime(time.Minute, 100*time.Hour))
+	good, banned := nodekey.ID("good"), nodekey.ID("banned")
+	s.Report(good, Block)
+	s.Report(banned, InvalidBlock)
+
+	// The score of good decayed to zero, but the ban of banned still counts.
+	clock.advance(20 * DefaultHalfLife)
+	s.Prune()
+	assert.NotContains(t, s.records, good)
+	assert.Contains(t, s.records, banned)
+
+	clock.advance(100 * time.Hour)
+	s.Prune()
+	assert.Empty(t, s.records)
+}
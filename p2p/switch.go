@@ -10,10 +10,13 @@ import (
 	"github.com/cometbft/cometbft/v2/internal/cmap"
 	"github.com/cometbft/cometbft/v2/internal/rand"
 	"github.com/cometbft/cometbft/v2/libs/service"
+	"github.com/cometbft/cometbft/v2/p2p/firewall"
 	ni "github.com/cometbft/cometbft/v2/p2p/internal/nodeinfo"
 	"github.com/cometbft/cometbft/v2/p2p/internal/nodekey"
 	na "github.com/cometbft/cometbft/v2/p2p/netaddr"
+	"github.com/cometbft/cometbft/v2/p2p/reputation"
 	"github.com/cometbft/cometbft/v2/p2p/transport"
+	"github.com/cometbft/cometbft/v2/p2p/transport/quic"
 	"github.com/cometbft/cometbft/v2/p2p/transport/tcp"
 )
 
@@ -34,6 +37,10 @@ const (
 
 	defaultFilterTimeout    = 5 * time.Second
 	defaultHandshakeTimeout = 20 * time.Second
+
+	// how often the reputation records that no longer carry any information
+	// are pruned, so that they don't pile up between restarts.
+	reputationPruneInterval = 10 * time.Minute
 )
 
 // -----------------------------------------------------------------------------
@@ -46,6 +53,7 @@ type AddrBook interface {
 	AddOurAddress(addr *na.NetAddr)
 	OurAddress(addr *na.NetAddr) bool
 	MarkGood(id nodekey.ID)
+	MarkBad(addr *na.NetAddr, dur time.Duration)
 	RemoveAddress(addr *na.NetAddr)
 	HasAddress(addr *na.NetAddr) bool
 	Save()
@@ -82,6 +90,9 @@ type Switch struct {
 	filterTimeout time.Duration
 	peerFilters   []PeerFilterFunc
 
+	reputation *reputation.Store
+	firewall   *firewall.Firewall
+
 	rng *rand.Rand // seed for randomizing dial times and orders
 
 	metrics *Metrics
@@ -114,6 +125,7 @@ func NewSwitch(
 		filterTimeout:        defaultFilterTimeout,
 		persistentPeersAddrs: make([]*na.NetAddr, 0),
 		unconditionalPeerIDs: make(map[nodekey.ID]struct{}),
+		reputation:           reputation.NewStore(""),
 	}
 
 	// Ensure we have a completely undeterministic PRNG.
@@ -138,6 +150,20 @@ func SwitchPeerFilters(filters ...PeerFilterFunc) SwitchOption {
 	return func(sw *Switch) { sw.peerFilters = filters }
 }
 
+// SwitchReputation sets the store of the reputation of peers. By default, the
+// reputation of peers is kept in memory only.
+func SwitchReputation(store *reputation.Store) SwitchOption {
+	return func(sw *Switch) { sw.reputation = store }
+}
+
+// SwitchFirewall sets the firewall applied to inbound and outbound peers. The
+// Switch starts and stops the firewall, and disconnects the peers which are
+// denied when the rules change. The firewall also applies to persistent and
+// unconditional peers.
+func SwitchFirewall(fw *firewall.Firewall) SwitchOption {
+	return func(sw *Switch) { sw.firewall = fw }
+}
+
 // WithMetrics sets the metrics.
 func WithMetrics(metrics *Metrics) SwitchOption {
 	return func(sw *Switch) { sw.metrics = metrics }
@@ -218,12 +244,36 @@ func (sw *Switch) OnStart() error {
 		}
 	}
 
+	if sw.firewall != nil {
+		sw.firewall.OnUpdate(sw.applyFirewall)
+		if err := sw.firewall.Start(); err != nil {
+			return fmt.Errorf("starting firewall: %w", err)
+		}
+	}
+
 	// Start accepting Peers.
 	go sw.acceptRoutine()
 
+	go sw.pruneReputationRoutine()
+
 	return nil
 }
 
+// pruneReputationRoutine periodically prunes the reputation of peers until
+// the switch stops.
+func (sw *Switch) pruneReputationRoutine() {
+	ticker := time.NewTicker(reputationPruneInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			sw.reputation.Prune()
+		case <-sw.Quit():
+			return
+		}
+	}
+}
+
 // OnStop implements BaseService. It stops all peers and reactors.
 func (sw *Switch) OnStop() {
 	// Stop peers
@@ -238,6 +288,16 @@ func (sw *Switch) OnStop() {
 			sw.Logger.Error("error while stopped reactor", "reactor", reactor, "err", err)
 		}
 	}
+
+	if err := sw.reputation.Save(); err != nil {
+		sw.Logger.Error("Failed to save peer reputation", "err", err)
+	}
+
+	if sw.firewall != nil && sw.firewall.IsRunning() {
+		if err := sw.firewall.Stop(); err != nil {
+			sw.Logger.Error("error while stopping firewall", "err", err)
+		}
+	}
 }
 
 // ---------------------------------------------------------------------
@@ -298,16 +358,25 @@ func (sw *Switch) Peers() IPeerSet {
 	return sw.peers
 }
 
-// StopPeerForError disconnects from a peer due to external error.
+// StopPeerForError disconnects from a peer due to external error, and lowers
+// its reputation, which may get the peer banned.
 // If the peer is persistent, it will attempt to reconnect.
-// TODO: make record depending on reason.
 func (sw *Switch) StopPeerForError(peer Peer, reason any) {
+	sw.StopPeerForBehaviour(peer, reputation.Error, reason)
+}
+
+// StopPeerForBehaviour disconnects from a peer due to external error, like
+// StopPeerForError, but records the given bad behaviour in its reputation
+// instead of a generic error. Reactors should use it rather than reporting
+// the behaviour before stopping the peer, so that it is only penalized once.
+func (sw *Switch) StopPeerForBehaviour(peer Peer, b reputation.Behaviour, reason any) {
 	if !peer.IsRunning() {
 		return
 	}
 
 	sw.Logger.Error("Stopping peer for error", "peer", peer, "err", reason)
 	sw.stopAndRemovePeer(peer, reason)
+	sw.reportBehaviour(peer, b)
 
 	if peer.IsPersistent() {
 		var addr *na.NetAddr
@@ -388,6 +457,9 @@ func (sw *Switch) reconnectToPeer(addr *na.NetAddr) {
 			return // success
 		} else if _, ok := err.(ErrCurrentlyDialingOrExistingAddress); ok {
 			return
+		} else if errors.As(err, &firewall.ErrDenied{}) {
+			sw.Logger.Info("Stopped reconnecting to peer denied by firewall", "addr", addr)
+			return
 		}
 
 		sw.Logger.Info("Error reconnecting to peer. Trying again", "tries", i, "err", err, "addr", addr)
@@ -412,6 +484,9 @@ func (sw *Switch) reconnectToPeer(addr *na.NetAddr) {
 			return // success
 		} else if _, ok := err.(ErrCurrentlyDialingOrExistingAddress); ok {
 			return
+		} else if errors.As(err, &firewall.ErrDenied{}) {
+			sw.Logger.Info("Stopped reconnecting to peer denied by firewall", "addr", addr)
+			return
 		}
 		sw.Logger.Info("Error reconnecting to peer. Trying again", "tries", i, "err", err, "addr", addr)
 	}
@@ -431,6 +506,59 @@ func (sw *Switch) MarkPeerAsGood(peer Peer) {
 	}
 }
 
+// ReportBehaviour records the behaviour of the peer in its reputation. If the
+// score of the peer drops to the ban threshold, the peer is disconnected and
+// banned, unless it is persistent or unconditional. Reactors should report
+// every message that was useful, and every message that was invalid, using
+// StopPeerForBehaviour instead if they also stop the peer.
+func (sw *Switch) ReportBehaviour(peer Peer, b reputation.Behaviour) {
+	if sw.reportBehaviour(peer, b) && peer.IsRunning() {
+		sw.stopAndRemovePeer(peer, ErrPeerBanned{ID: peer.ID()})
+	}
+}
+
+// reportBehaviour records the behaviour of the peer and returns true if the
+// peer was banned as a result.
+func (sw *Switch) reportBehaviour(peer Peer, b reputation.Behaviour) bool {
+	score, banTime := sw.reputation.Report(peer.ID(), b)
+	sw.metrics.PeerScore.With("peer_id", string(peer.ID())).Set(score)
+	sw.metrics.PeerBehaviourReports.With("reason", b.Reason).Add(1)
+	if banTime == 0 || sw.isPeerExemptFromBans(peer) {
+		return false
+	}
+
+	sw.Logger.Info("Banning peer for bad behaviour", "peer", peer, "reason", b.Reason, "duration", banTime)
+	sw.metrics.PeerBans.Add(1)
+	if sw.addrBook != nil {
+		sw.addrBook.MarkBad(peer.SocketAddr(), banTime)
+	}
+	if err := sw.reputation.Save(); err != nil {
+		sw.Logger.Error("Failed to save peer reputation", "err", err)
+	}
+	return true
+}
+
+// isPeerExemptFromBans returns true if the peer must not be banned, which is
+// the case of persistent and unconditional peers.
+func (sw *Switch) isPeerExemptFromBans(peer Peer) bool {
+	return peer.IsPersistent() || sw.IsPeerUnconditional(peer.ID())
+}
+
+// applyFirewall disconnects the peers which are denied by the firewall.
+func (sw *Switch) applyFirewall() {
+	for _, p := range sw.peers.Copy() {
+		if err := sw.firewall.Check(p.ID(), p.RemoteIP()); err != nil {
+			sw.Logger.Info("Stopping peer denied by firewall", "peer", p, "err", err)
+			sw.stopAndRemovePeer(p, err)
+		}
+	}
+}
+
+// PeerScore returns the reputation score of the peer with the given ID.
+func (sw *Switch) PeerScore(id nodekey.ID) float64 {
+	return sw.reputation.Score(id)
+}
+
 // ---------------------------------------------------------------------
 // Dialing
 
@@ -527,6 +655,12 @@ func (sw *Switch) DialPeerWithAddress(addr *na.NetAddr) error {
 		return ErrCurrentlyDialingOrExistingAddress{addr.String()}
 	}
 
+	if sw.firewall != nil {
+		if err := sw.firewall.Check(addr.ID, addr.IP); err != nil {
+			return err
+		}
+	}
+
 	sw.dialing.Set(addr.ID, addr)
 	defer sw.dialing.Delete(addr.ID)
 
@@ -619,6 +753,15 @@ func (sw *Switch) acceptRoutine() {
 					"numPeers", sw.peers.Size(),
 				)
 
+				continue
+			case quic.ErrRejected:
+				sw.Logger.Info(
+					"Inbound Peer rejected",
+					"peer", addr,
+					"err", err,
+					"numPeers", sw.peers.Size(),
+				)
+
 				continue
 			case tcp.ErrFilterTimeout:
 				sw.Logger.Error(
@@ -628,7 +771,7 @@ func (sw *Switch) acceptRoutine() {
 				)
 
 				continue
-			case tcp.ErrTransportClosed:
+			case tcp.ErrTransportClosed, quic.ErrTransportClosed:
 				sw.Logger.Error("Stopped accept routine, as transport is closed")
 			default:
 				sw.Logger.Error(
@@ -790,6 +933,16 @@ func (sw *Switch) filterPeer(p Peer) error {
 		return ErrRejected{id: p.ID(), isDuplicate: true}
 	}
 
+	if sw.reputation.IsBanned(p.ID()) && !sw.isPeerExemptFromBans(p) {
+		return ErrRejected{id: p.ID(), err: ErrPeerBanned{ID: p.ID()}, isFiltered: true}
+	}
+
+	if sw.firewall != nil {
+		if err := sw.firewall.Check(p.ID(), p.RemoteIP()); err != nil {
+			return ErrRejected{id: p.ID(), err: err, isFiltered: true}
+		}
+	}
+
 	errc := make(chan error, len(sw.peerFilters))
 
 	for _, f := range sw.peerFilters {
@@ -25,10 +25,13 @@ import (
 	"github.com/cometbft/cometbft/v2/crypto/ed25519"
 	"github.com/cometbft/cometbft/v2/libs/log"
 	cmtsync "github.com/cometbft/cometbft/v2/libs/sync"
+	"github.com/cometbft/cometbft/v2/p2p/firewall"
 	ni "github.com/cometbft/cometbft/v2/p2p/internal/nodeinfo"
 	"github.com/cometbft/cometbft/v2/p2p/internal/nodekey"
 	na "github.com/cometbft/cometbft/v2/p2p/netaddr"
+	"github.com/cometbft/cometbft/v2/p2p/reputation"
 	"github.com/cometbft/cometbft/v2/p2p/transport"
+	"github.com/cometbft/cometbft/v2/p2p/transport/quic"
 	"github.com/cometbft/cometbft/v2/p2p/transport/tcp"
 	tcpconn "github.com/cometbft/cometbft/v2/p2p/transport/tcp/conn"
 )
@@ -194,6 +197,60 @@ func TestSwitches(t *testing.T) {
 		s2.Reactor("bar").(*TestReactor), 200*time.Millisecond, 5*time.Second)
 }
 
+// makeQUICSwitch returns a started switch, which connects to peers with the
+// QUIC transport.
+func makeQUICSwitch(t *testing.T, i int) *Switch {
+	t.Helper()
+
+	nk := nodekey.NodeKey{PrivKey: ed25519.GenPrivKey()}
+	qt, err := quic.NewTransport(nk)
+	require.NoError(t, err)
+	addr, err := na.NewFromString(na.IDAddrString(nk.ID(), "127.0.0.1:0"))
+	require.NoError(t, err)
+	require.NoError(t, qt.Listen(*addr))
+	t.Cleanup(func() { _ = qt.Close() })
+
+	sw := initSwitchFunc(i, NewSwitch(cfg, qt))
+	sw.SetLogger(log.TestingLogger().With("switch", i))
+	sw.SetNodeKey(&nk)
+	nodeInfo := testNodeInfo(nk.ID(), fmt.Sprintf("node%d", i))
+	listenAddr := qt.NetAddr()
+	nodeInfo.ListenAddr = listenAddr.DialString()
+	for ch := range sw.streamInfoByStreamID {
+		if ch != 0x01 {
+			nodeInfo.Channels = append(nodeInfo.Channels, ch)
+		}
+	}
+	sw.SetNodeInfo(nodeInfo)
+
+	require.NoError(t, sw.Start())
+	t.Cleanup(func() { _ = sw.Stop() })
+	return sw
+}
+
+func TestSwitchesOverQUIC(t *testing.T) {
+	s1, s2 := makeQUICSwitch(t, 0), makeQUICSwitch(t, 1)
+
+	require.NoError(t, s1.DialPeerWithAddress(s2.NetAddr()))
+	require.Eventually(t, func() bool {
+		return s1.Peers().Size() == 1 && s2.Peers().Size() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	msg := &p2pproto.PexAddrs{Addrs: []p2pproto.NetAddress{{ID: "quic"}}}
+	s1.Broadcast(Envelope{ChannelID: byte(0x02), Message: msg})
+	assertMsgReceivedWithTimeout(t,
+		msg,
+		byte(0x02),
+		s2.Reactor("bar").(*TestReactor), 10*time.Millisecond, 5*time.Second)
+
+	reply := &p2pproto.PexAddrs{Addrs: []p2pproto.NetAddress{{ID: "reply"}}}
+	s2.Broadcast(Envelope{ChannelID: byte(0x00), Message: reply})
+	assertMsgReceivedWithTimeout(t,
+		reply,
+		byte(0x00),
+		s1.Reactor("foo").(*TestReactor), 10*time.Millisecond, 5*time.Second)
+}
+
 func assertMsgReceivedWithTimeout(
 	t *testing.T,
 	msg proto.Message,
@@ -513,6 +570,110 @@ func TestSwitchStopPeerForError(t *testing.T) {
 	assert.EqualValues(t, 0, peersMetricValue())
 }
 
+func TestSwitchBansMisbehavingPeer(t *testing.T) {
+	sw := MakeSwitch(cfg, 1, initSwitchFunc)
+	err := sw.Start()
+	require.NoError(t, err)
+	defer sw.Stop() //nolint:errcheck
+
+	// simulate remote peer
+	rp := newRemoteTCPPeer()
+	rp.Start()
+	defer rp.Stop()
+
+	addPeer := func() (Peer, error) {
+		conn, err := sw.transport.Dial(*rp.Addr())
+		require.NoError(t, err)
+		p := wrapPeer(conn,
+			rp.nodeInfo(),
+			peerConfig{
+				onPeerError:          sw.StopPeerForError,
+				isPersistent:         sw.IsPeerPersistent,
+				streamInfoByStreamID: sw.streamInfoByStreamID,
+				metrics:              sw.metrics,
+				outbound:             true,
+			},
+			rp.Addr())
+		return p, sw.addPeer(p)
+	}
+
+	p, err := addPeer()
+	require.NoError(t, err)
+
+	// bad behaviour gets the peer banned
+	sw.ReportBehaviour(p, reputation.InvalidBlock)
+	assertNoPeersAfterTimeout(t, sw, 100*time.Millisecond)
+	assert.False(t, p.IsRunning())
+
+	// a banned peer is rejected
+	_, err = addPeer()
+	var errRejected ErrRejected
+	require.ErrorAs(t, err, &errRejected)
+	assert.True(t, errRejected.IsFiltered())
+	assert.ErrorAs(t, err, &ErrPeerBanned{})
+}
+
+func TestSwitchStopPeerForBehaviour(t *testing.T) {
+	sw := MakeSwitch(cfg, 1, initSwitchFunc)
+	err := sw.Start()
+	require.NoError(t, err)
+	defer sw.Stop() //nolint:errcheck
+
+	// simulate remote peer
+	rp := newRemoteTCPPeer()
+	rp.Start()
+	defer rp.Stop()
+
+	conn, err := sw.transport.Dial(*rp.Addr())
+	require.NoError(t, err)
+	p := wrapPeer(conn,
+		rp.nodeInfo(),
+		peerConfig{
+			onPeerError:          sw.StopPeerForError,
+			isPersistent:         sw.IsPeerPersistent,
+			streamInfoByStreamID: sw.streamInfoByStreamID,
+			metrics:              sw.metrics,
+			outbound:             true,
+		},
+		rp.Addr())
+	require.NoError(t, sw.addPeer(p))
+
+	// the peer is only penalized for its bad behaviour, not for the error too
+	sw.StopPeerForBehaviour(p, reputation.BadMessage, errors.New("bad message"))
+	assertNoPeersAfterTimeout(t, sw, 100*time.Millisecond)
+	assert.InDelta(t, reputation.BadMessage.Delta, sw.PeerScore(p.ID()), 0.1)
+}
+
+func TestSwitchFirewall(t *testing.T) {
+	fw := firewall.NewFirewall("")
+	s1 := MakeSwitch(cfg, 1, initSwitchFunc, SwitchFirewall(fw))
+	s2 := MakeSwitch(cfg, 2, initSwitchFunc)
+	for _, sw := range []*Switch{s1, s2} {
+		require.NoError(t, sw.Start())
+		defer sw.Stop() //nolint:errcheck
+	}
+
+	require.NoError(t, s1.DialPeerWithAddress(s2.NetAddr()))
+	require.Equal(t, 1, s1.Peers().Size())
+
+	// Denying the peer disconnects it.
+	require.NoError(t, fw.AddRules(firewall.Rules{DenyCIDRs: []string{"127.0.0.0/8"}}))
+	assertNoPeersAfterTimeout(t, s1, 100*time.Millisecond)
+
+	// A denied peer is not dialed.
+	err := s1.DialPeerWithAddress(s2.NetAddr())
+	require.ErrorAs(t, err, &firewall.ErrDenied{})
+
+	// A denied peer is rejected when it dials.
+	_ = s2.DialPeerWithAddress(s1.NetAddr())
+	assertNoPeersAfterTimeout(t, s1, 100*time.Millisecond)
+
+	// The peer is allowed again once the rule is removed.
+	require.NoError(t, fw.RemoveRules(firewall.Rules{DenyCIDRs: []string{"127.0.0.0/8"}}))
+	require.NoError(t, s1.DialPeerWithAddress(s2.NetAddr()))
+	assert.Equal(t, 1, s1.Peers().Size())
+}
+
 func TestSwitchReconnectsToOutboundPersistentPeer(t *testing.T) {
 	sw := MakeSwitch(cfg, 1, initSwitchFunc)
 	err := sw.Start()
@@ -16,6 +16,18 @@ type ConnState struct {
 	//
 	// Only applies to TCP.
 	RecvRateLimiterDelay time.Duration `json:"recv_rate_limiter_delay"`
+	// BytesSent is the number of bytes sent on the connection.
+	BytesSent uint64 `json:"bytes_sent"`
+	// BytesReceived is the number of bytes received on the connection.
+	BytesReceived uint64 `json:"bytes_received"`
+	// SendRate is the current send rate, in bytes/second.
+	//
+	// Only applies to TCP.
+	SendRate int64 `json:"send_rate"`
+	// RecvRate is the current receive rate, in bytes/second.
+	//
+	// Only applies to TCP.
+	RecvRate int64 `json:"recv_rate"`
 }
 
 // StreamState is the state of a stream.
@@ -24,4 +36,18 @@ type StreamState struct {
 	SendQueueSize int `json:"send_queue_size"`
 	// SendQueueCapacity is the capacity of the send queue.
 	SendQueueCapacity int `json:"send_queue_capacity"`
+	// BytesSent is the number of message bytes sent on the stream.
+	BytesSent uint64 `json:"bytes_sent"`
+	// BytesReceived is the number of message bytes received on the stream.
+	BytesReceived uint64 `json:"bytes_received"`
+	// MessagesSent is the number of messages sent on the stream.
+	MessagesSent uint64 `json:"messages_sent"`
+	// MessagesReceived is the number of messages received on the stream,
+	// including the dropped ones.
+	MessagesReceived uint64 `json:"messages_received"`
+	// MessagesDropped is the number of received messages, which were dropped
+	// because the stream exceeded its receive rate.
+	//
+	// Only applies to TCP.
+	MessagesDropped uint64 `json:"messages_dropped"`
 }
@@ -0,0 +1,127 @@
+package quic
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"io"
+	"math/big"
+	"time"
+
+	tmp2p "github.com/cometbft/cometbft/api/cometbft/p2p/v1"
+	"github.com/cometbft/cometbft/v2/crypto"
+	cryptoenc "github.com/cometbft/cometbft/v2/crypto/encoding"
+	"github.com/cometbft/cometbft/v2/internal/async"
+	"github.com/cometbft/cometbft/v2/libs/protoio"
+)
+
+const (
+	// alpnProtocol is the ALPN protocol negotiated by QUIC connections.
+	alpnProtocol = "cometbft-p2p"
+
+	// authExporterLabel is the label of the TLS exported keying material
+	// signed by both sides of a connection.
+	authExporterLabel = "EXPORTER-cometbft-p2p-auth"
+	authChallengeSize = 32
+
+	maxAuthSigMessageSize = 1024 * 1024
+)
+
+var errAuthSignature = errors.New("challenge verification failed")
+
+// newTLSConfig returns the TLS configuration of the transport.
+//
+// QUIC requires TLS 1.3, but the TLS certificate is not used to authenticate
+// the peers: it is an ephemeral self-signed certificate, and peers are instead
+// authenticated with their node keys by authenticate. This way, the identity
+// of a node does not depend on the key types supported by TLS.
+func newTLSConfig() (*tls.Config, error) {
+	cert, err := newEphemeralCertificate()
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		NextProtos:   []string{alpnProtocol},
+		MinVersion:   tls.VersionTLS13,
+		// Peers are authenticated with their node keys after the TLS handshake.
+		InsecureSkipVerify: true, //nolint:gosec
+	}, nil
+}
+
+func newEphemeralCertificate() (tls.Certificate, error) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: alpnProtocol},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(100 * 365 * 24 * time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, pub, priv)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: priv}, nil
+}
+
+// authenticate proves to the remote peer that we own privKey, and returns the
+// public key of the remote peer once it proved that it owns it.
+//
+// Both sides sign keying material exported from the TLS session, which is
+// unique to the connection, so that signatures cannot be replayed on another
+// connection.
+func authenticate(s io.ReadWriter, tlsState tls.ConnectionState, privKey crypto.PrivKey) (crypto.PubKey, error) {
+	challenge, err := tlsState.ExportKeyingMaterial(authExporterLabel, nil, authChallengeSize)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := privKey.Sign(challenge)
+	if err != nil {
+		return nil, err
+	}
+
+	// Send our signature and receive theirs in tandem.
+	trs, _ := async.Parallel(
+		func(_ int) (val any, abort bool, err error) {
+			pbpk, err := cryptoenc.PubKeyToProto(privKey.PubKey())
+			if err != nil {
+				return nil, true, err
+			}
+			_, err = protoio.NewDelimitedWriter(s).WriteMsg(&tmp2p.AuthSigMessage{PubKey: pbpk, Sig: sig})
+			if err != nil {
+				return nil, true, err // abort
+			}
+			return nil, false, nil
+		},
+		func(_ int) (val any, abort bool, err error) {
+			var msg tmp2p.AuthSigMessage
+			_, err = protoio.NewDelimitedReader(s, maxAuthSigMessageSize).ReadMsg(&msg)
+			if err != nil {
+				return nil, true, err // abort
+			}
+			pk, err := cryptoenc.PubKeyFromProto(msg.PubKey)
+			if err != nil {
+				return nil, true, err // abort
+			}
+			if !pk.VerifySignature(challenge, msg.Sig) {
+				return nil, true, errAuthSignature
+			}
+			return pk, false, nil
+		},
+	)
+	if err := trs.FirstError(); err != nil {
+		return nil, err
+	}
+
+	return trs.FirstValue().(crypto.PubKey), nil
+}
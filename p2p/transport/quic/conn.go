@@ -0,0 +1,334 @@
+package quic
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/quic-go/quic-go"
+
+	"github.com/cometbft/cometbft/v2/crypto"
+	"github.com/cometbft/cometbft/v2/libs/log"
+	"github.com/cometbft/cometbft/v2/p2p/transport"
+	tcpconn "github.com/cometbft/cometbft/v2/p2p/transport/tcp/conn"
+)
+
+const (
+	// Application error codes sent to the remote peer when closing a
+	// connection.
+	closeCodeNormal   quic.ApplicationErrorCode = 0
+	closeCodeRejected quic.ApplicationErrorCode = 1
+	closeCodeError    quic.ApplicationErrorCode = 2
+
+	// flushTimeout is the maximum time FlushAndClose waits for the queued
+	// messages to be sent.
+	flushTimeout = 10 * time.Second
+	// lingerTime is the time FlushAndClose keeps the connection open after
+	// the queued messages were written, so that the remote peer can receive
+	// them. QUIC drops the data that was not yet delivered when a connection
+	// is closed.
+	lingerTime = 500 * time.Millisecond
+)
+
+// OnReceiveFn is a callback func, which is called by the Conn when a new
+// message is received.
+type OnReceiveFn = func(byte, []byte)
+
+// Conn is a QUIC connection to a peer, authenticated with the node keys of
+// both sides. Every stream of the Conn is sent on its own QUIC stream, while
+// the handshake is done on a bidirectional QUIC stream opened by the dialer.
+//
+// As with MConnection, all streams must be opened before the Conn is started,
+// and received messages are passed to the callback set with OnReceive.
+type Conn struct {
+	qconn           *quic.Conn
+	handshakeStream *quic.Stream
+	remotePubKey    crypto.PubKey
+	created         time.Time
+	logger          log.Logger
+
+	mtx     sync.Mutex
+	streams map[byte]*Stream
+	started bool
+
+	onReceiveFn OnReceiveFn
+	errorCh     chan error
+
+	// Rate limits of the streams in bytes/second.
+	streamSendRates map[byte]int64
+	streamRecvRates map[byte]int64
+
+	closeOnce sync.Once
+	closec    chan struct{}
+}
+
+var _ transport.Conn = (*Conn)(nil)
+
+func newConn(qconn *quic.Conn, handshakeStream *quic.Stream, remotePubKey crypto.PubKey) *Conn {
+	return &Conn{
+		qconn:           qconn,
+		handshakeStream: handshakeStream,
+		remotePubKey:    remotePubKey,
+		created:         time.Now(),
+		logger:          log.NewNopLogger(),
+		streams:         make(map[byte]*Stream),
+		errorCh:         make(chan error, 1),
+		closec:          make(chan struct{}),
+	}
+}
+
+// SetLogger sets the logger of the connection.
+func (c *Conn) SetLogger(l log.Logger) {
+	c.logger = l
+}
+
+// RemotePubKey returns the public key of the remote peer.
+func (c *Conn) RemotePubKey() crypto.PubKey {
+	return c.remotePubKey
+}
+
+// OnReceive sets the callback function to be executed each time we read a
+// message. It must be called before Start.
+func (c *Conn) OnReceive(fn OnReceiveFn) {
+	c.onReceiveFn = fn
+}
+
+// OpenStream implements transport.Conn. desc may be a
+// tcpconn.StreamDescriptor, which sets the capacities of the stream.
+//
+// Returns an error if the connection is already started (i.e., all streams
+// must be registered in advance).
+func (c *Conn) OpenStream(streamID byte, desc any) (transport.Stream, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.started {
+		return nil, errors.New("connection is already started. Please register all streams in advance")
+	}
+	if _, ok := c.streams[streamID]; ok {
+		return nil, fmt.Errorf("stream %X already exists", streamID)
+	}
+
+	d := tcpconn.StreamDescriptor{ID: streamID}
+	if desc, ok := desc.(tcpconn.StreamDescriptor); ok {
+		d = desc
+	}
+	s := newStream(c, d, c.streamSendRates[streamID], c.streamRecvRates[streamID])
+	c.streams[streamID] = s
+	return s, nil
+}
+
+// Start starts sending the queued messages of the streams and receiving
+// messages from the remote peer.
+func (c *Conn) Start() error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.started {
+		return errors.New("connection is already started")
+	}
+	c.started = true
+
+	for _, s := range c.streams {
+		go s.sendRoutine()
+	}
+	go c.acceptStreamsRoutine()
+
+	return nil
+}
+
+// HandshakeStream implements transport.Conn.
+func (c *Conn) HandshakeStream() transport.HandshakeStream {
+	return c.handshakeStream
+}
+
+// LocalAddr implements transport.Conn.
+func (c *Conn) LocalAddr() net.Addr {
+	return c.qconn.LocalAddr()
+}
+
+// RemoteAddr implements transport.Conn.
+func (c *Conn) RemoteAddr() net.Addr {
+	return c.qconn.RemoteAddr()
+}
+
+// ErrorCh implements transport.Conn.
+func (c *Conn) ErrorCh() <-chan error {
+	return c.errorCh
+}
+
+// Close implements transport.Conn. The queued messages are dropped.
+func (c *Conn) Close(reason string) error {
+	return c.close(closeCodeNormal, reason)
+}
+
+// FlushAndClose implements transport.Conn. It waits for the queued messages to
+// be sent before closing the connection.
+func (c *Conn) FlushAndClose(reason string) error {
+	c.mtx.Lock()
+	started := c.started
+	c.mtx.Unlock()
+
+	if started {
+		timeout := time.After(flushTimeout)
+	FLUSH:
+		for _, s := range c.streams {
+			_ = s.Close()
+			select {
+			case <-s.donec:
+			case <-timeout:
+				break FLUSH
+			case <-c.closec:
+				return nil
+			}
+		}
+
+		select {
+		case <-time.After(lingerTime):
+		case <-c.qconn.Context().Done():
+		}
+	}
+
+	return c.close(closeCodeNormal, reason)
+}
+
+// ConnState implements transport.Conn.
+func (c *Conn) ConnState() (state transport.ConnState) {
+	state.ConnectedFor = time.Since(c.created)
+	state.StreamStates = make(map[byte]transport.StreamState)
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	for streamID, s := range c.streams {
+		ss := s.state()
+		state.StreamStates[streamID] = ss
+		state.BytesSent += ss.BytesSent
+		state.BytesReceived += ss.BytesReceived
+	}
+
+	return state
+}
+
+func (c *Conn) String() string {
+	return fmt.Sprintf("QUICConn{%v}", c.qconn.RemoteAddr())
+}
+
+// Quit returns a channel, which is closed when the connection is closed.
+func (c *Conn) Quit() <-chan struct{} {
+	return c.closec
+}
+
+// close closes the connection and sends the reason to the error channel.
+func (c *Conn) close(code quic.ApplicationErrorCode, reason string) error {
+	var err error
+	c.closeOnce.Do(func() {
+		close(c.closec)
+
+		// inform the error channel that we are shutting down.
+		select {
+		case c.errorCh <- errors.New(reason):
+		default:
+		}
+
+		err = c.qconn.CloseWithError(code, reason)
+	})
+	return err
+}
+
+// fail closes the connection because of err.
+func (c *Conn) fail(err error) {
+	select {
+	case <-c.closec:
+		// The error is a consequence of closing the connection.
+		return
+	default:
+	}
+
+	c.logger.Debug("Connection failed", "err", err)
+	_ = c.close(closeCodeError, err.Error())
+}
+
+// acceptStreamsRoutine accepts the QUIC streams opened by the remote peer, and
+// receives the messages of every stream in its own goroutine.
+func (c *Conn) acceptStreamsRoutine() {
+	var (
+		mtx      sync.Mutex
+		accepted = make(map[byte]bool)
+	)
+	for {
+		rs, err := c.qconn.AcceptUniStream(context.Background())
+		if err != nil {
+			if cause := context.Cause(c.qconn.Context()); cause != nil {
+				err = cause
+			}
+			c.fail(err)
+			return
+		}
+
+		go func() {
+			r := bufio.NewReader(rs)
+			streamID, err := r.ReadByte()
+			if err != nil {
+				c.fail(err)
+				return
+			}
+
+			mtx.Lock()
+			duplicate := accepted[streamID]
+			accepted[streamID] = true
+			mtx.Unlock()
+			if duplicate {
+				c.fail(fmt.Errorf("stream %X opened twice", streamID))
+				return
+			}
+
+			c.recvRoutine(streamID, r)
+		}()
+	}
+}
+
+// recvRoutine reads the messages of a stream and passes them to the
+// onReceiveFn.
+func (c *Conn) recvRoutine(streamID byte, r *bufio.Reader) {
+	c.mtx.Lock()
+	s, ok := c.streams[streamID]
+	c.mtx.Unlock()
+	if !ok {
+		c.fail(ErrUnknownStream{StreamID: streamID})
+		return
+	}
+
+	for {
+		size, err := binary.ReadUvarint(r)
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				c.fail(err)
+			}
+			return
+		}
+		if size > uint64(s.desc.RecvMessageCapacity) {
+			c.fail(ErrMessageTooBig{StreamID: streamID, Received: size, Max: s.desc.RecvMessageCapacity})
+			return
+		}
+		msg := make([]byte, size)
+		if _, err := io.ReadFull(r, msg); err != nil {
+			c.fail(err)
+			return
+		}
+		// Block until the stream is below its receive rate. QUIC flow
+		// control then slows down the remote peer on this stream only.
+		s.recvMonitor.Limit(int(size), s.recvRate, true)
+		s.recvMonitor.Update(int(size))
+		s.bytesReceived.Add(size)
+		s.msgsReceived.Add(1)
+		if c.onReceiveFn != nil {
+			c.onReceiveFn(streamID, msg)
+		}
+	}
+}
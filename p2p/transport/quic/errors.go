@@ -0,0 +1,90 @@
+package quic
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/cometbft/cometbft/v2/p2p/internal/nodekey"
+)
+
+// ErrTransportClosed is raised when the Transport has been closed.
+type ErrTransportClosed struct{}
+
+func (ErrTransportClosed) Error() string {
+	return "transport has been closed"
+}
+
+// ErrSendQueueFull is returned by TryWrite when the send queue of the stream
+// is full.
+type ErrSendQueueFull struct{}
+
+func (ErrSendQueueFull) Error() string {
+	return "send queue is full"
+}
+
+// Full implements transport.WriteError.
+func (ErrSendQueueFull) Full() bool {
+	return true
+}
+
+// ErrUnknownStream is returned when the remote peer sends data on a stream
+// that was not opened.
+type ErrUnknownStream struct {
+	StreamID byte
+}
+
+func (e ErrUnknownStream) Error() string {
+	return fmt.Sprintf("unknown stream %X", e.StreamID)
+}
+
+// ErrMessageTooBig is returned when the remote peer sends a message that
+// exceeds the receive capacity of the stream.
+type ErrMessageTooBig struct {
+	StreamID byte
+	Received uint64
+	Max      int
+}
+
+func (e ErrMessageTooBig) Error() string {
+	return fmt.Sprintf("message on stream %X exceeds available capacity (max: %d, got: %d)",
+		e.StreamID, e.Max, e.Received)
+}
+
+// ErrRejected indicates that a connection was rejected carrying additional
+// information as to the reason.
+type ErrRejected struct {
+	addr          net.Addr
+	err           error
+	id            nodekey.ID
+	isAuthFailure bool
+	isDuplicate   bool
+	isFiltered    bool
+}
+
+func (e ErrRejected) Error() string {
+	if e.isAuthFailure {
+		return fmt.Sprintf("auth failure: %s", e.err)
+	}
+
+	if e.isDuplicate {
+		return fmt.Sprintf("duplicate CONN<%s>", e.addr)
+	}
+
+	if e.isFiltered {
+		return fmt.Sprintf("filtered CONN<%s>: %s", e.addr, e.err)
+	}
+
+	return e.err.Error()
+}
+
+// Unwrap returns the reason of the rejection.
+func (e ErrRejected) Unwrap() error { return e.err }
+
+// IsAuthFailure when Peer authentication was unsuccessful.
+func (e ErrRejected) IsAuthFailure() bool { return e.isAuthFailure }
+
+// IsDuplicate when Peer IP is present already.
+func (e ErrRejected) IsDuplicate() bool { return e.isDuplicate }
+
+// IsFiltered when Peer IP was filtered.
+func (e ErrRejected) IsFiltered() bool { return e.isFiltered }
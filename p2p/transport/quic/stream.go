@@ -0,0 +1,173 @@
+package quic
+
+import (
+	"encoding/binary"
+	"sync"
+	"sync/atomic"
+
+	"github.com/quic-go/quic-go"
+
+	flow "github.com/cometbft/cometbft/v2/internal/flowrate"
+	"github.com/cometbft/cometbft/v2/p2p/transport"
+	tcpconn "github.com/cometbft/cometbft/v2/p2p/transport/tcp/conn"
+)
+
+// Stream is a stream of a QUIC connection. Messages written to the Stream are
+// queued and sent in order on a unidirectional QUIC stream, which is opened
+// when the first message is sent. The QUIC stream starts with the ID of the
+// Stream, and every message is prefixed with its length as an uvarint.
+//
+// Since every Stream is sent on its own QUIC stream, a message that is lost
+// or delayed on one Stream does not hold back the messages of other Streams.
+type Stream struct {
+	conn *Conn
+	desc tcpconn.StreamDescriptor
+
+	sendQueue     chan []byte
+	sendQueueSize atomic.Int32
+
+	// Rate limits of the stream in bytes/second, 0 if unlimited.
+	sendRate    int64
+	recvRate    int64
+	sendMonitor *flow.Monitor
+	recvMonitor *flow.Monitor
+
+	bytesSent     atomic.Uint64
+	bytesReceived atomic.Uint64
+	msgsSent      atomic.Uint64
+	msgsReceived  atomic.Uint64
+
+	closeOnce sync.Once
+	closec    chan struct{}
+	// donec is closed when the sendRoutine exits.
+	donec chan struct{}
+}
+
+var _ transport.Stream = (*Stream)(nil)
+
+func newStream(conn *Conn, desc tcpconn.StreamDescriptor, sendRate, recvRate int64) *Stream {
+	desc = desc.FillDefaults()
+	return &Stream{
+		conn:        conn,
+		desc:        desc,
+		sendQueue:   make(chan []byte, desc.SendQueueCapacity),
+		sendRate:    sendRate,
+		recvRate:    recvRate,
+		sendMonitor: flow.New(0, 0),
+		recvMonitor: flow.New(0, 0),
+		closec:      make(chan struct{}),
+		donec:       make(chan struct{}),
+	}
+}
+
+// Write queues b to be sent. It blocks until there is room in the send queue,
+// or the connection is closed.
+func (s *Stream) Write(b []byte) (n int, err error) {
+	select {
+	case s.sendQueue <- b:
+		s.sendQueueSize.Add(1)
+		return len(b), nil
+	case <-s.closec:
+		return 0, nil
+	case <-s.conn.closec:
+		return 0, nil
+	}
+}
+
+// TryWrite queues b to be sent. It returns ErrSendQueueFull if there is no
+// room in the send queue.
+func (s *Stream) TryWrite(b []byte) (n int, err error) {
+	select {
+	case s.sendQueue <- b:
+		s.sendQueueSize.Add(1)
+		return len(b), nil
+	case <-s.closec:
+		return 0, nil
+	case <-s.conn.closec:
+		return 0, nil
+	default:
+		return 0, ErrSendQueueFull{}
+	}
+}
+
+// Close closes the Stream once the queued messages are sent.
+func (s *Stream) Close() error {
+	s.closeOnce.Do(func() { close(s.closec) })
+	return nil
+}
+
+func (s *Stream) state() transport.StreamState {
+	return transport.StreamState{
+		SendQueueSize:     int(s.sendQueueSize.Load()),
+		SendQueueCapacity: cap(s.sendQueue),
+		BytesSent:         s.bytesSent.Load(),
+		BytesReceived:     s.bytesReceived.Load(),
+		MessagesSent:      s.msgsSent.Load(),
+		MessagesReceived:  s.msgsReceived.Load(),
+	}
+}
+
+// sendRoutine sends the queued messages until the Stream or the connection is
+// closed. When the Stream is closed, the queued messages are sent before the
+// QUIC stream is closed.
+func (s *Stream) sendRoutine() {
+	defer close(s.donec)
+
+	var (
+		qs  *quic.SendStream
+		buf []byte
+	)
+	defer func() {
+		if qs != nil {
+			_ = qs.Close()
+		}
+	}()
+
+	send := func(msg []byte) bool {
+		s.sendQueueSize.Add(-1)
+		if qs == nil {
+			var err error
+			if qs, err = s.conn.qconn.OpenUniStream(); err != nil {
+				s.conn.fail(err)
+				return false
+			}
+			buf = append(buf, s.desc.ID)
+		}
+		buf = binary.AppendUvarint(buf, uint64(len(msg)))
+		buf = append(buf, msg...)
+		// Block until the stream is below its send rate.
+		s.sendMonitor.Limit(len(buf), s.sendRate, true)
+		n, err := qs.Write(buf)
+		s.sendMonitor.Update(n)
+		buf = buf[:0]
+		if err != nil {
+			s.conn.fail(err)
+			return false
+		}
+		s.bytesSent.Add(uint64(len(msg)))
+		s.msgsSent.Add(1)
+		return true
+	}
+
+	for {
+		select {
+		case msg := <-s.sendQueue:
+			if !send(msg) {
+				return
+			}
+		case <-s.closec:
+			for {
+				select {
+				case msg := <-s.sendQueue:
+					if !send(msg) {
+						return
+					}
+				default:
+					return
+				}
+			}
+		case <-s.conn.closec:
+			return
+		}
+	}
+}
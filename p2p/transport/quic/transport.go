@@ -0,0 +1,506 @@
+// Package quic implements the p2p transport over QUIC.
+//
+// Connections are encrypted with TLS 1.3, as required by QUIC, and both sides
+// authenticate with their node keys by signing keying material exported from
+// the TLS session. Every stream is sent on its own unidirectional QUIC stream,
+// so that, unlike with the multiplexed TCP connections, a slow stream (e.g.
+// mempool) does not delay the messages of another stream (e.g. consensus).
+package quic
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/quic-go/quic-go"
+
+	"github.com/cometbft/cometbft/v2/crypto"
+	"github.com/cometbft/cometbft/v2/libs/log"
+	cmtsync "github.com/cometbft/cometbft/v2/libs/sync"
+	"github.com/cometbft/cometbft/v2/p2p/internal/nodekey"
+	na "github.com/cometbft/cometbft/v2/p2p/netaddr"
+	"github.com/cometbft/cometbft/v2/p2p/transport"
+)
+
+const (
+	defaultDialTimeout      = 3 * time.Second
+	defaultFilterTimeout    = 5 * time.Second
+	defaultHandshakeTimeout = 3 * time.Second
+	defaultMaxIdleTimeout   = 60 * time.Second
+	defaultKeepAlivePeriod  = 15 * time.Second
+
+	// Stream IDs are bytes, so a peer cannot open more than 256 streams.
+	maxIncomingUniStreams = 256
+)
+
+// accept is the container to carry the upgraded connection from an
+// asynchronously running routine to the Accept method.
+type accept struct {
+	netAddr *na.NetAddr
+	conn    *Conn
+	err     error
+}
+
+// ConnFilterFunc to be implemented by filter hooks after a new connection has
+// been established. The IPs of the existing connections are passed along
+// together with the address of the new connection.
+type ConnFilterFunc func(connectedIPs []net.IP, remoteAddr net.Addr) error
+
+// ConnDuplicateIPFilter refuses new connections if they come from a known ip.
+func ConnDuplicateIPFilter() ConnFilterFunc {
+	return func(connectedIPs []net.IP, remoteAddr net.Addr) error {
+		ip := addrIP(remoteAddr)
+		for _, known := range connectedIPs {
+			if known.Equal(ip) {
+				return fmt.Errorf("ip<%v> already connected", ip)
+			}
+		}
+		return nil
+	}
+}
+
+// TransportOption sets an optional parameter on the Transport.
+type TransportOption func(*Transport)
+
+// TransportConnFilters sets the filters for rejection new connections.
+func TransportConnFilters(filters ...ConnFilterFunc) TransportOption {
+	return func(t *Transport) { t.connFilters = filters }
+}
+
+// TransportFilterTimeout sets the timeout waited for filter calls to return.
+func TransportFilterTimeout(timeout time.Duration) TransportOption {
+	return func(t *Transport) { t.filterTimeout = timeout }
+}
+
+// TransportHandshakeTimeout sets the timeout of the QUIC handshake and of the
+// authentication of the peers.
+func TransportHandshakeTimeout(timeout time.Duration) TransportOption {
+	return func(t *Transport) { t.handshakeTimeout = timeout }
+}
+
+// TransportMaxIncomingConnections sets the maximum number of simultaneous
+// connections (incoming). Default: 0 (unlimited).
+func TransportMaxIncomingConnections(n int) TransportOption {
+	return func(t *Transport) { t.maxIncomingConnections = n }
+}
+
+// TransportStreamRates sets the rates, in bytes/second, at which messages can
+// be sent and received on the given streams. Since every stream is sent on its
+// own QUIC stream, a rate limited stream does not delay the other streams.
+func TransportStreamRates(sendRates, recvRates map[byte]int64) TransportOption {
+	return func(t *Transport) {
+		t.streamSendRates = sendRates
+		t.streamRecvRates = recvRates
+	}
+}
+
+// Transport accepts and dials QUIC connections, and authenticates the peers
+// with their node keys.
+type Transport struct {
+	netAddr                na.NetAddr
+	maxIncomingConnections int // see TransportMaxIncomingConnections
+
+	// qtr is used both to listen and to dial, so that outgoing connections
+	// are sent from the listening port. If Dial is called before Listen, a
+	// random port is used.
+	mtx      cmtsync.Mutex
+	qtr      *quic.Transport
+	listener *quic.Listener
+
+	acceptc   chan accept
+	closec    chan struct{}
+	closeOnce sync.Once
+
+	// Remote addresses of the connections, for duplicate ip checks.
+	connsMtx    cmtsync.Mutex
+	conns       map[string]net.Addr
+	numIncoming int
+	connFilters []ConnFilterFunc
+
+	streamSendRates map[byte]int64 // see TransportStreamRates
+	streamRecvRates map[byte]int64
+
+	dialTimeout      time.Duration
+	filterTimeout    time.Duration
+	handshakeTimeout time.Duration
+	nodeKey          nodekey.NodeKey
+	tlsConfig        *tls.Config
+	logger           log.Logger
+}
+
+// Test Transport for interface completeness.
+var _ transport.Transport = (*Transport)(nil)
+
+// NewTransport returns a new QUIC transport, which authenticates with
+// nodeKey.
+func NewTransport(nodeKey nodekey.NodeKey, options ...TransportOption) (*Transport, error) {
+	tlsConfig, err := newTLSConfig()
+	if err != nil {
+		return nil, fmt.Errorf("creating TLS certificate: %w", err)
+	}
+
+	t := &Transport{
+		acceptc:          make(chan accept),
+		closec:           make(chan struct{}),
+		conns:            make(map[string]net.Addr),
+		dialTimeout:      defaultDialTimeout,
+		filterTimeout:    defaultFilterTimeout,
+		handshakeTimeout: defaultHandshakeTimeout,
+		nodeKey:          nodeKey,
+		tlsConfig:        tlsConfig,
+		logger:           log.NewNopLogger(),
+	}
+	for _, option := range options {
+		option(t)
+	}
+	return t, nil
+}
+
+// SetLogger sets the logger for the transport.
+func (t *Transport) SetLogger(l log.Logger) {
+	t.logger = l
+}
+
+// NetAddr implements transport.Transport.
+func (t *Transport) NetAddr() na.NetAddr {
+	return t.netAddr
+}
+
+// Listen listens for QUIC connections on the UDP address addr.
+func (t *Transport) Listen(addr na.NetAddr) error {
+	udpAddr, err := net.ResolveUDPAddr("udp", addr.DialString())
+	if err != nil {
+		return err
+	}
+	udpConn, err := net.ListenUDP("udp", udpAddr)
+	if err != nil {
+		return err
+	}
+
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	if t.qtr != nil {
+		_ = udpConn.Close()
+		return errors.New("transport is already listening or dialing")
+	}
+	qtr := &quic.Transport{Conn: udpConn}
+	ln, err := qtr.Listen(t.tlsConfig, t.quicConfig())
+	if err != nil {
+		_ = qtr.Close()
+		_ = udpConn.Close()
+		return err
+	}
+
+	t.netAddr = *na.New(addr.ID, udpConn.LocalAddr())
+	t.qtr = qtr
+	t.listener = ln
+
+	go t.acceptPeers(ln)
+
+	return nil
+}
+
+// Accept implements transport.Transport.
+func (t *Transport) Accept() (transport.Conn, *na.NetAddr, error) {
+	select {
+	// This case should never have any side-effectful/blocking operations to
+	// ensure that quality peers are ready to be used.
+	case a := <-t.acceptc:
+		if a.err != nil {
+			return nil, nil, a.err
+		}
+
+		return a.conn, a.netAddr, nil
+	case <-t.closec:
+		return nil, nil, ErrTransportClosed{}
+	}
+}
+
+// Dial implements transport.Transport.
+func (t *Transport) Dial(addr na.NetAddr) (transport.Conn, error) {
+	qtr, err := t.dialTransport()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), t.dialTimeout)
+	defer cancel()
+	qconn, err := qtr.Dial(ctx, &net.UDPAddr{IP: addr.IP, Port: int(addr.Port)}, t.tlsConfig, t.quicConfig())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := t.filterConn(qconn, false); err != nil {
+		return nil, err
+	}
+
+	conn, err := t.upgrade(qconn, &addr)
+	if err != nil {
+		return nil, err
+	}
+	conn.SetLogger(t.logger.With("remote", addr))
+
+	return conn, nil
+}
+
+// Close closes the transport and all its connections.
+func (t *Transport) Close() error {
+	var err error
+	t.closeOnce.Do(func() {
+		close(t.closec)
+
+		t.mtx.Lock()
+		defer t.mtx.Unlock()
+		if t.listener != nil {
+			err = t.listener.Close()
+		}
+		if t.qtr != nil {
+			_ = t.qtr.Close()
+			_ = t.qtr.Conn.Close()
+		}
+	})
+	return err
+}
+
+// dialTransport returns the QUIC transport used to dial, which is created if
+// the transport is not listening.
+func (t *Transport) dialTransport() (*quic.Transport, error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	select {
+	case <-t.closec:
+		return nil, ErrTransportClosed{}
+	default:
+	}
+
+	if t.qtr == nil {
+		udpConn, err := net.ListenUDP("udp", nil)
+		if err != nil {
+			return nil, err
+		}
+		t.qtr = &quic.Transport{Conn: udpConn}
+	}
+	return t.qtr, nil
+}
+
+func (t *Transport) quicConfig() *quic.Config {
+	return &quic.Config{
+		HandshakeIdleTimeout: t.handshakeTimeout,
+		MaxIdleTimeout:       defaultMaxIdleTimeout,
+		KeepAlivePeriod:      defaultKeepAlivePeriod,
+		// Only the dialer opens a bidirectional stream, for the handshake.
+		MaxIncomingStreams:    1,
+		MaxIncomingUniStreams: maxIncomingUniStreams,
+	}
+}
+
+func (t *Transport) acceptPeers(ln *quic.Listener) {
+	for {
+		qconn, err := ln.Accept(context.Background())
+		if err != nil {
+			// If Close() has been called, silently exit.
+			select {
+			case <-t.closec:
+				return
+			default:
+				// Transport is not closed
+			}
+
+			select {
+			case t.acceptc <- accept{err: err}:
+			case <-t.closec:
+			}
+			return
+		}
+
+		// Filtering and authentication are asynchronous to avoid
+		// head-of-line blocking.
+		go func(qconn *quic.Conn) {
+			var (
+				conn    *Conn
+				netAddr *na.NetAddr
+			)
+
+			err := t.filterConn(qconn, true)
+			if err == nil {
+				conn, err = t.upgrade(qconn, nil)
+				if err == nil {
+					id := nodekey.PubKeyToID(conn.RemotePubKey())
+					netAddr = na.New(id, qconn.RemoteAddr())
+					conn.SetLogger(t.logger.With("remote", netAddr))
+				}
+			}
+
+			select {
+			case t.acceptc <- accept{netAddr, conn, err}:
+				// Make the upgraded peer available.
+			case <-t.closec:
+				// Give up if the transport was closed.
+				_ = qconn.CloseWithError(closeCodeNormal, "transport closed")
+			}
+		}(qconn)
+	}
+}
+
+// filterConn checks that the connection is allowed, and registers it. The
+// connection is closed if it is rejected.
+func (t *Transport) filterConn(qconn *quic.Conn, incoming bool) (err error) {
+	remoteAddr := qconn.RemoteAddr()
+	defer func() {
+		if err != nil {
+			_ = qconn.CloseWithError(closeCodeRejected, err.Error())
+		}
+	}()
+
+	t.connsMtx.Lock()
+	if _, ok := t.conns[remoteAddr.String()]; ok {
+		t.connsMtx.Unlock()
+		return ErrRejected{addr: remoteAddr, isDuplicate: true}
+	}
+	if incoming && t.maxIncomingConnections > 0 && t.numIncoming >= t.maxIncomingConnections {
+		t.connsMtx.Unlock()
+		return ErrRejected{
+			addr:       remoteAddr,
+			err:        fmt.Errorf("max incoming connections (%d) reached", t.maxIncomingConnections),
+			isFiltered: true,
+		}
+	}
+	connectedIPs := make([]net.IP, 0, len(t.conns))
+	for _, addr := range t.conns {
+		connectedIPs = append(connectedIPs, addrIP(addr))
+	}
+	t.connsMtx.Unlock()
+
+	errc := make(chan error, len(t.connFilters))
+	for _, f := range t.connFilters {
+		go func(f ConnFilterFunc) {
+			errc <- f(connectedIPs, remoteAddr)
+		}(f)
+	}
+
+	for i := 0; i < cap(errc); i++ {
+		select {
+		case err := <-errc:
+			if err != nil {
+				return ErrRejected{addr: remoteAddr, err: err, isFiltered: true}
+			}
+		case <-time.After(t.filterTimeout):
+			return ErrRejected{addr: remoteAddr, err: errors.New("filter timed out"), isFiltered: true}
+		}
+	}
+
+	t.connsMtx.Lock()
+	t.conns[remoteAddr.String()] = remoteAddr
+	if incoming {
+		t.numIncoming++
+	}
+	t.connsMtx.Unlock()
+
+	go t.cleanupConn(qconn, incoming)
+
+	return nil
+}
+
+func (t *Transport) cleanupConn(qconn *quic.Conn, incoming bool) {
+	select {
+	case <-qconn.Context().Done():
+	case <-t.closec:
+		return
+	}
+
+	t.connsMtx.Lock()
+	defer t.connsMtx.Unlock()
+	delete(t.conns, qconn.RemoteAddr().String())
+	if incoming {
+		t.numIncoming--
+	}
+}
+
+// upgrade authenticates the remote peer on a QUIC stream opened by the
+// dialer. For outgoing connections, the ID of the remote peer must match the
+// dialed ID.
+func (t *Transport) upgrade(qconn *quic.Conn, dialedAddr *na.NetAddr) (conn *Conn, err error) {
+	remoteAddr := qconn.RemoteAddr()
+	defer func() {
+		if err != nil {
+			_ = qconn.CloseWithError(closeCodeRejected, err.Error())
+		}
+	}()
+
+	hs, remotePubKey, err := t.authenticate(qconn, dialedAddr != nil)
+	if err != nil {
+		return nil, ErrRejected{
+			addr:          remoteAddr,
+			err:           fmt.Errorf("authentication failed: %w", err),
+			isAuthFailure: true,
+		}
+	}
+
+	if dialedAddr != nil {
+		if connID, dialedID := nodekey.PubKeyToID(remotePubKey), dialedAddr.ID; connID != dialedID {
+			return nil, ErrRejected{
+				addr: remoteAddr,
+				id:   connID,
+				err: fmt.Errorf(
+					"conn.ID (%v) dialed ID (%v) mismatch",
+					connID,
+					dialedID,
+				),
+				isAuthFailure: true,
+			}
+		}
+	}
+
+	c := newConn(qconn, hs, remotePubKey)
+	c.streamSendRates = t.streamSendRates
+	c.streamRecvRates = t.streamRecvRates
+	return c, nil
+}
+
+func (t *Transport) authenticate(qconn *quic.Conn, isDialer bool) (*quic.Stream, crypto.PubKey, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), t.handshakeTimeout)
+	defer cancel()
+
+	var (
+		hs  *quic.Stream
+		err error
+	)
+	if isDialer {
+		hs, err = qconn.OpenStreamSync(ctx)
+	} else {
+		hs, err = qconn.AcceptStream(ctx)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := hs.SetDeadline(time.Now().Add(t.handshakeTimeout)); err != nil {
+		return nil, nil, err
+	}
+	remotePubKey, err := authenticate(hs, qconn.ConnectionState().TLS, t.nodeKey.PrivKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return hs, remotePubKey, hs.SetDeadline(time.Time{})
+}
+
+// addrIP returns the IP of a UDP or TCP address.
+func addrIP(addr net.Addr) net.IP {
+	switch addr := addr.(type) {
+	case *net.UDPAddr:
+		return addr.IP
+	case *net.TCPAddr:
+		return addr.IP
+	}
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return nil
+	}
+	return net.ParseIP(host)
+}
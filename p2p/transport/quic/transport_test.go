@@ -0,0 +1,230 @@
+package quic
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/v2/crypto/ed25519"
+	"github.com/cometbft/cometbft/v2/libs/log"
+	"github.com/cometbft/cometbft/v2/p2p/internal/nodekey"
+	na "github.com/cometbft/cometbft/v2/p2p/netaddr"
+	"github.com/cometbft/cometbft/v2/p2p/transport"
+	tcpconn "github.com/cometbft/cometbft/v2/p2p/transport/tcp/conn"
+)
+
+func newTestTransport(t *testing.T, options ...TransportOption) *Transport {
+	t.Helper()
+
+	tr, err := NewTransport(nodekey.NodeKey{PrivKey: ed25519.GenPrivKey()}, options...)
+	require.NoError(t, err)
+	tr.SetLogger(log.TestingLogger())
+	t.Cleanup(func() { _ = tr.Close() })
+	return tr
+}
+
+func listen(t *testing.T, tr *Transport) na.NetAddr {
+	t.Helper()
+
+	addr, err := na.NewFromString(na.IDAddrString(tr.nodeKey.ID(), "127.0.0.1:0"))
+	require.NoError(t, err)
+	require.NoError(t, tr.Listen(*addr))
+	return tr.NetAddr()
+}
+
+// connect dials the listening transport from the dialing transport, and
+// returns both ends of the connection.
+func connect(t *testing.T, dialer, listener *Transport) (dialed, accepted *Conn) {
+	t.Helper()
+
+	addr := listen(t, listener)
+	acceptc := make(chan transport.Conn)
+	errc := make(chan error, 1)
+	go func() {
+		c, netAddr, err := listener.Accept()
+		if err != nil {
+			errc <- err
+			return
+		}
+		if netAddr.ID != dialer.nodeKey.ID() {
+			errc <- errors.New("unexpected ID of the dialer")
+			return
+		}
+		acceptc <- c
+	}()
+
+	c, err := dialer.Dial(addr)
+	require.NoError(t, err)
+
+	select {
+	case a := <-acceptc:
+		return c.(*Conn), a.(*Conn)
+	case err := <-errc:
+		t.Fatal(err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the connection to be accepted")
+	}
+	return nil, nil
+}
+
+func TestTransportSendReceive(t *testing.T) {
+	dialed, accepted := connect(t, newTestTransport(t), newTestTransport(t))
+
+	type message struct {
+		streamID byte
+		bz       string
+	}
+	received := make(chan message, 10)
+	accepted.OnReceive(func(streamID byte, bz []byte) {
+		received <- message{streamID, string(bz)}
+	})
+	dialed.OnReceive(func(streamID byte, bz []byte) {
+		received <- message{streamID, string(bz)}
+	})
+
+	// The handshake stream is shared by both ends.
+	_, err := dialed.HandshakeStream().Write([]byte("hello"))
+	require.NoError(t, err)
+	buf := make([]byte, 5)
+	_, err = accepted.HandshakeStream().Read(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(buf))
+
+	streams := make(map[*Conn]map[byte]transport.Stream)
+	for _, c := range []*Conn{dialed, accepted} {
+		streams[c] = make(map[byte]transport.Stream)
+		for _, id := range []byte{0x20, 0x30} {
+			s, err := c.OpenStream(id, tcpconn.StreamDescriptor{ID: id, SendQueueCapacity: 10})
+			require.NoError(t, err)
+			streams[c][id] = s
+		}
+		require.NoError(t, c.Start())
+	}
+	_, err = dialed.OpenStream(0x40, nil)
+	require.Error(t, err, "streams cannot be opened once started")
+
+	_, err = streams[dialed][0x20].Write([]byte("consensus"))
+	require.NoError(t, err)
+	_, err = streams[dialed][0x30].TryWrite([]byte("mempool"))
+	require.NoError(t, err)
+	_, err = streams[accepted][0x20].Write([]byte("reply"))
+	require.NoError(t, err)
+
+	got := make(map[message]bool)
+	for i := 0; i < 3; i++ {
+		select {
+		case m := <-received:
+			got[m] = true
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for messages")
+		}
+	}
+	assert.Equal(t, map[message]bool{
+		{0x20, "consensus"}: true,
+		{0x30, "mempool"}:   true,
+		{0x20, "reply"}:     true,
+	}, got)
+
+	state := dialed.ConnState()
+	assert.Equal(t, 10, state.StreamStates[0x20].SendQueueCapacity)
+	assert.EqualValues(t, 1, state.StreamStates[0x20].MessagesSent)
+	assert.EqualValues(t, len("consensus"), state.StreamStates[0x20].BytesSent)
+	assert.EqualValues(t, len("reply"), state.StreamStates[0x20].BytesReceived)
+	assert.EqualValues(t, len("consensus")+len("mempool")+len("reply"), state.BytesSent+state.BytesReceived)
+}
+
+func TestTransportCloseNotifiesRemote(t *testing.T) {
+	dialed, accepted := connect(t, newTestTransport(t), newTestTransport(t))
+	require.NoError(t, dialed.Start())
+	require.NoError(t, accepted.Start())
+
+	require.NoError(t, dialed.Close("bye"))
+
+	select {
+	case err := <-accepted.ErrorCh():
+		require.Error(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("remote was not notified")
+	}
+}
+
+func TestTransportUnknownStream(t *testing.T) {
+	dialed, accepted := connect(t, newTestTransport(t), newTestTransport(t))
+	s, err := dialed.OpenStream(0x20, nil)
+	require.NoError(t, err)
+	require.NoError(t, dialed.Start())
+	require.NoError(t, accepted.Start())
+
+	_, err = s.Write([]byte("unknown"))
+	require.NoError(t, err)
+
+	select {
+	case err := <-accepted.ErrorCh():
+		assert.ErrorContains(t, err, ErrUnknownStream{StreamID: 0x20}.Error())
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected an error for the unknown stream")
+	}
+}
+
+func TestTransportTryWriteFull(t *testing.T) {
+	dialed, _ := connect(t, newTestTransport(t), newTestTransport(t))
+	// The connection is not started, so nothing is sent.
+	s, err := dialed.OpenStream(0x20, tcpconn.StreamDescriptor{ID: 0x20, SendQueueCapacity: 1})
+	require.NoError(t, err)
+
+	_, err = s.TryWrite([]byte("a"))
+	require.NoError(t, err)
+	_, err = s.TryWrite([]byte("b"))
+	var werr transport.WriteError
+	require.ErrorAs(t, err, &werr)
+	assert.True(t, werr.Full())
+}
+
+func TestTransportDialRejectWrongID(t *testing.T) {
+	dialer, listener := newTestTransport(t), newTestTransport(t)
+	addr := listen(t, listener)
+
+	wrongAddr := addr
+	wrongAddr.ID = nodekey.PubKeyToID(ed25519.GenPrivKey().PubKey())
+	_, err := dialer.Dial(wrongAddr)
+	var rejected ErrRejected
+	require.ErrorAs(t, err, &rejected)
+	assert.True(t, rejected.IsAuthFailure())
+}
+
+func TestTransportConnFilter(t *testing.T) {
+	dialer := newTestTransport(t)
+	listener := newTestTransport(t, TransportConnFilters(
+		func(_ []net.IP, _ net.Addr) error { return nil },
+		func(_ []net.IP, _ net.Addr) error { return errors.New("rejected") },
+	))
+	addr := listen(t, listener)
+
+	go func() { _, _ = dialer.Dial(addr) }()
+
+	_, _, err := listener.Accept()
+	var rejected ErrRejected
+	require.ErrorAs(t, err, &rejected)
+	assert.True(t, rejected.IsFiltered())
+}
+
+func TestConnDuplicateIPFilter(t *testing.T) {
+	filter := ConnDuplicateIPFilter()
+	remote := &net.UDPAddr{IP: net.ParseIP("127.0.0.1"), Port: 26656}
+
+	require.NoError(t, filter(nil, remote))
+	require.Error(t, filter([]net.IP{net.ParseIP("127.0.0.1")}, remote))
+}
+
+func TestTransportAcceptAfterClose(t *testing.T) {
+	tr := newTestTransport(t)
+	listen(t, tr)
+	require.NoError(t, tr.Close())
+
+	_, _, err := tr.Accept()
+	require.ErrorAs(t, err, &ErrTransportClosed{})
+}
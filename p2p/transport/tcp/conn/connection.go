@@ -41,6 +41,10 @@ const (
 	defaultRecvRate     = int64(512000) // 500KB/s
 	defaultPingInterval = 60 * time.Second
 	defaultPongTimeout  = 45 * time.Second
+
+	// throttleRetryInterval is the interval, at which the sendRoutine retries
+	// sending the messages of streams, which exceeded their send rate.
+	throttleRetryInterval = 10 * time.Millisecond
 )
 
 // OnReceiveFn is a callback func, which is called by the MConnection when a
@@ -86,8 +90,9 @@ type MConnection struct {
 	// Closing quitRecvRouting will cause the recvRouting to eventually quit.
 	quitRecvRoutine chan struct{}
 
-	flushTimer *timer.ThrottleTimer // flush writes as necessary but throttled.
-	pingTimer  *time.Ticker         // send pings periodically
+	flushTimer    *timer.ThrottleTimer // flush writes as necessary but throttled.
+	throttleTimer *timer.ThrottleTimer // retry sending on rate limited streams.
+	pingTimer     *time.Ticker         // send pings periodically
 
 	// close conn if pong is not received in pongTimeout
 	pongTimer     *time.Timer
@@ -115,6 +120,13 @@ type MConnConfig struct {
 	SendRate int64 `mapstructure:"send_rate"`
 	RecvRate int64 `mapstructure:"recv_rate"`
 
+	// Rates at which messages can be sent on a given stream, in bytes/second.
+	// Streams without a rate are only limited by SendRate.
+	StreamSendRates map[byte]int64 `mapstructure:"stream_send_rates"`
+	// Rates at which messages can be received on a given stream, in
+	// bytes/second. Messages received above the rate are dropped.
+	StreamRecvRates map[byte]int64 `mapstructure:"stream_recv_rates"`
+
 	// Maximum payload size
 	MaxPacketMsgPayloadSize int `mapstructure:"max_packet_msg_payload_size"`
 
@@ -188,6 +200,7 @@ func (c *MConnection) OnStart() error {
 		return err
 	}
 	c.flushTimer = timer.NewThrottleTimer("flush", c.config.FlushThrottle)
+	c.throttleTimer = timer.NewThrottleTimer("throttle", throttleRetryInterval)
 	c.pingTimer = time.NewTicker(c.config.PingInterval)
 	c.pongTimeoutCh = make(chan bool, 1)
 	c.chStatsTimer = time.NewTicker(updateStats)
@@ -221,6 +234,7 @@ func (c *MConnection) stopServices() (alreadyStopped bool) {
 	}
 
 	c.flushTimer.Stop()
+	c.throttleTimer.Stop()
 	c.pingTimer.Stop()
 	c.chStatsTimer.Stop()
 
@@ -343,15 +357,25 @@ func (c *MConnection) FlushAndClose(reason string) error {
 }
 
 func (c *MConnection) ConnState() (state transport.ConnState) {
+	sendStatus, recvStatus := c.sendMonitor.Status(), c.recvMonitor.Status()
 	state.ConnectedFor = time.Since(c.created)
-	state.SendRateLimiterDelay = c.sendMonitor.Status().SleepTime
-	state.RecvRateLimiterDelay = c.recvMonitor.Status().SleepTime
+	state.SendRateLimiterDelay = sendStatus.SleepTime
+	state.RecvRateLimiterDelay = recvStatus.SleepTime
+	state.BytesSent = uint64(sendStatus.Bytes)
+	state.BytesReceived = uint64(recvStatus.Bytes)
+	state.SendRate = sendStatus.CurRate
+	state.RecvRate = recvStatus.CurRate
 	state.StreamStates = make(map[byte]transport.StreamState)
 
 	for streamID, channel := range c.channelsIdx {
 		state.StreamStates[streamID] = transport.StreamState{
 			SendQueueSize:     channel.loadSendQueueSize(),
 			SendQueueCapacity: cap(channel.sendQueue),
+			BytesSent:         channel.bytesSent.Load(),
+			BytesReceived:     channel.bytesReceived.Load(),
+			MessagesSent:      channel.msgsSent.Load(),
+			MessagesReceived:  channel.msgsReceived.Load(),
+			MessagesDropped:   channel.msgsDropped.Load(),
 		}
 	}
 
@@ -437,6 +461,12 @@ FOR_LOOP:
 			if fErr := c.flush(); fErr != nil {
 				c.Logger.Error("Failed to flush", "err", fErr)
 			}
+		case <-c.throttleTimer.Ch:
+			// Retry sending on the streams, which were rate limited.
+			select {
+			case c.send <- struct{}{}:
+			default:
+			}
 		case <-c.chStatsTimer.C:
 			for _, channel := range c.channelsIdx {
 				channel.updateStats()
@@ -528,9 +558,13 @@ func (c *MConnection) sendBatchPacketMsgs(w protoio.Writer, batchSize int) bool
 		}
 	}()
 	for i := 0; i < batchSize; i++ {
-		channel := c.selectChannel()
+		channel, throttled := c.selectChannel()
 		// nothing to send across any channel.
 		if channel == nil {
+			if throttled {
+				// Some streams have messages, but exceeded their send rate.
+				c.throttleTimer.Set()
+			}
 			return true
 		}
 		bytesWritten, err := c.sendPacketMsgOnChannel(w, channel)
@@ -542,21 +576,28 @@ func (c *MConnection) sendBatchPacketMsgs(w protoio.Writer, batchSize int) bool
 	return false
 }
 
-// selects a channel to gossip our next message on.
+// selects a channel to gossip our next message on. throttled is true if some
+// channels have messages to send, but were skipped because they exceeded their
+// send rate.
 // TODO: Make "batchChannelToGossipOn", so we can do our proto marshaling overheads in parallel,
 // and we can avoid re-checking for `isSendPending`.
 // We can easily mock the recentlySent differences for the batch choosing.
-func (c *MConnection) selectChannel() *stream {
+func (c *MConnection) selectChannel() (leastChannel *stream, throttled bool) {
 	// Choose a channel to create a PacketMsg from.
 	// The chosen channel will be the one whose recentlySent/priority is the least.
 	var leastRatio float32 = math.MaxFloat32
-	var leastChannel *stream
 	for _, channel := range c.channelsIdx {
 		// If nothing to send, skip this channel
 		// TODO: Skip continually looking for isSendPending on channels we've already skipped in this batch-send.
 		if !channel.isSendPending() {
 			continue
 		}
+		// Skip the channel if it exceeded its send rate, so that it does not
+		// delay the other channels.
+		if !channel.canSendNow() {
+			throttled = true
+			continue
+		}
 		// Get ratio, and keep track of lowest ratio.
 		// TODO: RecentlySent right now is bytes. This should be refactored to num messages to fix
 		// gossip prioritization bugs.
@@ -566,7 +607,7 @@ func (c *MConnection) selectChannel() *stream {
 			leastChannel = channel
 		}
 	}
-	return leastChannel
+	return leastChannel, throttled
 }
 
 // returns (num_bytes_written, error_occurred).
@@ -670,7 +711,11 @@ FOR_LOOP:
 				c.Close(err.Error())
 				break FOR_LOOP
 			}
-			if msgBytes != nil {
+			if msgBytes != nil && !channel.acceptRecvMsg(len(msgBytes)) {
+				// The stream exceeded its receive rate, drop the message
+				// instead of blocking the other streams.
+				c.Logger.Debug("Dropping message above the receive rate", "streamID", channelID)
+			} else if msgBytes != nil {
 				// Uncomment in you need to see raw bytes.
 				// c.Logger.Debug("Received", "streamID", channelID, "msgBytes", log.NewLazySprintf("%X", msgBytes))
 				if c.onReceiveFn != nil {
@@ -741,6 +786,18 @@ type stream struct {
 	sending       []byte
 	recentlySent  int64 // exponential moving average
 
+	// Rate limits of the stream in bytes/second, 0 if unlimited.
+	sendRate    int64
+	recvRate    int64
+	sendMonitor *flow.Monitor
+	recvMonitor *flow.Monitor
+
+	bytesSent     atomic.Uint64
+	bytesReceived atomic.Uint64
+	msgsSent      atomic.Uint64
+	msgsReceived  atomic.Uint64
+	msgsDropped   atomic.Uint64
+
 	nextPacketMsg           *tmp2p.PacketMsg
 	nextP2pWrapperPacketMsg *tmp2p.Packet_PacketMsg
 	nextPacket              *tmp2p.Packet
@@ -764,6 +821,10 @@ func newChannel(conn *MConnection, desc StreamDescriptor) *stream {
 		nextP2pWrapperPacketMsg: &tmp2p.Packet_PacketMsg{},
 		nextPacket:              &tmp2p.Packet{},
 		maxPacketMsgPayloadSize: conn.config.MaxPacketMsgPayloadSize,
+		sendRate:                conn.config.StreamSendRates[desc.ID],
+		recvRate:                conn.config.StreamRecvRates[desc.ID],
+		sendMonitor:             flow.New(0, 0),
+		recvMonitor:             flow.New(0, 0),
 	}
 }
 
@@ -819,6 +880,27 @@ func (ch *stream) isSendPending() bool {
 	return true
 }
 
+// Returns true if the stream did not exceed its send rate in the current
+// sampling period.
+// Not goroutine-safe.
+func (ch *stream) canSendNow() bool {
+	return ch.sendMonitor.Limit(1, ch.sendRate, false) > 0
+}
+
+// Records a received message of size bytes, and returns false if the message
+// must be dropped because the stream exceeded its receive rate in the current
+// sampling period.
+// Not goroutine-safe.
+func (ch *stream) acceptRecvMsg(size int) bool {
+	ch.msgsReceived.Add(1)
+	if ch.recvMonitor.Limit(1, ch.recvRate, false) == 0 {
+		ch.msgsDropped.Add(1)
+		return false
+	}
+	ch.recvMonitor.Update(size)
+	return true
+}
+
 // Updates the nextPacket proto message for us to send.
 // Not goroutine-safe.
 func (ch *stream) updateNextPacket() {
@@ -828,6 +910,7 @@ func (ch *stream) updateNextPacket() {
 		ch.nextPacketMsg.EOF = true
 		ch.sending = nil
 		atomic.AddInt32(&ch.sendQueueSize, -1) // decrement sendQueueSize
+		ch.msgsSent.Add(1)
 	} else {
 		ch.nextPacketMsg.Data = ch.sending[:maxSize]
 		ch.nextPacketMsg.EOF = false
@@ -848,6 +931,8 @@ func (ch *stream) writePacketMsgTo(w protoio.Writer) (n int, err error) {
 	}
 
 	atomic.AddInt64(&ch.recentlySent, int64(n))
+	ch.bytesSent.Add(uint64(len(ch.nextPacketMsg.Data)))
+	ch.sendMonitor.Update(n)
 	return n, err
 }
 
@@ -861,6 +946,7 @@ func (ch *stream) recvPacketMsg(packet tmp2p.PacketMsg) ([]byte, error) {
 	}
 
 	ch.recving = append(ch.recving, packet.Data...)
+	ch.bytesReceived.Add(uint64(len(packet.Data)))
 	if packet.EOF {
 		msgBytes := ch.recving
 
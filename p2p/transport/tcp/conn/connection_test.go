@@ -3,6 +3,7 @@ package conn
 import (
 	"encoding/hex"
 	"net"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -501,3 +502,146 @@ func TestMConnection_ChannelOverflow(t *testing.T) {
 	_, err = protoWriter.WriteMsg(mustWrapPacket(&packet))
 	require.NoError(t, err)
 }
+
+func createMConnectionWithStreams(t *testing.T, conn net.Conn, cfg MConnConfig, streamIDs ...byte) *MConnection {
+	t.Helper()
+
+	cfg.PingInterval = 90 * time.Millisecond
+	cfg.PongTimeout = 45 * time.Millisecond
+	c := NewMConnection(conn, cfg)
+	c.SetLogger(log.TestingLogger())
+
+	for _, id := range streamIDs {
+		_, err := c.OpenStream(id, StreamDescriptor{ID: id, Priority: 1, SendQueueCapacity: 100})
+		require.NoError(t, err)
+	}
+
+	return c
+}
+
+func TestMConnection_StreamSendRate(t *testing.T) {
+	server, client := net.Pipe()
+	defer server.Close()
+	defer client.Close()
+
+	const limitedStreamID, otherStreamID = 0x01, 0x02
+
+	cfg := DefaultMConnConfig()
+	cfg.StreamSendRates = map[byte]int64{limitedStreamID: 1000}
+	mconnClient := createMConnectionWithStreams(t, client, cfg, limitedStreamID, otherStreamID)
+	mconnServer := createMConnectionWithStreams(t, server, DefaultMConnConfig(), limitedStreamID, otherStreamID)
+
+	received := make(chan byte, 100)
+	mconnServer.OnReceive(func(streamID byte, _ []byte) { received <- streamID })
+	require.NoError(t, mconnClient.Start())
+	defer mconnClient.Close("normal")
+	require.NoError(t, mconnServer.Start())
+	defer mconnServer.Close("normal")
+
+	msg := make([]byte, 500)
+	for i := 0; i < 50; i++ {
+		require.NoError(t, mconnClient.sendBytes(limitedStreamID, msg, false))
+	}
+	require.NoError(t, mconnClient.sendBytes(otherStreamID, msg, false))
+
+	// The other stream is not delayed by the limited one.
+	var numLimited int
+	timeout := time.After(500 * time.Millisecond)
+LOOP:
+	for {
+		select {
+		case streamID := <-received:
+			if streamID == limitedStreamID {
+				numLimited++
+			}
+		case <-timeout:
+			break LOOP
+		}
+	}
+	state := mconnClient.ConnState()
+	assert.EqualValues(t, 1, state.StreamStates[otherStreamID].MessagesSent)
+	assert.EqualValues(t, len(msg), state.StreamStates[otherStreamID].BytesSent)
+
+	// Without the limit, all the messages would have been sent.
+	assert.Positive(t, numLimited)
+	assert.Less(t, numLimited, 20)
+}
+
+func TestMConnection_StreamRecvRate(t *testing.T) {
+	server, client := net.Pipe()
+	defer server.Close()
+	defer client.Close()
+
+	mconnClient := createMConnectionWithStreams(t, client, DefaultMConnConfig(), testStreamID)
+	cfg := DefaultMConnConfig()
+	cfg.StreamRecvRates = map[byte]int64{testStreamID: 1000}
+	mconnServer := createMConnectionWithStreams(t, server, cfg, testStreamID)
+
+	received := make(chan struct{}, 100)
+	mconnServer.OnReceive(func(byte, []byte) { received <- struct{}{} })
+	require.NoError(t, mconnClient.Start())
+	defer mconnClient.Close("normal")
+	require.NoError(t, mconnServer.Start())
+	defer mconnServer.Close("normal")
+
+	const numMsgs = 50
+	msg := make([]byte, 500)
+	for i := 0; i < numMsgs; i++ {
+		require.NoError(t, mconnClient.sendBytes(testStreamID, msg, false))
+	}
+
+	require.Eventually(t, func() bool {
+		return mconnServer.ConnState().StreamStates[testStreamID].MessagesReceived == numMsgs
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The messages above the rate were dropped.
+	state := mconnServer.ConnState().StreamStates[testStreamID]
+	assert.EqualValues(t, numMsgs*len(msg), state.BytesReceived)
+	assert.Positive(t, state.MessagesDropped)
+	assert.EqualValues(t, numMsgs-len(received), state.MessagesDropped)
+}
+
+func TestMConnection_StreamRecvRateConsensusNotDropped(t *testing.T) {
+	server, client := net.Pipe()
+	defer server.Close()
+	defer client.Close()
+
+	// Only loss tolerant streams, like mempool, can have a receive rate.
+	const mempoolStreamID, consensusStreamID = 0x30, 0x20
+
+	mconnClient := createMConnectionWithStreams(t, client, DefaultMConnConfig(), mempoolStreamID, consensusStreamID)
+	cfg := DefaultMConnConfig()
+	cfg.StreamRecvRates = map[byte]int64{mempoolStreamID: 1000}
+	mconnServer := createMConnectionWithStreams(t, server, cfg, mempoolStreamID, consensusStreamID)
+
+	var numConsensus atomic.Int32
+	mconnServer.OnReceive(func(streamID byte, _ []byte) {
+		if streamID == consensusStreamID {
+			numConsensus.Add(1)
+		}
+	})
+	require.NoError(t, mconnClient.Start())
+	defer mconnClient.Close("normal")
+	require.NoError(t, mconnServer.Start())
+	defer mconnServer.Close("normal")
+
+	const numMsgs = 50
+	msg := make([]byte, 500)
+	for i := 0; i < numMsgs; i++ {
+		require.NoError(t, mconnClient.sendBytes(mempoolStreamID, msg, false))
+		require.NoError(t, mconnClient.sendBytes(consensusStreamID, msg, false))
+	}
+
+	require.Eventually(t, func() bool {
+		states := mconnServer.ConnState().StreamStates
+		return states[mempoolStreamID].MessagesReceived == numMsgs &&
+			states[consensusStreamID].MessagesReceived == numMsgs
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Mempool messages above the rate were dropped, but all the consensus
+	// messages were delivered.
+	states := mconnServer.ConnState().StreamStates
+	assert.Positive(t, states[mempoolStreamID].MessagesDropped)
+	assert.Zero(t, states[consensusStreamID].MessagesDropped)
+	assert.EqualValues(t, numMsgs, numConsensus.Load())
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/cosmos/gogoproto/proto"
 
 	tmp2p "github.com/cometbft/cometbft/api/cometbft/p2p/v1"
+	"github.com/cometbft/cometbft/v2/crypto/armor"
 	ni "github.com/cometbft/cometbft/v2/p2p/internal/nodeinfo"
 	"github.com/cometbft/cometbft/v2/p2p/internal/nodekey"
 	"github.com/cometbft/cometbft/v2/p2p/transport"
@@ -80,3 +81,22 @@ func LoadOrGenNodeKey(path string) (*nodekey.NodeKey, error) {
 func LoadNodeKey(path string) (*nodekey.NodeKey, error) {
 	return nodekey.Load(path)
 }
+
+// LoadOrGenNodeKeyWithPassphrase loads a node key from the given path,
+// decrypting it with the passphrase returned by passphrase if it is
+// encrypted, or generates a new one.
+func LoadOrGenNodeKeyWithPassphrase(path string, passphrase armor.PassphraseFunc) (*nodekey.NodeKey, error) {
+	return nodekey.LoadOrGenWithPassphrase(path, passphrase)
+}
+
+// LoadNodeKeyWithPassphrase loads a node key from the given path, decrypting
+// it with the passphrase returned by passphrase if it is encrypted.
+func LoadNodeKeyWithPassphrase(path string, passphrase armor.PassphraseFunc) (*nodekey.NodeKey, error) {
+	return nodekey.LoadWithPassphrase(path, passphrase)
+}
+
+// NodeInfoDefaultFromProto converts the given Protobuf representation of a
+// NodeInfoDefault.
+func NodeInfoDefaultFromProto(pb *tmp2p.DefaultNodeInfo) (NodeInfoDefault, error) {
+	return ni.DefaultFromToProto(pb)
+}
@@ -23,5 +23,21 @@ SignerDialerEndpoint is a simple wrapper around a net.Conn. It's used by both IP
 SignerClient handles remote validator connections that provide signing services.
 In production, it's recommended to wrap it with RetrySignerClient to avoid
 termination in case of temporary errors.
+
+# GRPCSignerClient
+
+GRPCSignerClient connects to a remote signer serving the PrivValidatorAPI gRPC
+service, implemented by GRPCSignerServer on top of any PrivValidator. Unlike
+with SignerClient, the signer is the server. Connections over TCP should be
+secured with mutual TLS (see NewGRPCClientTLSConfig and NewGRPCServerTLSConfig).
+
+# ThresholdSignerClient
+
+ThresholdSignerClient requires t-of-n remote signers to approve each signature.
+The BLS12-381 validator key is split into n shares with bls12381.SplitPrivKey,
+each held by a signer running a SignerServer. Requests are sent to all the
+signers and the partial signatures of the first t agreeing signers are combined
+into a signature by the validator key. The node uses it when the
+priv_validator_threshold_file config option points to a ThresholdSignerFile.
 */
 package privval
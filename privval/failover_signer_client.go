@@ -0,0 +1,348 @@
+package privval
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	cmtproto "github.com/cometbft/cometbft/api/cometbft/types/v2"
+	"github.com/cometbft/cometbft/v2/crypto"
+	"github.com/cometbft/cometbft/v2/internal/tempfile"
+	cmtbytes "github.com/cometbft/cometbft/v2/libs/bytes"
+	cmtjson "github.com/cometbft/cometbft/v2/libs/json"
+	"github.com/cometbft/cometbft/v2/libs/log"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+// SignerHighWaterMarkFile is the name of the file in the data directory in
+// which the node persists the high-water mark of its FailoverSignerClient.
+const SignerHighWaterMarkFile = "signer_high_water_mark.json"
+
+// ErrSignerHighWaterMark is returned when a message conflicts with, or is
+// older than, the high-water mark of a FailoverSignerClient.
+var ErrSignerHighWaterMark = errors.New("message conflicts with the signer high-water mark")
+
+// SignerHighWaterMark is the height, round and step of the last vote or
+// proposal sent to the signers of a FailoverSignerClient, along with its sign
+// bytes. It is recorded before the message is sent, so that it covers messages
+// which may have been signed by a signer that timed out.
+type SignerHighWaterMark struct {
+	Height    int64             `json:"height"`
+	Round     int32             `json:"round"`
+	Step      int8              `json:"step"`
+	SignBytes cmtbytes.HexBytes `json:"signbytes,omitempty"`
+
+	mtx      sync.Mutex
+	filePath string
+}
+
+// LoadOrNewSignerHighWaterMark loads the high-water mark from filePath, or
+// returns an empty one if the file does not exist. If filePath is empty, the
+// high-water mark is only kept in memory.
+func LoadOrNewSignerHighWaterMark(filePath string) (*SignerHighWaterMark, error) {
+	hwm := &SignerHighWaterMark{filePath: filePath}
+	if filePath == "" {
+		return hwm, nil
+	}
+	bz, err := os.ReadFile(filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return hwm, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := cmtjson.Unmarshal(bz, hwm); err != nil {
+		return nil, fmt.Errorf("error reading signer high-water mark from %v: %w", filePath, err)
+	}
+	return hwm, nil
+}
+
+// checkAndRecord checks the given height, round, step and sign bytes against
+// the high-water mark and records them. Messages at the same height, round and
+// step are only accepted if they have the same sign bytes, or only differ by
+// their timestamp, in which case the timestamp of the recorded message is
+// returned so that the message can be signed with it.
+func (hwm *SignerHighWaterMark) checkAndRecord(
+	height int64,
+	round int32,
+	step int8,
+	signBytes []byte,
+	onlyDifferByTimestamp func(lastSignBytes, newSignBytes []byte) (time.Time, bool),
+) (time.Time, bool, error) {
+	hwm.mtx.Lock()
+	defer hwm.mtx.Unlock()
+
+	last := SignedRecord{Height: hwm.Height, Round: hwm.Round, Step: hwm.Step}
+	cmp := last.compareHRS(height, round, step)
+	switch {
+	case cmp > 0:
+		return time.Time{}, false, fmt.Errorf("%w: height %d round %d step %d is below height %d round %d step %d",
+			ErrSignerHighWaterMark, height, round, step, hwm.Height, hwm.Round, hwm.Step)
+	case cmp == 0 && len(hwm.SignBytes) > 0:
+		if bytes.Equal(signBytes, hwm.SignBytes) {
+			return time.Time{}, false, nil
+		}
+		if timestamp, ok := onlyDifferByTimestamp(hwm.SignBytes, signBytes); ok {
+			return timestamp, true, nil
+		}
+		return time.Time{}, false, fmt.Errorf("%w: conflicting data at height %d round %d step %d",
+			ErrSignerHighWaterMark, height, round, step)
+	}
+
+	// Only update the mark once it is persisted, so that a message which could
+	// not be recorded is checked again when retried.
+	next := &SignerHighWaterMark{
+		Height:    height,
+		Round:     round,
+		Step:      step,
+		SignBytes: bytes.Clone(signBytes),
+		filePath:  hwm.filePath,
+	}
+	if err := next.save(); err != nil {
+		return time.Time{}, false, err
+	}
+	hwm.Height, hwm.Round, hwm.Step, hwm.SignBytes = next.Height, next.Round, next.Step, next.SignBytes
+	return time.Time{}, false, nil
+}
+
+func (hwm *SignerHighWaterMark) save() error {
+	if hwm.filePath == "" {
+		return nil
+	}
+	bz, err := cmtjson.MarshalIndent(hwm, "", "  ")
+	if err != nil {
+		return err
+	}
+	return tempfile.WriteFileAtomic(hwm.filePath, bz, 0o600)
+}
+
+// FailoverSignerClientOption sets an optional parameter on the
+// FailoverSignerClient.
+type FailoverSignerClientOption func(*FailoverSignerClient)
+
+// FailoverSignerClientLogger sets the logger of the client.
+func FailoverSignerClientLogger(logger log.Logger) FailoverSignerClientOption {
+	return func(fc *FailoverSignerClient) { fc.logger = logger }
+}
+
+// FailoverSignerClientPingInterval makes the client ping the active signer
+// every interval, failing over to the next signer if it does not respond.
+func FailoverSignerClientPingInterval(interval time.Duration) FailoverSignerClientOption {
+	return func(fc *FailoverSignerClient) { fc.pingInterval = interval }
+}
+
+// FailoverSignerClient implements PrivValidator with redundant remote signers
+// holding the same key. Requests are sent to the active signer, and the next
+// signer becomes active when a request to the active signer fails, e.g.
+// because it timed out. Errors returned by a signer, such as a refusal to
+// double sign, are returned without failing over.
+//
+// Since a signer which timed out may still have signed a message, every vote
+// and proposal is checked against a high-water mark shared by all signers
+// before it is sent. Once a message has been sent at a given height, round and
+// step, no signer is asked to sign conflicting data at that height, round and
+// step, or to sign data at a lower height, round and step.
+type FailoverSignerClient struct {
+	mtx     sync.Mutex
+	signers []types.PrivValidator
+	active  int
+	pubKey  crypto.PubKey
+	hwm     *SignerHighWaterMark
+
+	logger       log.Logger
+	pingInterval time.Duration
+	quit         chan struct{}
+	closeOnce    sync.Once
+}
+
+var _ types.PrivValidator = (*FailoverSignerClient)(nil)
+
+// NewFailoverSignerClient returns a FailoverSignerClient sending requests to
+// the given signers, the first of which is initially active.
+func NewFailoverSignerClient(
+	signers []types.PrivValidator,
+	hwm *SignerHighWaterMark,
+	options ...FailoverSignerClientOption,
+) (*FailoverSignerClient, error) {
+	if len(signers) == 0 {
+		return nil, errors.New("no signers")
+	}
+	if hwm == nil {
+		return nil, errors.New("nil signer high-water mark")
+	}
+	fc := &FailoverSignerClient{
+		signers: signers,
+		hwm:     hwm,
+		logger:  log.NewNopLogger(),
+		quit:    make(chan struct{}),
+	}
+	for _, option := range options {
+		option(fc)
+	}
+	if fc.pingInterval > 0 {
+		go fc.pingRoutine()
+	}
+	return fc, nil
+}
+
+// Close stops pinging the signers and closes the signers implementing
+// io.Closer.
+func (fc *FailoverSignerClient) Close() error {
+	fc.closeOnce.Do(func() { close(fc.quit) })
+	var errs []error
+	for _, signer := range fc.signers {
+		if closer, ok := signer.(io.Closer); ok {
+			errs = append(errs, closer.Close())
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Active returns the index of the active signer.
+func (fc *FailoverSignerClient) Active() int {
+	fc.mtx.Lock()
+	defer fc.mtx.Unlock()
+	return fc.active
+}
+
+func (fc *FailoverSignerClient) pingRoutine() {
+	ticker := time.NewTicker(fc.pingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := fc.Ping(); err != nil {
+				fc.logger.Error("Failed to ping signers", "err", err)
+			}
+		case <-fc.quit:
+			return
+		}
+	}
+}
+
+// --------------------------------------------------------
+// Implement PrivValidator
+
+// Ping pings the active signer, failing over to the next signer answering
+// pings if it does not respond.
+func (fc *FailoverSignerClient) Ping() error {
+	fc.mtx.Lock()
+	defer fc.mtx.Unlock()
+	return fc.do("ping", func(pv types.PrivValidator) error {
+		pinger, ok := pv.(interface{ Ping() error })
+		if !ok {
+			// Signers that cannot be pinged are reached by requesting their key.
+			_, err := pv.GetPubKey()
+			return err
+		}
+		return pinger.Ping()
+	})
+}
+
+// GetPubKey retrieves the public key from the active signer. It returns an
+// error if the signers return different keys.
+func (fc *FailoverSignerClient) GetPubKey() (crypto.PubKey, error) {
+	fc.mtx.Lock()
+	defer fc.mtx.Unlock()
+	var pubKey crypto.PubKey
+	err := fc.do("get pubkey", func(pv types.PrivValidator) error {
+		pk, err := pv.GetPubKey()
+		if err != nil {
+			return err
+		}
+		if fc.pubKey != nil && (fc.pubKey.Type() != pk.Type() || !bytes.Equal(fc.pubKey.Bytes(), pk.Bytes())) {
+			return fmt.Errorf("signer returned pubkey %v, expected %v", pk, fc.pubKey)
+		}
+		pubKey = pk
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	fc.pubKey = pubKey
+	return pubKey, nil
+}
+
+// SignVote checks the vote against the high-water mark, records it, and sends
+// it to the active signer, failing over to the next signer on errors.
+func (fc *FailoverSignerClient) SignVote(chainID string, vote *cmtproto.Vote, signExtension bool) error {
+	fc.mtx.Lock()
+	defer fc.mtx.Unlock()
+
+	timestamp, sameVote, err := fc.hwm.checkAndRecord(vote.Height, vote.Round, voteToStep(vote),
+		types.VoteSignBytes(chainID, vote), checkVotesOnlyDifferByTimestamp)
+	if err != nil {
+		return err
+	}
+	if sameVote {
+		vote.Timestamp = timestamp
+	}
+	return fc.do("sign vote", func(pv types.PrivValidator) error {
+		return pv.SignVote(chainID, vote, signExtension)
+	})
+}
+
+// SignProposal checks the proposal against the high-water mark, records it,
+// and sends it to the active signer, failing over to the next signer on
+// errors.
+func (fc *FailoverSignerClient) SignProposal(chainID string, proposal *cmtproto.Proposal) error {
+	fc.mtx.Lock()
+	defer fc.mtx.Unlock()
+
+	timestamp, sameProposal, err := fc.hwm.checkAndRecord(proposal.Height, proposal.Round, stepPropose,
+		types.ProposalSignBytes(chainID, proposal), checkProposalsOnlyDifferByTimestamp)
+	if err != nil {
+		return err
+	}
+	if sameProposal {
+		proposal.Timestamp = timestamp
+	}
+	return fc.do("sign proposal", func(pv types.PrivValidator) error {
+		return pv.SignProposal(chainID, proposal)
+	})
+}
+
+// SignBytes sends the bytes to the active signer, failing over to the next
+// signer on errors.
+func (fc *FailoverSignerClient) SignBytes(bytes []byte) ([]byte, error) {
+	fc.mtx.Lock()
+	defer fc.mtx.Unlock()
+	var sig []byte
+	err := fc.do("sign bytes", func(pv types.PrivValidator) error {
+		var err error
+		sig, err = pv.SignBytes(bytes)
+		return err
+	})
+	return sig, err
+}
+
+// do calls fn with the active signer, and with the following signers in turn
+// until one succeeds, which becomes active. Errors returned by the signers
+// themselves are returned immediately.
+// Must be called with fc.mtx held.
+func (fc *FailoverSignerClient) do(method string, fn func(types.PrivValidator) error) error {
+	errs := make([]string, 0, len(fc.signers))
+	for i := range fc.signers {
+		idx := (fc.active + i) % len(fc.signers)
+		err := fn(fc.signers[idx])
+		if err == nil {
+			if idx != fc.active {
+				fc.logger.Info("Failed over to signer", "signer", idx, "previous", fc.active)
+				fc.active = idx
+			}
+			return nil
+		}
+		var remoteErr *RemoteSignerError
+		if errors.As(err, &remoteErr) {
+			return err
+		}
+		fc.logger.Error("Signer failed", "signer", idx, "method", method, "err", err)
+		errs = append(errs, fmt.Sprintf("signer %d: %v", idx, err))
+	}
+	return fmt.Errorf("all signers failed to %s: %s", method, strings.Join(errs, "; "))
+}
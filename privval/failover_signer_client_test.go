@@ -0,0 +1,143 @@
+package privval
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	cmtproto "github.com/cometbft/cometbft/api/cometbft/types/v2"
+	"github.com/cometbft/cometbft/v2/crypto/ed25519"
+	"github.com/cometbft/cometbft/v2/crypto/tmhash"
+	cmtrand "github.com/cometbft/cometbft/v2/internal/rand"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+// flakySigner is a signer which fails with err, after signing if signFirst is
+// set.
+type flakySigner struct {
+	types.PrivValidator
+	err       error
+	signFirst bool
+}
+
+func (fs *flakySigner) Ping() error {
+	return fs.err
+}
+
+func (fs *flakySigner) SignVote(chainID string, vote *cmtproto.Vote, signExtension bool) error {
+	if fs.err != nil && !fs.signFirst {
+		return fs.err
+	}
+	if err := fs.PrivValidator.SignVote(chainID, vote, signExtension); err != nil {
+		return err
+	}
+	return fs.err
+}
+
+func (fs *flakySigner) SignProposal(chainID string, proposal *cmtproto.Proposal) error {
+	if fs.err != nil && !fs.signFirst {
+		return fs.err
+	}
+	if err := fs.PrivValidator.SignProposal(chainID, proposal); err != nil {
+		return err
+	}
+	return fs.err
+}
+
+func TestSignerHighWaterMarkSaveError(t *testing.T) {
+	// The parent of the mark file is a regular file, so it cannot be written.
+	parent := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(parent, nil, 0o600))
+	hwm := &SignerHighWaterMark{filePath: filepath.Join(parent, "hwm.json")}
+
+	signBytes := cmtrand.Bytes(32)
+	for i := 0; i < 2; i++ {
+		_, _, err := hwm.checkAndRecord(1, 0, stepPrecommit, signBytes, checkVotesOnlyDifferByTimestamp)
+		require.Error(t, err)
+		require.Zero(t, hwm.Height)
+		require.Empty(t, hwm.SignBytes)
+	}
+
+	hwm.filePath = filepath.Join(t.TempDir(), "hwm.json")
+	_, _, err := hwm.checkAndRecord(1, 0, stepPrecommit, signBytes, checkVotesOnlyDifferByTimestamp)
+	require.NoError(t, err)
+	loaded, err := LoadOrNewSignerHighWaterMark(hwm.filePath)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), loaded.Height)
+	require.Equal(t, signBytes, []byte(loaded.SignBytes))
+}
+
+func TestFailoverSignerClient(t *testing.T) {
+	const chainID = "test-chain"
+	privKey := ed25519.GenPrivKey()
+	signers := make([]*flakySigner, 2)
+	pvs := make([]types.PrivValidator, len(signers))
+	for i := range signers {
+		dir := t.TempDir()
+		signers[i] = &flakySigner{PrivValidator: NewFilePV(privKey, filepath.Join(dir, "key.json"), filepath.Join(dir, "state.json"))}
+		pvs[i] = signers[i]
+	}
+	hwmFile := filepath.Join(t.TempDir(), "hwm.json")
+	hwm, err := LoadOrNewSignerHighWaterMark(hwmFile)
+	require.NoError(t, err)
+	fc, err := NewFailoverSignerClient(pvs, hwm)
+	require.NoError(t, err)
+
+	pubKey, err := fc.GetPubKey()
+	require.NoError(t, err)
+	require.Equal(t, privKey.PubKey(), pubKey)
+
+	randbytes := cmtrand.Bytes(tmhash.Size)
+	block1 := types.BlockID{Hash: randbytes, PartSetHeader: types.PartSetHeader{Total: 5, Hash: randbytes}}
+	randbytes2 := cmtrand.Bytes(tmhash.Size)
+	block2 := types.BlockID{Hash: randbytes2, PartSetHeader: types.PartSetHeader{Total: 5, Hash: randbytes2}}
+
+	require.NoError(t, fc.SignProposal(chainID, newProposal(1, 0, block1).ToProto()))
+	require.NoError(t, fc.SignVote(chainID, newVote(pubKey.Address(), 1, 0, types.PrevoteType, block1).ToProto(), false))
+
+	// The active signer signs the precommit but times out: the next signer
+	// signs it again.
+	signers[0].err, signers[0].signFirst = ErrReadTimeout, true
+	precommit := newVote(pubKey.Address(), 1, 0, types.PrecommitType, block1).ToProto()
+	require.NoError(t, fc.SignVote(chainID, precommit, false))
+	require.True(t, pubKey.VerifySignature(types.VoteSignBytes(chainID, precommit), precommit.Signature))
+	require.Equal(t, 1, fc.Active())
+
+	// No signer may sign a conflicting precommit or an older message.
+	err = fc.SignVote(chainID, newVote(pubKey.Address(), 1, 0, types.PrecommitType, block2).ToProto(), false)
+	require.ErrorIs(t, err, ErrSignerHighWaterMark)
+	err = fc.SignVote(chainID, newVote(pubKey.Address(), 1, 0, types.PrevoteType, block2).ToProto(), false)
+	require.ErrorIs(t, err, ErrSignerHighWaterMark)
+
+	// The same precommit with another timestamp gets the original timestamp.
+	again := newVote(pubKey.Address(), 1, 0, types.PrecommitType, block1).ToProto()
+	require.NoError(t, fc.SignVote(chainID, again, false))
+	require.Equal(t, precommit.Timestamp, again.Timestamp)
+	require.Equal(t, precommit.Signature, again.Signature)
+
+	// The high-water mark is persisted.
+	loaded, err := LoadOrNewSignerHighWaterMark(hwmFile)
+	require.NoError(t, err)
+	_, _, err = loaded.checkAndRecord(1, 0, stepPrecommit,
+		types.VoteSignBytes(chainID, newVote(pubKey.Address(), 1, 0, types.PrecommitType, block2).ToProto()),
+		checkVotesOnlyDifferByTimestamp)
+	require.ErrorIs(t, err, ErrSignerHighWaterMark)
+
+	// Errors returned by the signer do not cause a fail-over.
+	signers[0].err, signers[0].signFirst = nil, false
+	signers[1].err = &RemoteSignerError{Description: "refused"}
+	err = fc.SignProposal(chainID, newProposal(2, 0, block1).ToProto())
+	require.ErrorContains(t, err, "refused")
+	require.Equal(t, 1, fc.Active())
+
+	// Pings fail over to a signer which responds.
+	signers[1].err = ErrReadTimeout
+	require.NoError(t, fc.Ping())
+	require.Equal(t, 0, fc.Active())
+
+	signers[0].err = ErrReadTimeout
+	require.ErrorContains(t, fc.Ping(), "all signers failed to ping")
+	require.NoError(t, fc.Close())
+}
@@ -11,6 +11,7 @@ import (
 
 	cmtproto "github.com/cometbft/cometbft/api/cometbft/types/v2"
 	"github.com/cometbft/cometbft/v2/crypto"
+	"github.com/cometbft/cometbft/v2/crypto/armor"
 	"github.com/cometbft/cometbft/v2/crypto/ed25519"
 	cmtos "github.com/cometbft/cometbft/v2/internal/os"
 	"github.com/cometbft/cometbft/v2/internal/tempfile"
@@ -43,6 +44,10 @@ func voteToStep(vote *cmtproto.Vote) int8 {
 
 // -------------------------------------------------------------------------------
 
+// ArmorBlockType is the type of the armor block in which FilePVKeys encrypted
+// with a passphrase are stored.
+const ArmorBlockType = "COMETBFT PRIV VALIDATOR KEY"
+
 // FilePVKey stores the immutable part of PrivValidator.
 type FilePVKey struct {
 	Address types.Address  `json:"address"`
@@ -50,23 +55,48 @@ type FilePVKey struct {
 	PrivKey crypto.PrivKey `json:"priv_key"`
 
 	filePath string
+	// passphrase the key is encrypted with on disk, if any.
+	passphrase []byte
+}
+
+// SetPassphrase sets the passphrase the key is encrypted with when it is
+// saved. If passphrase is empty, the key is saved unencrypted.
+func (pvKey *FilePVKey) SetPassphrase(passphrase []byte) {
+	pvKey.passphrase = passphrase
+}
+
+// IsEncrypted returns true if the key is saved encrypted.
+func (pvKey FilePVKey) IsEncrypted() bool {
+	return len(pvKey.passphrase) > 0
 }
 
 // Save persists the FilePVKey to its filePath.
 func (pvKey FilePVKey) Save() {
+	if err := pvKey.save(); err != nil {
+		panic(err)
+	}
+}
+
+func (pvKey FilePVKey) save() error {
 	outFile := pvKey.filePath
 	if outFile == "" {
-		panic("cannot save PrivValidator key: filePath not set")
+		return errors.New("cannot save PrivValidator key: filePath not set")
 	}
 
 	jsonBytes, err := cmtjson.MarshalIndent(pvKey, "", "  ")
 	if err != nil {
-		panic(err)
+		return err
 	}
 
-	if err := tempfile.WriteFileAtomic(outFile, jsonBytes, 0o600); err != nil {
-		panic(err)
+	if pvKey.IsEncrypted() {
+		armored, err := armor.EncryptArmor(ArmorBlockType, jsonBytes, pvKey.passphrase, armor.KDFArgon2id)
+		if err != nil {
+			return err
+		}
+		jsonBytes = []byte(armored)
 	}
+
+	return tempfile.WriteFileAtomic(outFile, jsonBytes, 0o600)
 }
 
 // -------------------------------------------------------------------------------
@@ -163,11 +193,29 @@ var _ types.PrivValidator = (*FilePV)(nil)
 // NOTE: the directories containing pv.Key.filePath and pv.LastSignState.filePath must already exist.
 // It includes the LastSignature and LastSignBytes so we don't lose the signature
 // if the process crashes after signing but before the resulting consensus message is processed.
+//
+// If SlashingProtection is set, the votes and proposals are also checked
+// against, and recorded in, the slashing-protection database before being
+// signed. This protects the validator if the last sign state is lost or rolled
+// back, e.g. when the key is moved to another machine.
+//
+// If NextKey is set, consensus replaces Key with NextKey as soon as NextKey
+// is in the validator set (see RotateKey). With WatchNextKey, NextKey is loaded
+// as soon as it is staged, without restarting the node.
 type FilePV struct {
-	Key           FilePVKey
-	LastSignState FilePVLastSignState
+	Key                FilePVKey
+	LastSignState      FilePVLastSignState
+	SlashingProtection *SlashingProtectionDB
+	NextKey            *FilePVKey
+
+	// File the next key is loaded from once it is staged, and the passphrase
+	// it is decrypted with.
+	nextKeyFile       string
+	nextKeyPassphrase armor.PassphraseFunc
 }
 
+var _ types.KeyRotatingPrivValidator = (*FilePV)(nil)
+
 // NewFilePV generates a new validator from the given key and paths.
 func NewFilePV(privKey crypto.PrivKey, keyFilePath, stateFilePath string) *FilePV {
 	return &FilePV{
@@ -200,33 +248,37 @@ func GenFilePV(keyFilePath, stateFilePath string, keyGen func() (crypto.PrivKey,
 
 // LoadFilePV loads a FilePV from the filePaths.  The FilePV handles double
 // signing prevention by persisting data to the stateFilePath.  If either file path
-// does not exist, the program will exit.
+// does not exist, or if the key is encrypted, the program will exit.
 func LoadFilePV(keyFilePath, stateFilePath string) *FilePV {
-	return loadFilePV(keyFilePath, stateFilePath, true)
+	return loadFilePV(keyFilePath, stateFilePath, true, nil)
+}
+
+// LoadFilePVWithPassphrase is like LoadFilePV, but decrypts the key with the
+// passphrase returned by passphrase if it is encrypted. The key remains
+// encrypted with the same passphrase when it is saved.
+func LoadFilePVWithPassphrase(keyFilePath, stateFilePath string, passphrase armor.PassphraseFunc) *FilePV {
+	return loadFilePV(keyFilePath, stateFilePath, true, passphrase)
 }
 
 // LoadFilePVEmptyState loads a FilePV from the given keyFilePath, with an empty LastSignState.
 // If the keyFilePath does not exist, the program will exit.
 func LoadFilePVEmptyState(keyFilePath, stateFilePath string) *FilePV {
-	return loadFilePV(keyFilePath, stateFilePath, false)
+	return loadFilePV(keyFilePath, stateFilePath, false, nil)
+}
+
+// LoadFilePVEmptyStateWithPassphrase is like LoadFilePVEmptyState, but
+// decrypts the key with the passphrase returned by passphrase if it is
+// encrypted.
+func LoadFilePVEmptyStateWithPassphrase(keyFilePath, stateFilePath string, passphrase armor.PassphraseFunc) *FilePV {
+	return loadFilePV(keyFilePath, stateFilePath, false, passphrase)
 }
 
 // If loadState is true, we load from the stateFilePath. Otherwise, we use an empty LastSignState.
-func loadFilePV(keyFilePath, stateFilePath string, loadState bool) *FilePV {
-	keyJSONBytes, err := os.ReadFile(keyFilePath)
+func loadFilePV(keyFilePath, stateFilePath string, loadState bool, passphrase armor.PassphraseFunc) *FilePV {
+	pvKey, err := loadFilePVKey(keyFilePath, passphrase)
 	if err != nil {
 		cmtos.Exit(err.Error())
 	}
-	pvKey := FilePVKey{}
-	err = cmtjson.Unmarshal(keyJSONBytes, &pvKey)
-	if err != nil {
-		cmtos.Exit(fmt.Sprintf("Error reading PrivValidator key from %v: %v\n", keyFilePath, err))
-	}
-
-	// overwrite pubkey and address for convenience
-	pvKey.PubKey = pvKey.PrivKey.PubKey()
-	pvKey.Address = pvKey.PubKey.Address()
-	pvKey.filePath = keyFilePath
 
 	pvState := FilePVLastSignState{}
 
@@ -249,12 +301,46 @@ func loadFilePV(keyFilePath, stateFilePath string, loadState bool) *FilePV {
 	}
 }
 
+// loadFilePVKey loads a FilePVKey from filePath, decrypting it with the
+// passphrase returned by passphrase if it is encrypted.
+func loadFilePVKey(filePath string, passphrase armor.PassphraseFunc) (FilePVKey, error) {
+	keyFileBytes, err := os.ReadFile(filePath)
+	if err != nil {
+		return FilePVKey{}, err
+	}
+	keyJSONBytes, keyPassphrase, err := armor.DecryptIfArmored(keyFileBytes, ArmorBlockType, passphrase)
+	if err != nil {
+		return FilePVKey{}, fmt.Errorf("error reading PrivValidator key from %v: %w", filePath, err)
+	}
+	pvKey := FilePVKey{passphrase: keyPassphrase}
+	if err := cmtjson.Unmarshal(keyJSONBytes, &pvKey); err != nil {
+		return FilePVKey{}, fmt.Errorf("error reading PrivValidator key from %v: %w", filePath, err)
+	}
+
+	// overwrite pubkey and address for convenience
+	pvKey.PubKey = pvKey.PrivKey.PubKey()
+	pvKey.Address = pvKey.PubKey.Address()
+	pvKey.filePath = filePath
+	return pvKey, nil
+}
+
 // LoadOrGenFilePV loads a FilePV from the given filePaths
 // or else generates a new one and saves it to the filePaths.
 func LoadOrGenFilePV(keyFilePath, stateFilePath string, keyGenF func() (crypto.PrivKey, error)) (*FilePV, error) {
+	return LoadOrGenFilePVWithPassphrase(keyFilePath, stateFilePath, keyGenF, nil)
+}
+
+// LoadOrGenFilePVWithPassphrase is like LoadOrGenFilePV, but decrypts the key
+// with the passphrase returned by passphrase if it is encrypted. New keys are
+// saved unencrypted.
+func LoadOrGenFilePVWithPassphrase(
+	keyFilePath, stateFilePath string,
+	keyGenF func() (crypto.PrivKey, error),
+	passphrase armor.PassphraseFunc,
+) (*FilePV, error) {
 	var pv *FilePV
 	if cmtos.FileExists(keyFilePath) {
-		pv = LoadFilePV(keyFilePath, stateFilePath)
+		pv = LoadFilePVWithPassphrase(keyFilePath, stateFilePath, passphrase)
 	} else {
 		var err error
 		pv, err = GenFilePV(keyFilePath, stateFilePath, keyGenF)
@@ -266,6 +352,94 @@ func LoadOrGenFilePV(keyFilePath, stateFilePath string, keyGenF func() (crypto.P
 	return pv, nil
 }
 
+// LoadNextKey loads the key staged to replace the current key from filePath,
+// decrypting it with the passphrase returned by passphrase if it is
+// encrypted. It does nothing if the file does not exist. A staged key equal to
+// the current key, left behind if the node stopped during RotateKey, is
+// removed.
+func (pv *FilePV) LoadNextKey(filePath string, passphrase armor.PassphraseFunc) error {
+	if !cmtos.FileExists(filePath) {
+		return nil
+	}
+	nextKey, err := loadFilePVKey(filePath, passphrase)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(nextKey.Address, pv.Key.Address) {
+		return os.Remove(filePath)
+	}
+	pv.NextKey = &nextKey
+	return nil
+}
+
+// WatchNextKey is like LoadNextKey, but if the file does not exist yet, the
+// next key is loaded from it by NextPubKey as soon as it is staged, e.g. by the
+// stage-validator-key command while the node is running.
+func (pv *FilePV) WatchNextKey(filePath string, passphrase armor.PassphraseFunc) error {
+	pv.nextKeyFile = filePath
+	pv.nextKeyPassphrase = passphrase
+	return pv.LoadNextKey(filePath, passphrase)
+}
+
+// StageNextKey saves privKey to filePath as the key to replace the current
+// key. It is encrypted with the same passphrase as the current key, if any.
+func (pv *FilePV) StageNextKey(privKey crypto.PrivKey, filePath string) error {
+	if bytes.Equal(privKey.PubKey().Address(), pv.Key.Address) {
+		return errors.New("next key is the current key")
+	}
+	nextKey := FilePVKey{
+		Address:    privKey.PubKey().Address(),
+		PubKey:     privKey.PubKey(),
+		PrivKey:    privKey,
+		filePath:   filePath,
+		passphrase: pv.Key.passphrase,
+	}
+	if err := nextKey.save(); err != nil {
+		return err
+	}
+	pv.NextKey = &nextKey
+	return nil
+}
+
+// NextPubKey returns the public key of the key staged to replace the current
+// key, or nil if there is none. If the file of the next key is watched (see
+// WatchNextKey), the key is loaded from it if it has been staged since.
+// Implements KeyRotatingPrivValidator.
+func (pv *FilePV) NextPubKey() (crypto.PubKey, error) {
+	if pv.NextKey == nil && pv.nextKeyFile != "" {
+		if err := pv.LoadNextKey(pv.nextKeyFile, pv.nextKeyPassphrase); err != nil {
+			return nil, fmt.Errorf("failed to load next key from %s: %w", pv.nextKeyFile, err)
+		}
+	}
+	if pv.NextKey == nil {
+		return nil, nil
+	}
+	return pv.NextKey.PubKey, nil
+}
+
+// RotateKey replaces the current key with the staged next key, both in memory
+// and on disk: the next key is written to the key file, and its own file is
+// removed. The last sign state is kept, so that the new key cannot sign below
+// the height, round and step signed by the old key.
+// Implements KeyRotatingPrivValidator.
+func (pv *FilePV) RotateKey() error {
+	if pv.NextKey == nil {
+		return errors.New("no next key to rotate to")
+	}
+	nextKeyFile := pv.NextKey.filePath
+	key := *pv.NextKey
+	key.filePath = pv.Key.filePath
+	if err := key.save(); err != nil {
+		return fmt.Errorf("failed to save the next key as the current key: %w", err)
+	}
+	pv.Key = key
+	pv.NextKey = nil
+	if err := os.Remove(nextKeyFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
 // GetAddress returns the address of the validator.
 // Implements PrivValidator.
 func (pv *FilePV) GetAddress() types.Address {
@@ -307,11 +481,17 @@ func (pv *FilePV) Save() {
 	pv.LastSignState.Save()
 }
 
-// Reset resets all fields in the FilePV.
+// Reset resets all fields in the FilePV, and removes the records of the key
+// from the slashing-protection database, if any.
 // NOTE: Unsafe!
 func (pv *FilePV) Reset() {
 	pv.LastSignState.reset()
 	pv.Save()
+	if pv.SlashingProtection != nil {
+		if err := pv.SlashingProtection.Reset(pv.Key.Address); err != nil {
+			panic(err)
+		}
+	}
 }
 
 // String returns a string representation of the FilePV.
@@ -388,6 +568,10 @@ func (pv *FilePV) signVote(chainID string, vote *cmtproto.Vote, signExtension bo
 		return err
 	}
 
+	if err := pv.checkSlashingProtection(height, round, step, signBytes); err != nil {
+		return err
+	}
+
 	// It passed the checks. Sign the vote
 	sig, err := pv.Key.PrivKey.Sign(signBytes)
 	if err != nil {
@@ -431,6 +615,10 @@ func (pv *FilePV) signProposal(chainID string, proposal *cmtproto.Proposal) erro
 		return err
 	}
 
+	if err := pv.checkSlashingProtection(height, round, step, signBytes); err != nil {
+		return err
+	}
+
 	// It passed the checks. Sign the proposal
 	sig, err := pv.Key.PrivKey.Sign(signBytes)
 	if err != nil {
@@ -441,6 +629,15 @@ func (pv *FilePV) signProposal(chainID string, proposal *cmtproto.Proposal) erro
 	return nil
 }
 
+// checkSlashingProtection checks signBytes against the slashing-protection
+// database, if any, and records it there.
+func (pv *FilePV) checkSlashingProtection(height int64, round int32, step int8, signBytes []byte) error {
+	if pv.SlashingProtection == nil {
+		return nil
+	}
+	return pv.SlashingProtection.CheckAndRecord(pv.Key.Address, height, round, step, signBytes)
+}
+
 // Persist height/round/step and signature.
 func (pv *FilePV) saveSigned(height int64, round int32, step int8,
 	signBytes []byte, sig []byte,
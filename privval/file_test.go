@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/cometbft/cometbft/v2/crypto"
+	"github.com/cometbft/cometbft/v2/crypto/armor"
 	"github.com/cometbft/cometbft/v2/crypto/ed25519"
 	"github.com/cometbft/cometbft/v2/crypto/tmhash"
 	kt "github.com/cometbft/cometbft/v2/internal/keytypes"
@@ -102,6 +103,98 @@ func TestLoadOrGenValidator(t *testing.T) {
 	}
 }
 
+func TestLoadEncryptedValidator(t *testing.T) {
+	privVal, keyFile, stateFile := newTestFilePV(t, nil)
+	privVal.Save()
+	privVal.Key.SetPassphrase([]byte("passphrase"))
+	privVal.Key.Save()
+
+	keyBytes, err := os.ReadFile(keyFile)
+	require.NoError(t, err)
+	require.True(t, armor.IsArmored(keyBytes))
+
+	calls := 0
+	passphrase := func() ([]byte, error) {
+		calls++
+		return []byte("passphrase"), nil
+	}
+	loaded, err := LoadOrGenFilePVWithPassphrase(keyFile, stateFile, nil, passphrase)
+	require.NoError(t, err)
+	assert.Equal(t, privVal.Key.PrivKey, loaded.Key.PrivKey)
+	assert.True(t, loaded.Key.IsEncrypted())
+	assert.Equal(t, 1, calls)
+
+	// The key remains encrypted when it is saved again, e.g. on reset.
+	loaded.Save()
+	keyBytes, err = os.ReadFile(keyFile)
+	require.NoError(t, err)
+	require.True(t, armor.IsArmored(keyBytes))
+
+	// Decrypt the key in place.
+	loaded.Key.SetPassphrase(nil)
+	loaded.Key.Save()
+	loaded = LoadFilePV(keyFile, stateFile)
+	assert.Equal(t, privVal.Key.PrivKey, loaded.Key.PrivKey)
+	assert.False(t, loaded.Key.IsEncrypted())
+}
+
+func TestRotateKey(t *testing.T) {
+	privVal, keyFile, stateFile := newTestFilePV(t, nil)
+	privVal.Key.SetPassphrase([]byte("passphrase"))
+	privVal.Save()
+	nextKeyFile := keyFile + ".next"
+	passphrase := func() ([]byte, error) { return []byte("passphrase"), nil }
+
+	require.Error(t, privVal.RotateKey())
+	require.Error(t, privVal.StageNextKey(privVal.Key.PrivKey, nextKeyFile))
+	nextKey := ed25519.GenPrivKey()
+	require.NoError(t, privVal.StageNextKey(nextKey, nextKeyFile))
+
+	// The next key is encrypted like the current key.
+	loaded := LoadFilePVWithPassphrase(keyFile, stateFile, passphrase)
+	require.NoError(t, loaded.LoadNextKey(nextKeyFile, passphrase))
+	nextPubKey, err := loaded.NextPubKey()
+	require.NoError(t, err)
+	assert.Equal(t, nextKey.PubKey(), nextPubKey)
+	assert.True(t, loaded.NextKey.IsEncrypted())
+
+	require.NoError(t, loaded.RotateKey())
+	assert.Equal(t, nextKey, loaded.Key.PrivKey)
+	assert.NoFileExists(t, nextKeyFile)
+	loaded = LoadFilePVWithPassphrase(keyFile, stateFile, passphrase)
+	assert.Equal(t, nextKey, loaded.Key.PrivKey)
+
+	// A next key left behind by an interrupted rotation is removed.
+	loaded.Key.filePath = nextKeyFile
+	loaded.Key.Save()
+	loaded.Key.filePath = keyFile
+	require.NoError(t, loaded.LoadNextKey(nextKeyFile, passphrase))
+	assert.Nil(t, loaded.NextKey)
+	assert.NoFileExists(t, nextKeyFile)
+}
+
+func TestWatchNextKey(t *testing.T) {
+	privVal, keyFile, stateFile := newTestFilePV(t, nil)
+	privVal.Save()
+	nextKeyFile := keyFile + ".next"
+	require.NoError(t, privVal.WatchNextKey(nextKeyFile, nil))
+	nextPubKey, err := privVal.NextPubKey()
+	require.NoError(t, err)
+	assert.Nil(t, nextPubKey)
+
+	// The next key is picked up once it is staged by another process.
+	nextKey := ed25519.GenPrivKey()
+	require.NoError(t, LoadFilePVEmptyState(keyFile, stateFile).StageNextKey(nextKey, nextKeyFile))
+	nextPubKey, err = privVal.NextPubKey()
+	require.NoError(t, err)
+	assert.Equal(t, nextKey.PubKey(), nextPubKey)
+
+	require.NoError(t, privVal.RotateKey())
+	nextPubKey, err = privVal.NextPubKey()
+	require.NoError(t, err)
+	assert.Nil(t, nextPubKey)
+}
+
 func TestUnmarshalValidatorState(t *testing.T) {
 	assert, require := assert.New(t), require.New(t)
 
@@ -0,0 +1,217 @@
+package privval
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	pvproto "github.com/cometbft/cometbft/api/cometbft/privval/v2"
+	cmtproto "github.com/cometbft/cometbft/api/cometbft/types/v2"
+	"github.com/cometbft/cometbft/v2/crypto"
+	cryptoenc "github.com/cometbft/cometbft/v2/crypto/encoding"
+	cmtnet "github.com/cometbft/cometbft/v2/internal/net"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+// GRPCSignerClientOption sets an optional parameter on the GRPCSignerClient.
+type GRPCSignerClientOption func(*GRPCSignerClient)
+
+// GRPCSignerClientTimeout sets the timeout of the requests sent to the
+// signer, including the time spent waiting for a connection.
+func GRPCSignerClientTimeout(timeout time.Duration) GRPCSignerClientOption {
+	return func(sc *GRPCSignerClient) { sc.timeout = timeout }
+}
+
+// GRPCSignerClient implements PrivValidator with a remote signer serving the
+// PrivValidatorAPI gRPC service. Unlike SignerClient, the node is the client
+// and connects to the signer.
+type GRPCSignerClient struct {
+	conn    *grpc.ClientConn
+	client  pvproto.PrivValidatorAPIClient
+	chainID string
+	timeout time.Duration
+}
+
+var _ types.PrivValidator = (*GRPCSignerClient)(nil)
+
+// NewGRPCSignerClient returns a GRPCSignerClient for the signer at addr, a
+// tcp:// or unix:// address, using creds to secure the connection (see
+// NewGRPCClientTLSConfig). The connection is established lazily.
+func NewGRPCSignerClient(
+	addr string,
+	chainID string,
+	creds credentials.TransportCredentials,
+	options ...GRPCSignerClientOption,
+) (*GRPCSignerClient, error) {
+	target, err := grpcTarget(addr)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := grpc.NewClient(target, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client for %v: %w", addr, err)
+	}
+	sc := &GRPCSignerClient{
+		conn:    conn,
+		client:  pvproto.NewPrivValidatorAPIClient(conn),
+		chainID: chainID,
+		timeout: defaultTimeoutReadWriteSeconds * time.Second,
+	}
+	for _, option := range options {
+		option(sc)
+	}
+	return sc, nil
+}
+
+// grpcTarget converts a tcp:// or unix:// address into a gRPC target.
+func grpcTarget(addr string) (string, error) {
+	protocol, address := cmtnet.ProtocolAndAddress(addr)
+	switch protocol {
+	case "tcp":
+		return address, nil
+	case "unix":
+		return "unix://" + address, nil
+	default:
+		return "", fmt.Errorf("wrong address %v: expected either 'tcp' or 'unix' protocols, got %s", addr, protocol)
+	}
+}
+
+// Close closes the connection to the signer.
+func (sc *GRPCSignerClient) Close() error {
+	return sc.conn.Close()
+}
+
+func (sc *GRPCSignerClient) context() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), sc.timeout)
+}
+
+// --------------------------------------------------------
+// Implement PrivValidator
+
+// Ping sends a ping request to the remote signer.
+func (sc *GRPCSignerClient) Ping() error {
+	ctx, cancel := sc.context()
+	defer cancel()
+	_, err := sc.client.Ping(ctx, &pvproto.PingRequest{}, grpc.WaitForReady(true))
+	return err
+}
+
+// GetPubKey retrieves a public key from the remote signer.
+func (sc *GRPCSignerClient) GetPubKey() (crypto.PubKey, error) {
+	ctx, cancel := sc.context()
+	defer cancel()
+	resp, err := sc.client.GetPubKey(ctx, &pvproto.PubKeyRequest{ChainId: sc.chainID}, grpc.WaitForReady(true))
+	if err != nil {
+		return nil, fmt.Errorf("send: %w", err)
+	}
+	if resp.Error != nil {
+		return nil, &RemoteSignerError{Code: int(resp.Error.Code), Description: resp.Error.Description}
+	}
+	return cryptoenc.PubKeyFromTypeAndBytes(resp.PubKeyType, resp.PubKeyBytes)
+}
+
+// SignVote requests the remote signer to sign a vote.
+func (sc *GRPCSignerClient) SignVote(chainID string, vote *cmtproto.Vote, signExtension bool) error {
+	ctx, cancel := sc.context()
+	defer cancel()
+	resp, err := sc.client.SignVote(ctx,
+		&pvproto.SignVoteRequest{Vote: vote, ChainId: chainID, SkipExtensionSigning: !signExtension},
+		grpc.WaitForReady(true))
+	if err != nil {
+		return err
+	}
+	if resp.Error != nil {
+		return &RemoteSignerError{Code: int(resp.Error.Code), Description: resp.Error.Description}
+	}
+	*vote = resp.Vote
+	return nil
+}
+
+// SignProposal requests the remote signer to sign a proposal.
+func (sc *GRPCSignerClient) SignProposal(chainID string, proposal *cmtproto.Proposal) error {
+	ctx, cancel := sc.context()
+	defer cancel()
+	resp, err := sc.client.SignProposal(ctx,
+		&pvproto.SignProposalRequest{Proposal: proposal, ChainId: chainID},
+		grpc.WaitForReady(true))
+	if err != nil {
+		return err
+	}
+	if resp.Error != nil {
+		return &RemoteSignerError{Code: int(resp.Error.Code), Description: resp.Error.Description}
+	}
+	*proposal = resp.Proposal
+	return nil
+}
+
+// SignBytes requests the remote signer to sign bytes.
+func (sc *GRPCSignerClient) SignBytes(bytes []byte) ([]byte, error) {
+	ctx, cancel := sc.context()
+	defer cancel()
+	resp, err := sc.client.SignBytes(ctx, &pvproto.SignBytesRequest{Value: bytes}, grpc.WaitForReady(true))
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != nil {
+		return nil, &RemoteSignerError{Code: int(resp.Error.Code), Description: resp.Error.Description}
+	}
+	return resp.Signature, nil
+}
+
+// --------------------------------------------------------
+// Mutual TLS
+
+// NewGRPCClientTLSConfig returns the TLS configuration of a node connecting to
+// a PrivValidatorAPI server, authenticating with the certificate in certFile
+// and keyFile, and only accepting servers with a certificate signed by the CA
+// in caFile.
+func NewGRPCClientTLSConfig(certFile, keyFile, caFile string) (*tls.Config, error) {
+	cert, pool, err := loadMutualTLSFiles(certFile, keyFile, caFile)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+		MinVersion:   tls.VersionTLS13,
+	}, nil
+}
+
+// NewGRPCServerTLSConfig returns the TLS configuration of a PrivValidatorAPI
+// server, authenticating with the certificate in certFile and keyFile, and
+// only accepting clients with a certificate signed by the CA in caFile.
+func NewGRPCServerTLSConfig(certFile, keyFile, caFile string) (*tls.Config, error) {
+	cert, pool, err := loadMutualTLSFiles(certFile, keyFile, caFile)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    pool,
+		MinVersion:   tls.VersionTLS13,
+	}, nil
+}
+
+func loadMutualTLSFiles(certFile, keyFile, caFile string) (tls.Certificate, *x509.CertPool, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return tls.Certificate{}, nil, fmt.Errorf("failed to load TLS key pair: %w", err)
+	}
+	caPEM, err := os.ReadFile(caFile)
+	if err != nil {
+		return tls.Certificate{}, nil, fmt.Errorf("failed to read CA certificate: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return tls.Certificate{}, nil, errors.New("no CA certificate found in " + caFile)
+	}
+	return cert, pool, nil
+}
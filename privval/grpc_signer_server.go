@@ -0,0 +1,113 @@
+package privval
+
+import (
+	"context"
+
+	"github.com/cosmos/gogoproto/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pvproto "github.com/cometbft/cometbft/api/cometbft/privval/v2"
+	"github.com/cometbft/cometbft/v2/libs/log"
+	cmtsync "github.com/cometbft/cometbft/v2/libs/sync"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+// GRPCSignerServer implements the PrivValidatorAPI gRPC service with a
+// PrivValidator. It validates and serves requests like the SignerServer of
+// the socket protocol, one at a time.
+//
+// Register it with a gRPC server using
+// pvproto.RegisterPrivValidatorAPIServer.
+type GRPCSignerServer struct {
+	chainID string
+	logger  log.Logger
+
+	mtx     cmtsync.Mutex
+	privVal types.PrivValidator
+}
+
+var _ pvproto.PrivValidatorAPIServer = (*GRPCSignerServer)(nil)
+
+// NewGRPCSignerServer returns a GRPCSignerServer signing messages of the given
+// chain with privVal.
+func NewGRPCSignerServer(chainID string, privVal types.PrivValidator, logger log.Logger) *GRPCSignerServer {
+	return &GRPCSignerServer{
+		chainID: chainID,
+		logger:  logger,
+		privVal: privVal,
+	}
+}
+
+// GetPubKey implements PrivValidatorAPIServer.
+func (ss *GRPCSignerServer) GetPubKey(_ context.Context, req *pvproto.PubKeyRequest) (*pvproto.PubKeyResponse, error) {
+	res, err := ss.handle(req)
+	if r := res.GetPubKeyResponse(); r != nil {
+		return r, nil
+	}
+	return nil, rejectedRequestError(res, err)
+}
+
+// SignVote implements PrivValidatorAPIServer.
+func (ss *GRPCSignerServer) SignVote(_ context.Context, req *pvproto.SignVoteRequest) (*pvproto.SignedVoteResponse, error) {
+	if req.Vote == nil {
+		return nil, status.Error(codes.InvalidArgument, "nil vote")
+	}
+	res, err := ss.handle(req)
+	if r := res.GetSignedVoteResponse(); r != nil {
+		return r, nil
+	}
+	return nil, rejectedRequestError(res, err)
+}
+
+// SignProposal implements PrivValidatorAPIServer.
+func (ss *GRPCSignerServer) SignProposal(_ context.Context, req *pvproto.SignProposalRequest) (*pvproto.SignedProposalResponse, error) {
+	if req.Proposal == nil {
+		return nil, status.Error(codes.InvalidArgument, "nil proposal")
+	}
+	res, err := ss.handle(req)
+	if r := res.GetSignedProposalResponse(); r != nil {
+		return r, nil
+	}
+	return nil, rejectedRequestError(res, err)
+}
+
+// SignBytes implements PrivValidatorAPIServer.
+func (ss *GRPCSignerServer) SignBytes(_ context.Context, req *pvproto.SignBytesRequest) (*pvproto.SignBytesResponse, error) {
+	res, err := ss.handle(req)
+	if r := res.GetSignBytesResponse(); r != nil {
+		return r, nil
+	}
+	return nil, rejectedRequestError(res, err)
+}
+
+// Ping implements PrivValidatorAPIServer.
+func (*GRPCSignerServer) Ping(context.Context, *pvproto.PingRequest) (*pvproto.PingResponse, error) {
+	return &pvproto.PingResponse{}, nil
+}
+
+// rejectedRequestError returns the gRPC error of a request whose response res
+// is not of the expected type: the error of the request handler if any, or an
+// internal error otherwise.
+func rejectedRequestError(res pvproto.Message, err error) error {
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return status.Errorf(codes.Internal, "unexpected response type %T", res.GetSum())
+}
+
+// handle serves the request with DefaultValidationRequestHandler, so that both
+// transports validate requests the same way. Like for the socket protocol,
+// signing errors are returned in the response; the error is only returned to
+// the client if the request is rejected without a response of the expected
+// type, e.g. because it is for another chain.
+func (ss *GRPCSignerServer) handle(req proto.Message) (pvproto.Message, error) {
+	ss.mtx.Lock()
+	defer ss.mtx.Unlock()
+
+	res, err := DefaultValidationRequestHandler(ss.privVal, mustWrapMsg(req), ss.chainID)
+	if err != nil {
+		ss.logger.Error("GRPCSignerServer: handleMessage", "err", err)
+	}
+	return res, err
+}
@@ -0,0 +1,147 @@
+package privval
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	pvproto "github.com/cometbft/cometbft/api/cometbft/privval/v2"
+	"github.com/cometbft/cometbft/v2/crypto/tmhash"
+	cmtrand "github.com/cometbft/cometbft/v2/internal/rand"
+	"github.com/cometbft/cometbft/v2/libs/log"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+// writeTestCert writes a certificate for 127.0.0.1 and its key to dir, signed
+// by the given CA or self-signed if ca is nil.
+func writeTestCert(t *testing.T, dir, name string, ca *x509.Certificate, caKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(cmtrand.Int63()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	if ca == nil {
+		tmpl.IsCA, tmpl.BasicConstraintsValid = true, true
+		ca, caKey = tmpl, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca, &key.PublicKey, caKey)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name+".crt"), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name+".key"), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert, key
+}
+
+func TestGRPCSigner(t *testing.T) {
+	const chainID = "test-chain"
+	dir := t.TempDir()
+	ca, caKey := writeTestCert(t, dir, "ca", nil, nil)
+	writeTestCert(t, dir, "server", ca, caKey)
+	writeTestCert(t, dir, "client", ca, caKey)
+	path := func(name string) string { return filepath.Join(dir, name) }
+
+	serverTLS, err := NewGRPCServerTLSConfig(path("server.crt"), path("server.key"), path("ca.crt"))
+	require.NoError(t, err)
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	srv := grpc.NewServer(grpc.Creds(credentials.NewTLS(serverTLS)))
+	filePV, _, _ := newTestFilePV(t, nil)
+	pvproto.RegisterPrivValidatorAPIServer(srv, NewGRPCSignerServer(chainID, filePV, log.TestingLogger()))
+	go func() { _ = srv.Serve(ln) }()
+	t.Cleanup(srv.Stop)
+	addr := "tcp://" + ln.Addr().String()
+
+	clientTLS, err := NewGRPCClientTLSConfig(path("client.crt"), path("client.key"), path("ca.crt"))
+	require.NoError(t, err)
+	sc, err := NewGRPCSignerClient(addr, chainID, credentials.NewTLS(clientTLS))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, sc.Close()) })
+
+	require.NoError(t, sc.Ping())
+	pubKey, err := sc.GetPubKey()
+	require.NoError(t, err)
+	require.Equal(t, filePV.Key.PubKey, pubKey)
+
+	randbytes := cmtrand.Bytes(tmhash.Size)
+	blockID := types.BlockID{Hash: randbytes, PartSetHeader: types.PartSetHeader{Total: 5, Hash: randbytes}}
+
+	proposal := newProposal(1, 0, blockID).ToProto()
+	require.NoError(t, sc.SignProposal(chainID, proposal))
+	require.True(t, pubKey.VerifySignature(types.ProposalSignBytes(chainID, proposal), proposal.Signature))
+
+	vote := newVote(pubKey.Address(), 1, 0, types.PrecommitType, blockID)
+	vote.Extension = []byte("extension")
+	vp := vote.ToProto()
+	require.NoError(t, sc.SignVote(chainID, vp, true))
+	vote, err = types.VoteFromProto(vp)
+	require.NoError(t, err)
+	require.NoError(t, vote.VerifyVoteAndExtension(chainID, pubKey))
+
+	sig, err := sc.SignBytes([]byte("hello"))
+	require.NoError(t, err)
+	require.True(t, pubKey.VerifySignature([]byte("hello"), sig))
+
+	// Requests for another chain are rejected.
+	require.Error(t, sc.SignProposal("other-chain", newProposal(2, 0, blockID).ToProto()))
+
+	// Signing errors are returned as RemoteSignerError.
+	randbytes2 := cmtrand.Bytes(tmhash.Size)
+	blockID2 := types.BlockID{Hash: randbytes2, PartSetHeader: types.PartSetHeader{Total: 5, Hash: randbytes2}}
+	err = sc.SignVote(chainID, newVote(pubKey.Address(), 1, 0, types.PrecommitType, blockID2).ToProto(), false)
+	require.ErrorAs(t, err, new(*RemoteSignerError))
+
+	// Clients without a certificate signed by the CA are rejected.
+	otherDir := t.TempDir()
+	otherCA, otherCAKey := writeTestCert(t, otherDir, "ca", nil, nil)
+	writeTestCert(t, otherDir, "client", otherCA, otherCAKey)
+	otherTLS, err := NewGRPCClientTLSConfig(filepath.Join(otherDir, "client.crt"), filepath.Join(otherDir, "client.key"), path("ca.crt"))
+	require.NoError(t, err)
+	other, err := NewGRPCSignerClient(addr, chainID, credentials.NewTLS(otherTLS), GRPCSignerClientTimeout(time.Second))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, other.Close()) })
+	_, err = other.GetPubKey()
+	require.Error(t, err)
+
+	insecureClient, err := NewGRPCSignerClient(addr, chainID, insecure.NewCredentials(), GRPCSignerClientTimeout(time.Second))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, insecureClient.Close()) })
+	require.Error(t, insecureClient.Ping())
+}
+
+func TestGRPCSignerServerRejectedRequestError(t *testing.T) {
+	// A response of another type without error must not panic.
+	res := mustWrapMsg(&pvproto.PingResponse{})
+	err := rejectedRequestError(res, nil)
+	require.Equal(t, codes.Internal, status.Code(err))
+	require.ErrorContains(t, err, "unexpected response type")
+
+	err = rejectedRequestError(res, errors.New("wrong chain"))
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,46 @@
+// Code generated by metricsgen. DO NOT EDIT.
+
+package privval
+
+import (
+	"github.com/cometbft/cometbft/v2/libs/metrics/discard"
+	prometheus "github.com/cometbft/cometbft/v2/libs/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		SignerRequests: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "signer_requests",
+			Help:      "Number of requests sent to each remote signer of a threshold signer, by method and status (ok, refused, invalid or error).",
+		}, append(labels, "signer", "method", "status")).With(labelsAndValues...),
+		SignerRequestDurationSeconds: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "signer_request_duration_seconds",
+			Help:      "Duration of the requests sent to each remote signer of a threshold signer, by method.",
+
+			Buckets: []float64{.001, .005, .01, .05, .1, .5, 1, 5},
+		}, append(labels, "signer", "method")).With(labelsAndValues...),
+		SignerUp: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "signer_up",
+			Help:      "Whether the last request sent to each remote signer of a threshold signer succeeded (1) or not (0).",
+		}, append(labels, "signer")).With(labelsAndValues...),
+	}
+}
+
+func NopMetrics() *Metrics {
+	return &Metrics{
+		SignerRequests:               discard.NewCounter(),
+		SignerRequestDurationSeconds: discard.NewHistogram(),
+		SignerUp:                     discard.NewGauge(),
+	}
+}
@@ -0,0 +1,28 @@
+package privval
+
+import (
+	"github.com/cometbft/cometbft/v2/libs/metrics"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "privval"
+)
+
+//go:generate go run ../scripts/metricsgen -struct=Metrics
+
+// Metrics contains the prometheus metrics exposed by the privval package.
+type Metrics struct {
+	// Number of requests sent to each remote signer of a threshold signer, by
+	// method and status (ok, refused, invalid or error).
+	SignerRequests metrics.Counter `metrics_labels:"signer, method, status"`
+
+	// Duration of the requests sent to each remote signer of a threshold
+	// signer, by method.
+	SignerRequestDurationSeconds metrics.Histogram `metrics_bucketsizes:".001,.005,.01,.05,.1,.5,1,5" metrics_labels:"signer, method"`
+
+	// Whether the last request sent to each remote signer of a threshold signer
+	// succeeded (1) or not (0).
+	SignerUp metrics.Gauge `metrics_labels:"signer"`
+}
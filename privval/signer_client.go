@@ -56,12 +56,12 @@ func (sc *SignerClient) Ping() error {
 	response, err := sc.endpoint.SendRequest(mustWrapMsg(&pvproto.PingRequest{}))
 	if err != nil {
 		sc.endpoint.Logger.Error("SignerClient::Ping", "err", err)
-		return nil
+		return err
 	}
 
 	pb := response.GetPingResponse()
 	if pb == nil {
-		return err
+		return cmterrors.ErrRequiredField{Field: "response"}
 	}
 
 	return nil
@@ -0,0 +1,390 @@
+package privval
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	"github.com/cometbft/cometbft/v2/crypto"
+	"github.com/cometbft/cometbft/v2/crypto/tmhash"
+	cmtbytes "github.com/cometbft/cometbft/v2/libs/bytes"
+	cmtjson "github.com/cometbft/cometbft/v2/libs/json"
+	cmtsync "github.com/cometbft/cometbft/v2/libs/sync"
+)
+
+const (
+	// SlashingProtectionDBID is the ID of the slashing-protection database,
+	// used to open it with a config.DBProvider.
+	SlashingProtectionDBID = "slashing_protection"
+
+	// InterchangeFormatVersion is the version of the interchange format
+	// produced by SlashingProtectionDB.Export.
+	InterchangeFormatVersion = "1"
+)
+
+var (
+	// ErrSlashingProtectionConflict is returned when the data to sign conflicts
+	// with data previously signed for the same height, round and step.
+	ErrSlashingProtectionConflict = errors.New("conflicting data previously signed for the same height, round and step")
+	// ErrSlashingProtectionRegression is returned when the height, round and
+	// step to sign are lower than those of data previously signed.
+	ErrSlashingProtectionRegression = errors.New("height, round and step lower than those of data previously signed")
+
+	signedRecordKeyPrefix = []byte("sr:")
+)
+
+// SignedRecord records that a validator key signed data for a given height,
+// round and step.
+type SignedRecord struct {
+	Height int64 `json:"height"`
+	Round  int32 `json:"round"`
+	Step   int8  `json:"step"`
+	// The hash of the signed bytes.
+	SignBytesHash cmtbytes.HexBytes `json:"sign_bytes_hash"`
+}
+
+// ValidateBasic performs basic validation of the record.
+func (r SignedRecord) ValidateBasic() error {
+	if r.Height <= 0 {
+		return fmt.Errorf("invalid height %d", r.Height)
+	}
+	if r.Round < 0 {
+		return fmt.Errorf("invalid round %d", r.Round)
+	}
+	if r.Step < stepPropose || r.Step > stepPrecommit {
+		return fmt.Errorf("invalid step %d", r.Step)
+	}
+	if len(r.SignBytesHash) != tmhash.Size {
+		return fmt.Errorf("invalid sign bytes hash size %d, expected %d", len(r.SignBytesHash), tmhash.Size)
+	}
+	return nil
+}
+
+// compareHRS compares the height, round and step of the record to the given
+// ones, returning -1, 0 or 1.
+func (r SignedRecord) compareHRS(height int64, round int32, step int8) int {
+	switch {
+	case r.Height != height:
+		return cmpInt(r.Height, height)
+	case r.Round != round:
+		return cmpInt(r.Round, round)
+	default:
+		return cmpInt(r.Step, step)
+	}
+}
+
+func cmpInt[T int64 | int32 | int8](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// SlashingProtectionInterchange is the JSON document used to move the
+// slashing-protection data of validator keys between machines.
+type SlashingProtectionInterchange struct {
+	Metadata InterchangeMetadata    `json:"metadata"`
+	Data     []InterchangeValidator `json:"data"`
+}
+
+// InterchangeMetadata describes a SlashingProtectionInterchange.
+type InterchangeMetadata struct {
+	InterchangeFormatVersion string `json:"interchange_format_version"`
+}
+
+// InterchangeValidator contains the records of a validator key. Export sorts
+// them by height, round and step.
+type InterchangeValidator struct {
+	Address crypto.Address `json:"address"`
+	Signed  []SignedRecord `json:"signed"`
+}
+
+// SlashingProtectionDB records all the votes and proposals signed by validator
+// keys, and refuses to sign data conflicting with them. Unlike the last sign
+// state of FilePV, it keeps the whole signing history of the keys, which can
+// be exported and imported when a validator is migrated to another machine.
+//
+// Data may be signed for a height, round and step (HRS) only if it is higher
+// than the HRS of all the records of the key, or if the exact same data was
+// already signed for that HRS.
+type SlashingProtectionDB struct {
+	mtx cmtsync.Mutex
+	db  dbm.DB
+}
+
+// NewSlashingProtectionDB returns a slashing-protection database persisting
+// its records to db.
+func NewSlashingProtectionDB(db dbm.DB) *SlashingProtectionDB {
+	return &SlashingProtectionDB{db: db}
+}
+
+// CheckAndRecord checks that the key with the given address may sign
+// signBytes for the given height, round and step, and records it if so. The
+// record is persisted before returning, so it must be called before signing.
+func (spdb *SlashingProtectionDB) CheckAndRecord(address crypto.Address, height int64, round int32, step int8, signBytes []byte) error {
+	record := SignedRecord{
+		Height:        height,
+		Round:         round,
+		Step:          step,
+		SignBytesHash: tmhash.Sum(signBytes),
+	}
+	if err := record.ValidateBasic(); err != nil {
+		return err
+	}
+
+	spdb.mtx.Lock()
+	defer spdb.mtx.Unlock()
+
+	existing, err := spdb.get(address, height, round, step)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		if !bytes.Equal(existing.SignBytesHash, record.SignBytesHash) {
+			return ErrSlashingProtectionConflict
+		}
+		return nil
+	}
+
+	last, err := spdb.last(address)
+	if err != nil {
+		return err
+	}
+	if last != nil && last.compareHRS(height, round, step) > 0 {
+		return fmt.Errorf("%w: got %d/%d/%d, last signed %d/%d/%d", ErrSlashingProtectionRegression,
+			height, round, step, last.Height, last.Round, last.Step)
+	}
+
+	return spdb.db.SetSync(signedRecordKey(address, height, round, step), record.SignBytesHash)
+}
+
+// SignedRecords returns the records of the key with the given address, sorted
+// by height, round and step.
+func (spdb *SlashingProtectionDB) SignedRecords(address crypto.Address) ([]SignedRecord, error) {
+	spdb.mtx.Lock()
+	defer spdb.mtx.Unlock()
+
+	prefix := addressKeyPrefix(address)
+	it, err := dbm.IteratePrefix(spdb.db, prefix)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	var records []SignedRecord
+	for ; it.Valid(); it.Next() {
+		_, record, err := decodeSignedRecord(it.Key(), it.Value())
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, it.Error()
+}
+
+// Export returns the records of all the keys in the interchange format.
+func (spdb *SlashingProtectionDB) Export() (*SlashingProtectionInterchange, error) {
+	spdb.mtx.Lock()
+	defer spdb.mtx.Unlock()
+
+	it, err := dbm.IteratePrefix(spdb.db, signedRecordKeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	interchange := &SlashingProtectionInterchange{
+		Metadata: InterchangeMetadata{InterchangeFormatVersion: InterchangeFormatVersion},
+		Data:     []InterchangeValidator{},
+	}
+	for ; it.Valid(); it.Next() {
+		address, record, err := decodeSignedRecord(it.Key(), it.Value())
+		if err != nil {
+			return nil, err
+		}
+		// Keys are sorted by address, so the records of a key are contiguous.
+		if n := len(interchange.Data); n == 0 || !bytes.Equal(interchange.Data[n-1].Address, address) {
+			interchange.Data = append(interchange.Data, InterchangeValidator{Address: address})
+		}
+		v := &interchange.Data[len(interchange.Data)-1]
+		v.Signed = append(v.Signed, record)
+	}
+	return interchange, it.Error()
+}
+
+// Import adds the records of the interchange to the database. Records already
+// in the database are kept. It fails without importing anything if a record
+// is invalid, or conflicts with another record for the same key and height,
+// round and step.
+func (spdb *SlashingProtectionDB) Import(interchange *SlashingProtectionInterchange) error {
+	if v := interchange.Metadata.InterchangeFormatVersion; v != InterchangeFormatVersion {
+		return fmt.Errorf("unsupported interchange format version %q, expected %q", v, InterchangeFormatVersion)
+	}
+
+	spdb.mtx.Lock()
+	defer spdb.mtx.Unlock()
+
+	batch := spdb.db.NewBatch()
+	defer batch.Close()
+
+	added := make(map[string][]byte)
+	for _, v := range interchange.Data {
+		if len(v.Address) != crypto.AddressSize {
+			return fmt.Errorf("invalid address %X", v.Address)
+		}
+		for _, record := range v.Signed {
+			if err := record.ValidateBasic(); err != nil {
+				return fmt.Errorf("invalid record of %X: %w", v.Address, err)
+			}
+			key := signedRecordKey(v.Address, record.Height, record.Round, record.Step)
+			hash, ok := added[string(key)]
+			if !ok {
+				existing, err := spdb.db.Get(key)
+				if err != nil {
+					return err
+				}
+				hash = existing
+			}
+			if hash != nil {
+				if !bytes.Equal(hash, record.SignBytesHash) {
+					return fmt.Errorf("record of %X at %d/%d/%d: %w", v.Address,
+						record.Height, record.Round, record.Step, ErrSlashingProtectionConflict)
+				}
+				continue
+			}
+			if err := batch.Set(key, record.SignBytesHash); err != nil {
+				return err
+			}
+			added[string(key)] = record.SignBytesHash
+		}
+	}
+	return batch.WriteSync()
+}
+
+// Reset removes the records of the key with the given address.
+// NOTE: Unsafe!
+func (spdb *SlashingProtectionDB) Reset(address crypto.Address) error {
+	spdb.mtx.Lock()
+	defer spdb.mtx.Unlock()
+
+	it, err := dbm.IteratePrefix(spdb.db, addressKeyPrefix(address))
+	if err != nil {
+		return err
+	}
+	var keys [][]byte
+	for ; it.Valid(); it.Next() {
+		keys = append(keys, it.Key())
+	}
+	if err := it.Error(); err != nil {
+		it.Close()
+		return err
+	}
+	it.Close()
+
+	batch := spdb.db.NewBatch()
+	defer batch.Close()
+	for _, key := range keys {
+		if err := batch.Delete(key); err != nil {
+			return err
+		}
+	}
+	return batch.WriteSync()
+}
+
+// Close closes the underlying database.
+func (spdb *SlashingProtectionDB) Close() error {
+	return spdb.db.Close()
+}
+
+// get returns the record of the key at the given height, round and step, or
+// nil if there is none.
+func (spdb *SlashingProtectionDB) get(address crypto.Address, height int64, round int32, step int8) (*SignedRecord, error) {
+	hash, err := spdb.db.Get(signedRecordKey(address, height, round, step))
+	if err != nil || hash == nil {
+		return nil, err
+	}
+	return &SignedRecord{Height: height, Round: round, Step: step, SignBytesHash: hash}, nil
+}
+
+// last returns the record of the key with the highest height, round and step,
+// or nil if there is none.
+func (spdb *SlashingProtectionDB) last(address crypto.Address) (*SignedRecord, error) {
+	prefix := addressKeyPrefix(address)
+	it, err := spdb.db.ReverseIterator(prefix, prefixEnd(prefix))
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	if !it.Valid() {
+		return nil, it.Error()
+	}
+	_, record, err := decodeSignedRecord(it.Key(), it.Value())
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// MarshalInterchange encodes the interchange to indented JSON.
+func MarshalInterchange(interchange *SlashingProtectionInterchange) ([]byte, error) {
+	return cmtjson.MarshalIndent(interchange, "", "  ")
+}
+
+// UnmarshalInterchange decodes an interchange encoded in JSON.
+func UnmarshalInterchange(bz []byte) (*SlashingProtectionInterchange, error) {
+	interchange := &SlashingProtectionInterchange{}
+	if err := cmtjson.Unmarshal(bz, interchange); err != nil {
+		return nil, err
+	}
+	return interchange, nil
+}
+
+// Records are keyed by address, height, round and step, encoded so that keys
+// sort in that order.
+func addressKeyPrefix(address crypto.Address) []byte {
+	key := make([]byte, 0, len(signedRecordKeyPrefix)+len(address))
+	key = append(key, signedRecordKeyPrefix...)
+	return append(key, address...)
+}
+
+func signedRecordKey(address crypto.Address, height int64, round int32, step int8) []byte {
+	key := addressKeyPrefix(address)
+	key = binary.BigEndian.AppendUint64(key, uint64(height))
+	key = binary.BigEndian.AppendUint32(key, uint32(round))
+	return append(key, byte(step))
+}
+
+func decodeSignedRecord(key, value []byte) (crypto.Address, SignedRecord, error) {
+	rest := key[len(signedRecordKeyPrefix):]
+	if len(rest) != crypto.AddressSize+8+4+1 {
+		return nil, SignedRecord{}, fmt.Errorf("invalid slashing-protection record key %X", key)
+	}
+	address := crypto.Address(bytes.Clone(rest[:crypto.AddressSize]))
+	rest = rest[crypto.AddressSize:]
+	return address, SignedRecord{
+		Height:        int64(binary.BigEndian.Uint64(rest[:8])),
+		Round:         int32(binary.BigEndian.Uint32(rest[8:12])),
+		Step:          int8(rest[12]),
+		SignBytesHash: bytes.Clone(value),
+	}, nil
+}
+
+// prefixEnd returns the end of the range of the keys starting with prefix.
+func prefixEnd(prefix []byte) []byte {
+	end := bytes.Clone(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		end[i]++
+		if end[i] != 0 {
+			return end[:i+1]
+		}
+	}
+	return nil
+}
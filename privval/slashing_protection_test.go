@@ -0,0 +1,138 @@
+package privval
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+
+	"github.com/cometbft/cometbft/v2/crypto/ed25519"
+	"github.com/cometbft/cometbft/v2/crypto/tmhash"
+	cmtrand "github.com/cometbft/cometbft/v2/internal/rand"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+func TestSlashingProtectionCheckAndRecord(t *testing.T) {
+	spdb := NewSlashingProtectionDB(dbm.NewMemDB())
+	addr := ed25519.GenPrivKey().PubKey().Address()
+	otherAddr := ed25519.GenPrivKey().PubKey().Address()
+
+	require.NoError(t, spdb.CheckAndRecord(addr, 10, 1, stepPrevote, []byte("a")))
+	// Signing the same data again is allowed.
+	require.NoError(t, spdb.CheckAndRecord(addr, 10, 1, stepPrevote, []byte("a")))
+	// Conflicting data for the same height, round and step.
+	require.ErrorIs(t, spdb.CheckAndRecord(addr, 10, 1, stepPrevote, []byte("b")), ErrSlashingProtectionConflict)
+	// Regressions.
+	require.ErrorIs(t, spdb.CheckAndRecord(addr, 10, 1, stepPropose, []byte("c")), ErrSlashingProtectionRegression)
+	require.ErrorIs(t, spdb.CheckAndRecord(addr, 10, 0, stepPrecommit, []byte("c")), ErrSlashingProtectionRegression)
+	require.ErrorIs(t, spdb.CheckAndRecord(addr, 9, 5, stepPrecommit, []byte("c")), ErrSlashingProtectionRegression)
+	// Progress.
+	require.NoError(t, spdb.CheckAndRecord(addr, 10, 1, stepPrecommit, []byte("d")))
+	require.NoError(t, spdb.CheckAndRecord(addr, 11, 0, stepPropose, []byte("e")))
+	// Old data already signed can still be signed again.
+	require.NoError(t, spdb.CheckAndRecord(addr, 10, 1, stepPrevote, []byte("a")))
+	// Keys are independent.
+	require.NoError(t, spdb.CheckAndRecord(otherAddr, 1, 0, stepPrevote, []byte("f")))
+	// Invalid records.
+	require.Error(t, spdb.CheckAndRecord(addr, 0, 0, stepPrevote, []byte("g")))
+	require.Error(t, spdb.CheckAndRecord(addr, 12, -1, stepPrevote, []byte("g")))
+
+	records, err := spdb.SignedRecords(addr)
+	require.NoError(t, err)
+	require.Equal(t, []SignedRecord{
+		{Height: 10, Round: 1, Step: stepPrevote, SignBytesHash: tmhash.Sum([]byte("a"))},
+		{Height: 10, Round: 1, Step: stepPrecommit, SignBytesHash: tmhash.Sum([]byte("d"))},
+		{Height: 11, Round: 0, Step: stepPropose, SignBytesHash: tmhash.Sum([]byte("e"))},
+	}, records)
+
+	require.NoError(t, spdb.Reset(addr))
+	records, err = spdb.SignedRecords(addr)
+	require.NoError(t, err)
+	require.Empty(t, records)
+	records, err = spdb.SignedRecords(otherAddr)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+}
+
+func TestSlashingProtectionExportImport(t *testing.T) {
+	src := NewSlashingProtectionDB(dbm.NewMemDB())
+	addr1 := ed25519.GenPrivKey().PubKey().Address()
+	addr2 := ed25519.GenPrivKey().PubKey().Address()
+	require.NoError(t, src.CheckAndRecord(addr1, 1, 0, stepPropose, []byte("a")))
+	require.NoError(t, src.CheckAndRecord(addr1, 1, 0, stepPrevote, []byte("b")))
+	require.NoError(t, src.CheckAndRecord(addr2, 5, 2, stepPrecommit, []byte("c")))
+
+	interchange, err := src.Export()
+	require.NoError(t, err)
+	require.Len(t, interchange.Data, 2)
+	bz, err := MarshalInterchange(interchange)
+	require.NoError(t, err)
+	decoded, err := UnmarshalInterchange(bz)
+	require.NoError(t, err)
+	require.Equal(t, interchange, decoded)
+
+	dst := NewSlashingProtectionDB(dbm.NewMemDB())
+	require.NoError(t, dst.CheckAndRecord(addr1, 1, 0, stepPropose, []byte("a")))
+	require.NoError(t, dst.Import(decoded))
+	// Importing twice is a no-op.
+	require.NoError(t, dst.Import(decoded))
+	exported, err := dst.Export()
+	require.NoError(t, err)
+	require.Equal(t, interchange, exported)
+
+	// The imported records protect the keys.
+	require.ErrorIs(t, dst.CheckAndRecord(addr2, 5, 2, stepPrecommit, []byte("d")), ErrSlashingProtectionConflict)
+	require.ErrorIs(t, dst.CheckAndRecord(addr2, 5, 1, stepPrecommit, []byte("d")), ErrSlashingProtectionRegression)
+
+	// Conflicting records are not imported.
+	interchange = &SlashingProtectionInterchange{
+		Metadata: InterchangeMetadata{InterchangeFormatVersion: InterchangeFormatVersion},
+		Data: []InterchangeValidator{{
+			Address: addr1,
+			Signed: []SignedRecord{
+				{Height: 2, Round: 0, Step: stepPrevote, SignBytesHash: tmhash.Sum([]byte("x"))},
+				{Height: 1, Round: 0, Step: stepPrevote, SignBytesHash: tmhash.Sum([]byte("y"))},
+			},
+		}},
+	}
+	require.ErrorIs(t, dst.Import(interchange), ErrSlashingProtectionConflict)
+	records, err := dst.SignedRecords(addr1)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+
+	// Invalid interchanges are rejected.
+	interchange.Metadata.InterchangeFormatVersion = "0"
+	require.Error(t, dst.Import(interchange))
+}
+
+func TestFilePVSlashingProtection(t *testing.T) {
+	chainID := "mychainid"
+	privVal, _, _ := newTestFilePV(t, nil)
+	privVal.SlashingProtection = NewSlashingProtectionDB(dbm.NewMemDB())
+
+	randbytes := cmtrand.Bytes(tmhash.Size)
+	block1 := types.BlockID{Hash: randbytes, PartSetHeader: types.PartSetHeader{Total: 5, Hash: randbytes}}
+	randbytes2 := cmtrand.Bytes(tmhash.Size)
+	block2 := types.BlockID{Hash: randbytes2, PartSetHeader: types.PartSetHeader{Total: 5, Hash: randbytes2}}
+
+	height, round := int64(10), int32(1)
+	require.NoError(t, privVal.SignProposal(chainID, newProposal(height, round, block1).ToProto()))
+	vote := newVote(privVal.Key.Address, height, round, types.PrevoteType, block1).ToProto()
+	require.NoError(t, privVal.SignVote(chainID, vote, false))
+
+	// Losing the last sign state does not allow signing conflicting data.
+	privVal.LastSignState.reset()
+	err := privVal.SignVote(chainID, newVote(privVal.Key.Address, height, round, types.PrevoteType, block2).ToProto(), false)
+	require.ErrorContains(t, err, ErrSlashingProtectionConflict.Error())
+	err = privVal.SignProposal(chainID, newProposal(height-1, round, block2).ToProto())
+	require.ErrorContains(t, err, ErrSlashingProtectionRegression.Error())
+
+	// Identical data may be signed again.
+	sig := vote.Signature
+	require.NoError(t, privVal.SignVote(chainID, vote, false))
+	require.Equal(t, sig, vote.Signature)
+
+	privVal.Reset()
+	require.NoError(t, privVal.SignVote(chainID, newVote(privVal.Key.Address, height, round, types.PrevoteType, block2).ToProto(), false))
+}
@@ -0,0 +1,420 @@
+package privval
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cosmos/gogoproto/proto"
+
+	cmtproto "github.com/cometbft/cometbft/api/cometbft/types/v2"
+	"github.com/cometbft/cometbft/v2/crypto"
+	"github.com/cometbft/cometbft/v2/crypto/bls12381"
+	"github.com/cometbft/cometbft/v2/libs/log"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+// errInvalidSignerResponse is returned when a signer of a threshold signer
+// returns invalid data.
+var errInvalidSignerResponse = errors.New("invalid signer response")
+
+// ThresholdSigner is a remote signer holding a share of a threshold key.
+type ThresholdSigner struct {
+	// Index of the key share, as given by bls12381.SplitPrivKey.
+	Index int
+	// Public key of the key share, used to verify the partial signatures of
+	// the signer.
+	PubKey crypto.PubKey
+	// Client sending requests to the signer, usually a SignerClient.
+	Client types.PrivValidator
+}
+
+// ThresholdSignerClientOption sets an optional parameter on the
+// ThresholdSignerClient.
+type ThresholdSignerClientOption func(*ThresholdSignerClient)
+
+// ThresholdSignerClientMetrics sets the metrics of the client.
+func ThresholdSignerClientMetrics(metrics *Metrics) ThresholdSignerClientOption {
+	return func(tc *ThresholdSignerClient) { tc.metrics = metrics }
+}
+
+// ThresholdSignerClientLogger sets the logger of the client.
+func ThresholdSignerClientLogger(logger log.Logger) ThresholdSignerClientOption {
+	return func(tc *ThresholdSignerClient) { tc.logger = logger }
+}
+
+// ThresholdSignerClient implements PrivValidator with a BLS12-381 key split
+// between several remote signers (see bls12381.SplitPrivKey), a threshold of
+// which must approve each signature.
+//
+// Each request is sent to all the signers, and the partial signatures of the
+// first threshold signers agreeing on the signed data are combined into a
+// signature by the split key. The signers are responsible for protecting their
+// key share against double signing, e.g. by running FilePV with a
+// slashing-protection database, so that the client can only produce
+// conflicting signatures if threshold signers are compromised.
+type ThresholdSignerClient struct {
+	pubKey    crypto.PubKey
+	threshold int
+	signers   []ThresholdSigner
+	metrics   *Metrics
+	logger    log.Logger
+}
+
+var _ types.PrivValidator = (*ThresholdSignerClient)(nil)
+
+// NewThresholdSignerClient returns a ThresholdSignerClient signing with the key
+// whose public key is pubKey, which must have been split between the signers
+// with the given threshold.
+func NewThresholdSignerClient(
+	pubKey crypto.PubKey,
+	threshold int,
+	signers []ThresholdSigner,
+	options ...ThresholdSignerClientOption,
+) (*ThresholdSignerClient, error) {
+	if pubKey.Type() != bls12381.KeyType {
+		return nil, fmt.Errorf("unsupported key type %s, only %s keys can be split", pubKey.Type(), bls12381.KeyType)
+	}
+	if threshold < 1 || threshold > len(signers) {
+		return nil, fmt.Errorf("invalid threshold %d for %d signers", threshold, len(signers))
+	}
+	indexes := make(map[int]bool, len(signers))
+	for _, s := range signers {
+		if s.Index < 1 || indexes[s.Index] {
+			return nil, fmt.Errorf("invalid or duplicate signer index %d", s.Index)
+		}
+		indexes[s.Index] = true
+		if s.PubKey == nil || s.Client == nil {
+			return nil, fmt.Errorf("missing public key or client of signer %d", s.Index)
+		}
+	}
+
+	tc := &ThresholdSignerClient{
+		pubKey:    pubKey,
+		threshold: threshold,
+		signers:   signers,
+		metrics:   NopMetrics(),
+		logger:    log.NewNopLogger(),
+	}
+	for _, option := range options {
+		option(tc)
+	}
+	return tc, nil
+}
+
+// Close closes the clients of the signers.
+func (tc *ThresholdSignerClient) Close() error {
+	var errs []error
+	for _, s := range tc.signers {
+		if c, ok := s.Client.(io.Closer); ok {
+			if err := c.Close(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Ping pings the signers, and returns an error if fewer than threshold signers
+// respond.
+func (tc *ThresholdSignerClient) Ping() error {
+	responses := fanOut(tc, "ping", func(pv types.PrivValidator) (struct{}, error) {
+		pinger, ok := pv.(interface{ Ping() error })
+		if !ok {
+			// Signers that cannot be pinged are reached by requesting their key.
+			_, err := pv.GetPubKey()
+			return struct{}{}, err
+		}
+		return struct{}{}, pinger.Ping()
+	})
+
+	var (
+		up   int
+		errs []string
+	)
+	for range tc.signers {
+		r := <-responses
+		if r.err != nil {
+			tc.observe(r.signer, "ping", r.err)
+			errs = append(errs, fmt.Sprintf("signer %d: %v", r.signer.Index, r.err))
+			continue
+		}
+		tc.observe(r.signer, "ping", nil)
+		up++
+	}
+	if up < tc.threshold {
+		return fmt.Errorf("only %d signers out of %d are up, threshold is %d: %s",
+			up, len(tc.signers), tc.threshold, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// GetPubKey returns the public key of the split key.
+func (tc *ThresholdSignerClient) GetPubKey() (crypto.PubKey, error) {
+	return tc.pubKey, nil
+}
+
+// SignVote requests the signers to sign the vote, and combines their partial
+// signatures.
+func (tc *ThresholdSignerClient) SignVote(chainID string, vote *cmtproto.Vote, signExtension bool) error {
+	const method = "sign_vote"
+	signBytes := types.VoteSignBytes(chainID, vote)
+	withExtension := signExtension && vote.Type == types.PrecommitType && !types.ProtoBlockIDIsNil(&vote.BlockID)
+
+	responses := fanOut(tc, method, func(pv types.PrivValidator) (*cmtproto.Vote, error) {
+		v := proto.Clone(vote).(*cmtproto.Vote)
+		err := pv.SignVote(chainID, v, signExtension)
+		return v, err
+	})
+
+	// The signers may return a vote with the timestamp of a vote they signed
+	// before for the same height, round and step, so the partial signatures
+	// are grouped by signed data.
+	groups := make(map[string][]partialSignature)
+	var errs []string
+	for range tc.signers {
+		r := <-responses
+		err := r.err
+		var v *cmtproto.Vote
+		var partialSignBytes []byte
+		if err == nil {
+			v = r.res
+			partialSignBytes = types.VoteSignBytes(chainID, v)
+			err = tc.verifyVote(r.signer, signBytes, partialSignBytes, v, chainID, withExtension)
+		}
+		tc.observe(r.signer, method, err)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("signer %d: %v", r.signer.Index, err))
+			continue
+		}
+
+		key := string(partialSignBytes)
+		groups[key] = append(groups[key], partialSignature{index: r.signer.Index, vote: v})
+		partials := groups[key]
+		if len(partials) < tc.threshold {
+			continue
+		}
+
+		sig, err := tc.combine(partials, partialSignBytes, func(p partialSignature) []byte { return p.vote.Signature })
+		if err != nil {
+			return err
+		}
+		var extSig, nonRpExtSig []byte
+		if withExtension {
+			extSignBytes, nonRpExtSignBytes := types.VoteExtensionSignBytes(chainID, v)
+			extSig, err = tc.combine(partials, extSignBytes, func(p partialSignature) []byte { return p.vote.ExtensionSignature })
+			if err != nil {
+				return err
+			}
+			nonRpExtSig, err = tc.combine(partials, nonRpExtSignBytes, func(p partialSignature) []byte { return p.vote.NonRpExtensionSignature })
+			if err != nil {
+				return err
+			}
+		}
+
+		vote.Timestamp = v.Timestamp
+		vote.Signature = sig
+		if signExtension {
+			vote.ExtensionSignature = extSig
+			vote.NonRpExtensionSignature = nonRpExtSig
+		}
+		return nil
+	}
+	return tc.thresholdError(errs)
+}
+
+// SignProposal requests the signers to sign the proposal, and combines their
+// partial signatures.
+func (tc *ThresholdSignerClient) SignProposal(chainID string, proposal *cmtproto.Proposal) error {
+	const method = "sign_proposal"
+	signBytes := types.ProposalSignBytes(chainID, proposal)
+
+	responses := fanOut(tc, method, func(pv types.PrivValidator) (*cmtproto.Proposal, error) {
+		p := proto.Clone(proposal).(*cmtproto.Proposal)
+		err := pv.SignProposal(chainID, p)
+		return p, err
+	})
+
+	groups := make(map[string][]partialSignature)
+	var errs []string
+	for range tc.signers {
+		r := <-responses
+		err := r.err
+		var p *cmtproto.Proposal
+		var partialSignBytes []byte
+		if err == nil {
+			p = r.res
+			partialSignBytes = types.ProposalSignBytes(chainID, p)
+			if _, ok := checkProposalsOnlyDifferByTimestamp(signBytes, partialSignBytes); !ok {
+				err = fmt.Errorf("%w: signed a different proposal", errInvalidSignerResponse)
+			} else if !r.signer.PubKey.VerifySignature(partialSignBytes, p.Signature) {
+				err = fmt.Errorf("%w: invalid partial signature", errInvalidSignerResponse)
+			}
+		}
+		tc.observe(r.signer, method, err)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("signer %d: %v", r.signer.Index, err))
+			continue
+		}
+
+		key := string(partialSignBytes)
+		groups[key] = append(groups[key], partialSignature{index: r.signer.Index, proposal: p})
+		partials := groups[key]
+		if len(partials) < tc.threshold {
+			continue
+		}
+
+		sig, err := tc.combine(partials, partialSignBytes, func(p partialSignature) []byte { return p.proposal.Signature })
+		if err != nil {
+			return err
+		}
+		proposal.Timestamp = p.Timestamp
+		proposal.Signature = sig
+		return nil
+	}
+	return tc.thresholdError(errs)
+}
+
+// SignBytes requests the signers to sign the bytes, and combines their partial
+// signatures.
+func (tc *ThresholdSignerClient) SignBytes(bytes []byte) ([]byte, error) {
+	const method = "sign_bytes"
+	responses := fanOut(tc, method, func(pv types.PrivValidator) ([]byte, error) {
+		return pv.SignBytes(bytes)
+	})
+
+	var (
+		partials []partialSignature
+		errs     []string
+	)
+	for range tc.signers {
+		r := <-responses
+		err := r.err
+		if err == nil && !r.signer.PubKey.VerifySignature(bytes, r.res) {
+			err = fmt.Errorf("%w: invalid partial signature", errInvalidSignerResponse)
+		}
+		tc.observe(r.signer, method, err)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("signer %d: %v", r.signer.Index, err))
+			continue
+		}
+
+		partials = append(partials, partialSignature{index: r.signer.Index, bytes: r.res})
+		if len(partials) == tc.threshold {
+			return tc.combine(partials, bytes, func(p partialSignature) []byte { return p.bytes })
+		}
+	}
+	return nil, tc.thresholdError(errs)
+}
+
+// partialSignature is the response of a signer, whose signatures are partial
+// signatures.
+type partialSignature struct {
+	index    int
+	vote     *cmtproto.Vote
+	proposal *cmtproto.Proposal
+	bytes    []byte
+}
+
+// signerResponse is the result of a request sent to a signer.
+type signerResponse[T any] struct {
+	signer *ThresholdSigner
+	res    T
+	err    error
+}
+
+// fanOut sends a request to all the signers concurrently, and returns the
+// channel their responses are sent to. The channel is buffered so that the
+// caller can stop reading once it has enough responses.
+func fanOut[T any](tc *ThresholdSignerClient, method string, request func(types.PrivValidator) (T, error)) <-chan signerResponse[T] {
+	responses := make(chan signerResponse[T], len(tc.signers))
+	for i := range tc.signers {
+		signer := &tc.signers[i]
+		go func() {
+			start := time.Now()
+			res, err := request(signer.Client)
+			tc.metrics.SignerRequestDurationSeconds.
+				With("signer", strconv.Itoa(signer.Index), "method", method).
+				Observe(time.Since(start).Seconds())
+			responses <- signerResponse[T]{signer: signer, res: res, err: err}
+		}()
+	}
+	return responses
+}
+
+// verifyVote checks that the vote returned by a signer only differs from the
+// requested one by its timestamp, and that its partial signatures are valid.
+func (*ThresholdSignerClient) verifyVote(
+	signer *ThresholdSigner,
+	signBytes, partialSignBytes []byte,
+	vote *cmtproto.Vote,
+	chainID string,
+	withExtension bool,
+) error {
+	if _, ok := checkVotesOnlyDifferByTimestamp(signBytes, partialSignBytes); !ok {
+		return fmt.Errorf("%w: signed a different vote", errInvalidSignerResponse)
+	}
+	if !signer.PubKey.VerifySignature(partialSignBytes, vote.Signature) {
+		return fmt.Errorf("%w: invalid partial signature", errInvalidSignerResponse)
+	}
+	if withExtension {
+		extSignBytes, nonRpExtSignBytes := types.VoteExtensionSignBytes(chainID, vote)
+		if !signer.PubKey.VerifySignature(extSignBytes, vote.ExtensionSignature) ||
+			!signer.PubKey.VerifySignature(nonRpExtSignBytes, vote.NonRpExtensionSignature) {
+			return fmt.Errorf("%w: invalid partial extension signature", errInvalidSignerResponse)
+		}
+	}
+	return nil
+}
+
+// combine combines the partial signatures of msg into a signature by the split
+// key, and verifies it.
+func (tc *ThresholdSignerClient) combine(partials []partialSignature, msg []byte, sig func(partialSignature) []byte) ([]byte, error) {
+	indexes := make([]int, len(partials))
+	sigs := make([][]byte, len(partials))
+	for i, p := range partials {
+		indexes[i] = p.index
+		sigs[i] = sig(p)
+	}
+	combined, err := bls12381.CombineSignatures(indexes, sigs)
+	if err != nil {
+		return nil, fmt.Errorf("combining partial signatures: %w", err)
+	}
+	if !tc.pubKey.VerifySignature(msg, combined) {
+		return nil, errors.New("combined signature is invalid, check the public keys of the signers")
+	}
+	return combined, nil
+}
+
+// observe updates the metrics of a signer with the outcome of a request.
+func (tc *ThresholdSignerClient) observe(signer *ThresholdSigner, method string, err error) {
+	status := "ok"
+	var remoteErr *RemoteSignerError
+	switch {
+	case err == nil:
+	case errors.As(err, &remoteErr):
+		status = "refused"
+	case errors.Is(err, errInvalidSignerResponse):
+		status = "invalid"
+	default:
+		status = "error"
+	}
+	label := strconv.Itoa(signer.Index)
+	tc.metrics.SignerRequests.With("signer", label, "method", method, "status", status).Add(1)
+	up := 0.0
+	if err == nil || status == "refused" {
+		// A signer refusing to sign is reachable.
+		up = 1
+	}
+	tc.metrics.SignerUp.With("signer", label).Set(up)
+	if err != nil {
+		tc.logger.Error("Threshold signer request failed", "signer", signer.Index, "method", method, "status", status, "err", err)
+	}
+}
+
+func (tc *ThresholdSignerClient) thresholdError(errs []string) error {
+	return fmt.Errorf("fewer than %d signers out of %d signed: %s", tc.threshold, len(tc.signers), strings.Join(errs, "; "))
+}
@@ -0,0 +1,143 @@
+//go:build bls12381
+
+package privval
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/v2/crypto"
+	"github.com/cometbft/cometbft/v2/crypto/bls12381"
+	"github.com/cometbft/cometbft/v2/crypto/tmhash"
+	cmtrand "github.com/cometbft/cometbft/v2/internal/rand"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+// newTestThresholdSignerClient splits a new key between n signers, the first
+// numFailing of which always fail. The others run FilePV behind a SignerServer.
+func newTestThresholdSignerClient(t *testing.T, chainID string, threshold, n, numFailing int) *ThresholdSignerClient {
+	t.Helper()
+	privKey, err := bls12381.GenPrivKey()
+	require.NoError(t, err)
+	shares, err := bls12381.SplitPrivKey(privKey, threshold, n)
+	require.NoError(t, err)
+
+	signers := make([]ThresholdSigner, n)
+	for i, share := range shares {
+		var client types.PrivValidator
+		if i < numFailing {
+			client = types.NewErroringMockPV()
+		} else {
+			dir := t.TempDir()
+			filePV := NewFilePV(share, filepath.Join(dir, "key.json"), filepath.Join(dir, "state.json"))
+			dtc := getDialerTestCases(t)[0]
+			sl, sd := getMockEndpoints(t, dtc.addr, dtc.dialer)
+			ss := NewSignerServer(sd, chainID, filePV)
+			require.NoError(t, ss.Start())
+			t.Cleanup(func() {
+				if err := ss.Stop(); err != nil {
+					t.Error(err)
+				}
+			})
+			sc, err := NewSignerClient(sl, chainID)
+			require.NoError(t, err)
+			client = sc
+		}
+		signers[i] = ThresholdSigner{Index: i + 1, PubKey: share.PubKey(), Client: client}
+	}
+
+	tc, err := NewThresholdSignerClient(privKey.PubKey(), threshold, signers)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := tc.Close(); err != nil {
+			t.Error(err)
+		}
+	})
+	return tc
+}
+
+func TestThresholdSignerClient(t *testing.T) {
+	const chainID = "test-chain"
+	tc := newTestThresholdSignerClient(t, chainID, 2, 3, 1)
+	pubKey, err := tc.GetPubKey()
+	require.NoError(t, err)
+
+	randbytes := cmtrand.Bytes(tmhash.Size)
+	block1 := types.BlockID{Hash: randbytes, PartSetHeader: types.PartSetHeader{Total: 5, Hash: randbytes}}
+	randbytes2 := cmtrand.Bytes(tmhash.Size)
+	block2 := types.BlockID{Hash: randbytes2, PartSetHeader: types.PartSetHeader{Total: 5, Hash: randbytes2}}
+
+	proposal := newProposal(1, 0, block1)
+	pp := proposal.ToProto()
+	require.NoError(t, tc.SignProposal(chainID, pp))
+	require.True(t, pubKey.VerifySignature(types.ProposalSignBytes(chainID, pp), pp.Signature))
+
+	vote := newVote(pubKey.Address(), 1, 0, types.PrecommitType, block1)
+	vote.Extension = []byte("extension")
+	vp := vote.ToProto()
+	require.NoError(t, tc.SignVote(chainID, vp, true))
+	vote, err = types.VoteFromProto(vp)
+	require.NoError(t, err)
+	require.NoError(t, vote.VerifyVoteAndExtension(chainID, pubKey))
+
+	// The signers refuse to sign a conflicting vote.
+	conflicting := newVote(pubKey.Address(), 1, 0, types.PrecommitType, block2).ToProto()
+	require.Error(t, tc.SignVote(chainID, conflicting, true))
+
+	// Signing the same vote again with a different timestamp returns the
+	// signature of the first vote.
+	again := newVote(pubKey.Address(), 1, 0, types.PrecommitType, block1)
+	again.Extension = []byte("extension")
+	ap := again.ToProto()
+	require.NoError(t, tc.SignVote(chainID, ap, true))
+	require.Equal(t, vp.Timestamp, ap.Timestamp)
+	require.Equal(t, vp.Signature, ap.Signature)
+
+	msg := []byte("hello")
+	sig, err := tc.SignBytes(msg)
+	require.NoError(t, err)
+	require.True(t, pubKey.VerifySignature(msg, sig))
+
+	require.NoError(t, tc.Ping())
+}
+
+func TestThresholdSignerClientNotEnoughSigners(t *testing.T) {
+	const chainID = "test-chain"
+	tc := newTestThresholdSignerClient(t, chainID, 3, 4, 2)
+	pubKey, err := tc.GetPubKey()
+	require.NoError(t, err)
+
+	randbytes := cmtrand.Bytes(tmhash.Size)
+	block := types.BlockID{Hash: randbytes, PartSetHeader: types.PartSetHeader{Total: 5, Hash: randbytes}}
+	err = tc.SignVote(chainID, newVote(pubKey.Address(), 1, 0, types.PrevoteType, block).ToProto(), false)
+	require.ErrorContains(t, err, "fewer than 3 signers out of 4 signed")
+	require.Error(t, tc.SignProposal(chainID, newProposal(1, 0, block).ToProto()))
+}
+
+func TestThresholdSignerClientInvalidSigner(t *testing.T) {
+	privKey, err := bls12381.GenPrivKey()
+	require.NoError(t, err)
+	shares, err := bls12381.SplitPrivKey(privKey, 2, 2)
+	require.NoError(t, err)
+
+	// The second signer uses a key that is not a share of the split key.
+	other, err := bls12381.GenPrivKey()
+	require.NoError(t, err)
+	keys := []crypto.PrivKey{shares[0], other}
+	signers := make([]ThresholdSigner, len(keys))
+	for i, key := range keys {
+		signers[i] = ThresholdSigner{Index: i + 1, PubKey: shares[i].PubKey(), Client: types.NewMockPVWithParams(key, false, false)}
+	}
+	tc, err := NewThresholdSignerClient(privKey.PubKey(), 2, signers)
+	require.NoError(t, err)
+	_, err = tc.SignBytes([]byte("hello"))
+	require.ErrorContains(t, err, "signer 2: "+errInvalidSignerResponse.Error())
+
+	_, err = NewThresholdSignerClient(privKey.PubKey(), 3, signers)
+	require.Error(t, err)
+	signers[1].Index = 1
+	_, err = NewThresholdSignerClient(privKey.PubKey(), 2, signers)
+	require.Error(t, err)
+}
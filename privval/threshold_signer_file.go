@@ -0,0 +1,62 @@
+package privval
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/cometbft/cometbft/v2/crypto"
+	"github.com/cometbft/cometbft/v2/internal/tempfile"
+	cmtjson "github.com/cometbft/cometbft/v2/libs/json"
+)
+
+// ThresholdSignerFile describes a key split between remote signers, as read
+// from the file given by the priv_validator_threshold_file config option.
+type ThresholdSignerFile struct {
+	// Public key of the split key, i.e. of the validator.
+	PubKey crypto.PubKey `json:"pub_key"`
+	// Number of signers that must approve each signature.
+	Threshold int `json:"threshold"`
+	// Signers holding the key shares.
+	Signers []ThresholdSignerAddr `json:"signers"`
+}
+
+// ThresholdSignerAddr is a signer of a ThresholdSignerFile.
+type ThresholdSignerAddr struct {
+	// Index of the key share, as given by bls12381.SplitPrivKey.
+	Index int `json:"index"`
+	// Public key of the key share.
+	PubKey crypto.PubKey `json:"pub_key"`
+	// TCP or UNIX socket address to listen on for a connection from the
+	// signer, as for the priv_validator_laddr config option.
+	ListenAddr string `json:"laddr"`
+}
+
+// LoadThresholdSignerFile reads a ThresholdSignerFile from filePath.
+func LoadThresholdSignerFile(filePath string) (*ThresholdSignerFile, error) {
+	bz, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	var tf ThresholdSignerFile
+	if err := cmtjson.Unmarshal(bz, &tf); err != nil {
+		return nil, fmt.Errorf("error reading threshold signer file %s: %w", filePath, err)
+	}
+	if tf.PubKey == nil {
+		return nil, fmt.Errorf("threshold signer file %s: missing public key", filePath)
+	}
+	for _, s := range tf.Signers {
+		if s.ListenAddr == "" {
+			return nil, fmt.Errorf("threshold signer file %s: missing listen address of signer %d", filePath, s.Index)
+		}
+	}
+	return &tf, nil
+}
+
+// Save writes the ThresholdSignerFile to filePath.
+func (tf *ThresholdSignerFile) Save(filePath string) error {
+	bz, err := cmtjson.MarshalIndent(tf, "", "  ")
+	if err != nil {
+		return err
+	}
+	return tempfile.WriteFileAtomic(filePath, bz, 0o600)
+}
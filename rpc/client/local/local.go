@@ -160,6 +160,24 @@ func (c *Local) DialPeers(
 	return c.env.UnsafeDialPeers(c.ctx, peers, persistent, unconditional, private)
 }
 
+func (c *Local) FirewallRules(context.Context) (*ctypes.ResultFirewallRules, error) {
+	return c.env.UnsafeFirewallRules(c.ctx)
+}
+
+func (c *Local) AddFirewallRules(
+	_ context.Context,
+	allowIDs, allowCIDRs, denyIDs, denyCIDRs []string,
+) (*ctypes.ResultFirewallRules, error) {
+	return c.env.UnsafeAddFirewallRules(c.ctx, allowIDs, allowCIDRs, denyIDs, denyCIDRs)
+}
+
+func (c *Local) RemoveFirewallRules(
+	_ context.Context,
+	allowIDs, allowCIDRs, denyIDs, denyCIDRs []string,
+) (*ctypes.ResultFirewallRules, error) {
+	return c.env.UnsafeRemoveFirewallRules(c.ctx, allowIDs, allowCIDRs, denyIDs, denyCIDRs)
+}
+
 func (c *Local) BlockchainInfo(_ context.Context, minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error) {
 	return c.env.BlockchainInfo(c.ctx, minHeight, maxHeight)
 }
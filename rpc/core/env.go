@@ -16,6 +16,7 @@ import (
 	"github.com/cometbft/cometbft/v2/libs/log"
 	mempl "github.com/cometbft/cometbft/v2/mempool"
 	"github.com/cometbft/cometbft/v2/p2p"
+	"github.com/cometbft/cometbft/v2/p2p/firewall"
 	"github.com/cometbft/cometbft/v2/proxy"
 	sm "github.com/cometbft/cometbft/v2/state"
 	"github.com/cometbft/cometbft/v2/state/indexer"
@@ -61,6 +62,13 @@ type peers interface {
 	AddPrivatePeerIDs(peerIDs []string) error
 	DialPeersAsync(peers []string) error
 	Peers() p2p.IPeerSet
+	PeerScore(id p2p.ID) float64
+}
+
+type p2pFirewall interface {
+	Rules() firewall.Rules
+	AddRules(rules firewall.Rules) error
+	RemoveRules(rules firewall.Rules) error
 }
 
 // A reactor that transitions from block sync or state sync to consensus mode.
@@ -96,6 +104,7 @@ type Environment struct {
 	MempoolReactor   mempoolReactor
 	P2PPeers         peers
 	P2PTransport     transport
+	P2PFirewall      p2pFirewall
 
 	// objects
 	PubKey       crypto.PubKey
@@ -18,6 +18,8 @@ var (
 	ErrGenesisRespSize         = errors.New("genesis response is too large, please use the genesis_chunked API instead")
 	ErrChunkNotInitialized     = errors.New("genesis chunks are not initialized")
 	ErrNoChunks                = errors.New("genesis file is small, therefore there are no chunks to serve. Please use the /genesis API instead")
+	ErrFirewallDisabled        = errors.New("p2p firewall is disabled")
+	ErrNoFirewallRules         = errors.New("no firewall rules provided")
 )
 
 type ErrMaxSubscription struct {
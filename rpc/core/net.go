@@ -9,6 +9,7 @@ import (
 
 	cmtjson "github.com/cometbft/cometbft/v2/libs/json"
 	"github.com/cometbft/cometbft/v2/p2p"
+	"github.com/cometbft/cometbft/v2/p2p/firewall"
 	na "github.com/cometbft/cometbft/v2/p2p/netaddr"
 	ctypes "github.com/cometbft/cometbft/v2/rpc/core/types"
 	rpctypes "github.com/cometbft/cometbft/v2/rpc/jsonrpc/types"
@@ -35,6 +36,7 @@ func (env *Environment) NetInfo(*rpctypes.Context) (*ctypes.ResultNetInfo, error
 			IsOutbound:       peer.IsOutbound(),
 			ConnectionStatus: peer.ConnState(),
 			RemoteIP:         peer.RemoteIP().String(),
+			Score:            env.P2PPeers.PeerScore(peer.ID()),
 		})
 	})
 	if err != nil {
@@ -107,6 +109,82 @@ func (env *Environment) UnsafeDialPeers(
 	return &ctypes.ResultDialPeers{Log: "Dialing peers in progress. See /net_info for details"}, nil
 }
 
+// UnsafeFirewallRules returns the rules of the p2p firewall.
+func (env *Environment) UnsafeFirewallRules(*rpctypes.Context) (*ctypes.ResultFirewallRules, error) {
+	if env.P2PFirewall == nil {
+		return nil, ErrFirewallDisabled
+	}
+	return resultFirewallRules(env.P2PFirewall.Rules()), nil
+}
+
+// UnsafeAddFirewallRules adds the given allow and deny rules, by node ID or
+// CIDR, to the p2p firewall. Connected peers, which are denied by the new
+// rules, are disconnected.
+func (env *Environment) UnsafeAddFirewallRules(
+	_ *rpctypes.Context,
+	allowIDs, allowCIDRs, denyIDs, denyCIDRs []string,
+) (*ctypes.ResultFirewallRules, error) {
+	if env.P2PFirewall == nil {
+		return nil, ErrFirewallDisabled
+	}
+	rules := firewallRules(allowIDs, allowCIDRs, denyIDs, denyCIDRs)
+	if rules.IsEmpty() {
+		return nil, ErrNoFirewallRules
+	}
+
+	env.Logger.Info("AddFirewallRules", "rules", rules)
+	if err := env.P2PFirewall.AddRules(rules); err != nil {
+		return nil, err
+	}
+	return resultFirewallRules(env.P2PFirewall.Rules()), nil
+}
+
+// UnsafeRemoveFirewallRules removes the given allow and deny rules from the
+// p2p firewall.
+func (env *Environment) UnsafeRemoveFirewallRules(
+	_ *rpctypes.Context,
+	allowIDs, allowCIDRs, denyIDs, denyCIDRs []string,
+) (*ctypes.ResultFirewallRules, error) {
+	if env.P2PFirewall == nil {
+		return nil, ErrFirewallDisabled
+	}
+	rules := firewallRules(allowIDs, allowCIDRs, denyIDs, denyCIDRs)
+	if rules.IsEmpty() {
+		return nil, ErrNoFirewallRules
+	}
+
+	env.Logger.Info("RemoveFirewallRules", "rules", rules)
+	if err := env.P2PFirewall.RemoveRules(rules); err != nil {
+		return nil, err
+	}
+	return resultFirewallRules(env.P2PFirewall.Rules()), nil
+}
+
+func firewallRules(allowIDs, allowCIDRs, denyIDs, denyCIDRs []string) firewall.Rules {
+	toIDs := func(ids []string) []p2p.ID {
+		res := make([]p2p.ID, 0, len(ids))
+		for _, id := range ids {
+			res = append(res, p2p.ID(id))
+		}
+		return res
+	}
+	return firewall.Rules{
+		AllowIDs:   toIDs(allowIDs),
+		AllowCIDRs: allowCIDRs,
+		DenyIDs:    toIDs(denyIDs),
+		DenyCIDRs:  denyCIDRs,
+	}
+}
+
+func resultFirewallRules(rules firewall.Rules) *ctypes.ResultFirewallRules {
+	return &ctypes.ResultFirewallRules{
+		AllowIDs:   rules.AllowIDs,
+		AllowCIDRs: rules.AllowCIDRs,
+		DenyIDs:    rules.DenyIDs,
+		DenyCIDRs:  rules.DenyCIDRs,
+	}
+}
+
 // Genesis returns genesis file.
 // More: https://docs.cometbft.com/main/rpc/#/Info/genesis
 func (env *Environment) Genesis(*rpctypes.Context) (*ctypes.ResultGenesis, error) {
@@ -9,6 +9,7 @@ import (
 	cfg "github.com/cometbft/cometbft/v2/config"
 	"github.com/cometbft/cometbft/v2/libs/log"
 	"github.com/cometbft/cometbft/v2/p2p"
+	"github.com/cometbft/cometbft/v2/p2p/firewall"
 	rpctypes "github.com/cometbft/cometbft/v2/rpc/jsonrpc/types"
 )
 
@@ -87,3 +88,32 @@ func TestUnsafeDialPeers(t *testing.T) {
 		}
 	}
 }
+
+func TestUnsafeFirewallRules(t *testing.T) {
+	env := &Environment{}
+	env.Logger = log.TestingLogger()
+
+	_, err := env.UnsafeFirewallRules(&rpctypes.Context{})
+	require.ErrorIs(t, err, ErrFirewallDisabled)
+
+	env.P2PFirewall = firewall.NewFirewall("")
+	id := "d51fb70907db1c6c2d5237e78379b25cf1a37ab4"
+
+	_, err = env.UnsafeAddFirewallRules(&rpctypes.Context{}, nil, nil, nil, nil)
+	require.ErrorIs(t, err, ErrNoFirewallRules)
+	_, err = env.UnsafeAddFirewallRules(&rpctypes.Context{}, nil, nil, nil, []string{"10.0.0.0/33"})
+	require.Error(t, err)
+
+	res, err := env.UnsafeAddFirewallRules(&rpctypes.Context{}, nil, nil, []string{id}, []string{"10.0.0.0/8"})
+	require.NoError(t, err)
+	assert.Equal(t, []p2p.ID{p2p.ID(id)}, res.DenyIDs)
+	assert.Equal(t, []string{"10.0.0.0/8"}, res.DenyCIDRs)
+
+	res, err = env.UnsafeRemoveFirewallRules(&rpctypes.Context{}, nil, nil, []string{id}, nil)
+	require.NoError(t, err)
+	assert.Empty(t, res.DenyIDs)
+
+	res, err = env.UnsafeFirewallRules(&rpctypes.Context{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.0/8"}, res.DenyCIDRs)
+}
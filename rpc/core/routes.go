@@ -60,5 +60,8 @@ func (env *Environment) AddUnsafeRoutes(routes RoutesMap) {
 	// control API
 	routes["dial_seeds"] = rpc.NewRPCFunc(env.UnsafeDialSeeds, "seeds")
 	routes["dial_peers"] = rpc.NewRPCFunc(env.UnsafeDialPeers, "peers,persistent,unconditional,private")
+	routes["firewall_rules"] = rpc.NewRPCFunc(env.UnsafeFirewallRules, "")
+	routes["add_firewall_rules"] = rpc.NewRPCFunc(env.UnsafeAddFirewallRules, "allow_ids,allow_cidrs,deny_ids,deny_cidrs")
+	routes["remove_firewall_rules"] = rpc.NewRPCFunc(env.UnsafeRemoveFirewallRules, "allow_ids,allow_cidrs,deny_ids,deny_cidrs")
 	routes["unsafe_flush_mempool"] = rpc.NewRPCFunc(env.UnsafeFlushMempool, "")
 }
@@ -129,12 +129,21 @@ type ResultDialPeers struct {
 	Log string `json:"log"`
 }
 
+// Rules of the p2p firewall.
+type ResultFirewallRules struct {
+	AllowIDs   []p2p.ID `json:"allow_ids"`
+	AllowCIDRs []string `json:"allow_cidrs"`
+	DenyIDs    []p2p.ID `json:"deny_ids"`
+	DenyCIDRs  []string `json:"deny_cidrs"`
+}
+
 // A peer.
 type Peer struct {
 	NodeInfo         p2p.NodeInfoDefault `json:"node_info"`
 	IsOutbound       bool                `json:"is_outbound"`
 	ConnectionStatus p2p.ConnState       `json:"connection_status"`
 	RemoteIP         string              `json:"remote_ip"`
+	Score            float64             `json:"score"`
 }
 
 // Validators for a height.
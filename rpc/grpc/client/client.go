@@ -22,6 +22,10 @@ type Client interface {
 	VersionServiceClient
 	BlockServiceClient
 	BlockResultsServiceClient
+	EventServiceClient
+	TxServiceClient
+	MempoolServiceClient
+	NetworkServiceClient
 
 	// Close the connection to the server. Any subsequent requests will fail.
 	Close() error
@@ -34,6 +38,10 @@ type clientBuilder struct {
 	versionServiceEnabled      bool
 	blockServiceEnabled        bool
 	blockResultsServiceEnabled bool
+	eventServiceEnabled        bool
+	txServiceEnabled           bool
+	mempoolServiceEnabled      bool
+	networkServiceEnabled      bool
 }
 
 func newClientBuilder() *clientBuilder {
@@ -43,6 +51,10 @@ func newClientBuilder() *clientBuilder {
 		versionServiceEnabled:      true,
 		blockServiceEnabled:        true,
 		blockResultsServiceEnabled: true,
+		eventServiceEnabled:        true,
+		txServiceEnabled:           true,
+		mempoolServiceEnabled:      true,
+		networkServiceEnabled:      true,
 	}
 }
 
@@ -56,6 +68,10 @@ type client struct {
 	VersionServiceClient
 	BlockServiceClient
 	BlockResultsServiceClient
+	EventServiceClient
+	TxServiceClient
+	MempoolServiceClient
+	NetworkServiceClient
 }
 
 // Close implements Client.
@@ -105,6 +121,50 @@ func WithBlockResultsServiceEnabled(enabled bool) Option {
 	}
 }
 
+// WithEventServiceEnabled allows control of whether or not to create a
+// client for interacting with the event service of a CometBFT node.
+//
+// If disabled and the client attempts to access the event service API, the
+// client will panic.
+func WithEventServiceEnabled(enabled bool) Option {
+	return func(b *clientBuilder) {
+		b.eventServiceEnabled = enabled
+	}
+}
+
+// WithTxServiceEnabled allows control of whether or not to create a client
+// for interacting with the tx service of a CometBFT node.
+//
+// If disabled and the client attempts to access the tx service API, the
+// client will panic.
+func WithTxServiceEnabled(enabled bool) Option {
+	return func(b *clientBuilder) {
+		b.txServiceEnabled = enabled
+	}
+}
+
+// WithMempoolServiceEnabled allows control of whether or not to create a
+// client for interacting with the mempool service of a CometBFT node.
+//
+// If disabled and the client attempts to access the mempool service API, the
+// client will panic.
+func WithMempoolServiceEnabled(enabled bool) Option {
+	return func(b *clientBuilder) {
+		b.mempoolServiceEnabled = enabled
+	}
+}
+
+// WithNetworkServiceEnabled allows control of whether or not to create a
+// client for interacting with the network service of a CometBFT node.
+//
+// If disabled and the client attempts to access the network service API, the
+// client will panic.
+func WithNetworkServiceEnabled(enabled bool) Option {
+	return func(b *clientBuilder) {
+		b.networkServiceEnabled = enabled
+	}
+}
+
 // WithGRPCDialOption allows passing lower-level gRPC dial options through to
 // the gRPC dialer when creating the client.
 func WithGRPCDialOption(opt ggrpc.DialOption) Option {
@@ -144,10 +204,30 @@ func New(_ context.Context, addr string, opts ...Option) (Client, error) {
 	if builder.blockResultsServiceEnabled {
 		blockResultServiceClient = newBlockResultsServiceClient(conn)
 	}
+	eventServiceClient := newDisabledEventServiceClient()
+	if builder.eventServiceEnabled {
+		eventServiceClient = newEventServiceClient(conn)
+	}
+	txServiceClient := newDisabledTxServiceClient()
+	if builder.txServiceEnabled {
+		txServiceClient = newTxServiceClient(conn)
+	}
+	mempoolServiceClient := newDisabledMempoolServiceClient()
+	if builder.mempoolServiceEnabled {
+		mempoolServiceClient = newMempoolServiceClient(conn)
+	}
+	networkServiceClient := newDisabledNetworkServiceClient()
+	if builder.networkServiceEnabled {
+		networkServiceClient = newNetworkServiceClient(conn)
+	}
 	return &client{
 		conn:                      conn,
 		VersionServiceClient:      versionServiceClient,
 		BlockServiceClient:        blockServiceClient,
 		BlockResultsServiceClient: blockResultServiceClient,
+		EventServiceClient:        eventServiceClient,
+		TxServiceClient:           txServiceClient,
+		MempoolServiceClient:      mempoolServiceClient,
+		NetworkServiceClient:      networkServiceClient,
 	}, nil
 }
@@ -47,3 +47,54 @@ func (e ErrDial) Error() string {
 func (e ErrDial) Unwrap() error {
 	return e.Source
 }
+
+type ErrSubscribe struct {
+	Query  string
+	Source error
+}
+
+func (e ErrSubscribe) Error() string {
+	return fmt.Sprintf("error subscribing to events with query %q: %v", e.Query, e.Source)
+}
+
+func (e ErrSubscribe) Unwrap() error {
+	return e.Source
+}
+
+type ErrEventReceive struct {
+	Query  string
+	Source error
+}
+
+func (e ErrEventReceive) Error() string {
+	return fmt.Sprintf("error receiving an event for query %q: %v", e.Query, e.Source)
+}
+
+func (e ErrEventReceive) Unwrap() error {
+	return e.Source
+}
+
+type ErrSearchTxs struct {
+	Query  string
+	Source error
+}
+
+func (e ErrSearchTxs) Error() string {
+	return fmt.Sprintf("error searching transactions with query %q: %v", e.Query, e.Source)
+}
+
+func (e ErrSearchTxs) Unwrap() error {
+	return e.Source
+}
+
+type ErrWatchMempoolTxs struct {
+	Source error
+}
+
+func (e ErrWatchMempoolTxs) Error() string {
+	return "error watching the mempool transactions: " + e.Source.Error()
+}
+
+func (e ErrWatchMempoolTxs) Unwrap() error {
+	return e.Source
+}
@@ -0,0 +1,129 @@
+package client
+
+import (
+	"context"
+
+	"github.com/cosmos/gogoproto/grpc"
+
+	eventsvc "github.com/cometbft/cometbft/api/cometbft/services/event/v1"
+	cmtjson "github.com/cometbft/cometbft/v2/libs/json"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+// Event is an event returned by the CometBFT EventService gRPC API.
+type Event struct {
+	// The query of the subscription the event matched.
+	Query string
+	// The event data, e.g. types.EventDataNewBlock or types.EventDataTx.
+	Data types.TMEventData
+	// The events of the message, indexed by their composite key (e.g.
+	// "transfer.sender").
+	Events map[string][]string
+}
+
+// EventResult type used in Subscribe and sent to the client via a channel.
+type EventResult struct {
+	Event *Event
+	Error error
+}
+
+type subscribeConfig struct {
+	chSize uint
+}
+
+type SubscribeOption func(*subscribeConfig)
+
+// SubscribeChannelSize allows control over the channel size. If not used or
+// the channel size is set to 0, an unbuffered channel will be created.
+func SubscribeChannelSize(sz uint) SubscribeOption {
+	return func(opts *subscribeConfig) {
+		opts.chSize = sz
+	}
+}
+
+// EventServiceClient provides the events published by a CometBFT node.
+type EventServiceClient interface {
+	// Subscribe sends the events matching the given query to the resulting
+	// output channel as they are published by the node. The channel is closed
+	// after an error is sent to it, or when the context is canceled.
+	//
+	// Unlike GetLatestHeight, events are never skipped by the client: if the
+	// events are not read fast enough, the server eventually cancels the
+	// subscription.
+	Subscribe(ctx context.Context, query string, opts ...SubscribeOption) (<-chan EventResult, error)
+}
+
+type eventServiceClient struct {
+	client eventsvc.EventServiceClient
+}
+
+func newEventServiceClient(conn grpc.ClientConn) EventServiceClient {
+	return &eventServiceClient{
+		client: eventsvc.NewEventServiceClient(conn),
+	}
+}
+
+// Subscribe implements EventServiceClient Subscribe.
+func (c *eventServiceClient) Subscribe(ctx context.Context, query string, opts ...SubscribeOption) (<-chan EventResult, error) {
+	subscribeClient, err := c.client.Subscribe(ctx, &eventsvc.SubscribeRequest{Query: query})
+	if err != nil {
+		return nil, ErrSubscribe{Query: query, Source: err}
+	}
+
+	cfg := &subscribeConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	resultCh := make(chan EventResult, cfg.chSize)
+
+	go func(client eventsvc.EventService_SubscribeClient) {
+		defer close(resultCh)
+		for {
+			var res EventResult
+			response, err := client.Recv()
+			if err == nil {
+				res.Event, err = eventFromProto(response)
+			}
+			if err != nil {
+				res.Error = ErrEventReceive{Query: query, Source: err}
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case resultCh <- res:
+			}
+			if res.Error != nil {
+				return
+			}
+		}
+	}(subscribeClient)
+
+	return resultCh, nil
+}
+
+func eventFromProto(res *eventsvc.SubscribeResponse) (*Event, error) {
+	var data types.TMEventData
+	if err := cmtjson.Unmarshal(res.Data, &data); err != nil {
+		return nil, err
+	}
+	events := make(map[string][]string, len(res.Events))
+	for key, values := range res.Events {
+		events[key] = values.GetValues()
+	}
+	return &Event{
+		Query:  res.Query,
+		Data:   data,
+		Events: events,
+	}, nil
+}
+
+type disabledEventServiceClient struct{}
+
+func newDisabledEventServiceClient() EventServiceClient {
+	return &disabledEventServiceClient{}
+}
+
+// Subscribe implements EventServiceClient Subscribe - disabled client.
+func (*disabledEventServiceClient) Subscribe(context.Context, string, ...SubscribeOption) (<-chan EventResult, error) {
+	panic("event service client is disabled")
+}
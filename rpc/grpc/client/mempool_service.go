@@ -0,0 +1,225 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cosmos/gogoproto/grpc"
+
+	mempoolsvc "github.com/cometbft/cometbft/api/cometbft/services/mempool/v1"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+// UnconfirmedTxs contains transactions in the mempool, as returned by the
+// CometBFT MempoolService gRPC API.
+type UnconfirmedTxs struct {
+	Txs []types.Tx
+	// The number of transactions in the mempool.
+	Total int
+	// The total size of the transactions in the mempool, in bytes.
+	TotalBytes int64
+}
+
+// MempoolStatus contains the size of the mempool and of its lanes.
+type MempoolStatus struct {
+	Size      int
+	SizeBytes int64
+	// The lanes of the mempool, sorted by descending priority. Empty if the
+	// mempool does not have lanes.
+	Lanes []LaneStatus
+}
+
+// LaneStatus contains the occupancy of a mempool lane.
+type LaneStatus struct {
+	ID        string
+	Priority  uint32
+	Size      int
+	SizeBytes int64
+}
+
+// MempoolTxEventType is the type of a MempoolTxEvent.
+type MempoolTxEventType int
+
+const (
+	// MempoolTxAdmitted means that the transaction was admitted to the
+	// mempool.
+	MempoolTxAdmitted MempoolTxEventType = iota + 1
+	// MempoolTxEvicted means that the transaction was removed from the
+	// mempool without being committed.
+	MempoolTxEvicted
+)
+
+// MempoolTxEvent is a transaction admitted to or evicted from the mempool.
+type MempoolTxEvent struct {
+	Type MempoolTxEventType
+	Tx   types.Tx
+	Hash []byte
+	// The reason of the eviction (one of the types.EvictedTxReason constants),
+	// for evicted transactions only.
+	Reason string
+}
+
+// MempoolTxResult type used in WatchMempoolTxs and sent to the client via a
+// channel.
+type MempoolTxResult struct {
+	Event *MempoolTxEvent
+	Error error
+}
+
+type watchMempoolTxsConfig struct {
+	chSize uint
+}
+
+type WatchMempoolTxsOption func(*watchMempoolTxsConfig)
+
+// WatchMempoolTxsChannelSize allows control over the channel size. If not used
+// or the channel size is set to 0, an unbuffered channel will be created.
+func WatchMempoolTxsChannelSize(sz uint) WatchMempoolTxsOption {
+	return func(opts *watchMempoolTxsConfig) {
+		opts.chSize = sz
+	}
+}
+
+// MempoolServiceClient provides information about the mempool of a node.
+type MempoolServiceClient interface {
+	// GetUnconfirmedTxs returns up to limit transactions from the mempool, in
+	// the order in which they would be reaped to build a block.
+	GetUnconfirmedTxs(ctx context.Context, limit int) (*UnconfirmedTxs, error)
+
+	// GetMempoolStatus returns the size of the mempool and of its lanes.
+	GetMempoolStatus(ctx context.Context) (*MempoolStatus, error)
+
+	// WatchMempoolTxs sends the transactions admitted to and evicted from the
+	// mempool to the resulting output channel. The channel is closed after an
+	// error is sent to it, or when the context is canceled.
+	WatchMempoolTxs(ctx context.Context, opts ...WatchMempoolTxsOption) (<-chan MempoolTxResult, error)
+}
+
+type mempoolServiceClient struct {
+	client mempoolsvc.MempoolServiceClient
+}
+
+func newMempoolServiceClient(conn grpc.ClientConn) MempoolServiceClient {
+	return &mempoolServiceClient{
+		client: mempoolsvc.NewMempoolServiceClient(conn),
+	}
+}
+
+// GetUnconfirmedTxs implements MempoolServiceClient GetUnconfirmedTxs.
+func (c *mempoolServiceClient) GetUnconfirmedTxs(ctx context.Context, limit int) (*UnconfirmedTxs, error) {
+	res, err := c.client.GetUnconfirmedTxs(ctx, &mempoolsvc.GetUnconfirmedTxsRequest{
+		Limit: int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	txs := make([]types.Tx, 0, len(res.Txs))
+	for _, tx := range res.Txs {
+		txs = append(txs, tx)
+	}
+	return &UnconfirmedTxs{
+		Txs:        txs,
+		Total:      int(res.Total),
+		TotalBytes: res.TotalBytes,
+	}, nil
+}
+
+// GetMempoolStatus implements MempoolServiceClient GetMempoolStatus.
+func (c *mempoolServiceClient) GetMempoolStatus(ctx context.Context) (*MempoolStatus, error) {
+	res, err := c.client.GetStatus(ctx, &mempoolsvc.GetStatusRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	lanes := make([]LaneStatus, 0, len(res.Lanes))
+	for _, lane := range res.Lanes {
+		lanes = append(lanes, LaneStatus{
+			ID:        lane.Id,
+			Priority:  lane.Priority,
+			Size:      int(lane.Size_),
+			SizeBytes: lane.SizeBytes,
+		})
+	}
+	return &MempoolStatus{
+		Size:      int(res.Size_),
+		SizeBytes: res.SizeBytes,
+		Lanes:     lanes,
+	}, nil
+}
+
+// WatchMempoolTxs implements MempoolServiceClient WatchMempoolTxs.
+func (c *mempoolServiceClient) WatchMempoolTxs(ctx context.Context, opts ...WatchMempoolTxsOption) (<-chan MempoolTxResult, error) {
+	watchClient, err := c.client.WatchTxs(ctx, &mempoolsvc.WatchTxsRequest{})
+	if err != nil {
+		return nil, ErrWatchMempoolTxs{Source: err}
+	}
+
+	cfg := &watchMempoolTxsConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	resultCh := make(chan MempoolTxResult, cfg.chSize)
+
+	go func(client mempoolsvc.MempoolService_WatchTxsClient) {
+		defer close(resultCh)
+		for {
+			var res MempoolTxResult
+			response, err := client.Recv()
+			if err == nil {
+				res.Event, err = mempoolTxEventFromProto(response)
+			}
+			if err != nil {
+				res.Error = ErrWatchMempoolTxs{Source: err}
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case resultCh <- res:
+			}
+			if res.Error != nil {
+				return
+			}
+		}
+	}(watchClient)
+
+	return resultCh, nil
+}
+
+func mempoolTxEventFromProto(res *mempoolsvc.WatchTxsResponse) (*MempoolTxEvent, error) {
+	event := &MempoolTxEvent{
+		Tx:     res.Tx,
+		Hash:   res.Hash,
+		Reason: res.Reason,
+	}
+	switch res.Type {
+	case mempoolsvc.TxEventType_TX_EVENT_TYPE_ADMITTED:
+		event.Type = MempoolTxAdmitted
+	case mempoolsvc.TxEventType_TX_EVENT_TYPE_EVICTED:
+		event.Type = MempoolTxEvicted
+	default:
+		return nil, fmt.Errorf("unknown mempool tx event type %v", res.Type)
+	}
+	return event, nil
+}
+
+type disabledMempoolServiceClient struct{}
+
+func newDisabledMempoolServiceClient() MempoolServiceClient {
+	return &disabledMempoolServiceClient{}
+}
+
+// GetUnconfirmedTxs implements MempoolServiceClient GetUnconfirmedTxs - disabled client.
+func (*disabledMempoolServiceClient) GetUnconfirmedTxs(context.Context, int) (*UnconfirmedTxs, error) {
+	panic("mempool service client is disabled")
+}
+
+// GetMempoolStatus implements MempoolServiceClient GetMempoolStatus - disabled client.
+func (*disabledMempoolServiceClient) GetMempoolStatus(context.Context) (*MempoolStatus, error) {
+	panic("mempool service client is disabled")
+}
+
+// WatchMempoolTxs implements MempoolServiceClient WatchMempoolTxs - disabled client.
+func (*disabledMempoolServiceClient) WatchMempoolTxs(context.Context, ...WatchMempoolTxsOption) (<-chan MempoolTxResult, error) {
+	panic("mempool service client is disabled")
+}
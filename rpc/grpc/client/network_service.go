@@ -0,0 +1,159 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/cosmos/gogoproto/grpc"
+
+	networksvc "github.com/cometbft/cometbft/api/cometbft/services/network/v1"
+	"github.com/cometbft/cometbft/v2/p2p"
+)
+
+// NetInfo contains the listening addresses and the peers of a node, as
+// returned by the CometBFT NetworkService gRPC API.
+type NetInfo struct {
+	Listening bool
+	Listeners []string
+	Peers     []Peer
+}
+
+// Peer contains information about a peer and the connection to it.
+type Peer struct {
+	NodeInfo             p2p.NodeInfoDefault
+	IsOutbound           bool
+	RemoteIP             string
+	ConnectedFor         time.Duration
+	SendRateLimiterDelay time.Duration
+	RecvRateLimiterDelay time.Duration
+}
+
+// ConsensusState contains a summary of the consensus state of a node.
+type ConsensusState struct {
+	Height    int64
+	Round     int32
+	Step      string
+	StartTime time.Time
+
+	ProposalBlockHash []byte
+	LockedBlockHash   []byte
+	LockedRound       int32
+	ValidBlockHash    []byte
+	ValidRound        int32
+
+	ProposerAddress []byte
+	ProposerIndex   int32
+
+	// The votes received for each round of the current height.
+	Votes []RoundVotes
+}
+
+// RoundVotes contains the votes received for a round.
+type RoundVotes struct {
+	Round int32
+	// The validators that have voted, and the fraction of the voting power
+	// they represent, e.g. "BA{4:xx_x} 30/40 = 0.75".
+	PrevotesBitArray   string
+	PrecommitsBitArray string
+	// The hash of the block that received +2/3 of the votes, if any.
+	PrevotesMaj23Hash   []byte
+	PrecommitsMaj23Hash []byte
+}
+
+// NetworkServiceClient provides information about the peers of a node and its
+// participation in consensus.
+type NetworkServiceClient interface {
+	// GetNetInfo returns the listening addresses and the peers of the node.
+	GetNetInfo(ctx context.Context) (*NetInfo, error)
+
+	// GetConsensusState returns a summary of the consensus state of the node.
+	GetConsensusState(ctx context.Context) (*ConsensusState, error)
+}
+
+type networkServiceClient struct {
+	client networksvc.NetworkServiceClient
+}
+
+func newNetworkServiceClient(conn grpc.ClientConn) NetworkServiceClient {
+	return &networkServiceClient{
+		client: networksvc.NewNetworkServiceClient(conn),
+	}
+}
+
+// GetNetInfo implements NetworkServiceClient GetNetInfo.
+func (c *networkServiceClient) GetNetInfo(ctx context.Context) (*NetInfo, error) {
+	res, err := c.client.GetNetInfo(ctx, &networksvc.GetNetInfoRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	peers := make([]Peer, 0, len(res.Peers))
+	for _, peer := range res.Peers {
+		nodeInfo, err := p2p.NodeInfoDefaultFromProto(peer.NodeInfo)
+		if err != nil {
+			return nil, err
+		}
+		peers = append(peers, Peer{
+			NodeInfo:             nodeInfo,
+			IsOutbound:           peer.IsOutbound,
+			RemoteIP:             peer.RemoteIp,
+			ConnectedFor:         peer.ConnectedFor,
+			SendRateLimiterDelay: peer.SendRateLimiterDelay,
+			RecvRateLimiterDelay: peer.RecvRateLimiterDelay,
+		})
+	}
+	return &NetInfo{
+		Listening: res.Listening,
+		Listeners: res.Listeners,
+		Peers:     peers,
+	}, nil
+}
+
+// GetConsensusState implements NetworkServiceClient GetConsensusState.
+func (c *networkServiceClient) GetConsensusState(ctx context.Context) (*ConsensusState, error) {
+	res, err := c.client.GetConsensusState(ctx, &networksvc.GetConsensusStateRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	votes := make([]RoundVotes, 0, len(res.Votes))
+	for _, v := range res.Votes {
+		votes = append(votes, RoundVotes{
+			Round:               v.Round,
+			PrevotesBitArray:    v.PrevotesBitArray,
+			PrecommitsBitArray:  v.PrecommitsBitArray,
+			PrevotesMaj23Hash:   v.PrevotesMaj23Hash,
+			PrecommitsMaj23Hash: v.PrecommitsMaj23Hash,
+		})
+	}
+	return &ConsensusState{
+		Height:            res.Height,
+		Round:             res.Round,
+		Step:              res.Step,
+		StartTime:         res.StartTime,
+		ProposalBlockHash: res.ProposalBlockHash,
+		LockedBlockHash:   res.LockedBlockHash,
+		LockedRound:       res.LockedRound,
+		ValidBlockHash:    res.ValidBlockHash,
+		ValidRound:        res.ValidRound,
+		ProposerAddress:   res.ProposerAddress,
+		ProposerIndex:     res.ProposerIndex,
+		Votes:             votes,
+	}, nil
+}
+
+type disabledNetworkServiceClient struct{}
+
+func newDisabledNetworkServiceClient() NetworkServiceClient {
+	return &disabledNetworkServiceClient{}
+}
+
+// GetNetInfo implements NetworkServiceClient GetNetInfo - disabled client.
+func (*disabledNetworkServiceClient) GetNetInfo(context.Context) (*NetInfo, error) {
+	panic("network service client is disabled")
+}
+
+// GetConsensusState implements NetworkServiceClient GetConsensusState - disabled client.
+func (*disabledNetworkServiceClient) GetConsensusState(context.Context) (*ConsensusState, error) {
+	panic("network service client is disabled")
+}
@@ -0,0 +1,302 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/cosmos/gogoproto/grpc"
+
+	txsvc "github.com/cometbft/cometbft/api/cometbft/services/tx/v1"
+	abci "github.com/cometbft/cometbft/v2/abci/types"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+// BroadcastTxResult contains the results of a transaction broadcast via the
+// CometBFT TxService gRPC API.
+type BroadcastTxResult struct {
+	Hash    []byte
+	CheckTx *abci.CheckTxResponse
+	// The execution result of the transaction and the height of the block
+	// including it. Only set by BroadcastTxCommit, if the transaction passed
+	// CheckTx.
+	TxResult *abci.ExecTxResult
+	Height   int64
+}
+
+// Tx is an indexed transaction returned by the CometBFT TxService gRPC API.
+type Tx struct {
+	Hash     []byte
+	Height   int64
+	Index    uint32
+	Tx       types.Tx
+	TxResult *abci.ExecTxResult
+	// The proof of the inclusion of the transaction in its block, if requested.
+	Proof *types.TxProof
+}
+
+func txFromProto(ptx *txsvc.IndexedTx) (*Tx, error) {
+	tx := &Tx{
+		Hash:     ptx.Hash,
+		Height:   ptx.Height,
+		Index:    ptx.Index,
+		Tx:       ptx.Tx,
+		TxResult: ptx.TxResult,
+	}
+	if ptx.Proof != nil {
+		proof, err := types.TxProofFromProto(*ptx.Proof)
+		if err != nil {
+			return nil, err
+		}
+		tx.Proof = &proof
+	}
+	return tx, nil
+}
+
+// SearchTxsPage contains a page of the transactions matching a query.
+type SearchTxsPage struct {
+	Txs        []*Tx
+	TotalCount int
+}
+
+// SearchTxsResult type used in SearchTxsStream and sent to the client via a
+// channel.
+type SearchTxsResult struct {
+	Tx    *Tx
+	Error error
+}
+
+type searchTxsConfig struct {
+	prove     bool
+	page      int
+	perPage   int
+	orderDesc bool
+	chSize    uint
+}
+
+type SearchTxsOption func(*searchTxsConfig)
+
+// SearchTxsProve requests the proofs of the inclusion of the transactions in
+// their blocks.
+func SearchTxsProve() SearchTxsOption {
+	return func(opts *searchTxsConfig) {
+		opts.prove = true
+	}
+}
+
+// SearchTxsPagination selects the page of results returned by SearchTxs, and
+// the number of results per page. It is ignored by SearchTxsStream.
+func SearchTxsPagination(page, perPage int) SearchTxsOption {
+	return func(opts *searchTxsConfig) {
+		opts.page = page
+		opts.perPage = perPage
+	}
+}
+
+// SearchTxsOrderDesc orders the transactions by descending height, rather than
+// by ascending height.
+func SearchTxsOrderDesc() SearchTxsOption {
+	return func(opts *searchTxsConfig) {
+		opts.orderDesc = true
+	}
+}
+
+// SearchTxsChannelSize allows control over the size of the channel returned
+// by SearchTxsStream. If not used or the channel size is set to 0, an
+// unbuffered channel will be created.
+func SearchTxsChannelSize(sz uint) SearchTxsOption {
+	return func(opts *searchTxsConfig) {
+		opts.chSize = sz
+	}
+}
+
+// TxServiceClient allows broadcasting, looking up and searching transactions.
+type TxServiceClient interface {
+	// BroadcastTxSync broadcasts the transaction and returns the result of
+	// CheckTx.
+	BroadcastTxSync(ctx context.Context, tx types.Tx) (*BroadcastTxResult, error)
+
+	// BroadcastTxCommit broadcasts the transaction and, if it passes CheckTx,
+	// waits for it to be included in a block.
+	BroadcastTxCommit(ctx context.Context, tx types.Tx) (*BroadcastTxResult, error)
+
+	// CheckTx checks the transaction against the application without adding
+	// it to the mempool.
+	CheckTx(ctx context.Context, tx types.Tx) (*abci.CheckTxResponse, error)
+
+	// GetTx retrieves the indexed transaction with the given hash.
+	GetTx(ctx context.Context, hash []byte, prove bool) (*Tx, error)
+
+	// SearchTxs returns a page of the indexed transactions matching the query.
+	SearchTxs(ctx context.Context, query string, opts ...SearchTxsOption) (*SearchTxsPage, error)
+
+	// SearchTxsStream sends all the indexed transactions matching the query to
+	// the resulting output channel. The channel is closed once all of them
+	// have been sent, after an error, or when the context is canceled.
+	SearchTxsStream(ctx context.Context, query string, opts ...SearchTxsOption) (<-chan SearchTxsResult, error)
+}
+
+type txServiceClient struct {
+	client txsvc.TxServiceClient
+}
+
+func newTxServiceClient(conn grpc.ClientConn) TxServiceClient {
+	return &txServiceClient{
+		client: txsvc.NewTxServiceClient(conn),
+	}
+}
+
+// BroadcastTxSync implements TxServiceClient BroadcastTxSync.
+func (c *txServiceClient) BroadcastTxSync(ctx context.Context, tx types.Tx) (*BroadcastTxResult, error) {
+	return c.broadcastTx(ctx, tx, txsvc.BroadcastMode_BROADCAST_MODE_SYNC)
+}
+
+// BroadcastTxCommit implements TxServiceClient BroadcastTxCommit.
+func (c *txServiceClient) BroadcastTxCommit(ctx context.Context, tx types.Tx) (*BroadcastTxResult, error) {
+	return c.broadcastTx(ctx, tx, txsvc.BroadcastMode_BROADCAST_MODE_COMMIT)
+}
+
+func (c *txServiceClient) broadcastTx(ctx context.Context, tx types.Tx, mode txsvc.BroadcastMode) (*BroadcastTxResult, error) {
+	res, err := c.client.BroadcastTx(ctx, &txsvc.BroadcastTxRequest{
+		Tx:   tx,
+		Mode: mode,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &BroadcastTxResult{
+		Hash:     res.Hash,
+		CheckTx:  res.CheckTx,
+		TxResult: res.TxResult,
+		Height:   res.Height,
+	}, nil
+}
+
+// CheckTx implements TxServiceClient CheckTx.
+func (c *txServiceClient) CheckTx(ctx context.Context, tx types.Tx) (*abci.CheckTxResponse, error) {
+	res, err := c.client.CheckTx(ctx, &txsvc.CheckTxRequest{Tx: tx})
+	if err != nil {
+		return nil, err
+	}
+	return res.CheckTx, nil
+}
+
+// GetTx implements TxServiceClient GetTx.
+func (c *txServiceClient) GetTx(ctx context.Context, hash []byte, prove bool) (*Tx, error) {
+	res, err := c.client.GetTx(ctx, &txsvc.GetTxRequest{
+		Hash:  hash,
+		Prove: prove,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return txFromProto(res.Tx)
+}
+
+// SearchTxs implements TxServiceClient SearchTxs.
+func (c *txServiceClient) SearchTxs(ctx context.Context, query string, opts ...SearchTxsOption) (*SearchTxsPage, error) {
+	cfg := &searchTxsConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	res, err := c.client.SearchTxs(ctx, &txsvc.SearchTxsRequest{
+		Query:     query,
+		Prove:     cfg.prove,
+		Page:      int32(cfg.page),
+		PerPage:   int32(cfg.perPage),
+		OrderDesc: cfg.orderDesc,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	txs := make([]*Tx, 0, len(res.Txs))
+	for _, ptx := range res.Txs {
+		tx, err := txFromProto(ptx)
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, tx)
+	}
+	return &SearchTxsPage{Txs: txs, TotalCount: int(res.TotalCount)}, nil
+}
+
+// SearchTxsStream implements TxServiceClient SearchTxsStream.
+func (c *txServiceClient) SearchTxsStream(ctx context.Context, query string, opts ...SearchTxsOption) (<-chan SearchTxsResult, error) {
+	cfg := &searchTxsConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	searchClient, err := c.client.SearchTxsStream(ctx, &txsvc.SearchTxsStreamRequest{
+		Query:     query,
+		Prove:     cfg.prove,
+		OrderDesc: cfg.orderDesc,
+	})
+	if err != nil {
+		return nil, ErrSearchTxs{Query: query, Source: err}
+	}
+	resultCh := make(chan SearchTxsResult, cfg.chSize)
+
+	go func(client txsvc.TxService_SearchTxsStreamClient) {
+		defer close(resultCh)
+		for {
+			var res SearchTxsResult
+			response, err := client.Recv()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err == nil {
+				res.Tx, err = txFromProto(response.Tx)
+			}
+			if err != nil {
+				res.Error = ErrSearchTxs{Query: query, Source: err}
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case resultCh <- res:
+			}
+			if res.Error != nil {
+				return
+			}
+		}
+	}(searchClient)
+
+	return resultCh, nil
+}
+
+type disabledTxServiceClient struct{}
+
+func newDisabledTxServiceClient() TxServiceClient {
+	return &disabledTxServiceClient{}
+}
+
+// BroadcastTxSync implements TxServiceClient BroadcastTxSync - disabled client.
+func (*disabledTxServiceClient) BroadcastTxSync(context.Context, types.Tx) (*BroadcastTxResult, error) {
+	panic("tx service client is disabled")
+}
+
+// BroadcastTxCommit implements TxServiceClient BroadcastTxCommit - disabled client.
+func (*disabledTxServiceClient) BroadcastTxCommit(context.Context, types.Tx) (*BroadcastTxResult, error) {
+	panic("tx service client is disabled")
+}
+
+// CheckTx implements TxServiceClient CheckTx - disabled client.
+func (*disabledTxServiceClient) CheckTx(context.Context, types.Tx) (*abci.CheckTxResponse, error) {
+	panic("tx service client is disabled")
+}
+
+// GetTx implements TxServiceClient GetTx - disabled client.
+func (*disabledTxServiceClient) GetTx(context.Context, []byte, bool) (*Tx, error) {
+	panic("tx service client is disabled")
+}
+
+// SearchTxs implements TxServiceClient SearchTxs - disabled client.
+func (*disabledTxServiceClient) SearchTxs(context.Context, string, ...SearchTxsOption) (*SearchTxsPage, error) {
+	panic("tx service client is disabled")
+}
+
+// SearchTxsStream implements TxServiceClient SearchTxsStream - disabled client.
+func (*disabledTxServiceClient) SearchTxsStream(context.Context, string, ...SearchTxsOption) (<-chan SearchTxsResult, error) {
+	panic("tx service client is disabled")
+}
@@ -4,18 +4,31 @@ import (
 	"fmt"
 	"net"
 	"strings"
+	"time"
 
 	"google.golang.org/grpc"
 
 	pbblocksvc "github.com/cometbft/cometbft/api/cometbft/services/block/v2"
 	brs "github.com/cometbft/cometbft/api/cometbft/services/block_results/v2"
+	pbeventsvc "github.com/cometbft/cometbft/api/cometbft/services/event/v1"
+	pbmempoolsvc "github.com/cometbft/cometbft/api/cometbft/services/mempool/v1"
+	pbnetworksvc "github.com/cometbft/cometbft/api/cometbft/services/network/v1"
+	pbtxsvc "github.com/cometbft/cometbft/api/cometbft/services/tx/v1"
 	pbversionsvc "github.com/cometbft/cometbft/api/cometbft/services/version/v1"
 	"github.com/cometbft/cometbft/v2/libs/log"
+	"github.com/cometbft/cometbft/v2/mempool"
+	"github.com/cometbft/cometbft/v2/proxy"
 	grpcerr "github.com/cometbft/cometbft/v2/rpc/grpc/errors"
 	"github.com/cometbft/cometbft/v2/rpc/grpc/server/services/blockresultservice"
 	"github.com/cometbft/cometbft/v2/rpc/grpc/server/services/blockservice"
+	"github.com/cometbft/cometbft/v2/rpc/grpc/server/services/eventservice"
+	"github.com/cometbft/cometbft/v2/rpc/grpc/server/services/mempoolservice"
+	"github.com/cometbft/cometbft/v2/rpc/grpc/server/services/networkservice"
+	"github.com/cometbft/cometbft/v2/rpc/grpc/server/services/subscription"
+	"github.com/cometbft/cometbft/v2/rpc/grpc/server/services/txservice"
 	"github.com/cometbft/cometbft/v2/rpc/grpc/server/services/versionservice"
 	sm "github.com/cometbft/cometbft/v2/state"
+	"github.com/cometbft/cometbft/v2/state/txindex"
 	"github.com/cometbft/cometbft/v2/store"
 	"github.com/cometbft/cometbft/v2/types"
 )
@@ -29,6 +42,10 @@ type serverBuilder struct {
 	versionService      pbversionsvc.VersionServiceServer
 	blockService        pbblocksvc.BlockServiceServer
 	blockResultsService brs.BlockResultsServiceServer
+	eventService        pbeventsvc.EventServiceServer
+	txService           pbtxsvc.TxServiceServer
+	mempoolService      pbmempoolsvc.MempoolServiceServer
+	networkService      pbnetworksvc.NetworkServiceServer
 	logger              log.Logger
 	grpcOpts            []grpc.ServerOption
 }
@@ -74,6 +91,61 @@ func WithBlockResultsService(bs *store.BlockStore, ss sm.Store, logger log.Logge
 	}
 }
 
+// WithEventService enables the event service on the CometBFT server. The
+// subscriptions are bounded by the given limiter, which should be shared with
+// the other services subscribing to events on behalf of clients.
+func WithEventService(eventBus *types.EventBus, limiter *subscription.Limiter, logger log.Logger) Option {
+	return func(b *serverBuilder) {
+		b.eventService = eventservice.New(eventBus, limiter, logger)
+	}
+}
+
+// WithTxService enables the tx service on the CometBFT server. In commit mode,
+// transactions are broadcast waiting at most commitTimeout for them to be
+// included in a block, subscribing to their event within the bounds of the
+// given limiter, which should be shared with the other services.
+func WithTxService(
+	mempoolReactor txservice.MempoolReactor,
+	proxyApp proxy.AppConnMempool,
+	txIndexer txindex.TxIndexer,
+	blockStore *store.BlockStore,
+	eventBus *types.EventBus,
+	limiter *subscription.Limiter,
+	commitTimeout time.Duration,
+	logger log.Logger,
+) Option {
+	return func(b *serverBuilder) {
+		b.txService = txservice.New(mempoolReactor, proxyApp, txIndexer, blockStore, eventBus, limiter, commitTimeout, logger)
+	}
+}
+
+// WithMempoolService enables the mempool service on the CometBFT server. The
+// admitted transactions are only streamed if the mempool publishes PendingTx
+// events on the event bus. The subscriptions of WatchTxs are bounded by the
+// given limiter, which should be shared with the other services.
+func WithMempoolService(
+	mempool mempool.Mempool,
+	eventBus *types.EventBus,
+	limiter *subscription.Limiter,
+	logger log.Logger,
+) Option {
+	return func(b *serverBuilder) {
+		b.mempoolService = mempoolservice.New(mempool, eventBus, limiter, logger)
+	}
+}
+
+// WithNetworkService enables the network service on the CometBFT server.
+func WithNetworkService(
+	transport networkservice.Transport,
+	peers networkservice.Peers,
+	consensusState networkservice.ConsensusState,
+	logger log.Logger,
+) Option {
+	return func(b *serverBuilder) {
+		b.networkService = networkservice.New(transport, peers, consensusState, logger)
+	}
+}
+
 // WithLogger enables logging using the given logger. If not specified, the
 // gRPC server does not log anything.
 func WithLogger(logger log.Logger) Option {
@@ -114,6 +186,22 @@ func Serve(listener net.Listener, opts ...Option) error {
 		brs.RegisterBlockResultsServiceServer(server, b.blockResultsService)
 		b.logger.Debug("Registered block results service")
 	}
+	if b.eventService != nil {
+		pbeventsvc.RegisterEventServiceServer(server, b.eventService)
+		b.logger.Debug("Registered event service")
+	}
+	if b.txService != nil {
+		pbtxsvc.RegisterTxServiceServer(server, b.txService)
+		b.logger.Debug("Registered tx service")
+	}
+	if b.mempoolService != nil {
+		pbmempoolsvc.RegisterMempoolServiceServer(server, b.mempoolService)
+		b.logger.Debug("Registered mempool service")
+	}
+	if b.networkService != nil {
+		pbnetworksvc.RegisterNetworkServiceServer(server, b.networkService)
+		b.logger.Debug("Registered network service")
+	}
 	b.logger.Info("serve", "msg", fmt.Sprintf("Starting gRPC server on %s", listener.Addr()))
 	return server.Serve(b.listener)
 }
@@ -0,0 +1,125 @@
+package eventservice
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	eventsvc "github.com/cometbft/cometbft/api/cometbft/services/event/v1"
+	"github.com/cometbft/cometbft/v2/internal/rpctrace"
+	cmtjson "github.com/cometbft/cometbft/v2/libs/json"
+	"github.com/cometbft/cometbft/v2/libs/log"
+	cmtpubsub "github.com/cometbft/cometbft/v2/libs/pubsub"
+	cmtquery "github.com/cometbft/cometbft/v2/libs/pubsub/query"
+	"github.com/cometbft/cometbft/v2/rpc/grpc/server/services/subscription"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+const (
+	// maxQueryLength is the maximum length of a query string that will be
+	// accepted. This is just a safety check to avoid outlandish queries.
+	maxQueryLength = 512
+
+	// subscriptionBufferSize is the number of events that can be buffered for
+	// a subscriber before it is considered too slow and its subscription is
+	// canceled.
+	subscriptionBufferSize = 100
+)
+
+type eventServiceServer struct {
+	eventBus *types.EventBus
+	limiter  *subscription.Limiter
+	logger   log.Logger
+}
+
+// New creates a new CometBFT event service server. The number of
+// subscriptions is bounded by the given limiter, if any.
+func New(eventBus *types.EventBus, limiter *subscription.Limiter, logger log.Logger) eventsvc.EventServiceServer {
+	return &eventServiceServer{
+		eventBus: eventBus,
+		limiter:  limiter,
+		logger:   logger.With("service", "EventService"),
+	}
+}
+
+// Subscribe implements v1.EventServiceServer Subscribe method.
+func (s *eventServiceServer) Subscribe(req *eventsvc.SubscribeRequest, stream eventsvc.EventService_SubscribeServer) error {
+	logger := s.logger.With("endpoint", "Subscribe")
+
+	if len(req.Query) > maxQueryLength {
+		return status.Errorf(codes.InvalidArgument, "Query is %d bytes long, maximum is %d", len(req.Query), maxQueryLength)
+	}
+	q, err := cmtquery.New(req.Query)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "Invalid query: %v", err)
+	}
+
+	ctx := stream.Context()
+	release, err := s.limiter.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	traceID, err := rpctrace.New()
+	if err != nil {
+		logger.Error("Error generating RPC trace ID", "err", err)
+		return status.Error(codes.Internal, "Internal server error")
+	}
+
+	// The trace ID is reused as a unique subscriber ID
+	sub, err := s.eventBus.Subscribe(ctx, traceID, q, subscriptionBufferSize)
+	if err != nil {
+		logger.Error("Cannot subscribe to events", "err", err, "query", req.Query, "traceID", traceID)
+		return status.Errorf(codes.Internal, "Cannot subscribe to events (see logs for trace ID: %s)", traceID)
+	}
+	defer func() {
+		if err := s.eventBus.UnsubscribeAll(context.Background(), traceID); err != nil && !errors.Is(err, cmtpubsub.ErrSubscriptionNotFound) {
+			logger.Error("Failed to unsubscribe", "err", err, "traceID", traceID)
+		}
+	}()
+
+	for {
+		select {
+		case msg := <-sub.Out():
+			data, err := cmtjson.Marshal(msg.Data())
+			if err != nil {
+				logger.Error("Failed to encode event data", "err", err, "traceID", traceID)
+				return status.Errorf(codes.Internal, "Internal server error (see logs for trace ID: %s)", traceID)
+			}
+			res := &eventsvc.SubscribeResponse{
+				Query:  req.Query,
+				Data:   data,
+				Events: eventsToProto(msg.Events()),
+			}
+			if err := stream.Send(res); err != nil {
+				logger.Error("Failed to stream event", "err", err, "traceID", traceID)
+				return status.Errorf(codes.Unavailable, "Cannot send stream response (see logs for trace ID: %s)", traceID)
+			}
+		case <-sub.Canceled():
+			switch err := sub.Err(); {
+			case errors.Is(err, cmtpubsub.ErrUnsubscribed):
+				return status.Error(codes.Canceled, "Subscription terminated")
+			case errors.Is(err, cmtpubsub.ErrOutOfCapacity):
+				return status.Error(codes.ResourceExhausted, "Subscription canceled because the client is too slow")
+			case err == nil:
+				return status.Error(codes.Canceled, "Subscription canceled without errors")
+			default:
+				logger.Info("Subscription canceled with errors", "err", err, "traceID", traceID)
+				return status.Errorf(codes.Canceled, "Subscription canceled with errors (see logs for trace ID: %s)", traceID)
+			}
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		}
+	}
+}
+
+func eventsToProto(events map[string][]string) map[string]*eventsvc.EventValues {
+	pbEvents := make(map[string]*eventsvc.EventValues, len(events))
+	for key, values := range events {
+		pbEvents[key] = &eventsvc.EventValues{Values: values}
+	}
+	return pbEvents
+}
@@ -0,0 +1,179 @@
+package mempoolservice
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	mempoolsvc "github.com/cometbft/cometbft/api/cometbft/services/mempool/v1"
+	"github.com/cometbft/cometbft/v2/internal/rpctrace"
+	"github.com/cometbft/cometbft/v2/libs/log"
+	cmtpubsub "github.com/cometbft/cometbft/v2/libs/pubsub"
+	cmtquery "github.com/cometbft/cometbft/v2/libs/pubsub/query"
+	"github.com/cometbft/cometbft/v2/mempool"
+	"github.com/cometbft/cometbft/v2/rpc/grpc/server/services/subscription"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+const (
+	defaultLimit = 30
+	maxLimit     = 100
+
+	// subscriptionBufferSize is the number of transactions that can be
+	// buffered for a subscriber before it is considered too slow and its
+	// subscription is canceled.
+	subscriptionBufferSize = 1000
+)
+
+// watchTxsQuery matches the events of the transactions admitted to and
+// evicted from the mempool.
+var watchTxsQuery = cmtquery.MustCompile(
+	"tm.event = '" + types.EventPendingTx + "' OR tm.event = '" + types.EventEvictedTx + "'",
+)
+
+// laneMempool is implemented by the mempools with lanes.
+type laneMempool interface {
+	LaneStats() []mempool.LaneStats
+}
+
+type mempoolServiceServer struct {
+	mempool  mempool.Mempool
+	eventBus *types.EventBus
+	limiter  *subscription.Limiter
+	logger   log.Logger
+}
+
+// New creates a new CometBFT mempool service server.
+//
+// WatchTxs only streams admitted transactions if the mempool publishes the
+// PendingTx events. Its subscriptions are bounded by the given limiter, if
+// any.
+func New(
+	mempool mempool.Mempool,
+	eventBus *types.EventBus,
+	limiter *subscription.Limiter,
+	logger log.Logger,
+) mempoolsvc.MempoolServiceServer {
+	return &mempoolServiceServer{
+		mempool:  mempool,
+		eventBus: eventBus,
+		limiter:  limiter,
+		logger:   logger.With("service", "MempoolService"),
+	}
+}
+
+// GetUnconfirmedTxs implements v1.MempoolServiceServer GetUnconfirmedTxs method.
+func (s *mempoolServiceServer) GetUnconfirmedTxs(_ context.Context, req *mempoolsvc.GetUnconfirmedTxsRequest) (*mempoolsvc.GetUnconfirmedTxsResponse, error) {
+	limit := int(req.Limit)
+	switch {
+	case limit < 1:
+		limit = defaultLimit
+	case limit > maxLimit:
+		limit = maxLimit
+	}
+
+	reaped := s.mempool.ReapMaxTxs(limit)
+	txs := make([][]byte, 0, len(reaped))
+	for _, tx := range reaped {
+		txs = append(txs, tx)
+	}
+	return &mempoolsvc.GetUnconfirmedTxsResponse{
+		Txs:        txs,
+		Total:      int64(s.mempool.Size()),
+		TotalBytes: s.mempool.SizeBytes(),
+	}, nil
+}
+
+// GetStatus implements v1.MempoolServiceServer GetStatus method.
+func (s *mempoolServiceServer) GetStatus(context.Context, *mempoolsvc.GetStatusRequest) (*mempoolsvc.GetStatusResponse, error) {
+	res := &mempoolsvc.GetStatusResponse{
+		Size_:     int64(s.mempool.Size()),
+		SizeBytes: s.mempool.SizeBytes(),
+	}
+	if mp, ok := s.mempool.(laneMempool); ok {
+		for _, lane := range mp.LaneStats() {
+			res.Lanes = append(res.Lanes, &mempoolsvc.LaneStatus{
+				Id:        string(lane.ID),
+				Priority:  uint32(lane.Priority),
+				Size_:     int64(lane.NumTxs),
+				SizeBytes: lane.Bytes,
+			})
+		}
+	}
+	return res, nil
+}
+
+// WatchTxs implements v1.MempoolServiceServer WatchTxs method.
+func (s *mempoolServiceServer) WatchTxs(_ *mempoolsvc.WatchTxsRequest, stream mempoolsvc.MempoolService_WatchTxsServer) error {
+	logger := s.logger.With("endpoint", "WatchTxs")
+
+	ctx := stream.Context()
+	release, err := s.limiter.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	traceID, err := rpctrace.New()
+	if err != nil {
+		logger.Error("Error generating RPC trace ID", "err", err)
+		return status.Error(codes.Internal, "Internal server error")
+	}
+
+	// The trace ID is reused as a unique subscriber ID
+	sub, err := s.eventBus.Subscribe(ctx, traceID, watchTxsQuery, subscriptionBufferSize)
+	if err != nil {
+		logger.Error("Cannot subscribe to mempool events", "err", err, "traceID", traceID)
+		return status.Errorf(codes.Internal, "Cannot subscribe to mempool events (see logs for trace ID: %s)", traceID)
+	}
+	defer func() {
+		if err := s.eventBus.UnsubscribeAll(context.Background(), traceID); err != nil && !errors.Is(err, cmtpubsub.ErrSubscriptionNotFound) {
+			logger.Error("Failed to unsubscribe", "err", err, "traceID", traceID)
+		}
+	}()
+
+	for {
+		select {
+		case msg := <-sub.Out():
+			var res *mempoolsvc.WatchTxsResponse
+			switch data := msg.Data().(type) {
+			case types.EventDataPendingTx:
+				res = &mempoolsvc.WatchTxsResponse{
+					Type: mempoolsvc.TxEventType_TX_EVENT_TYPE_ADMITTED,
+					Tx:   data.Tx,
+					Hash: types.Tx(data.Tx).Hash(),
+				}
+			case types.EventDataEvictedTx:
+				res = &mempoolsvc.WatchTxsResponse{
+					Type:   mempoolsvc.TxEventType_TX_EVENT_TYPE_EVICTED,
+					Tx:     data.Tx,
+					Hash:   types.Tx(data.Tx).Hash(),
+					Reason: data.Reason,
+				}
+			default:
+				logger.Error("Unexpected event type", "type", data, "traceID", traceID)
+				return status.Errorf(codes.Internal, "Internal server error (see logs for trace ID: %s)", traceID)
+			}
+			if err := stream.Send(res); err != nil {
+				logger.Error("Failed to stream mempool event", "err", err, "traceID", traceID)
+				return status.Errorf(codes.Unavailable, "Cannot send stream response (see logs for trace ID: %s)", traceID)
+			}
+		case <-sub.Canceled():
+			switch err := sub.Err(); {
+			case errors.Is(err, cmtpubsub.ErrUnsubscribed):
+				return status.Error(codes.Canceled, "Subscription terminated")
+			case errors.Is(err, cmtpubsub.ErrOutOfCapacity):
+				return status.Error(codes.ResourceExhausted, "Subscription canceled because the client is too slow")
+			case err == nil:
+				return status.Error(codes.Canceled, "Subscription canceled without errors")
+			default:
+				logger.Info("Subscription canceled with errors", "err", err, "traceID", traceID)
+				return status.Errorf(codes.Canceled, "Subscription canceled with errors (see logs for trace ID: %s)", traceID)
+			}
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package networkservice
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	networksvc "github.com/cometbft/cometbft/api/cometbft/services/network/v1"
+	cstypes "github.com/cometbft/cometbft/v2/internal/consensus/types"
+	"github.com/cometbft/cometbft/v2/libs/log"
+	"github.com/cometbft/cometbft/v2/p2p"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+// Transport provides the listening addresses of the node.
+type Transport interface {
+	Listeners() []string
+	IsListening() bool
+}
+
+// Peers provides the peers of the node.
+type Peers interface {
+	Peers() p2p.IPeerSet
+}
+
+// ConsensusState provides the consensus state of the node.
+type ConsensusState interface {
+	GetRoundState() cstypes.RoundState
+}
+
+type networkServiceServer struct {
+	transport      Transport
+	peers          Peers
+	consensusState ConsensusState
+	logger         log.Logger
+}
+
+// New creates a new CometBFT network service server.
+func New(transport Transport, peers Peers, consensusState ConsensusState, logger log.Logger) networksvc.NetworkServiceServer {
+	return &networkServiceServer{
+		transport:      transport,
+		peers:          peers,
+		consensusState: consensusState,
+		logger:         logger.With("service", "NetworkService"),
+	}
+}
+
+// GetNetInfo implements v1.NetworkServiceServer GetNetInfo method.
+func (s *networkServiceServer) GetNetInfo(context.Context, *networksvc.GetNetInfoRequest) (*networksvc.GetNetInfoResponse, error) {
+	logger := s.logger.With("endpoint", "GetNetInfo")
+
+	var peers []*networksvc.Peer
+	var invalidPeer p2p.Peer
+	s.peers.Peers().ForEach(func(peer p2p.Peer) {
+		nodeInfo, ok := peer.NodeInfo().(p2p.NodeInfoDefault)
+		if !ok {
+			invalidPeer = peer
+			return
+		}
+		connState := peer.ConnState()
+		peers = append(peers, &networksvc.Peer{
+			NodeInfo:             nodeInfo.ToProto(),
+			IsOutbound:           peer.IsOutbound(),
+			RemoteIp:             peer.RemoteIP().String(),
+			ConnectedFor:         connState.ConnectedFor,
+			SendRateLimiterDelay: connState.SendRateLimiterDelay,
+			RecvRateLimiterDelay: connState.RecvRateLimiterDelay,
+		})
+	})
+	if invalidPeer != nil {
+		logger.Error("Unexpected peer node info type", "peer", invalidPeer.ID(), "type", invalidPeer.NodeInfo())
+		return nil, status.Error(codes.Internal, "Internal server error - see logs for details")
+	}
+
+	return &networksvc.GetNetInfoResponse{
+		Listening: s.transport.IsListening(),
+		Listeners: s.transport.Listeners(),
+		Peers:     peers,
+	}, nil
+}
+
+// GetConsensusState implements v1.NetworkServiceServer GetConsensusState method.
+func (s *networkServiceServer) GetConsensusState(context.Context, *networksvc.GetConsensusStateRequest) (*networksvc.GetConsensusStateResponse, error) {
+	rs := s.consensusState.GetRoundState()
+
+	res := &networksvc.GetConsensusStateResponse{
+		Height:            rs.Height,
+		Round:             rs.Round,
+		Step:              rs.Step.String(),
+		StartTime:         rs.StartTime,
+		ProposalBlockHash: rs.ProposalBlock.Hash(),
+		LockedBlockHash:   rs.LockedBlock.Hash(),
+		LockedRound:       rs.LockedRound,
+		ValidBlockHash:    rs.ValidBlock.Hash(),
+		ValidRound:        rs.ValidRound,
+	}
+	// The validator set is shared with the consensus state: read the proposer
+	// directly rather than with GetProposer, which may update the set.
+	if rs.Validators != nil {
+		if proposer := rs.Validators.Proposer; proposer != nil {
+			res.ProposerAddress = proposer.Address
+			res.ProposerIndex, _ = rs.Validators.GetByAddress(proposer.Address)
+		}
+	}
+	if rs.Votes != nil {
+		for round := int32(0); round <= rs.Votes.Round(); round++ {
+			res.Votes = append(res.Votes, roundVotes(round, rs.Votes.Prevotes(round), rs.Votes.Precommits(round)))
+		}
+	}
+	return res, nil
+}
+
+func roundVotes(round int32, prevotes, precommits *types.VoteSet) *networksvc.RoundVotes {
+	votes := &networksvc.RoundVotes{Round: round}
+	if prevotes != nil {
+		votes.PrevotesBitArray = prevotes.BitArrayString()
+		if blockID, ok := prevotes.TwoThirdsMajority(); ok {
+			votes.PrevotesMaj23Hash = blockID.Hash
+		}
+	}
+	if precommits != nil {
+		votes.PrecommitsBitArray = precommits.BitArrayString()
+		if blockID, ok := precommits.TwoThirdsMajority(); ok {
+			votes.PrecommitsMaj23Hash = blockID.Hash
+		}
+	}
+	return votes
+}
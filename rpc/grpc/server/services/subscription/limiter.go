@@ -0,0 +1,83 @@
+// Package subscription limits the number of event subscriptions the gRPC
+// services open on behalf of their clients.
+package subscription
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Limiter bounds the number of clients holding event subscriptions and the
+// number of subscriptions per client, like the JSON-RPC server does with the
+// rpc.max_subscription_clients and rpc.max_subscriptions_per_client settings.
+// A client is identified by the remote address of its gRPC connection. A nil
+// Limiter does not limit anything.
+//
+// Limiter is safe for concurrent use and is meant to be shared by all the
+// services of a gRPC server.
+type Limiter struct {
+	maxClients                int
+	maxSubscriptionsPerClient int
+
+	mtx     sync.Mutex
+	clients map[string]int // number of subscriptions per client address
+}
+
+// NewLimiter creates a Limiter allowing at most maxClients clients with at
+// most maxSubscriptionsPerClient subscriptions each.
+func NewLimiter(maxClients, maxSubscriptionsPerClient int) *Limiter {
+	return &Limiter{
+		maxClients:                maxClients,
+		maxSubscriptionsPerClient: maxSubscriptionsPerClient,
+		clients:                   make(map[string]int),
+	}
+}
+
+// Acquire reserves a subscription for the client calling the RPC the given
+// context belongs to. On success, the returned function must be called once
+// the subscription is over to release it. If a limit is reached, Acquire
+// returns a gRPC status error with code ResourceExhausted.
+func (l *Limiter) Acquire(ctx context.Context) (release func(), err error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	addr := clientAddr(ctx)
+
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	n, ok := l.clients[addr]
+	switch {
+	case !ok && len(l.clients) >= l.maxClients:
+		return nil, status.Errorf(codes.ResourceExhausted, "Maximum number of subscription clients reached: %d", l.maxClients)
+	case n >= l.maxSubscriptionsPerClient:
+		return nil, status.Errorf(codes.ResourceExhausted, "Maximum number of subscriptions per client reached: %d", l.maxSubscriptionsPerClient)
+	}
+	l.clients[addr] = n + 1
+
+	var once sync.Once
+	return func() { once.Do(func() { l.release(addr) }) }, nil
+}
+
+func (l *Limiter) release(addr string) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	if l.clients[addr] <= 1 {
+		delete(l.clients, addr)
+		return
+	}
+	l.clients[addr]--
+}
+
+// clientAddr returns the remote address of the gRPC client calling the RPC
+// the given context belongs to, or an empty string if it is unknown.
+func clientAddr(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	return p.Addr.String()
+}
@@ -0,0 +1,61 @@
+package subscription
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func clientContext(addr string) context.Context {
+	tcpAddr := net.TCPAddrFromAddrPort(netip.MustParseAddrPort(addr))
+	return peer.NewContext(context.Background(), &peer.Peer{Addr: tcpAddr})
+}
+
+func TestLimiter(t *testing.T) {
+	l := NewLimiter(2, 2)
+	alice := clientContext("127.0.0.1:1000")
+	bob := clientContext("127.0.0.1:2000")
+	carol := clientContext("127.0.0.1:3000")
+
+	releaseAlice1, err := l.Acquire(alice)
+	require.NoError(t, err)
+	_, err = l.Acquire(alice)
+	require.NoError(t, err)
+
+	// Alice has too many subscriptions.
+	_, err = l.Acquire(alice)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	releaseBob, err := l.Acquire(bob)
+	require.NoError(t, err)
+
+	// There are too many clients.
+	_, err = l.Acquire(carol)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// Releasing twice has no further effect.
+	releaseAlice1()
+	releaseAlice1()
+	_, err = l.Acquire(alice)
+	require.NoError(t, err)
+	_, err = l.Acquire(alice)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// Bob's last subscription is over, so Carol can take his place.
+	releaseBob()
+	_, err = l.Acquire(carol)
+	require.NoError(t, err)
+}
+
+func TestLimiterNil(t *testing.T) {
+	var l *Limiter
+	release, err := l.Acquire(context.Background())
+	require.NoError(t, err)
+	release()
+}
@@ -0,0 +1,331 @@
+package txservice
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	txsvc "github.com/cometbft/cometbft/api/cometbft/services/tx/v1"
+	abcicli "github.com/cometbft/cometbft/v2/abci/client"
+	abci "github.com/cometbft/cometbft/v2/abci/types"
+	"github.com/cometbft/cometbft/v2/internal/rpctrace"
+	"github.com/cometbft/cometbft/v2/libs/log"
+	cmtpubsub "github.com/cometbft/cometbft/v2/libs/pubsub"
+	cmtquery "github.com/cometbft/cometbft/v2/libs/pubsub/query"
+	"github.com/cometbft/cometbft/v2/p2p"
+	"github.com/cometbft/cometbft/v2/proxy"
+	"github.com/cometbft/cometbft/v2/rpc/grpc/server/services/subscription"
+	"github.com/cometbft/cometbft/v2/state/txindex"
+	"github.com/cometbft/cometbft/v2/state/txindex/null"
+	"github.com/cometbft/cometbft/v2/store"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+const (
+	// maxQueryLength is the maximum length of a query string that will be
+	// accepted. This is just a safety check to avoid outlandish queries.
+	maxQueryLength = 512
+
+	defaultPerPage = 30
+	maxPerPage     = 100
+)
+
+// MempoolReactor is the part of the mempool reactor used to broadcast
+// transactions.
+type MempoolReactor interface {
+	// WaitSync returns true while the node is catching up, in which case
+	// transactions are not accepted.
+	WaitSync() bool
+	TryAddTx(tx types.Tx, sender p2p.Peer) (*abcicli.ReqRes, error)
+}
+
+type txServiceServer struct {
+	mempoolReactor MempoolReactor
+	proxyApp       proxy.AppConnMempool
+	txIndexer      txindex.TxIndexer
+	blockStore     *store.BlockStore
+	eventBus       *types.EventBus
+	limiter        *subscription.Limiter
+	commitTimeout  time.Duration
+	logger         log.Logger
+}
+
+// New creates a new CometBFT tx service server.
+//
+// In commit mode, BroadcastTx waits at most commitTimeout for the transaction
+// to be included in a block, subscribing to its event within the bounds of
+// the given limiter, if any.
+func New(
+	mempoolReactor MempoolReactor,
+	proxyApp proxy.AppConnMempool,
+	txIndexer txindex.TxIndexer,
+	blockStore *store.BlockStore,
+	eventBus *types.EventBus,
+	limiter *subscription.Limiter,
+	commitTimeout time.Duration,
+	logger log.Logger,
+) txsvc.TxServiceServer {
+	return &txServiceServer{
+		mempoolReactor: mempoolReactor,
+		proxyApp:       proxyApp,
+		txIndexer:      txIndexer,
+		blockStore:     blockStore,
+		eventBus:       eventBus,
+		limiter:        limiter,
+		commitTimeout:  commitTimeout,
+		logger:         logger.With("service", "TxService"),
+	}
+}
+
+// BroadcastTx implements v1.TxServiceServer BroadcastTx method.
+func (s *txServiceServer) BroadcastTx(ctx context.Context, req *txsvc.BroadcastTxRequest) (*txsvc.BroadcastTxResponse, error) {
+	logger := s.logger.With("endpoint", "BroadcastTx")
+
+	if len(req.Tx) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Transaction cannot be empty")
+	}
+	if s.mempoolReactor.WaitSync() {
+		return nil, status.Error(codes.Unavailable, "Node is catching up")
+	}
+	tx := types.Tx(req.Tx)
+
+	switch req.Mode {
+	case txsvc.BroadcastMode_BROADCAST_MODE_UNSPECIFIED, txsvc.BroadcastMode_BROADCAST_MODE_SYNC:
+		checkTxRes, err := s.addTx(ctx, tx)
+		if err != nil {
+			return nil, err
+		}
+		return &txsvc.BroadcastTxResponse{Hash: tx.Hash(), CheckTx: checkTxRes}, nil
+
+	case txsvc.BroadcastMode_BROADCAST_MODE_COMMIT:
+		return s.broadcastTxCommit(ctx, tx, logger)
+
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "Unknown broadcast mode %v", req.Mode)
+	}
+}
+
+func (s *txServiceServer) broadcastTxCommit(ctx context.Context, tx types.Tx, logger log.Logger) (*txsvc.BroadcastTxResponse, error) {
+	release, err := s.limiter.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	traceID, err := rpctrace.New()
+	if err != nil {
+		logger.Error("Error generating RPC trace ID", "err", err)
+		return nil, status.Error(codes.Internal, "Internal server error - see logs for details")
+	}
+
+	// Subscribe to the tx being committed in a block before adding it to the
+	// mempool, so as not to miss it. The trace ID is reused as a unique
+	// subscriber ID.
+	q := types.EventQueryTxFor(tx)
+	txSub, err := s.eventBus.Subscribe(ctx, traceID, q)
+	if err != nil {
+		logger.Error("Cannot subscribe to tx events", "err", err, "traceID", traceID)
+		return nil, status.Errorf(codes.Internal, "Cannot subscribe to tx events (see logs for trace ID: %s)", traceID)
+	}
+	defer func() {
+		if err := s.eventBus.Unsubscribe(context.Background(), traceID, q); err != nil && !errors.Is(err, cmtpubsub.ErrSubscriptionNotFound) {
+			logger.Error("Failed to unsubscribe", "err", err, "traceID", traceID)
+		}
+	}()
+
+	checkTxRes, err := s.addTx(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	res := &txsvc.BroadcastTxResponse{Hash: tx.Hash(), CheckTx: checkTxRes}
+	if checkTxRes.Code != abci.CodeTypeOK {
+		return res, nil
+	}
+
+	timer := time.NewTimer(s.commitTimeout)
+	defer timer.Stop()
+	select {
+	case msg := <-txSub.Out():
+		txEvent := msg.Data().(types.EventDataTx)
+		res.TxResult = &txEvent.Result
+		res.Height = txEvent.Height
+		return res, nil
+	case <-txSub.Canceled():
+		logger.Info("Subscription canceled", "err", txSub.Err(), "traceID", traceID)
+		return nil, status.Errorf(codes.Aborted, "Subscription canceled before the transaction was committed (see logs for trace ID: %s)", traceID)
+	case <-timer.C:
+		return nil, status.Error(codes.DeadlineExceeded, "Timed out waiting for the transaction to be committed")
+	case <-ctx.Done():
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+}
+
+// addTx adds tx to the mempool and returns the result of CheckTx.
+func (s *txServiceServer) addTx(ctx context.Context, tx types.Tx) (*abci.CheckTxResponse, error) {
+	reqRes, err := s.mempoolReactor.TryAddTx(tx, nil)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "Cannot add transaction to the mempool: %v", err)
+	}
+
+	// The ABCI client guarantees that it will eventually call reqRes.Done(),
+	// even in the case of error.
+	done := make(chan struct{})
+	go func() {
+		reqRes.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+	if err := reqRes.Error(); err != nil {
+		return nil, status.Errorf(codes.Internal, "CheckTx failed: %v", err)
+	}
+	return reqRes.Response.GetCheckTx(), nil
+}
+
+// CheckTx implements v1.TxServiceServer CheckTx method.
+func (s *txServiceServer) CheckTx(ctx context.Context, req *txsvc.CheckTxRequest) (*txsvc.CheckTxResponse, error) {
+	if len(req.Tx) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Transaction cannot be empty")
+	}
+	res, err := s.proxyApp.CheckTx(ctx, &abci.CheckTxRequest{Tx: req.Tx, Type: abci.CHECK_TX_TYPE_CHECK})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "CheckTx failed: %v", err)
+	}
+	return &txsvc.CheckTxResponse{CheckTx: res}, nil
+}
+
+// GetTx implements v1.TxServiceServer GetTx method.
+func (s *txServiceServer) GetTx(_ context.Context, req *txsvc.GetTxRequest) (*txsvc.GetTxResponse, error) {
+	logger := s.logger.With("endpoint", "GetTx")
+
+	if err := s.checkIndexingEnabled(); err != nil {
+		return nil, err
+	}
+	if len(req.Hash) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Transaction hash cannot be empty")
+	}
+
+	r, err := s.txIndexer.Get(req.Hash)
+	if err != nil {
+		logger.Error("Error fetching transaction", "err", err, "hash", req.Hash)
+		return nil, status.Error(codes.Internal, "Internal server error - see logs for details")
+	}
+	if r == nil {
+		return nil, status.Errorf(codes.NotFound, "Transaction %X not found", req.Hash)
+	}
+	return &txsvc.GetTxResponse{Tx: s.indexedTx(r, req.Prove)}, nil
+}
+
+// SearchTxs implements v1.TxServiceServer SearchTxs method.
+func (s *txServiceServer) SearchTxs(ctx context.Context, req *txsvc.SearchTxsRequest) (*txsvc.SearchTxsResponse, error) {
+	logger := s.logger.With("endpoint", "SearchTxs")
+
+	q, err := s.parseQuery(req.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	page := int(req.Page)
+	if page == 0 {
+		page = 1
+	}
+	perPage := int(req.PerPage)
+	switch {
+	case perPage < 1:
+		perPage = defaultPerPage
+	case perPage > maxPerPage:
+		perPage = maxPerPage
+	}
+
+	results, total, err := s.txIndexer.Search(ctx, q, txindex.Pagination{
+		OrderDesc:   req.OrderDesc,
+		IsPaginated: true,
+		Page:        page,
+		PerPage:     perPage,
+	})
+	if err != nil {
+		logger.Error("Error searching transactions", "err", err, "query", req.Query)
+		return nil, status.Errorf(codes.InvalidArgument, "Cannot search transactions: %v", err)
+	}
+
+	txs := make([]*txsvc.IndexedTx, 0, len(results))
+	for _, r := range results {
+		txs = append(txs, s.indexedTx(r, req.Prove))
+	}
+	return &txsvc.SearchTxsResponse{Txs: txs, TotalCount: int64(total)}, nil
+}
+
+// SearchTxsStream implements v1.TxServiceServer SearchTxsStream method.
+func (s *txServiceServer) SearchTxsStream(req *txsvc.SearchTxsStreamRequest, stream txsvc.TxService_SearchTxsStreamServer) error {
+	logger := s.logger.With("endpoint", "SearchTxsStream")
+
+	q, err := s.parseQuery(req.Query)
+	if err != nil {
+		return err
+	}
+
+	// Run the query once: the indexers match and sort all the results of
+	// every page anyway, and transactions indexed while streaming would
+	// shift the following pages.
+	results, _, err := s.txIndexer.Search(stream.Context(), q, txindex.Pagination{
+		OrderDesc: req.OrderDesc,
+	})
+	if err != nil {
+		logger.Error("Error searching transactions", "err", err, "query", req.Query)
+		return status.Errorf(codes.InvalidArgument, "Cannot search transactions: %v", err)
+	}
+	for _, r := range results {
+		if err := stream.Send(&txsvc.SearchTxsStreamResponse{Tx: s.indexedTx(r, req.Prove)}); err != nil {
+			logger.Error("Failed to stream transaction", "err", err)
+			return status.Error(codes.Unavailable, "Cannot send stream response")
+		}
+	}
+	return nil
+}
+
+func (s *txServiceServer) checkIndexingEnabled() error {
+	if _, ok := s.txIndexer.(*null.TxIndex); ok {
+		return status.Error(codes.FailedPrecondition, "Transaction indexing is disabled")
+	}
+	return nil
+}
+
+func (s *txServiceServer) parseQuery(query string) (*cmtquery.Query, error) {
+	if err := s.checkIndexingEnabled(); err != nil {
+		return nil, err
+	}
+	if len(query) > maxQueryLength {
+		return nil, status.Errorf(codes.InvalidArgument, "Query is %d bytes long, maximum is %d", len(query), maxQueryLength)
+	}
+	q, err := cmtquery.New(query)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid query: %v", err)
+	}
+	return q, nil
+}
+
+// indexedTx converts r to its Protobuf representation, including the proof of
+// its inclusion in its block if prove is true and the block is available.
+func (s *txServiceServer) indexedTx(r *abci.TxResult, prove bool) *txsvc.IndexedTx {
+	tx := &txsvc.IndexedTx{
+		Hash:     types.Tx(r.Tx).Hash(),
+		Height:   r.Height,
+		Index:    r.Index,
+		Tx:       r.Tx,
+		TxResult: &r.Result,
+	}
+	if prove {
+		block, _ := s.blockStore.LoadBlock(r.Height)
+		if block != nil {
+			proof := block.Data.Txs.Proof(int(r.Index)).ToProto()
+			tx.Proof = &proof
+		}
+	}
+	return tx
+}
@@ -199,8 +199,17 @@ func (blockExec *BlockExecutor) ProcessProposal(
 // Validation does not mutate state, but does require historical information from the stateDB,
 // ie. to verify evidence from a validator at an old height.
 func (blockExec *BlockExecutor) ValidateBlock(state State, block *types.Block) error {
+	return blockExec.ValidateBlockWithCache(state, block, nil)
+}
+
+// ValidateBlockWithCache does the same as `ValidateBlock`, but skips the
+// verification of the LastCommit signatures found in the given cache, and adds
+// the verified ones to it.
+func (blockExec *BlockExecutor) ValidateBlockWithCache(
+	state State, block *types.Block, verifiedSignatureCache types.SignatureCache,
+) error {
 	if !blockExec.lastValidatedBlock.HashesTo(block.Hash()) {
-		if err := validateBlock(state, block); err != nil {
+		if err := validateBlock(state, block, verifiedSignatureCache); err != nil {
 			return err
 		}
 		blockExec.lastValidatedBlock = block
@@ -225,7 +234,7 @@ func (blockExec *BlockExecutor) ApplyBlock(
 	state State, blockID types.BlockID, block *types.Block, syncingToHeight int64,
 ) (State, error) {
 	if !blockExec.lastValidatedBlock.HashesTo(block.Hash()) {
-		if err := validateBlock(state, block); err != nil {
+		if err := validateBlock(state, block, nil); err != nil {
 			return state, ErrInvalidBlock(err)
 		}
 		blockExec.lastValidatedBlock = block
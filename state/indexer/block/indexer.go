@@ -3,6 +3,7 @@ package block
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
 
 	dbm "github.com/cometbft/cometbft-db"
 	"github.com/cometbft/cometbft/v2/config"
@@ -10,6 +11,7 @@ import (
 	blockidxkv "github.com/cometbft/cometbft/v2/state/indexer/block/kv"
 	blockidxnull "github.com/cometbft/cometbft/v2/state/indexer/block/null"
 	"github.com/cometbft/cometbft/v2/state/indexer/sink/psql"
+	"github.com/cometbft/cometbft/v2/state/indexer/sink/sqlite"
 	"github.com/cometbft/cometbft/v2/state/txindex"
 	"github.com/cometbft/cometbft/v2/state/txindex/kv"
 	"github.com/cometbft/cometbft/v2/state/txindex/null"
@@ -63,6 +65,13 @@ func IndexerFromConfig(cfg *config.Config, dbProvider config.DBProvider, chainID
 		}
 		return es.TxIndexer(), es.BlockIndexer(), false, nil
 
+	case "sqlite":
+		es, err := sqlite.NewEventSink(filepath.Join(cfg.DBDir(), sqlite.DBFileName), chainID)
+		if err != nil {
+			return nil, nil, false, fmt.Errorf("creating sqlite indexer: %w", err)
+		}
+		return es.TxIndexer(), es.BlockIndexer(), false, nil
+
 	default:
 		return &null.TxIndex{}, &blockidxnull.BlockerIndexer{}, true, nil
 	}
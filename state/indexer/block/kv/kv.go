@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"math/big"
 	"sort"
 	"strconv"
@@ -248,6 +249,10 @@ func (idx *BlockerIndexer) getLastRetainHeight() (int64, error) {
 // one or more block heights. In the case of height queries, i.e. block.height=H,
 // if the height is indexed, that height alone will be returned. An error and
 // nil slice is returned. Otherwise, a non-nil slice and nil error is returned.
+//
+// Queries with OR and NOT operators are evaluated by searching each
+// conjunction of conditions separately, and then computing the union,
+// intersection or difference of the resulting sets of heights.
 func (idx *BlockerIndexer) Search(ctx context.Context, q *query.Query) ([]int64, error) {
 	results := make([]int64, 0)
 	select {
@@ -257,7 +262,174 @@ func (idx *BlockerIndexer) Search(ctx context.Context, q *query.Query) ([]int64,
 	default:
 	}
 
-	conditions := q.Syntax()
+	if conditions, ok := q.Expr().Conditions(); ok {
+		return idx.searchConditions(ctx, conditions)
+	}
+
+	heights, err := idx.searchExpr(ctx, q.Expr())
+	if err != nil {
+		return nil, err
+	}
+	for h := range heights {
+		results = append(results, h)
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i] < results[j] })
+
+	return results, nil
+}
+
+// searchExpr returns the heights matching expr. Conjunctions of conditions are
+// matched by searchConditions, and the results for the operands of OR, AND and
+// NOT expressions are combined with set operations.
+func (idx *BlockerIndexer) searchExpr(ctx context.Context, expr syntax.Expr) (map[int64]struct{}, error) {
+	if conditions, ok := expr.Conditions(); ok {
+		return idx.searchConjunction(ctx, conditions)
+	}
+
+	switch expr.Op {
+	case syntax.TOr:
+		union := make(map[int64]struct{})
+		for _, arg := range expr.Args {
+			heights, err := idx.searchExpr(ctx, arg)
+			if err != nil {
+				return nil, err
+			}
+			maps.Copy(union, heights)
+		}
+		return union, nil
+
+	case syntax.TAnd:
+		// Match all the conditions at once, intersect the result with the
+		// results for the compound operands, and subtract the results for the
+		// negated operands.
+		var (
+			conditions []syntax.Condition
+			compound   []syntax.Expr
+			negated    []syntax.Expr
+		)
+		for _, arg := range expr.Args {
+			switch {
+			case arg.Cond != nil:
+				conditions = append(conditions, *arg.Cond)
+			case arg.Op == syntax.TNot:
+				negated = append(negated, arg.Args[0])
+			default:
+				compound = append(compound, arg)
+			}
+		}
+
+		var result map[int64]struct{}
+		if len(conditions) > 0 {
+			heights, err := idx.searchConjunction(ctx, conditions)
+			if err != nil {
+				return nil, err
+			}
+			result = heights
+		}
+		for _, arg := range compound {
+			if result != nil && len(result) == 0 {
+				return result, nil
+			}
+			heights, err := idx.searchExpr(ctx, arg)
+			if err != nil {
+				return nil, err
+			}
+			if result == nil {
+				result = heights
+				continue
+			}
+			maps.DeleteFunc(result, func(h int64, _ struct{}) bool {
+				_, ok := heights[h]
+				return !ok
+			})
+		}
+		if result == nil {
+			heights, err := idx.allHeights(ctx)
+			if err != nil {
+				return nil, err
+			}
+			result = heights
+		}
+		return idx.subtract(ctx, result, negated)
+
+	case syntax.TNot:
+		all, err := idx.allHeights(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return idx.subtract(ctx, all, expr.Args)
+
+	default:
+		return nil, fmt.Errorf("unknown operator %v in query", expr.Op)
+	}
+}
+
+// searchConjunction returns the set of heights matching all the given
+// conditions.
+func (idx *BlockerIndexer) searchConjunction(ctx context.Context, conditions []syntax.Condition) (map[int64]struct{}, error) {
+	results, err := idx.searchConditions(ctx, conditions)
+	if err != nil {
+		return nil, err
+	}
+	heights := make(map[int64]struct{}, len(results))
+	for _, h := range results {
+		heights[h] = struct{}{}
+	}
+	return heights, nil
+}
+
+// subtract removes from heights the heights matching any of the given
+// expressions.
+func (idx *BlockerIndexer) subtract(ctx context.Context, heights map[int64]struct{}, exprs []syntax.Expr) (map[int64]struct{}, error) {
+	for _, expr := range exprs {
+		if len(heights) == 0 {
+			break
+		}
+		excluded, err := idx.searchExpr(ctx, expr)
+		if err != nil {
+			return nil, err
+		}
+		for h := range excluded {
+			delete(heights, h)
+		}
+	}
+	return heights, nil
+}
+
+// allHeights returns all the indexed heights.
+func (idx *BlockerIndexer) allHeights(ctx context.Context) (map[int64]struct{}, error) {
+	prefix, err := orderedcode.Append(nil, types.BlockHeightKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create prefix key: %w", err)
+	}
+
+	it, err := dbm.IteratePrefix(idx.store, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create prefix iterator: %w", err)
+	}
+	defer it.Close()
+
+	heights := make(map[int64]struct{})
+LOOP:
+	for ; it.Valid(); it.Next() {
+		heights[int64FromBytes(it.Value())] = struct{}{}
+
+		select {
+		case <-ctx.Done():
+			break LOOP
+		default:
+		}
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+	return heights, nil
+}
+
+// searchConditions returns the heights matching all the given conditions, in
+// ascending order.
+func (idx *BlockerIndexer) searchConditions(ctx context.Context, conditions []syntax.Condition) ([]int64, error) {
+	results := make([]int64, 0)
 
 	// conditions to skip because they're handled before "everything else"
 	skipIndexes := make([]int, 0)
@@ -204,6 +204,22 @@ func TestBlockIndexer(t *testing.T) {
 			q:       query.MustCompile("end_event.foo CONTAINS '1'"),
 			results: []int64{1, 10},
 		},
+		"end_event.foo = 2 OR end_event.foo = 10": {
+			q:       query.MustCompile("end_event.foo = 2 OR end_event.foo = 10"),
+			results: []int64{2, 10},
+		},
+		"NOT end_event.foo EXISTS": {
+			q:       query.MustCompile("NOT end_event.foo EXISTS"),
+			results: []int64{3, 5, 7, 9, 11},
+		},
+		"(block.height <= 3 OR block.height >= 10) AND NOT end_event.foo = 10": {
+			q:       query.MustCompile("(block.height <= 3 OR block.height >= 10) AND NOT end_event.foo = 10"),
+			results: []int64{1, 2, 3, 11},
+		},
+		"begin_event.proposer = 'FCAA001' AND NOT (end_event.foo <= 5 OR block.height > 4)": {
+			q:       query.MustCompile("begin_event.proposer = 'FCAA001' AND NOT (end_event.foo <= 5 OR block.height > 4)"),
+			results: []int64{1, 3},
+		},
 	}
 
 	for name, tc := range testCases {
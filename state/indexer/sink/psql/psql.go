@@ -16,6 +16,7 @@ import (
 	abci "github.com/cometbft/cometbft/v2/abci/types"
 	"github.com/cometbft/cometbft/v2/internal/rand"
 	"github.com/cometbft/cometbft/v2/libs/pubsub/query"
+	"github.com/cometbft/cometbft/v2/state/txindex"
 	"github.com/cometbft/cometbft/v2/types"
 )
 
@@ -300,24 +301,147 @@ func (es *EventSink) IndexTxEvents(txrs []*abci.TxResult) error {
 	return nil
 }
 
-// SearchBlockEvents is not implemented by this sink, and reports an error for all queries.
-func (*EventSink) SearchBlockEvents(_ context.Context, _ *query.Query) ([]int64, error) {
-	return nil, errors.New("block search is not supported via the postgres event sink")
+// SearchBlockEvents returns the heights of the blocks whose events match q,
+// in ascending order.
+func (es *EventSink) SearchBlockEvents(ctx context.Context, q *query.Query) ([]int64, error) {
+	sq := new(sqlQuery)
+	chainID := sq.arg(es.chainID)
+	where, err := es.where(sq, es.blockEventFilter(), q)
+	if err != nil {
+		return nil, fmt.Errorf("translating query: %w", err)
+	}
+
+	rows, err := es.store.QueryContext(ctx, `
+SELECT `+es.tableBlocks+`.height FROM `+es.tableBlocks+`
+  WHERE `+es.tableBlocks+`.chain_id = `+chainID+` AND `+where+`
+  ORDER BY `+es.tableBlocks+`.height;
+`, sq.args...)
+	if err != nil {
+		return nil, fmt.Errorf("searching blocks: %w", err)
+	}
+	defer rows.Close()
+
+	heights := make([]int64, 0)
+	for rows.Next() {
+		var height int64
+		if err := rows.Scan(&height); err != nil {
+			return nil, fmt.Errorf("scanning block height: %w", err)
+		}
+		heights = append(heights, height)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("searching blocks: %w", err)
+	}
+	return heights, nil
+}
+
+// SearchTxEvents returns the tx results whose events match q, ordered by
+// height and index, and the total number of matching results. If the search
+// is paginated, only the results in the requested page are returned.
+func (es *EventSink) SearchTxEvents(ctx context.Context, q *query.Query, pagSettings txindex.Pagination) ([]*abci.TxResult, int, error) {
+	sq := new(sqlQuery)
+	chainID := sq.arg(es.chainID)
+	where, err := es.where(sq, es.txEventFilter(), q)
+	if err != nil {
+		return nil, 0, fmt.Errorf("translating query: %w", err)
+	}
+	from := es.tableTxResults + ` JOIN ` + es.tableBlocks + `
+  ON ` + es.tableBlocks + `.rowid = ` + es.tableTxResults + `.block_id
+  WHERE ` + es.tableBlocks + `.chain_id = ` + chainID + ` AND ` + where
+
+	var total int
+	if err := es.store.QueryRowContext(ctx, `SELECT count(*) FROM `+from+`;`, sq.args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("counting tx results: %w", err)
+	}
+
+	order := "ASC"
+	if pagSettings.OrderDesc {
+		order = "DESC"
+	}
+	limit := ""
+	if pagSettings.IsPaginated {
+		page, err := validatePage(pagSettings.Page, pagSettings.PerPage, total)
+		if err != nil {
+			return nil, 0, err
+		}
+		limit = fmt.Sprintf(" LIMIT %s OFFSET %s", sq.arg(pagSettings.PerPage), sq.arg((page-1)*pagSettings.PerPage))
+	}
+
+	rows, err := es.store.QueryContext(ctx, `
+SELECT `+es.tableTxResults+`.tx_result FROM `+from+`
+  ORDER BY `+es.tableBlocks+`.height `+order+`, `+es.tableTxResults+`.index `+order+limit+`;
+`, sq.args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("searching tx results: %w", err)
+	}
+	defer rows.Close()
+
+	results := make([]*abci.TxResult, 0)
+	for rows.Next() {
+		var resultData []byte
+		if err := rows.Scan(&resultData); err != nil {
+			return nil, 0, fmt.Errorf("scanning tx result: %w", err)
+		}
+		txr := new(abci.TxResult)
+		if err := proto.Unmarshal(resultData, txr); err != nil {
+			return nil, 0, fmt.Errorf("unmarshaling tx_result: %w", err)
+		}
+		results = append(results, txr)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("searching tx results: %w", err)
+	}
+	return results, total, nil
 }
 
-// SearchTxEvents is not implemented by this sink, and reports an error for all queries.
-func (*EventSink) SearchTxEvents(_ context.Context, _ *query.Query) ([]*abci.TxResult, error) {
-	return nil, errors.New("tx search is not supported via the postgres event sink")
+// GetTxByHash returns the tx result for the transaction with the given hash,
+// or nil if the transaction is not indexed. If the transaction was included in
+// more than one block, the latest result is returned.
+func (es *EventSink) GetTxByHash(hash []byte) (*abci.TxResult, error) {
+	var resultData []byte
+	err := es.store.QueryRow(`
+SELECT `+es.tableTxResults+`.tx_result FROM `+es.tableTxResults+` JOIN `+es.tableBlocks+`
+  ON `+es.tableBlocks+`.rowid = `+es.tableTxResults+`.block_id
+  WHERE `+es.tableTxResults+`.tx_hash = $1 AND `+es.tableBlocks+`.chain_id = $2
+  ORDER BY `+es.tableBlocks+`.height DESC
+  LIMIT 1;
+`, fmt.Sprintf("%X", hash), es.chainID).Scan(&resultData)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("getting tx result: %w", err)
+	}
+
+	txr := new(abci.TxResult)
+	if err := proto.Unmarshal(resultData, txr); err != nil {
+		return nil, fmt.Errorf("unmarshaling tx_result: %w", err)
+	}
+	return txr, nil
 }
 
-// GetTxByHash is not implemented by this sink, and reports an error for all queries.
-func (*EventSink) GetTxByHash(_ []byte) (*abci.TxResult, error) {
-	return nil, errors.New("getTxByHash is not supported via the postgres event sink")
+// HasBlock reports whether the events of the block at the given height have
+// been indexed.
+func (es *EventSink) HasBlock(height int64) (bool, error) {
+	var exists bool
+	if err := es.store.QueryRow(`
+SELECT EXISTS(SELECT 1 FROM `+es.tableBlocks+` WHERE height = $1 AND chain_id = $2);
+`, height, es.chainID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("checking block existence: %w", err)
+	}
+	return exists, nil
 }
 
-// HasBlock is not implemented by this sink, and reports an error for all queries.
-func (*EventSink) HasBlock(_ int64) (bool, error) {
-	return false, errors.New("hasBlock is not supported via the postgres event sink")
+// validatePage returns the requested page if it is within the range of pages
+// needed to return totalCount results, perPage at a time.
+func validatePage(page, perPage, totalCount int) (int, error) {
+	if perPage < 1 {
+		return 1, fmt.Errorf("zero or negative perPage: %d", perPage)
+	}
+	pages := max(((totalCount-1)/perPage)+1, 1)
+	if page <= 0 || page > pages {
+		return 1, fmt.Errorf("page should be within [1, %d] range, given %d", pages, page)
+	}
+	return page, nil
 }
 
 // Stop closes the underlying PostgreSQL database.
@@ -23,6 +23,7 @@ import (
 
 	abci "github.com/cometbft/cometbft/v2/abci/types"
 	tmlog "github.com/cometbft/cometbft/v2/libs/log"
+	"github.com/cometbft/cometbft/v2/libs/pubsub/query"
 	"github.com/cometbft/cometbft/v2/state/txindex"
 	"github.com/cometbft/cometbft/v2/types"
 )
@@ -147,13 +148,33 @@ func TestIndexing(t *testing.T) {
 		verifyBlock(t, indexer, 1)
 		verifyBlock(t, indexer, 2)
 
-		verifyNotImplemented(t, "hasBlock", func() (bool, error) { return indexer.HasBlock(1) })
-		verifyNotImplemented(t, "hasBlock", func() (bool, error) { return indexer.HasBlock(2) })
-
-		verifyNotImplemented(t, "block search", func() (bool, error) {
-			v, err := indexer.SearchBlockEvents(context.Background(), nil)
-			return v != nil, err
-		})
+		has, err := indexer.HasBlock(1)
+		require.NoError(t, err)
+		assert.True(t, has)
+		has, err = indexer.HasBlock(2)
+		require.NoError(t, err)
+		assert.False(t, has)
+
+		testCases := []struct {
+			q       string
+			heights []int64
+		}{
+			{"begin_event.proposer = 'FCAA001'", []int64{1}},
+			{"begin_event.proposer = 'FCAA002'", []int64{}},
+			{"thingy.whatzit CONTAINS '-.'", []int64{1}},
+			{"end_event.foo >= 100 AND end_event.foo < 101", []int64{1}},
+			{"end_event.foo > 100", []int64{}},
+			{"begin_event EXISTS", []int64{1}},
+			{"block.height = 1 AND thingy.whatzit = 'O.O'", []int64{1}},
+			{"block.height > 1", []int64{}},
+			{"block.height > 1 OR begin_event.proposer = 'FCAA001'", []int64{1}},
+			{"NOT end_event.foo = 100", []int64{}},
+		}
+		for _, tc := range testCases {
+			heights, err := indexer.SearchBlockEvents(context.Background(), query.MustCompile(tc.q))
+			require.NoError(t, err, tc.q)
+			assert.Equal(t, tc.heights, heights, tc.q)
+		}
 
 		require.NoError(t, verifyTimeStamp(indexer.tableBlocks))
 
@@ -187,14 +208,39 @@ func TestIndexing(t *testing.T) {
 		require.NoError(t, verifyTimeStamp(indexer.tableTxResults))
 		require.NoError(t, verifyTimeStamp(viewTxEvents))
 
-		verifyNotImplemented(t, "getTxByHash", func() (bool, error) {
-			txr, err := indexer.GetTxByHash(types.Tx(txResult.Tx).Hash())
-			return txr != nil, err
-		})
-		verifyNotImplemented(t, "tx search", func() (bool, error) {
-			txr, err := indexer.SearchTxEvents(context.Background(), nil)
-			return txr != nil, err
-		})
+		txr, err = indexer.GetTxByHash(types.Tx(txResult.Tx).Hash())
+		require.NoError(t, err)
+		assert.Equal(t, txResult, txr)
+		txr, err = indexer.GetTxByHash(types.Tx("unknown").Hash())
+		require.NoError(t, err)
+		assert.Nil(t, txr)
+
+		pagination := txindex.Pagination{IsPaginated: true, Page: 1, PerPage: 10}
+		testCases := []struct {
+			q       string
+			results []*abci.TxResult
+		}{
+			{"account.owner = 'Ivan'", []*abci.TxResult{txResult}},
+			{"account.owner = 'Vlad'", []*abci.TxResult{}},
+			{"account.number >= 1 AND account.owner CONTAINS 'Yul'", []*abci.TxResult{txResult}},
+			{"account.number < 1", []*abci.TxResult{}},
+			{fmt.Sprintf("tx.hash = '%x'", types.Tx(txResult.Tx).Hash()), []*abci.TxResult{txResult}},
+			{"tx.height = 1 AND account EXISTS", []*abci.TxResult{txResult}},
+			{"tx.height > 1", []*abci.TxResult{}},
+			{"account.owner = 'Vlad' OR account.number = 1", []*abci.TxResult{txResult}},
+			{"account EXISTS AND NOT (account.owner = 'Vlad' OR tx.height > 1)", []*abci.TxResult{txResult}},
+		}
+		for _, tc := range testCases {
+			results, total, err := indexer.SearchTxEvents(context.Background(), query.MustCompile(tc.q), pagination)
+			require.NoError(t, err, tc.q)
+			assert.Equal(t, tc.results, results, tc.q)
+			assert.Equal(t, len(tc.results), total, tc.q)
+		}
+
+		// Pages out of range are rejected.
+		pagination.Page = 2
+		_, _, err = indexer.SearchTxEvents(context.Background(), query.MustCompile("account.owner = 'Ivan'"), pagination)
+		require.Error(t, err)
 
 		// try to insert the duplicate tx events.
 		err = indexer.IndexTxEvents([]*abci.TxResult{txResult})
@@ -362,20 +408,6 @@ SELECT type, height, chain_id FROM `+viewBlockEvents+`
 	}
 }
 
-// verifyNotImplemented calls f and verifies that it returns both a
-// false-valued flag and a non-nil error whose string matching the expected
-// "not supported" message with label prefixed.
-func verifyNotImplemented(t *testing.T, label string, f func() (bool, error)) {
-	t.Helper()
-	t.Logf("Verifying that %q reports it is not implemented", label)
-
-	want := label + " is not supported via the postgres event sink"
-	ok, err := f()
-	assert.False(t, ok)
-	require.Error(t, err)
-	assert.Equal(t, want, err.Error())
-}
-
 // waitForInterrupt blocks until a SIGINT is received by the process.
 func waitForInterrupt() {
 	ch := make(chan os.Signal, 1)
@@ -0,0 +1,215 @@
+package psql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cometbft/cometbft/v2/libs/pubsub/query"
+	"github.com/cometbft/cometbft/v2/libs/pubsub/query/syntax"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+// Patterns used to extract comparable values from attribute values, matching
+// the parsing done by the query package. Values that do not match compare as
+// NULL, so they never satisfy the condition.
+const (
+	numberPattern = `^[0-9]+(?:\.[0-9]+)?`
+	datePattern   = `^[0-9]{4}-[0-9]{2}-[0-9]{2}$`
+	timePattern   = `^[0-9]{4}-[0-9]{2}-[0-9]{2}T[0-9]{2}:[0-9]{2}:[0-9]{2}(?:\.[0-9]+)?(?:Z|[+-][0-9]{2}:[0-9]{2})$`
+)
+
+// sqlQuery accumulates the text of a SQL filter and its positional arguments.
+type sqlQuery struct {
+	args []any
+}
+
+// arg adds v to the arguments of the query and returns its placeholder.
+func (q *sqlQuery) arg(v any) string {
+	q.args = append(q.args, v)
+	return "$" + strconv.Itoa(len(q.args))
+}
+
+// eventFilter describes how the conditions of a query are translated into SQL
+// for a kind of events (block or transaction events).
+type eventFilter struct {
+	// Column identifying the row being searched (a block or a tx result).
+	idColumn string
+	// Subquery returning the IDs of the rows with a matching event, given the
+	// predicate on the event (columns of the events and attributes tables).
+	eventIDs func(predicate string) string
+	// Special tags that are matched directly against columns of the rows being
+	// searched, rather than against their events.
+	heightTag, hashTag string
+}
+
+// txEventFilter returns the filter used to search tx results.
+func (es *EventSink) txEventFilter() eventFilter {
+	return eventFilter{
+		idColumn: es.tableTxResults + ".rowid",
+		eventIDs: func(predicate string) string {
+			return `SELECT ev.tx_id FROM ` + es.tableEvents + ` ev
+  LEFT JOIN ` + es.tableAttributes + ` attr ON ev.rowid = attr.event_id
+  WHERE ev.tx_id IS NOT NULL AND (` + predicate + `)`
+		},
+		heightTag: types.TxHeightKey,
+		hashTag:   types.TxHashKey,
+	}
+}
+
+// blockEventFilter returns the filter used to search blocks.
+func (es *EventSink) blockEventFilter() eventFilter {
+	return eventFilter{
+		idColumn: es.tableBlocks + ".rowid",
+		eventIDs: func(predicate string) string {
+			return `SELECT ev.block_id FROM ` + es.tableEvents + ` ev
+  LEFT JOIN ` + es.tableAttributes + ` attr ON ev.rowid = attr.event_id
+  WHERE ev.tx_id IS NULL AND (` + predicate + `)`
+		},
+		heightTag: types.BlockHeightKey,
+	}
+}
+
+// where translates q into a SQL predicate. Like the kv indexer, a row matches
+// a condition if it is satisfied by at least one of its events, and the
+// conditions are combined with the AND, OR and NOT operators of the query.
+func (es *EventSink) where(sq *sqlQuery, f eventFilter, q *query.Query) (string, error) {
+	return es.exprPredicate(sq, f, q.Expr())
+}
+
+// exprPredicate translates expr into a SQL predicate.
+func (es *EventSink) exprPredicate(sq *sqlQuery, f eventFilter, expr syntax.Expr) (string, error) {
+	if expr.Cond != nil {
+		return es.conditionPredicate(sq, f, *expr.Cond)
+	}
+
+	preds := make([]string, 0, len(expr.Args))
+	for _, arg := range expr.Args {
+		pred, err := es.exprPredicate(sq, f, arg)
+		if err != nil {
+			return "", err
+		}
+		preds = append(preds, "("+pred+")")
+	}
+
+	switch expr.Op {
+	case syntax.TAnd:
+		if len(preds) == 0 {
+			return "TRUE", nil
+		}
+		return strings.Join(preds, " AND "), nil
+	case syntax.TOr:
+		if len(preds) == 0 {
+			return "FALSE", nil
+		}
+		return strings.Join(preds, " OR "), nil
+	case syntax.TNot:
+		if len(preds) != 1 {
+			return "", fmt.Errorf("invalid number of operands for NOT: %d", len(preds))
+		}
+		return "NOT " + preds[0], nil
+	default:
+		return "", fmt.Errorf("unsupported operator %v", expr.Op)
+	}
+}
+
+// conditionPredicate translates a single condition into a SQL predicate.
+func (es *EventSink) conditionPredicate(sq *sqlQuery, f eventFilter, c syntax.Condition) (string, error) {
+	switch {
+	case c.Tag == f.heightTag && c.Arg != nil && c.Arg.Type == syntax.TNumber:
+		op, err := sqlOperator(c.Op)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s.height %s %s::numeric", es.tableBlocks, op, sq.arg(c.Arg.Value())), nil
+
+	case c.Tag == f.hashTag && c.Op == syntax.TEq && c.Arg != nil && c.Arg.Type == syntax.TString:
+		// Hashes are stored as uppercase hex strings.
+		return fmt.Sprintf("%s.tx_hash = %s", es.tableTxResults, sq.arg(strings.ToUpper(c.Arg.Value()))), nil
+	}
+
+	valuePred, err := valuePredicate(sq, "attr.value", c)
+	if err != nil {
+		return "", err
+	}
+	tag := sq.arg(c.Tag)
+	pred := fmt.Sprintf("attr.composite_key = %s AND %s", tag, valuePred)
+
+	// As in the query package, a tag equal to the type of an event is matched
+	// against an empty value.
+	if conditionMatchesEmpty(c) {
+		pred = fmt.Sprintf("(%s) OR ev.type = %s", pred, tag)
+	}
+	return fmt.Sprintf("%s IN (%s)", f.idColumn, f.eventIDs(pred)), nil
+}
+
+// valuePredicate returns a SQL predicate checking that column satisfies the
+// operator and argument of c.
+func valuePredicate(sq *sqlQuery, column string, c syntax.Condition) (string, error) {
+	if c.Op == syntax.TExists {
+		return "TRUE", nil
+	}
+	if c.Arg == nil {
+		return "", fmt.Errorf("missing argument for %v", c.Op)
+	}
+
+	switch c.Arg.Type {
+	case syntax.TString:
+		switch c.Op {
+		case syntax.TEq:
+			return fmt.Sprintf("%s = %s", column, sq.arg(c.Arg.Value())), nil
+		case syntax.TContains:
+			return fmt.Sprintf("strpos(%s, %s) > 0", column, sq.arg(c.Arg.Value())), nil
+		}
+
+	case syntax.TNumber:
+		if op, err := sqlOperator(c.Op); err == nil {
+			return fmt.Sprintf("substring(%s from '%s')::numeric %s %s::numeric",
+				column, numberPattern, op, sq.arg(c.Arg.Value())), nil
+		}
+
+	case syntax.TDate:
+		if op, err := sqlOperator(c.Op); err == nil {
+			return fmt.Sprintf("substring(%s from '%s')::date %s %s::date",
+				column, datePattern, op, sq.arg(c.Arg.Time().Format(syntax.DateFormat))), nil
+		}
+
+	case syntax.TTime:
+		if op, err := sqlOperator(c.Op); err == nil {
+			return fmt.Sprintf("substring(%s from '%s')::timestamptz %s %s::timestamptz",
+				column, timePattern, op, sq.arg(c.Arg.Time().Format(syntax.TimeFormat))), nil
+		}
+	}
+	return "", fmt.Errorf("invalid op/arg combination (%v, %v)", c.Op, c.Arg.Type)
+}
+
+// conditionMatchesEmpty reports whether c is satisfied by an empty value.
+// Empty values are not numbers, dates or timestamps.
+func conditionMatchesEmpty(c syntax.Condition) bool {
+	switch c.Op {
+	case syntax.TExists:
+		return true
+	case syntax.TEq, syntax.TContains:
+		return c.Arg != nil && c.Arg.Type == syntax.TString && c.Arg.Value() == ""
+	default:
+		return false
+	}
+}
+
+// sqlOperator returns the SQL comparison operator corresponding to op.
+func sqlOperator(op syntax.Token) (string, error) {
+	switch op {
+	case syntax.TEq:
+		return "=", nil
+	case syntax.TLt:
+		return "<", nil
+	case syntax.TLeq:
+		return "<=", nil
+	case syntax.TGt:
+		return ">", nil
+	case syntax.TGeq:
+		return ">=", nil
+	default:
+		return "", fmt.Errorf("unsupported comparison operator %v", op)
+	}
+}
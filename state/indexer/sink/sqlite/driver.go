@@ -0,0 +1,21 @@
+//go:build cgo
+
+package sqlite
+
+import (
+	"database/sql"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// Supported reports whether the sink is supported by the binary: the SQLite
+// driver requires cgo.
+const Supported = true
+
+func init() {
+	sql.Register(driverName, &sqlite3.SQLiteDriver{
+		ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+			return conn.RegisterFunc(matchFuncName, matchValue, true)
+		},
+	})
+}
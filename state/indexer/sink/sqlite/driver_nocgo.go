@@ -0,0 +1,7 @@
+//go:build !cgo
+
+package sqlite
+
+// Supported reports whether the sink is supported by the binary: the SQLite
+// driver requires cgo.
+const Supported = false
@@ -0,0 +1,120 @@
+package sqlite
+
+import (
+	"context"
+
+	abci "github.com/cometbft/cometbft/v2/abci/types"
+	"github.com/cometbft/cometbft/v2/libs/log"
+	"github.com/cometbft/cometbft/v2/libs/pubsub/query"
+	"github.com/cometbft/cometbft/v2/state/indexer"
+	"github.com/cometbft/cometbft/v2/state/txindex"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+var (
+	_ txindex.TxIndexer    = TxIndexer{}
+	_ indexer.BlockIndexer = BlockIndexer{}
+)
+
+// TxIndexer returns the transaction indexer backed by es.
+func (es *EventSink) TxIndexer() TxIndexer {
+	return TxIndexer{sqlite: es}
+}
+
+// TxIndexer implements the txindex.TxIndexer interface by delegating
+// indexing operations to an underlying SQLite event sink.
+type TxIndexer struct{ sqlite *EventSink }
+
+// GetRetainHeight returns the retain height of the tx indexer, as part of
+// TxIndexer.
+func (t TxIndexer) GetRetainHeight() (int64, error) {
+	return t.sqlite.GetTxRetainHeight()
+}
+
+// SetRetainHeight sets the retain height of the tx indexer, as part of
+// TxIndexer.
+func (t TxIndexer) SetRetainHeight(retainHeight int64) error {
+	return t.sqlite.SetTxRetainHeight(retainHeight)
+}
+
+// Prune removes the transactions below retainHeight, as part of TxIndexer.
+func (t TxIndexer) Prune(retainHeight int64) (numPruned, newRetainHeight int64, err error) {
+	return t.sqlite.PruneTxEvents(retainHeight)
+}
+
+// AddBatch indexes a batch of transactions in SQLite, as part of TxIndexer.
+func (t TxIndexer) AddBatch(batch *txindex.Batch) error {
+	return t.sqlite.IndexTxEvents(batch.Ops)
+}
+
+// Index indexes a single transaction result in SQLite, as part of TxIndexer.
+func (t TxIndexer) Index(txr *abci.TxResult) error {
+	return t.sqlite.IndexTxEvents([]*abci.TxResult{txr})
+}
+
+// Get returns the result of the transaction with the given hash, or nil if it
+// is not indexed, as part of TxIndexer.
+func (t TxIndexer) Get(hash []byte) (*abci.TxResult, error) {
+	return t.sqlite.GetTxByHash(hash)
+}
+
+// Search returns the transaction results matching the query, as part of
+// TxIndexer.
+func (t TxIndexer) Search(ctx context.Context, q *query.Query, pagSettings txindex.Pagination) ([]*abci.TxResult, int, error) {
+	return t.sqlite.SearchTxEvents(ctx, q, pagSettings)
+}
+
+func (TxIndexer) SetLogger(log.Logger) {}
+
+// Close closes the indexer's underlying database. The caller is responsible for
+// calling Close when done with the indexer.
+func (t TxIndexer) Close() error {
+	return t.sqlite.Stop()
+}
+
+// BlockIndexer returns the block indexer backed by es.
+func (es *EventSink) BlockIndexer() BlockIndexer {
+	return BlockIndexer{sqlite: es}
+}
+
+// BlockIndexer implements the indexer.BlockIndexer interface by delegating
+// indexing operations to an underlying SQLite event sink.
+type BlockIndexer struct{ sqlite *EventSink }
+
+// SetRetainHeight sets the retain height of the block indexer. It is part of
+// the BlockIndexer interface.
+func (b BlockIndexer) SetRetainHeight(retainHeight int64) error {
+	return b.sqlite.SetBlockRetainHeight(retainHeight)
+}
+
+// GetRetainHeight returns the retain height of the block indexer. It is part
+// of the BlockIndexer interface.
+func (b BlockIndexer) GetRetainHeight() (int64, error) {
+	return b.sqlite.GetBlockRetainHeight()
+}
+
+// Prune removes the events of the blocks below retainHeight. It is part of the
+// BlockIndexer interface.
+func (b BlockIndexer) Prune(retainHeight int64) (numPruned, newRetainHeight int64, err error) {
+	return b.sqlite.PruneBlockEvents(retainHeight)
+}
+
+// Has reports whether the events of the block at the given height have been
+// indexed. It is part of the BlockIndexer interface.
+func (b BlockIndexer) Has(height int64) (bool, error) {
+	return b.sqlite.HasBlock(height)
+}
+
+// Index indexes block begin and end events for the specified block. It is
+// part of the BlockIndexer interface.
+func (b BlockIndexer) Index(block types.EventDataNewBlockEvents) error {
+	return b.sqlite.IndexBlockEvents(block)
+}
+
+// Search returns the heights of the blocks whose events match the query. It
+// is part of the BlockIndexer interface.
+func (b BlockIndexer) Search(ctx context.Context, q *query.Query) ([]int64, error) {
+	return b.sqlite.SearchBlockEvents(ctx, q)
+}
+
+func (BlockIndexer) SetLogger(log.Logger) {}
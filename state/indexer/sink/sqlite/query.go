@@ -0,0 +1,225 @@
+package sqlite
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/cometbft/cometbft/v2/libs/pubsub/query"
+	"github.com/cometbft/cometbft/v2/libs/pubsub/query/syntax"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+const (
+	// matchFuncName is the name of the SQL function that reports whether an
+	// attribute value satisfies a condition, given as text. It lets numbers,
+	// dates and timestamps be compared as in the query package.
+	matchFuncName = "cmt_match"
+
+	// maxMatchers is the maximum number of compiled conditions cached for the
+	// match function.
+	maxMatchers = 1024
+)
+
+// matchers caches the compiled conditions used by the match function.
+var matchers = struct {
+	sync.Mutex
+	m map[string]func(string) bool
+}{m: make(map[string]func(string) bool)}
+
+// conditionMatcher returns the value matcher of the condition with the given
+// text.
+func conditionMatcher(condition string) (func(string) bool, error) {
+	matchers.Lock()
+	defer matchers.Unlock()
+
+	if match, ok := matchers.m[condition]; ok {
+		return match, nil
+	}
+	ast, err := syntax.Parse(condition)
+	if err != nil {
+		return nil, err
+	}
+	if len(ast) != 1 {
+		return nil, fmt.Errorf("invalid condition %q", condition)
+	}
+	match, err := query.ValueMatcher(ast[0])
+	if err != nil {
+		return nil, err
+	}
+	if len(matchers.m) >= maxMatchers {
+		clear(matchers.m)
+	}
+	matchers.m[condition] = match
+	return match, nil
+}
+
+// matchValue implements the match function.
+func matchValue(condition string, value any) (bool, error) {
+	var s string
+	switch v := value.(type) {
+	case nil:
+		return false, nil
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return false, errors.New("attribute value is not text")
+	}
+	match, err := conditionMatcher(condition)
+	if err != nil {
+		return false, err
+	}
+	return match(s), nil
+}
+
+// sqlQuery accumulates the positional arguments of a SQL filter.
+type sqlQuery struct {
+	args []any
+}
+
+// arg adds v to the arguments of the query and returns its placeholder.
+func (q *sqlQuery) arg(v any) string {
+	q.args = append(q.args, v)
+	return "?" + strconv.Itoa(len(q.args))
+}
+
+// eventFilter describes how the conditions of a query are translated into SQL
+// for a kind of events (block or transaction events).
+type eventFilter struct {
+	// Column identifying the row being searched (a block or a tx result).
+	idColumn string
+	// Subquery returning the IDs of the rows with a matching event, given the
+	// predicate on the event (columns of the events and attributes tables).
+	eventIDs func(predicate string) string
+	// Special tags that are matched directly against columns of the rows being
+	// searched, rather than against their events.
+	heightTag, hashTag string
+}
+
+// txEventFilter returns the filter used to search tx results.
+func txEventFilter() eventFilter {
+	return eventFilter{
+		idColumn: tableTxResults + ".rowid",
+		eventIDs: func(predicate string) string {
+			return `SELECT ev.tx_id FROM ` + tableEvents + ` ev
+  LEFT JOIN ` + tableAttributes + ` attr ON ev.rowid = attr.event_id
+  WHERE ev.tx_id IS NOT NULL AND (` + predicate + `)`
+		},
+		heightTag: types.TxHeightKey,
+		hashTag:   types.TxHashKey,
+	}
+}
+
+// blockEventFilter returns the filter used to search blocks.
+func blockEventFilter() eventFilter {
+	return eventFilter{
+		idColumn: tableBlocks + ".rowid",
+		eventIDs: func(predicate string) string {
+			return `SELECT ev.block_id FROM ` + tableEvents + ` ev
+  LEFT JOIN ` + tableAttributes + ` attr ON ev.rowid = attr.event_id
+  WHERE ev.tx_id IS NULL AND (` + predicate + `)`
+		},
+		heightTag: types.BlockHeightKey,
+	}
+}
+
+// where translates q into a SQL predicate. Like the kv indexer, a row matches
+// a condition if it is satisfied by at least one of its events, and the
+// conditions are combined with the AND, OR and NOT operators of the query.
+func where(sq *sqlQuery, f eventFilter, q *query.Query) (string, error) {
+	return exprPredicate(sq, f, q.Expr())
+}
+
+// exprPredicate translates expr into a SQL predicate.
+func exprPredicate(sq *sqlQuery, f eventFilter, expr syntax.Expr) (string, error) {
+	if expr.Cond != nil {
+		return conditionPredicate(sq, f, *expr.Cond)
+	}
+
+	preds := make([]string, 0, len(expr.Args))
+	for _, arg := range expr.Args {
+		pred, err := exprPredicate(sq, f, arg)
+		if err != nil {
+			return "", err
+		}
+		preds = append(preds, "("+pred+")")
+	}
+
+	switch expr.Op {
+	case syntax.TAnd:
+		if len(preds) == 0 {
+			return "TRUE", nil
+		}
+		return strings.Join(preds, " AND "), nil
+	case syntax.TOr:
+		if len(preds) == 0 {
+			return "FALSE", nil
+		}
+		return strings.Join(preds, " OR "), nil
+	case syntax.TNot:
+		if len(preds) != 1 {
+			return "", fmt.Errorf("invalid number of operands for NOT: %d", len(preds))
+		}
+		return "NOT " + preds[0], nil
+	default:
+		return "", fmt.Errorf("unsupported operator %v", expr.Op)
+	}
+}
+
+// conditionPredicate translates a single condition into a SQL predicate.
+func conditionPredicate(sq *sqlQuery, f eventFilter, c syntax.Condition) (string, error) {
+	match, err := query.ValueMatcher(c)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case c.Tag == f.heightTag && c.Arg != nil && c.Arg.Type == syntax.TNumber:
+		return fmt.Sprintf("%s.height %s %s", tableBlocks, sqlOperator(c.Op), sq.arg(c.Arg.Value())), nil
+
+	case c.Tag == f.hashTag && c.Op == syntax.TEq && c.Arg != nil && c.Arg.Type == syntax.TString:
+		// Hashes are stored as uppercase hex strings.
+		return fmt.Sprintf("%s.tx_hash = %s", tableTxResults, sq.arg(strings.ToUpper(c.Arg.Value()))), nil
+	}
+
+	var valuePred string
+	switch {
+	case c.Op == syntax.TExists:
+		valuePred = "TRUE"
+	case c.Op == syntax.TEq && c.Arg.Type == syntax.TString:
+		// Compare strings directly, so that the index on attributes is used.
+		valuePred = "attr.value = " + sq.arg(c.Arg.Value())
+	default:
+		valuePred = fmt.Sprintf("%s(%s, attr.value)", matchFuncName, sq.arg(c.String()))
+	}
+	tag := sq.arg(c.Tag)
+	pred := fmt.Sprintf("attr.composite_key = %s AND %s", tag, valuePred)
+
+	// As in the query package, a tag equal to the type of an event is matched
+	// against an empty value.
+	if match("") {
+		pred = fmt.Sprintf("(%s) OR ev.type = %s", pred, tag)
+	}
+	return fmt.Sprintf("%s IN (%s)", f.idColumn, f.eventIDs(pred)), nil
+}
+
+// sqlOperator returns the SQL comparison operator corresponding to op, which
+// must be a comparison operator.
+func sqlOperator(op syntax.Token) string {
+	switch op {
+	case syntax.TLt:
+		return "<"
+	case syntax.TLeq:
+		return "<="
+	case syntax.TGt:
+		return ">"
+	case syntax.TGeq:
+		return ">="
+	default:
+		return "="
+	}
+}
@@ -0,0 +1,570 @@
+// Package sqlite implements an event sink backed by an embedded SQLite
+// database.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	_ "embed" // embed the schema
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cosmos/gogoproto/proto"
+
+	abci "github.com/cometbft/cometbft/v2/abci/types"
+	"github.com/cometbft/cometbft/v2/libs/pubsub/query"
+	"github.com/cometbft/cometbft/v2/state"
+	"github.com/cometbft/cometbft/v2/state/txindex"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+const (
+	// DBFileName is the name of the database file of the sink, in the
+	// directory of the node databases.
+	DBFileName = "tx_index.sqlite"
+
+	driverName = "sqlite3_cometbft"
+
+	tableBlocks     = "blocks"
+	tableTxResults  = "tx_results"
+	tableEvents     = "events"
+	tableAttributes = "attributes"
+
+	// Names of the rows of the retain_heights table.
+	txRetainHeightKey        = "tx_indexer"
+	lastTxRetainHeightKey    = "last_tx_indexer"
+	blockRetainHeightKey     = "block_indexer"
+	lastBlockRetainHeightKey = "last_block_indexer"
+)
+
+//go:embed schema.sql
+var schema string
+
+// ErrNotSupported is returned when creating a sink in a binary built without
+// cgo, which the SQLite driver requires.
+var ErrNotSupported = errors.New("the sqlite indexer requires a binary built with cgo (CGO_ENABLED=1)")
+
+// EventSink is an indexer backend providing the tx/block index services. This
+// implementation stores records in a SQLite database file using the schema
+// defined in state/indexer/sink/sqlite/schema.sql, which is installed when
+// the sink is created.
+type EventSink struct {
+	store   *sql.DB
+	chainID string
+}
+
+// NewEventSink constructs an event sink storing events in the SQLite database
+// at path, which is created if it does not exist. Events written to the sink
+// are attributed to the specified chainID.
+func NewEventSink(path, chainID string) (*EventSink, error) {
+	if !Supported {
+		return nil, ErrNotSupported
+	}
+	// Writers take the database lock when they start a transaction, rather
+	// than when they first write, so that concurrent writers wait for each
+	// other instead of failing.
+	db, err := sql.Open(driverName,
+		"file:"+path+"?_journal_mode=WAL&_busy_timeout=5000&_foreign_keys=on&_txlock=immediate")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.Exec(schema); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("installing schema: %w", err)
+	}
+	return &EventSink{store: db, chainID: chainID}, nil
+}
+
+// DB returns the underlying SQLite database used by the sink.
+// This is exported to support testing.
+func (es *EventSink) DB() *sql.DB { return es.store }
+
+// runInTransaction executes query in a fresh database transaction.
+// If query reports an error, the transaction is rolled back and the
+// error from query is reported to the caller.
+// Otherwise, the result of committing the transaction is returned.
+func runInTransaction(db *sql.DB, query func(*sql.Tx) error) error {
+	dbtx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	if err := query(dbtx); err != nil {
+		_ = dbtx.Rollback() // report the initial error, not the rollback
+		return err
+	}
+	return dbtx.Commit()
+}
+
+// insertEvents inserts the events of the given block and transaction. If txID
+// is not positive, the events are block events.
+func insertEvents(dbtx *sql.Tx, blockID, txID int64, events []abci.Event) error {
+	// Populate the transaction ID field iff one is defined (> 0).
+	var txIDArg any
+	if txID > 0 {
+		txIDArg = txID
+	}
+
+	eventStmt, err := dbtx.Prepare(`INSERT INTO ` + tableEvents + ` (block_id, tx_id, type) VALUES (?, ?, ?);`)
+	if err != nil {
+		return fmt.Errorf("preparing event insert statement: %w", err)
+	}
+	defer eventStmt.Close()
+	attrStmt, err := dbtx.Prepare(`INSERT INTO ` + tableAttributes + ` (event_id, key, composite_key, value)
+  VALUES (?, ?, ?, ?) ON CONFLICT DO NOTHING;`)
+	if err != nil {
+		return fmt.Errorf("preparing attribute insert statement: %w", err)
+	}
+	defer attrStmt.Close()
+
+	for _, event := range events {
+		// Skip events with an empty type.
+		if event.Type == "" {
+			continue
+		}
+		res, err := eventStmt.Exec(blockID, txIDArg, event.Type)
+		if err != nil {
+			return fmt.Errorf("inserting event: %w", err)
+		}
+		eventID, err := res.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("getting event id: %w", err)
+		}
+		for _, attr := range event.Attributes {
+			if !attr.Index {
+				continue
+			}
+			compositeKey := event.Type + "." + attr.Key
+			if _, err := attrStmt.Exec(eventID, attr.Key, compositeKey, attr.Value); err != nil {
+				return fmt.Errorf("inserting attribute: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// makeIndexedEvent constructs an event from the specified composite key and
+// value. If the key has the form "type.name", the event will have a single
+// attribute with that name and the value; otherwise the event will have only
+// a type and no attributes.
+func makeIndexedEvent(compositeKey, value string) abci.Event {
+	i := strings.Index(compositeKey, ".")
+	if i < 0 {
+		return abci.Event{Type: compositeKey}
+	}
+	return abci.Event{Type: compositeKey[:i], Attributes: []abci.EventAttribute{
+		{Key: compositeKey[i+1:], Value: value, Index: true},
+	}}
+}
+
+// IndexBlockEvents indexes the specified block header, part of the
+// indexer.EventSink interface.
+func (es *EventSink) IndexBlockEvents(h types.EventDataNewBlockEvents) error {
+	ts := time.Now().UTC().Format(time.RFC3339Nano)
+
+	return runInTransaction(es.store, func(dbtx *sql.Tx) error {
+		// Add the block to the blocks table and use its row ID to index the
+		// events of the block.
+		res, err := dbtx.Exec(`
+INSERT INTO `+tableBlocks+` (height, chain_id, created_at)
+  VALUES (?, ?, ?)
+  ON CONFLICT DO NOTHING;
+`, h.Height, es.chainID, ts)
+		if err != nil {
+			return fmt.Errorf("indexing block header: %w", err)
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return fmt.Errorf("indexing block header: %w", err)
+		} else if n == 0 {
+			return nil // we already saw this block; quietly succeed
+		}
+		blockID, err := res.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("getting block id: %w", err)
+		}
+
+		// Insert the special block meta-event for height.
+		events := append([]abci.Event{makeIndexedEvent(types.BlockHeightKey, strconv.FormatInt(h.Height, 10))}, h.Events...)
+		if err := insertEvents(dbtx, blockID, 0, events); err != nil {
+			return fmt.Errorf("indexing block events: %w", err)
+		}
+		return nil
+	})
+}
+
+// IndexTxEvents indexes the specified transaction results. The block of each
+// result must have been indexed before.
+func (es *EventSink) IndexTxEvents(txrs []*abci.TxResult) error {
+	ts := time.Now().UTC().Format(time.RFC3339Nano)
+
+	return runInTransaction(es.store, func(dbtx *sql.Tx) error {
+		for _, txr := range txrs {
+			var blockID int64
+			err := dbtx.QueryRow(`
+SELECT rowid FROM `+tableBlocks+` WHERE height = ? AND chain_id = ?;
+`, txr.Height, es.chainID).Scan(&blockID)
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("block %d must be indexed before its transactions", txr.Height)
+			} else if err != nil {
+				return fmt.Errorf("getting block id for tx: %w", err)
+			}
+
+			// Encode the result message in protobuf wire format for indexing.
+			resultData, err := proto.Marshal(txr)
+			if err != nil {
+				return fmt.Errorf("marshaling tx_result: %w", err)
+			}
+			// Index the hash of the underlying transaction as a hex string.
+			txHash := fmt.Sprintf("%X", types.Tx(txr.Tx).Hash())
+
+			res, err := dbtx.Exec(`
+INSERT INTO `+tableTxResults+` (block_id, "index", created_at, tx_hash, tx_result)
+  VALUES (?, ?, ?, ?, ?)
+  ON CONFLICT DO NOTHING;
+`, blockID, txr.Index, ts, txHash, resultData)
+			if err != nil {
+				return fmt.Errorf("indexing tx result: %w", err)
+			}
+			if n, err := res.RowsAffected(); err != nil {
+				return fmt.Errorf("indexing tx result: %w", err)
+			} else if n == 0 {
+				continue // already indexed
+			}
+			txID, err := res.LastInsertId()
+			if err != nil {
+				return fmt.Errorf("getting tx result id: %w", err)
+			}
+
+			// Insert the special transaction meta-events for hash and height.
+			events := append([]abci.Event{
+				makeIndexedEvent(types.TxHashKey, txHash),
+				makeIndexedEvent(types.TxHeightKey, strconv.FormatInt(txr.Height, 10)),
+			},
+				txr.Result.Events...,
+			)
+			if err := insertEvents(dbtx, blockID, txID, events); err != nil {
+				return fmt.Errorf("indexing tx events: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// SearchBlockEvents returns the heights of the blocks whose events match q,
+// in ascending order.
+func (es *EventSink) SearchBlockEvents(ctx context.Context, q *query.Query) ([]int64, error) {
+	sq := new(sqlQuery)
+	chainID := sq.arg(es.chainID)
+	where, err := where(sq, blockEventFilter(), q)
+	if err != nil {
+		return nil, fmt.Errorf("translating query: %w", err)
+	}
+
+	rows, err := es.store.QueryContext(ctx, `
+SELECT `+tableBlocks+`.height FROM `+tableBlocks+`
+  WHERE `+tableBlocks+`.chain_id = `+chainID+`
+    AND `+tableBlocks+`.height >= `+retainedHeight(lastBlockRetainHeightKey)+`
+    AND `+where+`
+  ORDER BY `+tableBlocks+`.height;
+`, sq.args...)
+	if err != nil {
+		return nil, fmt.Errorf("searching blocks: %w", err)
+	}
+	defer rows.Close()
+
+	heights := make([]int64, 0)
+	for rows.Next() {
+		var height int64
+		if err := rows.Scan(&height); err != nil {
+			return nil, fmt.Errorf("scanning block height: %w", err)
+		}
+		heights = append(heights, height)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("searching blocks: %w", err)
+	}
+	return heights, nil
+}
+
+// SearchTxEvents returns the tx results whose events match q, ordered by
+// height and index, and the total number of matching results. If the search
+// is paginated, only the results in the requested page are returned.
+func (es *EventSink) SearchTxEvents(ctx context.Context, q *query.Query, pagSettings txindex.Pagination) ([]*abci.TxResult, int, error) {
+	sq := new(sqlQuery)
+	chainID := sq.arg(es.chainID)
+	where, err := where(sq, txEventFilter(), q)
+	if err != nil {
+		return nil, 0, fmt.Errorf("translating query: %w", err)
+	}
+	from := tableTxResults + ` JOIN ` + tableBlocks + `
+  ON ` + tableBlocks + `.rowid = ` + tableTxResults + `.block_id
+  WHERE ` + tableBlocks + `.chain_id = ` + chainID + ` AND ` + where
+
+	var total int
+	if err := es.store.QueryRowContext(ctx, `SELECT count(*) FROM `+from+`;`, sq.args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("counting tx results: %w", err)
+	}
+
+	order := "ASC"
+	if pagSettings.OrderDesc {
+		order = "DESC"
+	}
+	limit := ""
+	if pagSettings.IsPaginated {
+		page, err := validatePage(pagSettings.Page, pagSettings.PerPage, total)
+		if err != nil {
+			return nil, 0, err
+		}
+		limit = fmt.Sprintf(" LIMIT %s OFFSET %s", sq.arg(pagSettings.PerPage), sq.arg((page-1)*pagSettings.PerPage))
+	}
+
+	rows, err := es.store.QueryContext(ctx, `
+SELECT `+tableTxResults+`.tx_result FROM `+from+`
+  ORDER BY `+tableBlocks+`.height `+order+`, `+tableTxResults+`."index" `+order+limit+`;
+`, sq.args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("searching tx results: %w", err)
+	}
+	defer rows.Close()
+
+	results := make([]*abci.TxResult, 0)
+	for rows.Next() {
+		var resultData []byte
+		if err := rows.Scan(&resultData); err != nil {
+			return nil, 0, fmt.Errorf("scanning tx result: %w", err)
+		}
+		txr := new(abci.TxResult)
+		if err := proto.Unmarshal(resultData, txr); err != nil {
+			return nil, 0, fmt.Errorf("unmarshaling tx_result: %w", err)
+		}
+		results = append(results, txr)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("searching tx results: %w", err)
+	}
+	return results, total, nil
+}
+
+// GetTxByHash returns the tx result for the transaction with the given hash,
+// or nil if the transaction is not indexed. If the transaction was included in
+// more than one block, the latest result is returned.
+func (es *EventSink) GetTxByHash(hash []byte) (*abci.TxResult, error) {
+	var resultData []byte
+	err := es.store.QueryRow(`
+SELECT `+tableTxResults+`.tx_result FROM `+tableTxResults+` JOIN `+tableBlocks+`
+  ON `+tableBlocks+`.rowid = `+tableTxResults+`.block_id
+  WHERE `+tableTxResults+`.tx_hash = ? AND `+tableBlocks+`.chain_id = ?
+  ORDER BY `+tableBlocks+`.height DESC
+  LIMIT 1;
+`, fmt.Sprintf("%X", hash), es.chainID).Scan(&resultData)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("getting tx result: %w", err)
+	}
+
+	txr := new(abci.TxResult)
+	if err := proto.Unmarshal(resultData, txr); err != nil {
+		return nil, fmt.Errorf("unmarshaling tx_result: %w", err)
+	}
+	return txr, nil
+}
+
+// HasBlock reports whether the events of the block at the given height have
+// been indexed, and not pruned.
+func (es *EventSink) HasBlock(height int64) (bool, error) {
+	var exists bool
+	if err := es.store.QueryRow(`
+SELECT EXISTS(SELECT 1 FROM `+tableBlocks+`
+  WHERE height = ? AND chain_id = ? AND height >= `+retainedHeight(lastBlockRetainHeightKey)+`);
+`, height, es.chainID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("checking block existence: %w", err)
+	}
+	return exists, nil
+}
+
+// PruneTxEvents removes the tx results below retainHeight, and their events.
+// It returns the number of heights whose tx results were removed, and the
+// height from which tx results are retained.
+func (es *EventSink) PruneTxEvents(retainHeight int64) (numPruned, newRetainHeight int64, err error) {
+	err = runInTransaction(es.store, func(dbtx *sql.Tx) error {
+		lastRetainHeight, err := getHeight(dbtx, lastTxRetainHeightKey)
+		if err != nil {
+			return err
+		}
+		if retainHeight <= lastRetainHeight {
+			numPruned, newRetainHeight = 0, lastRetainHeight
+			return nil
+		}
+
+		prunedBlocks := `SELECT rowid FROM ` + tableBlocks + ` WHERE chain_id = ?1 AND height < ?2`
+		prunedTxs := `SELECT rowid FROM ` + tableTxResults + ` WHERE block_id IN (` + prunedBlocks + `)`
+		if err := dbtx.QueryRow(`
+SELECT count(DISTINCT block_id) FROM `+tableTxResults+` WHERE block_id IN (`+prunedBlocks+`);
+`, es.chainID, retainHeight).Scan(&numPruned); err != nil {
+			return fmt.Errorf("counting pruned heights: %w", err)
+		}
+		for _, stmt := range []string{
+			`DELETE FROM ` + tableAttributes + ` WHERE event_id IN (
+  SELECT rowid FROM ` + tableEvents + ` WHERE tx_id IN (` + prunedTxs + `));`,
+			`DELETE FROM ` + tableEvents + ` WHERE tx_id IN (` + prunedTxs + `);`,
+			`DELETE FROM ` + tableTxResults + ` WHERE rowid IN (` + prunedTxs + `);`,
+		} {
+			if _, err := dbtx.Exec(stmt, es.chainID, retainHeight); err != nil {
+				return fmt.Errorf("pruning tx results: %w", err)
+			}
+		}
+		if err := es.deletePrunedBlocks(dbtx); err != nil {
+			return err
+		}
+
+		newRetainHeight = retainHeight
+		return setHeight(dbtx, lastTxRetainHeightKey, retainHeight)
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return numPruned, newRetainHeight, nil
+}
+
+// PruneBlockEvents removes the events of the blocks below retainHeight. It
+// returns the number of blocks whose events were removed, and the height
+// from which blocks are retained.
+func (es *EventSink) PruneBlockEvents(retainHeight int64) (numPruned, newRetainHeight int64, err error) {
+	err = runInTransaction(es.store, func(dbtx *sql.Tx) error {
+		lastRetainHeight, err := getHeight(dbtx, lastBlockRetainHeightKey)
+		if err != nil {
+			return err
+		}
+		if retainHeight <= lastRetainHeight {
+			numPruned, newRetainHeight = 0, lastRetainHeight
+			return nil
+		}
+
+		prunedBlocks := `SELECT rowid FROM ` + tableBlocks + ` WHERE chain_id = ?1 AND height >= ?2 AND height < ?3`
+		if err := dbtx.QueryRow(`SELECT count(*) FROM (`+prunedBlocks+`);`,
+			es.chainID, lastRetainHeight, retainHeight).Scan(&numPruned); err != nil {
+			return fmt.Errorf("counting pruned heights: %w", err)
+		}
+		for _, stmt := range []string{
+			`DELETE FROM ` + tableAttributes + ` WHERE event_id IN (
+  SELECT rowid FROM ` + tableEvents + ` WHERE tx_id IS NULL AND block_id IN (` + prunedBlocks + `));`,
+			`DELETE FROM ` + tableEvents + ` WHERE tx_id IS NULL AND block_id IN (` + prunedBlocks + `);`,
+		} {
+			if _, err := dbtx.Exec(stmt, es.chainID, lastRetainHeight, retainHeight); err != nil {
+				return fmt.Errorf("pruning block events: %w", err)
+			}
+		}
+		if err := setHeight(dbtx, lastBlockRetainHeightKey, retainHeight); err != nil {
+			return err
+		}
+		if err := es.deletePrunedBlocks(dbtx); err != nil {
+			return err
+		}
+
+		newRetainHeight = retainHeight
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return numPruned, newRetainHeight, nil
+}
+
+// deletePrunedBlocks removes the blocks whose events have been pruned and that
+// have no remaining tx results.
+func (es *EventSink) deletePrunedBlocks(dbtx *sql.Tx) error {
+	if _, err := dbtx.Exec(`
+DELETE FROM `+tableBlocks+`
+  WHERE chain_id = ? AND height < `+retainedHeight(lastBlockRetainHeightKey)+`
+    AND NOT EXISTS (SELECT 1 FROM `+tableTxResults+` WHERE block_id = `+tableBlocks+`.rowid);
+`, es.chainID); err != nil {
+		return fmt.Errorf("pruning blocks: %w", err)
+	}
+	return nil
+}
+
+// GetTxRetainHeight returns the retain height of the tx indexer, or
+// state.ErrKeyNotFound if it has not been set.
+func (es *EventSink) GetTxRetainHeight() (int64, error) {
+	return es.getRetainHeight(txRetainHeightKey)
+}
+
+// SetTxRetainHeight sets the retain height of the tx indexer.
+func (es *EventSink) SetTxRetainHeight(height int64) error {
+	return es.setRetainHeight(txRetainHeightKey, height)
+}
+
+// GetBlockRetainHeight returns the retain height of the block indexer, or
+// state.ErrKeyNotFound if it has not been set.
+func (es *EventSink) GetBlockRetainHeight() (int64, error) {
+	return es.getRetainHeight(blockRetainHeightKey)
+}
+
+// SetBlockRetainHeight sets the retain height of the block indexer.
+func (es *EventSink) SetBlockRetainHeight(height int64) error {
+	return es.setRetainHeight(blockRetainHeightKey, height)
+}
+
+func (es *EventSink) getRetainHeight(name string) (int64, error) {
+	var height int64
+	err := es.store.QueryRow(`SELECT height FROM retain_heights WHERE name = ?;`, name).Scan(&height)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, state.ErrKeyNotFound
+	} else if err != nil {
+		return 0, fmt.Errorf("getting retain height: %w", err)
+	}
+	return height, nil
+}
+
+func (es *EventSink) setRetainHeight(name string, height int64) error {
+	return runInTransaction(es.store, func(dbtx *sql.Tx) error {
+		return setHeight(dbtx, name, height)
+	})
+}
+
+// getHeight returns the height stored in the retain_heights table under the
+// given name, or 0 if there is none.
+func getHeight(dbtx *sql.Tx, name string) (int64, error) {
+	var height int64
+	err := dbtx.QueryRow(`SELECT height FROM retain_heights WHERE name = ?;`, name).Scan(&height)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("getting retain height: %w", err)
+	}
+	return height, nil
+}
+
+// setHeight stores height in the retain_heights table under the given name.
+func setHeight(dbtx *sql.Tx, name string, height int64) error {
+	if _, err := dbtx.Exec(`
+INSERT INTO retain_heights (name, height) VALUES (?, ?)
+  ON CONFLICT (name) DO UPDATE SET height = excluded.height;
+`, name, height); err != nil {
+		return fmt.Errorf("setting retain height: %w", err)
+	}
+	return nil
+}
+
+// retainedHeight returns a SQL expression evaluating to the height stored in
+// the retain_heights table under the given name, or 0 if there is none.
+func retainedHeight(name string) string {
+	return `COALESCE((SELECT height FROM retain_heights WHERE name = '` + name + `'), 0)`
+}
+
+// validatePage returns the requested page if it is within the range of pages
+// needed to return totalCount results, perPage at a time.
+func validatePage(page, perPage, totalCount int) (int, error) {
+	if perPage < 1 {
+		return 1, fmt.Errorf("zero or negative perPage: %d", perPage)
+	}
+	pages := max(((totalCount-1)/perPage)+1, 1)
+	if page <= 0 || page > pages {
+		return 1, fmt.Errorf("page should be within [1, %d] range, given %d", pages, page)
+	}
+	return page, nil
+}
+
+// Stop closes the underlying SQLite database.
+func (es *EventSink) Stop() error { return es.store.Close() }
@@ -0,0 +1,15 @@
+//go:build !cgo
+
+package sqlite
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEventSinkNotSupported(t *testing.T) {
+	_, err := NewEventSink(filepath.Join(t.TempDir(), DBFileName), "test-chainID")
+	require.ErrorIs(t, err, ErrNotSupported)
+}
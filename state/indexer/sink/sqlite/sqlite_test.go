@@ -0,0 +1,303 @@
+//go:build cgo
+
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/cometbft/cometbft/v2/abci/types"
+	tmlog "github.com/cometbft/cometbft/v2/libs/log"
+	"github.com/cometbft/cometbft/v2/libs/pubsub/query"
+	"github.com/cometbft/cometbft/v2/state"
+	"github.com/cometbft/cometbft/v2/state/txindex"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+const chainID = "test-chainID"
+
+func newTestEventSink(t *testing.T) *EventSink {
+	t.Helper()
+	es, err := NewEventSink(filepath.Join(t.TempDir(), DBFileName), chainID)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = es.Stop() })
+	return es
+}
+
+func TestIndexing(t *testing.T) {
+	t.Run("IndexBlockEvents", func(t *testing.T) {
+		indexer := newTestEventSink(t)
+		require.NoError(t, indexer.IndexBlockEvents(newTestBlockEvents()))
+
+		has, err := indexer.HasBlock(1)
+		require.NoError(t, err)
+		assert.True(t, has)
+		has, err = indexer.HasBlock(2)
+		require.NoError(t, err)
+		assert.False(t, has)
+
+		testCases := []struct {
+			q       string
+			heights []int64
+		}{
+			{"begin_event.proposer = 'FCAA001'", []int64{1}},
+			{"begin_event.proposer = 'FCAA002'", []int64{}},
+			{"thingy.whatzit CONTAINS '-.'", []int64{1}},
+			{"end_event.foo >= 100 AND end_event.foo < 101", []int64{1}},
+			{"end_event.foo > 100", []int64{}},
+			{"begin_event EXISTS", []int64{1}},
+			{"block.height = 1 AND thingy.whatzit = 'O.O'", []int64{1}},
+			{"block.height > 1", []int64{}},
+			{"block.height > 1 OR begin_event.proposer = 'FCAA001'", []int64{1}},
+			{"NOT end_event.foo = 100", []int64{}},
+		}
+		for _, tc := range testCases {
+			heights, err := indexer.SearchBlockEvents(context.Background(), query.MustCompile(tc.q))
+			require.NoError(t, err, tc.q)
+			assert.Equal(t, tc.heights, heights, tc.q)
+		}
+
+		// Attempting to reindex the same events should gracefully succeed.
+		require.NoError(t, indexer.IndexBlockEvents(newTestBlockEvents()))
+	})
+
+	t.Run("IndexTxEvents", func(t *testing.T) {
+		indexer := newTestEventSink(t)
+
+		txResult := txResultWithEvents([]abci.Event{
+			makeIndexedEvent("account.number", "1"),
+			makeIndexedEvent("account.owner", "Ivan"),
+			makeIndexedEvent("account.owner", "Yulieta"),
+
+			{Type: "", Attributes: []abci.EventAttribute{
+				{
+					Key:   "not_allowed",
+					Value: "Vlad",
+					Index: true,
+				},
+			}},
+		})
+		// The block must be indexed before its transactions.
+		require.Error(t, indexer.IndexTxEvents([]*abci.TxResult{txResult}))
+		require.NoError(t, indexer.IndexBlockEvents(newTestBlockEvents()))
+		require.NoError(t, indexer.IndexTxEvents([]*abci.TxResult{txResult}))
+
+		txr, err := indexer.GetTxByHash(types.Tx(txResult.Tx).Hash())
+		require.NoError(t, err)
+		assert.Equal(t, txResult, txr)
+		txr, err = indexer.GetTxByHash(types.Tx("unknown").Hash())
+		require.NoError(t, err)
+		assert.Nil(t, txr)
+
+		pagination := txindex.Pagination{IsPaginated: true, Page: 1, PerPage: 10}
+		testCases := []struct {
+			q       string
+			results []*abci.TxResult
+		}{
+			{"account.owner = 'Ivan'", []*abci.TxResult{txResult}},
+			{"account.owner = 'Vlad'", []*abci.TxResult{}},
+			{"account.number >= 1 AND account.owner CONTAINS 'Yul'", []*abci.TxResult{txResult}},
+			{"account.number < 1", []*abci.TxResult{}},
+			{fmt.Sprintf("tx.hash = '%x'", types.Tx(txResult.Tx).Hash()), []*abci.TxResult{txResult}},
+			{"tx.height = 1 AND account EXISTS", []*abci.TxResult{txResult}},
+			{"tx.height > 1", []*abci.TxResult{}},
+			{"not_allowed = 'Vlad'", []*abci.TxResult{}},
+			{"account.owner = 'Vlad' OR account.number = 1", []*abci.TxResult{txResult}},
+			{"account EXISTS AND NOT (account.owner = 'Vlad' OR tx.height > 1)", []*abci.TxResult{txResult}},
+		}
+		for _, tc := range testCases {
+			results, total, err := indexer.SearchTxEvents(context.Background(), query.MustCompile(tc.q), pagination)
+			require.NoError(t, err, tc.q)
+			assert.Equal(t, tc.results, results, tc.q)
+			assert.Equal(t, len(tc.results), total, tc.q)
+		}
+
+		// Pages out of range are rejected.
+		pagination.Page = 2
+		_, _, err = indexer.SearchTxEvents(context.Background(), query.MustCompile("account.owner = 'Ivan'"), pagination)
+		require.Error(t, err)
+
+		// try to insert the duplicate tx events.
+		require.NoError(t, indexer.IndexTxEvents([]*abci.TxResult{txResult}))
+		_, total, err := indexer.SearchTxEvents(context.Background(), query.MustCompile("account.number = 1"), txindex.Pagination{})
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+	})
+
+	t.Run("IndexerService", func(t *testing.T) {
+		indexer := newTestEventSink(t)
+
+		// event bus
+		eventBus := types.NewEventBus()
+		err := eventBus.Start()
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			if err := eventBus.Stop(); err != nil {
+				t.Error(err)
+			}
+		})
+
+		service := txindex.NewIndexerService(indexer.TxIndexer(), indexer.BlockIndexer(), eventBus, true)
+		service.SetLogger(tmlog.TestingLogger())
+		err = service.Start()
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			if err := service.Stop(); err != nil {
+				t.Error(err)
+			}
+		})
+
+		// publish block with txs
+		err = eventBus.PublishEventNewBlockEvents(types.EventDataNewBlockEvents{
+			Height: 1,
+			NumTxs: 2,
+		})
+		require.NoError(t, err)
+		txResult1 := &abci.TxResult{
+			Height: 1,
+			Index:  uint32(0),
+			Tx:     types.Tx("foo"),
+			Result: abci.ExecTxResult{Code: 0},
+		}
+		err = eventBus.PublishEventTx(types.EventDataTx{TxResult: *txResult1})
+		require.NoError(t, err)
+		txResult2 := &abci.TxResult{
+			Height: 1,
+			Index:  uint32(1),
+			Tx:     types.Tx("bar"),
+			Result: abci.ExecTxResult{Code: 1},
+		}
+		err = eventBus.PublishEventTx(types.EventDataTx{TxResult: *txResult2})
+		require.NoError(t, err)
+
+		require.Eventually(t, func() bool {
+			txr, err := indexer.GetTxByHash(types.Tx("bar").Hash())
+			return err == nil && txr != nil
+		}, time.Second, 10*time.Millisecond)
+		require.True(t, service.IsRunning())
+	})
+}
+
+func TestPrune(t *testing.T) {
+	indexer := newTestEventSink(t)
+	txIndexer, blockIndexer := indexer.TxIndexer(), indexer.BlockIndexer()
+
+	for h := int64(1); h <= 5; h++ {
+		require.NoError(t, blockIndexer.Index(types.EventDataNewBlockEvents{
+			Height: h,
+			Events: []abci.Event{makeIndexedEvent("end_event.foo", strconv.FormatInt(h, 10))},
+		}))
+		txr := txResultWithEvents([]abci.Event{makeIndexedEvent("account.number", strconv.FormatInt(h, 10))})
+		txr.Height = h
+		txr.Tx = types.Tx("tx" + strconv.FormatInt(h, 10))
+		require.NoError(t, txIndexer.Index(txr))
+	}
+
+	_, err := txIndexer.GetRetainHeight()
+	require.ErrorIs(t, err, state.ErrKeyNotFound)
+	require.NoError(t, txIndexer.SetRetainHeight(3))
+	retainHeight, err := txIndexer.GetRetainHeight()
+	require.NoError(t, err)
+	require.Equal(t, int64(3), retainHeight)
+
+	_, err = blockIndexer.GetRetainHeight()
+	require.ErrorIs(t, err, state.ErrKeyNotFound)
+	require.NoError(t, blockIndexer.SetRetainHeight(2))
+	retainHeight, err = blockIndexer.GetRetainHeight()
+	require.NoError(t, err)
+	require.Equal(t, int64(2), retainHeight)
+
+	// Prune the transactions below height 3.
+	numPruned, newRetainHeight, err := txIndexer.Prune(3)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), numPruned)
+	require.Equal(t, int64(3), newRetainHeight)
+
+	results, total, err := txIndexer.Search(context.Background(), query.MustCompile("account.number EXISTS"), txindex.Pagination{})
+	require.NoError(t, err)
+	require.Equal(t, 3, total)
+	require.Equal(t, int64(3), results[0].Height)
+	txr, err := txIndexer.Get(types.Tx("tx1").Hash())
+	require.NoError(t, err)
+	require.Nil(t, txr)
+
+	// Pruning again to the same height does nothing.
+	numPruned, newRetainHeight, err = txIndexer.Prune(3)
+	require.NoError(t, err)
+	require.Zero(t, numPruned)
+	require.Equal(t, int64(3), newRetainHeight)
+
+	// Block events are still there until the block indexer is pruned.
+	heights, err := blockIndexer.Search(context.Background(), query.MustCompile("end_event.foo <= 2"))
+	require.NoError(t, err)
+	require.Equal(t, []int64{1, 2}, heights)
+
+	numPruned, newRetainHeight, err = blockIndexer.Prune(4)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), numPruned)
+	require.Equal(t, int64(4), newRetainHeight)
+
+	heights, err = blockIndexer.Search(context.Background(), query.MustCompile("block.height < 10"))
+	require.NoError(t, err)
+	require.Equal(t, []int64{4, 5}, heights)
+	has, err := blockIndexer.Has(3)
+	require.NoError(t, err)
+	require.False(t, has)
+
+	// The transactions of pruned blocks are kept until they are pruned.
+	_, total, err = txIndexer.Search(context.Background(), query.MustCompile("tx.height >= 3"), txindex.Pagination{})
+	require.NoError(t, err)
+	require.Equal(t, 3, total)
+
+	var numBlocks int
+	require.NoError(t, indexer.DB().QueryRow(`SELECT count(*) FROM blocks;`).Scan(&numBlocks))
+	require.Equal(t, 3, numBlocks)
+	numPruned, _, err = txIndexer.Prune(5)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), numPruned)
+	require.NoError(t, indexer.DB().QueryRow(`SELECT count(*) FROM blocks;`).Scan(&numBlocks))
+	require.Equal(t, 2, numBlocks)
+}
+
+func TestStop(t *testing.T) {
+	indexer, err := NewEventSink(filepath.Join(t.TempDir(), DBFileName), chainID)
+	require.NoError(t, err)
+	require.NoError(t, indexer.Stop())
+}
+
+// newTestBlockEvents constructs a fresh copy of a new block event containing
+// known test values to exercise the indexer.
+func newTestBlockEvents() types.EventDataNewBlockEvents {
+	return types.EventDataNewBlockEvents{
+		Height: 1,
+		Events: []abci.Event{
+			makeIndexedEvent("begin_event.proposer", "FCAA001"),
+			makeIndexedEvent("thingy.whatzit", "O.O"),
+			makeIndexedEvent("end_event.foo", "100"),
+			makeIndexedEvent("thingy.whatzit", "-.O"),
+		},
+	}
+}
+
+// txResultWithEvents constructs a fresh transaction result with fixed values
+// for testing, that includes the specified events.
+func txResultWithEvents(events []abci.Event) *abci.TxResult {
+	return &abci.TxResult{
+		Height: 1,
+		Index:  0,
+		Tx:     types.Tx("HELLO WORLD"),
+		Result: abci.ExecTxResult{
+			Data:   []byte{0},
+			Code:   abci.CodeTypeOK,
+			Log:    "",
+			Events: events,
+		},
+	}
+}
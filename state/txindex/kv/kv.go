@@ -6,6 +6,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"maps"
 	"math"
 	"math/big"
 	"sort"
@@ -457,6 +458,10 @@ func byHeightAsc(i, j *hashKey) bool {
 // performing a full scan. Results from querying indexes are then intersected
 // and returned to the caller, in no particular order.
 //
+// Queries with OR and NOT operators are evaluated by searching each
+// conjunction of conditions as above, and then computing the union,
+// intersection or difference of the resulting sets of txs.
+//
 // Search will exit early and return any result fetched so far,
 // when a message is received on the context chan.
 func (txi *TxIndex) Search(ctx context.Context, q *query.Query, pagSettings txindex.Pagination) ([]*abci.TxResult, int, error) {
@@ -467,28 +472,115 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query, pagSettings txin
 	default:
 	}
 
-	var hashesInitialized bool
-	filteredHashes := make(map[string]TxInfo)
+	var (
+		filteredHashes map[string]TxInfo
+		err            error
+	)
 
 	// get a list of conditions (like "tx.height > 5")
-	conditions := q.Syntax()
+	conditions, isConjunction := q.Expr().Conditions()
+	if isConjunction {
+		// if there is a hash condition, return the result immediately
+		hash, ok, err := lookForHash(conditions)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error during searching for a hash in the query: %w", err)
+		} else if ok {
+			res, err := txi.Get(hash)
+			switch {
+			case err != nil:
+				return []*abci.TxResult{}, 0, fmt.Errorf("error while retrieving the result: %w", err)
+			case res == nil:
+				return []*abci.TxResult{}, 0, nil
+			default:
+				return []*abci.TxResult{res}, 0, nil
+			}
+		}
 
-	// if there is a hash condition, return the result immediately
-	hash, ok, err := lookForHash(conditions)
-	if err != nil {
-		return nil, 0, fmt.Errorf("error during searching for a hash in the query: %w", err)
-	} else if ok {
-		res, err := txi.Get(hash)
-		switch {
-		case err != nil:
-			return []*abci.TxResult{}, 0, fmt.Errorf("error while retrieving the result: %w", err)
-		case res == nil:
+		filteredHashes = txi.searchConditions(ctx, conditions)
+	} else {
+		filteredHashes, err = txi.searchExpr(ctx, q.Expr())
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	numResults := len(filteredHashes)
+
+	// Convert map keys to slice for deterministic ordering
+	hashKeys := make([]hashKey, 0, numResults)
+	for k, v := range filteredHashes {
+		hashKeys = append(hashKeys, hashKey{hash: k, height: v.Height})
+	}
+
+	var by func(i, j *hashKey) bool
+
+	if pagSettings.OrderDesc {
+		by = byHeightDesc
+	} else {
+		by = byHeightAsc
+	}
+
+	// Sort by height
+	sort.Sort(&hashKeySorter{
+		keys: hashKeys,
+		by:   by,
+	})
+
+	// If paginated, determine which hash keys to return
+	if pagSettings.IsPaginated {
+		// Now that we know the total number of results, validate that the page
+		// requested is within bounds
+		pagSettings.Page, err = validatePage(&pagSettings.Page, pagSettings.PerPage, numResults)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		// Calculate pagination start and end indices
+		startIndex := (pagSettings.Page - 1) * pagSettings.PerPage
+		endIndex := startIndex + pagSettings.PerPage
+
+		// Apply pagination limits
+		if endIndex > len(hashKeys) {
+			endIndex = len(hashKeys)
+		}
+		if startIndex >= len(hashKeys) {
 			return []*abci.TxResult{}, 0, nil
+		}
+
+		hashKeys = hashKeys[startIndex:endIndex]
+	}
+
+	results := make([]*abci.TxResult, 0, len(hashKeys))
+	resultMap := make(map[string]struct{})
+RESULTS_LOOP:
+	for _, hKey := range hashKeys {
+		h := filteredHashes[hKey.hash].TxBytes
+		res, err := txi.Get(h)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to get Tx{%X}: %w", h, err)
+		}
+		hashString := string(h)
+		if _, ok := resultMap[hashString]; !ok {
+			resultMap[hashString] = struct{}{}
+			results = append(results, res)
+		}
+		// Potentially exit early.
+		select {
+		case <-ctx.Done():
+			break RESULTS_LOOP
 		default:
-			return []*abci.TxResult{res}, 0, nil
 		}
 	}
 
+	return results, numResults, nil
+}
+
+// searchConditions returns the txs matching all the given conditions. The
+// result is keyed by tx hash and event sequence.
+func (txi *TxIndex) searchConditions(ctx context.Context, conditions []syntax.Condition) map[string]TxInfo {
+	var hashesInitialized bool
+	filteredHashes := make(map[string]TxInfo)
+
 	// conditions to skip because they're handled before "everything else"
 	skipIndexes := make([]int, 0)
 	var heightInfo HeightInfo
@@ -554,75 +646,140 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query, pagSettings txin
 		}
 	}
 
-	numResults := len(filteredHashes)
+	return filteredHashes
+}
 
-	// Convert map keys to slice for deterministic ordering
-	hashKeys := make([]hashKey, 0, numResults)
-	for k, v := range filteredHashes {
-		hashKeys = append(hashKeys, hashKey{hash: k, height: v.Height})
+// searchExpr returns the txs matching expr, keyed by tx hash. Conjunctions of
+// conditions are matched as in Search, and the results for the operands of
+// OR, AND and NOT expressions are combined with set operations.
+func (txi *TxIndex) searchExpr(ctx context.Context, expr syntax.Expr) (map[string]TxInfo, error) {
+	if conditions, ok := expr.Conditions(); ok {
+		return txi.searchConjunction(ctx, conditions)
 	}
 
-	var by func(i, j *hashKey) bool
-
-	if pagSettings.OrderDesc {
-		by = byHeightDesc
-	} else {
-		by = byHeightAsc
-	}
+	switch expr.Op {
+	case syntax.TOr:
+		union := make(map[string]TxInfo)
+		for _, arg := range expr.Args {
+			hashes, err := txi.searchExpr(ctx, arg)
+			if err != nil {
+				return nil, err
+			}
+			maps.Copy(union, hashes)
+		}
+		return union, nil
+
+	case syntax.TAnd:
+		// Match all the conditions at once, intersect the result with the
+		// results for the compound operands, and subtract the results for the
+		// negated operands.
+		var (
+			conditions []syntax.Condition
+			compound   []syntax.Expr
+			negated    []syntax.Expr
+		)
+		for _, arg := range expr.Args {
+			switch {
+			case arg.Cond != nil:
+				conditions = append(conditions, *arg.Cond)
+			case arg.Op == syntax.TNot:
+				negated = append(negated, arg.Args[0])
+			default:
+				compound = append(compound, arg)
+			}
+		}
 
-	// Sort by height
-	sort.Sort(&hashKeySorter{
-		keys: hashKeys,
-		by:   by,
-	})
+		var result map[string]TxInfo
+		if len(conditions) > 0 {
+			hashes, err := txi.searchConjunction(ctx, conditions)
+			if err != nil {
+				return nil, err
+			}
+			result = hashes
+		}
+		for _, arg := range compound {
+			if result != nil && len(result) == 0 {
+				return result, nil
+			}
+			hashes, err := txi.searchExpr(ctx, arg)
+			if err != nil {
+				return nil, err
+			}
+			if result == nil {
+				result = hashes
+				continue
+			}
+			maps.DeleteFunc(result, func(hash string, _ TxInfo) bool {
+				_, ok := hashes[hash]
+				return !ok
+			})
+		}
+		if result == nil {
+			hashes, err := txi.searchAll(ctx)
+			if err != nil {
+				return nil, err
+			}
+			result = hashes
+		}
+		return txi.subtract(ctx, result, negated)
 
-	// If paginated, determine which hash keys to return
-	if pagSettings.IsPaginated {
-		// Now that we know the total number of results, validate that the page
-		// requested is within bounds
-		pagSettings.Page, err = validatePage(&pagSettings.Page, pagSettings.PerPage, numResults)
+	case syntax.TNot:
+		all, err := txi.searchAll(ctx)
 		if err != nil {
-			return nil, 0, err
+			return nil, err
 		}
+		return txi.subtract(ctx, all, expr.Args)
 
-		// Calculate pagination start and end indices
-		startIndex := (pagSettings.Page - 1) * pagSettings.PerPage
-		endIndex := startIndex + pagSettings.PerPage
+	default:
+		return nil, fmt.Errorf("unknown operator %v in query", expr.Op)
+	}
+}
 
-		// Apply pagination limits
-		if endIndex > len(hashKeys) {
-			endIndex = len(hashKeys)
+// subtract removes from hashes the txs matching any of the given expressions.
+func (txi *TxIndex) subtract(ctx context.Context, hashes map[string]TxInfo, exprs []syntax.Expr) (map[string]TxInfo, error) {
+	for _, expr := range exprs {
+		if len(hashes) == 0 {
+			break
 		}
-		if startIndex >= len(hashKeys) {
-			return []*abci.TxResult{}, 0, nil
+		excluded, err := txi.searchExpr(ctx, expr)
+		if err != nil {
+			return nil, err
+		}
+		for hash := range excluded {
+			delete(hashes, hash)
 		}
-
-		hashKeys = hashKeys[startIndex:endIndex]
 	}
+	return hashes, nil
+}
 
-	results := make([]*abci.TxResult, 0, len(hashKeys))
-	resultMap := make(map[string]struct{})
-RESULTS_LOOP:
-	for _, hKey := range hashKeys {
-		h := filteredHashes[hKey.hash].TxBytes
-		res, err := txi.Get(h)
+// searchConjunction returns the txs matching all the given conditions, keyed
+// by tx hash.
+func (txi *TxIndex) searchConjunction(ctx context.Context, conditions []syntax.Condition) (map[string]TxInfo, error) {
+	hashes := make(map[string]TxInfo)
+
+	hash, ok, err := lookForHash(conditions)
+	if err != nil {
+		return nil, fmt.Errorf("error during searching for a hash in the query: %w", err)
+	} else if ok {
+		res, err := txi.Get(hash)
 		if err != nil {
-			return nil, 0, fmt.Errorf("failed to get Tx{%X}: %w", h, err)
+			return nil, fmt.Errorf("error while retrieving the result: %w", err)
 		}
-		hashString := string(h)
-		if _, ok := resultMap[hashString]; !ok {
-			resultMap[hashString] = struct{}{}
-			results = append(results, res)
-		}
-		// Potentially exit early.
-		select {
-		case <-ctx.Done():
-			break RESULTS_LOOP
-		default:
+		if res != nil {
+			hashes[string(hash)] = TxInfo{TxBytes: hash, Height: res.Height}
 		}
+		return hashes, nil
 	}
 
-	return results, numResults, nil
+	for _, info := range txi.searchConditions(ctx, conditions) {
+		hashes[string(info.TxBytes)] = info
+	}
+	return hashes, nil
+}
+
+// searchAll returns all the indexed txs, keyed by tx hash.
+func (txi *TxIndex) searchAll(ctx context.Context) (map[string]TxInfo, error) {
+	return txi.searchConjunction(ctx, []syntax.Condition{{Tag: types.TxHeightKey, Op: syntax.TExists}})
 }
 
 func lookForHash(conditions []syntax.Condition) (hash []byte, ok bool, err error) {
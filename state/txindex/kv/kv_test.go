@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strconv"
 	"testing"
 
 	"github.com/cosmos/gogoproto/proto"
@@ -739,6 +740,56 @@ func TestTxSearchMultipleTxs(t *testing.T) {
 	require.Len(t, results, 3)
 }
 
+func TestTxSearchExpr(t *testing.T) {
+	indexer := NewTxIndex(db.NewMemDB())
+
+	txs := []types.Tx{types.Tx("tx1"), types.Tx("tx2"), types.Tx("tx3"), types.Tx("tx4")}
+	for i, tx := range txs {
+		events := []abci.Event{
+			{Type: "account", Attributes: []abci.EventAttribute{{Key: "number", Value: strconv.Itoa(i + 1), Index: true}}},
+		}
+		if i%2 == 1 {
+			events = append(events, abci.Event{Type: "account", Attributes: []abci.EventAttribute{{Key: "owner", Value: "Ivan", Index: true}}})
+		}
+		txResult := txResultWithEvents(events)
+		txResult.Tx = tx
+		txResult.Height = int64(i/2 + 1)
+		txResult.Index = uint32(i % 2)
+		require.NoError(t, indexer.Index(txResult))
+	}
+
+	testCases := []struct {
+		q       string
+		results []types.Tx
+	}{
+		{"account.number = 1 OR account.number = 4", []types.Tx{txs[0], txs[3]}},
+		{"account.number = 1 OR account.number = 5", []types.Tx{txs[0]}},
+		{"NOT account.owner EXISTS", []types.Tx{txs[0], txs[2]}},
+		{"NOT account.number > 1", []types.Tx{txs[0]}},
+		{"tx.height = 2 AND NOT account.owner = 'Ivan'", []types.Tx{txs[2]}},
+		{"(tx.height = 1 OR account.number = 4) AND account.owner = 'Ivan'", []types.Tx{txs[1], txs[3]}},
+		{"account.number >= 1 AND NOT (account.number = 2 OR tx.height = 2)", []types.Tx{txs[0]}},
+		{fmt.Sprintf("tx.hash = '%X' OR account.number = 3", txs[1].Hash()), []types.Tx{txs[1], txs[2]}},
+		{"NOT tx.height EXISTS", nil},
+	}
+
+	ctx := context.Background()
+
+	for _, tc := range testCases {
+		t.Run(tc.q, func(t *testing.T) {
+			results, numResults, err := indexer.Search(ctx, query.MustCompile(tc.q), DefaultPagination)
+			require.NoError(t, err)
+			require.Equal(t, len(tc.results), numResults)
+
+			found := make([]types.Tx, 0, len(results))
+			for _, txr := range results {
+				found = append(found, txr.Tx)
+			}
+			require.ElementsMatch(t, tc.results, found)
+		})
+	}
+}
+
 func txResultWithEvents(events []abci.Event) *abci.TxResult {
 	tx := types.Tx("HELLO WORLD")
 	return &abci.TxResult{
@@ -14,7 +14,7 @@ import (
 // -----------------------------------------------------
 // Validate block
 
-func validateBlock(state State, block *types.Block) error {
+func validateBlock(state State, block *types.Block, verifiedSignatureCache types.SignatureCache) error {
 	// Validate internal consistency.
 	if err := block.ValidateBasic(); err != nil {
 		return err
@@ -91,8 +91,8 @@ func validateBlock(state State, block *types.Block) error {
 		}
 	} else {
 		// LastCommit.Signatures length is checked in VerifyCommit.
-		if err := state.LastValidators.VerifyCommit(
-			state.ChainID, state.LastBlockID, block.Height-1, block.LastCommit); err != nil {
+		if err := state.LastValidators.VerifyCommitWithCache(
+			state.ChainID, state.LastBlockID, block.Height-1, block.LastCommit, verifiedSignatureCache); err != nil {
 			return err
 		}
 	}
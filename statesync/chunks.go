@@ -8,6 +8,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/cometbft/cometbft/v2/internal/tempfile"
 	cmtsync "github.com/cometbft/cometbft/v2/libs/sync"
 	"github.com/cometbft/cometbft/v2/p2p"
 )
@@ -31,6 +32,7 @@ type chunkQueue struct {
 	cmtsync.Mutex
 	snapshot       *snapshot                  // if this is nil, the queue has been closed
 	dir            string                     // temp dir for on-disk chunk storage
+	persistent     bool                       // whether dir is kept when the queue is closed
 	chunkFiles     map[uint32]string          // path to temporary chunk file
 	chunkSenders   map[uint32]p2p.ID          // the peer who sent the given chunk
 	chunkAllocated map[uint32]bool            // chunks that have been allocated via Allocate()
@@ -48,6 +50,37 @@ func newChunkQueue(snapshot *snapshot, tempDir string) (*chunkQueue, error) {
 	if snapshot.Chunks == 0 {
 		return nil, errors.New("snapshot has no chunks")
 	}
+	return makeChunkQueue(snapshot, dir), nil
+}
+
+// openChunkQueue opens a chunk queue for a snapshot, persisting the chunks in the given directory
+// and loading the chunks already persisted there. The directory is kept when the queue is closed,
+// so that the chunks can be reused after a restart.
+func openChunkQueue(snapshot *snapshot, dir string) (*chunkQueue, error) {
+	if snapshot.Chunks == 0 {
+		return nil, errors.New("snapshot has no chunks")
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("unable to create dir for state sync chunks: %w", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read dir for state sync chunks: %w", err)
+	}
+	q := makeChunkQueue(snapshot, dir)
+	q.persistent = true
+	for _, entry := range entries {
+		index, err := strconv.ParseUint(entry.Name(), 10, 32)
+		if err != nil || !entry.Type().IsRegular() || uint32(index) >= snapshot.Chunks {
+			continue
+		}
+		q.chunkFiles[uint32(index)] = filepath.Join(dir, entry.Name())
+		q.chunkAllocated[uint32(index)] = true
+	}
+	return q, nil
+}
+
+func makeChunkQueue(snapshot *snapshot, dir string) *chunkQueue {
 	return &chunkQueue{
 		snapshot:       snapshot,
 		dir:            dir,
@@ -56,7 +89,7 @@ func newChunkQueue(snapshot *snapshot, tempDir string) (*chunkQueue, error) {
 		chunkAllocated: make(map[uint32]bool, snapshot.Chunks),
 		chunkReturned:  make(map[uint32]bool, snapshot.Chunks),
 		waiters:        make(map[uint32][]chan<- uint32),
-	}, nil
+	}
 }
 
 // Add adds a chunk to the queue. It ignores chunks that already exist, returning false.
@@ -83,7 +116,7 @@ func (q *chunkQueue) Add(chunk *chunk) (bool, error) {
 	}
 
 	path := filepath.Join(q.dir, strconv.FormatUint(uint64(chunk.Index), 10))
-	err := os.WriteFile(path, chunk.Chunk, 0o600)
+	err := tempfile.WriteFileAtomic(path, chunk.Chunk, 0o600)
 	if err != nil {
 		return false, fmt.Errorf("failed to save chunk %v to file %v: %w", chunk.Index, path, err)
 	}
@@ -120,7 +153,8 @@ func (q *chunkQueue) Allocate() (uint32, error) {
 	return 0, errDone
 }
 
-// Close closes the chunk queue, cleaning up all temporary files.
+// Close closes the chunk queue, cleaning up all temporary files. The files of a persistent queue
+// are kept, and must be removed by the caller once no longer needed.
 func (q *chunkQueue) Close() error {
 	q.Lock()
 	defer q.Unlock()
@@ -134,6 +168,9 @@ func (q *chunkQueue) Close() error {
 	}
 	q.waiters = nil
 	q.snapshot = nil
+	if q.persistent {
+		return nil
+	}
 	err := os.RemoveAll(q.dir)
 	if err != nil {
 		return fmt.Errorf("failed to clean up state sync tempdir %v: %w", q.dir, err)
@@ -2,6 +2,7 @@ package statesync
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -54,6 +55,41 @@ func TestNewChunkQueue_TempDir(t *testing.T) {
 	assert.Empty(t, files)
 }
 
+func TestOpenChunkQueue(t *testing.T) {
+	snapshot := &snapshot{
+		Height:   3,
+		Format:   1,
+		Chunks:   3,
+		Hash:     []byte{7},
+		Metadata: nil,
+	}
+	dir := filepath.Join(t.TempDir(), "chunks")
+	queue, err := openChunkQueue(snapshot, dir)
+	require.NoError(t, err)
+	added, err := queue.Add(&chunk{Height: 3, Format: 1, Index: 1, Chunk: []byte{3, 1, 1}})
+	require.NoError(t, err)
+	assert.True(t, added)
+
+	// Closing the queue keeps the persisted chunks.
+	require.NoError(t, queue.Close())
+	assert.FileExists(t, filepath.Join(dir, "1"))
+
+	// Reopening the queue loads them, and only the missing chunks are allocated.
+	queue, err = openChunkQueue(snapshot, dir)
+	require.NoError(t, err)
+	defer queue.Close()
+	assert.True(t, queue.Has(1))
+	assert.False(t, queue.Has(0))
+	index, err := queue.Allocate()
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, index)
+	index, err = queue.Allocate()
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, index)
+	_, err = queue.Allocate()
+	require.ErrorIs(t, err, errDone)
+}
+
 func TestChunkQueue(t *testing.T) {
 	queue, teardown := setupChunkQueue(t)
 	defer teardown()
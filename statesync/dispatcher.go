@@ -0,0 +1,207 @@
+package statesync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	ssproto "github.com/cometbft/cometbft/api/cometbft/statesync/v1"
+	cmtsync "github.com/cometbft/cometbft/v2/libs/sync"
+	lightprovider "github.com/cometbft/cometbft/v2/light/provider"
+	"github.com/cometbft/cometbft/v2/p2p"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+// lightBlockResponseTimeout is how long to wait for a peer to respond to a light block or
+// consensus params request.
+const lightBlockResponseTimeout = 10 * time.Second
+
+// dispatcher sends light block and consensus params requests to peers, and routes their
+// responses back to the callers waiting for them. There is at most one pending request of
+// each kind per peer.
+type dispatcher struct {
+	mtx         cmtsync.Mutex
+	lightBlocks map[p2p.ID]chan *types.LightBlock
+	params      map[p2p.ID]chan paramsResponse
+}
+
+// paramsResponse is the consensus params at a height, as received from a peer.
+type paramsResponse struct {
+	height int64
+	params types.ConsensusParams
+}
+
+func newDispatcher() *dispatcher {
+	return &dispatcher{
+		lightBlocks: make(map[p2p.ID]chan *types.LightBlock),
+		params:      make(map[p2p.ID]chan paramsResponse),
+	}
+}
+
+// LightBlock requests the light block at the given height from a peer, or the latest one if the
+// height is 0, and waits for the response. It returns nil if the peer does not have it.
+func (d *dispatcher) LightBlock(ctx context.Context, peer p2p.Peer, height int64) (*types.LightBlock, error) {
+	d.mtx.Lock()
+	if _, ok := d.lightBlocks[peer.ID()]; ok {
+		d.mtx.Unlock()
+		return nil, fmt.Errorf("a light block request to peer %v is already pending", peer.ID())
+	}
+	ch := make(chan *types.LightBlock, 1)
+	d.lightBlocks[peer.ID()] = ch
+	d.mtx.Unlock()
+	defer func() {
+		d.mtx.Lock()
+		delete(d.lightBlocks, peer.ID())
+		d.mtx.Unlock()
+	}()
+
+	err := peer.Send(p2p.Envelope{
+		ChannelID: LightBlockChannel,
+		Message:   &ssproto.LightBlockRequest{Height: uint64(height)},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to request light block: %w", err)
+	}
+	for {
+		select {
+		case lightBlock := <-ch:
+			// Responses to earlier requests which timed out are ignored.
+			if lightBlock == nil || height == 0 || lightBlock.Height == height {
+				return lightBlock, nil
+			}
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// ConsensusParams requests the consensus params at the given height from a peer, and waits for
+// the response.
+func (d *dispatcher) ConsensusParams(ctx context.Context, peer p2p.Peer, height int64) (types.ConsensusParams, error) {
+	d.mtx.Lock()
+	if _, ok := d.params[peer.ID()]; ok {
+		d.mtx.Unlock()
+		return types.ConsensusParams{}, fmt.Errorf("a consensus params request to peer %v is already pending", peer.ID())
+	}
+	ch := make(chan paramsResponse, 1)
+	d.params[peer.ID()] = ch
+	d.mtx.Unlock()
+	defer func() {
+		d.mtx.Lock()
+		delete(d.params, peer.ID())
+		d.mtx.Unlock()
+	}()
+
+	err := peer.Send(p2p.Envelope{
+		ChannelID: LightBlockChannel,
+		Message:   &ssproto.ParamsRequest{Height: uint64(height)},
+	})
+	if err != nil {
+		return types.ConsensusParams{}, fmt.Errorf("failed to request consensus params: %w", err)
+	}
+	for {
+		select {
+		case resp := <-ch:
+			// Responses to earlier requests which timed out are ignored.
+			if resp.height == height {
+				return resp.params, nil
+			}
+		case <-ctx.Done():
+			return types.ConsensusParams{}, ctx.Err()
+		}
+	}
+}
+
+// RespondLightBlock routes a light block received from a peer to the caller waiting for it. It
+// returns false if no light block was requested from the peer.
+func (d *dispatcher) RespondLightBlock(peerID p2p.ID, lightBlock *types.LightBlock) bool {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	ch, ok := d.lightBlocks[peerID]
+	if !ok {
+		return false
+	}
+	select {
+	case ch <- lightBlock:
+	default: // the caller has not consumed the previous response yet
+	}
+	return true
+}
+
+// RespondConsensusParams routes consensus params received from a peer to the caller waiting for
+// them. It returns false if no consensus params were requested from the peer.
+func (d *dispatcher) RespondConsensusParams(peerID p2p.ID, height int64, params types.ConsensusParams) bool {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	ch, ok := d.params[peerID]
+	if !ok {
+		return false
+	}
+	select {
+	case ch <- paramsResponse{height: height, params: params}:
+	default: // the caller has not consumed the previous response yet
+	}
+	return true
+}
+
+// blockProvider is a light client provider, which fetches light blocks from a peer. Requests
+// are sent one at a time.
+type blockProvider struct {
+	mtx        cmtsync.Mutex
+	chainID    string
+	peer       p2p.Peer
+	dispatcher *dispatcher
+}
+
+var _ lightprovider.Provider = (*blockProvider)(nil)
+
+func newBlockProvider(chainID string, peer p2p.Peer, dispatcher *dispatcher) *blockProvider {
+	return &blockProvider{
+		chainID:    chainID,
+		peer:       peer,
+		dispatcher: dispatcher,
+	}
+}
+
+// ChainID implements lightprovider.Provider.
+func (p *blockProvider) ChainID() string {
+	return p.chainID
+}
+
+// LightBlock implements lightprovider.Provider.
+func (p *blockProvider) LightBlock(ctx context.Context, height int64) (*types.LightBlock, error) {
+	if height < 0 {
+		return nil, lightprovider.ErrNegativeHeight{Height: height}
+	}
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, lightBlockResponseTimeout)
+	defer cancel()
+	lightBlock, err := p.dispatcher.LightBlock(ctx, p.peer, height)
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return nil, lightprovider.ErrNoResponse
+	case err != nil:
+		return nil, err
+	case lightBlock == nil:
+		return nil, lightprovider.ErrLightBlockNotFound
+	}
+
+	if err := lightBlock.ValidateBasic(p.chainID); err != nil {
+		return nil, lightprovider.ErrBadLightBlock{Reason: err}
+	}
+	return lightBlock, nil
+}
+
+// ReportEvidence implements lightprovider.Provider. Evidence cannot be reported to peers over
+// the state sync channels.
+func (*blockProvider) ReportEvidence(context.Context, types.Evidence) error {
+	return errors.New("reporting evidence to peers is not supported")
+}
+
+// String implements fmt.Stringer.
+func (p *blockProvider) String() string {
+	return fmt.Sprintf("peer{%v}", p.peer.ID())
+}
@@ -0,0 +1,190 @@
+package statesync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	cmtstate "github.com/cometbft/cometbft/api/cometbft/state/v2"
+	ssproto "github.com/cometbft/cometbft/api/cometbft/statesync/v1"
+	"github.com/cometbft/cometbft/v2/config"
+	"github.com/cometbft/cometbft/v2/libs/log"
+	"github.com/cometbft/cometbft/v2/light"
+	lightprovider "github.com/cometbft/cometbft/v2/light/provider"
+	"github.com/cometbft/cometbft/v2/p2p"
+	p2pmocks "github.com/cometbft/cometbft/v2/p2p/mocks"
+	"github.com/cometbft/cometbft/v2/types"
+)
+
+// makeServingPeer returns a mock peer, whose requests on the light block channel are served by
+// the given reactor, and whose responses are routed to the given dispatcher.
+func makeServingPeer(t *testing.T, id p2p.ID, server *Reactor, d *dispatcher) *p2pmocks.Peer {
+	t.Helper()
+
+	// The client, as seen by the server.
+	client := &p2pmocks.Peer{}
+	client.On("ID").Return(p2p.ID("client"))
+
+	peer := &p2pmocks.Peer{}
+	peer.On("ID").Return(id)
+	peer.On("HasChannel", LightBlockChannel).Return(true)
+	peer.On("Send", mock.MatchedBy(func(e p2p.Envelope) bool {
+		return e.ChannelID == LightBlockChannel
+	})).Run(func(args mock.Arguments) {
+		server.Receive(p2p.Envelope{ChannelID: LightBlockChannel, Src: client, Message: args[0].(p2p.Envelope).Message})
+	}).Return(nil)
+	client.On("Send", mock.MatchedBy(func(e p2p.Envelope) bool {
+		return e.ChannelID == LightBlockChannel
+	})).Run(func(args mock.Arguments) {
+		switch msg := args[0].(p2p.Envelope).Message.(type) {
+		case *ssproto.LightBlockResponse:
+			var lightBlock *types.LightBlock
+			if msg.LightBlock != nil {
+				var err error
+				lightBlock, err = types.LightBlockFromProto(msg.LightBlock)
+				require.NoError(t, err)
+			}
+			d.RespondLightBlock(id, lightBlock)
+		case *ssproto.ParamsResponse:
+			d.RespondConsensusParams(id, int64(msg.Height), types.ConsensusParamsFromProto(msg.ConsensusParams))
+		}
+	}).Return(nil)
+	return peer
+}
+
+func TestDispatcher_LightBlock(t *testing.T) {
+	d := newDispatcher()
+	lightBlock := &types.LightBlock{SignedHeader: &types.SignedHeader{Header: &types.Header{Height: 2}}}
+
+	peer := &p2pmocks.Peer{}
+	peer.On("ID").Return(p2p.ID("id"))
+	peer.On("Send", mock.Anything).Run(func(mock.Arguments) {
+		go func() {
+			// Responses at other heights are ignored.
+			assert.True(t, d.RespondLightBlock("id", &types.LightBlock{
+				SignedHeader: &types.SignedHeader{Header: &types.Header{Height: 1}},
+			}))
+			time.Sleep(10 * time.Millisecond)
+			assert.True(t, d.RespondLightBlock("id", lightBlock))
+		}()
+	}).Return(nil)
+
+	received, err := d.LightBlock(context.Background(), peer, 2)
+	require.NoError(t, err)
+	assert.Equal(t, lightBlock, received)
+
+	// Responses are only routed while a request is pending.
+	assert.False(t, d.RespondLightBlock("id", lightBlock))
+	assert.False(t, d.RespondConsensusParams("id", 2, types.ConsensusParams{}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	silent := &p2pmocks.Peer{}
+	silent.On("ID").Return(p2p.ID("silent"))
+	silent.On("Send", mock.Anything).Return(nil)
+	_, err = d.LightBlock(ctx, silent, 2)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestBlockProvider(t *testing.T) {
+	stateStore, blockStore, _ := makeNodeSnapshotChain(t, 4)
+	server := NewReactor(*config.DefaultStateSyncConfig(), nil, nil, NopMetrics(),
+		WithStores(stateStore, blockStore))
+	require.NoError(t, server.Start())
+	t.Cleanup(func() {
+		if err := server.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	d := newDispatcher()
+	provider := newBlockProvider("test-chain", makeServingPeer(t, "a", server, d), d)
+	assert.Equal(t, "peer{a}", provider.String())
+
+	for _, height := range []int64{1, 3, 4} {
+		lightBlock, err := provider.LightBlock(context.Background(), height)
+		require.NoError(t, err)
+		assert.Equal(t, height, lightBlock.Height)
+		assert.Equal(t, blockStore.LoadBlockMeta(height).BlockID.Hash, lightBlock.Hash())
+	}
+
+	// The latest light block is returned for height 0.
+	lightBlock, err := provider.LightBlock(context.Background(), 0)
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, lightBlock.Height)
+
+	_, err = provider.LightBlock(context.Background(), 5)
+	require.ErrorIs(t, err, lightprovider.ErrLightBlockNotFound)
+	_, err = provider.LightBlock(context.Background(), -1)
+	require.Error(t, err)
+
+	// Light blocks of another chain are rejected.
+	provider = newBlockProvider("other-chain", makeServingPeer(t, "b", server, d), d)
+	_, err = provider.LightBlock(context.Background(), 1)
+	require.ErrorAs(t, err, &lightprovider.ErrBadLightBlock{})
+}
+
+func TestP2PStateProvider(t *testing.T) {
+	stateStore, blockStore, states := makeNodeSnapshotChain(t, 8)
+	server := NewReactor(*config.DefaultStateSyncConfig(), nil, nil, NopMetrics(),
+		WithStores(stateStore, blockStore))
+	require.NoError(t, server.Start())
+	t.Cleanup(func() {
+		if err := server.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	d := newDispatcher()
+	trustOptions := light.TrustOptions{
+		Period: time.Hour,
+		Height: 1,
+		Hash:   blockStore.LoadBlockMeta(1).BlockID.Hash,
+	}
+	version := cmtstate.Version{Consensus: states[1].Version.Consensus}
+
+	_, err := newP2PStateProvider(context.Background(), "test-chain", version, 1,
+		[]p2p.Peer{makeServingPeer(t, "a", server, d)}, d, trustOptions, log.NewNopLogger(), "")
+	require.Error(t, err)
+
+	stateProvider, err := newP2PStateProvider(context.Background(), "test-chain", version, 1,
+		[]p2p.Peer{makeServingPeer(t, "a", server, d), makeServingPeer(t, "b", server, d)},
+		d, trustOptions, log.NewNopLogger(), "")
+	require.NoError(t, err)
+
+	// Peers connecting later on are added as witnesses, once.
+	stateProvider.addPeer(makeServingPeer(t, "c", server, d))
+	stateProvider.addPeer(makeServingPeer(t, "c", server, d))
+	assert.Len(t, stateProvider.lc.Witnesses(), 2)
+
+	// Disconnected peers are removed, and added again with a new provider if they reconnect.
+	peer := makeServingPeer(t, "c", server, d)
+	stateProvider.removePeer(peer)
+	assert.Len(t, stateProvider.lc.Witnesses(), 1)
+	stateProvider.addPeer(peer)
+	require.Len(t, stateProvider.lc.Witnesses(), 2)
+	assert.Same(t, peer, stateProvider.lc.Witnesses()[1].(*blockProvider).peer)
+
+	appHash, err := stateProvider.AppHash(context.Background(), 5)
+	require.NoError(t, err)
+	assert.Equal(t, states[5].AppHash, []byte(appHash))
+
+	commit, err := stateProvider.Commit(context.Background(), 5)
+	require.NoError(t, err)
+	assert.Equal(t, blockStore.LoadBlockCommit(5).Hash(), commit.Hash())
+
+	state, err := stateProvider.State(context.Background(), 5)
+	require.NoError(t, err)
+	expected := states[5]
+	assert.EqualValues(t, 5, state.LastBlockHeight)
+	assert.Equal(t, expected.LastBlockID, state.LastBlockID)
+	assert.Equal(t, expected.AppHash, state.AppHash)
+	assert.Equal(t, expected.LastResultsHash, state.LastResultsHash)
+	assert.Equal(t, expected.Validators.Hash(), state.Validators.Hash())
+	assert.Equal(t, expected.NextValidators.Hash(), state.NextValidators.Hash())
+	assert.Equal(t, expected.ConsensusParams.Hash(), state.ConsensusParams.Hash())
+}
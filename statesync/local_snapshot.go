@@ -0,0 +1,259 @@
+package statesync
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/cometbft/cometbft/v2/config"
+	cmtbytes "github.com/cometbft/cometbft/v2/libs/bytes"
+	"github.com/cometbft/cometbft/v2/libs/log"
+	"github.com/cometbft/cometbft/v2/proxy"
+	sm "github.com/cometbft/cometbft/v2/state"
+	"github.com/cometbft/cometbft/v2/types"
+	"github.com/cometbft/cometbft/v2/version"
+)
+
+const (
+	// LocalSnapshotMetadataFile is the file describing the application snapshot
+	// of a local snapshot directory.
+	LocalSnapshotMetadataFile = "snapshot.json"
+	// LocalSnapshotChunksDir is the directory of the application snapshot
+	// chunks in a local snapshot directory, one file per chunk named after the
+	// chunk index.
+	LocalSnapshotChunksDir = "chunks"
+)
+
+// LocalSnapshotMetadata describes the application snapshot of a local snapshot
+// directory, as returned by the application in ListSnapshots.
+type LocalSnapshotMetadata struct {
+	Height   uint64            `json:"height"`
+	Format   uint32            `json:"format"`
+	Chunks   uint32            `json:"chunks"`
+	Hash     cmtbytes.HexBytes `json:"hash"`
+	Metadata []byte            `json:"metadata,omitempty"`
+}
+
+// RestoreLocalSnapshot restores the application from a local snapshot, without
+// any peers. The path is either a directory or a tar archive (optionally
+// gzipped) of a directory containing:
+//
+//   - snapshot.json: the LocalSnapshotMetadata of the application snapshot
+//   - chunks/<index>: the application snapshot chunks
+//   - <height>.snapshot: the node snapshot at the same height, as taken by a
+//     node with statesync.node_snapshot_interval set
+//
+// The node snapshot must belong to the given chain, and is verified against the
+// trusted hash of the header at the snapshot height, or at the following one.
+// It returns the state, commit and
+// recent blocks of the node snapshot, which the caller must use to bootstrap
+// the state and block stores.
+func RestoreLocalSnapshot(
+	cfg config.StateSyncConfig,
+	chainID string,
+	path string,
+	trustHeight int64,
+	trustHash []byte,
+	conn proxy.AppConnSnapshot,
+	connQuery proxy.AppConnQuery,
+	logger log.Logger,
+) (sm.State, *types.Commit, []*types.Block, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return sm.State{}, nil, nil, err
+	}
+	dir := path
+	if !info.IsDir() {
+		dir, err = os.MkdirTemp(cfg.TempDir, "local-snapshot")
+		if err != nil {
+			return sm.State{}, nil, nil, fmt.Errorf("failed to create temporary directory: %w", err)
+		}
+		defer os.RemoveAll(dir)
+		logger.Info("Extracting local snapshot archive", "archive", path)
+		if err := extractSnapshotArchive(path, dir); err != nil {
+			return sm.State{}, nil, nil, fmt.Errorf("failed to extract %v: %w", path, err)
+		}
+	}
+
+	snapshot, err := loadLocalSnapshot(dir)
+	if err != nil {
+		return sm.State{}, nil, nil, err
+	}
+	nodeSnapshots, err := NewNodeSnapshotStore(dir)
+	if err != nil {
+		return sm.State{}, nil, nil, err
+	}
+	nodeSnapshot, err := nodeSnapshots.Load(snapshot.Height)
+	if err != nil {
+		return sm.State{}, nil, nil, fmt.Errorf("failed to load node snapshot at height %d: %w", snapshot.Height, err)
+	}
+	if nodeSnapshot.State.ChainID != chainID {
+		return sm.State{}, nil, nil, fmt.Errorf("node snapshot belongs to chain %q, not %q", nodeSnapshot.State.ChainID, chainID)
+	}
+	if err := nodeSnapshot.Verify(trustHeight, trustHash); err != nil {
+		return sm.State{}, nil, nil, fmt.Errorf("failed to verify node snapshot: %w", err)
+	}
+	logger.Info("Verified node snapshot", "height", snapshot.Height, "trustHeight", trustHeight,
+		"trustHash", log.NewLazySprintf("%X", trustHash))
+
+	syncer := newSyncer(cfg, logger, conn, connQuery, newNodeSnapshotStateProvider(nodeSnapshot), cfg.TempDir)
+	syncer.localDir = dir
+	chunks, err := newChunkQueue(snapshot, cfg.TempDir)
+	if err != nil {
+		return sm.State{}, nil, nil, fmt.Errorf("failed to create chunk queue: %w", err)
+	}
+	defer chunks.Close()
+
+	state, commit, _, err := syncer.Sync(snapshot, chunks)
+	if err != nil {
+		return sm.State{}, nil, nil, err
+	}
+	return state, commit, nodeSnapshot.Blocks, nil
+}
+
+// loadLocalSnapshot loads the metadata of the application snapshot in a local
+// snapshot directory.
+func loadLocalSnapshot(dir string) (*snapshot, error) {
+	bz, err := os.ReadFile(filepath.Join(dir, LocalSnapshotMetadataFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot metadata: %w", err)
+	}
+	var metadata LocalSnapshotMetadata
+	if err := json.Unmarshal(bz, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot metadata: %w", err)
+	}
+	if metadata.Height == 0 {
+		return nil, errors.New("snapshot height cannot be 0")
+	}
+	if metadata.Chunks == 0 {
+		return nil, errors.New("snapshot has no chunks")
+	}
+	return &snapshot{
+		Height:   metadata.Height,
+		Format:   metadata.Format,
+		Chunks:   metadata.Chunks,
+		Hash:     metadata.Hash,
+		Metadata: metadata.Metadata,
+	}, nil
+}
+
+// loadLocalChunk loads an application snapshot chunk from a local snapshot
+// directory.
+func loadLocalChunk(dir string, index uint32) ([]byte, error) {
+	return os.ReadFile(filepath.Join(dir, LocalSnapshotChunksDir, strconv.FormatUint(uint64(index), 10)))
+}
+
+// extractSnapshotArchive extracts the regular files of a tar archive, which
+// may be gzipped, into a directory.
+func extractSnapshotArchive(archive, dir string) error {
+	f, err := os.Open(archive)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	br := bufio.NewReader(f)
+	var r io.Reader = br
+	if magic, err := br.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		// Archives commonly have a top-level directory, and must not write
+		// outside of the extraction directory.
+		name := filepath.Clean(filepath.FromSlash(header.Name))
+		if filepath.IsAbs(name) || name == ".." || strings.HasPrefix(name, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("invalid file name %q in archive", header.Name)
+		}
+		name = stripArchiveRoot(name)
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			return err
+		}
+		out, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(out, tr)
+		if cerr := out.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// stripArchiveRoot strips the top-level directory of a file name in a
+// snapshot archive, if the archive has one.
+func stripArchiveRoot(name string) string {
+	root, rest, ok := strings.Cut(name, string(filepath.Separator))
+	if !ok || root == LocalSnapshotChunksDir {
+		return name
+	}
+	return rest
+}
+
+// nodeSnapshotStateProvider is a state provider backed by a verified node
+// snapshot, used to restore a local snapshot without a light client.
+type nodeSnapshotStateProvider struct {
+	nodeSnapshot *NodeSnapshot
+}
+
+func newNodeSnapshotStateProvider(nodeSnapshot *NodeSnapshot) StateProvider {
+	return &nodeSnapshotStateProvider{nodeSnapshot: nodeSnapshot}
+}
+
+// AppHash implements StateProvider.
+func (s *nodeSnapshotStateProvider) AppHash(_ context.Context, height uint64) ([]byte, error) {
+	if int64(height) != s.nodeSnapshot.Height() {
+		return nil, fmt.Errorf("no app hash at height %d", height)
+	}
+	return s.nodeSnapshot.State.AppHash, nil
+}
+
+// Commit implements StateProvider.
+func (s *nodeSnapshotStateProvider) Commit(_ context.Context, height uint64) (*types.Commit, error) {
+	switch int64(height) {
+	case s.nodeSnapshot.Height():
+		return s.nodeSnapshot.Commit, nil
+	case s.nodeSnapshot.Height() + 1:
+		return s.nodeSnapshot.NextSignedHeader.Commit, nil
+	default:
+		return nil, fmt.Errorf("no commit at height %d", height)
+	}
+}
+
+// State implements StateProvider.
+func (s *nodeSnapshotStateProvider) State(_ context.Context, height uint64) (sm.State, error) {
+	if int64(height) != s.nodeSnapshot.Height() {
+		return sm.State{}, fmt.Errorf("no state at height %d", height)
+	}
+	state := s.nodeSnapshot.State.Copy()
+	state.Version.Software = version.CMTSemVer
+	return state, nil
+}
@@ -0,0 +1,161 @@
+package statesync
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/cometbft/cometbft/v2/abci/types"
+	"github.com/cometbft/cometbft/v2/config"
+	"github.com/cometbft/cometbft/v2/libs/log"
+	"github.com/cometbft/cometbft/v2/proxy"
+	proxymocks "github.com/cometbft/cometbft/v2/proxy/mocks"
+)
+
+// writeTarGz writes the given files to a gzipped tar archive.
+func writeTarGz(t *testing.T, path string, files map[string][]byte) {
+	t.Helper()
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	for name, bz := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name: name, Mode: 0o600, Size: int64(len(bz)), Typeflag: tar.TypeReg,
+		}))
+		_, err := tw.Write(bz)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+}
+
+func TestRestoreLocalSnapshot(t *testing.T) {
+	stateStore, blockStore, _ := makeNodeSnapshotChain(t, 8)
+	nodeSnapshot, err := MakeNodeSnapshot(stateStore, blockStore, 6)
+	require.NoError(t, err)
+	nodeSnapshotBz, err := nodeSnapshot.Bytes()
+	require.NoError(t, err)
+
+	metadata, err := json.Marshal(LocalSnapshotMetadata{Height: 6, Format: 1, Chunks: 2, Hash: []byte{1, 2, 3}})
+	require.NoError(t, err)
+	files := map[string][]byte{
+		LocalSnapshotMetadataFile:                  metadata,
+		filepath.Join(LocalSnapshotChunksDir, "0"): {1, 0},
+		filepath.Join(LocalSnapshotChunksDir, "1"): {1, 1},
+		"6" + nodeSnapshotFileExt:                  nodeSnapshotBz,
+	}
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, LocalSnapshotChunksDir), 0o700))
+	for name, bz := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), bz, 0o600))
+	}
+	archiveFiles := make(map[string][]byte, len(files))
+	for name, bz := range files {
+		archiveFiles["snapshot/"+filepath.ToSlash(name)] = bz
+	}
+	archive := filepath.Join(t.TempDir(), "snapshot.tar.gz")
+	writeTarGz(t, archive, archiveFiles)
+
+	trustHash := blockStore.LoadBlockMeta(7).BlockID.Hash
+	cfg := *config.DefaultStateSyncConfig()
+	cfg.TempDir = t.TempDir()
+
+	for _, path := range []string{dir, archive} {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			connSnapshot := &proxymocks.AppConnSnapshot{}
+			connQuery := &proxymocks.AppConnQuery{}
+			connSnapshot.On("OfferSnapshot", mock.Anything, &abci.OfferSnapshotRequest{
+				Snapshot: &abci.Snapshot{Height: 6, Format: 1, Chunks: 2, Hash: []byte{1, 2, 3}},
+				AppHash:  nodeSnapshot.State.AppHash,
+			}).Once().Return(&abci.OfferSnapshotResponse{Result: abci.OFFER_SNAPSHOT_RESULT_ACCEPT}, nil)
+			for i := uint32(0); i < 2; i++ {
+				connSnapshot.On("ApplySnapshotChunk", mock.Anything, &abci.ApplySnapshotChunkRequest{
+					Index: i, Chunk: []byte{1, byte(i)},
+				}).Once().Return(&abci.ApplySnapshotChunkResponse{Result: abci.APPLY_SNAPSHOT_CHUNK_RESULT_ACCEPT}, nil)
+			}
+			connQuery.On("Info", mock.Anything, proxy.InfoRequest).Return(&abci.InfoResponse{
+				AppVersion:       nodeSnapshot.State.Version.Consensus.App,
+				LastBlockHeight:  6,
+				LastBlockAppHash: nodeSnapshot.State.AppHash,
+			}, nil)
+
+			state, commit, blocks, err := RestoreLocalSnapshot(cfg, "test-chain", path, 7, trustHash,
+				connSnapshot, connQuery, log.NewNopLogger())
+			require.NoError(t, err)
+			assert.EqualValues(t, 6, state.LastBlockHeight)
+			assert.Equal(t, nodeSnapshot.State.AppHash, state.AppHash)
+			assert.Equal(t, blockStore.LoadBlockCommit(6).Hash(), commit.Hash())
+			assert.Len(t, blocks, len(nodeSnapshot.Blocks))
+			connSnapshot.AssertExpectations(t)
+			connQuery.AssertExpectations(t)
+		})
+	}
+
+	// The node snapshot must belong to the chain, and match the trusted hash.
+	_, _, _, err = RestoreLocalSnapshot(cfg, "other-chain", dir, 7, trustHash, nil, nil, log.NewNopLogger())
+	require.Error(t, err)
+	_, _, _, err = RestoreLocalSnapshot(cfg, "test-chain", dir, 7, []byte("bad"), nil, nil, log.NewNopLogger())
+	require.Error(t, err)
+	_, _, _, err = RestoreLocalSnapshot(cfg, "test-chain", t.TempDir(), 7, trustHash, nil, nil, log.NewNopLogger())
+	require.Error(t, err)
+
+	// The restoration fails, instead of waiting forever, if a chunk is missing.
+	require.NoError(t, os.Remove(filepath.Join(dir, LocalSnapshotChunksDir, "1")))
+	connSnapshot := &proxymocks.AppConnSnapshot{}
+	connSnapshot.On("OfferSnapshot", mock.Anything, mock.Anything).
+		Return(&abci.OfferSnapshotResponse{Result: abci.OFFER_SNAPSHOT_RESULT_ACCEPT}, nil)
+	connSnapshot.On("ApplySnapshotChunk", mock.Anything, mock.Anything).Maybe().
+		Return(&abci.ApplySnapshotChunkResponse{Result: abci.APPLY_SNAPSHOT_CHUNK_RESULT_ACCEPT}, nil)
+	_, _, _, err = RestoreLocalSnapshot(cfg, "test-chain", dir, 7, trustHash,
+		connSnapshot, &proxymocks.AppConnQuery{}, log.NewNopLogger())
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestExtractSnapshotArchive(t *testing.T) {
+	testcases := map[string]struct {
+		files  map[string][]byte
+		expect map[string][]byte
+		valid  bool
+	}{
+		"root dir": {
+			map[string][]byte{"root/snapshot.json": {1}, "root/chunks/0": {2}},
+			map[string][]byte{"snapshot.json": {1}, "chunks/0": {2}},
+			true,
+		},
+		"no root dir": {
+			map[string][]byte{"snapshot.json": {1}, "chunks/0": {2}},
+			map[string][]byte{"snapshot.json": {1}, "chunks/0": {2}},
+			true,
+		},
+		"parent dir":    {map[string][]byte{"../snapshot.json": {1}}, nil, false},
+		"nested parent": {map[string][]byte{"root/../../snapshot.json": {1}}, nil, false},
+		"absolute":      {map[string][]byte{"/snapshot.json": {1}}, nil, false},
+	}
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			archive := filepath.Join(t.TempDir(), "snapshot.tar.gz")
+			writeTarGz(t, archive, tc.files)
+			dir := t.TempDir()
+			err := extractSnapshotArchive(archive, dir)
+			if !tc.valid {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			for name, bz := range tc.expect {
+				extracted, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+				require.NoError(t, err)
+				assert.Equal(t, bz, extracted)
+			}
+		})
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/cosmos/gogoproto/proto"
 
 	ssproto "github.com/cometbft/cometbft/api/cometbft/statesync/v1"
+	cmtproto "github.com/cometbft/cometbft/api/cometbft/types/v2"
+	"github.com/cometbft/cometbft/v2/types"
 )
 
 const (
@@ -14,6 +16,8 @@ const (
 	snapshotMsgSize = int(4e6)
 	// chunkMsgSize is the maximum size of a chunkResponseMessage.
 	chunkMsgSize = int(16e6)
+	// lightBlockMsgSize is the maximum size of a lightBlockResponseMessage.
+	lightBlockMsgSize = int(1e7)
 )
 
 // validateMsg validates a message.
@@ -47,8 +51,34 @@ func validateMsg(pb proto.Message) error {
 		if msg.Chunks == 0 {
 			return errors.New("snapshot has no chunks")
 		}
+	case *ssproto.LightBlockRequest:
+	case *ssproto.LightBlockResponse:
+	case *ssproto.ParamsRequest:
+		if msg.Height == 0 {
+			return errors.New("height cannot be 0")
+		}
+	case *ssproto.ParamsResponse:
+		if msg.Height == 0 {
+			return errors.New("height cannot be 0")
+		}
+		if err := validateConsensusParams(msg.ConsensusParams); err != nil {
+			return fmt.Errorf("invalid consensus params: %w", err)
+		}
 	default:
 		return fmt.Errorf("unknown message type %T", msg)
 	}
 	return nil
 }
+
+// validateConsensusParams validates consensus params received from a peer, which must be
+// complete to be converted from their protobuf representation.
+func validateConsensusParams(pb cmtproto.ConsensusParams) error {
+	if pb.Block == nil || pb.Evidence == nil || pb.Validator == nil || pb.Version == nil {
+		return errors.New("block, evidence, validator and version params are required")
+	}
+	//nolint: staticcheck
+	if pb.GetAbci().GetVoteExtensionsEnableHeight() > 0 && pb.GetFeature().GetVoteExtensionsEnableHeight().GetValue() > 0 {
+		return errors.New("vote extensions enable height cannot be set in both abci and feature params")
+	}
+	return types.ConsensusParamsFromProto(pb).ValidateBasic()
+}
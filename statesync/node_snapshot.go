@@ -0,0 +1,363 @@
+package statesync
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"github.com/cosmos/gogoproto/proto"
+
+	cmtstate "github.com/cometbft/cometbft/api/cometbft/state/v2"
+	ssproto "github.com/cometbft/cometbft/api/cometbft/statesync/v1"
+	cmtproto "github.com/cometbft/cometbft/api/cometbft/types/v2"
+	sm "github.com/cometbft/cometbft/v2/state"
+	"github.com/cometbft/cometbft/v2/types"
+	"github.com/cometbft/cometbft/v2/version"
+)
+
+const (
+	// nodeSnapshotFormat is the format of the node snapshots.
+	nodeSnapshotFormat = uint32(1)
+	// nodeSnapshotChunkSize is the size of the chunks node snapshots are served in.
+	nodeSnapshotChunkSize = 10 * 1024 * 1024
+	// nodeSnapshotBlocks is the number of recent blocks in a node snapshot.
+	nodeSnapshotBlocks = 10
+)
+
+// NodeSnapshot is a snapshot of the CometBFT data at a given height, which a
+// node produces independently of the application. It contains everything a
+// node needs to bootstrap its state and block stores at that height, once the
+// application restored its own snapshot at the same height.
+type NodeSnapshot struct {
+	// State is the state after the block at the snapshot height was committed.
+	State sm.State
+	// Blocks are the recent blocks, in ascending order. The last block is at
+	// the snapshot height.
+	Blocks []*types.Block
+	// Commit is the commit for the block at the snapshot height.
+	Commit *types.Commit
+	// NextSignedHeader is the header and commit at the height following the
+	// snapshot height, which contain the app hash and the results hash of the
+	// snapshot height.
+	NextSignedHeader *types.SignedHeader
+}
+
+// MakeNodeSnapshot makes the node snapshot at the given height from the state
+// and block stores. The block at height+2 must have been committed, so that
+// the stores contain the validator sets and the canonical commits the snapshot
+// is made of.
+func MakeNodeSnapshot(stateStore sm.Store, blockStore sm.BlockStore, height int64) (*NodeSnapshot, error) {
+	if blockStore.Height() < height+2 {
+		return nil, fmt.Errorf("block at height %d is not committed yet", height+2)
+	}
+	latestState, err := stateStore.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load state: %w", err)
+	}
+
+	// The snapshot height maps onto the state heights as follows, like in
+	// lightClientStateProvider.State:
+	//
+	// height: last block, i.e. the snapshotted height
+	// height+1: current block, i.e. the first block a node processes after restoring the snapshot
+	// height+2: next block, i.e. the second block after the snapshot
+	nextMeta := blockStore.LoadBlockMeta(height + 1)
+	if nextMeta == nil {
+		return nil, fmt.Errorf("no block meta at height %d", height+1)
+	}
+	commit := blockStore.LoadBlockCommit(height)
+	if commit == nil {
+		return nil, fmt.Errorf("no commit at height %d", height)
+	}
+	nextCommit := blockStore.LoadBlockCommit(height + 1)
+	if nextCommit == nil {
+		return nil, fmt.Errorf("no commit at height %d", height+1)
+	}
+	lastValidators, err := stateStore.LoadValidators(height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load validators at height %d: %w", height, err)
+	}
+	validators, err := stateStore.LoadValidators(height + 1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load validators at height %d: %w", height+1, err)
+	}
+	nextValidators, err := stateStore.LoadValidators(height + 2)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load validators at height %d: %w", height+2, err)
+	}
+	consensusParams, err := stateStore.LoadConsensusParams(height + 1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load consensus params at height %d: %w", height+1, err)
+	}
+
+	base := max(blockStore.Base(), height-nodeSnapshotBlocks+1)
+	if base > height {
+		return nil, fmt.Errorf("block at height %d was pruned", height)
+	}
+	blocks := make([]*types.Block, 0, height-base+1)
+	for h := base; h <= height; h++ {
+		block, _ := blockStore.LoadBlock(h)
+		if block == nil {
+			return nil, fmt.Errorf("no block at height %d", h)
+		}
+		blocks = append(blocks, block)
+	}
+	lastBlock := blocks[len(blocks)-1]
+
+	nextHeader := nextMeta.Header
+	state := sm.State{
+		Version: cmtstate.Version{
+			Consensus: nextHeader.Version,
+			Software:  version.CMTSemVer,
+		},
+		ChainID:                          latestState.ChainID,
+		InitialHeight:                    latestState.InitialHeight,
+		LastBlockHeight:                  height,
+		LastBlockID:                      nextHeader.LastBlockID,
+		LastBlockTime:                    lastBlock.Time,
+		NextValidators:                   nextValidators,
+		Validators:                       validators,
+		LastValidators:                   lastValidators,
+		LastHeightValidatorsChanged:      height + 2,
+		ConsensusParams:                  consensusParams,
+		LastHeightConsensusParamsChanged: height + 1,
+		LastResultsHash:                  nextHeader.LastResultsHash,
+		AppHash:                          nextHeader.AppHash,
+	}
+
+	return &NodeSnapshot{
+		State:  state,
+		Blocks: blocks,
+		Commit: commit,
+		NextSignedHeader: &types.SignedHeader{
+			Header: &nextHeader,
+			Commit: nextCommit,
+		},
+	}, nil
+}
+
+// Height returns the snapshot height.
+func (s *NodeSnapshot) Height() int64 {
+	return s.State.LastBlockHeight
+}
+
+// Verify verifies the node snapshot against a trusted header hash, which must
+// be the hash of the header at the snapshot height or at the following one.
+// The rest of the snapshot is verified through the hashes in the headers, and
+// the commit signatures.
+func (s *NodeSnapshot) Verify(trustedHeight int64, trustedHash []byte) error {
+	height := s.Height()
+	if len(s.Blocks) == 0 {
+		return errors.New("no blocks")
+	}
+	if s.Commit == nil {
+		return errors.New("no commit")
+	}
+	if s.NextSignedHeader == nil {
+		return errors.New("no next signed header")
+	}
+	lastBlock := s.Blocks[len(s.Blocks)-1]
+	nextHeader := s.NextSignedHeader.Header
+
+	// Check the trusted hash first, so that the chain ID the rest of the
+	// snapshot is checked against can be trusted.
+	switch trustedHeight {
+	case height:
+		if !bytes.Equal(lastBlock.Hash(), trustedHash) {
+			return fmt.Errorf("block at height %d is %X, expected trusted hash %X", height, lastBlock.Hash(), trustedHash)
+		}
+	case height + 1:
+		if nextHeader == nil || !bytes.Equal(nextHeader.Hash(), trustedHash) {
+			return fmt.Errorf("header at height %d does not match the trusted hash %X", height+1, trustedHash)
+		}
+	default:
+		return fmt.Errorf("trusted height %d must be the snapshot height %d or the following one", trustedHeight, height)
+	}
+	chainID := lastBlock.ChainID
+	if s.State.ChainID != chainID {
+		return fmt.Errorf("state belongs to chain %q, not %q", s.State.ChainID, chainID)
+	}
+
+	// The blocks are linked by the hash of the previous block in each header.
+	for i, block := range s.Blocks {
+		if err := block.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid block at height %d: %w", block.Height, err)
+		}
+		if i == 0 {
+			continue
+		}
+		prev := s.Blocks[i-1]
+		if block.Height != prev.Height+1 {
+			return fmt.Errorf("block at height %d follows block at height %d", block.Height, prev.Height)
+		}
+		if !bytes.Equal(block.LastBlockID.Hash, prev.Hash()) {
+			return fmt.Errorf("block at height %d does not link to the block at height %d", block.Height, prev.Height)
+		}
+	}
+	if lastBlock.Height != height {
+		return fmt.Errorf("last block height %d does not match the snapshot height %d", lastBlock.Height, height)
+	}
+
+	if err := s.NextSignedHeader.ValidateBasic(chainID); err != nil {
+		return fmt.Errorf("invalid signed header at height %d: %w", height+1, err)
+	}
+	if nextHeader.Height != height+1 {
+		return fmt.Errorf("next header height %d does not follow the snapshot height %d", nextHeader.Height, height)
+	}
+	if err := s.Commit.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid commit: %w", err)
+	}
+	if s.Commit.Height != height || !bytes.Equal(s.Commit.BlockID.Hash, lastBlock.Hash()) {
+		return fmt.Errorf("commit does not commit the block at height %d", height)
+	}
+	if !s.Commit.BlockID.Equals(nextHeader.LastBlockID) {
+		return fmt.Errorf("header at height %d does not link to the block at height %d", height+1, height)
+	}
+	if !bytes.Equal(s.Commit.Hash(), nextHeader.LastCommitHash) {
+		return fmt.Errorf("commit does not match the last commit hash at height %d", height+1)
+	}
+
+	// Check the state against the headers.
+	state := s.State
+	switch {
+	case state.LastValidators == nil || state.Validators == nil || state.NextValidators == nil:
+		return errors.New("state is missing validator sets")
+	case !bytes.Equal(state.LastValidators.Hash(), lastBlock.ValidatorsHash):
+		return errors.New("last validators do not match the header at the snapshot height")
+	case !bytes.Equal(state.Validators.Hash(), lastBlock.NextValidatorsHash),
+		!bytes.Equal(state.Validators.Hash(), nextHeader.ValidatorsHash):
+		return errors.New("validators do not match the header at the following height")
+	case !bytes.Equal(state.NextValidators.Hash(), nextHeader.NextValidatorsHash):
+		return errors.New("next validators do not match the header at the following height")
+	case !bytes.Equal(state.ConsensusParams.Hash(), nextHeader.ConsensusHash):
+		return errors.New("consensus params do not match the header at the following height")
+	case !bytes.Equal(state.AppHash, nextHeader.AppHash):
+		return errors.New("app hash does not match the header at the following height")
+	case !bytes.Equal(state.LastResultsHash, nextHeader.LastResultsHash):
+		return errors.New("last results hash does not match the header at the following height")
+	case state.Version.Consensus != nextHeader.Version:
+		return errors.New("consensus version does not match the header at the following height")
+	case !state.LastBlockID.Equals(s.Commit.BlockID):
+		return errors.New("last block ID does not match the commit")
+	case !state.LastBlockTime.Equal(lastBlock.Time):
+		return errors.New("last block time does not match the block at the snapshot height")
+	case state.InitialHeight <= 0 || state.InitialHeight > s.Blocks[0].Height:
+		return fmt.Errorf("invalid initial height %d", state.InitialHeight)
+	}
+
+	// Finally, check the commit signatures, which verify the header at the
+	// following height if the trusted hash is the one of the snapshot height.
+	if err := state.LastValidators.VerifyCommitLight(chainID, s.Commit.BlockID, height, s.Commit); err != nil {
+		return fmt.Errorf("invalid commit at height %d: %w", height, err)
+	}
+	nextCommit := s.NextSignedHeader.Commit
+	if err := state.Validators.VerifyCommitLight(chainID, nextCommit.BlockID, height+1, nextCommit); err != nil {
+		return fmt.Errorf("invalid commit at height %d: %w", height+1, err)
+	}
+	return nil
+}
+
+// ToProto converts the node snapshot to its protobuf representation.
+func (s *NodeSnapshot) ToProto() (*ssproto.NodeSnapshot, error) {
+	state, err := s.State.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	blocks := make([]*cmtproto.Block, 0, len(s.Blocks))
+	for _, block := range s.Blocks {
+		pb, err := block.ToProto()
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, pb)
+	}
+	return &ssproto.NodeSnapshot{
+		State:            state,
+		Blocks:           blocks,
+		Commit:           s.Commit.ToProto(),
+		NextSignedHeader: s.NextSignedHeader.ToProto(),
+	}, nil
+}
+
+// NodeSnapshotFromProto converts a protobuf node snapshot to a NodeSnapshot.
+// The node snapshot must be verified with Verify before being used.
+func NodeSnapshotFromProto(pb *ssproto.NodeSnapshot) (*NodeSnapshot, error) {
+	if pb == nil || pb.State == nil {
+		return nil, errors.New("nil node snapshot")
+	}
+	state, err := sm.FromProto(pb.State)
+	if err != nil {
+		return nil, fmt.Errorf("invalid state: %w", err)
+	}
+	blocks := make([]*types.Block, 0, len(pb.Blocks))
+	for _, bp := range pb.Blocks {
+		block, err := types.BlockFromProto(bp)
+		if err != nil {
+			return nil, fmt.Errorf("invalid block: %w", err)
+		}
+		blocks = append(blocks, block)
+	}
+	commit, err := types.CommitFromProto(pb.Commit)
+	if err != nil {
+		return nil, fmt.Errorf("invalid commit: %w", err)
+	}
+	nextSignedHeader, err := types.SignedHeaderFromProto(pb.NextSignedHeader)
+	if err != nil {
+		return nil, fmt.Errorf("invalid next signed header: %w", err)
+	}
+	return &NodeSnapshot{
+		State:            *state,
+		Blocks:           blocks,
+		Commit:           commit,
+		NextSignedHeader: nextSignedHeader,
+	}, nil
+}
+
+// Bytes returns the protobuf encoding of the node snapshot.
+func (s *NodeSnapshot) Bytes() ([]byte, error) {
+	pb, err := s.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(pb)
+}
+
+// decodeNodeSnapshot decodes a node snapshot from its protobuf encoding.
+func decodeNodeSnapshot(bz []byte) (*NodeSnapshot, error) {
+	pb := new(ssproto.NodeSnapshot)
+	if err := proto.Unmarshal(bz, pb); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal node snapshot: %w", err)
+	}
+	return NodeSnapshotFromProto(pb)
+}
+
+// nodeSnapshotMetadata returns the metadata of an encoded node snapshot, as
+// advertised to peers.
+func nodeSnapshotMetadata(height uint64, bz []byte) *snapshot {
+	hash := sha256.Sum256(bz)
+	return &snapshot{
+		Height: height,
+		Format: nodeSnapshotFormat,
+		Chunks: uint32((len(bz) + nodeSnapshotChunkSize - 1) / nodeSnapshotChunkSize),
+		Hash:   hash[:],
+	}
+}
+
+// SaveBlocks saves the consecutive blocks restored from a node snapshot to an
+// empty block store, with the given commit of the last block as its seen
+// commit.
+func SaveBlocks(blockStore sm.BlockStore, blocks []*types.Block, commit *types.Commit) error {
+	for i, block := range blocks {
+		seenCommit := commit
+		if i < len(blocks)-1 {
+			seenCommit = blocks[i+1].LastCommit
+		}
+		partSet, err := block.MakePartSet(types.BlockPartSizeBytes)
+		if err != nil {
+			return fmt.Errorf("failed to make part set of block at height %d: %w", block.Height, err)
+		}
+		blockStore.SaveBlock(block, partSet, seenCommit)
+	}
+	return nil
+}
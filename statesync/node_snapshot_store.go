@@ -0,0 +1,164 @@
+package statesync
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cometbft/cometbft/v2/internal/tempfile"
+	cmtsync "github.com/cometbft/cometbft/v2/libs/sync"
+)
+
+// nodeSnapshotFileExt is the extension of the node snapshot files.
+const nodeSnapshotFileExt = ".snapshot"
+
+// NodeSnapshotStore stores the encoded node snapshots as files in a directory,
+// one file per snapshot named after the snapshot height.
+type NodeSnapshotStore struct {
+	dir string
+
+	mtx cmtsync.Mutex
+	// metadata caches the metadata of the snapshots by height, so that the
+	// files are not hashed on every request.
+	metadata map[uint64]*snapshot
+}
+
+// NewNodeSnapshotStore creates a node snapshot store in the given directory,
+// creating the directory if it does not exist.
+func NewNodeSnapshotStore(dir string) (*NodeSnapshotStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create node snapshot directory: %w", err)
+	}
+	return &NodeSnapshotStore{
+		dir:      dir,
+		metadata: make(map[uint64]*snapshot),
+	}, nil
+}
+
+// Save encodes and saves a node snapshot, overwriting any snapshot at the same
+// height.
+func (s *NodeSnapshotStore) Save(nodeSnapshot *NodeSnapshot) error {
+	bz, err := nodeSnapshot.Bytes()
+	if err != nil {
+		return fmt.Errorf("failed to encode node snapshot: %w", err)
+	}
+	height := uint64(nodeSnapshot.Height())
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if err := tempfile.WriteFileAtomic(s.path(height), bz, 0o600); err != nil {
+		return fmt.Errorf("failed to write node snapshot: %w", err)
+	}
+	s.metadata[height] = nodeSnapshotMetadata(height, bz)
+	return nil
+}
+
+// Load loads the node snapshot at the given height.
+func (s *NodeSnapshotStore) Load(height uint64) (*NodeSnapshot, error) {
+	bz, err := os.ReadFile(s.path(height))
+	if err != nil {
+		return nil, err
+	}
+	return decodeNodeSnapshot(bz)
+}
+
+// LoadChunk loads a chunk of the encoded node snapshot at the given height. It
+// returns nil if the snapshot or the chunk does not exist.
+func (s *NodeSnapshotStore) LoadChunk(height uint64, index uint32) ([]byte, error) {
+	f, err := os.Open(s.path(height))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	buf := make([]byte, nodeSnapshotChunkSize)
+	n, err := f.ReadAt(buf, int64(index)*nodeSnapshotChunkSize)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, nil
+	}
+	return buf[:n], nil
+}
+
+// List returns the metadata of the stored node snapshots, the most recent
+// first.
+func (s *NodeSnapshotStore) List() ([]*snapshot, error) {
+	heights, err := s.heights()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	snapshots := make([]*snapshot, 0, len(heights))
+	for _, height := range heights {
+		metadata, ok := s.metadata[height]
+		if !ok {
+			bz, err := os.ReadFile(s.path(height))
+			if err != nil {
+				return nil, err
+			}
+			metadata = nodeSnapshotMetadata(height, bz)
+			s.metadata[height] = metadata
+		}
+		snapshots = append(snapshots, metadata)
+	}
+	return snapshots, nil
+}
+
+// Prune deletes all but the keepRecent most recent node snapshots.
+func (s *NodeSnapshotStore) Prune(keepRecent int) error {
+	heights, err := s.heights()
+	if err != nil {
+		return err
+	}
+	if len(heights) <= keepRecent {
+		return nil
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	for _, height := range heights[keepRecent:] {
+		if err := os.Remove(s.path(height)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		delete(s.metadata, height)
+	}
+	return nil
+}
+
+// heights returns the heights of the stored node snapshots, in descending
+// order.
+func (s *NodeSnapshotStore) heights() ([]uint64, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+	heights := make([]uint64, 0, len(entries))
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), nodeSnapshotFileExt)
+		if !ok || entry.IsDir() {
+			continue
+		}
+		height, err := strconv.ParseUint(name, 10, 64)
+		if err != nil {
+			continue
+		}
+		heights = append(heights, height)
+	}
+	sort.Slice(heights, func(i, j int) bool { return heights[i] > heights[j] })
+	return heights, nil
+}
+
+func (s *NodeSnapshotStore) path(height uint64) string {
+	return filepath.Join(s.dir, strconv.FormatUint(height, 10)+nodeSnapshotFileExt)
+}
@@ -0,0 +1,61 @@
+package statesync
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeSnapshotStore(t *testing.T) {
+	stateStore, blockStore, _ := makeNodeSnapshotChain(t, 10)
+	dir := t.TempDir()
+	s, err := NewNodeSnapshotStore(dir)
+	require.NoError(t, err)
+
+	snapshots, err := s.List()
+	require.NoError(t, err)
+	assert.Empty(t, snapshots)
+
+	for _, height := range []int64{4, 6, 8} {
+		nodeSnapshot, err := MakeNodeSnapshot(stateStore, blockStore, height)
+		require.NoError(t, err)
+		require.NoError(t, s.Save(nodeSnapshot))
+	}
+
+	snapshots, err = s.List()
+	require.NoError(t, err)
+	require.Len(t, snapshots, 3)
+	for i, height := range []uint64{8, 6, 4} {
+		assert.Equal(t, height, snapshots[i].Height)
+		assert.Equal(t, nodeSnapshotFormat, snapshots[i].Format)
+		assert.EqualValues(t, 1, snapshots[i].Chunks)
+	}
+
+	nodeSnapshot, err := s.Load(6)
+	require.NoError(t, err)
+	bz, err := nodeSnapshot.Bytes()
+	require.NoError(t, err)
+	assert.Equal(t, nodeSnapshotMetadata(6, bz), snapshots[1])
+
+	chunk, err := s.LoadChunk(6, 0)
+	require.NoError(t, err)
+	assert.Equal(t, bz, chunk)
+	chunk, err = s.LoadChunk(6, 1)
+	require.NoError(t, err)
+	assert.Nil(t, chunk)
+	chunk, err = s.LoadChunk(5, 0)
+	require.NoError(t, err)
+	assert.Nil(t, chunk)
+
+	require.NoError(t, s.Prune(2))
+	_, err = s.Load(4)
+	require.Error(t, err)
+
+	// A new store over the same directory lists the remaining snapshots.
+	s, err = NewNodeSnapshotStore(dir)
+	require.NoError(t, err)
+	reopened, err := s.List()
+	require.NoError(t, err)
+	assert.Equal(t, snapshots[:2], reopened)
+}
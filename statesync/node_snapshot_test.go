@@ -0,0 +1,253 @@
+package statesync
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+	ssproto "github.com/cometbft/cometbft/api/cometbft/statesync/v1"
+	"github.com/cometbft/cometbft/v2/abci/example/kvstore"
+	"github.com/cometbft/cometbft/v2/config"
+	"github.com/cometbft/cometbft/v2/internal/test"
+	"github.com/cometbft/cometbft/v2/libs/log"
+	"github.com/cometbft/cometbft/v2/mempool"
+	"github.com/cometbft/cometbft/v2/p2p"
+	p2pmocks "github.com/cometbft/cometbft/v2/p2p/mocks"
+	"github.com/cometbft/cometbft/v2/proxy"
+	sm "github.com/cometbft/cometbft/v2/state"
+	"github.com/cometbft/cometbft/v2/statesync/mocks"
+	"github.com/cometbft/cometbft/v2/store"
+	"github.com/cometbft/cometbft/v2/types"
+	cmttime "github.com/cometbft/cometbft/v2/types/time"
+)
+
+// makeNodeSnapshotChain commits a chain of the given height with a single
+// validator, returning its stores and the state after each height.
+func makeNodeSnapshotChain(t *testing.T, height int64) (sm.Store, *store.BlockStore, map[int64]sm.State) {
+	t.Helper()
+
+	val, privVal, err := test.Validator(context.Background(), 10)
+	require.NoError(t, err)
+	genDoc := test.GenesisDoc(cmttime.Now(), []*types.Validator{val}, test.ConsensusParams(), "test-chain")
+	state, err := sm.MakeGenesisState(genDoc)
+	require.NoError(t, err)
+
+	stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{})
+	require.NoError(t, stateStore.Save(state))
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+
+	proxyApp := proxy.NewAppConns(proxy.NewLocalClientCreator(kvstore.NewInMemoryApplication()), proxy.NopMetrics())
+	require.NoError(t, proxyApp.Start())
+	t.Cleanup(func() {
+		if err := proxyApp.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	blockExec := sm.NewBlockExecutor(stateStore, log.NewNopLogger(), proxyApp.Consensus(),
+		&mempool.NopMempool{}, sm.EmptyEvidencePool{}, blockStore)
+
+	states := make(map[int64]sm.State, height)
+	lastCommit := &types.Commit{}
+	for h := int64(1); h <= height; h++ {
+		txs := types.Txs{types.Tx(fmt.Sprintf("key%d=value%d", h, h))}
+		block := state.MakeBlock(h, txs, lastCommit, nil, state.Validators.GetProposer().Address)
+		partSet, err := block.MakePartSet(types.BlockPartSizeBytes)
+		require.NoError(t, err)
+		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: partSet.Header()}
+
+		commit, err := test.MakeCommit(blockID, h, 0, state.Validators, []types.PrivValidator{privVal},
+			state.ChainID, cmttime.Now())
+		require.NoError(t, err)
+		blockStore.SaveBlock(block, partSet, commit)
+
+		require.NoError(t, blockExec.ValidateBlock(state, block))
+		state, err = blockExec.ApplyBlock(state, blockID, block, h)
+		require.NoError(t, err)
+		states[h] = state
+		lastCommit = commit
+	}
+	return stateStore, blockStore, states
+}
+
+func TestNodeSnapshot(t *testing.T) {
+	stateStore, blockStore, states := makeNodeSnapshotChain(t, 15)
+
+	// The block at height+2 must be committed.
+	_, err := MakeNodeSnapshot(stateStore, blockStore, 14)
+	require.Error(t, err)
+
+	nodeSnapshot, err := MakeNodeSnapshot(stateStore, blockStore, 12)
+	require.NoError(t, err)
+	assert.EqualValues(t, 12, nodeSnapshot.Height())
+	require.Len(t, nodeSnapshot.Blocks, nodeSnapshotBlocks)
+	assert.EqualValues(t, 3, nodeSnapshot.Blocks[0].Height)
+
+	expected := states[12]
+	state := nodeSnapshot.State
+	assert.Equal(t, expected.ChainID, state.ChainID)
+	assert.Equal(t, expected.LastBlockID, state.LastBlockID)
+	assert.True(t, expected.LastBlockTime.Equal(state.LastBlockTime))
+	assert.Equal(t, expected.AppHash, state.AppHash)
+	assert.Equal(t, expected.LastResultsHash, state.LastResultsHash)
+	assert.Equal(t, expected.Validators.Hash(), state.Validators.Hash())
+	assert.Equal(t, expected.NextValidators.Hash(), state.NextValidators.Hash())
+	assert.Equal(t, expected.LastValidators.Hash(), state.LastValidators.Hash())
+	assert.Equal(t, expected.ConsensusParams.Hash(), state.ConsensusParams.Hash())
+
+	nextMeta := blockStore.LoadBlockMeta(13)
+	require.NotNil(t, nextMeta)
+	require.NoError(t, nodeSnapshot.Verify(13, nextMeta.BlockID.Hash))
+	require.NoError(t, nodeSnapshot.Verify(12, nodeSnapshot.Commit.BlockID.Hash))
+	require.Error(t, nodeSnapshot.Verify(13, nodeSnapshot.Commit.BlockID.Hash))
+	require.Error(t, nodeSnapshot.Verify(11, nodeSnapshot.Commit.BlockID.Hash))
+
+	// The encoding roundtrips, and is deterministic.
+	bz, err := nodeSnapshot.Bytes()
+	require.NoError(t, err)
+	decoded, err := decodeNodeSnapshot(bz)
+	require.NoError(t, err)
+	require.NoError(t, decoded.Verify(13, nextMeta.BlockID.Hash))
+	decodedBz, err := decoded.Bytes()
+	require.NoError(t, err)
+	assert.Equal(t, bz, decodedBz)
+
+	other, err := MakeNodeSnapshot(stateStore, blockStore, 12)
+	require.NoError(t, err)
+	otherBz, err := other.Bytes()
+	require.NoError(t, err)
+	assert.Equal(t, bz, otherBz)
+}
+
+func TestNodeSnapshot_VerifyTampered(t *testing.T) {
+	stateStore, blockStore, _ := makeNodeSnapshotChain(t, 8)
+	nextMeta := blockStore.LoadBlockMeta(7)
+	require.NotNil(t, nextMeta)
+	nodeSnapshot, err := MakeNodeSnapshot(stateStore, blockStore, 6)
+	require.NoError(t, err)
+	require.NoError(t, nodeSnapshot.Verify(7, nextMeta.BlockID.Hash))
+	bz, err := nodeSnapshot.Bytes()
+	require.NoError(t, err)
+
+	testCases := map[string]func(*NodeSnapshot){
+		"app hash": func(s *NodeSnapshot) {
+			s.State.AppHash = []byte("tampered")
+		},
+		"last results hash": func(s *NodeSnapshot) {
+			s.State.LastResultsHash = []byte("tampered")
+		},
+		"validators": func(s *NodeSnapshot) {
+			s.State.Validators = s.State.Validators.CopyIncrementProposerPriority(1)
+			s.State.Validators.Validators[0].VotingPower++
+		},
+		"block txs": func(s *NodeSnapshot) {
+			b := s.Blocks[0]
+			tampered := types.MakeBlock(b.Height, types.Txs{types.Tx("tampered")}, b.LastCommit, nil)
+			tampered.Header.Populate(b.Version, b.ChainID, b.Time, b.LastBlockID, b.ValidatorsHash,
+				b.NextValidatorsHash, b.ConsensusHash, b.AppHash, b.LastResultsHash, b.ProposerAddress)
+			s.Blocks[0] = tampered
+		},
+		"missing block": func(s *NodeSnapshot) {
+			s.Blocks = append(s.Blocks[:1], s.Blocks[2:]...)
+		},
+		"commit": func(s *NodeSnapshot) {
+			s.Commit.Signatures[0].Signature = make([]byte, len(s.Commit.Signatures[0].Signature))
+		},
+		"next commit": func(s *NodeSnapshot) {
+			s.NextSignedHeader.Commit.Signatures[0].Signature = make([]byte, len(s.NextSignedHeader.Commit.Signatures[0].Signature))
+		},
+		"chain ID": func(s *NodeSnapshot) {
+			s.State.ChainID = "other-chain"
+		},
+	}
+	for name, tamper := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// Tamper with a freshly decoded copy, since the block store caches
+			// the commits the node snapshot is made of.
+			decoded, err := decodeNodeSnapshot(bz)
+			require.NoError(t, err)
+			tamper(decoded)
+			require.Error(t, decoded.Verify(7, nextMeta.BlockID.Hash))
+		})
+	}
+}
+
+func TestSaveBlocks(t *testing.T) {
+	stateStore, blockStore, _ := makeNodeSnapshotChain(t, 15)
+	nodeSnapshot, err := MakeNodeSnapshot(stateStore, blockStore, 12)
+	require.NoError(t, err)
+
+	restored := store.NewBlockStore(dbm.NewMemDB())
+	require.NoError(t, SaveBlocks(restored, nodeSnapshot.Blocks, nodeSnapshot.Commit))
+	assert.EqualValues(t, 3, restored.Base())
+	assert.EqualValues(t, 12, restored.Height())
+	for h := int64(3); h <= 12; h++ {
+		block, _ := restored.LoadBlock(h)
+		require.NotNil(t, block)
+		assert.Equal(t, blockStore.LoadBlockMeta(h).BlockID.Hash, block.Hash())
+	}
+	assert.Equal(t, nodeSnapshot.Commit.Hash(), restored.LoadSeenCommit(12).Hash())
+}
+
+func TestSyncer_FetchNodeSnapshot(t *testing.T) {
+	stateStore, blockStore, _ := makeNodeSnapshotChain(t, 8)
+	nodeSnapshots, err := NewNodeSnapshotStore(t.TempDir())
+	require.NoError(t, err)
+	nodeSnapshot, err := MakeNodeSnapshot(stateStore, blockStore, 6)
+	require.NoError(t, err)
+	require.NoError(t, nodeSnapshots.Save(nodeSnapshot))
+	listed, err := nodeSnapshots.List()
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	good := *listed[0]
+	bad := good
+	bad.Hash = []byte("bad")
+
+	stateProvider := &mocks.StateProvider{}
+	stateProvider.On("Commit", mock.Anything, uint64(7)).Return(blockStore.LoadBlockCommit(7), nil)
+	cfg := config.DefaultStateSyncConfig()
+	syncer := newSyncer(*cfg, log.NewNopLogger(), nil, nil, stateProvider, "")
+
+	// The peers serve node snapshot chunks from the store.
+	onChunkRequest := func(args mock.Arguments) {
+		msg := args[0].(p2p.Envelope).Message.(*ssproto.ChunkRequest)
+		bz, err := nodeSnapshots.LoadChunk(msg.Height, msg.Index)
+		require.NoError(t, err)
+		_, err = syncer.AddNodeChunk(&chunk{Height: msg.Height, Format: msg.Format, Index: msg.Index, Chunk: bz})
+		require.NoError(t, err)
+	}
+	for id, s := range map[p2p.ID]snapshot{"a": good, "b": bad} {
+		peer := &p2pmocks.Peer{}
+		peer.On("ID").Return(id)
+		peer.On("Send", mock.MatchedBy(func(i any) bool {
+			e, ok := i.(p2p.Envelope)
+			return ok && e.ChannelID == NodeChunkChannel
+		})).Maybe().Run(onChunkRequest).Return(nil)
+		_, err := syncer.AddNodeSnapshot(peer, &s)
+		require.NoError(t, err)
+	}
+	_, err = syncer.AddNodeSnapshot(&p2pmocks.Peer{}, &snapshot{Height: 6, Format: 2, Chunks: 1, Hash: []byte{1}})
+	require.Error(t, err)
+
+	// There is no node snapshot at other heights.
+	fetched, err := syncer.fetchNodeSnapshot(context.Background(), &snapshot{Height: 5})
+	require.NoError(t, err)
+	assert.Nil(t, fetched)
+
+	// The bad node snapshot is rejected if tried, and the good one is verified.
+	fetched, err = syncer.fetchNodeSnapshot(context.Background(), &snapshot{Height: 6, trustedAppHash: nodeSnapshot.State.AppHash})
+	require.NoError(t, err)
+	require.NotNil(t, fetched)
+	assert.Equal(t, nodeSnapshot.Commit.Hash(), fetched.Commit.Hash())
+	assert.Len(t, fetched.Blocks, 6)
+
+	// Node snapshots whose app hash does not match the trusted one are rejected.
+	fetched, err = syncer.fetchNodeSnapshot(context.Background(), &snapshot{Height: 6, trustedAppHash: []byte("other")})
+	require.NoError(t, err)
+	assert.Nil(t, fetched)
+	assert.Empty(t, syncer.nodeSnapshots.Ranked())
+}
@@ -0,0 +1,107 @@
+package statesync
+
+import (
+	"time"
+
+	"github.com/cometbft/cometbft/v2/libs/log"
+	"github.com/cometbft/cometbft/v2/libs/service"
+	sm "github.com/cometbft/cometbft/v2/state"
+)
+
+// nodeSnapshotterInterval is the interval at which the node snapshotter checks
+// whether a new node snapshot can be taken.
+const nodeSnapshotterInterval = time.Second
+
+// NodeSnapshotter is a service, which takes node snapshots at the heights that
+// are multiples of the configured interval, and prunes the old ones. The node
+// snapshot at height H is taken once the block at height H+2 is committed.
+type NodeSnapshotter struct {
+	service.BaseService
+
+	store      *NodeSnapshotStore
+	stateStore sm.Store
+	blockStore sm.BlockStore
+	interval   int64
+	keepRecent int
+
+	// lastHeight is the height of the last node snapshot taken, or skipped.
+	lastHeight int64
+}
+
+// NewNodeSnapshotter creates a service, which takes node snapshots every
+// interval blocks, and keeps the keepRecent most recent ones in the store.
+func NewNodeSnapshotter(
+	store *NodeSnapshotStore,
+	stateStore sm.Store,
+	blockStore sm.BlockStore,
+	interval int64,
+	keepRecent int,
+	logger log.Logger,
+) *NodeSnapshotter {
+	s := &NodeSnapshotter{
+		store:      store,
+		stateStore: stateStore,
+		blockStore: blockStore,
+		interval:   interval,
+		keepRecent: keepRecent,
+	}
+	s.BaseService = *service.NewBaseService(logger, "NodeSnapshotter", s)
+	return s
+}
+
+// OnStart implements service.Service.
+func (s *NodeSnapshotter) OnStart() error {
+	snapshots, err := s.store.List()
+	if err != nil {
+		return err
+	}
+	if len(snapshots) > 0 {
+		s.lastHeight = int64(snapshots[0].Height)
+	}
+	go s.snapshotRoutine()
+	return nil
+}
+
+func (s *NodeSnapshotter) snapshotRoutine() {
+	s.Logger.Info("Started taking node snapshots", "interval", s.interval)
+	ticker := time.NewTicker(nodeSnapshotterInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.Quit():
+			return
+		case <-ticker.C:
+			s.takeSnapshot()
+		}
+	}
+}
+
+// takeSnapshot takes the node snapshot at the most recent height, which is a
+// multiple of the interval and whose data is available in the stores. While
+// the node is catching up, intermediate heights are skipped.
+func (s *NodeSnapshotter) takeSnapshot() {
+	height := (s.blockStore.Height() - 2) / s.interval * s.interval
+	if height <= 0 || height <= s.lastHeight {
+		return
+	}
+	s.lastHeight = height
+	if height < s.blockStore.Base() {
+		s.Logger.Debug("Skipping node snapshot of pruned height", "height", height)
+		return
+	}
+
+	nodeSnapshot, err := MakeNodeSnapshot(s.stateStore, s.blockStore, height)
+	if err != nil {
+		s.Logger.Error("Failed to make node snapshot", "height", height, "err", err)
+		return
+	}
+	if err := s.store.Save(nodeSnapshot); err != nil {
+		s.Logger.Error("Failed to save node snapshot", "height", height, "err", err)
+		return
+	}
+	s.Logger.Info("Took node snapshot", "height", height, "blocks", len(nodeSnapshot.Blocks))
+
+	if err := s.store.Prune(s.keepRecent); err != nil {
+		s.Logger.Error("Failed to prune node snapshots", "err", err)
+	}
+}
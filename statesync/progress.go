@@ -0,0 +1,95 @@
+package statesync
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/cometbft/cometbft/v2/internal/tempfile"
+	cmtbytes "github.com/cometbft/cometbft/v2/libs/bytes"
+)
+
+const (
+	// syncProgressFile is the file the progress of a snapshot restoration is
+	// persisted in, within the progress directory.
+	syncProgressFile = "progress.json"
+	// syncProgressChunksDir is the directory the chunks of the snapshot being
+	// restored are persisted in, within the progress directory.
+	syncProgressChunksDir = "chunks"
+)
+
+// syncProgress is the progress of a snapshot restoration, persisted so that an
+// interrupted restoration is resumed after a restart instead of starting over.
+// As the app restarts the restoration when the snapshot is offered again, all
+// the chunks are applied again on resumption, from the chunks persisted along
+// with the progress.
+type syncProgress struct {
+	Height         uint64            `json:"height"`
+	Format         uint32            `json:"format"`
+	Chunks         uint32            `json:"chunks"`
+	Hash           cmtbytes.HexBytes `json:"hash"`
+	Metadata       []byte            `json:"metadata,omitempty"`
+	TrustedAppHash cmtbytes.HexBytes `json:"trusted_app_hash"`
+}
+
+// newSyncProgress creates the progress of the restoration of a snapshot,
+// whose app hash has been verified.
+func newSyncProgress(snapshot *snapshot) *syncProgress {
+	return &syncProgress{
+		Height:         snapshot.Height,
+		Format:         snapshot.Format,
+		Chunks:         snapshot.Chunks,
+		Hash:           snapshot.Hash,
+		Metadata:       snapshot.Metadata,
+		TrustedAppHash: snapshot.trustedAppHash,
+	}
+}
+
+// Snapshot returns the snapshot being restored, along with its verified app
+// hash.
+func (p *syncProgress) Snapshot() *snapshot {
+	return &snapshot{
+		Height:         p.Height,
+		Format:         p.Format,
+		Chunks:         p.Chunks,
+		Hash:           p.Hash,
+		Metadata:       p.Metadata,
+		trustedAppHash: p.TrustedAppHash,
+	}
+}
+
+// loadSyncProgress loads the progress persisted in a directory, or nil if there
+// is none.
+func loadSyncProgress(dir string) (*syncProgress, error) {
+	bz, err := os.ReadFile(filepath.Join(dir, syncProgressFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read state sync progress: %w", err)
+	}
+	var progress syncProgress
+	if err := json.Unmarshal(bz, &progress); err != nil {
+		return nil, fmt.Errorf("failed to parse state sync progress: %w", err)
+	}
+	if progress.Chunks == 0 {
+		return nil, errors.New("state sync progress has no chunks")
+	}
+	return &progress, nil
+}
+
+// saveSyncProgress persists the progress in a directory.
+func saveSyncProgress(dir string, progress *syncProgress) error {
+	bz, err := json.Marshal(progress)
+	if err != nil {
+		return fmt.Errorf("failed to encode state sync progress: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create state sync progress directory: %w", err)
+	}
+	if err := tempfile.WriteFileAtomic(filepath.Join(dir, syncProgressFile), bz, 0o600); err != nil {
+		return fmt.Errorf("failed to write state sync progress: %w", err)
+	}
+	return nil
+}
@@ -5,14 +5,19 @@ package statesync
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sort"
 	"time"
 
+	cmtstate "github.com/cometbft/cometbft/api/cometbft/state/v2"
 	ssproto "github.com/cometbft/cometbft/api/cometbft/statesync/v1"
+	cmtproto "github.com/cometbft/cometbft/api/cometbft/types/v2"
 	abci "github.com/cometbft/cometbft/v2/abci/types"
 	"github.com/cometbft/cometbft/v2/config"
 	cmtsync "github.com/cometbft/cometbft/v2/libs/sync"
+	"github.com/cometbft/cometbft/v2/light"
 	"github.com/cometbft/cometbft/v2/p2p"
+	"github.com/cometbft/cometbft/v2/p2p/reputation"
 	tcpconn "github.com/cometbft/cometbft/v2/p2p/transport/tcp/conn"
 	"github.com/cometbft/cometbft/v2/proxy"
 	sm "github.com/cometbft/cometbft/v2/state"
@@ -24,6 +29,13 @@ const (
 	SnapshotChannel = byte(0x60)
 	// ChunkChannel exchanges chunk contents.
 	ChunkChannel = byte(0x61)
+	// NodeSnapshotChannel exchanges node snapshot metadata.
+	NodeSnapshotChannel = byte(0x62)
+	// NodeChunkChannel exchanges node snapshot chunk contents.
+	NodeChunkChannel = byte(0x63)
+	// LightBlockChannel exchanges light blocks and consensus params, for light
+	// client verification of the synced state without RPC servers.
+	LightBlockChannel = byte(0x64)
 	// recentSnapshots is the number of recent snapshots to send and receive per peer.
 	recentSnapshots = 10
 )
@@ -39,10 +51,50 @@ type Reactor struct {
 	tempDir   string
 	metrics   *Metrics
 
+	// The node snapshots served to peers, nil if the node does not take node
+	// snapshots.
+	nodeSnapshots *NodeSnapshotStore
+	// The directory the progress of a state sync is persisted in, empty if it
+	// is not persisted.
+	progressDir string
+	// The stores the light blocks and consensus params served to peers are
+	// loaded from, nil if the node does not serve them.
+	stateStore sm.Store
+	blockStore sm.BlockStore
+	// dispatcher routes the light block and consensus params responses to the
+	// p2p state provider.
+	dispatcher *dispatcher
+
 	// This will only be set when a state sync is in progress. It is used to feed received
 	// snapshots and chunks into the sync.
-	mtx    cmtsync.RWMutex
-	syncer *syncer
+	mtx           cmtsync.RWMutex
+	syncer        *syncer
+	stateProvider *p2pStateProvider
+}
+
+// ReactorOption sets an optional parameter on the Reactor.
+type ReactorOption func(*Reactor)
+
+// WithNodeSnapshotStore sets the store of the node snapshots, which the
+// reactor serves to peers.
+func WithNodeSnapshotStore(store *NodeSnapshotStore) ReactorOption {
+	return func(r *Reactor) { r.nodeSnapshots = store }
+}
+
+// WithProgressDir sets the directory the progress of a state sync and the
+// chunks of its snapshot are persisted in, so that a state sync interrupted by
+// a restart is resumed instead of started over.
+func WithProgressDir(dir string) ReactorOption {
+	return func(r *Reactor) { r.progressDir = dir }
+}
+
+// WithStores sets the stores the light blocks and consensus params served to
+// peers are loaded from, for peers state syncing without RPC servers.
+func WithStores(stateStore sm.Store, blockStore sm.BlockStore) ReactorOption {
+	return func(r *Reactor) {
+		r.stateStore = stateStore
+		r.blockStore = blockStore
+	}
 }
 
 // NewReactor creates a new state sync reactor.
@@ -51,14 +103,19 @@ func NewReactor(
 	conn proxy.AppConnSnapshot,
 	connQuery proxy.AppConnQuery,
 	metrics *Metrics,
+	options ...ReactorOption,
 ) *Reactor {
 	r := &Reactor{
-		cfg:       cfg,
-		conn:      conn,
-		connQuery: connQuery,
-		metrics:   metrics,
+		cfg:        cfg,
+		conn:       conn,
+		connQuery:  connQuery,
+		metrics:    metrics,
+		dispatcher: newDispatcher(),
 	}
 	r.BaseReactor = *p2p.NewBaseReactor("StateSync", r)
+	for _, option := range options {
+		option(r)
+	}
 
 	return r
 }
@@ -80,6 +137,27 @@ func (*Reactor) StreamDescriptors() []p2p.StreamDescriptor {
 			RecvMessageCapacity: chunkMsgSize,
 			MessageTypeI:        &ssproto.Message{},
 		},
+		tcpconn.StreamDescriptor{
+			ID:                  NodeSnapshotChannel,
+			Priority:            5,
+			SendQueueCapacity:   10,
+			RecvMessageCapacity: snapshotMsgSize,
+			MessageTypeI:        &ssproto.Message{},
+		},
+		tcpconn.StreamDescriptor{
+			ID:                  NodeChunkChannel,
+			Priority:            3,
+			SendQueueCapacity:   10,
+			RecvMessageCapacity: chunkMsgSize,
+			MessageTypeI:        &ssproto.Message{},
+		},
+		tcpconn.StreamDescriptor{
+			ID:                  LightBlockChannel,
+			Priority:            5,
+			SendQueueCapacity:   10,
+			RecvMessageCapacity: lightBlockMsgSize,
+			MessageTypeI:        &ssproto.Message{},
+		},
 	}
 }
 
@@ -95,6 +173,9 @@ func (r *Reactor) AddPeer(peer p2p.Peer) {
 	if r.syncer != nil {
 		r.syncer.AddPeer(peer)
 	}
+	if r.stateProvider != nil && peer.HasChannel(LightBlockChannel) {
+		r.stateProvider.addPeer(peer)
+	}
 }
 
 // RemovePeer implements p2p.Reactor.
@@ -104,6 +185,9 @@ func (r *Reactor) RemovePeer(peer p2p.Peer, _ any) {
 	if r.syncer != nil {
 		r.syncer.RemovePeer(peer)
 	}
+	if r.stateProvider != nil {
+		r.stateProvider.removePeer(peer)
+	}
 }
 
 // Receive implements p2p.Reactor.
@@ -115,7 +199,7 @@ func (r *Reactor) Receive(e p2p.Envelope) {
 	err := validateMsg(e.Message)
 	if err != nil {
 		r.Logger.Error("Invalid message", "peer", e.Src, "msg", e.Message, "err", err)
-		r.Switch.StopPeerForError(e.Src, err)
+		r.Switch.StopPeerForBehaviour(e.Src, reputation.BadMessage, err)
 		return
 	}
 
@@ -206,7 +290,7 @@ func (r *Reactor) Receive(e p2p.Envelope) {
 			}
 			r.Logger.Debug("Received chunk, adding to sync", "height", msg.Height, "format", msg.Format,
 				"chunk", msg.Index, "peer", e.Src.ID())
-			_, err := r.syncer.AddChunk(&chunk{
+			added, err := r.syncer.AddChunk(&chunk{
 				Height: msg.Height,
 				Format: msg.Format,
 				Index:  msg.Index,
@@ -218,6 +302,178 @@ func (r *Reactor) Receive(e p2p.Envelope) {
 					"chunk", msg.Index, "err", err)
 				return
 			}
+			if added && msg.Chunk != nil {
+				r.Switch.ReportBehaviour(e.Src, reputation.SnapshotChunk)
+			}
+
+		default:
+			r.Logger.Error("Received unknown message %T", msg)
+		}
+
+	case NodeSnapshotChannel:
+		switch msg := e.Message.(type) {
+		case *ssproto.SnapshotsRequest:
+			if r.nodeSnapshots == nil {
+				return
+			}
+			snapshots, err := r.nodeSnapshots.List()
+			if err != nil {
+				r.Logger.Error("Failed to list node snapshots", "err", err)
+				return
+			}
+			for i, snapshot := range snapshots {
+				if i >= recentSnapshots {
+					break
+				}
+				r.Logger.Debug("Advertising node snapshot", "height", snapshot.Height, "peer", e.Src.ID())
+				_ = e.Src.Send(p2p.Envelope{
+					ChannelID: e.ChannelID,
+					Message: &ssproto.SnapshotsResponse{
+						Height: snapshot.Height,
+						Format: snapshot.Format,
+						Chunks: snapshot.Chunks,
+						Hash:   snapshot.Hash,
+					},
+				})
+			}
+
+		case *ssproto.SnapshotsResponse:
+			r.mtx.RLock()
+			defer r.mtx.RUnlock()
+			if r.syncer == nil {
+				r.Logger.Debug("Received unexpected node snapshot, no state sync in progress")
+				return
+			}
+			r.Logger.Debug("Received node snapshot", "height", msg.Height, "format", msg.Format, "peer", e.Src.ID())
+			_, err := r.syncer.AddNodeSnapshot(e.Src, &snapshot{
+				Height:   msg.Height,
+				Format:   msg.Format,
+				Chunks:   msg.Chunks,
+				Hash:     msg.Hash,
+				Metadata: msg.Metadata,
+			})
+			if err != nil {
+				r.Logger.Error("Failed to add node snapshot", "height", msg.Height, "format", msg.Format,
+					"peer", e.Src.ID(), "err", err)
+				return
+			}
+
+		default:
+			r.Logger.Error("Received unknown message %T", msg)
+		}
+
+	case NodeChunkChannel:
+		switch msg := e.Message.(type) {
+		case *ssproto.ChunkRequest:
+			var chunk []byte
+			if r.nodeSnapshots != nil && msg.Format == nodeSnapshotFormat {
+				var err error
+				chunk, err = r.nodeSnapshots.LoadChunk(msg.Height, msg.Index)
+				if err != nil {
+					r.Logger.Error("Failed to load node snapshot chunk", "height", msg.Height,
+						"chunk", msg.Index, "err", err)
+					return
+				}
+			}
+			r.Logger.Debug("Sending node snapshot chunk", "height", msg.Height, "chunk", msg.Index,
+				"peer", e.Src.ID())
+			_ = e.Src.Send(p2p.Envelope{
+				ChannelID: NodeChunkChannel,
+				Message: &ssproto.ChunkResponse{
+					Height:  msg.Height,
+					Format:  msg.Format,
+					Index:   msg.Index,
+					Chunk:   chunk,
+					Missing: chunk == nil,
+				},
+			})
+
+		case *ssproto.ChunkResponse:
+			r.mtx.RLock()
+			defer r.mtx.RUnlock()
+			if r.syncer == nil {
+				r.Logger.Debug("Received unexpected node snapshot chunk, no state sync in progress", "peer", e.Src.ID())
+				return
+			}
+			r.Logger.Debug("Received node snapshot chunk", "height", msg.Height, "chunk", msg.Index,
+				"peer", e.Src.ID())
+			_, err := r.syncer.AddNodeChunk(&chunk{
+				Height: msg.Height,
+				Format: msg.Format,
+				Index:  msg.Index,
+				Chunk:  msg.Chunk,
+				Sender: e.Src.ID(),
+			})
+			if err != nil {
+				r.Logger.Error("Failed to add node snapshot chunk", "height", msg.Height,
+					"chunk", msg.Index, "err", err)
+				return
+			}
+
+		default:
+			r.Logger.Error("Received unknown message %T", msg)
+		}
+
+	case LightBlockChannel:
+		switch msg := e.Message.(type) {
+		case *ssproto.LightBlockRequest:
+			lightBlock, err := r.loadLightBlock(int64(msg.Height))
+			if err != nil {
+				r.Logger.Debug("Failed to load light block", "height", msg.Height, "err", err)
+			}
+			var pbLightBlock *cmtproto.LightBlock
+			if lightBlock != nil {
+				pbLightBlock, err = lightBlock.ToProto()
+				if err != nil {
+					r.Logger.Error("Failed to convert light block to proto", "height", msg.Height, "err", err)
+					return
+				}
+			}
+			r.Logger.Debug("Sending light block", "height", msg.Height, "found", lightBlock != nil,
+				"peer", e.Src.ID())
+			_ = e.Src.Send(p2p.Envelope{
+				ChannelID: LightBlockChannel,
+				Message:   &ssproto.LightBlockResponse{LightBlock: pbLightBlock},
+			})
+
+		case *ssproto.LightBlockResponse:
+			var lightBlock *types.LightBlock
+			if msg.LightBlock != nil {
+				var err error
+				lightBlock, err = types.LightBlockFromProto(msg.LightBlock)
+				if err != nil {
+					r.Logger.Error("Invalid light block", "peer", e.Src, "err", err)
+					r.Switch.StopPeerForBehaviour(e.Src, reputation.BadMessage, err)
+					return
+				}
+			}
+			if !r.dispatcher.RespondLightBlock(e.Src.ID(), lightBlock) {
+				r.Logger.Debug("Received unexpected light block", "peer", e.Src.ID())
+			}
+
+		case *ssproto.ParamsRequest:
+			if r.stateStore == nil {
+				return
+			}
+			params, err := r.stateStore.LoadConsensusParams(int64(msg.Height))
+			if err != nil {
+				r.Logger.Debug("Failed to load consensus params", "height", msg.Height, "err", err)
+				return
+			}
+			r.Logger.Debug("Sending consensus params", "height", msg.Height, "peer", e.Src.ID())
+			_ = e.Src.Send(p2p.Envelope{
+				ChannelID: LightBlockChannel,
+				Message: &ssproto.ParamsResponse{
+					Height:          msg.Height,
+					ConsensusParams: params.ToProto(),
+				},
+			})
+
+		case *ssproto.ParamsResponse:
+			params := types.ConsensusParamsFromProto(msg.ConsensusParams)
+			if !r.dispatcher.RespondConsensusParams(e.Src.ID(), int64(msg.Height), params) {
+				r.Logger.Debug("Received unexpected consensus params", "peer", e.Src.ID())
+			}
 
 		default:
 			r.Logger.Error("Received unknown message %T", msg)
@@ -228,6 +484,37 @@ func (r *Reactor) Receive(e p2p.Envelope) {
 	}
 }
 
+// loadLightBlock loads the light block at the given height from the stores, or the latest one if
+// the height is 0. It returns nil if the stores do not have it.
+func (r *Reactor) loadLightBlock(height int64) (*types.LightBlock, error) {
+	if r.stateStore == nil || r.blockStore == nil {
+		return nil, nil
+	}
+	if height == 0 {
+		height = r.blockStore.Height()
+	}
+	meta := r.blockStore.LoadBlockMeta(height)
+	if meta == nil {
+		return nil, nil
+	}
+	// The commit of the latest block is only available as the seen commit.
+	commit := r.blockStore.LoadBlockCommit(height)
+	if commit == nil {
+		commit = r.blockStore.LoadSeenCommit(height)
+	}
+	if commit == nil {
+		return nil, nil
+	}
+	validators, err := r.stateStore.LoadValidators(height)
+	if err != nil {
+		return nil, err
+	}
+	return &types.LightBlock{
+		SignedHeader: &types.SignedHeader{Header: &meta.Header, Commit: commit},
+		ValidatorSet: validators,
+	}, nil
+}
+
 // recentSnapshots fetches the n most recent snapshots from the app.
 func (r *Reactor) recentSnapshots(n uint32) ([]*snapshot, error) {
 	resp, err := r.conn.ListSnapshots(context.TODO(), &abci.ListSnapshotsRequest{})
@@ -262,16 +549,67 @@ func (r *Reactor) recentSnapshots(n uint32) ([]*snapshot, error) {
 	return snapshots, nil
 }
 
-// Sync runs a state sync, returning the new state and last commit at the snapshot height.
-// The caller must store the state and commit in the state database and block store.
-func (r *Reactor) Sync(stateProvider StateProvider, maxDiscoveryTime time.Duration) (sm.State, *types.Commit, error) {
+// NewP2PStateProvider creates a state provider, which verifies the light blocks, commits and
+// consensus params fetched from peers with a light client. It waits for at least 2 peers to be
+// connected, the first one acting as the primary and the others as witnesses, and adds the peers
+// connecting later on as witnesses until the state sync is over.
+func (r *Reactor) NewP2PStateProvider(
+	ctx context.Context,
+	chainID string,
+	version cmtstate.Version,
+	initialHeight int64,
+	trustOptions light.TrustOptions,
+	dbKeyLayoutVersion string,
+) (StateProvider, error) {
+	peers := r.lightBlockPeers()
+	for len(peers) < 2 {
+		r.Logger.Info("Waiting for peers to fetch light blocks from", "peers", len(peers))
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("not enough peers to fetch light blocks from: %w", ctx.Err())
+		case <-time.After(time.Second):
+		}
+		peers = r.lightBlockPeers()
+	}
+
+	stateProvider, err := newP2PStateProvider(ctx, chainID, version, initialHeight, peers, r.dispatcher,
+		trustOptions, r.Logger.With("module", "light"), dbKeyLayoutVersion)
+	if err != nil {
+		return nil, err
+	}
+	r.mtx.Lock()
+	r.stateProvider = stateProvider
+	r.mtx.Unlock()
+	// Peers may have connected while the light client was being set up.
+	for _, peer := range r.lightBlockPeers() {
+		stateProvider.addPeer(peer)
+	}
+	return stateProvider, nil
+}
+
+// lightBlockPeers returns the connected peers serving light blocks.
+func (r *Reactor) lightBlockPeers() []p2p.Peer {
+	var peers []p2p.Peer
+	r.Switch.Peers().ForEach(func(peer p2p.Peer) {
+		if peer.HasChannel(LightBlockChannel) {
+			peers = append(peers, peer)
+		}
+	})
+	return peers
+}
+
+// Sync runs a state sync, returning the new state and last commit at the snapshot height, along
+// with the recent blocks up to the snapshot height if they were restored from a node snapshot. The
+// caller must store the state, blocks and commit in the state database and block store.
+func (r *Reactor) Sync(stateProvider StateProvider, maxDiscoveryTime time.Duration) (sm.State, *types.Commit, []*types.Block, error) {
 	r.mtx.Lock()
 	if r.syncer != nil {
 		r.mtx.Unlock()
-		return sm.State{}, nil, errors.New("a state sync is already in progress")
+		return sm.State{}, nil, nil, errors.New("a state sync is already in progress")
 	}
 	r.metrics.Syncing.Set(1)
 	r.syncer = newSyncer(r.cfg, r.Logger, r.conn, r.connQuery, stateProvider, r.tempDir)
+	r.syncer.progressDir = r.progressDir
 	r.mtx.Unlock()
 
 	hook := func() {
@@ -282,16 +620,21 @@ func (r *Reactor) Sync(stateProvider StateProvider, maxDiscoveryTime time.Durati
 			ChannelID: SnapshotChannel,
 			Message:   &ssproto.SnapshotsRequest{},
 		})
+		r.Switch.Broadcast(p2p.Envelope{
+			ChannelID: NodeSnapshotChannel,
+			Message:   &ssproto.SnapshotsRequest{},
+		})
 	}
 
 	hook()
 
 	const discoveryTime = 5 * time.Second
-	state, commit, err := r.syncer.SyncAny(discoveryTime, maxDiscoveryTime, hook)
+	state, commit, blocks, err := r.syncer.SyncAny(discoveryTime, maxDiscoveryTime, hook)
 
 	r.mtx.Lock()
 	r.syncer = nil
+	r.stateProvider = nil
 	r.metrics.Syncing.Set(0)
 	r.mtx.Unlock()
-	return state, commit, err
+	return state, commit, blocks, err
 }
@@ -1,6 +1,7 @@
 package statesync
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -15,6 +16,7 @@ import (
 	lighthttp "github.com/cometbft/cometbft/v2/light/provider/http"
 	lightrpc "github.com/cometbft/cometbft/v2/light/rpc"
 	lightdb "github.com/cometbft/cometbft/v2/light/store/db"
+	"github.com/cometbft/cometbft/v2/p2p"
 	rpchttp "github.com/cometbft/cometbft/v2/rpc/client/http"
 	sm "github.com/cometbft/cometbft/v2/state"
 	"github.com/cometbft/cometbft/v2/types"
@@ -140,6 +142,36 @@ func (s *lightClientStateProvider) State(ctx context.Context, height uint64) (sm
 	s.Lock()
 	defer s.Unlock()
 
+	state, currentLightBlock, err := s.lightState(ctx, height)
+	if err != nil {
+		return sm.State{}, err
+	}
+
+	// We'll also need to fetch consensus params via RPC, using light client verification.
+	primaryURL, ok := s.providers[s.lc.Primary()]
+	if !ok || primaryURL == "" {
+		return sm.State{}, errors.New("could not find address for primary light client provider")
+	}
+	primaryRPC, err := rpcClient(primaryURL)
+	if err != nil {
+		return sm.State{}, fmt.Errorf("unable to create RPC client: %w", err)
+	}
+	rpcclient := lightrpc.NewClient(primaryRPC, s.lc)
+	result, err := rpcclient.ConsensusParams(ctx, &currentLightBlock.Height)
+	if err != nil {
+		return sm.State{}, fmt.Errorf("unable to fetch consensus parameters for height %v: %w",
+			currentLightBlock.Height, err)
+	}
+	state.ConsensusParams = result.ConsensusParams
+	state.LastHeightConsensusParamsChanged = currentLightBlock.Height
+
+	return state, nil
+}
+
+// lightState builds the state at the given height from the light blocks verified by the light
+// client, without the consensus params. It also returns the light block at the following height,
+// whose header commits to the consensus params. The caller must hold the lock.
+func (s *lightClientStateProvider) lightState(ctx context.Context, height uint64) (sm.State, *types.LightBlock, error) {
 	state := sm.State{
 		ChainID:       s.lc.ChainID(),
 		Version:       s.version,
@@ -159,15 +191,15 @@ func (s *lightClientStateProvider) State(ctx context.Context, height uint64) (sm
 	// the validator set at the snapshot height then this only takes effect at height+2.
 	lastLightBlock, err := s.lc.VerifyLightBlockAtHeight(ctx, int64(height), cmttime.Now())
 	if err != nil {
-		return sm.State{}, err
+		return sm.State{}, nil, err
 	}
 	currentLightBlock, err := s.lc.VerifyLightBlockAtHeight(ctx, int64(height+1), cmttime.Now())
 	if err != nil {
-		return sm.State{}, err
+		return sm.State{}, nil, err
 	}
 	nextLightBlock, err := s.lc.VerifyLightBlockAtHeight(ctx, int64(height+2), cmttime.Now())
 	if err != nil {
-		return sm.State{}, err
+		return sm.State{}, nil, err
 	}
 
 	state.Version = cmtstate.Version{
@@ -184,27 +216,137 @@ func (s *lightClientStateProvider) State(ctx context.Context, height uint64) (sm
 	state.NextValidators = nextLightBlock.ValidatorSet
 	state.LastHeightValidatorsChanged = nextLightBlock.Height
 
-	// We'll also need to fetch consensus params via RPC, using light client verification.
-	primaryURL, ok := s.providers[s.lc.Primary()]
-	if !ok || primaryURL == "" {
-		return sm.State{}, errors.New("could not find address for primary light client provider")
+	return state, currentLightBlock, nil
+}
+
+// p2pStateProvider is a state provider using the light client, with the light blocks and
+// consensus params fetched from peers.
+type p2pStateProvider struct {
+	*lightClientStateProvider
+	chainID    string
+	dispatcher *dispatcher
+
+	providersMtx cmtsync.Mutex
+	providers    map[p2p.ID]*blockProvider
+}
+
+// newP2PStateProvider creates a state provider fetching light blocks from the given peers, the
+// first one being the primary and the others witnesses. At least 2 peers are required.
+func newP2PStateProvider(
+	ctx context.Context,
+	chainID string,
+	version cmtstate.Version,
+	initialHeight int64,
+	peers []p2p.Peer,
+	dispatcher *dispatcher,
+	trustOptions light.TrustOptions,
+	logger log.Logger,
+	dbKeyLayoutVersion string,
+) (*p2pStateProvider, error) {
+	if len(peers) < 2 {
+		return nil, fmt.Errorf("at least 2 peers are required, got %v", len(peers))
 	}
-	primaryRPC, err := rpcClient(primaryURL)
+
+	providers := make([]lightprovider.Provider, 0, len(peers))
+	peerProviders := make(map[p2p.ID]*blockProvider, len(peers))
+	for _, peer := range peers {
+		provider := newBlockProvider(chainID, peer, dispatcher)
+		providers = append(providers, provider)
+		peerProviders[peer.ID()] = provider
+	}
+
+	lc, err := light.NewClient(ctx, chainID, trustOptions, providers[0], providers[1:],
+		lightdb.NewWithDBVersion(dbm.NewMemDB(), "", dbKeyLayoutVersion), light.Logger(logger), light.MaxRetryAttempts(5))
 	if err != nil {
-		return sm.State{}, fmt.Errorf("unable to create RPC client: %w", err)
+		return nil, err
 	}
-	rpcclient := lightrpc.NewClient(primaryRPC, s.lc)
-	result, err := rpcclient.ConsensusParams(ctx, &currentLightBlock.Height)
+	return &p2pStateProvider{
+		lightClientStateProvider: &lightClientStateProvider{
+			lc:            lc,
+			version:       version,
+			initialHeight: initialHeight,
+		},
+		chainID:    chainID,
+		dispatcher: dispatcher,
+		providers:  peerProviders,
+	}, nil
+}
+
+// addPeer adds a peer as a witness of the light client, unless it already is a provider.
+func (s *p2pStateProvider) addPeer(peer p2p.Peer) {
+	s.providersMtx.Lock()
+	defer s.providersMtx.Unlock()
+	if _, ok := s.providers[peer.ID()]; ok {
+		return
+	}
+	provider := newBlockProvider(s.chainID, peer, s.dispatcher)
+	s.providers[peer.ID()] = provider
+	s.lc.AddWitness(provider)
+}
+
+// removePeer removes the provider of a disconnected peer from the light client witnesses, so that
+// the peer is added again with a new provider if it reconnects. A primary is replaced by the light
+// client once it fails to respond.
+func (s *p2pStateProvider) removePeer(peer p2p.Peer) {
+	s.providersMtx.Lock()
+	defer s.providersMtx.Unlock()
+	provider, ok := s.providers[peer.ID()]
+	if !ok {
+		return
+	}
+	delete(s.providers, peer.ID())
+	s.lc.RemoveWitness(provider)
+}
+
+// State implements StateProvider.
+func (s *p2pStateProvider) State(ctx context.Context, height uint64) (sm.State, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	state, currentLightBlock, err := s.lightState(ctx, height)
 	if err != nil {
-		return sm.State{}, fmt.Errorf("unable to fetch consensus parameters for height %v: %w",
-			nextLightBlock.Height, err)
+		return sm.State{}, err
 	}
-	state.ConsensusParams = result.ConsensusParams
+	params, err := s.consensusParams(ctx, currentLightBlock)
+	if err != nil {
+		return sm.State{}, err
+	}
+	state.ConsensusParams = params
 	state.LastHeightConsensusParamsChanged = currentLightBlock.Height
 
 	return state, nil
 }
 
+// consensusParams fetches the consensus params at the height of a verified light block from the
+// light client providers, primary first, and verifies them against its header.
+func (s *p2pStateProvider) consensusParams(ctx context.Context, lightBlock *types.LightBlock) (types.ConsensusParams, error) {
+	providers := append([]lightprovider.Provider{s.lc.Primary()}, s.lc.Witnesses()...)
+	err := errors.New("no peers")
+	for _, provider := range providers {
+		provider, ok := provider.(*blockProvider)
+		if !ok {
+			continue
+		}
+		var params types.ConsensusParams
+		pctx, cancel := context.WithTimeout(ctx, lightBlockResponseTimeout)
+		params, err = s.dispatcher.ConsensusParams(pctx, provider.peer, lightBlock.Height)
+		cancel()
+		if err == nil && !bytes.Equal(params.Hash(), lightBlock.ConsensusHash) {
+			err = fmt.Errorf("consensus params hash %X does not match header %X",
+				params.Hash(), lightBlock.ConsensusHash)
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return types.ConsensusParams{}, ctx.Err()
+			}
+			continue
+		}
+		return params, nil
+	}
+	return types.ConsensusParams{}, fmt.Errorf("unable to fetch consensus parameters for height %v from any peer: %w",
+		lightBlock.Height, err)
+}
+
 // rpcClient sets up a new RPC client.
 func rpcClient(server string) (*rpchttp.HTTP, error) {
 	if !strings.Contains(server, "://") {
@@ -3,8 +3,11 @@ package statesync
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"time"
 
 	ssproto "github.com/cometbft/cometbft/api/cometbft/statesync/v1"
@@ -17,6 +20,7 @@ import (
 	"github.com/cometbft/cometbft/v2/proxy"
 	sm "github.com/cometbft/cometbft/v2/state"
 	"github.com/cometbft/cometbft/v2/types"
+	"github.com/cometbft/cometbft/v2/version"
 )
 
 const (
@@ -45,19 +49,37 @@ var (
 
 // syncer runs a state sync against an ABCI app. Use either SyncAny() to automatically attempt to
 // sync all snapshots in the pool (pausing to discover new ones), or Sync() to sync a specific
-// snapshot. Snapshots and chunks are fed via AddSnapshot() and AddChunk() as appropriate.
+// snapshot. Snapshots and chunks are fed via AddSnapshot() and AddChunk() as appropriate, and
+// node snapshots and their chunks via AddNodeSnapshot() and AddNodeChunk().
 type syncer struct {
 	logger        log.Logger
 	stateProvider StateProvider
 	conn          proxy.AppConnSnapshot
 	connQuery     proxy.AppConnQuery
 	snapshots     *snapshotPool
+	nodeSnapshots *snapshotPool
 	tempDir       string
 	chunkFetchers int32
 	retryTimeout  time.Duration
 
-	mtx    cmtsync.RWMutex
-	chunks *chunkQueue
+	// localDir is the local snapshot directory chunks are loaded from instead of
+	// being requested from peers, when restoring a local snapshot.
+	localDir string
+	// localErr is the error loading a chunk from localDir, which aborts the restoration.
+	localErr error
+
+	// progressDir is the directory the progress of a restoration and the chunks of its snapshot
+	// are persisted in, so that a restoration interrupted by a restart is resumed instead of
+	// started over. It is empty if the progress is not persisted.
+	progressDir string
+	// progress is the progress of the ongoing restoration, if persisted. resume is set when it was
+	// loaded after a restart.
+	progress *syncProgress
+	resume   bool
+
+	mtx        cmtsync.RWMutex
+	chunks     *chunkQueue
+	nodeChunks *chunkQueue
 }
 
 // newSyncer creates a new syncer.
@@ -75,6 +97,7 @@ func newSyncer(
 		conn:          conn,
 		connQuery:     connQuery,
 		snapshots:     newSnapshotPool(),
+		nodeSnapshots: newSnapshotPool(),
 		tempDir:       tempDir,
 		chunkFetchers: cfg.ChunkFetchers,
 		retryTimeout:  cfg.ChunkRequestTimeout,
@@ -117,8 +140,36 @@ func (s *syncer) AddSnapshot(peer p2p.Peer, snapshot *snapshot) (bool, error) {
 	return added, nil
 }
 
+// AddNodeChunk adds a node snapshot chunk to the node chunk queue, if any. It returns false if the
+// chunk has already been added to the queue, or an error if no node snapshot is being fetched.
+func (s *syncer) AddNodeChunk(chunk *chunk) (bool, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	if s.nodeChunks == nil {
+		return false, errors.New("no node snapshot is being fetched")
+	}
+	return s.nodeChunks.Add(chunk)
+}
+
+// AddNodeSnapshot adds a node snapshot to the node snapshot pool. It returns true if a new,
+// previously unseen node snapshot was accepted and added.
+func (s *syncer) AddNodeSnapshot(peer p2p.Peer, snapshot *snapshot) (bool, error) {
+	if snapshot.Format != nodeSnapshotFormat {
+		return false, fmt.Errorf("unsupported node snapshot format %v", snapshot.Format)
+	}
+	added, err := s.nodeSnapshots.Add(peer, snapshot)
+	if err != nil {
+		return false, err
+	}
+	if added {
+		s.logger.Info("Discovered new node snapshot", "height", snapshot.Height,
+			"hash", log.NewLazySprintf("%X", snapshot.Hash))
+	}
+	return added, nil
+}
+
 // AddPeer adds a peer to the pool. For now we just keep it simple and send a single request
-// to discover snapshots, later we may want to do retries and stuff.
+// to discover snapshots and node snapshots, later we may want to do retries and stuff.
 func (s *syncer) AddPeer(peer p2p.Peer) {
 	s.logger.Debug("Requesting snapshots from peer", "peer", peer.ID())
 	e := p2p.Envelope{
@@ -126,22 +177,28 @@ func (s *syncer) AddPeer(peer p2p.Peer) {
 		Message:   &ssproto.SnapshotsRequest{},
 	}
 	_ = peer.Send(e)
+	_ = peer.Send(p2p.Envelope{
+		ChannelID: NodeSnapshotChannel,
+		Message:   &ssproto.SnapshotsRequest{},
+	})
 }
 
 // RemovePeer removes a peer from the pool.
 func (s *syncer) RemovePeer(peer p2p.Peer) {
 	s.logger.Debug("Removing peer from sync", "peer", peer.ID())
 	s.snapshots.RemovePeer(peer.ID())
+	s.nodeSnapshots.RemovePeer(peer.ID())
 }
 
 // SyncAny tries to sync any of the snapshots in the snapshot pool, waiting to
 // discover further snapshots if none were found within discoveryTime. It
 // returns the latest state and block commit which the caller must use to
-// bootstrap the node.
+// bootstrap the node, along with the recent blocks if they were restored from
+// a node snapshot.
 //
 // If none snapshots are found after maxDiscoveryTime, errNoSnapshots is
 // returned.
-func (s *syncer) SyncAny(discoveryTime, maxDiscoveryTime time.Duration, retryHook func()) (sm.State, *types.Commit, error) {
+func (s *syncer) SyncAny(discoveryTime, maxDiscoveryTime time.Duration, retryHook func()) (sm.State, *types.Commit, []*types.Block, error) {
 	timeStart := time.Now()
 
 	s.logger.Info(fmt.Sprintf("Discovering snapshots for %v", discoveryTime))
@@ -154,6 +211,18 @@ func (s *syncer) SyncAny(discoveryTime, maxDiscoveryTime time.Duration, retryHoo
 		chunks   *chunkQueue
 		err      error
 	)
+	if s.progressDir != "" {
+		progress, err := loadSyncProgress(s.progressDir)
+		if err != nil {
+			s.logger.Error("Failed to load state sync progress, starting over", "err", err)
+			s.clearProgress()
+		} else if progress != nil {
+			snapshot = progress.Snapshot()
+			s.progress, s.resume = progress, true
+			s.logger.Info("Resuming snapshot restoration", "height", snapshot.Height, "format", snapshot.Format,
+				"hash", log.NewLazySprintf("%X", snapshot.Hash), "chunks", snapshot.Chunks)
+		}
+	}
 	for {
 		// If not nil, we're going to retry restoration of the same snapshot.
 		if snapshot == nil {
@@ -162,7 +231,7 @@ func (s *syncer) SyncAny(discoveryTime, maxDiscoveryTime time.Duration, retryHoo
 		}
 		if snapshot == nil {
 			if maxDiscoveryTime > 0 && time.Since(timeStart) >= maxDiscoveryTime {
-				return sm.State{}, nil, errNoSnapshots
+				return sm.State{}, nil, nil, errNoSnapshots
 			}
 			retryHook()
 			s.logger.Info("sync any", "msg", fmt.Sprintf("Discovering snapshots for %v", discoveryTime))
@@ -170,20 +239,23 @@ func (s *syncer) SyncAny(discoveryTime, maxDiscoveryTime time.Duration, retryHoo
 			continue
 		}
 		if chunks == nil {
-			chunks, err = newChunkQueue(snapshot, s.tempDir)
+			chunks, err = s.newChunkQueue(snapshot)
 			if err != nil {
-				return sm.State{}, nil, fmt.Errorf("failed to create chunk queue: %w", err)
+				s.clearProgress()
+				return sm.State{}, nil, nil, fmt.Errorf("failed to create chunk queue: %w", err)
 			}
 			defer chunks.Close() // in case we forget to close it elsewhere
 		}
 
-		newState, commit, err := s.Sync(snapshot, chunks)
+		newState, commit, blocks, err := s.Sync(snapshot, chunks)
 		switch {
 		case err == nil:
-			return newState, commit, nil
+			s.clearProgress()
+			return newState, commit, blocks, nil
 
 		case errors.Is(err, errAbort):
-			return sm.State{}, nil, err
+			s.clearProgress()
+			return sm.State{}, nil, nil, err
 
 		case errors.Is(err, errRetrySnapshot):
 			chunks.RetryAll()
@@ -218,7 +290,8 @@ func (s *syncer) SyncAny(discoveryTime, maxDiscoveryTime time.Duration, retryHoo
 			s.snapshots.Reject(snapshot)
 
 		default:
-			return sm.State{}, nil, fmt.Errorf("snapshot restoration failed: %w", err)
+			s.clearProgress()
+			return sm.State{}, nil, nil, fmt.Errorf("snapshot restoration failed: %w", err)
 		}
 
 		// Discard snapshot and chunks for next iteration
@@ -226,18 +299,20 @@ func (s *syncer) SyncAny(discoveryTime, maxDiscoveryTime time.Duration, retryHoo
 		if err != nil {
 			s.logger.Error("Failed to clean up chunk queue", "err", err)
 		}
+		s.clearProgress()
 		snapshot = nil
 		chunks = nil
 	}
 }
 
 // Sync executes a sync for a specific snapshot, returning the latest state and block commit which
-// the caller must use to bootstrap the node.
-func (s *syncer) Sync(snapshot *snapshot, chunks *chunkQueue) (sm.State, *types.Commit, error) {
+// the caller must use to bootstrap the node, along with the recent blocks if they were restored
+// from a node snapshot.
+func (s *syncer) Sync(snapshot *snapshot, chunks *chunkQueue) (sm.State, *types.Commit, []*types.Block, error) {
 	s.mtx.Lock()
 	if s.chunks != nil {
 		s.mtx.Unlock()
-		return sm.State{}, nil, errors.New("a state sync is already in progress")
+		return sm.State{}, nil, nil, errors.New("a state sync is already in progress")
 	}
 	s.chunks = chunks
 	s.mtx.Unlock()
@@ -250,20 +325,35 @@ func (s *syncer) Sync(snapshot *snapshot, chunks *chunkQueue) (sm.State, *types.
 	hctx, cancel := context.WithTimeout(context.TODO(), 30*time.Second)
 	defer cancel()
 
-	appHash, err := s.stateProvider.AppHash(hctx, snapshot.Height)
-	if err != nil {
-		s.logger.Info("failed to fetch and verify app hash", "err", err)
-		if errors.Is(err, light.ErrNoWitnesses) {
-			return sm.State{}, nil, err
+	// The app hash of a restoration resumed after a restart was verified before it.
+	resume := s.resume
+	s.resume = false
+	if !resume {
+		appHash, err := s.stateProvider.AppHash(hctx, snapshot.Height)
+		if err != nil {
+			s.logger.Info("failed to fetch and verify app hash", "err", err)
+			if errors.Is(err, light.ErrNoWitnesses) {
+				return sm.State{}, nil, nil, err
+			}
+			return sm.State{}, nil, nil, errRejectSnapshot
 		}
-		return sm.State{}, nil, errRejectSnapshot
+		snapshot.trustedAppHash = appHash
 	}
-	snapshot.trustedAppHash = appHash
 
 	// Offer snapshot to ABCI app.
-	err = s.offerSnapshot(snapshot)
+	err := s.offerSnapshot(snapshot)
 	if err != nil {
-		return sm.State{}, nil, err
+		return sm.State{}, nil, nil, err
+	}
+
+	// Persist the progress of the restoration. When resuming it, the app restarted the restoration
+	// when the snapshot was offered again, so all the chunks are applied again, starting with the
+	// ones persisted before the restart instead of fetching them again.
+	if s.progressDir != "" && !resume {
+		s.progress = newSyncProgress(snapshot)
+		if err := saveSyncProgress(s.progressDir, s.progress); err != nil {
+			return sm.State{}, nil, nil, err
+		}
 	}
 
 	// Spawn chunk fetchers. They will terminate when the chunk queue is closed or context canceled.
@@ -277,39 +367,191 @@ func (s *syncer) Sync(snapshot *snapshot, chunks *chunkQueue) (sm.State, *types.
 	defer pcancel()
 
 	// Optimistically build new state, so we don't discover any light client failures at the end.
-	state, err := s.stateProvider.State(pctx, snapshot.Height)
+	// A node snapshot at the same height is preferred, since it also carries the recent blocks.
+	var (
+		state  sm.State
+		commit *types.Commit
+		blocks []*types.Block
+	)
+	nodeSnapshot, err := s.fetchNodeSnapshot(pctx, snapshot)
 	if err != nil {
-		s.logger.Info("failed to fetch and verify CometBFT state", "err", err)
+		s.logger.Info("failed to fetch and verify node snapshot", "err", err)
 		if errors.Is(err, light.ErrNoWitnesses) {
-			return sm.State{}, nil, err
+			return sm.State{}, nil, nil, err
 		}
-		return sm.State{}, nil, errRejectSnapshot
+		return sm.State{}, nil, nil, errRejectSnapshot
 	}
-	commit, err := s.stateProvider.Commit(pctx, snapshot.Height)
-	if err != nil {
-		s.logger.Info("failed to fetch and verify commit", "err", err)
-		if errors.Is(err, light.ErrNoWitnesses) {
-			return sm.State{}, nil, err
+	if nodeSnapshot != nil {
+		state, commit, blocks = nodeSnapshot.State, nodeSnapshot.Commit, nodeSnapshot.Blocks
+	} else {
+		state, err = s.stateProvider.State(pctx, snapshot.Height)
+		if err != nil {
+			s.logger.Info("failed to fetch and verify CometBFT state", "err", err)
+			if errors.Is(err, light.ErrNoWitnesses) {
+				return sm.State{}, nil, nil, err
+			}
+			return sm.State{}, nil, nil, errRejectSnapshot
+		}
+		commit, err = s.stateProvider.Commit(pctx, snapshot.Height)
+		if err != nil {
+			s.logger.Info("failed to fetch and verify commit", "err", err)
+			if errors.Is(err, light.ErrNoWitnesses) {
+				return sm.State{}, nil, nil, err
+			}
+			return sm.State{}, nil, nil, errRejectSnapshot
 		}
-		return sm.State{}, nil, errRejectSnapshot
 	}
 
 	// Restore snapshot
 	err = s.applyChunks(chunks)
 	if err != nil {
-		return sm.State{}, nil, err
+		return sm.State{}, nil, nil, err
 	}
 
 	// Verify app and app version
 	if err := s.verifyApp(snapshot, state.Version.Consensus.App); err != nil {
-		return sm.State{}, nil, err
+		return sm.State{}, nil, nil, err
 	}
 
 	// Done! 🎉
 	s.logger.Info("Snapshot restored", "height", snapshot.Height, "format", snapshot.Format,
 		"hash", log.NewLazySprintf("%X", snapshot.Hash))
 
-	return state, commit, nil
+	return state, commit, blocks, nil
+}
+
+// fetchNodeSnapshot fetches a node snapshot at the height of the given app snapshot from peers, and
+// verifies it against the header trusted by the state provider at the next height. Node
+// snapshots that cannot be fetched or fail verification are rejected. It returns nil if no
+// node snapshot was usable, in which case the caller falls back to the state provider.
+func (s *syncer) fetchNodeSnapshot(ctx context.Context, appSnapshot *snapshot) (*NodeSnapshot, error) {
+	var candidates []*snapshot
+	for _, nodeSnapshot := range s.nodeSnapshots.Ranked() {
+		if nodeSnapshot.Height == appSnapshot.Height {
+			candidates = append(candidates, nodeSnapshot)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	// The commit at height H+1 is signed by the validators which are part of the node snapshot,
+	// and its block ID is the hash of the header following the last block of the node snapshot.
+	nextCommit, err := s.stateProvider.Commit(ctx, appSnapshot.Height+1)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, candidate := range candidates {
+		nodeSnapshot, err := s.restoreNodeSnapshot(candidate)
+		if err == nil {
+			err = nodeSnapshot.Verify(int64(appSnapshot.Height)+1, nextCommit.BlockID.Hash)
+		}
+		if err == nil && !bytes.Equal(nodeSnapshot.State.AppHash, appSnapshot.trustedAppHash) {
+			err = fmt.Errorf("app hash %X does not match trusted app hash %X",
+				nodeSnapshot.State.AppHash, appSnapshot.trustedAppHash)
+		}
+		if err != nil {
+			s.logger.Info("Node snapshot rejected", "height", candidate.Height,
+				"hash", log.NewLazySprintf("%X", candidate.Hash), "err", err)
+			s.nodeSnapshots.Reject(candidate)
+			continue
+		}
+		nodeSnapshot.State.Version.Software = version.CMTSemVer
+		s.logger.Info("Node snapshot restored", "height", candidate.Height,
+			"hash", log.NewLazySprintf("%X", candidate.Hash), "blocks", len(nodeSnapshot.Blocks))
+		return nodeSnapshot, nil
+	}
+	return nil, nil
+}
+
+// restoreNodeSnapshot fetches the chunks of a node snapshot from peers, and decodes the node
+// snapshot once its hash is checked.
+func (s *syncer) restoreNodeSnapshot(snapshot *snapshot) (*NodeSnapshot, error) {
+	chunks, err := newChunkQueue(snapshot, s.tempDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create chunk queue: %w", err)
+	}
+	defer chunks.Close()
+
+	s.mtx.Lock()
+	s.nodeChunks = chunks
+	s.mtx.Unlock()
+	defer func() {
+		s.mtx.Lock()
+		s.nodeChunks = nil
+		s.mtx.Unlock()
+	}()
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	go s.fetchNodeChunks(ctx, snapshot, chunks)
+
+	var bz []byte
+	for {
+		chunk, err := chunks.Next()
+		if errors.Is(err, errDone) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to fetch chunk: %w", err)
+		}
+		bz = append(bz, chunk.Chunk...)
+	}
+	if hash := sha256.Sum256(bz); !bytes.Equal(hash[:], snapshot.Hash) {
+		return nil, fmt.Errorf("node snapshot hash %X does not match advertised hash %X", hash, snapshot.Hash)
+	}
+	return decodeNodeSnapshot(bz)
+}
+
+// fetchNodeChunks requests the chunks of a node snapshot from peers, one at a time. Chunks will
+// be received from the reactor via syncer.AddNodeChunk() to chunkQueue.Add().
+func (s *syncer) fetchNodeChunks(ctx context.Context, snapshot *snapshot, chunks *chunkQueue) {
+	var (
+		next  = true
+		index uint32
+		err   error
+	)
+
+	for {
+		if next {
+			index, err = chunks.Allocate()
+			if err != nil {
+				return
+			}
+		}
+		s.requestNodeChunk(snapshot, index)
+
+		select {
+		case <-chunks.WaitFor(index):
+			next = true
+
+		case <-time.After(s.retryTimeout):
+			next = false
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// requestNodeChunk requests a node snapshot chunk from a peer.
+func (s *syncer) requestNodeChunk(snapshot *snapshot, chunk uint32) {
+	peer := s.nodeSnapshots.GetPeer(snapshot)
+	if peer == nil {
+		s.logger.Error("No valid peers found for node snapshot", "height", snapshot.Height,
+			"hash", log.NewLazySprintf("%X", snapshot.Hash))
+		return
+	}
+	s.logger.Debug("Requesting node snapshot chunk", "height", snapshot.Height,
+		"chunk", chunk, "peer", peer.ID())
+	_ = peer.Send(p2p.Envelope{
+		ChannelID: NodeChunkChannel,
+		Message: &ssproto.ChunkRequest{
+			Height: snapshot.Height,
+			Format: snapshot.Format,
+			Index:  chunk,
+		},
+	})
 }
 
 // offerSnapshot offers a snapshot to the app. It returns various errors depending on the app's
@@ -353,6 +595,15 @@ func (s *syncer) offerSnapshot(snapshot *snapshot) error {
 func (s *syncer) applyChunks(chunks *chunkQueue) error {
 	for {
 		chunk, err := chunks.Next()
+		if err != nil {
+			// The chunk queue is closed when a local chunk cannot be loaded.
+			s.mtx.RLock()
+			localErr := s.localErr
+			s.mtx.RUnlock()
+			if localErr != nil {
+				return localErr
+			}
+		}
 		if errors.Is(err, errDone) {
 			return nil
 		} else if err != nil {
@@ -405,6 +656,34 @@ func (s *syncer) applyChunks(chunks *chunkQueue) error {
 	}
 }
 
+// newChunkQueue creates the chunk queue of a snapshot. If the progress is persisted, the chunks are
+// persisted along with it, and the chunks persisted before a restart are reused when resuming.
+func (s *syncer) newChunkQueue(snapshot *snapshot) (*chunkQueue, error) {
+	if s.progressDir == "" {
+		return newChunkQueue(snapshot, s.tempDir)
+	}
+	dir := filepath.Join(s.progressDir, syncProgressChunksDir)
+	if !s.resume {
+		if err := os.RemoveAll(dir); err != nil {
+			return nil, fmt.Errorf("failed to remove state sync chunks: %w", err)
+		}
+	}
+	return openChunkQueue(snapshot, dir)
+}
+
+// clearProgress removes the persisted progress and chunks, if any, once the restoration is
+// completed, the snapshot is given up on, or the restoration fails.
+func (s *syncer) clearProgress() {
+	s.progress = nil
+	s.resume = false
+	if s.progressDir == "" {
+		return
+	}
+	if err := os.RemoveAll(s.progressDir); err != nil {
+		s.logger.Error("Failed to remove state sync progress", "err", err)
+	}
+}
+
 // fetchChunks requests chunks from peers, receiving allocations from the chunk queue. Chunks
 // will be received from the reactor via syncer.AddChunks() to chunkQueue.Add().
 func (s *syncer) fetchChunks(ctx context.Context, snapshot *snapshot, chunks *chunkQueue) {
@@ -436,7 +715,24 @@ func (s *syncer) fetchChunks(ctx context.Context, snapshot *snapshot, chunks *ch
 		s.logger.Info("Fetching snapshot chunk", "height", snapshot.Height,
 			"format", snapshot.Format, "chunk", index, "total", chunks.Size())
 
-		s.requestChunk(snapshot, index)
+		if s.localDir != "" {
+			if err := s.loadLocalChunk(snapshot, index); err != nil {
+				// There is no other source for the chunk, so give up on the restoration.
+				s.logger.Error("Failed to load local snapshot chunk", "height", snapshot.Height,
+					"format", snapshot.Format, "chunk", index, "err", err)
+				s.mtx.Lock()
+				if s.localErr == nil {
+					s.localErr = err
+				}
+				s.mtx.Unlock()
+				if err := chunks.Close(); err != nil {
+					s.logger.Error("Failed to close chunk queue", "err", err)
+				}
+				return
+			}
+		} else {
+			s.requestChunk(snapshot, index)
+		}
 
 		select {
 		case <-chunks.WaitFor(index):
@@ -471,6 +767,23 @@ func (s *syncer) requestChunk(snapshot *snapshot, chunk uint32) {
 	})
 }
 
+// loadLocalChunk loads a chunk from the local snapshot directory into the chunk queue.
+func (s *syncer) loadLocalChunk(snapshot *snapshot, index uint32) error {
+	bz, err := loadLocalChunk(s.localDir, index)
+	if err != nil {
+		return fmt.Errorf("failed to load local snapshot chunk %d: %w", index, err)
+	}
+	if _, err := s.AddChunk(&chunk{
+		Height: snapshot.Height,
+		Format: snapshot.Format,
+		Index:  index,
+		Chunk:  bz,
+	}); err != nil {
+		return fmt.Errorf("failed to add local snapshot chunk %d: %w", index, err)
+	}
+	return nil
+}
+
 // verifyApp verifies the sync, checking the app hash, last block height and app version.
 func (s *syncer) verifyApp(snapshot *snapshot, appVersion uint64) error {
 	resp, err := s.connQuery.Info(context.TODO(), proxy.InfoRequest)
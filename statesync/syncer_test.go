@@ -2,6 +2,9 @@ package statesync
 
 import (
 	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
 	"testing"
 	"time"
 
@@ -108,7 +111,7 @@ func TestSyncer_SyncAny(t *testing.T) {
 			return false
 		}
 		req, ok := e.Message.(*ssproto.SnapshotsRequest)
-		return ok && e.ChannelID == SnapshotChannel && req != nil
+		return ok && (e.ChannelID == SnapshotChannel || e.ChannelID == NodeSnapshotChannel) && req != nil
 	})).Return(nil)
 	syncer.AddPeer(peerA)
 	peerA.AssertExpectations(t)
@@ -121,7 +124,7 @@ func TestSyncer_SyncAny(t *testing.T) {
 			return false
 		}
 		req, ok := e.Message.(*ssproto.SnapshotsRequest)
-		return ok && e.ChannelID == SnapshotChannel && req != nil
+		return ok && (e.ChannelID == SnapshotChannel || e.ChannelID == NodeSnapshotChannel) && req != nil
 	})).Return(nil)
 	syncer.AddPeer(peerB)
 	peerB.AssertExpectations(t)
@@ -215,7 +218,7 @@ func TestSyncer_SyncAny(t *testing.T) {
 		LastBlockAppHash: []byte("app_hash"),
 	}, nil)
 
-	newState, lastCommit, err := syncer.SyncAny(0, maxDiscoveryTime, func() {})
+	newState, lastCommit, _, err := syncer.SyncAny(0, maxDiscoveryTime, func() {})
 	require.NoError(t, err)
 
 	time.Sleep(50 * time.Millisecond) // wait for peers to receive requests
@@ -237,7 +240,7 @@ func TestSyncer_SyncAny(t *testing.T) {
 
 func TestSyncer_SyncAny_noSnapshots(t *testing.T) {
 	syncer, _ := setupOfferSyncer()
-	_, _, err := syncer.SyncAny(0, maxDiscoveryTime, func() {})
+	_, _, _, err := syncer.SyncAny(0, maxDiscoveryTime, func() {})
 	assert.Equal(t, errNoSnapshots, err)
 }
 
@@ -251,7 +254,7 @@ func TestSyncer_SyncAny_abort(t *testing.T) {
 		Snapshot: toABCI(s), AppHash: []byte("app_hash"),
 	}).Once().Return(&abci.OfferSnapshotResponse{Result: abci.OFFER_SNAPSHOT_RESULT_ABORT}, nil)
 
-	_, _, err = syncer.SyncAny(0, maxDiscoveryTime, func() {})
+	_, _, _, err = syncer.SyncAny(0, maxDiscoveryTime, func() {})
 	assert.Equal(t, errAbort, err)
 	connSnapshot.AssertExpectations(t)
 }
@@ -282,7 +285,7 @@ func TestSyncer_SyncAny_reject(t *testing.T) {
 		Snapshot: toABCI(s11), AppHash: []byte("app_hash"),
 	}).Once().Return(&abci.OfferSnapshotResponse{Result: abci.OFFER_SNAPSHOT_RESULT_REJECT}, nil)
 
-	_, _, err = syncer.SyncAny(0, maxDiscoveryTime, func() {})
+	_, _, _, err = syncer.SyncAny(0, maxDiscoveryTime, func() {})
 	assert.Equal(t, errNoSnapshots, err)
 	connSnapshot.AssertExpectations(t)
 }
@@ -309,7 +312,7 @@ func TestSyncer_SyncAny_reject_format(t *testing.T) {
 		Snapshot: toABCI(s11), AppHash: []byte("app_hash"),
 	}).Once().Return(&abci.OfferSnapshotResponse{Result: abci.OFFER_SNAPSHOT_RESULT_ABORT}, nil)
 
-	_, _, err = syncer.SyncAny(0, maxDiscoveryTime, func() {})
+	_, _, _, err = syncer.SyncAny(0, maxDiscoveryTime, func() {})
 	assert.Equal(t, errAbort, err)
 	connSnapshot.AssertExpectations(t)
 }
@@ -347,7 +350,7 @@ func TestSyncer_SyncAny_reject_sender(t *testing.T) {
 		Snapshot: toABCI(sa), AppHash: []byte("app_hash"),
 	}).Once().Return(&abci.OfferSnapshotResponse{Result: abci.OFFER_SNAPSHOT_RESULT_REJECT}, nil)
 
-	_, _, err = syncer.SyncAny(0, maxDiscoveryTime, func() {})
+	_, _, _, err = syncer.SyncAny(0, maxDiscoveryTime, func() {})
 	assert.Equal(t, errNoSnapshots, err)
 	connSnapshot.AssertExpectations(t)
 }
@@ -363,11 +366,151 @@ func TestSyncer_SyncAny_abciError(t *testing.T) {
 		Snapshot: toABCI(s), AppHash: []byte("app_hash"),
 	}).Once().Return(nil, errBoom)
 
-	_, _, err = syncer.SyncAny(0, maxDiscoveryTime, func() {})
+	_, _, _, err = syncer.SyncAny(0, maxDiscoveryTime, func() {})
 	require.ErrorIs(t, err, errBoom)
 	connSnapshot.AssertExpectations(t)
 }
 
+// setupResumeSyncer sets up a syncer persisting its progress in progressDir, which loads the
+// chunks of the snapshot s from a local directory instead of requesting them from peers. The
+// local chunk i is {b, b, i}.
+func setupResumeSyncer(
+	t *testing.T,
+	s *snapshot,
+	b byte,
+	stateProvider StateProvider,
+	progressDir string,
+) (*syncer, *proxymocks.AppConnSnapshot, *proxymocks.AppConnQuery) {
+	t.Helper()
+	localDir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(localDir, LocalSnapshotChunksDir), 0o700))
+	for i := uint32(0); i < s.Chunks; i++ {
+		require.NoError(t, os.WriteFile(filepath.Join(localDir, LocalSnapshotChunksDir, strconv.Itoa(int(i))),
+			[]byte{b, b, byte(i)}, 0o600))
+	}
+	connSnapshot := &proxymocks.AppConnSnapshot{}
+	connQuery := &proxymocks.AppConnQuery{}
+	cfg := config.DefaultStateSyncConfig()
+	syncer := newSyncer(*cfg, log.NewNopLogger(), connSnapshot, connQuery, stateProvider, "")
+	syncer.localDir = localDir
+	syncer.progressDir = progressDir
+	return syncer, connSnapshot, connQuery
+}
+
+func TestSyncer_SyncAny_resume(t *testing.T) {
+	state := sm.State{
+		Version: cmtstate.Version{Consensus: cmtversion.Consensus{App: testAppVersion}},
+		AppHash: []byte("app_hash"),
+	}
+	commit := &types.Commit{BlockID: types.BlockID{Hash: []byte("blockhash")}}
+	s := &snapshot{Height: 1, Format: 1, Chunks: 3, Hash: []byte{1, 2, 3}}
+	progressDir := filepath.Join(t.TempDir(), "statesync")
+	crashDir := filepath.Join(t.TempDir(), "statesync")
+
+	// The app hash is only verified once, and persisted for the restoration resumed after a restart.
+	stateProvider := &mocks.StateProvider{}
+	stateProvider.On("AppHash", mock.Anything, uint64(1)).Once().Return(state.AppHash, nil)
+	stateProvider.On("Commit", mock.Anything, uint64(1)).Return(commit, nil)
+	stateProvider.On("State", mock.Anything, uint64(1)).Return(state, nil)
+
+	// The node dies while the app applies chunk 1, once all the chunks are persisted: the progress
+	// directory is copied as it is at that point.
+	syncer, connSnapshot, _ := setupResumeSyncer(t, s, 1, stateProvider, progressDir)
+	_, err := syncer.AddSnapshot(simplePeer("id"), s)
+	require.NoError(t, err)
+	connSnapshot.On("OfferSnapshot", mock.Anything, &abci.OfferSnapshotRequest{
+		Snapshot: toABCI(s), AppHash: state.AppHash,
+	}).Once().Return(&abci.OfferSnapshotResponse{Result: abci.OFFER_SNAPSHOT_RESULT_ACCEPT}, nil)
+	connSnapshot.On("ApplySnapshotChunk", mock.Anything, &abci.ApplySnapshotChunkRequest{
+		Index: 0, Chunk: []byte{1, 1, 0},
+	}).Once().Return(&abci.ApplySnapshotChunkResponse{Result: abci.APPLY_SNAPSHOT_CHUNK_RESULT_ACCEPT}, nil)
+	connSnapshot.On("ApplySnapshotChunk", mock.Anything, &abci.ApplySnapshotChunkRequest{
+		Index: 1, Chunk: []byte{1, 1, 1},
+	}).Once().Run(func(mock.Arguments) {
+		require.Eventually(t, func() bool {
+			_, err := os.Stat(filepath.Join(progressDir, syncProgressChunksDir, "2"))
+			return err == nil
+		}, time.Second, 10*time.Millisecond)
+		require.NoError(t, os.CopyFS(crashDir, os.DirFS(progressDir)))
+	}).Return(nil, errors.New("connection lost"))
+
+	_, _, _, err = syncer.SyncAny(0, maxDiscoveryTime, func() {})
+	require.Error(t, err)
+	connSnapshot.AssertExpectations(t)
+
+	// The progress of a failed restoration is removed.
+	assert.NoDirExists(t, progressDir)
+
+	progress, err := loadSyncProgress(crashDir)
+	require.NoError(t, err)
+	require.NotNil(t, progress)
+	assert.Equal(t, s.Hash, []byte(progress.Hash))
+	assert.Equal(t, state.AppHash, []byte(progress.TrustedAppHash))
+	require.NoError(t, os.CopyFS(progressDir, os.DirFS(crashDir)))
+
+	// After the restart, the snapshot is offered again, without waiting to discover it again, and
+	// the app restarts the restoration: all the chunks are applied again from the persisted
+	// chunks, instead of the local chunks {2, 2, i}.
+	syncer, connSnapshot, connQuery := setupResumeSyncer(t, s, 2, stateProvider, progressDir)
+	connSnapshot.On("OfferSnapshot", mock.Anything, &abci.OfferSnapshotRequest{
+		Snapshot: toABCI(s), AppHash: state.AppHash,
+	}).Once().Return(&abci.OfferSnapshotResponse{Result: abci.OFFER_SNAPSHOT_RESULT_ACCEPT}, nil)
+	for i := uint32(0); i < s.Chunks; i++ {
+		connSnapshot.On("ApplySnapshotChunk", mock.Anything, &abci.ApplySnapshotChunkRequest{
+			Index: i, Chunk: []byte{1, 1, byte(i)},
+		}).Once().Return(&abci.ApplySnapshotChunkResponse{Result: abci.APPLY_SNAPSHOT_CHUNK_RESULT_ACCEPT}, nil)
+	}
+	connQuery.On("Info", mock.Anything, proxy.InfoRequest).Return(&abci.InfoResponse{
+		AppVersion:       testAppVersion,
+		LastBlockHeight:  1,
+		LastBlockAppHash: state.AppHash,
+	}, nil)
+
+	newState, lastCommit, _, err := syncer.SyncAny(0, maxDiscoveryTime, func() {})
+	require.NoError(t, err)
+	assert.Equal(t, state, newState)
+	assert.Equal(t, commit, lastCommit)
+	connSnapshot.AssertExpectations(t)
+	stateProvider.AssertExpectations(t)
+
+	// The progress is removed once the restoration is completed.
+	assert.NoDirExists(t, progressDir)
+}
+
+func TestSyncer_SyncAny_resumeVerifyFailed(t *testing.T) {
+	state := sm.State{
+		Version: cmtstate.Version{Consensus: cmtversion.Consensus{App: testAppVersion}},
+		AppHash: []byte("app_hash"),
+	}
+	commit := &types.Commit{BlockID: types.BlockID{Hash: []byte("blockhash")}}
+	s := &snapshot{Height: 1, Format: 1, Chunks: 3, Hash: []byte{1, 2, 3}, trustedAppHash: state.AppHash}
+	progressDir := filepath.Join(t.TempDir(), "statesync")
+	require.NoError(t, saveSyncProgress(progressDir, newSyncProgress(s)))
+
+	stateProvider := &mocks.StateProvider{}
+	stateProvider.On("Commit", mock.Anything, uint64(1)).Return(commit, nil)
+	stateProvider.On("State", mock.Anything, uint64(1)).Return(state, nil)
+
+	// The restored app does not match the snapshot, so the progress is removed, and the next
+	// restart starts over instead of resuming the same restoration again.
+	syncer, connSnapshot, connQuery := setupResumeSyncer(t, s, 1, stateProvider, progressDir)
+	connSnapshot.On("OfferSnapshot", mock.Anything, &abci.OfferSnapshotRequest{
+		Snapshot: toABCI(s), AppHash: state.AppHash,
+	}).Once().Return(&abci.OfferSnapshotResponse{Result: abci.OFFER_SNAPSHOT_RESULT_ACCEPT}, nil)
+	connSnapshot.On("ApplySnapshotChunk", mock.Anything, mock.Anything).
+		Times(int(s.Chunks)).Return(&abci.ApplySnapshotChunkResponse{Result: abci.APPLY_SNAPSHOT_CHUNK_RESULT_ACCEPT}, nil)
+	connQuery.On("Info", mock.Anything, proxy.InfoRequest).Return(&abci.InfoResponse{
+		AppVersion:       testAppVersion,
+		LastBlockHeight:  1,
+		LastBlockAppHash: []byte("other_hash"),
+	}, nil)
+
+	_, _, _, err := syncer.SyncAny(0, maxDiscoveryTime, func() {})
+	require.ErrorIs(t, err, errVerifyFailed)
+	connSnapshot.AssertExpectations(t)
+	assert.NoDirExists(t, progressDir)
+}
+
 func TestSyncer_offerSnapshot(t *testing.T) {
 	unknownErr := errors.New("unknown error")
 	boom := errors.New("boom")
@@ -245,6 +245,10 @@ func MakeConfig(node *e2e.Node) (*config.Config, error) {
 	cfg.GRPC.VersionService.Enabled = true
 	cfg.GRPC.BlockService.Enabled = true
 	cfg.GRPC.BlockResultsService.Enabled = true
+	cfg.GRPC.EventService.Enabled = true
+	cfg.GRPC.TxService.Enabled = true
+	cfg.GRPC.MempoolService.Enabled = true
+	cfg.GRPC.NetworkService.Enabled = true
 
 	cfg.P2P.ExternalAddress = fmt.Sprintf("tcp://%v", node.AddressP2P(false))
 	cfg.P2P.AddrBookStrict = false
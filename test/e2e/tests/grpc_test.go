@@ -1,14 +1,18 @@
 package e2e_test
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/cometbft/cometbft/v2/rpc/grpc/client"
 	e2e "github.com/cometbft/cometbft/v2/test/e2e/pkg"
+	"github.com/cometbft/cometbft/v2/types"
 	"github.com/cometbft/cometbft/v2/version"
 )
 
@@ -94,6 +98,137 @@ func TestGRPC_GetBlockResults(t *testing.T) {
 	})
 }
 
+// Test the GRPC Event service. Invoke the Subscribe method to receive the next
+// new block header published by the node.
+func TestGRPC_Event_Subscribe(t *testing.T) {
+	testFullNodesOrValidators(t, 0, func(t *testing.T, node e2e.Node) {
+		t.Helper()
+
+		ctx, ctxCancel := context.WithTimeout(context.Background(), time.Minute)
+		defer ctxCancel()
+
+		gRPCClient, err := node.GRPCClient(ctx)
+		require.NoError(t, err)
+		defer gRPCClient.Close()
+
+		query := types.QueryForEvent(types.EventNewBlockHeader).String()
+		eventCh, err := gRPCClient.Subscribe(ctx, query)
+		require.NoError(t, err)
+
+		res, ok := <-eventCh
+		require.True(t, ok)
+		require.NoError(t, res.Error)
+		require.Equal(t, query, res.Event.Query)
+		header, ok := res.Event.Data.(types.EventDataNewBlockHeader)
+		require.True(t, ok)
+		require.Positive(t, header.Header.Height)
+		require.Equal(t, []string{types.EventNewBlockHeader}, res.Event.Events[types.EventTypeKey])
+	})
+}
+
+// Test the GRPC Tx service. Invoke the BroadcastTxCommit method, then retrieve
+// the committed transaction with the GetTx and SearchTxs methods.
+func TestGRPC_Tx(t *testing.T) {
+	testFullNodesOrValidators(t, 0, func(t *testing.T, node e2e.Node) {
+		t.Helper()
+
+		ctx, ctxCancel := context.WithTimeout(context.Background(), time.Minute)
+		defer ctxCancel()
+
+		gRPCClient, err := node.GRPCClient(ctx)
+		require.NoError(t, err)
+		defer gRPCClient.Close()
+
+		// Use a unique value, to prevent duplicate tx errors when manually
+		// running the test multiple times for a testnet.
+		tx := types.Tx(fmt.Sprintf("testgrpc-tx-%v=%v", node.Name, time.Now().UnixNano()))
+		res, err := gRPCClient.BroadcastTxCommit(ctx, tx)
+		require.NoError(t, err)
+		require.Equal(t, tx.Hash(), res.Hash)
+		require.Zero(t, res.CheckTx.Code)
+		require.NotNil(t, res.TxResult)
+		require.Positive(t, res.Height)
+
+		indexedTx, err := gRPCClient.GetTx(ctx, res.Hash, true)
+		require.NoError(t, err)
+		require.Equal(t, tx, indexedTx.Tx)
+		require.Equal(t, res.Height, indexedTx.Height)
+		require.NotNil(t, indexedTx.Proof)
+		require.NoError(t, indexedTx.Proof.Validate(indexedTx.Proof.RootHash))
+
+		page, err := gRPCClient.SearchTxs(ctx, fmt.Sprintf("tx.hash = '%X'", res.Hash))
+		require.NoError(t, err)
+		require.Equal(t, 1, page.TotalCount)
+		require.Equal(t, tx, page.Txs[0].Tx)
+	})
+}
+
+// Test the GRPC Mempool service. Watch the mempool, broadcast a transaction,
+// and check that its admission is reported.
+func TestGRPC_Mempool(t *testing.T) {
+	testFullNodesOrValidators(t, 0, func(t *testing.T, node e2e.Node) {
+		t.Helper()
+
+		ctx, ctxCancel := context.WithTimeout(context.Background(), time.Minute)
+		defer ctxCancel()
+
+		gRPCClient, err := node.GRPCClient(ctx)
+		require.NoError(t, err)
+		defer gRPCClient.Close()
+
+		status, err := gRPCClient.GetMempoolStatus(ctx)
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, status.Size, 0)
+
+		unconfirmed, err := gRPCClient.GetUnconfirmedTxs(ctx, 10)
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(unconfirmed.Txs), 10)
+
+		txCh, err := gRPCClient.WatchMempoolTxs(ctx, client.WatchMempoolTxsChannelSize(100))
+		require.NoError(t, err)
+
+		tx := types.Tx(fmt.Sprintf("testgrpc-mempool-%v=%v", node.Name, time.Now().UnixNano()))
+		res, err := gRPCClient.BroadcastTxSync(ctx, tx)
+		require.NoError(t, err)
+		require.Zero(t, res.CheckTx.Code)
+
+		for res := range txCh {
+			require.NoError(t, res.Error)
+			if res.Event.Type == client.MempoolTxAdmitted && bytes.Equal(res.Event.Hash, tx.Hash()) {
+				require.Equal(t, tx, res.Event.Tx)
+				return
+			}
+		}
+		t.Fatal("transaction admission not reported")
+	})
+}
+
+// Test the GRPC Network service methods.
+func TestGRPC_Network(t *testing.T) {
+	testFullNodesOrValidators(t, 0, func(t *testing.T, node e2e.Node) {
+		t.Helper()
+
+		ctx, ctxCancel := context.WithTimeout(context.Background(), time.Minute)
+		defer ctxCancel()
+
+		gRPCClient, err := node.GRPCClient(ctx)
+		require.NoError(t, err)
+		defer gRPCClient.Close()
+
+		netInfo, err := gRPCClient.GetNetInfo(ctx)
+		require.NoError(t, err)
+		require.True(t, netInfo.Listening)
+		for _, peer := range netInfo.Peers {
+			require.NoError(t, peer.NodeInfo.Validate())
+		}
+
+		state, err := gRPCClient.GetConsensusState(ctx)
+		require.NoError(t, err)
+		require.Positive(t, state.Height)
+		require.NotEmpty(t, state.Step)
+	})
+}
+
 // Test the GRPC Privileged Pruning Service methods to set and get the block retain height.
 func TestGRPC_BlockRetainHeight(t *testing.T) {
 	t.Helper()
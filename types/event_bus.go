@@ -178,6 +178,15 @@ func (b *EventBus) PublishEventPendingTx(data EventDataPendingTx) error {
 	})
 }
 
+func (b *EventBus) PublishEventEvictedTx(data EventDataEvictedTx) error {
+	// no explicit deadline for publishing events
+	ctx := context.Background()
+	return b.pubsub.PublishWithEvents(ctx, data, map[string][]string{
+		EventTypeKey: {EventEvictedTx},
+		TxHashKey:    {fmt.Sprintf("%X", Tx(data.Tx).Hash())},
+	})
+}
+
 // PublishEventTx publishes tx event with events from Result. Note it will add
 // predefined keys (EventTypeKey, TxHashKey). Existing events with the same keys
 // will be overwritten.
@@ -275,6 +284,10 @@ func (NopEventBus) PublishEventPendingTx(EventDataPendingTx) error {
 	return nil
 }
 
+func (NopEventBus) PublishEventEvictedTx(EventDataEvictedTx) error {
+	return nil
+}
+
 func (NopEventBus) PublishEventTx(EventDataTx) error {
 	return nil
 }
@@ -52,6 +52,43 @@ func TestEventBusPublishEventPendingTx(t *testing.T) {
 	}
 }
 
+func TestEventBusPublishEventEvictedTx(t *testing.T) {
+	eventBus := NewEventBus()
+	err := eventBus.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := eventBus.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	tx := Tx("foo")
+	query := fmt.Sprintf("tm.event='EvictedTx' AND tx.hash='%X'", tx.Hash())
+	txsSub, err := eventBus.Subscribe(context.Background(), "test", cmtquery.MustCompile(query))
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		msg := <-txsSub.Out()
+		edt := msg.Data().(EventDataEvictedTx)
+		assert.EqualValues(t, tx, edt.Tx)
+		assert.Equal(t, EvictedTxReasonExpired, edt.Reason)
+		close(done)
+	}()
+
+	err = eventBus.PublishEventEvictedTx(EventDataEvictedTx{
+		Tx:     tx,
+		Reason: EvictedTxReasonExpired,
+	})
+	require.NoError(t, err)
+
+	select {
+	case <-done:
+	case <-time.After(1 * time.Second):
+		t.Fatal("did not receive an evicted transaction after 1 sec.")
+	}
+}
+
 func TestEventBusPublishEventTx(t *testing.T) {
 	eventBus := NewEventBus()
 	err := eventBus.Start()
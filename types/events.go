@@ -16,6 +16,7 @@ const (
 	// after a block has been committed.
 	// These are also used by the tx indexer for async indexing.
 	// All of this data can be fetched through the rpc.
+	EventEvictedTx           = "EvictedTx"
 	EventNewBlock            = "NewBlock"
 	EventNewBlockHeader      = "NewBlockHeader"
 	EventNewBlockEvents      = "NewBlockEvents"
@@ -48,6 +49,7 @@ type TMEventData interface { //nolint:revive // this empty interface angers the
 }
 
 func init() {
+	cmtjson.RegisterType(EventDataEvictedTx{}, "tendermint/event/EvictedTx")
 	cmtjson.RegisterType(EventDataNewBlock{}, "tendermint/event/NewBlock")
 	cmtjson.RegisterType(EventDataNewBlockHeader{}, "tendermint/event/NewBlockHeader")
 	cmtjson.RegisterType(EventDataNewBlockEvents{}, "tendermint/event/NewBlockEvents")
@@ -90,6 +92,27 @@ type EventDataPendingTx struct {
 	Tx []byte `json:"tx"`
 }
 
+// Reasons for which a tx can be evicted from the mempool, as reported in
+// EventDataEvictedTx.
+const (
+	// The tx has been in the mempool for too long (see the mempool's
+	// ttl_duration and ttl_num_blocks config options).
+	EvictedTxReasonExpired = "expired"
+	// The tx became invalid when it was rechecked after a block was committed.
+	EvictedTxReasonInvalid = "invalid"
+	// The tx was evicted to make room for a tx with higher priority.
+	EvictedTxReasonFull = "full"
+	// The tx was replaced by a tx with the same sender and sequence number, and
+	// a higher priority.
+	EvictedTxReasonReplaced = "replaced"
+)
+
+// Txs removed from the mempool without being committed fire EventDataEvictedTx.
+type EventDataEvictedTx struct {
+	Tx     []byte `json:"tx"`
+	Reason string `json:"reason"`
+}
+
 // All txs fire EventDataTx.
 type EventDataTx struct {
 	abci.TxResult
@@ -153,6 +176,7 @@ const (
 
 var (
 	EventQueryCompleteProposal    = QueryForEvent(EventCompleteProposal)
+	EventQueryEvictedTx           = QueryForEvent(EventEvictedTx)
 	EventQueryLock                = QueryForEvent(EventLock)
 	EventQueryNewBlock            = QueryForEvent(EventNewBlock)
 	EventQueryNewBlockHeader      = QueryForEvent(EventNewBlockHeader)
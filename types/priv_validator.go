@@ -29,6 +29,22 @@ type PrivValidator interface {
 	SignBytes(bytes []byte) ([]byte, error)
 }
 
+// KeyRotatingPrivValidator is a PrivValidator holding a next key alongside its
+// current key. Consensus rotates to the next key as soon as it is in the
+// validator set of the height being decided, i.e. two heights after the
+// application announced it in the validator updates of FinalizeBlock. Until
+// then, the current key keeps signing.
+type KeyRotatingPrivValidator interface {
+	PrivValidator
+
+	// NextPubKey returns the public key of the next key, or nil if there is
+	// no next key.
+	NextPubKey() (crypto.PubKey, error)
+
+	// RotateKey replaces the current key with the next key.
+	RotateKey() error
+}
+
 type PrivValidatorsByAddress []PrivValidator
 
 func (pvs PrivValidatorsByAddress) Len() int {
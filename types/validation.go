@@ -29,6 +29,24 @@ func shouldBatchVerify(vals *ValidatorSet, commit *Commit) bool {
 // with a bonus for including more than +2/3 of the signatures.
 func VerifyCommit(chainID string, vals *ValidatorSet, blockID BlockID,
 	height int64, commit *Commit,
+) error {
+	return verifyCommitInternal(chainID, vals, blockID, height, commit, nil)
+}
+
+// VerifyCommitWithCache verifies +2/3 of the set had signed the given commit.
+//
+// It checks all the signatures, like VerifyCommit.
+// The cache provided will be used to skip signature verification for entries where the
+// key (signature), validator pubkey, and vote sign bytes all match.
+// Additionally, any verified signatures will be added to the cache.
+func VerifyCommitWithCache(chainID string, vals *ValidatorSet, blockID BlockID,
+	height int64, commit *Commit, verifiedSignatureCache SignatureCache,
+) error {
+	return verifyCommitInternal(chainID, vals, blockID, height, commit, verifiedSignatureCache)
+}
+
+func verifyCommitInternal(chainID string, vals *ValidatorSet, blockID BlockID,
+	height int64, commit *Commit, verifiedSignatureCache SignatureCache,
 ) error {
 	// run a basic validation of the arguments
 	if err := verifyBasicValsAndCommit(vals, commit, height, blockID); err != nil {
@@ -48,12 +66,12 @@ func VerifyCommit(chainID string, vals *ValidatorSet, blockID BlockID,
 	// attempt to batch verify
 	if shouldBatchVerify(vals, commit) {
 		return verifyCommitBatch(chainID, vals, commit,
-			votingPowerNeeded, ignore, count, true, true, nil, nil)
+			votingPowerNeeded, ignore, count, true, true, nil, verifiedSignatureCache)
 	}
 
 	// if verification failed or is not supported then fallback to single verification
 	return verifyCommitSingle(chainID, vals, commit, votingPowerNeeded,
-		ignore, count, true, true, nil)
+		ignore, count, true, true, verifiedSignatureCache)
 }
 
 // LIGHT CLIENT VERIFICATION METHODS
@@ -3,6 +3,7 @@ package types
 import (
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -433,6 +434,31 @@ func TestValidatorSet_VerifyCommitLightWithCache_UsesCache(t *testing.T) {
 	require.Equal(t, 5, cache.Len()) // no new signature checks, so no new cache entries
 }
 
+func TestValidatorSet_VerifyCommitWithCache(t *testing.T) {
+	var (
+		blockID                       = makeBlockIDRandom()
+		voteSet, originalValset, vals = randVoteSet(1, 1, PrecommitType, 6, 1, false)
+		extCommit, err                = MakeExtCommit(blockID, 1, 1, voteSet, vals, cmttime.Now(), false)
+	)
+	require.NoError(t, err)
+	commit := extCommit.ToCommit()
+
+	cache := NewSignatureCache()
+	err = originalValset.VerifyCommitWithCache("test_chain_id", blockID, 1, commit, cache)
+	require.NoError(t, err)
+	require.Equal(t, 6, cache.Len()) // all the signatures are checked
+
+	// The light verification only checks signatures found in the cache.
+	err = originalValset.VerifyCommitLightWithCache("test_chain_id", blockID, 1, commit, cache)
+	require.NoError(t, err)
+	require.Equal(t, 6, cache.Len())
+
+	// A cached signature does not pass for another message.
+	commit.Signatures[0].Timestamp = commit.Signatures[0].Timestamp.Add(time.Second)
+	err = originalValset.VerifyCommitWithCache("test_chain_id", blockID, 1, commit, cache)
+	require.Error(t, err)
+}
+
 func TestValidatorSet_VerifyCommitLightTrustingErrorsOnOverflow(t *testing.T) {
 	var (
 		blockID               = makeBlockIDRandom()